@@ -11,6 +11,7 @@ var (
 	defaultKeyIDs = []string{
 		"domainVerificationKey",
 		"idpConfigKey",
+		"idpTokenKey",
 		"oidcKey",
 		"samlKey",
 		"otpKey",
@@ -18,6 +19,7 @@ var (
 		"smtpKey",
 		"userKey",
 		"targetKey",
+		"personalDataKey",
 		"csrfCookieKey",
 		"userAgentCookieKey",
 	}
@@ -26,6 +28,7 @@ var (
 type EncryptionKeyConfig struct {
 	DomainVerification   *crypto.KeyConfig
 	IDPConfig            *crypto.KeyConfig
+	IDPToken             *crypto.KeyConfig
 	OIDC                 *crypto.KeyConfig
 	SAML                 *crypto.KeyConfig
 	OTP                  *crypto.KeyConfig
@@ -33,6 +36,7 @@ type EncryptionKeyConfig struct {
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
 	Target               *crypto.KeyConfig
+	PersonalData         *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
@@ -40,6 +44,7 @@ type EncryptionKeyConfig struct {
 type EncryptionKeys struct {
 	DomainVerification crypto.EncryptionAlgorithm
 	IDPConfig          crypto.EncryptionAlgorithm
+	IDPToken           crypto.EncryptionAlgorithm
 	OIDC               crypto.EncryptionAlgorithm
 	SAML               crypto.EncryptionAlgorithm
 	OTP                crypto.EncryptionAlgorithm
@@ -47,6 +52,7 @@ type EncryptionKeys struct {
 	SMTP               crypto.EncryptionAlgorithm
 	User               crypto.EncryptionAlgorithm
 	Target             crypto.EncryptionAlgorithm
+	PersonalData       crypto.EncryptionAlgorithm
 	CSRFCookieKey      []byte
 	UserAgentCookieKey []byte
 	OIDCKey            []byte
@@ -65,6 +71,10 @@ func EnsureEncryptionKeys(ctx context.Context, keyConfig *EncryptionKeyConfig, k
 	if err != nil {
 		return nil, err
 	}
+	keys.IDPToken, err = crypto.NewAESCrypto(keyConfig.IDPToken, keyStorage)
+	if err != nil {
+		return nil, err
+	}
 	keys.OIDC, err = crypto.NewAESCrypto(keyConfig.OIDC, keyStorage)
 	if err != nil {
 		return nil, err
@@ -98,6 +108,10 @@ func EnsureEncryptionKeys(ctx context.Context, keyConfig *EncryptionKeyConfig, k
 	if err != nil {
 		return nil, err
 	}
+	keys.PersonalData, err = crypto.NewAESCrypto(keyConfig.PersonalData, keyStorage)
+	if err != nil {
+		return nil, err
+	}
 	key, err = crypto.LoadKey(keyConfig.CSRFCookieKeyID, keyStorage)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	authz_es "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
+	"github.com/zitadel/zitadel/internal/cache/connector"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query"
+	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
+	es_v4_pg "github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
+)
+
+const (
+	flagInstanceID = "instance-id"
+	flagUserID     = "user-id"
+	flagOutput     = "output"
+)
+
+func newUserData() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user-data --instance-id <id> --user-id <id> [--output file]",
+		Short: "export all data stored about a user",
+		Long: `export all data stored about a user as JSON, e.g. to answer a data subject access request.
+The export contains the profile, contact information, metadata, identity provider links, grants, memberships, sessions,
+personal access tokens, registered authentication methods and the event history of the user.
+Secrets like password hashes, encrypted codes and tokens are redacted.`,
+		Example: `user-data --instance-id 69629012906488320 --user-id 69629012906488334 --output user.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceID, _ := cmd.Flags().GetString(flagInstanceID)
+			userID, _ := cmd.Flags().GetString(flagUserID)
+			if instanceID == "" || userID == "" {
+				return fmt.Errorf("--%s and --%s are required", flagInstanceID, flagUserID)
+			}
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if output, _ := cmd.Flags().GetString(flagOutput); output != "" {
+				file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+			return exportUserData(cmd.Context(), MustNewConfig(viper.GetViper()), masterKey, instanceID, userID, out)
+		},
+	}
+	cmd.Flags().String(flagInstanceID, "", "id of the instance the user belongs to")
+	cmd.Flags().String(flagUserID, "", "id of the user to export")
+	cmd.Flags().StringP(flagOutput, "o", "", "path of the file to write the export to, defaults to stdout")
+	return cmd
+}
+
+func exportUserData(ctx context.Context, config *Config, masterKey, instanceID, userID string, out io.Writer) error {
+	queries, err := startQueries(ctx, config, masterKey)
+	if err != nil {
+		return err
+	}
+	instance, err := queries.InstanceByID(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+	export, err := queries.UserDataExport(internal_authz.WithInstance(ctx, instance), userID)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+func startQueries(ctx context.Context, config *Config, masterKey string) (*query.Queries, error) {
+	client, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start DB client: %w", err)
+	}
+	keyStorage, err := cryptoDB.NewKeyStorage(client, masterKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot start key storage: %w", err)
+	}
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Eventstore.Pusher = new_es.NewEventstore(client)
+	config.Eventstore.Searcher = new_es.NewEventstore(client)
+	config.Eventstore.Querier = old_es.NewCRDB(client)
+	config.Eventstore.DataKeys = new_es.NewEventstore(client)
+	config.Eventstore.PersonalDataEncryption = keys.PersonalData
+	es := eventstore.NewEventstore(config.Eventstore)
+	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, &es_v4_pg.Config{
+		MaxRetries: config.Eventstore.MaxRetries,
+	}))
+
+	cacheConnectors, err := connector.StartConnectors(config.Caches, client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start caches: %w", err)
+	}
+
+	return query.StartQueries(
+		ctx,
+		es,
+		esV4.Querier,
+		client,
+		client,
+		cacheConnectors,
+		config.Projections,
+		config.SystemDefaults,
+		keys.IDPConfig,
+		keys.OTP,
+		keys.OIDC,
+		keys.SAML,
+		keys.Target,
+		config.InternalAuthZ.RolePermissionMappings,
+		internal_authz.SessionTokenVerifier(keys.OIDC),
+		func(q *query.Queries) domain.PermissionCheck {
+			return func(ctx context.Context, permission, orgID, resourceID string) (err error) {
+				return internal_authz.CheckPermission(ctx, &authz_es.UserMembershipRepo{Queries: q}, config.InternalAuthZ.RolePermissionMappings, permission, orgID, resourceID)
+			}
+		},
+		0,
+		config.SystemAPIUsers,
+		false,
+	)
+}
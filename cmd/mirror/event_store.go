@@ -54,6 +54,7 @@ func copyEventstore(ctx context.Context, config *Migration) {
 
 	copyEvents(ctx, sourceClient, destClient, config.EventBulkSize)
 	copyUniqueConstraints(ctx, sourceClient, destClient)
+	copyDataKeys(ctx, sourceClient, destClient)
 }
 
 func positionQuery(db *db.DB) string {
@@ -248,3 +249,56 @@ func copyUniqueConstraints(ctx context.Context, source, dest *db.DB) {
 	logging.OnError(<-errs).Fatal("unable to copy unique constraints from source")
 	logging.WithFields("took", time.Since(start), "count", eventCount).Info("unique constraints migrated")
 }
+
+// copyDataKeys copies the keys of the personal data in the events,
+// without them the personal data of the copied events would be redacted.
+func copyDataKeys(ctx context.Context, source, dest *db.DB) {
+	start := time.Now()
+	reader, writer := io.Pipe()
+	errs := make(chan error, 1)
+
+	sourceConn, err := source.Conn(ctx)
+	logging.OnError(err).Fatal("unable to acquire source connection")
+
+	go func() {
+		err := sourceConn.Raw(func(driverConn interface{}) error {
+			conn := driverConn.(*stdlib.Conn).Conn()
+			var stmt database.Statement
+			stmt.WriteString("COPY (SELECT instance_id, aggregate_type, aggregate_id, key_id, data_key, created_at FROM eventstore.data_keys ")
+			stmt.WriteString(instanceClause())
+			stmt.WriteString(") TO stdout")
+
+			_, err := conn.PgConn().CopyTo(ctx, writer, stmt.String())
+			writer.Close()
+			return err
+		})
+		errs <- err
+	}()
+
+	destConn, err := dest.Conn(ctx)
+	logging.OnError(err).Fatal("unable to acquire dest connection")
+
+	var keyCount int64
+	err = destConn.Raw(func(driverConn interface{}) error {
+		conn := driverConn.(*stdlib.Conn).Conn()
+
+		if shouldReplace {
+			var stmt database.Statement
+			stmt.WriteString("DELETE FROM eventstore.data_keys ")
+			stmt.WriteString(instanceClause())
+
+			_, err := conn.Exec(ctx, stmt.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		tag, err := conn.PgConn().CopyFrom(ctx, reader, "COPY eventstore.data_keys (instance_id, aggregate_type, aggregate_id, key_id, data_key, created_at) FROM stdin")
+		keyCount = tag.RowsAffected()
+
+		return err
+	})
+	logging.OnError(err).Fatal("unable to copy data keys to destination")
+	logging.OnError(<-errs).Fatal("unable to copy data keys from source")
+	logging.WithFields("took", time.Since(start), "count", keyCount).Info("data keys migrated")
+}
@@ -122,6 +122,8 @@ func projections(
 	esPusherDBClient, err := database.Connect(config.Destination, false, dialect.DBPurposeEventPusher)
 	logging.OnError(err).Fatal("unable to connect eventstore push client")
 	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.DataKeys = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.PersonalDataEncryption = keys.PersonalData
 	es := eventstore.NewEventstore(config.Eventstore)
 	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, &es_v4_pg.Config{
 		MaxRetries: config.Eventstore.MaxRetries,
@@ -185,6 +187,7 @@ func projections(
 		keys.OIDC,
 		keys.SAML,
 		keys.Target,
+		keys.IDPToken,
 		&http.Client{},
 		func(ctx context.Context, permission, orgID, resourceID string) (err error) {
 			return internal_authz.CheckPermission(ctx, authZRepo, config.InternalAuthZ.RolePermissionMappings, permission, orgID, resourceID)
@@ -0,0 +1,22 @@
+package projections
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/cmd/key"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projections",
+		Short: "manage the projections of ZITADEL",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("no additional command provided")
+		},
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.AddCommand(newRebuild())
+	return cmd
+}
@@ -0,0 +1,87 @@
+package projections
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func newRebuild() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild <projection>...",
+		Short: "rebuild projections without downtime",
+		Long: `rebuild projections from the first event without downtime.
+The projection is built into shadow tables in the projections_shadow schema while ZITADEL keeps serving the current tables.
+As soon as the rebuilt projection caught up with the running projection, the current tables are replaced in a single transaction.
+The projections are rebuilt one after another, the progress is logged and stored in projections.rebuilds.`,
+		Example: `rebuild projections.users14 projections.login_names3`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("at least one projection is required")
+			}
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			return rebuild(cmd.Context(), MustNewConfig(viper.GetViper()), masterKey, args)
+		},
+	}
+}
+
+func rebuild(ctx context.Context, config *Config, masterKey string, projections []string) error {
+	queryDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeQuery)
+	if err != nil {
+		return err
+	}
+	esPusherDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeEventPusher)
+	if err != nil {
+		return err
+	}
+	projectionDBClient, err := database.Connect(config.Database, false, dialect.DBPurposeProjectionSpooler)
+	if err != nil {
+		return err
+	}
+
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	if err != nil {
+		return err
+	}
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	if err != nil {
+		return err
+	}
+
+	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
+	esV3 := new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.Pusher = esV3
+	config.Eventstore.Searcher = esV3
+	config.Eventstore.DataKeys = esV3
+	config.Eventstore.PersonalDataEncryption = keys.PersonalData
+	es := eventstore.NewEventstore(config.Eventstore)
+
+	err = projection.Create(ctx, projectionDBClient, es, config.Projections, keys.OIDC, keys.SAML, config.SystemAPIUsers)
+	if err != nil {
+		return err
+	}
+	for _, name := range projections {
+		logging.WithFields("projection", name).Info("rebuild started")
+		if err = projection.Rebuild(ctx, name); err != nil {
+			return err
+		}
+		logging.WithFields("projection", name).Info("rebuild done")
+	}
+	return nil
+}
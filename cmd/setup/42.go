@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 42.sql
+	addSnapshotTable string
+)
+
+type AddSnapshotTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddSnapshotTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addSnapshotTable)
+	return err
+}
+
+func (mig *AddSnapshotTable) String() string {
+	return "42_add_snapshot_table"
+}
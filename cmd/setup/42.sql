@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    -- identifies the write model which state is stored
+    , snapshot_type TEXT NOT NULL
+
+    , resource_owner TEXT NOT NULL
+    -- snapshots of other versions than the one of the running code are ignored
+    , "version" TEXT NOT NULL
+    -- sequence and position of the last event reduced into the snapshot
+    , "sequence" BIGINT NOT NULL
+    , "position" DECIMAL NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , payload JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id, snapshot_type)
+);
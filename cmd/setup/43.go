@@ -0,0 +1,127 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 43.sql
+	addDataKeysTable string
+	//go:embed 43_events.sql
+	unencryptedPersonalDataEvents string
+)
+
+const (
+	encryptPersonalDataBatchSize = 1000
+	updateEventPayloadStmt       = `UPDATE eventstore.events2 SET payload = $1 WHERE instance_id = $2 AND aggregate_type = $3 AND aggregate_id = $4 AND "sequence" = $5`
+)
+
+// EncryptPersonalData creates the table of the data keys
+// and encrypts the personal data of the events stored before personal data was encrypted,
+// including the fields added to events which were encrypted before the fields were registered.
+// The personal data of aggregates which were already removed is redacted.
+type EncryptPersonalData struct {
+	dbClient   *database.DB
+	eventstore *eventstore.Eventstore
+}
+
+type personalDataEvent struct {
+	aggregate eventstore.Aggregate
+	sequence  uint64
+	typ       eventstore.EventType
+	payload   []byte
+	shredded  bool
+}
+
+func (mig *EncryptPersonalData) Execute(ctx context.Context, _ eventstore.Event) error {
+	if _, err := mig.dbClient.ExecContext(ctx, addDataKeysTable); err != nil {
+		return err
+	}
+	eventTypes := database.TextArray[eventstore.EventType](eventstore.PersonalDataEventTypes())
+	shredderTypes := database.TextArray[eventstore.EventType](eventstore.PersonalDataShredderTypes())
+	fields := personalDataFields(eventTypes)
+
+	// the events are paginated by their primary key,
+	// because events without personal data fields are not updated and would be selected again
+	var last personalDataEvent
+	for {
+		events, err := mig.events(ctx, eventTypes, shredderTypes, fields, &last)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			payload, err := mig.eventstore.EncryptPersonalDataPayload(ctx, &event.aggregate, event.typ, event.payload, event.shredded)
+			if err != nil {
+				return err
+			}
+			_, err = mig.dbClient.ExecContext(ctx, updateEventPayloadStmt, payload, event.aggregate.InstanceID, event.aggregate.Type, event.aggregate.ID, event.sequence)
+			if err != nil {
+				return err
+			}
+		}
+		logging.WithFields("migration", mig.String(), "count", len(events)).Info("personal data encrypted")
+		if len(events) < encryptPersonalDataBatchSize {
+			return nil
+		}
+		last = *events[len(events)-1]
+	}
+}
+
+func (mig *EncryptPersonalData) events(ctx context.Context, eventTypes, shredderTypes database.TextArray[eventstore.EventType], fields database.TextArray[string], last *personalDataEvent) (events []*personalDataEvent, err error) {
+	events = make([]*personalDataEvent, 0, encryptPersonalDataBatchSize)
+	err = mig.dbClient.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				event := new(personalDataEvent)
+				if err := rows.Scan(
+					&event.aggregate.InstanceID,
+					&event.aggregate.Type,
+					&event.aggregate.ID,
+					&event.sequence,
+					&event.typ,
+					&event.payload,
+					&event.shredded,
+				); err != nil {
+					return err
+				}
+				events = append(events, event)
+			}
+			return nil
+		},
+		unencryptedPersonalDataEvents,
+		eventTypes,
+		shredderTypes,
+		fields,
+		last.aggregate.InstanceID,
+		last.aggregate.Type,
+		last.aggregate.ID,
+		last.sequence,
+		encryptPersonalDataBatchSize,
+	)
+	return events, err
+}
+
+// personalDataFields returns the personal data fields of all event types
+func personalDataFields(eventTypes []eventstore.EventType) database.TextArray[string] {
+	fields := make(database.TextArray[string], 0)
+	for _, eventType := range eventTypes {
+		for _, field := range eventstore.PersonalDataFields(eventType) {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+func (mig *EncryptPersonalData) String() string {
+	return "43_encrypt_personal_data"
+}
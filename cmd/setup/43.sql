@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS eventstore.data_keys (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    -- identifies the key in the encrypted payloads
+    , key_id TEXT NOT NULL
+    -- the key is encrypted by the personal data encryption key
+    , data_key JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id)
+);
@@ -0,0 +1,29 @@
+SELECT
+    e.instance_id
+    , e.aggregate_type
+    , e.aggregate_id
+    , e."sequence"
+    , e.event_type
+    , e.payload
+    -- the personal data of removed aggregates is redacted instead of encrypted
+    , EXISTS (
+        SELECT 1 FROM eventstore.events2 s
+        WHERE s.instance_id = e.instance_id
+            AND s.aggregate_type = e.aggregate_type
+            AND s.aggregate_id = e.aggregate_id
+            AND s.event_type = ANY($2)
+    ) AS shredded
+FROM
+    eventstore.events2 e
+WHERE
+    e.event_type = ANY($1)
+    AND e.payload IS NOT NULL
+    -- events encrypted before further fields were registered still contain them in plain text
+    AND (e.payload->'encryptedPersonalData' IS NULL OR e.payload ?| $3)
+    AND (e.instance_id, e.aggregate_type, e.aggregate_id, e."sequence") > ($4, $5, $6, $7)
+ORDER BY
+    e.instance_id
+    , e.aggregate_type
+    , e.aggregate_id
+    , e."sequence"
+LIMIT $8
@@ -0,0 +1,16 @@
+-- projections are rebuilt into the shadow schema and moved to the projections schema afterwards
+CREATE SCHEMA IF NOT EXISTS projections_shadow;
+
+CREATE TABLE IF NOT EXISTS projections.rebuilds (
+    projection_name TEXT NOT NULL
+    -- 1: building, 2: swapping, 3: done, 4: failed
+    , "state" SMALLINT NOT NULL
+    , instances_total INTEGER NOT NULL DEFAULT 0
+    , instances_done INTEGER NOT NULL DEFAULT 0
+    , error TEXT
+    , started_at TIMESTAMPTZ NOT NULL
+    -- updated regularly while the rebuild is running, stale rebuilds can be restarted
+    , changed_at TIMESTAMPTZ NOT NULL
+
+    , PRIMARY KEY (projection_name)
+);
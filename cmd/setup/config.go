@@ -129,6 +129,9 @@ type Steps struct {
 	s40InitPushFunc                         *InitPushFunc
 	s39DeleteStaleOrgFields                 *DeleteStaleOrgFields
 	s41FillFieldsForInstanceDomains         *FillFieldsForInstanceDomains
+	s42AddSnapshotTable                     *AddSnapshotTable
+	s43EncryptPersonalData                  *EncryptPersonalData
+	s44AddProjectionRebuilds                *AddProjectionRebuilds
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
@@ -113,6 +113,13 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	esV3 := new_es.NewEventstore(esPusherDBClient)
 	config.Eventstore.Pusher = esV3
 	config.Eventstore.Searcher = esV3
+
+	keyStorage, err := cryptoDB.NewKeyStorage(queryDBClient, masterKey)
+	logging.OnError(err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(err).Fatal("unable to ensure encryption keys")
+	config.Eventstore.DataKeys = esV3
+	config.Eventstore.PersonalDataEncryption = keys.PersonalData
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 
 	logging.OnError(err).Fatal("unable to start eventstore")
@@ -172,6 +179,9 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.s39DeleteStaleOrgFields = &DeleteStaleOrgFields{dbClient: esPusherDBClient}
 	steps.s40InitPushFunc = &InitPushFunc{dbClient: esPusherDBClient}
 	steps.s41FillFieldsForInstanceDomains = &FillFieldsForInstanceDomains{eventstore: eventstoreClient}
+	steps.s42AddSnapshotTable = &AddSnapshotTable{dbClient: esPusherDBClient}
+	steps.s43EncryptPersonalData = &EncryptPersonalData{dbClient: esPusherDBClient, eventstore: eventstoreClient}
+	steps.s44AddProjectionRebuilds = &AddProjectionRebuilds{dbClient: queryDBClient}
 
 	err = projection.Create(ctx, projectionDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -197,6 +207,8 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s2AssetsTable,
 		steps.s28AddFieldTable,
 		steps.s31AddAggregateIndexToFields,
+		steps.s42AddSnapshotTable,
+		steps.s43EncryptPersonalData,
 		steps.FirstInstance,
 		steps.s5LastFailed,
 		steps.s6OwnerRemoveColumns,
@@ -220,6 +232,7 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s36FillV2Milestones,
 		steps.s38BackChannelLogoutNotificationStart,
 		steps.s41FillFieldsForInstanceDomains,
+		steps.s44AddProjectionRebuilds,
 	} {
 		mustExecuteMigration(ctx, eventstoreClient, step, "migration failed")
 	}
@@ -428,6 +441,7 @@ func initProjections(
 		keys.OIDC,
 		keys.SAML,
 		keys.Target,
+		keys.IDPToken,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -25,8 +25,11 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/grantexpiry"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/inactivity"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -34,6 +37,7 @@ import (
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	profiler "github.com/zitadel/zitadel/internal/telemetry/profiler/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/userschema"
 )
 
 type Config struct {
@@ -55,6 +59,10 @@ type Config struct {
 	Profiler            profiler.Config
 	Projections         projection.Config
 	Notifications       handlers.WorkerConfig
+	Inactivity          inactivity.Config
+	GrantExpiry         grantexpiry.Config
+	UserSchemaMigration userschema.Config
+	EventSinks          eventsink.Config
 	Auth                auth_es.Config
 	Admin               admin_es.Config
 	UserAgentCookie     *middleware.UserAgentCookieConfig
@@ -100,6 +108,7 @@ func MustNewConfig(v *viper.Viper) *Config {
 			hook.EnumHookFunc(internal_authz.MemberTypeString),
 			hooks.MapTypeStringDecode[domain.Feature, any],
 			hooks.SliceTypeStringDecode[*command.SetQuota],
+			hooks.SliceTypeStringDecode[*eventsink.SinkConfig],
 			hook.Base64ToBytesHookFunc(),
 			hook.TagToLanguageHookFunc(),
 			mapstructure.StringToTimeDurationHookFunc(),
@@ -34,6 +34,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/delivery"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	feature_v2 "github.com/zitadel/zitadel/internal/api/grpc/feature/v2"
@@ -62,6 +63,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/robots_txt"
 	"github.com/zitadel/zitadel/internal/api/saml"
+	"github.com/zitadel/zitadel/internal/api/ssf"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/console/path"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
@@ -76,11 +78,14 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/dialect"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/grantexpiry"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/inactivity"
 	"github.com/zitadel/zitadel/internal/integration/sink"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
@@ -91,6 +96,7 @@ import (
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userschema"
 	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
 	es_v4_pg "github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
 	"github.com/zitadel/zitadel/internal/webauthn"
@@ -171,6 +177,9 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 
 	config.Eventstore.Pusher = new_es.NewEventstore(esPusherDBClient)
 	config.Eventstore.Searcher = new_es.NewEventstore(queryDBClient)
+	config.Eventstore.Snapshotter = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.DataKeys = new_es.NewEventstore(esPusherDBClient)
+	config.Eventstore.PersonalDataEncryption = keys.PersonalData
 	config.Eventstore.Querier = old_es.NewCRDB(queryDBClient)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(queryDBClient, &es_v4_pg.Config{
@@ -247,6 +256,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.OIDC,
 		keys.SAML,
 		keys.Target,
+		keys.IDPToken,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -297,6 +307,13 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		queryDBClient,
 	)
 	notification.Start(ctx)
+	inactivity.NewWorker(config.Inactivity, commands, queries, inactivity.NewLocker(queryDBClient)).Start(ctx)
+	grantexpiry.NewWorker(config.GrantExpiry, commands, queries, grantexpiry.NewLocker(queryDBClient)).Start(ctx)
+	userschema.NewWorker(config.UserSchemaMigration, commands, queries, userschema.NewLocker(queryDBClient)).Start(ctx)
+	if err = eventsink.Register(ctx, config.EventSinks, config.Projections.Customizations["eventsinks"]); err != nil {
+		return err
+	}
+	eventsink.Start(ctx)
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -449,7 +466,7 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, feature_v2beta.CreateServer(commands, queries)); err != nil {
 		return nil, err
 	}
-	if err := apis.RegisterService(ctx, session_v2.CreateServer(commands, queries)); err != nil {
+	if err := apis.RegisterService(ctx, session_v2.CreateServer(commands, queries, idp.LogoutCallbackURL(), idp.SAMLRootURL())); err != nil {
 		return nil, err
 	}
 	if err := apis.RegisterService(ctx, settings_v2.CreateServer(commands, queries)); err != nil {
@@ -470,7 +487,7 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, userschema_v3_alpha.CreateServer(config.SystemDefaults, commands, queries)); err != nil {
 		return nil, err
 	}
-	if err := apis.RegisterService(ctx, user_v3_alpha.CreateServer(commands)); err != nil {
+	if err := apis.RegisterService(ctx, user_v3_alpha.CreateServer(config.SystemDefaults, commands, queries)); err != nil {
 		return nil, err
 	}
 	if err := apis.RegisterService(ctx, webkey.CreateServer(commands, queries)); err != nil {
@@ -484,6 +501,9 @@ func startAPIs(
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
 	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, instanceInterceptor.Handler))
+	apis.RegisterHandlerOnPrefix(delivery.HandlerPrefix, delivery.NewHandler(commands, queries, keys.SMS, keys.SMTP, instanceInterceptor.Handler))
+
+	apis.RegisterHandlerPrefixes(ssf.NewHandler(commands, queries, verifier, config.InternalAuthZ, instanceInterceptor.Handler), ssf.Prefixes...)
 
 	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointExternalLoginCallbackFormPost, login.EndpointSAMLACS)
 	if err != nil {
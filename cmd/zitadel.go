@@ -13,9 +13,11 @@ import (
 
 	"github.com/zitadel/zitadel/cmd/admin"
 	"github.com/zitadel/zitadel/cmd/build"
+	"github.com/zitadel/zitadel/cmd/export"
 	"github.com/zitadel/zitadel/cmd/initialise"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/mirror"
+	"github.com/zitadel/zitadel/cmd/projections"
 	"github.com/zitadel/zitadel/cmd/ready"
 	"github.com/zitadel/zitadel/cmd/setup"
 	"github.com/zitadel/zitadel/cmd/start"
@@ -59,6 +61,8 @@ func New(out io.Writer, in io.Reader, args []string, server chan<- *start.Server
 		mirror.New(&configFiles),
 		key.New(),
 		ready.New(),
+		export.New(),
+		projections.New(),
 	)
 
 	cmd.InitDefaultVersionFlag()
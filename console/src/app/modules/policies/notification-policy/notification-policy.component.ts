@@ -27,7 +27,16 @@ export class NotificationPolicyComponent implements OnInit {
   @Input() public serviceType: PolicyComponentServiceType = PolicyComponentServiceType.MGMT;
   public service!: ManagementService | AdminService;
 
-  public notificationData?: NotificationPolicy.AsObject = { isDefault: false, passwordChange: false };
+  public notificationData?: NotificationPolicy.AsObject = {
+    isDefault: false,
+    passwordChange: false,
+    newDeviceSignIn: false,
+    mfaAdded: false,
+    mfaRemoved: false,
+    emailChange: false,
+    accountLocked: false,
+    personalAccessTokenAdded: false,
+  };
 
   public PolicyComponentServiceType: any = PolicyComponentServiceType;
 
@@ -123,7 +132,7 @@ export class NotificationPolicyComponent implements OnInit {
         case PolicyComponentServiceType.MGMT:
           if ((this.notificationData as NotificationPolicy.AsObject).isDefault) {
             const req = new AddCustomNotificationPolicyRequest();
-            req.setPasswordChange(this.notificationData.passwordChange);
+            this.setRequestValues(req, this.notificationData);
             (this.service as ManagementService)
               .addCustomNotificationPolicy(req)
               .then(() => {
@@ -135,7 +144,7 @@ export class NotificationPolicyComponent implements OnInit {
               });
           } else {
             const req = new UpdateNotificationPolicyRequest();
-            req.setPasswordChange(this.notificationData.passwordChange);
+            this.setRequestValues(req, this.notificationData);
             (this.service as ManagementService)
               .updateCustomNotificationPolicy(req)
               .then(() => {
@@ -150,7 +159,7 @@ export class NotificationPolicyComponent implements OnInit {
         case PolicyComponentServiceType.ADMIN:
           if (this.hasNotificationPolicy) {
             const req = new UpdateNotificationPolicyRequest();
-            req.setPasswordChange(this.notificationData.passwordChange);
+            this.setRequestValues(req, this.notificationData);
             (this.service as AdminService)
               .updateNotificationPolicy(req)
               .then(() => {
@@ -162,7 +171,7 @@ export class NotificationPolicyComponent implements OnInit {
               });
           } else {
             const req = new AddNotificationPolicyRequest();
-            req.setPasswordChange(this.notificationData.passwordChange);
+            this.setRequestValues(req, this.notificationData);
             (this.service as AdminService)
               .addNotificationPolicy(req)
               .then(() => {
@@ -178,4 +187,20 @@ export class NotificationPolicyComponent implements OnInit {
       }
     }
   }
+
+  private setRequestValues(
+    req:
+      | AddCustomNotificationPolicyRequest
+      | UpdateNotificationPolicyRequest
+      | AddNotificationPolicyRequest,
+    policy: NotificationPolicy.AsObject,
+  ): void {
+    req.setPasswordChange(policy.passwordChange);
+    req.setNewDeviceSignIn(policy.newDeviceSignIn);
+    req.setMfaAdded(policy.mfaAdded);
+    req.setMfaRemoved(policy.mfaRemoved);
+    req.setEmailChange(policy.emailChange);
+    req.setAccountLocked(policy.accountLocked);
+    req.setPersonalAccessTokenAdded(policy.personalAccessTokenAdded);
+  }
 }
@@ -259,6 +259,7 @@ module.exports = {
         },
         "guides/integrate/token-exchange",
         "guides/integrate/back-channel-logout",
+        "guides/integrate/shared-signals",
         {
           type: "category",
           label: "Service Users",
@@ -329,6 +330,7 @@ module.exports = {
             "guides/integrate/identity-providers/jwt_idp",
             "guides/integrate/identity-providers/migrate",
             "guides/integrate/identity-providers/additional-information",
+            "guides/integrate/identity-providers/federated-logout",
           ],
         },
         {
@@ -930,6 +932,8 @@ module.exports = {
         "self-hosting/manage/cache",
         "self-hosting/manage/updating_scaling",
         "self-hosting/manage/usage_control",
+        "self-hosting/manage/event-sinks",
+        "self-hosting/manage/projection-rebuilds",
         {
           type: "category",
           label: "Command Line Interface",
@@ -14,6 +14,12 @@ type MembershipsResolver interface {
 	SearchMyMemberships(ctx context.Context, orgID string, shouldTriggerBulk bool) ([]*Membership, error)
 }
 
+// CustomRolesResolver can optionally be implemented by a [MembershipsResolver]
+// to resolve the custom roles of the instance and the organization (including its ancestors).
+type CustomRolesResolver interface {
+	SearchCustomRoles(ctx context.Context, orgID string) ([]RoleMapping, error)
+}
+
 type authZRepo interface {
 	MembershipsResolver
 	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
@@ -50,6 +50,16 @@ func (v *ApiTokenVerifier) SearchMyMemberships(ctx context.Context, orgID string
 	return v.authZRepo.SearchMyMemberships(ctx, orgID, shouldTriggerBulk)
 }
 
+func (v *ApiTokenVerifier) SearchCustomRoles(ctx context.Context, orgID string) (_ []RoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	resolver, ok := v.authZRepo.(CustomRolesResolver)
+	if !ok {
+		return nil, nil
+	}
+	return resolver.SearchCustomRoles(ctx, orgID)
+}
+
 func (v *ApiTokenVerifier) ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (_ string, _ []string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -7,6 +7,9 @@ type Config struct {
 type RoleMapping struct {
 	Role        string
 	Permissions []string
+	// ResourceOwner restricts the role to the memberships of the organization.
+	// It is only set for the custom roles of an organization.
+	ResourceOwner string
 }
 
 type MethodMapping map[string]Option
@@ -17,11 +20,20 @@ type Option struct {
 	AllowSelf  bool
 }
 
-func getPermissionsFromRole(rolePermissionMappings []RoleMapping, role string) []string {
+func getPermissionsFromRole(rolePermissionMappings []RoleMapping, membership *Membership, role string) []string {
 	for _, roleMap := range rolePermissionMappings {
-		if roleMap.Role == role {
+		if roleMap.Role == role && (roleMap.ResourceOwner == "" || roleMap.ResourceOwner == membership.AggregateID) {
 			return roleMap.Permissions
 		}
 	}
 	return nil
 }
+
+func containsRole(rolePermissionMappings []RoleMapping, role string) bool {
+	for _, roleMap := range rolePermissionMappings {
+		if roleMap.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package authz
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -49,10 +50,39 @@ func getUserPermissions(ctx context.Context, resolver MembershipsResolver, requi
 			return nil, nil, err
 		}
 	}
+	roleMappings, err = appendCustomRoles(ctx, resolver, roleMappings, memberships, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
 	requestedPermissions, allPermissions = mapMembershipsToPermissions(requiredPerm, memberships, roleMappings)
 	return requestedPermissions, allPermissions, nil
 }
 
+// appendCustomRoles adds the custom roles of the instance and the organization to the role mappings,
+// if the resolver is able to resolve them and any membership has a role which is not statically configured.
+func appendCustomRoles(ctx context.Context, resolver MembershipsResolver, roleMappings []RoleMapping, memberships []*Membership, orgID string) ([]RoleMapping, error) {
+	customRolesResolver, ok := resolver.(CustomRolesResolver)
+	if !ok || !hasUnknownRole(memberships, roleMappings) {
+		return roleMappings, nil
+	}
+	customRoles, err := customRolesResolver.SearchCustomRoles(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(roleMappings, customRoles), nil
+}
+
+func hasUnknownRole(memberships []*Membership, roleMappings []RoleMapping) bool {
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if !containsRole(roleMappings, role) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // checkUserResourcePermissions checks that if a user i granted either the requested permission globally (project.write)
 // or the specific resource (project.write:123)
 func checkUserResourcePermissions(userPerms []string, resourceID string) error {
@@ -98,7 +128,7 @@ func mapMembershipsToPermissions(requiredPerm string, memberships []*Membership,
 func mapMembershipToPerm(requiredPerm string, membership *Membership, roleMappings []RoleMapping, requestPermissions, allPermissions []string) ([]string, []string) {
 	roleNames, roleContextID := roleWithContext(membership)
 	for _, roleName := range roleNames {
-		perms := getPermissionsFromRole(roleMappings, roleName)
+		perms := getPermissionsFromRole(roleMappings, membership, roleName)
 
 		for _, p := range perms {
 			permWithCtx := addRoleContextIDToPerm(p, roleContextID)
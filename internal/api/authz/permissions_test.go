@@ -25,6 +25,15 @@ func (m membershipsResolverFunc) SearchMyMemberships(ctx context.Context, orgID
 	return m(ctx, orgID, shouldTriggerBulk)
 }
 
+type customRolesResolver struct {
+	membershipsResolverFunc
+	customRoles []RoleMapping
+}
+
+func (r *customRolesResolver) SearchCustomRoles(context.Context, string) ([]RoleMapping, error) {
+	return r.customRoles, nil
+}
+
 func Test_GetUserPermissions(t *testing.T) {
 	type args struct {
 		ctxData             CtxData
@@ -117,6 +126,76 @@ func Test_GetUserPermissions(t *testing.T) {
 			},
 			result: []string{"project.read"},
 		},
+		{
+			name: "Get Permissions, custom role",
+			args: args{
+				ctxData: CtxData{UserID: "userID", OrgID: "orgID"},
+				membershipsResolver: &customRolesResolver{
+					membershipsResolverFunc: func(ctx context.Context, orgID string, shouldTriggerBulk bool) ([]*Membership, error) {
+						return []*Membership{
+							{
+								AggregateID: "orgID",
+								ObjectID:    "orgID",
+								MemberType:  MemberTypeOrganization,
+								Roles:       []string{"ORG_HELPDESK"},
+							},
+						}, nil
+					},
+					customRoles: []RoleMapping{
+						{
+							Role:          "ORG_HELPDESK",
+							Permissions:   []string{"user.credential.write"},
+							ResourceOwner: "orgID",
+						},
+					},
+				},
+				requiredPerm: "user.credential.write",
+				authConfig: Config{
+					RolePermissionMappings: []RoleMapping{
+						{
+							Role:        "ORG_OWNER",
+							Permissions: []string{"org.read", "user.credential.write"},
+						},
+					},
+				},
+			},
+			result: []string{"user.credential.write"},
+		},
+		{
+			name: "custom role of other organization",
+			args: args{
+				ctxData: CtxData{UserID: "userID", OrgID: "orgID"},
+				membershipsResolver: &customRolesResolver{
+					membershipsResolverFunc: func(ctx context.Context, orgID string, shouldTriggerBulk bool) ([]*Membership, error) {
+						return []*Membership{
+							{
+								AggregateID: "orgID",
+								ObjectID:    "orgID",
+								MemberType:  MemberTypeOrganization,
+								Roles:       []string{"ORG_HELPDESK"},
+							},
+						}, nil
+					},
+					customRoles: []RoleMapping{
+						{
+							Role:          "ORG_HELPDESK",
+							Permissions:   []string{"user.credential.write"},
+							ResourceOwner: "otherOrgID",
+						},
+					},
+				},
+				requiredPerm: "user.credential.write",
+				authConfig: Config{
+					RolePermissionMappings: []RoleMapping{
+						{
+							Role:        "ORG_OWNER",
+							Permissions: []string{"org.read", "user.credential.write"},
+						},
+					},
+				},
+			},
+			result: []string{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
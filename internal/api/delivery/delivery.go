@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// HandlerPrefix is the path prefix of the callbacks, which providers call to report the delivery status of notifications
+	HandlerPrefix = "/notifications/delivery"
+
+	providerPrefix = "/{" + varProviderID + ":[0-9]+}"
+
+	twilioPath   = "/twilio" + providerPrefix
+	sendGridPath = "/sendgrid" + providerPrefix
+	sesPath      = "/ses" + providerPrefix
+
+	varProviderID = "providerid"
+
+	// reporterUserID is set as editor of the reported delivery status
+	reporterUserID = "NOTIFICATION_PROVIDER"
+)
+
+// Handler receives the delivery status callbacks of the notification providers.
+// The callbacks are not authenticated by a token, instead the signature of the provider (Twilio)
+// or the delivery secret of the email provider (SendGrid and Amazon SES) is verified.
+type Handler struct {
+	commands       *command.Commands
+	queries        *query.Queries
+	smsEncryption  crypto.EncryptionAlgorithm
+	smtpEncryption crypto.EncryptionAlgorithm
+	httpClient     *http.Client
+}
+
+func NewHandler(
+	commands *command.Commands,
+	queries *query.Queries,
+	smsEncryption crypto.EncryptionAlgorithm,
+	smtpEncryption crypto.EncryptionAlgorithm,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		commands:       commands,
+		queries:        queries,
+		smsEncryption:  smsEncryption,
+		smtpEncryption: smtpEncryption,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+	}
+
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor)
+	router.HandleFunc(twilioPath, h.handleTwilio).Methods(http.MethodPost)
+	router.HandleFunc(sendGridPath, h.authorizeEmailProvider(h.handleSendGrid)).Methods(http.MethodPost)
+	router.HandleFunc(sesPath, h.authorizeEmailProvider(h.handleSES)).Methods(http.MethodPost)
+	return router
+}
+
+// authorizeEmailProvider checks the password of the basic auth against the delivery secret of the email provider.
+// SendGrid and Amazon SNS both send the credentials of the userinfo of the configured callback url as basic auth.
+func (h *Handler) authorizeEmailProvider(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, password, ok := r.BasicAuth()
+		if !ok || password == "" {
+			h.writeUnauthorized(w, r, zerrors.ThrowUnauthenticated(nil, "DELIV-Ba1mS", "basic auth missing"))
+			return
+		}
+		encryptedSecret, err := h.queries.SMTPConfigDeliverySecret(r.Context(), mux.Vars(r)[varProviderID])
+		if err != nil {
+			h.writeUnauthorized(w, r, err)
+			return
+		}
+		if encryptedSecret == nil {
+			h.writeUnauthorized(w, r, zerrors.ThrowUnauthenticated(nil, "DELIV-Ds1nG", "delivery secret not generated"))
+			return
+		}
+		secret, err := crypto.DecryptString(encryptedSecret, h.smtpEncryption)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(password), []byte(secret)) != 1 {
+			h.writeUnauthorized(w, r, zerrors.ThrowUnauthenticated(nil, "DELIV-Ds1iV", "invalid delivery secret"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// report sets the status of the notification which the provider sent with the message id.
+// Messages which were not recorded or which were sent by another provider are ignored,
+// so the provider does not retry the callback.
+func (h *Handler) report(ctx context.Context, providerID string, channel domain.NotificationType, messageID string, status domain.NotificationDeliveryStatus, reason string) error {
+	if messageID == "" || !status.IsReportable() {
+		return nil
+	}
+	delivery, err := h.queries.NotificationDeliveryByProviderMessageID(ctx, true, messageID)
+	if zerrors.IsNotFound(err) {
+		logging.WithFields("provider", providerID, "message", messageID).Debug("delivery status of unknown message ignored")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.ProviderID != providerID || delivery.Channel != channel {
+		logging.WithFields("provider", providerID, "message", messageID).Info("delivery status of message of other provider ignored")
+		return nil
+	}
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: reporterUserID, OrgID: authz.GetInstance(ctx).InstanceID()})
+	_, err = h.commands.ReportNotificationDelivery(ctx, delivery.ID, status, reason)
+	if zerrors.IsPreconditionFailed(err) {
+		logging.WithFields("provider", providerID, "message", messageID).WithError(err).Info("delivery status not reportable")
+		return nil
+	}
+	return err
+}
+
+// normalizeMessageID returns the Message-ID header as it is sent by the smtp channel, including the angle brackets.
+func normalizeMessageID(messageID string) string {
+	messageID = strings.TrimSpace(messageID)
+	if messageID == "" {
+		return ""
+	}
+	return "<" + strings.TrimSuffix(strings.TrimPrefix(messageID, "<"), ">") + ">"
+}
+
+func (h *Handler) writeUnauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	logging.WithFields("uri", r.URL.Path).WithError(err).Debug("delivery callback not authorized")
+	w.Header().Set("WWW-Authenticate", `Basic realm="notification delivery"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	logging.WithFields("uri", r.URL.Path).WithError(err).Warn("error occurred on delivery callback")
+	code, ok := http_utils.ZitadelErrorToHTTPStatusCode(err)
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	http.Error(w, http.StatusText(code), code)
+}
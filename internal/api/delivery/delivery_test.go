@@ -0,0 +1,251 @@
+package delivery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_verifyTwilioSignature(t *testing.T) {
+	callbackURL := "https://zitadel.example.com/notifications/delivery/twilio/123?source=zitadel"
+	params := url.Values{
+		"MessageStatus": []string{"undelivered"},
+		"MessageSid":    []string{"SM1"},
+		"ErrorCode":     []string{"30003"},
+	}
+	mac := hmac.New(sha1.New, []byte("token"))
+	mac.Write([]byte(callbackURL + "ErrorCode30003" + "MessageSidSM1" + "MessageStatusundelivered"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name        string
+		authToken   string
+		callbackURL string
+		params      url.Values
+		signature   string
+		want        bool
+	}{
+		{
+			name:        "valid",
+			authToken:   "token",
+			callbackURL: callbackURL,
+			params:      params,
+			signature:   signature,
+			want:        true,
+		},
+		{
+			name:        "missing signature",
+			authToken:   "token",
+			callbackURL: callbackURL,
+			params:      params,
+			want:        false,
+		},
+		{
+			name:        "wrong token",
+			authToken:   "other",
+			callbackURL: callbackURL,
+			params:      params,
+			signature:   signature,
+			want:        false,
+		},
+		{
+			name:        "other url",
+			authToken:   "token",
+			callbackURL: "https://zitadel.example.com/notifications/delivery/twilio/456?source=zitadel",
+			params:      params,
+			signature:   signature,
+			want:        false,
+		},
+		{
+			name:        "changed params",
+			authToken:   "token",
+			callbackURL: callbackURL,
+			params: url.Values{
+				"MessageStatus": []string{"delivered"},
+				"MessageSid":    []string{"SM1"},
+				"ErrorCode":     []string{"30003"},
+			},
+			signature: signature,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, verifyTwilioSignature(tt.authToken, tt.callbackURL, tt.params, tt.signature))
+		})
+	}
+}
+
+func Test_twilioStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     url.Values
+		wantStatus domain.NotificationDeliveryStatus
+		wantReason string
+	}{
+		{
+			name:       "sent, ignored",
+			params:     url.Values{"MessageStatus": []string{"sent"}},
+			wantStatus: domain.NotificationDeliveryStatusUnspecified,
+		},
+		{
+			name:       "delivered",
+			params:     url.Values{"MessageStatus": []string{"delivered"}},
+			wantStatus: domain.NotificationDeliveryStatusDelivered,
+		},
+		{
+			name:       "undelivered",
+			params:     url.Values{"MessageStatus": []string{"undelivered"}, "ErrorCode": []string{"30003"}},
+			wantStatus: domain.NotificationDeliveryStatusBounced,
+			wantReason: "undelivered (error code 30003)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, reason := twilioStatus(tt.params)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_sendGridEvent_status(t *testing.T) {
+	var events []*sendGridEvent
+	err := json.Unmarshal([]byte(`[
+		{"email":"user@example.com","event":"processed","smtp-id":"<id1@zitadel.example.com>"},
+		{"email":"user@example.com","event":"delivered","smtp-id":"<id1@zitadel.example.com>"},
+		{"email":"user@example.com","event":"bounce","type":"blocked","reason":"500 unknown recipient","smtp-id":"<id2@zitadel.example.com>"},
+		{"email":"user@example.com","event":"dropped","reason":"Bounced Address","smtp-id":"<id3@zitadel.example.com>"}
+	]`), &events)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	tests := []struct {
+		event      *sendGridEvent
+		wantStatus domain.NotificationDeliveryStatus
+		wantReason string
+	}{
+		{
+			event:      events[0],
+			wantStatus: domain.NotificationDeliveryStatusUnspecified,
+		},
+		{
+			event:      events[1],
+			wantStatus: domain.NotificationDeliveryStatusDelivered,
+		},
+		{
+			event:      events[2],
+			wantStatus: domain.NotificationDeliveryStatusBounced,
+			wantReason: "blocked: 500 unknown recipient",
+		},
+		{
+			event:      events[3],
+			wantStatus: domain.NotificationDeliveryStatusBounced,
+			wantReason: "dropped: Bounced Address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event.Event, func(t *testing.T) {
+			status, reason := tt.event.status()
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_sesNotification(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		wantMessageID string
+		wantStatus    domain.NotificationDeliveryStatus
+		wantReason    string
+	}{
+		{
+			name:          "identity notification, delivery",
+			message:       `{"notificationType":"Delivery","mail":{"commonHeaders":{"messageId":"<id1@zitadel.example.com>"}}}`,
+			wantMessageID: "<id1@zitadel.example.com>",
+			wantStatus:    domain.NotificationDeliveryStatusDelivered,
+		},
+		{
+			name:          "configuration set event, bounce with headers",
+			message:       `{"eventType":"Bounce","bounce":{"bounceType":"Permanent","bouncedRecipients":[{"diagnosticCode":"smtp; 550 user unknown"}]},"mail":{"headers":[{"name":"Message-ID","value":"<id2@zitadel.example.com>"}],"commonHeaders":{"messageId":"other"}}}`,
+			wantMessageID: "<id2@zitadel.example.com>",
+			wantStatus:    domain.NotificationDeliveryStatusBounced,
+			wantReason:    "Permanent: smtp; 550 user unknown",
+		},
+		{
+			name:          "complaint, ignored",
+			message:       `{"notificationType":"Complaint","mail":{"commonHeaders":{"messageId":"id3@zitadel.example.com"}}}`,
+			wantMessageID: "<id3@zitadel.example.com>",
+			wantStatus:    domain.NotificationDeliveryStatusUnspecified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification := new(sesNotification)
+			require.NoError(t, json.Unmarshal([]byte(tt.message), notification))
+			assert.Equal(t, tt.wantMessageID, notification.messageID())
+			status, reason := notification.status()
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestHandler_confirmSNSSubscription(t *testing.T) {
+	h := &Handler{httpClient: http.DefaultClient}
+	tests := []struct {
+		name         string
+		subscribeURL string
+	}{
+		{
+			name:         "no https",
+			subscribeURL: "http://sns.eu-central-1.amazonaws.com/?Action=ConfirmSubscription",
+		},
+		{
+			name:         "other host",
+			subscribeURL: "https://sns.eu-central-1.amazonaws.com.example.com/?Action=ConfirmSubscription",
+		},
+		{
+			name:         "internal host",
+			subscribeURL: "https://localhost:8080/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := h.confirmSNSSubscription(context.Background(), tt.subscribeURL)
+			assert.True(t, zerrors.IsErrorInvalidArgument(err))
+		})
+	}
+}
+
+func TestHandler_authorizeEmailProvider_missingBasicAuth(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/sendgrid/123", nil)
+	resp := httptest.NewRecorder()
+
+	new(Handler).authorizeEmailProvider(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	})(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	assert.NotEmpty(t, resp.Header().Get("WWW-Authenticate"))
+}
+
+func Test_normalizeMessageID(t *testing.T) {
+	assert.Equal(t, "", normalizeMessageID(" "))
+	assert.Equal(t, "<id@example.com>", normalizeMessageID("id@example.com"))
+	assert.Equal(t, "<id@example.com>", normalizeMessageID("<id@example.com>"))
+}
@@ -0,0 +1,59 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// sendGridEvent is an event of the SendGrid Event Webhook.
+// SMTPID is the Message-ID header of the email as set by the smtp channel.
+type sendGridEvent struct {
+	Event  string `json:"event"`
+	SMTPID string `json:"smtp-id"`
+	Reason string `json:"reason"`
+	Type   string `json:"type"`
+}
+
+// handleSendGrid receives the events of the SendGrid Event Webhook, which are posted in batches.
+func (h *Handler) handleSendGrid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	providerID := mux.Vars(r)[varProviderID]
+	var events []*sendGridEvent
+	if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "DELIV-Sg1pB", "invalid body"))
+		return
+	}
+	for _, event := range events {
+		status, reason := event.status()
+		if err := h.report(ctx, providerID, domain.NotificationTypeEmail, normalizeMessageID(event.SMTPID), status, reason); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// status maps the delivery events of SendGrid to the delivery status,
+// engagement events like open or click are not reported.
+func (e *sendGridEvent) status() (domain.NotificationDeliveryStatus, string) {
+	switch e.Event {
+	case "delivered":
+		return domain.NotificationDeliveryStatusDelivered, ""
+	case "bounce", "dropped":
+		reason := e.Event
+		if e.Type != "" {
+			reason = e.Type
+		}
+		if e.Reason != "" {
+			reason += ": " + e.Reason
+		}
+		return domain.NotificationDeliveryStatusBounced, reason
+	default:
+		return domain.NotificationDeliveryStatusUnspecified, ""
+	}
+}
@@ -0,0 +1,148 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	snsTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsTypeNotification             = "Notification"
+)
+
+// snsHostRegex restricts the subscription confirmation to the endpoints of Amazon SNS
+var snsHostRegex = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsMessage is the envelope of the messages Amazon SNS posts to HTTP subscriptions.
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+// sesNotification is a notification of Amazon SES, published either as identity notification (notificationType)
+// or by a configuration set (eventType).
+type sesNotification struct {
+	NotificationType string     `json:"notificationType"`
+	EventType        string     `json:"eventType"`
+	Mail             sesMail    `json:"mail"`
+	Bounce           *sesBounce `json:"bounce"`
+}
+
+type sesMail struct {
+	Headers []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"headers"`
+	CommonHeaders struct {
+		MessageID string `json:"messageId"`
+	} `json:"commonHeaders"`
+}
+
+type sesBounce struct {
+	BounceType        string `json:"bounceType"`
+	BouncedRecipients []struct {
+		DiagnosticCode string `json:"diagnosticCode"`
+	} `json:"bouncedRecipients"`
+}
+
+// handleSES receives the bounce and delivery notifications of Amazon SES through an Amazon SNS topic.
+// The subscription of the topic is confirmed automatically.
+func (h *Handler) handleSES(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	providerID := mux.Vars(r)[varProviderID]
+	message := new(snsMessage)
+	if err := json.NewDecoder(r.Body).Decode(message); err != nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "DELIV-Se1pB", "invalid body"))
+		return
+	}
+	switch message.Type {
+	case snsTypeSubscriptionConfirmation:
+		if err := h.confirmSNSSubscription(ctx, message.SubscribeURL); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	case snsTypeNotification:
+		notification := new(sesNotification)
+		if err := json.Unmarshal([]byte(message.Message), notification); err != nil {
+			h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "DELIV-Se1pN", "invalid notification"))
+			return
+		}
+		status, reason := notification.status()
+		if err := h.report(ctx, providerID, domain.NotificationTypeEmail, notification.messageID(), status, reason); err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// confirmSNSSubscription visits the subscribe url, which must point to Amazon SNS.
+func (h *Handler) confirmSNSSubscription(ctx context.Context, subscribeURL string) error {
+	confirmURL, err := url.Parse(subscribeURL)
+	if err != nil || confirmURL.Scheme != "https" || !snsHostRegex.MatchString(confirmURL.Hostname()) {
+		return zerrors.ThrowInvalidArgument(err, "DELIV-Se1sU", "invalid subscribe url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, confirmURL.String(), nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "DELIV-Se1rQ", "unable to create confirmation request")
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return zerrors.ThrowUnavailable(err, "DELIV-Se1cF", "subscription confirmation failed")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return zerrors.ThrowUnavailable(nil, "DELIV-Se1cS", "subscription confirmation failed")
+	}
+	return nil
+}
+
+// messageID returns the Message-ID header set by the smtp channel,
+// the headers are only included if the notification is configured to do so.
+func (n *sesNotification) messageID() string {
+	for _, header := range n.Mail.Headers {
+		if strings.EqualFold(header.Name, "Message-ID") {
+			return normalizeMessageID(header.Value)
+		}
+	}
+	return normalizeMessageID(n.Mail.CommonHeaders.MessageID)
+}
+
+// status maps the bounce and delivery notifications to the delivery status,
+// other notifications like complaints are not reported.
+func (n *sesNotification) status() (domain.NotificationDeliveryStatus, string) {
+	notificationType := n.NotificationType
+	if notificationType == "" {
+		notificationType = n.EventType
+	}
+	switch notificationType {
+	case "Delivery":
+		return domain.NotificationDeliveryStatusDelivered, ""
+	case "Bounce":
+		if n.Bounce == nil {
+			return domain.NotificationDeliveryStatusBounced, ""
+		}
+		reason := n.Bounce.BounceType
+		for _, recipient := range n.Bounce.BouncedRecipients {
+			if recipient.DiagnosticCode != "" {
+				reason += ": " + recipient.DiagnosticCode
+				break
+			}
+		}
+		return domain.NotificationDeliveryStatusBounced, reason
+	default:
+		return domain.NotificationDeliveryStatusUnspecified, ""
+	}
+}
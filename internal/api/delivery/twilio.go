@@ -0,0 +1,107 @@
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const twilioSignatureHeader = "X-Twilio-Signature"
+
+// handleTwilio receives the status callbacks of Twilio messages,
+// which are signed with the auth token of the Twilio account.
+func (h *Handler) handleTwilio(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	providerID := mux.Vars(r)[varProviderID]
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "DELIV-Tw1pF", "invalid form"))
+		return
+	}
+	config, err := h.queries.SMSProviderConfigByID(ctx, providerID)
+	if err != nil {
+		h.writeUnauthorized(w, r, err)
+		return
+	}
+	if config.TwilioConfig == nil {
+		h.writeUnauthorized(w, r, zerrors.ThrowUnauthenticated(nil, "DELIV-Tw1nT", "provider is no Twilio provider"))
+		return
+	}
+	token, err := crypto.DecryptString(config.TwilioConfig.Token, h.smsEncryption)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	callbackURL := http_utils.DomainContext(ctx).Origin() + HandlerPrefix + r.URL.RequestURI()
+	if !verifyTwilioSignature(token, callbackURL, r.PostForm, r.Header.Get(twilioSignatureHeader)) {
+		h.writeUnauthorized(w, r, zerrors.ThrowUnauthenticated(nil, "DELIV-Tw1sI", "invalid signature"))
+		return
+	}
+	status, reason := twilioStatus(r.PostForm)
+	if err = h.report(ctx, providerID, domain.NotificationTypeSms, r.PostForm.Get("MessageSid"), status, reason); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verifyTwilioSignature checks the signature of the request as described in https://www.twilio.com/docs/usage/security#validating-requests:
+// the base64 encoded HMAC-SHA1 of the full url followed by the sorted names and values of the posted parameters.
+func verifyTwilioSignature(authToken, callbackURL string, params url.Values, signature string) bool {
+	if signature == "" {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, twilioSignature(authToken, callbackURL, params))
+}
+
+func twilioSignature(authToken, callbackURL string, params url.Values) []byte {
+	var data strings.Builder
+	data.WriteString(callbackURL)
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		values := slices.Clone(params[key])
+		slices.Sort(values)
+		for _, value := range values {
+			data.WriteString(key)
+			data.WriteString(value)
+		}
+	}
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+	return mac.Sum(nil)
+}
+
+// twilioStatus maps the final message status of Twilio to the delivery status,
+// intermediate states like queued or sent are not reported.
+func twilioStatus(params url.Values) (domain.NotificationDeliveryStatus, string) {
+	switch params.Get("MessageStatus") {
+	case "delivered", "read":
+		return domain.NotificationDeliveryStatusDelivered, ""
+	case "undelivered", "failed":
+		reason := params.Get("MessageStatus")
+		if code := params.Get("ErrorCode"); code != "" {
+			reason += " (error code " + code + ")"
+		}
+		return domain.NotificationDeliveryStatusBounced, reason
+	default:
+		return domain.NotificationDeliveryStatusUnspecified, ""
+	}
+}
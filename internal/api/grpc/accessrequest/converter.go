@@ -0,0 +1,94 @@
+package accessrequest
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	accessrequest_pb "github.com/zitadel/zitadel/pkg/grpc/accessrequest"
+)
+
+func AccessRequestsToPb(requests []*query.AccessRequest) []*accessrequest_pb.AccessRequest {
+	r := make([]*accessrequest_pb.AccessRequest, len(requests))
+	for i, request := range requests {
+		r[i] = AccessRequestToPb(request)
+	}
+	return r
+}
+
+func AccessRequestToPb(request *query.AccessRequest) *accessrequest_pb.AccessRequest {
+	return &accessrequest_pb.AccessRequest{
+		Id:             request.ID,
+		State:          AccessRequestStateToPb(request.State),
+		UserId:         request.UserID,
+		ProjectId:      request.ProjectID,
+		ProjectGrantId: request.ProjectGrantID,
+		RoleKeys:       request.RoleKeys,
+		Reason:         request.Reason,
+		UserGrantId:    request.UserGrantID,
+		Comment:        request.Comment,
+		DecidedBy:      request.DecidedBy,
+		Details: object.ToViewDetailsPb(
+			request.Sequence,
+			request.CreationDate,
+			request.ChangeDate,
+			request.ResourceOwner,
+		),
+	}
+}
+
+func AccessRequestStateToPb(state domain.AccessRequestState) accessrequest_pb.AccessRequestState {
+	switch state {
+	case domain.AccessRequestStatePending:
+		return accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_PENDING
+	case domain.AccessRequestStateApproved:
+		return accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_APPROVED
+	case domain.AccessRequestStateDenied:
+		return accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_DENIED
+	case domain.AccessRequestStateWithdrawn:
+		return accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_WITHDRAWN
+	default:
+		return accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_UNSPECIFIED
+	}
+}
+
+func AccessRequestStateToDomain(state accessrequest_pb.AccessRequestState) domain.AccessRequestState {
+	switch state {
+	case accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_PENDING:
+		return domain.AccessRequestStatePending
+	case accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_APPROVED:
+		return domain.AccessRequestStateApproved
+	case accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_DENIED:
+		return domain.AccessRequestStateDenied
+	case accessrequest_pb.AccessRequestState_ACCESS_REQUEST_STATE_WITHDRAWN:
+		return domain.AccessRequestStateWithdrawn
+	default:
+		return domain.AccessRequestStateUnspecified
+	}
+}
+
+func AccessRequestQueriesToModel(queries []*accessrequest_pb.AccessRequestQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = AccessRequestQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func AccessRequestQueryToModel(requestQuery *accessrequest_pb.AccessRequestQuery) (query.SearchQuery, error) {
+	switch q := requestQuery.Query.(type) {
+	case *accessrequest_pb.AccessRequestQuery_UserIdQuery:
+		return query.NewAccessRequestUserIDSearchQuery(q.UserIdQuery.UserId)
+	case *accessrequest_pb.AccessRequestQuery_ProjectIdQuery:
+		return query.NewAccessRequestProjectIDSearchQuery(q.ProjectIdQuery.ProjectId)
+	case *accessrequest_pb.AccessRequestQuery_ProjectGrantIdQuery:
+		return query.NewAccessRequestProjectGrantIDSearchQuery(q.ProjectGrantIdQuery.ProjectGrantId)
+	case *accessrequest_pb.AccessRequestQuery_StateQuery:
+		return query.NewAccessRequestStateSearchQuery(AccessRequestStateToDomain(q.StateQuery.State))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "ACCREQ-Qn4vd", "List.Query.Invalid")
+	}
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	member_grpc "github.com/zitadel/zitadel/internal/api/grpc/member"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListCustomRoles(ctx context.Context, req *admin_pb.ListCustomRolesRequest) (*admin_pb.ListCustomRolesResponse, error) {
+	queries, err := listCustomRolesRequestToModel(req, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.query.SearchCustomRoles(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListCustomRolesResponse{
+		Result:  member_grpc.CustomRolesToPb(roles.CustomRoles),
+		Details: object_grpc.ToListDetails(roles.Count, roles.Sequence, roles.LastRun),
+	}, nil
+}
+
+func (s *Server) AddCustomRole(ctx context.Context, req *admin_pb.AddCustomRoleRequest) (*admin_pb.AddCustomRoleResponse, error) {
+	details, err := s.command.AddInstanceCustomRole(ctx, &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddCustomRoleResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateCustomRole(ctx context.Context, req *admin_pb.UpdateCustomRoleRequest) (*admin_pb.UpdateCustomRoleResponse, error) {
+	details, err := s.command.ChangeInstanceCustomRole(ctx, req.Key, req.DisplayName, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateCustomRoleResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveCustomRole(ctx context.Context, req *admin_pb.RemoveCustomRoleRequest) (*admin_pb.RemoveCustomRoleResponse, error) {
+	details, err := s.command.RemoveInstanceCustomRole(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveCustomRoleResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func listCustomRolesRequestToModel(req *admin_pb.ListCustomRolesRequest, instanceID string) (*query.CustomRoleSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := member_grpc.CustomRoleQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewCustomRoleResourceOwnerSearchQuery(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.CustomRoleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
@@ -444,6 +444,294 @@ func (s *Server) ResetCustomInviteUserMessageTextToDefault(ctx context.Context,
 	}, nil
 }
 
+func (s *Server) GetDefaultNewDeviceSignInMessageText(ctx context.Context, req *admin_pb.GetDefaultNewDeviceSignInMessageTextRequest) (*admin_pb.GetDefaultNewDeviceSignInMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.NewDeviceSignInMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultNewDeviceSignInMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomNewDeviceSignInMessageText(ctx context.Context, req *admin_pb.GetCustomNewDeviceSignInMessageTextRequest) (*admin_pb.GetCustomNewDeviceSignInMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.NewDeviceSignInMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomNewDeviceSignInMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultNewDeviceSignInMessageText(ctx context.Context, req *admin_pb.SetDefaultNewDeviceSignInMessageTextRequest) (*admin_pb.SetDefaultNewDeviceSignInMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetNewDeviceSignInCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultNewDeviceSignInMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomNewDeviceSignInMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomNewDeviceSignInMessageTextToDefaultRequest) (*admin_pb.ResetCustomNewDeviceSignInMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.NewDeviceSignInMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomNewDeviceSignInMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFAAddedMessageTextRequest) (*admin_pb.GetDefaultMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFAAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFAAddedMessageText(ctx context.Context, req *admin_pb.GetCustomMFAAddedMessageTextRequest) (*admin_pb.GetCustomMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFAAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFAAddedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFAAddedMessageTextRequest) (*admin_pb.SetDefaultMFAAddedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFAAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFAAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFAAddedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFAAddedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFAAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFAAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.GetDefaultMFARemovedMessageTextRequest) (*admin_pb.GetDefaultMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.MFARemovedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomMFARemovedMessageText(ctx context.Context, req *admin_pb.GetCustomMFARemovedMessageTextRequest) (*admin_pb.GetCustomMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.MFARemovedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultMFARemovedMessageText(ctx context.Context, req *admin_pb.SetDefaultMFARemovedMessageTextRequest) (*admin_pb.SetDefaultMFARemovedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetMFARemovedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultMFARemovedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFARemovedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomMFARemovedMessageTextToDefaultRequest) (*admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.MFARemovedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomMFARemovedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultEmailChangeMessageText(ctx context.Context, req *admin_pb.GetDefaultEmailChangeMessageTextRequest) (*admin_pb.GetDefaultEmailChangeMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.EmailChangeMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultEmailChangeMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomEmailChangeMessageText(ctx context.Context, req *admin_pb.GetCustomEmailChangeMessageTextRequest) (*admin_pb.GetCustomEmailChangeMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.EmailChangeMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomEmailChangeMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultEmailChangeMessageText(ctx context.Context, req *admin_pb.SetDefaultEmailChangeMessageTextRequest) (*admin_pb.SetDefaultEmailChangeMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetEmailChangeCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultEmailChangeMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomEmailChangeMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomEmailChangeMessageTextToDefaultRequest) (*admin_pb.ResetCustomEmailChangeMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.EmailChangeMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomEmailChangeMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultAccountLockedMessageText(ctx context.Context, req *admin_pb.GetDefaultAccountLockedMessageTextRequest) (*admin_pb.GetDefaultAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.AccountLockedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomAccountLockedMessageText(ctx context.Context, req *admin_pb.GetCustomAccountLockedMessageTextRequest) (*admin_pb.GetCustomAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.AccountLockedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultAccountLockedMessageText(ctx context.Context, req *admin_pb.SetDefaultAccountLockedMessageTextRequest) (*admin_pb.SetDefaultAccountLockedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetAccountLockedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultAccountLockedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomAccountLockedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomAccountLockedMessageTextToDefaultRequest) (*admin_pb.ResetCustomAccountLockedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.AccountLockedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomAccountLockedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetDefaultPersonalAccessTokenAddedMessageText(ctx context.Context, req *admin_pb.GetDefaultPersonalAccessTokenAddedMessageTextRequest) (*admin_pb.GetDefaultPersonalAccessTokenAddedMessageTextResponse, error) {
+	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PersonalAccessTokenAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetDefaultPersonalAccessTokenAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetCustomPersonalAccessTokenAddedMessageText(ctx context.Context, req *admin_pb.GetCustomPersonalAccessTokenAddedMessageTextRequest) (*admin_pb.GetCustomPersonalAccessTokenAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetInstance(ctx).InstanceID(), domain.PersonalAccessTokenAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetCustomPersonalAccessTokenAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetDefaultPersonalAccessTokenAddedMessageText(ctx context.Context, req *admin_pb.SetDefaultPersonalAccessTokenAddedMessageTextRequest) (*admin_pb.SetDefaultPersonalAccessTokenAddedMessageTextResponse, error) {
+	result, err := s.command.SetDefaultMessageText(ctx, authz.GetInstance(ctx).InstanceID(), SetPersonalAccessTokenAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetDefaultPersonalAccessTokenAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomPersonalAccessTokenAddedMessageTextToDefault(ctx context.Context, req *admin_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultRequest) (*admin_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveInstanceMessageTexts(ctx, domain.PersonalAccessTokenAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetDefaultPasswordlessRegistrationMessageText(ctx context.Context, req *admin_pb.GetDefaultPasswordlessRegistrationMessageTextRequest) (*admin_pb.GetDefaultPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.DefaultMessageTextByTypeAndLanguageFromFileSystem(ctx, domain.PasswordlessRegistrationMessageType, req.Language)
 	if err != nil {
@@ -90,6 +90,57 @@ func (s *Server) UpdateEmailProviderSMTPPassword(ctx context.Context, req *admin
 	}, nil
 }
 
+func (s *Server) SetEmailProviderSMTPXOAuth2(ctx context.Context, req *admin_pb.SetEmailProviderSMTPXOAuth2Request) (*admin_pb.SetEmailProviderSMTPXOAuth2Response, error) {
+	config := setEmailProviderSMTPXOAuth2ToConfig(ctx, req)
+	if err := s.command.SetSMTPConfigXOAuth2(ctx, config); err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetEmailProviderSMTPXOAuth2Response{
+		Details: object.DomainToChangeDetailsPb(config.Details),
+	}, nil
+}
+
+func (s *Server) RemoveEmailProviderSMTPXOAuth2(ctx context.Context, req *admin_pb.RemoveEmailProviderSMTPXOAuth2Request) (*admin_pb.RemoveEmailProviderSMTPXOAuth2Response, error) {
+	details, err := s.command.RemoveSMTPConfigXOAuth2(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveEmailProviderSMTPXOAuth2Response{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetEmailProviderSMTPDKIM(ctx context.Context, req *admin_pb.SetEmailProviderSMTPDKIMRequest) (*admin_pb.SetEmailProviderSMTPDKIMResponse, error) {
+	config := setEmailProviderSMTPDKIMToConfig(ctx, req)
+	if err := s.command.SetSMTPConfigDKIM(ctx, config); err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetEmailProviderSMTPDKIMResponse{
+		Details: object.DomainToChangeDetailsPb(config.Details),
+	}, nil
+}
+
+func (s *Server) RemoveEmailProviderSMTPDKIM(ctx context.Context, req *admin_pb.RemoveEmailProviderSMTPDKIMRequest) (*admin_pb.RemoveEmailProviderSMTPDKIMResponse, error) {
+	details, err := s.command.RemoveSMTPConfigDKIM(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveEmailProviderSMTPDKIMResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GenerateEmailProviderDeliverySecret(ctx context.Context, req *admin_pb.GenerateEmailProviderDeliverySecretRequest) (*admin_pb.GenerateEmailProviderDeliverySecretResponse, error) {
+	secret, details, err := s.command.GenerateSMTPConfigDeliverySecret(ctx, authz.GetInstance(ctx).InstanceID(), req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GenerateEmailProviderDeliverySecretResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+		Secret:  secret,
+	}, nil
+}
+
 func (s *Server) ListEmailProviders(ctx context.Context, req *admin_pb.ListEmailProvidersRequest) (*admin_pb.ListEmailProvidersResponse, error) {
 	queries, err := listEmailProvidersToModel(req)
 	if err != nil {
@@ -81,10 +81,33 @@ func smtpToPb(config *query.SMTP) *settings_pb.EmailProvider_Smtp {
 			User:          config.User,
 			SenderAddress: config.SenderAddress,
 			SenderName:    config.SenderName,
+			Xoauth2:       smtpXOAuth2ToPb(config.XOAuth2),
+			Dkim:          smtpDKIMToPb(config.DKIM),
 		},
 	}
 }
 
+func smtpXOAuth2ToPb(xoauth2 *query.SMTPXOAuth2) *settings_pb.EmailProviderSMTPXOAuth2 {
+	if xoauth2 == nil {
+		return nil
+	}
+	return &settings_pb.EmailProviderSMTPXOAuth2{
+		TokenEndpoint: xoauth2.TokenEndpoint,
+		ClientId:      xoauth2.ClientID,
+		Scopes:        xoauth2.Scopes,
+	}
+}
+
+func smtpDKIMToPb(dkim *query.SMTPDKIM) *settings_pb.EmailProviderSMTPDKIM {
+	if dkim == nil {
+		return nil
+	}
+	return &settings_pb.EmailProviderSMTPDKIM{
+		Domain:   dkim.Domain,
+		Selector: dkim.Selector,
+	}
+}
+
 func addEmailProviderSMTPToConfig(ctx context.Context, req *admin_pb.AddEmailProviderSMTPRequest) *command.AddSMTPConfig {
 	return &command.AddSMTPConfig{
 		ResourceOwner:  authz.GetInstance(ctx).InstanceID(),
@@ -114,6 +137,27 @@ func updateEmailProviderSMTPToConfig(ctx context.Context, req *admin_pb.UpdateEm
 	}
 }
 
+func setEmailProviderSMTPXOAuth2ToConfig(ctx context.Context, req *admin_pb.SetEmailProviderSMTPXOAuth2Request) *command.SetSMTPConfigXOAuth2 {
+	return &command.SetSMTPConfigXOAuth2{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		ID:            req.Id,
+		TokenEndpoint: req.TokenEndpoint,
+		ClientID:      req.ClientId,
+		ClientSecret:  req.ClientSecret,
+		Scopes:        req.Scopes,
+	}
+}
+
+func setEmailProviderSMTPDKIMToConfig(ctx context.Context, req *admin_pb.SetEmailProviderSMTPDKIMRequest) *command.SetSMTPConfigDKIM {
+	return &command.SetSMTPConfigDKIM{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		ID:            req.Id,
+		Domain:        req.Domain,
+		Selector:      req.Selector,
+		PrivateKey:    req.PrivateKey,
+	}
+}
+
 func addEmailProviderHTTPToConfig(ctx context.Context, req *admin_pb.AddEmailProviderHTTPRequest) *command.AddSMTPConfigHTTP {
 	return &command.AddSMTPConfigHTTP{
 		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
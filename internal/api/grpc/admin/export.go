@@ -767,12 +767,13 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 		if err != nil {
 			return nil, nil, nil, nil, nil, err
 		}
-		for _, role := range queriedProjectRoles.ProjectRoles {
+		for _, role := range projectRolesInImportOrder(queriedProjectRoles.ProjectRoles) {
 			orgProjectRoles = append(orgProjectRoles, &management_pb.AddProjectRoleRequest{
-				ProjectId:   role.ProjectID,
-				RoleKey:     role.Key,
-				DisplayName: role.DisplayName,
-				Group:       role.Group,
+				ProjectId:     role.ProjectID,
+				RoleKey:       role.Key,
+				DisplayName:   role.DisplayName,
+				Group:         role.Group,
+				IncludedRoles: role.IncludedRoles,
 			})
 		}
 
@@ -1268,3 +1269,31 @@ func (s *Server) getCustomPasswordlessRegistrationMessageTexts(ctx context.Conte
 
 	return customTexts, nil
 }
+
+// projectRolesInImportOrder orders the roles so that included roles are exported before the roles including them,
+// as the import adds the roles one by one.
+func projectRolesInImportOrder(roles []*query.ProjectRole) []*query.ProjectRole {
+	byKey := make(map[string]*query.ProjectRole, len(roles))
+	for _, role := range roles {
+		byKey[role.Key] = role
+	}
+	ordered := make([]*query.ProjectRole, 0, len(roles))
+	visited := make(map[string]bool, len(roles))
+	var visit func(role *query.ProjectRole)
+	visit = func(role *query.ProjectRole) {
+		if visited[role.Key] {
+			return
+		}
+		visited[role.Key] = true
+		for _, key := range role.IncludedRoles {
+			if included, ok := byKey[key]; ok {
+				visit(included)
+			}
+		}
+		ordered = append(ordered, role)
+	}
+	for _, role := range roles {
+		visit(role)
+	}
+	return ordered
+}
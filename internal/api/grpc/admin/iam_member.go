@@ -11,6 +11,11 @@ import (
 
 func (s *Server) ListIAMMemberRoles(ctx context.Context, req *admin_pb.ListIAMMemberRolesRequest) (*admin_pb.ListIAMMemberRolesResponse, error) {
 	roles := s.query.GetIAMMemberRoles()
+	customRoles, err := s.query.GetCustomIAMMemberRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles = append(roles, customRoles...)
 	return &admin_pb.ListIAMMemberRolesResponse{
 		Roles:   roles,
 		Details: object.ToListDetails(uint64(len(roles)), 0, time.Now()),
@@ -466,3 +466,90 @@ func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProvide
 		Details: object_pb.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) GetProviderGroupMappings(ctx context.Context, req *admin_pb.GetProviderGroupMappingsRequest) (*admin_pb.GetProviderGroupMappingsResponse, error) {
+	instanceIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, instanceIDQuery); err != nil {
+		return nil, err
+	}
+	mappings, err := s.query.IDPGroupMappingsByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderGroupMappingsResponse{
+		Details:  object_pb.ToViewDetailsPb(mappings.Sequence, mappings.CreationDate, mappings.EventDate, mappings.ResourceOwner),
+		Mappings: idp_grpc.GroupMappingsToPb(mappings.Mappings),
+	}, nil
+}
+
+func (s *Server) SetProviderGroupMappings(ctx context.Context, req *admin_pb.SetProviderGroupMappingsRequest) (*admin_pb.SetProviderGroupMappingsResponse, error) {
+	details, err := s.command.SetInstanceIDPGroupMappings(ctx, req.Id, idp_grpc.GroupMappingsToDomain(req.Mappings))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderGroupMappingsResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderFederatedLogout(ctx context.Context, req *admin_pb.GetProviderFederatedLogoutRequest) (*admin_pb.GetProviderFederatedLogoutResponse, error) {
+	instanceIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, instanceIDQuery); err != nil {
+		return nil, err
+	}
+	logout, err := s.query.IDPFederatedLogoutByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderFederatedLogoutResponse{
+		Details: object_pb.ToViewDetailsPb(logout.Sequence, logout.CreationDate, logout.EventDate, logout.ResourceOwner),
+		Enabled: logout.Enabled,
+	}, nil
+}
+
+func (s *Server) SetProviderFederatedLogout(ctx context.Context, req *admin_pb.SetProviderFederatedLogoutRequest) (*admin_pb.SetProviderFederatedLogoutResponse, error) {
+	details, err := s.command.SetInstanceIDPFederatedLogout(ctx, req.Id, req.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderFederatedLogoutResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderTokenExchangeClients(ctx context.Context, req *admin_pb.GetProviderTokenExchangeClientsRequest) (*admin_pb.GetProviderTokenExchangeClientsResponse, error) {
+	instanceIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, instanceIDQuery); err != nil {
+		return nil, err
+	}
+	clients, err := s.query.IDPTokenExchangeClientsByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetProviderTokenExchangeClientsResponse{
+		Details:   object_pb.ToViewDetailsPb(clients.Sequence, clients.CreationDate, clients.EventDate, clients.ResourceOwner),
+		ClientIds: clients.ClientIDs,
+	}, nil
+}
+
+func (s *Server) SetProviderTokenExchangeClients(ctx context.Context, req *admin_pb.SetProviderTokenExchangeClientsRequest) (*admin_pb.SetProviderTokenExchangeClientsResponse, error) {
+	details, err := s.command.SetInstanceIDPTokenExchangeClients(ctx, req.Id, req.ClientIds)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderTokenExchangeClientsResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
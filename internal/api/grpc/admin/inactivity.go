@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetInactivityPolicy(ctx context.Context, req *admin_pb.GetInactivityPolicyRequest) (*admin_pb.GetInactivityPolicyResponse, error) {
+	policy, err := s.query.DefaultInactivityPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetInactivityPolicyResponse{Policy: policy_grpc.ModelInactivityPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateInactivityPolicy(ctx context.Context, req *admin_pb.UpdateInactivityPolicyRequest) (*admin_pb.UpdateInactivityPolicyResponse, error) {
+	policy, err := s.command.SetDefaultInactivityPolicy(ctx, UpdateInactivityPolicyToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateInactivityPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
@@ -0,0 +1,14 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func UpdateInactivityPolicyToDomain(p *admin.UpdateInactivityPolicyRequest) *domain.InactivityPolicy {
+	return &domain.InactivityPolicy{
+		InactivityPeriod: p.GetInactivityPeriod().AsDuration(),
+		WarningPeriod:    p.GetWarningPeriod().AsDuration(),
+		DryRun:           p.DryRun,
+	}
+}
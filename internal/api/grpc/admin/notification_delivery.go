@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/notificationdelivery"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ListNotificationDeliveries(ctx context.Context, req *admin_pb.ListNotificationDeliveriesRequest) (*admin_pb.ListNotificationDeliveriesResponse, error) {
+	queries, err := listNotificationDeliveriesToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchNotificationDeliveries(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListNotificationDeliveriesResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.LastRun),
+		Result:  notificationdelivery.NotificationDeliveriesToPb(result.NotificationDeliveries),
+	}, nil
+}
+
+func (s *Server) GetNotificationDelivery(ctx context.Context, req *admin_pb.GetNotificationDeliveryRequest) (*admin_pb.GetNotificationDeliveryResponse, error) {
+	delivery, err := s.query.NotificationDeliveryByID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetNotificationDeliveryResponse{
+		Delivery: notificationdelivery.NotificationDeliveryToPb(delivery),
+	}, nil
+}
+
+// ReportNotificationDelivery is called by relays of HTTP providers, which might only know the message id of the provider.
+// Twilio, SendGrid and Amazon SES report to their own callbacks in the delivery package.
+func (s *Server) ReportNotificationDelivery(ctx context.Context, req *admin_pb.ReportNotificationDeliveryRequest) (*admin_pb.ReportNotificationDeliveryResponse, error) {
+	id := req.GetId()
+	if providerMessageID := req.GetProviderMessageId(); providerMessageID != "" {
+		delivery, err := s.query.NotificationDeliveryByProviderMessageID(ctx, true, providerMessageID)
+		if err != nil {
+			return nil, err
+		}
+		id = delivery.ID
+	}
+	details, err := s.command.ReportNotificationDelivery(ctx, id, notificationdelivery.NotificationDeliveryStatusToDomain(req.Status), req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ReportNotificationDeliveryResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func listNotificationDeliveriesToModel(req *admin_pb.ListNotificationDeliveriesRequest) (*query.NotificationDeliverySearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := notificationdelivery.NotificationDeliveryQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.NotificationDeliverySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func AddNotificationPolicyToDomain(req *admin_pb.AddNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceSignIn:          req.GetNewDeviceSignIn(),
+		MFAAdded:                 req.GetMfaAdded(),
+		MFARemoved:               req.GetMfaRemoved(),
+		EmailChange:              req.GetEmailChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
+
+func UpdateNotificationPolicyToDomain(req *admin_pb.UpdateNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceSignIn:          req.GetNewDeviceSignIn(),
+		MFAAdded:                 req.GetMfaAdded(),
+		MFARemoved:               req.GetMfaRemoved(),
+		EmailChange:              req.GetEmailChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
@@ -65,6 +65,68 @@ func (s *Server) UpdateSMSProviderTwilioToken(ctx context.Context, req *admin_pb
 	}, nil
 }
 
+func (s *Server) AddSMSProviderVonage(ctx context.Context, req *admin_pb.AddSMSProviderVonageRequest) (*admin_pb.AddSMSProviderVonageResponse, error) {
+	smsConfig := addSMSConfigVonageToConfig(ctx, req)
+	if err := s.command.AddSMSConfigVonage(ctx, smsConfig); err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSMSProviderVonageResponse{
+		Details: object.DomainToAddDetailsPb(smsConfig.Details),
+		Id:      smsConfig.ID,
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderVonage(ctx context.Context, req *admin_pb.UpdateSMSProviderVonageRequest) (*admin_pb.UpdateSMSProviderVonageResponse, error) {
+	smsConfig := updateSMSConfigVonageToConfig(ctx, req)
+	if err := s.command.ChangeSMSConfigVonage(ctx, smsConfig); err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderVonageResponse{
+		Details: object.DomainToChangeDetailsPb(smsConfig.Details),
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderVonageAPISecret(ctx context.Context, req *admin_pb.UpdateSMSProviderVonageAPISecretRequest) (*admin_pb.UpdateSMSProviderVonageAPISecretResponse, error) {
+	result, err := s.command.ChangeSMSConfigVonageAPISecret(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.ApiSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderVonageAPISecretResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
+func (s *Server) AddSMSProviderMessageBird(ctx context.Context, req *admin_pb.AddSMSProviderMessageBirdRequest) (*admin_pb.AddSMSProviderMessageBirdResponse, error) {
+	smsConfig := addSMSConfigMessageBirdToConfig(ctx, req)
+	if err := s.command.AddSMSConfigMessageBird(ctx, smsConfig); err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddSMSProviderMessageBirdResponse{
+		Details: object.DomainToAddDetailsPb(smsConfig.Details),
+		Id:      smsConfig.ID,
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderMessageBird(ctx context.Context, req *admin_pb.UpdateSMSProviderMessageBirdRequest) (*admin_pb.UpdateSMSProviderMessageBirdResponse, error) {
+	smsConfig := updateSMSConfigMessageBirdToConfig(ctx, req)
+	if err := s.command.ChangeSMSConfigMessageBird(ctx, smsConfig); err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderMessageBirdResponse{
+		Details: object.DomainToChangeDetailsPb(smsConfig.Details),
+	}, nil
+}
+
+func (s *Server) UpdateSMSProviderMessageBirdAccessKey(ctx context.Context, req *admin_pb.UpdateSMSProviderMessageBirdAccessKeyRequest) (*admin_pb.UpdateSMSProviderMessageBirdAccessKeyResponse, error) {
+	result, err := s.command.ChangeSMSConfigMessageBirdAccessKey(ctx, authz.GetInstance(ctx).InstanceID(), req.Id, req.AccessKey)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateSMSProviderMessageBirdAccessKeyResponse{
+		Details: object.DomainToChangeDetailsPb(result),
+	}, nil
+}
+
 func (s *Server) AddSMSProviderHTTP(ctx context.Context, req *admin_pb.AddSMSProviderHTTPRequest) (*admin_pb.AddSMSProviderHTTPResponse, error) {
 	smsConfig := addSMSConfigHTTPToConfig(ctx, req)
 	if err := s.command.AddSMSConfigHTTP(ctx, smsConfig); err != nil {
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/muhlemmer/gu"
 
@@ -47,6 +48,12 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 	if config.TwilioConfig != nil {
 		return TwilioConfigToPb(config.TwilioConfig)
 	}
+	if config.VonageConfig != nil {
+		return VonageConfigToPb(config.VonageConfig)
+	}
+	if config.MessageBirdConfig != nil {
+		return MessageBirdConfigToPb(config.MessageBirdConfig)
+	}
 	if config.HTTPConfig != nil {
 		return HTTPConfigToPb(config.HTTPConfig)
 	}
@@ -56,7 +63,9 @@ func SMSConfigToPb(config *query.SMSConfig) settings_pb.SMSConfig {
 func HTTPConfigToPb(http *query.HTTP) *settings_pb.SMSProvider_Http {
 	return &settings_pb.SMSProvider_Http{
 		Http: &settings_pb.HTTPConfig{
-			Endpoint: http.Endpoint,
+			Endpoint:    http.Endpoint,
+			Template:    http.Template,
+			ContentType: http.ContentType,
 		},
 	}
 }
@@ -71,6 +80,25 @@ func TwilioConfigToPb(twilio *query.Twilio) *settings_pb.SMSProvider_Twilio {
 	}
 }
 
+func VonageConfigToPb(vonage *query.Vonage) *settings_pb.SMSProvider_Vonage {
+	return &settings_pb.SMSProvider_Vonage{
+		Vonage: &settings_pb.VonageConfig{
+			ApiKey:       vonage.APIKey,
+			SenderNumber: vonage.SenderNumber,
+			VerifyBrand:  vonage.VerifyBrand,
+		},
+	}
+}
+
+func MessageBirdConfigToPb(messageBird *query.MessageBird) *settings_pb.SMSProvider_MessageBird {
+	return &settings_pb.SMSProvider_MessageBird{
+		MessageBird: &settings_pb.MessageBirdConfig{
+			Originator: messageBird.Originator,
+			Verify:     messageBird.Verify,
+		},
+	}
+}
+
 func smsStateToPb(state domain.SMSConfigState) settings_pb.SMSProviderConfigState {
 	switch state {
 	case domain.SMSConfigStateInactive:
@@ -109,6 +137,9 @@ func addSMSConfigHTTPToConfig(ctx context.Context, req *admin_pb.AddSMSProviderH
 		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
 		Description:   req.GetDescription(),
 		Endpoint:      req.GetEndpoint(),
+		Template:      req.GetTemplate(),
+		ContentType:   req.GetContentType(),
+		Headers:       smsHTTPHeadersToCommand(req.GetHeaders()),
 	}
 }
 
@@ -118,5 +149,73 @@ func updateSMSConfigHTTPToConfig(ctx context.Context, req *admin_pb.UpdateSMSPro
 		ID:            req.Id,
 		Description:   gu.Ptr(req.Description),
 		Endpoint:      gu.Ptr(req.Endpoint),
+		Template:      req.Template,
+		ContentType:   req.ContentType,
+		Headers:       updateSMSHTTPHeadersToCommand(req.Headers),
+	}
+}
+
+// updateSMSHTTPHeadersToCommand returns nil if the headers are not changed and an empty header if they are removed
+func updateSMSHTTPHeadersToCommand(headers *admin_pb.SMSProviderHTTPHeaders) http.Header {
+	if headers == nil {
+		return nil
+	}
+	header := smsHTTPHeadersToCommand(headers.GetHeaders())
+	if header == nil {
+		return http.Header{}
+	}
+	return header
+}
+
+func smsHTTPHeadersToCommand(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	header := make(http.Header, len(headers))
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+	return header
+}
+
+func addSMSConfigVonageToConfig(ctx context.Context, req *admin_pb.AddSMSProviderVonageRequest) *command.AddVonageConfig {
+	return &command.AddVonageConfig{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		Description:   req.Description,
+		APIKey:        req.ApiKey,
+		APISecret:     req.ApiSecret,
+		SenderNumber:  req.SenderNumber,
+		VerifyBrand:   req.VerifyBrand,
+	}
+}
+
+func updateSMSConfigVonageToConfig(ctx context.Context, req *admin_pb.UpdateSMSProviderVonageRequest) *command.ChangeVonageConfig {
+	return &command.ChangeVonageConfig{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		ID:            req.Id,
+		Description:   gu.Ptr(req.Description),
+		APIKey:        gu.Ptr(req.ApiKey),
+		SenderNumber:  gu.Ptr(req.SenderNumber),
+		VerifyBrand:   gu.Ptr(req.VerifyBrand),
+	}
+}
+
+func addSMSConfigMessageBirdToConfig(ctx context.Context, req *admin_pb.AddSMSProviderMessageBirdRequest) *command.AddMessageBirdConfig {
+	return &command.AddMessageBirdConfig{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		Description:   req.Description,
+		AccessKey:     req.AccessKey,
+		Originator:    req.Originator,
+		Verify:        req.Verify,
+	}
+}
+
+func updateSMSConfigMessageBirdToConfig(ctx context.Context, req *admin_pb.UpdateSMSProviderMessageBirdRequest) *command.ChangeMessageBirdConfig {
+	return &command.ChangeMessageBirdConfig{
+		ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		ID:            req.Id,
+		Description:   gu.Ptr(req.Description),
+		Originator:    gu.Ptr(req.Originator),
+		Verify:        gu.Ptr(req.Verify),
 	}
 }
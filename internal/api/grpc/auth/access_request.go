@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	accessrequest_grpc "github.com/zitadel/zitadel/internal/api/grpc/accessrequest"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	auth_pb "github.com/zitadel/zitadel/pkg/grpc/auth"
+)
+
+func (s *Server) AddMyAccessRequest(ctx context.Context, req *auth_pb.AddMyAccessRequestRequest) (*auth_pb.AddMyAccessRequestResponse, error) {
+	ctxData := authz.GetCtxData(ctx)
+	resourceOwner, projectGrantID, err := s.query.UserGrantTargetOfOrg(ctx, req.ProjectId, ctxData.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	request := &domain.AccessRequest{
+		UserID:         ctxData.UserID,
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: projectGrantID,
+		RoleKeys:       req.RoleKeys,
+		Reason:         req.Reason,
+	}
+	details, err := s.command.AddAccessRequest(ctx, request, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.AddMyAccessRequestResponse{
+		Id:      request.AggregateID,
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListMyAccessRequests(ctx context.Context, req *auth_pb.ListMyAccessRequestsRequest) (*auth_pb.ListMyAccessRequestsResponse, error) {
+	queries, err := ListMyAccessRequestsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchAccessRequests(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.ListMyAccessRequestsResponse{
+		Result:  accessrequest_grpc.AccessRequestsToPb(res.AccessRequests),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
+func (s *Server) WithdrawMyAccessRequest(ctx context.Context, req *auth_pb.WithdrawMyAccessRequestRequest) (*auth_pb.WithdrawMyAccessRequestResponse, error) {
+	details, err := s.command.WithdrawAccessRequest(ctx, req.Id, authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &auth_pb.WithdrawMyAccessRequestResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func ListMyAccessRequestsRequestToQuery(ctx context.Context, req *auth_pb.ListMyAccessRequestsRequest) (*query.AccessRequestSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	userIDQuery, err := query.NewAccessRequestUserIDSearchQuery(authz.GetCtxData(ctx).UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.AccessRequestSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			userIDQuery,
+		},
+	}, nil
+}
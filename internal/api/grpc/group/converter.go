@@ -0,0 +1,118 @@
+package group
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	group_pb "github.com/zitadel/zitadel/pkg/grpc/group"
+)
+
+func GroupsToPb(groups []*query.Group) []*group_pb.Group {
+	g := make([]*group_pb.Group, len(groups))
+	for i, group := range groups {
+		g[i] = GroupToPb(group)
+	}
+	return g
+}
+
+func GroupToPb(group *query.Group) *group_pb.Group {
+	return &group_pb.Group{
+		Id:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		Details: object.ToViewDetailsPb(
+			group.Sequence,
+			group.CreationDate,
+			group.EventDate,
+			group.ResourceOwner,
+		),
+	}
+}
+
+func GroupMembersToPb(members []*query.GroupMember) []*group_pb.GroupMember {
+	m := make([]*group_pb.GroupMember, len(members))
+	for i, member := range members {
+		m[i] = GroupMemberToPb(member)
+	}
+	return m
+}
+
+func GroupMemberToPb(member *query.GroupMember) *group_pb.GroupMember {
+	return &group_pb.GroupMember{
+		GroupId:           member.GroupID,
+		UserId:            member.UserID,
+		UserResourceOwner: member.UserResourceOwner,
+		Details: object.ToViewDetailsPb(
+			member.Sequence,
+			member.CreationDate,
+			member.CreationDate,
+			member.ResourceOwner,
+		),
+	}
+}
+
+func GroupGrantsToPb(grants []*query.GroupGrant) []*group_pb.GroupGrant {
+	g := make([]*group_pb.GroupGrant, len(grants))
+	for i, grant := range grants {
+		g[i] = GroupGrantToPb(grant)
+	}
+	return g
+}
+
+func GroupGrantToPb(grant *query.GroupGrant) *group_pb.GroupGrant {
+	return &group_pb.GroupGrant{
+		Id:             grant.ID,
+		GroupId:        grant.GroupID,
+		ProjectId:      grant.ProjectID,
+		ProjectGrantId: grant.ProjectGrantID,
+		RoleKeys:       grant.RoleKeys,
+		Details: object.ToViewDetailsPb(
+			grant.Sequence,
+			grant.CreationDate,
+			grant.ChangeDate,
+			grant.ResourceOwner,
+		),
+	}
+}
+
+func GroupQueriesToModel(queries []*group_pb.GroupQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = GroupQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func GroupQueryToModel(groupQuery *group_pb.GroupQuery) (query.SearchQuery, error) {
+	switch q := groupQuery.Query.(type) {
+	case *group_pb.GroupQuery_NameQuery:
+		return query.NewGroupNameSearchQuery(object.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
+	case *group_pb.GroupQuery_IdsQuery:
+		return query.NewGroupInIDsSearchQuery(q.IdsQuery.Ids)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GROUP-Nq3vd", "List.Query.Invalid")
+	}
+}
+
+func GroupGrantQueriesToModel(queries []*group_pb.GroupGrantQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = GroupGrantQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func GroupGrantQueryToModel(grantQuery *group_pb.GroupGrantQuery) (query.SearchQuery, error) {
+	switch q := grantQuery.Query.(type) {
+	case *group_pb.GroupGrantQuery_ProjectIdQuery:
+		return query.NewGroupGrantProjectIDSearchQuery(q.ProjectIdQuery.ProjectId)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GROUP-Kx8ep", "List.Query.Invalid")
+	}
+}
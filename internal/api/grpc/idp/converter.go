@@ -699,3 +699,33 @@ func nameIDToPb(format domain.SAMLNameIDFormat) idp_pb.SAMLNameIDFormat {
 		return idp_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_UNSPECIFIED
 	}
 }
+
+func GroupMappingsToPb(mappings []*domain.IDPGroupMapping) []*idp_pb.GroupMapping {
+	result := make([]*idp_pb.GroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &idp_pb.GroupMapping{
+			Claim:          mapping.Claim,
+			Group:          mapping.Group,
+			ProjectId:      mapping.ProjectID,
+			ProjectGrantId: mapping.ProjectGrantID,
+			RoleKeys:       mapping.RoleKeys,
+			OrgMemberRoles: mapping.OrgMemberRoles,
+		}
+	}
+	return result
+}
+
+func GroupMappingsToDomain(mappings []*idp_pb.GroupMapping) []*domain.IDPGroupMapping {
+	result := make([]*domain.IDPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &domain.IDPGroupMapping{
+			Claim:          mapping.GetClaim(),
+			Group:          mapping.GetGroup(),
+			ProjectID:      mapping.GetProjectId(),
+			ProjectGrantID: mapping.GetProjectGrantId(),
+			RoleKeys:       mapping.GetRoleKeys(),
+			OrgMemberRoles: mapping.GetOrgMemberRoles(),
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	accessrequest_grpc "github.com/zitadel/zitadel/internal/api/grpc/accessrequest"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListAccessRequests(ctx context.Context, req *mgmt_pb.ListAccessRequestsRequest) (*mgmt_pb.ListAccessRequestsResponse, error) {
+	queries, err := listAccessRequestsRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := s.query.SearchAccessRequests(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListAccessRequestsResponse{
+		Result:  accessrequest_grpc.AccessRequestsToPb(requests.AccessRequests),
+		Details: object_grpc.ToListDetails(requests.Count, requests.Sequence, requests.LastRun),
+	}, nil
+}
+
+func (s *Server) GetAccessRequestByID(ctx context.Context, req *mgmt_pb.GetAccessRequestByIDRequest) (*mgmt_pb.GetAccessRequestByIDResponse, error) {
+	request, err := s.accessRequestOfProject(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAccessRequestByIDResponse{
+		AccessRequest: accessrequest_grpc.AccessRequestToPb(request),
+	}, nil
+}
+
+func (s *Server) ApproveAccessRequest(ctx context.Context, req *mgmt_pb.ApproveAccessRequestRequest) (*mgmt_pb.ApproveAccessRequestResponse, error) {
+	if _, err := s.accessRequestOfProject(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	details, err := s.command.ApproveAccessRequest(ctx, req.Id, authz.GetCtxData(ctx).OrgID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ApproveAccessRequestResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DenyAccessRequest(ctx context.Context, req *mgmt_pb.DenyAccessRequestRequest) (*mgmt_pb.DenyAccessRequestResponse, error) {
+	if _, err := s.accessRequestOfProject(ctx, req.Id); err != nil {
+		return nil, err
+	}
+	details, err := s.command.DenyAccessRequest(ctx, req.Id, authz.GetCtxData(ctx).OrgID, req.Comment)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.DenyAccessRequestResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+// accessRequestOfProject returns the request if the caller is allowed to decide on the requested project,
+// approvers are usually only members of the project or project grant.
+func (s *Server) accessRequestOfProject(ctx context.Context, id string) (*query.AccessRequest, error) {
+	request, err := s.query.AccessRequestByID(ctx, id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkExplicitProjectPermission(ctx, request.ProjectGrantID, request.ProjectID); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func listAccessRequestsRequestToModel(req *mgmt_pb.ListAccessRequestsRequest, resourceOwner string) (*query.AccessRequestSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := accessrequest_grpc.AccessRequestQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewAccessRequestResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.AccessRequestSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	member_grpc "github.com/zitadel/zitadel/internal/api/grpc/member"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListOrgCustomRoles(ctx context.Context, req *mgmt_pb.ListOrgCustomRolesRequest) (*mgmt_pb.ListOrgCustomRolesResponse, error) {
+	queries, err := listOrgCustomRolesRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.query.SearchCustomRoles(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgCustomRolesResponse{
+		Result:  member_grpc.CustomRolesToPb(roles.CustomRoles),
+		Details: object_grpc.ToListDetails(roles.Count, roles.Sequence, roles.LastRun),
+	}, nil
+}
+
+func (s *Server) AddOrgCustomRole(ctx context.Context, req *mgmt_pb.AddOrgCustomRoleRequest) (*mgmt_pb.AddOrgCustomRoleResponse, error) {
+	details, err := s.command.AddOrgCustomRole(ctx, authz.GetCtxData(ctx).OrgID, &domain.CustomRole{
+		Key:         req.Key,
+		DisplayName: req.DisplayName,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOrgCustomRoleResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOrgCustomRole(ctx context.Context, req *mgmt_pb.UpdateOrgCustomRoleRequest) (*mgmt_pb.UpdateOrgCustomRoleResponse, error) {
+	details, err := s.command.ChangeOrgCustomRole(ctx, authz.GetCtxData(ctx).OrgID, req.Key, req.DisplayName, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOrgCustomRoleResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrgCustomRole(ctx context.Context, req *mgmt_pb.RemoveOrgCustomRoleRequest) (*mgmt_pb.RemoveOrgCustomRoleResponse, error) {
+	details, err := s.command.RemoveOrgCustomRole(ctx, authz.GetCtxData(ctx).OrgID, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgCustomRoleResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func listOrgCustomRolesRequestToModel(req *mgmt_pb.ListOrgCustomRolesRequest, resourceOwner string) (*query.CustomRoleSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := member_grpc.CustomRoleQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewCustomRoleResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.CustomRoleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
@@ -444,6 +444,294 @@ func (s *Server) ResetCustomInviteUserMessageTextToDefault(ctx context.Context,
 	}, nil
 }
 
+func (s *Server) GetCustomNewDeviceSignInMessageText(ctx context.Context, req *mgmt_pb.GetCustomNewDeviceSignInMessageTextRequest) (*mgmt_pb.GetCustomNewDeviceSignInMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.NewDeviceSignInMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomNewDeviceSignInMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultNewDeviceSignInMessageText(ctx context.Context, req *mgmt_pb.GetDefaultNewDeviceSignInMessageTextRequest) (*mgmt_pb.GetDefaultNewDeviceSignInMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.NewDeviceSignInMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultNewDeviceSignInMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomNewDeviceSignInMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomNewDeviceSignInMessageTextRequest) (*mgmt_pb.SetCustomNewDeviceSignInMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetNewDeviceSignInCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomNewDeviceSignInMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomNewDeviceSignInMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomNewDeviceSignInMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomNewDeviceSignInMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.NewDeviceSignInMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomNewDeviceSignInMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomMFAAddedMessageText(ctx context.Context, req *mgmt_pb.GetCustomMFAAddedMessageTextRequest) (*mgmt_pb.GetCustomMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.MFAAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFAAddedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultMFAAddedMessageTextRequest) (*mgmt_pb.GetDefaultMFAAddedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.MFAAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultMFAAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomMFAAddedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomMFAAddedMessageTextRequest) (*mgmt_pb.SetCustomMFAAddedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetMFAAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMFAAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFAAddedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.MFAAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMFAAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomMFARemovedMessageText(ctx context.Context, req *mgmt_pb.GetCustomMFARemovedMessageTextRequest) (*mgmt_pb.GetCustomMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.MFARemovedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultMFARemovedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultMFARemovedMessageTextRequest) (*mgmt_pb.GetDefaultMFARemovedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.MFARemovedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultMFARemovedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomMFARemovedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomMFARemovedMessageTextRequest) (*mgmt_pb.SetCustomMFARemovedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetMFARemovedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomMFARemovedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomMFARemovedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.MFARemovedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomMFARemovedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomEmailChangeMessageText(ctx context.Context, req *mgmt_pb.GetCustomEmailChangeMessageTextRequest) (*mgmt_pb.GetCustomEmailChangeMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.EmailChangeMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomEmailChangeMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultEmailChangeMessageText(ctx context.Context, req *mgmt_pb.GetDefaultEmailChangeMessageTextRequest) (*mgmt_pb.GetDefaultEmailChangeMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.EmailChangeMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultEmailChangeMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomEmailChangeMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomEmailChangeMessageTextRequest) (*mgmt_pb.SetCustomEmailChangeMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetEmailChangeCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomEmailChangeMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomEmailChangeMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomEmailChangeMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomEmailChangeMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.EmailChangeMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomEmailChangeMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomAccountLockedMessageText(ctx context.Context, req *mgmt_pb.GetCustomAccountLockedMessageTextRequest) (*mgmt_pb.GetCustomAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.AccountLockedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultAccountLockedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultAccountLockedMessageTextRequest) (*mgmt_pb.GetDefaultAccountLockedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.AccountLockedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultAccountLockedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomAccountLockedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomAccountLockedMessageTextRequest) (*mgmt_pb.SetCustomAccountLockedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetAccountLockedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomAccountLockedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomAccountLockedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomAccountLockedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomAccountLockedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.AccountLockedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomAccountLockedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetCustomPersonalAccessTokenAddedMessageText(ctx context.Context, req *mgmt_pb.GetCustomPersonalAccessTokenAddedMessageTextRequest) (*mgmt_pb.GetCustomPersonalAccessTokenAddedMessageTextResponse, error) {
+	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.PersonalAccessTokenAddedMessageType, req.Language, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetCustomPersonalAccessTokenAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) GetDefaultPersonalAccessTokenAddedMessageText(ctx context.Context, req *mgmt_pb.GetDefaultPersonalAccessTokenAddedMessageTextRequest) (*mgmt_pb.GetDefaultPersonalAccessTokenAddedMessageTextResponse, error) {
+	msg, err := s.query.IAMMessageTextByTypeAndLanguage(ctx, domain.PersonalAccessTokenAddedMessageType, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultPersonalAccessTokenAddedMessageTextResponse{
+		CustomText: text_grpc.ModelCustomMessageTextToPb(msg),
+	}, nil
+}
+
+func (s *Server) SetCustomPersonalAccessTokenAddedMessageCustomText(ctx context.Context, req *mgmt_pb.SetCustomPersonalAccessTokenAddedMessageTextRequest) (*mgmt_pb.SetCustomPersonalAccessTokenAddedMessageTextResponse, error) {
+	result, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, SetPersonalAccessTokenAddedCustomTextToDomain(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetCustomPersonalAccessTokenAddedMessageTextResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetCustomPersonalAccessTokenAddedMessageTextToDefault(ctx context.Context, req *mgmt_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultRequest) (*mgmt_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultResponse, error) {
+	result, err := s.command.RemoveOrgMessageTexts(ctx, authz.GetCtxData(ctx).OrgID, domain.PersonalAccessTokenAddedMessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetCustomPersonalAccessTokenAddedMessageTextToDefaultResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
 func (s *Server) GetCustomPasswordlessRegistrationMessageText(ctx context.Context, req *mgmt_pb.GetCustomPasswordlessRegistrationMessageTextRequest) (*mgmt_pb.GetCustomPasswordlessRegistrationMessageTextResponse, error) {
 	msg, err := s.query.CustomMessageTextByTypeAndLanguage(ctx, authz.GetCtxData(ctx).OrgID, domain.PasswordlessRegistrationMessageType, req.Language, false)
 	if err != nil {
@@ -0,0 +1,234 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	group_grpc "github.com/zitadel/zitadel/internal/api/grpc/group"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetGroupByID(ctx context.Context, req *mgmt_pb.GetGroupByIDRequest) (*mgmt_pb.GetGroupByIDResponse, error) {
+	group, err := s.query.GroupByID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetGroupByIDResponse{
+		Group: group_grpc.GroupToPb(group),
+	}, nil
+}
+
+func (s *Server) ListGroups(ctx context.Context, req *mgmt_pb.ListGroupsRequest) (*mgmt_pb.ListGroupsResponse, error) {
+	queries, err := listGroupsRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.query.SearchGroups(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupsResponse{
+		Result:  group_grpc.GroupsToPb(groups.Groups),
+		Details: object_grpc.ToListDetails(groups.Count, groups.Sequence, groups.LastRun),
+	}, nil
+}
+
+func (s *Server) AddGroup(ctx context.Context, req *mgmt_pb.AddGroupRequest) (*mgmt_pb.AddGroupResponse, error) {
+	add := AddGroupRequestToCommand(req)
+	details, err := s.command.AddGroup(ctx, add, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupResponse{
+		Id:      add.AggregateID,
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateGroup(ctx context.Context, req *mgmt_pb.UpdateGroupRequest) (*mgmt_pb.UpdateGroupResponse, error) {
+	details, err := s.command.ChangeGroup(ctx, UpdateGroupRequestToCommand(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateGroupResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveGroup(ctx context.Context, req *mgmt_pb.RemoveGroupRequest) (*mgmt_pb.RemoveGroupResponse, error) {
+	details, err := s.command.RemoveGroup(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListGroupMembers(ctx context.Context, req *mgmt_pb.ListGroupMembersRequest) (*mgmt_pb.ListGroupMembersResponse, error) {
+	queries, err := listGroupMembersRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.query.SearchGroupMembers(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupMembersResponse{
+		Result:  group_grpc.GroupMembersToPb(members.Members),
+		Details: object_grpc.ToListDetails(members.Count, members.Sequence, members.LastRun),
+	}, nil
+}
+
+func (s *Server) AddGroupMember(ctx context.Context, req *mgmt_pb.AddGroupMemberRequest) (*mgmt_pb.AddGroupMemberResponse, error) {
+	details, err := s.command.AddGroupMember(ctx, req.GroupId, req.UserId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupMemberResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveGroupMember(ctx context.Context, req *mgmt_pb.RemoveGroupMemberRequest) (*mgmt_pb.RemoveGroupMemberResponse, error) {
+	details, err := s.command.RemoveGroupMember(ctx, req.GroupId, req.UserId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupMemberResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListGroupGrants(ctx context.Context, req *mgmt_pb.ListGroupGrantsRequest) (*mgmt_pb.ListGroupGrantsResponse, error) {
+	queries, err := listGroupGrantsRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.query.SearchGroupGrants(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListGroupGrantsResponse{
+		Result:  group_grpc.GroupGrantsToPb(grants.Grants),
+		Details: object_grpc.ToListDetails(grants.Count, grants.Sequence, grants.LastRun),
+	}, nil
+}
+
+func (s *Server) AddGroupGrant(ctx context.Context, req *mgmt_pb.AddGroupGrantRequest) (*mgmt_pb.AddGroupGrantResponse, error) {
+	grantID, details, err := s.command.AddGroupGrant(ctx, req.GroupId, &command.GroupGrant{
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: req.ProjectGrantId,
+		RoleKeys:       req.RoleKeys,
+	}, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddGroupGrantResponse{
+		GrantId: grantID,
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateGroupGrant(ctx context.Context, req *mgmt_pb.UpdateGroupGrantRequest) (*mgmt_pb.UpdateGroupGrantResponse, error) {
+	details, err := s.command.ChangeGroupGrant(ctx, req.GroupId, req.GrantId, req.RoleKeys, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateGroupGrantResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveGroupGrant(ctx context.Context, req *mgmt_pb.RemoveGroupGrantRequest) (*mgmt_pb.RemoveGroupGrantResponse, error) {
+	details, err := s.command.RemoveGroupGrant(ctx, req.GroupId, req.GrantId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveGroupGrantResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func listGroupsRequestToModel(req *mgmt_pb.ListGroupsRequest, resourceOwner string) (*query.GroupSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := group_grpc.GroupQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
+
+func listGroupMembersRequestToModel(req *mgmt_pb.ListGroupMembersRequest, resourceOwner string) (*query.GroupMemberSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	groupQuery, err := query.NewGroupMemberGroupIDSearchQuery(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupMemberResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupMemberSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{groupQuery, ownerQuery},
+	}, nil
+}
+
+func listGroupGrantsRequestToModel(req *mgmt_pb.ListGroupGrantsRequest, resourceOwner string) (*query.GroupGrantSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := group_grpc.GroupGrantQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	groupQuery, err := query.NewGroupGrantGroupIDSearchQuery(req.GroupId)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewGroupGrantResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupGrantSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, groupQuery, ownerQuery),
+	}, nil
+}
+
+func AddGroupRequestToCommand(req *mgmt_pb.AddGroupRequest) *command.AddGroup {
+	return &command.AddGroup{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func UpdateGroupRequestToCommand(req *mgmt_pb.UpdateGroupRequest) *command.ChangeGroup {
+	change := &command.ChangeGroup{
+		Name:        &req.Name,
+		Description: &req.Description,
+	}
+	change.AggregateID = req.Id
+	return change
+}
@@ -458,3 +458,90 @@ func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProvider
 		Details: object_pb.DomainToChangeDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) GetProviderGroupMappings(ctx context.Context, req *mgmt_pb.GetProviderGroupMappingsRequest) (*mgmt_pb.GetProviderGroupMappingsResponse, error) {
+	orgIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, orgIDQuery); err != nil {
+		return nil, err
+	}
+	mappings, err := s.query.IDPGroupMappingsByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderGroupMappingsResponse{
+		Details:  object_pb.ToViewDetailsPb(mappings.Sequence, mappings.CreationDate, mappings.EventDate, mappings.ResourceOwner),
+		Mappings: idp_grpc.GroupMappingsToPb(mappings.Mappings),
+	}, nil
+}
+
+func (s *Server) SetProviderGroupMappings(ctx context.Context, req *mgmt_pb.SetProviderGroupMappingsRequest) (*mgmt_pb.SetProviderGroupMappingsResponse, error) {
+	details, err := s.command.SetOrgIDPGroupMappings(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.GroupMappingsToDomain(req.Mappings))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderGroupMappingsResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderFederatedLogout(ctx context.Context, req *mgmt_pb.GetProviderFederatedLogoutRequest) (*mgmt_pb.GetProviderFederatedLogoutResponse, error) {
+	orgIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, orgIDQuery); err != nil {
+		return nil, err
+	}
+	logout, err := s.query.IDPFederatedLogoutByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderFederatedLogoutResponse{
+		Details: object_pb.ToViewDetailsPb(logout.Sequence, logout.CreationDate, logout.EventDate, logout.ResourceOwner),
+		Enabled: logout.Enabled,
+	}, nil
+}
+
+func (s *Server) SetProviderFederatedLogout(ctx context.Context, req *mgmt_pb.SetProviderFederatedLogoutRequest) (*mgmt_pb.SetProviderFederatedLogoutResponse, error) {
+	details, err := s.command.SetOrgIDPFederatedLogout(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.Enabled)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderFederatedLogoutResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetProviderTokenExchangeClients(ctx context.Context, req *mgmt_pb.GetProviderTokenExchangeClientsRequest) (*mgmt_pb.GetProviderTokenExchangeClientsResponse, error) {
+	orgIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	// ensure the provider exists and belongs to the resource owner
+	if _, err = s.query.IDPTemplateByID(ctx, true, req.Id, false, nil, orgIDQuery); err != nil {
+		return nil, err
+	}
+	clients, err := s.query.IDPTokenExchangeClientsByIDPID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProviderTokenExchangeClientsResponse{
+		Details:   object_pb.ToViewDetailsPb(clients.Sequence, clients.CreationDate, clients.EventDate, clients.ResourceOwner),
+		ClientIds: clients.ClientIDs,
+	}, nil
+}
+
+func (s *Server) SetProviderTokenExchangeClients(ctx context.Context, req *mgmt_pb.SetProviderTokenExchangeClientsRequest) (*mgmt_pb.SetProviderTokenExchangeClientsResponse, error) {
+	details, err := s.command.SetOrgIDPTokenExchangeClients(ctx, authz.GetCtxData(ctx).OrgID, req.Id, req.ClientIds)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderTokenExchangeClientsResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package management
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) ListNotificationTemplates(ctx context.Context, req *mgmt_pb.ListNotificationTemplatesRequest) (*mgmt_pb.ListNotificationTemplatesResponse, error) {
+	result, err := s.query.NotificationTemplatesByOrg(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListNotificationTemplatesResponse{
+		Details: object.ToListDetails(result.Count, result.Sequence, result.LastRun),
+		Result:  text_grpc.NotificationTemplatesToPb(result.Templates),
+	}, nil
+}
+
+func (s *Server) SetNotificationTemplate(ctx context.Context, req *mgmt_pb.SetNotificationTemplateRequest) (*mgmt_pb.SetNotificationTemplateResponse, error) {
+	details, err := s.command.SetOrgNotificationTemplate(ctx, authz.GetCtxData(ctx).OrgID, &domain.NotificationTemplate{
+		MessageType: req.MessageType,
+		Language:    language.Make(req.Language),
+		Subject:     req.Subject,
+		HTML:        req.Html,
+		Text:        req.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetNotificationTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveNotificationTemplate(ctx context.Context, req *mgmt_pb.RemoveNotificationTemplateRequest) (*mgmt_pb.RemoveNotificationTemplateResponse, error) {
+	details, err := s.command.RemoveOrgNotificationTemplate(ctx, authz.GetCtxData(ctx).OrgID, req.MessageType, language.Make(req.Language))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveNotificationTemplateResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+// PreviewNotificationTemplate renders the template with sample data and the message texts of the organization.
+// Nothing is sent, so a template can be checked before it is set.
+func (s *Server) PreviewNotificationTemplate(ctx context.Context, req *mgmt_pb.PreviewNotificationTemplateRequest) (*mgmt_pb.PreviewNotificationTemplateResponse, error) {
+	if !domain.IsMessageTextType(req.MessageType) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "MANAG-Nt1pT", "Errors.NotificationTemplate.Invalid")
+	}
+	orgID := authz.GetCtxData(ctx).OrgID
+	lang := language.Make(req.Language)
+	subject, html, text := req.Subject, req.Html, req.Text
+	if subject == "" && html == "" && text == "" {
+		stored, err := s.query.NotificationTemplatesByOrg(ctx, orgID, req.MessageType)
+		if err != nil {
+			return nil, err
+		}
+		template := stored.ForLanguage(lang)
+		if template == nil {
+			return nil, zerrors.ThrowNotFound(nil, "MANAG-Nt2nF", "Errors.NotificationTemplate.NotFound")
+		}
+		subject, html, text = template.Subject, template.HTML, template.Text
+	}
+	translator, err := s.notificationTranslator(ctx, orgID, req.MessageType)
+	if err != nil {
+		return nil, err
+	}
+	data := templates.SampleTemplateData(translator, req.MessageType, lang.String())
+	rendered, err := templates.RenderCustomTemplate(subject, html, text, data)
+	if err != nil {
+		return nil, err
+	}
+	if rendered.Subject == "" {
+		rendered.Subject = data.Subject
+	}
+	return &mgmt_pb.PreviewNotificationTemplateResponse{
+		Subject: rendered.Subject,
+		Html:    rendered.HTML,
+		Text:    rendered.Text,
+	}, nil
+}
+
+func (s *Server) notificationTranslator(ctx context.Context, orgID, messageType string) (*i18n.Translator, error) {
+	translator, err := i18n.NewNotificationTranslator(s.query.GetDefaultLanguage(ctx), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, aggregateID := range []string{authz.GetInstance(ctx).InstanceID(), orgID} {
+		texts, err := s.query.CustomTextListByTemplate(ctx, aggregateID, messageType, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, text := range texts.CustomTexts {
+			if err := translator.AddMessages(text.Language, i18n.Message{ID: text.Template + "." + text.Key, Text: text.Text}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return translator, nil
+}
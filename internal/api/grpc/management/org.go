@@ -141,6 +141,30 @@ func (s *Server) RemoveOrg(ctx context.Context, req *mgmt_pb.RemoveOrgRequest) (
 	return &mgmt_pb.RemoveOrgResponse{Details: object.DomainToChangeDetailsPb(details)}, nil
 }
 
+func (s *Server) SetOrgParent(ctx context.Context, req *mgmt_pb.SetOrgParentRequest) (*mgmt_pb.SetOrgParentResponse, error) {
+	details, err := s.command.SetOrgParent(ctx, authz.GetCtxData(ctx).OrgID, req.ParentOrgId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetOrgParentResponse{Details: object.DomainToChangeDetailsPb(details)}, nil
+}
+
+func (s *Server) RemoveOrgParent(ctx context.Context, _ *mgmt_pb.RemoveOrgParentRequest) (*mgmt_pb.RemoveOrgParentResponse, error) {
+	details, err := s.command.RemoveOrgParent(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveOrgParentResponse{Details: object.DomainToChangeDetailsPb(details)}, nil
+}
+
+func (s *Server) ListOrgChildren(ctx context.Context, _ *mgmt_pb.ListOrgChildrenRequest) (*mgmt_pb.ListOrgChildrenResponse, error) {
+	orgIDs, err := s.query.OrgChildIDs(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListOrgChildrenResponse{OrgIds: orgIDs}, nil
+}
+
 func (s *Server) GetDomainPolicy(ctx context.Context, req *mgmt_pb.GetDomainPolicyRequest) (*mgmt_pb.GetDomainPolicyResponse, error) {
 	policy, err := s.query.DomainPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID, false)
 	if err != nil {
@@ -258,6 +282,11 @@ func (s *Server) ListOrgMemberRoles(ctx context.Context, _ *mgmt_pb.ListOrgMembe
 		return nil, err
 	}
 	roles := s.query.GetOrgMemberRoles(authz.GetCtxData(ctx).OrgID == instance.DefaultOrgID)
+	customRoles, err := s.query.GetCustomOrgMemberRoles(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	roles = append(roles, customRoles...)
 	return &mgmt_pb.ListOrgMemberRolesResponse{
 		Result: roles,
 	}, nil
@@ -0,0 +1,60 @@
+package management
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddInactivityPolicyToDomain(p *mgmt_pb.AddCustomInactivityPolicyRequest) *domain.InactivityPolicy {
+	return &domain.InactivityPolicy{
+		InactivityPeriod: p.GetInactivityPeriod().AsDuration(),
+		WarningPeriod:    p.GetWarningPeriod().AsDuration(),
+		DryRun:           p.DryRun,
+	}
+}
+
+func UpdateInactivityPolicyToDomain(p *mgmt_pb.UpdateCustomInactivityPolicyRequest) *domain.InactivityPolicy {
+	return &domain.InactivityPolicy{
+		InactivityPeriod: p.GetInactivityPeriod().AsDuration(),
+		WarningPeriod:    p.GetWarningPeriod().AsDuration(),
+		DryRun:           p.DryRun,
+	}
+}
+
+func InactiveUsersToPb(users []*query.InactiveUser) []*mgmt_pb.InactiveUser {
+	result := make([]*mgmt_pb.InactiveUser, len(users))
+	for i, user := range users {
+		result[i] = InactiveUserToPb(user)
+	}
+	return result
+}
+
+func InactiveUserToPb(user *query.InactiveUser) *mgmt_pb.InactiveUser {
+	pb := &mgmt_pb.InactiveUser{
+		UserId:           user.UserID,
+		UserName:         user.Username,
+		LastActivity:     timestamppb.New(user.LastActivity),
+		DeactivationDate: timestamppb.New(user.DeactivationDate),
+		Action:           inactiveUserActionToPb(user.Action),
+	}
+	if !user.WarnedAt.IsZero() {
+		pb.WarnedAt = timestamppb.New(user.WarnedAt)
+	}
+	return pb
+}
+
+func inactiveUserActionToPb(action query.InactiveUserAction) mgmt_pb.InactiveUserAction {
+	switch action {
+	case query.InactiveUserActionWarn:
+		return mgmt_pb.InactiveUserAction_INACTIVE_USER_ACTION_WARN
+	case query.InactiveUserActionDeactivate:
+		return mgmt_pb.InactiveUserAction_INACTIVE_USER_ACTION_DEACTIVATE
+	case query.InactiveUserActionUnspecified:
+		return mgmt_pb.InactiveUserAction_INACTIVE_USER_ACTION_UNSPECIFIED
+	default:
+		return mgmt_pb.InactiveUserAction_INACTIVE_USER_ACTION_UNSPECIFIED
+	}
+}
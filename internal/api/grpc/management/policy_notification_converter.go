@@ -0,0 +1,30 @@
+package management
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func AddNotificationPolicyToDomain(req *mgmt_pb.AddCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceSignIn:          req.GetNewDeviceSignIn(),
+		MFAAdded:                 req.GetMfaAdded(),
+		MFARemoved:               req.GetMfaRemoved(),
+		EmailChange:              req.GetEmailChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
+
+func UpdateNotificationPolicyToDomain(req *mgmt_pb.UpdateCustomNotificationPolicyRequest) *domain.NotificationPolicy {
+	return &domain.NotificationPolicy{
+		PasswordChange:           req.GetPasswordChange(),
+		NewDeviceSignIn:          req.GetNewDeviceSignIn(),
+		MFAAdded:                 req.GetMfaAdded(),
+		MFARemoved:               req.GetMfaRemoved(),
+		EmailChange:              req.GetEmailChange(),
+		AccountLocked:            req.GetAccountLocked(),
+		PersonalAccessTokenAdded: req.GetPersonalAccessTokenAdded(),
+	}
+}
@@ -53,9 +53,10 @@ func AddProjectRoleRequestToDomain(req *mgmt_pb.AddProjectRoleRequest) *domain.P
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		Key:         req.RoleKey,
-		DisplayName: req.DisplayName,
-		Group:       req.Group,
+		Key:           req.RoleKey,
+		DisplayName:   req.DisplayName,
+		Group:         req.Group,
+		IncludedRoles: req.IncludedRoles,
 	}
 }
 
@@ -66,9 +67,10 @@ func BulkAddProjectRolesRequestToDomain(req *mgmt_pb.BulkAddProjectRolesRequest)
 			ObjectRoot: models.ObjectRoot{
 				AggregateID: req.ProjectId,
 			},
-			Key:         role.Key,
-			DisplayName: role.DisplayName,
-			Group:       role.Group,
+			Key:           role.Key,
+			DisplayName:   role.DisplayName,
+			Group:         role.Group,
+			IncludedRoles: role.IncludedRoles,
 		}
 	}
 	return roles
@@ -79,9 +81,10 @@ func UpdateProjectRoleRequestToDomain(req *mgmt_pb.UpdateProjectRoleRequest) *do
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		Key:         req.RoleKey,
-		DisplayName: req.DisplayName,
-		Group:       req.Group,
+		Key:           req.RoleKey,
+		DisplayName:   req.DisplayName,
+		Group:         req.Group,
+		IncludedRoles: req.IncludedRoles,
 	}
 }
 
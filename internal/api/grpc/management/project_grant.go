@@ -127,6 +127,16 @@ func (s *Server) ReactivateProjectGrant(ctx context.Context, req *mgmt_pb.Reacti
 	}, nil
 }
 
+func (s *Server) SetProjectGrantValidity(ctx context.Context, req *mgmt_pb.SetProjectGrantValidityRequest) (*mgmt_pb.SetProjectGrantValidityResponse, error) {
+	details, err := s.command.SetProjectGrantValidity(ctx, req.ProjectId, req.GrantId, authz.GetCtxData(ctx).OrgID, object_grpc.GrantValidityToDomain(req.ValidFrom, req.ValidUntil))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProjectGrantValidityResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) RemoveProjectGrant(ctx context.Context, req *mgmt_pb.RemoveProjectGrantRequest) (*mgmt_pb.RemoveProjectGrantResponse, error) {
 	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(req.ProjectId)
 	if err != nil {
@@ -105,8 +105,9 @@ func AddProjectGrantRequestToDomain(req *mgmt_pb.AddProjectGrantRequest) *domain
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		GrantedOrgID: req.GrantedOrgId,
-		RoleKeys:     req.RoleKeys,
+		GrantedOrgID:  req.GrantedOrgId,
+		RoleKeys:      req.RoleKeys,
+		GrantValidity: object.GrantValidityToDomain(req.ValidFrom, req.ValidUntil),
 	}
 }
 
@@ -0,0 +1,112 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	relation_grpc "github.com/zitadel/zitadel/internal/api/grpc/relation"
+	"github.com/zitadel/zitadel/internal/query"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetProjectAuthorizationModel(ctx context.Context, req *mgmt_pb.GetProjectAuthorizationModelRequest) (*mgmt_pb.GetProjectAuthorizationModelResponse, error) {
+	model, err := s.query.AuthorizationModelByProjectID(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetProjectAuthorizationModelResponse{
+		Model: relation_grpc.AuthorizationModelToPb(model.Model),
+		Details: object_grpc.ToViewDetailsPb(
+			model.Sequence,
+			model.CreationDate,
+			model.ChangeDate,
+			model.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) SetProjectAuthorizationModel(ctx context.Context, req *mgmt_pb.SetProjectAuthorizationModelRequest) (*mgmt_pb.SetProjectAuthorizationModelResponse, error) {
+	details, err := s.command.SetProjectAuthorizationModel(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, relation_grpc.AuthorizationModelToDomain(req.Model))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProjectAuthorizationModelResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListRelationTuples(ctx context.Context, req *mgmt_pb.ListRelationTuplesRequest) (*mgmt_pb.ListRelationTuplesResponse, error) {
+	queries, err := listRelationTuplesRequestToModel(req, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	tuples, err := s.query.SearchRelationTuples(ctx, req.ProjectId, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListRelationTuplesResponse{
+		Result:  relation_grpc.RelationTuplesToPb(tuples.Tuples),
+		Details: object_grpc.ToListDetails(tuples.Count, tuples.Sequence, tuples.LastRun),
+	}, nil
+}
+
+func (s *Server) AddRelationTuple(ctx context.Context, req *mgmt_pb.AddRelationTupleRequest) (*mgmt_pb.AddRelationTupleResponse, error) {
+	details, err := s.command.AddRelationTuple(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, relation_grpc.RelationTupleToDomain(req.Tuple))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddRelationTupleResponse{
+		Details: object_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveRelationTuple(ctx context.Context, req *mgmt_pb.RemoveRelationTupleRequest) (*mgmt_pb.RemoveRelationTupleResponse, error) {
+	details, err := s.command.RemoveRelationTuple(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, relation_grpc.RelationTupleToDomain(req.Tuple))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveRelationTupleResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) CheckRelation(ctx context.Context, req *mgmt_pb.CheckRelationRequest) (*mgmt_pb.CheckRelationResponse, error) {
+	allowed, err := s.query.CheckRelation(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, req.UserId, req.ObjectType, req.ObjectId, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.CheckRelationResponse{
+		Allowed: allowed,
+	}, nil
+}
+
+func (s *Server) ListRelatedObjects(ctx context.Context, req *mgmt_pb.ListRelatedObjectsRequest) (*mgmt_pb.ListRelatedObjectsResponse, error) {
+	objectIDs, err := s.query.ListRelatedObjects(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID, req.UserId, req.ObjectType, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListRelatedObjectsResponse{
+		ObjectIds: objectIDs,
+	}, nil
+}
+
+func listRelationTuplesRequestToModel(req *mgmt_pb.ListRelationTuplesRequest, resourceOwner string) (*query.RelationTupleSearchQueries, error) {
+	offset, limit, asc := object_grpc.ListQueryToModel(req.Query)
+	queries, err := relation_grpc.RelationTupleQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	ownerQuery, err := query.NewRelationTupleResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.RelationTupleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, ownerQuery),
+	}, nil
+}
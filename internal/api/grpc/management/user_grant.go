@@ -96,6 +96,16 @@ func (s *Server) ReactivateUserGrant(ctx context.Context, req *mgmt_pb.Reactivat
 	}, nil
 }
 
+func (s *Server) SetUserGrantValidity(ctx context.Context, req *mgmt_pb.SetUserGrantValidityRequest) (*mgmt_pb.SetUserGrantValidityResponse, error) {
+	objectDetails, err := s.command.SetUserGrantValidity(ctx, req.GrantId, authz.GetCtxData(ctx).OrgID, obj_grpc.GrantValidityToDomain(req.ValidFrom, req.ValidUntil))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetUserGrantValidityResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) RemoveUserGrant(ctx context.Context, req *mgmt_pb.RemoveUserGrantRequest) (*mgmt_pb.RemoveUserGrantResponse, error) {
 	objectDetails, err := s.command.RemoveUserGrant(ctx, req.GrantId, authz.GetCtxData(ctx).OrgID)
 	if err != nil {
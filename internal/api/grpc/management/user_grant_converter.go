@@ -55,6 +55,7 @@ func AddUserGrantRequestToDomain(req *mgmt_pb.AddUserGrantRequest) *domain.UserG
 		ProjectID:      req.ProjectId,
 		ProjectGrantID: req.ProjectGrantId,
 		RoleKeys:       req.RoleKeys,
+		GrantValidity:  object.GrantValidityToDomain(req.ValidFrom, req.ValidUntil),
 	}
 }
 
@@ -0,0 +1,50 @@
+package member
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	member_pb "github.com/zitadel/zitadel/pkg/grpc/member"
+)
+
+func CustomRolesToPb(roles []*query.CustomRole) []*member_pb.CustomRole {
+	r := make([]*member_pb.CustomRole, len(roles))
+	for i, role := range roles {
+		r[i] = CustomRoleToPb(role)
+	}
+	return r
+}
+
+func CustomRoleToPb(role *query.CustomRole) *member_pb.CustomRole {
+	return &member_pb.CustomRole{
+		Key:         role.Key,
+		DisplayName: role.DisplayName,
+		Permissions: role.Permissions,
+		Details: object.ToViewDetailsPb(
+			role.Sequence,
+			role.CreationDate,
+			role.ChangeDate,
+			role.ResourceOwner,
+		),
+	}
+}
+
+func CustomRoleQueriesToModel(queries []*member_pb.CustomRoleQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = CustomRoleQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func CustomRoleQueryToModel(roleQuery *member_pb.CustomRoleQuery) (query.SearchQuery, error) {
+	switch q := roleQuery.Query.(type) {
+	case *member_pb.CustomRoleQuery_KeyQuery:
+		return query.NewCustomRoleKeySearchQuery(object.TextMethodToQuery(q.KeyQuery.Method), q.KeyQuery.Key)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "MEMBER-Cr2qm", "List.Query.Invalid")
+	}
+}
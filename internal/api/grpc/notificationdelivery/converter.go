@@ -0,0 +1,118 @@
+package notificationdelivery
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	delivery_pb "github.com/zitadel/zitadel/pkg/grpc/notificationdelivery"
+)
+
+func NotificationDeliveriesToPb(deliveries []*query.NotificationDelivery) []*delivery_pb.NotificationDelivery {
+	d := make([]*delivery_pb.NotificationDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		d[i] = NotificationDeliveryToPb(delivery)
+	}
+	return d
+}
+
+func NotificationDeliveryToPb(delivery *query.NotificationDelivery) *delivery_pb.NotificationDelivery {
+	return &delivery_pb.NotificationDelivery{
+		Id:                delivery.ID,
+		UserId:            delivery.UserID,
+		UserResourceOwner: delivery.UserResourceOwner,
+		MessageType:       delivery.MessageType,
+		Channel:           NotificationChannelToPb(delivery.Channel),
+		Status:            NotificationDeliveryStatusToPb(delivery.Status),
+		Attempts:          delivery.Attempts,
+		ProviderId:        delivery.ProviderID,
+		ProviderType:      delivery.ProviderType,
+		Recipient:         delivery.Recipient,
+		ProviderMessageId: delivery.ProviderMessageID,
+		Error:             delivery.Error,
+		Details: object.ToViewDetailsPb(
+			delivery.Sequence,
+			delivery.CreationDate,
+			delivery.ChangeDate,
+			delivery.UserResourceOwner,
+		),
+	}
+}
+
+func NotificationChannelToPb(channel domain.NotificationType) delivery_pb.NotificationChannel {
+	switch channel {
+	case domain.NotificationTypeSms:
+		return delivery_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS
+	default:
+		return delivery_pb.NotificationChannel_NOTIFICATION_CHANNEL_EMAIL
+	}
+}
+
+func NotificationChannelToDomain(channel delivery_pb.NotificationChannel) domain.NotificationType {
+	switch channel {
+	case delivery_pb.NotificationChannel_NOTIFICATION_CHANNEL_SMS:
+		return domain.NotificationTypeSms
+	default:
+		return domain.NotificationTypeEmail
+	}
+}
+
+func NotificationDeliveryStatusToPb(status domain.NotificationDeliveryStatus) delivery_pb.NotificationDeliveryStatus {
+	switch status {
+	case domain.NotificationDeliveryStatusPending:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_PENDING
+	case domain.NotificationDeliveryStatusSent:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_SENT
+	case domain.NotificationDeliveryStatusFailed:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_FAILED
+	case domain.NotificationDeliveryStatusDelivered:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_DELIVERED
+	case domain.NotificationDeliveryStatusBounced:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_BOUNCED
+	default:
+		return delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+func NotificationDeliveryStatusToDomain(status delivery_pb.NotificationDeliveryStatus) domain.NotificationDeliveryStatus {
+	switch status {
+	case delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_PENDING:
+		return domain.NotificationDeliveryStatusPending
+	case delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_SENT:
+		return domain.NotificationDeliveryStatusSent
+	case delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_FAILED:
+		return domain.NotificationDeliveryStatusFailed
+	case delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_DELIVERED:
+		return domain.NotificationDeliveryStatusDelivered
+	case delivery_pb.NotificationDeliveryStatus_NOTIFICATION_DELIVERY_STATUS_BOUNCED:
+		return domain.NotificationDeliveryStatusBounced
+	default:
+		return domain.NotificationDeliveryStatusUnspecified
+	}
+}
+
+func NotificationDeliveryQueriesToModel(queries []*delivery_pb.NotificationDeliveryQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = NotificationDeliveryQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func NotificationDeliveryQueryToModel(deliveryQuery *delivery_pb.NotificationDeliveryQuery) (query.SearchQuery, error) {
+	switch q := deliveryQuery.Query.(type) {
+	case *delivery_pb.NotificationDeliveryQuery_UserIdQuery:
+		return query.NewNotificationDeliveryUserIDSearchQuery(q.UserIdQuery.UserId)
+	case *delivery_pb.NotificationDeliveryQuery_MessageTypeQuery:
+		return query.NewNotificationDeliveryMessageTypeSearchQuery(q.MessageTypeQuery.MessageType)
+	case *delivery_pb.NotificationDeliveryQuery_ChannelQuery:
+		return query.NewNotificationDeliveryChannelSearchQuery(NotificationChannelToDomain(q.ChannelQuery.Channel))
+	case *delivery_pb.NotificationDeliveryQuery_StatusQuery:
+		return query.NewNotificationDeliveryStatusSearchQuery(NotificationDeliveryStatusToDomain(q.StatusQuery.Status))
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "NOTDEL-Nd1qI", "List.Query.Invalid")
+	}
+}
@@ -97,6 +97,26 @@ func ToListDetails(
 	return details
 }
 
+// GrantValidityToDomain maps the optional validity timestamps of a grant, missing timestamps leave the window open.
+func GrantValidityToDomain(validFrom, validUntil *timestamppb.Timestamp) domain.GrantValidity {
+	var validity domain.GrantValidity
+	if validFrom != nil {
+		validity.ValidFrom = validFrom.AsTime()
+	}
+	if validUntil != nil {
+		validity.ValidUntil = validUntil.AsTime()
+	}
+	return validity
+}
+
+// OptionalTimestampToPb returns nil for the zero time.
+func OptionalTimestampToPb(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 func TextMethodToQuery(method object_pb.TextQueryMethod) query.TextComparison {
 	switch method {
 	case object_pb.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS:
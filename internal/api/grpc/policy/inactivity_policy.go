@@ -0,0 +1,24 @@
+package policy
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelInactivityPolicyToPb(policy *query.InactivityPolicy) *policy_pb.InactivityPolicy {
+	return &policy_pb.InactivityPolicy{
+		IsDefault:        policy.IsDefault,
+		InactivityPeriod: durationpb.New(policy.InactivityPeriod),
+		WarningPeriod:    durationpb.New(policy.WarningPeriod),
+		DryRun:           policy.DryRun,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
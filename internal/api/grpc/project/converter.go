@@ -54,6 +54,8 @@ func GrantedProjectViewToPb(project *query.ProjectGrant) *proj_pb.GrantedProject
 		GrantedOrgId:     project.GrantedOrgID,
 		GrantedOrgName:   project.OrgName,
 		GrantedRoleKeys:  project.GrantedRoleKeys,
+		ValidFrom:        object.OptionalTimestampToPb(project.ValidFrom),
+		ValidUntil:       object.OptionalTimestampToPb(project.ValidUntil),
 	}
 }
 func ProjectQueriesToModel(queries []*proj_pb.ProjectQuery) (_ []query.SearchQuery, err error) {
@@ -128,6 +130,8 @@ func RoleQueryToModel(apiQuery *proj_pb.RoleQuery) (query.SearchQuery, error) {
 		return query.NewProjectRoleKeySearchQuery(object.TextMethodToQuery(q.KeyQuery.Method), q.KeyQuery.Key)
 	case *proj_pb.RoleQuery_DisplayNameQuery:
 		return query.NewProjectRoleDisplayNameSearchQuery(object.TextMethodToQuery(q.DisplayNameQuery.Method), q.DisplayNameQuery.DisplayName)
+	case *proj_pb.RoleQuery_IncludesRoleQuery:
+		return query.NewProjectRoleIncludesRoleSearchQuery(q.IncludesRoleQuery.Key)
 	default:
 		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-fms0e", "List.Query.Invalid")
 	}
@@ -143,9 +147,10 @@ func RoleViewsToPb(roles []*query.ProjectRole) []*proj_pb.Role {
 
 func RoleViewToPb(role *query.ProjectRole) *proj_pb.Role {
 	return &proj_pb.Role{
-		Key:         role.Key,
-		DisplayName: role.DisplayName,
-		Group:       role.Group,
+		Key:           role.Key,
+		DisplayName:   role.DisplayName,
+		Group:         role.Group,
+		IncludedRoles: role.IncludedRoles,
 		Details: object.ToViewDetailsPb(
 
 			role.Sequence,
@@ -0,0 +1,155 @@
+package relation
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	relation_pb "github.com/zitadel/zitadel/pkg/grpc/relation"
+)
+
+func AuthorizationModelToPb(model *domain.AuthorizationModel) *relation_pb.AuthorizationModel {
+	if model == nil {
+		return nil
+	}
+	types := make([]*relation_pb.ObjectType, len(model.Types))
+	for i, t := range model.Types {
+		relations := make([]*relation_pb.Relation, len(t.Relations))
+		for j, r := range t.Relations {
+			relations[j] = relationToPb(r)
+		}
+		types[i] = &relation_pb.ObjectType{
+			Name:      t.Name,
+			Relations: relations,
+		}
+	}
+	return &relation_pb.AuthorizationModel{Types: types}
+}
+
+func relationToPb(relation *domain.AuthorizationModelRelation) *relation_pb.Relation {
+	subjects := make([]*relation_pb.SubjectType, len(relation.DirectSubjects))
+	for i, subject := range relation.DirectSubjects {
+		subjects[i] = &relation_pb.SubjectType{
+			Type:     subject.Type,
+			Relation: subject.Relation,
+		}
+	}
+	parents := make([]*relation_pb.FromParent, len(relation.FromParents))
+	for i, parent := range relation.FromParents {
+		parents[i] = &relation_pb.FromParent{
+			Tupleset: parent.Tupleset,
+			Relation: parent.Relation,
+		}
+	}
+	return &relation_pb.Relation{
+		Name:           relation.Name,
+		DirectSubjects: subjects,
+		ImpliedBy:      relation.ImpliedBy,
+		FromParents:    parents,
+	}
+}
+
+func AuthorizationModelToDomain(model *relation_pb.AuthorizationModel) *domain.AuthorizationModel {
+	if model == nil {
+		return nil
+	}
+	types := make([]*domain.AuthorizationModelType, len(model.GetTypes()))
+	for i, t := range model.GetTypes() {
+		relations := make([]*domain.AuthorizationModelRelation, len(t.GetRelations()))
+		for j, r := range t.GetRelations() {
+			relations[j] = relationToDomain(r)
+		}
+		types[i] = &domain.AuthorizationModelType{
+			Name:      t.GetName(),
+			Relations: relations,
+		}
+	}
+	return &domain.AuthorizationModel{Types: types}
+}
+
+func relationToDomain(relation *relation_pb.Relation) *domain.AuthorizationModelRelation {
+	subjects := make([]*domain.RelationSubjectType, len(relation.GetDirectSubjects()))
+	for i, subject := range relation.GetDirectSubjects() {
+		subjects[i] = &domain.RelationSubjectType{
+			Type:     subject.GetType(),
+			Relation: subject.GetRelation(),
+		}
+	}
+	parents := make([]*domain.RelationFromParent, len(relation.GetFromParents()))
+	for i, parent := range relation.GetFromParents() {
+		parents[i] = &domain.RelationFromParent{
+			Tupleset: parent.GetTupleset(),
+			Relation: parent.GetRelation(),
+		}
+	}
+	return &domain.AuthorizationModelRelation{
+		Name:           relation.GetName(),
+		DirectSubjects: subjects,
+		ImpliedBy:      relation.GetImpliedBy(),
+		FromParents:    parents,
+	}
+}
+
+func RelationTuplesToPb(tuples []*query.RelationTuple) []*relation_pb.RelationTuple {
+	t := make([]*relation_pb.RelationTuple, len(tuples))
+	for i, tuple := range tuples {
+		t[i] = RelationTupleToPb(tuple)
+	}
+	return t
+}
+
+func RelationTupleToPb(tuple *query.RelationTuple) *relation_pb.RelationTuple {
+	return &relation_pb.RelationTuple{
+		ObjectType:      tuple.ObjectType,
+		ObjectId:        tuple.ObjectID,
+		Relation:        tuple.Relation,
+		SubjectType:     tuple.SubjectType,
+		SubjectId:       tuple.SubjectID,
+		SubjectRelation: tuple.SubjectRelation,
+		Details: object.ToViewDetailsPb(
+			tuple.Sequence,
+			tuple.CreationDate,
+			tuple.CreationDate,
+			tuple.ResourceOwner,
+		),
+	}
+}
+
+func RelationTupleToDomain(tuple *relation_pb.RelationTuple) *domain.RelationTuple {
+	return &domain.RelationTuple{
+		ObjectType:      tuple.GetObjectType(),
+		ObjectID:        tuple.GetObjectId(),
+		Relation:        tuple.GetRelation(),
+		SubjectType:     tuple.GetSubjectType(),
+		SubjectID:       tuple.GetSubjectId(),
+		SubjectRelation: tuple.GetSubjectRelation(),
+	}
+}
+
+func RelationTupleQueriesToModel(queries []*relation_pb.RelationTupleQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = RelationTupleQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func RelationTupleQueryToModel(tupleQuery *relation_pb.RelationTupleQuery) (query.SearchQuery, error) {
+	switch q := tupleQuery.Query.(type) {
+	case *relation_pb.RelationTupleQuery_ObjectTypeQuery:
+		return query.NewRelationTupleObjectTypeSearchQuery(q.ObjectTypeQuery.ObjectType)
+	case *relation_pb.RelationTupleQuery_ObjectIdQuery:
+		return query.NewRelationTupleObjectIDSearchQuery(q.ObjectIdQuery.ObjectId)
+	case *relation_pb.RelationTupleQuery_RelationQuery:
+		return query.NewRelationTupleRelationSearchQuery(q.RelationQuery.Relation)
+	case *relation_pb.RelationTupleQuery_SubjectTypeQuery:
+		return query.NewRelationTupleSubjectTypeSearchQuery(q.SubjectTypeQuery.SubjectType)
+	case *relation_pb.RelationTupleQuery_SubjectIdQuery:
+		return query.NewRelationTupleSubjectIDSearchQuery(q.SubjectIdQuery.SubjectId)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "RELATION-Qt5vd", "List.Query.Invalid")
+	}
+}
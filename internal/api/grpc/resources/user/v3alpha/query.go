@@ -3,12 +3,174 @@ package user
 import (
 	"context"
 
+	"google.golang.org/protobuf/types/known/structpb"
+
+	resource_object "github.com/zitadel/zitadel/internal/api/grpc/resources/object/v3alpha"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	object "github.com/zitadel/zitadel/pkg/grpc/object/v3alpha"
 	user "github.com/zitadel/zitadel/pkg/grpc/resources/user/v3alpha"
 )
 
-func (s *Server) SearchUsers(ctx context.Context, _ *user.SearchUsersRequest) (_ *user.SearchUsersResponse, err error) {
+func (s *Server) SearchUsers(ctx context.Context, req *user.SearchUsersRequest) (_ *user.SearchUsersResponse, err error) {
 	if err := checkUserSchemaEnabled(ctx); err != nil {
 		return nil, err
 	}
-	return &user.SearchUsersResponse{}, nil
+	queries, err := s.searchUsersToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchSchemaUsers(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	users, err := schemaUsersToPb(res.Users)
+	if err != nil {
+		return nil, err
+	}
+	return &user.SearchUsersResponse{
+		Details: resource_object.ToSearchDetailsPb(queries.SearchRequest, res.SearchResponse),
+		Result:  users,
+	}, nil
+}
+
+func (s *Server) searchUsersToModel(req *user.SearchUsersRequest) (*query.SchemaUserSearchQueries, error) {
+	offset, limit, asc, err := resource_object.SearchQueryPbToQuery(s.systemDefaults, req.Query)
+	if err != nil {
+		return nil, err
+	}
+	queries, err := userFiltersToQuery(req.Filters, 0) // start at level 0
+	if err != nil {
+		return nil, err
+	}
+	return &query.SchemaUserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+			// the users can only be sorted by their id, as the other fields are not indexed
+			SortingColumn: query.SchemaUserIDCol,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func schemaUsersToPb(users []*query.SchemaUser) (_ []*user.GetUser, err error) {
+	result := make([]*user.GetUser, len(users))
+	for i, schemaUser := range users {
+		result[i], err = schemaUserToPb(schemaUser)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func schemaUserToPb(schemaUser *query.SchemaUser) (*user.GetUser, error) {
+	data := new(structpb.Struct)
+	if err := data.UnmarshalJSON(schemaUser.Data); err != nil {
+		return nil, err
+	}
+	return &user.GetUser{
+		Details: resource_object.DomainToDetailsPb(&schemaUser.ObjectDetails, object.OwnerType_OWNER_TYPE_ORG, schemaUser.ResourceOwner),
+		Schema: &user.GetSchema{
+			Id:       schemaUser.SchemaID,
+			Type:     schemaUser.SchemaType,
+			Revision: uint32(schemaUser.SchemaRevision),
+		},
+		Data: data,
+		Contact: &user.Contact{
+			Email: &user.Email{
+				Address:    schemaUser.Email,
+				IsVerified: schemaUser.IsEmailVerified,
+			},
+			Phone: &user.Phone{
+				Number:     schemaUser.Phone,
+				IsVerified: schemaUser.IsPhoneVerified,
+			},
+		},
+		State: userStateToPb(schemaUser.State),
+	}, nil
+}
+
+func userStateToPb(state domain.UserState) user.State {
+	switch state {
+	case domain.UserStateActive:
+		return user.State_USER_STATE_ACTIVE
+	case domain.UserStateInactive:
+		return user.State_USER_STATE_INACTIVE
+	case domain.UserStateDeleted:
+		return user.State_USER_STATE_DELETED
+	case domain.UserStateLocked:
+		return user.State_USER_STATE_LOCKED
+	case domain.UserStateUnspecified,
+		domain.UserStateInitial,
+		domain.UserStateSuspend:
+		return user.State_USER_STATE_UNSPECIFIED
+	default:
+		return user.State_USER_STATE_UNSPECIFIED
+	}
+}
+
+func userFiltersToQuery(filters []*user.SearchFilter, level uint8) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(filters))
+	for i, filter := range filters {
+		q[i], err = userFilterToQuery(filter, level)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func userFilterToQuery(filter *user.SearchFilter, level uint8) (query.SearchQuery, error) {
+	if level > 20 {
+		// can't go deeper than 20 levels of nesting.
+		return nil, zerrors.ThrowInvalidArgument(nil, "USERv3-Sch1l", "Errors.Query.TooManyNestingLevels")
+	}
+	switch f := filter.Filter.(type) {
+	case *user.SearchFilter_UserIdFilter:
+		return query.NewSchemaUserIDSearchQuery(f.UserIdFilter.GetId(), resource_object.TextMethodPbToQuery(f.UserIdFilter.GetMethod()))
+	case *user.SearchFilter_OrganizationIdFilter:
+		return query.NewSchemaUserResourceOwnerSearchQuery(f.OrganizationIdFilter.GetId(), resource_object.TextMethodPbToQuery(f.OrganizationIdFilter.GetMethod()))
+	case *user.SearchFilter_SchemaIdFilter:
+		return query.NewSchemaUserSchemaIDSearchQuery(f.SchemaIdFilter.GetId())
+	case *user.SearchFilter_DataFieldFilter:
+		return dataFieldFilterToQuery(f.DataFieldFilter)
+	case *user.SearchFilter_OrFilter:
+		mappedQueries, err := userFiltersToQuery(f.OrFilter.GetQueries(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewUserOrSearchQuery(mappedQueries)
+	case *user.SearchFilter_AndFilter:
+		mappedQueries, err := userFiltersToQuery(f.AndFilter.GetQueries(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewUserAndSearchQuery(mappedQueries)
+	case *user.SearchFilter_NotFilter:
+		mappedQuery, err := userFilterToQuery(f.NotFilter.GetQuery(), level+1)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewUserNotSearchQuery(mappedQuery)
+	case *user.SearchFilter_UsernameFilter,
+		*user.SearchFilter_EmailFilter,
+		*user.SearchFilter_PhoneFilter,
+		*user.SearchFilter_StateFilter,
+		*user.SearchFilter_SchemaTypeFilter:
+		return nil, zerrors.ThrowUnimplemented(nil, "USERv3-Sch2u", "Errors.Query.InvalidRequest")
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "USERv3-Sch3i", "List.Query.Invalid")
+	}
+}
+
+func dataFieldFilterToQuery(filter *user.DataFieldFilter) (query.SearchQuery, error) {
+	q, err := query.NewSchemaUserDataSearchQuery(filter.GetPath(), filter.GetValue().AsInterface(), resource_object.TextMethodPbToQuery(filter.GetMethod()))
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "USERv3-Sch4v", "Errors.Query.InvalidRequest")
+	}
+	return q, nil
 }
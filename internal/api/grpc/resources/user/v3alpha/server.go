@@ -6,6 +6,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/query"
 	user "github.com/zitadel/zitadel/pkg/grpc/resources/user/v3alpha"
 )
 
@@ -13,16 +15,22 @@ var _ user.ZITADELUsersServer = (*Server)(nil)
 
 type Server struct {
 	user.UnimplementedZITADELUsersServer
-	command *command.Commands
+	systemDefaults systemdefaults.SystemDefaults
+	command        *command.Commands
+	query          *query.Queries
 }
 
 type Config struct{}
 
 func CreateServer(
+	systemDefaults systemdefaults.SystemDefaults,
 	command *command.Commands,
+	query *query.Queries,
 ) *Server {
 	return &Server{
-		command: command,
+		systemDefaults: systemDefaults,
+		command:        command,
+		query:          query,
 	}
 }
 
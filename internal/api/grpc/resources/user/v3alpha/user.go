@@ -24,9 +24,10 @@ func (s *Server) CreateUser(ctx context.Context, req *user.CreateUserRequest) (_
 		return nil, err
 	}
 	return &user.CreateUserResponse{
-		Details:   resource_object.DomainToDetailsPb(details, object.OwnerType_OWNER_TYPE_ORG, details.ResourceOwner),
-		EmailCode: schemauser.ReturnCodeEmail,
-		PhoneCode: schemauser.ReturnCodePhone,
+		Details:        resource_object.DomainToDetailsPb(details, object.OwnerType_OWNER_TYPE_ORG, details.ResourceOwner),
+		EmailCode:      schemauser.ReturnCodeEmail,
+		PhoneCode:      schemauser.ReturnCodePhone,
+		SchemaRevision: uint32(schemauser.SchemaRevision()),
 	}, nil
 }
 
@@ -95,9 +96,10 @@ func (s *Server) PatchUser(ctx context.Context, req *user.PatchUserRequest) (_ *
 		return nil, err
 	}
 	return &user.PatchUserResponse{
-		Details:   resource_object.DomainToDetailsPb(details, object.OwnerType_OWNER_TYPE_ORG, details.ResourceOwner),
-		EmailCode: schemauser.ReturnCodeEmail,
-		PhoneCode: schemauser.ReturnCodePhone,
+		Details:        resource_object.DomainToDetailsPb(details, object.OwnerType_OWNER_TYPE_ORG, details.ResourceOwner),
+		EmailCode:      schemauser.ReturnCodeEmail,
+		PhoneCode:      schemauser.ReturnCodePhone,
+		SchemaRevision: uint32(schemauser.SchemaRevision()),
 	}, nil
 }
 
@@ -0,0 +1,146 @@
+package userschema
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	resource_object "github.com/zitadel/zitadel/internal/api/grpc/resources/object/v3alpha"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
+	"github.com/zitadel/zitadel/internal/query"
+	object "github.com/zitadel/zitadel/pkg/grpc/object/v3alpha"
+	schema "github.com/zitadel/zitadel/pkg/grpc/resources/userschema/v3alpha"
+)
+
+func (s *Server) StartUserSchemaMigration(ctx context.Context, req *schema.StartUserSchemaMigrationRequest) (*schema.StartUserSchemaMigrationResponse, error) {
+	if err := checkUserSchemaEnabled(ctx); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	details, err := s.command.StartUserSchemaMigration(ctx, req.GetId(), instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.StartUserSchemaMigrationResponse{
+		Details: resource_object.DomainToDetailsPb(details, object.OwnerType_OWNER_TYPE_INSTANCE, instanceID),
+	}, nil
+}
+
+func (s *Server) GetUserSchemaMigration(ctx context.Context, req *schema.GetUserSchemaMigrationRequest) (*schema.GetUserSchemaMigrationResponse, error) {
+	if err := checkUserSchemaEnabled(ctx); err != nil {
+		return nil, err
+	}
+	migration, err := s.query.UserSchemaMigration(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &schema.GetUserSchemaMigrationResponse{
+		Migration: userSchemaMigrationToPb(migration),
+	}, nil
+}
+
+func migrationToDomain(migration *schema.Migration) (_ *domain_schema.Migration, err error) {
+	if migration == nil {
+		return nil, nil
+	}
+	m := &domain_schema.Migration{
+		Renames:  make([]*domain_schema.MigrationRename, len(migration.GetRenames())),
+		Defaults: make([]*domain_schema.MigrationDefault, len(migration.GetDefaults())),
+		Patch:    make([]*domain_schema.PatchOperation, len(migration.GetPatch())),
+	}
+	for i, rename := range migration.GetRenames() {
+		m.Renames[i] = &domain_schema.MigrationRename{
+			From: rename.GetFrom(),
+			To:   rename.GetTo(),
+		}
+	}
+	for i, def := range migration.GetDefaults() {
+		value, err := valueToJSON(def.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		m.Defaults[i] = &domain_schema.MigrationDefault{
+			Path:  def.GetPath(),
+			Value: value,
+		}
+	}
+	for i, op := range migration.GetPatch() {
+		value, err := valueToJSON(op.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		m.Patch[i] = &domain_schema.PatchOperation{
+			Op:    patchOperationTypeToDomain(op.GetOp()),
+			Path:  op.GetPath(),
+			From:  op.GetFrom(),
+			Value: value,
+		}
+	}
+	return m, nil
+}
+
+func valueToJSON(value *structpb.Value) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return value.MarshalJSON()
+}
+
+func patchOperationTypeToDomain(op schema.PatchOperationType) domain_schema.PatchOperationType {
+	switch op {
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_ADD:
+		return domain_schema.PatchOperationAdd
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_REMOVE:
+		return domain_schema.PatchOperationRemove
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_REPLACE:
+		return domain_schema.PatchOperationReplace
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_MOVE:
+		return domain_schema.PatchOperationMove
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_COPY:
+		return domain_schema.PatchOperationCopy
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_TEST:
+		return domain_schema.PatchOperationTest
+	case schema.PatchOperationType_PATCH_OPERATION_TYPE_UNSPECIFIED:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func userSchemaMigrationToPb(migration *query.UserSchemaMigration) *schema.UserSchemaMigration {
+	failures := make([]*schema.MigrationFailure, len(migration.Failures))
+	for i, failure := range migration.Failures {
+		failures[i] = &schema.MigrationFailure{
+			UserId: failure.UserID,
+			Reason: failure.Reason,
+		}
+	}
+	pb := &schema.UserSchemaMigration{
+		State:     migrationStateToPb(migration.State),
+		Revision:  uint32(migration.SchemaRevision),
+		StartedAt: timestamppb.New(migration.StartedAt),
+		Migrated:  migration.Migrated,
+		Valid:     migration.Valid,
+		Failures:  failures,
+	}
+	if !migration.FinishedAt.IsZero() {
+		pb.FinishedAt = timestamppb.New(migration.FinishedAt)
+	}
+	return pb
+}
+
+func migrationStateToPb(state query.UserSchemaMigrationState) schema.MigrationState {
+	switch state {
+	case query.UserSchemaMigrationStateRunning:
+		return schema.MigrationState_MIGRATION_STATE_RUNNING
+	case query.UserSchemaMigrationStateDone:
+		return schema.MigrationState_MIGRATION_STATE_DONE
+	case query.UserSchemaMigrationStateUnspecified:
+		return schema.MigrationState_MIGRATION_STATE_UNSPECIFIED
+	default:
+		return schema.MigrationState_MIGRATION_STATE_UNSPECIFIED
+	}
+}
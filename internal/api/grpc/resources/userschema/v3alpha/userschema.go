@@ -109,6 +109,11 @@ func patchUserSchemaToCommand(req *schema.PatchUserSchemaRequest, resourceOwner
 		return nil, err
 	}
 
+	migration, err := migrationToDomain(req.GetUserSchema().GetMigration())
+	if err != nil {
+		return nil, err
+	}
+
 	var ty *string
 	if req.GetUserSchema() != nil && req.GetUserSchema().GetType() != "" {
 		ty = gu.Ptr(req.GetUserSchema().GetType())
@@ -119,6 +124,7 @@ func patchUserSchemaToCommand(req *schema.PatchUserSchemaRequest, resourceOwner
 		Type:                   ty,
 		Schema:                 schema,
 		PossibleAuthenticators: authenticatorsToDomain(req.GetUserSchema().GetPossibleAuthenticators()),
+		Migration:              migration,
 	}, nil
 }
 
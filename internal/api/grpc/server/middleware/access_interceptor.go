@@ -25,33 +25,53 @@ func AccessStorageInterceptor(svc *logstore.Service[*record.AccessLog]) grpc.Una
 
 		resp, handlerErr := handler(ctx, req)
 
-		interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
-		defer func() { span.EndWithError(err) }()
+		storeAccessLog(ctx, svc, info.FullMethod, reqMd, handlerErr)
+		return resp, handlerErr
+	}
+}
 
-		var respStatus uint32
-		grpcStatus, ok := status.FromError(handlerErr)
-		if ok {
-			respStatus = uint32(grpcStatus.Code())
+// AccessStorageStreamInterceptor stores an access log for each streaming call after the stream finished.
+func AccessStorageStreamInterceptor(svc *logstore.Service[*record.AccessLog]) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !svc.Enabled() {
+			return handler(srv, stream)
 		}
+		ctx := stream.Context()
+		reqMd, _ := metadata.FromIncomingContext(ctx)
 
-		resMd, _ := metadata.FromOutgoingContext(ctx)
-		instance := authz.GetInstance(ctx)
-		domainCtx := http_util.DomainContext(ctx)
-
-		r := &record.AccessLog{
-			LogDate:         time.Now(),
-			Protocol:        record.GRPC,
-			RequestURL:      info.FullMethod,
-			ResponseStatus:  respStatus,
-			RequestHeaders:  reqMd,
-			ResponseHeaders: resMd,
-			InstanceID:      instance.InstanceID(),
-			ProjectID:       instance.ProjectID(),
-			RequestedDomain: domainCtx.RequestedDomain(),
-			RequestedHost:   domainCtx.RequestedHost(),
-		}
+		handlerErr := handler(srv, stream)
 
-		svc.Handle(interceptorCtx, r)
-		return resp, handlerErr
+		storeAccessLog(ctx, svc, info.FullMethod, reqMd, handlerErr)
+		return handlerErr
 	}
 }
+
+func storeAccessLog(ctx context.Context, svc *logstore.Service[*record.AccessLog], fullMethod string, reqMd metadata.MD, handlerErr error) {
+	interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer func() { span.EndWithError(handlerErr) }()
+
+	var respStatus uint32
+	grpcStatus, ok := status.FromError(handlerErr)
+	if ok {
+		respStatus = uint32(grpcStatus.Code())
+	}
+
+	resMd, _ := metadata.FromOutgoingContext(ctx)
+	instance := authz.GetInstance(ctx)
+	domainCtx := http_util.DomainContext(ctx)
+
+	r := &record.AccessLog{
+		LogDate:         time.Now(),
+		Protocol:        record.GRPC,
+		RequestURL:      fullMethod,
+		ResponseStatus:  respStatus,
+		RequestHeaders:  reqMd,
+		ResponseHeaders: resMd,
+		InstanceID:      instance.InstanceID(),
+		ProjectID:       instance.ProjectID(),
+		RequestedDomain: domainCtx.RequestedDomain(),
+		RequestedHost:   domainCtx.RequestedHost(),
+	}
+
+	svc.Handle(interceptorCtx, r)
+}
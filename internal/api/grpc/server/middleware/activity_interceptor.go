@@ -5,6 +5,7 @@ import (
 	"slices"
 	"strings"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -26,6 +27,22 @@ func ActivityInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// ActivityStreamInterceptor triggers the activity of streaming calls to the resource APIs (e.g. bulk imports).
+func ActivityStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := activityInfoFromGateway(stream.Context()).SetMethod(info.FullMethod).IntoContext(stream.Context())
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		if isResourceAPI(info.FullMethod) {
+			code, _, _, _ := gerrors.ExtractZITADELError(err)
+			ctx = ainfo.ActivityInfoFromContext(ctx).SetGRPCStatus(code).IntoContext(ctx)
+			activity.TriggerGRPCWithContext(ctx, activity.ResourceAPI)
+		}
+		return err
+	}
+}
+
 var resourcePrefixes = []string{
 	"/zitadel.management.v1.ManagementService/",
 	"/zitadel.admin.v1.AdminService/",
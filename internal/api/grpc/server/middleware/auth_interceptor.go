@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -42,6 +43,40 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	return handler(ctxSetter(ctx), req)
 }
 
+// AuthorizationStreamInterceptor authorizes streaming calls.
+// As the messages are only received in the handler, the organization is only taken from the header
+// and permissions requiring a check of request params are not supported.
+func AuthorizationStreamInterceptor(verifier authz.APITokenVerifier, authConfig authz.Config) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return authorizeStream(srv, stream, info, handler, verifier, authConfig)
+	}
+}
+
+func authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler, verifier authz.APITokenVerifier, authConfig authz.Config) (err error) {
+	authOpt, needsToken := verifier.CheckAuthMethod(info.FullMethod)
+	if !needsToken {
+		return handler(srv, stream)
+	}
+
+	authCtx, span := tracing.NewServerInterceptorSpan(stream.Context())
+	defer func() { span.EndWithError(err) }()
+
+	authToken := grpc_util.GetAuthorizationHeader(authCtx)
+	if authToken == "" {
+		return status.Error(codes.Unauthenticated, "auth header missing")
+	}
+
+	orgID := grpc_util.GetHeader(authCtx, http.ZitadelOrgID)
+	ctxSetter, err := authz.CheckUserAuthorization(authCtx, nil, authToken, orgID, "", verifier, authConfig, authOpt, info.FullMethod)
+	if err != nil {
+		return err
+	}
+	span.End()
+	wrapped := grpc_middleware.WrapServerStream(stream)
+	wrapped.WrappedContext = ctxSetter(stream.Context())
+	return handler(srv, wrapped)
+}
+
 func orgIDAndDomainFromRequest(ctx context.Context, req interface{}) (id, domain string) {
 	orgID := grpc_util.GetHeader(ctx, http.ZitadelOrgID)
 	oz, ok := req.(OrganizationFromRequest)
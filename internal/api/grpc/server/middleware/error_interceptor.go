@@ -19,3 +19,9 @@ func toGRPCError(ctx context.Context, req interface{}, handler grpc.UnaryHandler
 	resp, err := handler(ctx, req)
 	return resp, gerrors.ZITADELToGRPCError(err)
 }
+
+func ErrorStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return gerrors.ZITADELToGRPCError(handler(srv, stream))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/zitadel/logging"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc"
@@ -90,6 +91,14 @@ func addInstanceByDomain(ctx context.Context, req interface{}, handler grpc.Unar
 }
 
 func addInstanceByRequestedHost(ctx context.Context, req interface{}, handler grpc.UnaryHandler, verifier authz.InstanceVerifier, translator *i18n.Translator, externalDomain string) (interface{}, error) {
+	instance, err := instanceByRequestedHost(ctx, verifier, translator, externalDomain)
+	if err != nil {
+		return nil, err
+	}
+	return handler(authz.WithInstance(ctx, instance), req)
+}
+
+func instanceByRequestedHost(ctx context.Context, verifier authz.InstanceVerifier, translator *i18n.Translator, externalDomain string) (authz.Instance, error) {
 	requestContext := zitadel_http.DomainContext(ctx)
 	if requestContext.InstanceHost == "" {
 		logging.WithFields("origin", requestContext.Origin(), "externalDomain", externalDomain).Error("unable to set instance")
@@ -107,5 +116,30 @@ func addInstanceByRequestedHost(ctx context.Context, req interface{}, handler gr
 		}
 		return nil, status.Error(codes.NotFound, fmt.Sprintf("unable to set instance using origin %s (ExternalDomain is %s)", origin, externalDomain))
 	}
-	return handler(authz.WithInstance(ctx, instance), req)
+	return instance, nil
+}
+
+// InstanceStreamInterceptor sets the instance for streaming calls based on the requested host.
+// As the messages are only received in the handler, the instance can't be passed in the request.
+// Streams of the ignored services (e.g. system, health and reflection) are not bound to an instance.
+func InstanceStreamInterceptor(verifier authz.InstanceVerifier, externalDomain string, ignoredServices ...string) grpc.StreamServerInterceptor {
+	translator, err := i18n.NewZitadelTranslator(language.English)
+	logging.OnError(err).Panic("unable to get translator")
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for _, service := range ignoredServices {
+			if !strings.HasPrefix(service, "/") {
+				service = "/" + service
+			}
+			if strings.HasPrefix(info.FullMethod, service) {
+				return handler(srv, stream)
+			}
+		}
+		instance, err := instanceByRequestedHost(stream.Context(), verifier, translator, externalDomain)
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = authz.WithInstance(stream.Context(), instance)
+		return handler(srv, wrapped)
+	}
 }
@@ -295,3 +295,60 @@ func (m *mockInstance) EnableImpersonation() bool {
 func (m *mockInstance) Features() feature.Features {
 	return feature.Features{}
 }
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestInstanceStreamInterceptor(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		fullMethod   string
+		wantInstance bool
+		wantErr      bool
+	}{
+		{
+			name:       "ignored service, no instance",
+			ctx:        context.Background(),
+			fullMethod: "/grpc.health.v1.Health/Watch",
+		},
+		{
+			name:       "instance not found, error",
+			ctx:        context.Background(),
+			fullMethod: "/zitadel.user.v2.UserService/ImportUsers",
+			wantErr:    true,
+		},
+		{
+			name:         "instance by host",
+			ctx:          http_util.WithDomainContext(context.Background(), &http_util.DomainCtx{InstanceHost: "host"}),
+			fullMethod:   "/zitadel.user.v2.UserService/ImportUsers",
+			wantInstance: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := InstanceStreamInterceptor(&mockInstanceVerifier{instanceHost: "host"}, "", "grpc.health.v1.Health", "/zitadel.system.v1.SystemService")
+			var called bool
+			err := interceptor(nil, &mockServerStream{ctx: tt.ctx}, &grpc.StreamServerInfo{FullMethod: tt.fullMethod}, func(_ interface{}, stream grpc.ServerStream) error {
+				called = true
+				_, ok := authz.GetInstance(stream.Context()).(*mockInstance)
+				if ok != tt.wantInstance {
+					t.Errorf("instance set = %v, want %v", ok, tt.wantInstance)
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InstanceStreamInterceptor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if called == tt.wantErr {
+				t.Errorf("handler called = %v, wantErr %v", called, tt.wantErr)
+			}
+		})
+	}
+}
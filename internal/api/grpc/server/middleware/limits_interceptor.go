@@ -11,21 +11,51 @@ import (
 )
 
 func LimitsInterceptor(ignoreService ...string) grpc.UnaryServerInterceptor {
-	for idx, service := range ignoreService {
-		if !strings.HasPrefix(service, "/") {
-			ignoreService[idx] = "/" + service
+	ignoreService = prefixServices(ignoreService)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		if err := checkInstanceBlocked(ctx, info.FullMethod, ignoreService); err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
 	}
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
-		for _, service := range ignoreService {
-			if strings.HasPrefix(info.FullMethod, service) {
-				return handler(ctx, req)
-			}
+}
+
+// LimitsStreamInterceptor prevents streaming calls (e.g. bulk imports) on blocked instances.
+func LimitsStreamInterceptor(ignoreService ...string) grpc.StreamServerInterceptor {
+	ignoreService = prefixServices(ignoreService)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkInstanceBlocked(stream.Context(), info.FullMethod, ignoreService); err != nil {
+			return err
 		}
-		instance := authz.GetInstance(ctx)
-		if block := instance.Block(); block != nil && *block {
-			return nil, zerrors.ThrowResourceExhausted(nil, "LIMITS-molsj", "Errors.Limits.Instance.Blocked")
+		return handler(srv, stream)
+	}
+}
+
+func checkInstanceBlocked(ctx context.Context, fullMethod string, ignoreService []string) error {
+	if isIgnoredService(fullMethod, ignoreService) {
+		return nil
+	}
+	instance := authz.GetInstance(ctx)
+	if block := instance.Block(); block != nil && *block {
+		return zerrors.ThrowResourceExhausted(nil, "LIMITS-molsj", "Errors.Limits.Instance.Blocked")
+	}
+	return nil
+}
+
+func prefixServices(services []string) []string {
+	for idx, service := range services {
+		if !strings.HasPrefix(service, "/") {
+			services[idx] = "/" + service
+		}
+	}
+	return services
+}
+
+func isIgnoredService(fullMethod string, ignoreService []string) bool {
+	for _, service := range ignoreService {
+		if strings.HasPrefix(fullMethod, service) {
+			return true
 		}
-		return handler(ctx, req)
 	}
+	return false
 }
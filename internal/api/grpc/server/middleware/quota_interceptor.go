@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"context"
-	"strings"
 
 	"google.golang.org/grpc"
 
@@ -14,38 +13,49 @@ import (
 )
 
 func QuotaExhaustedInterceptor(svc *logstore.Service[*record.AccessLog], ignoreService ...string) grpc.UnaryServerInterceptor {
-	for idx, service := range ignoreService {
-		if !strings.HasPrefix(service, "/") {
-			ignoreService[idx] = "/" + service
-		}
-	}
+	ignoreService = prefixServices(ignoreService)
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
-		if !svc.Enabled() {
-			return handler(ctx, req)
-		}
-		interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
-		defer func() { span.EndWithError(err) }()
-
-		// The auth interceptor will ensure that only authorized or public requests are allowed.
-		// So if there's no authorization context, we don't need to check for limitation
-		// Also, we don't limit calls with system user tokens
-		ctxData := authz.GetCtxData(ctx)
-		if ctxData.IsZero() || ctxData.SystemMemberships != nil {
-			return handler(ctx, req)
+		if err := checkQuotaExhausted(ctx, svc, info.FullMethod, ignoreService); err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		for _, service := range ignoreService {
-			if strings.HasPrefix(info.FullMethod, service) {
-				return handler(ctx, req)
-			}
+// QuotaExhaustedStreamInterceptor prevents streaming calls (e.g. bulk imports) if the access quota of the instance is exhausted.
+func QuotaExhaustedStreamInterceptor(svc *logstore.Service[*record.AccessLog], ignoreService ...string) grpc.StreamServerInterceptor {
+	ignoreService = prefixServices(ignoreService)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkQuotaExhausted(stream.Context(), svc, info.FullMethod, ignoreService); err != nil {
+			return err
 		}
+		return handler(srv, stream)
+	}
+}
 
-		instance := authz.GetInstance(ctx)
-		remaining := svc.Limit(interceptorCtx, instance.InstanceID())
-		if remaining != nil && *remaining == 0 {
-			return nil, zerrors.ThrowResourceExhausted(nil, "QUOTA-vjAy8", "Quota.Access.Exhausted")
-		}
-		span.End()
-		return handler(ctx, req)
+func checkQuotaExhausted(ctx context.Context, svc *logstore.Service[*record.AccessLog], fullMethod string, ignoreService []string) (err error) {
+	if !svc.Enabled() {
+		return nil
+	}
+	interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	// The auth interceptor will ensure that only authorized or public requests are allowed.
+	// So if there's no authorization context, we don't need to check for limitation
+	// Also, we don't limit calls with system user tokens
+	ctxData := authz.GetCtxData(ctx)
+	if ctxData.IsZero() || ctxData.SystemMemberships != nil {
+		return nil
+	}
+
+	if isIgnoredService(fullMethod, ignoreService) {
+		return nil
+	}
+
+	instance := authz.GetInstance(ctx)
+	remaining := svc.Limit(interceptorCtx, instance.InstanceID())
+	if remaining != nil && *remaining == 0 {
+		return zerrors.ThrowResourceExhausted(nil, "QUOTA-vjAy8", "Quota.Access.Exhausted")
 	}
+	return nil
 }
@@ -32,3 +32,18 @@ func TracingServer(ignoredMethods ...GRPCMethod) grpc.UnaryServerInterceptor {
 		return grpc_trace.UnaryServerInterceptor()(ctx, req, info, handler)
 	}
 }
+
+func DefaultTracingStreamServer() grpc.StreamServerInterceptor {
+	return TracingStreamServer(grpc_utils.Healthz, grpc_utils.Readiness, grpc_utils.Validation)
+}
+
+func TracingStreamServer(ignoredMethods ...GRPCMethod) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for _, ignoredMethod := range ignoredMethods {
+			if strings.HasSuffix(info.FullMethod, string(ignoredMethod)) {
+				return handler(srv, stream)
+			}
+		}
+		return grpc_trace.StreamServerInterceptor()(srv, stream, info, handler)
+	}
+}
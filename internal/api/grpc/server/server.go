@@ -7,6 +7,8 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflection_v1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflection_v1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	grpc_api "github.com/zitadel/zitadel/internal/api/grpc"
@@ -63,6 +65,27 @@ func CreateServer(
 				middleware.ActivityInterceptor(),
 			),
 		),
+		// streaming calls (e.g. bulk imports) only receive their messages in the handler
+		// and therefore only run the interceptors not depending on the request
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.CallDurationStreamHandler(),
+				middleware.DefaultTracingStreamServer(),
+				middleware.InstanceStreamInterceptor(queries, externalDomain,
+					system_pb.SystemService_ServiceDesc.ServiceName,
+					healthpb.Health_ServiceDesc.ServiceName,
+					reflection_v1.ServerReflection_ServiceDesc.ServiceName,
+					reflection_v1alpha.ServerReflection_ServiceDesc.ServiceName,
+				),
+				middleware.AccessStorageStreamInterceptor(accessSvc),
+				middleware.ErrorStreamHandler(),
+				middleware.LimitsStreamInterceptor(system_pb.SystemService_ServiceDesc.ServiceName),
+				middleware.AuthorizationStreamInterceptor(verifier, authConfig),
+				middleware.QuotaExhaustedStreamInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
+				middleware.ServiceStreamHandler(),
+				middleware.ActivityStreamInterceptor(),
+			),
+		),
 	}
 	if tlsConfig != nil {
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
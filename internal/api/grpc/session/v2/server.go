@@ -1,6 +1,8 @@
 package session
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -16,6 +18,9 @@ type Server struct {
 	session.UnimplementedSessionServiceServer
 	command *command.Commands
 	query   *query.Queries
+
+	logoutCallbackURL func(ctx context.Context) string
+	samlRootURL       func(ctx context.Context, idpID string) string
 }
 
 type Config struct{}
@@ -23,10 +28,14 @@ type Config struct{}
 func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
+	logoutCallbackURL func(ctx context.Context) string,
+	samlRootURL func(ctx context.Context, idpID string) string,
 ) *Server {
 	return &Server{
-		command: command,
-		query:   query,
+		command:           command,
+		query:             query,
+		logoutCallbackURL: logoutCallbackURL,
+		samlRootURL:       samlRootURL,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/muhlemmer/gu"
+	"github.com/zitadel/logging"
 	"golang.org/x/text/language"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -105,9 +106,16 @@ func (s *Server) DeleteSession(ctx context.Context, req *session.DeleteSessionRe
 	if err != nil {
 		return nil, err
 	}
-	return &session.DeleteSessionResponse{
+	logoutURL, err := s.command.IDPLogoutURL(ctx, req.GetSessionId(), req.GetIdpPostLogoutRedirectUri(), s.logoutCallbackURL, s.samlRootURL)
+	// the session is already terminated, so a failing federated logout must not fail the request
+	logging.WithFields("session", req.GetSessionId()).OnError(err).Warn("unable to create idp logout url")
+	resp := &session.DeleteSessionResponse{
 		Details: object.DomainToDetailsPb(details),
-	}, nil
+	}
+	if logoutURL != "" {
+		resp.IdpLogoutUrl = &logoutURL
+	}
+	return resp, nil
 }
 
 func sessionsToPb(sessions []*query.Session) []*session.Session {
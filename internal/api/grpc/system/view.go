@@ -22,3 +22,19 @@ func (s *Server) ClearView(ctx context.Context, req *system_pb.ClearViewRequest)
 	}
 	return &system_pb.ClearViewResponse{}, nil
 }
+
+func (s *Server) RebuildView(ctx context.Context, req *system_pb.RebuildViewRequest) (*system_pb.RebuildViewResponse, error) {
+	err := s.query.RebuildProjection(ctx, req.ViewName)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.RebuildViewResponse{}, nil
+}
+
+func (s *Server) ListViewRebuilds(ctx context.Context, _ *system_pb.ListViewRebuildsRequest) (*system_pb.ListViewRebuildsResponse, error) {
+	rebuilds, err := s.query.SearchProjectionRebuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &system_pb.ListViewRebuildsResponse{Result: ProjectionRebuildsToPb(s.database, rebuilds)}, nil
+}
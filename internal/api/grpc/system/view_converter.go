@@ -3,6 +3,7 @@ package system
 import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query"
 	system_pb "github.com/zitadel/zitadel/pkg/grpc/system"
 )
@@ -23,3 +24,41 @@ func CurrentSequenceToPb(database string, currentSequence *query.CurrentState) *
 		LastSuccessfulSpoolerRun: timestamppb.New(currentSequence.LastRun),
 	}
 }
+
+func ProjectionRebuildsToPb(database string, rebuilds *query.ProjectionRebuilds) []*system_pb.ViewRebuild {
+	v := make([]*system_pb.ViewRebuild, len(rebuilds.Rebuilds))
+	for i, rebuild := range rebuilds.Rebuilds {
+		v[i] = ProjectionRebuildToPb(database, rebuild)
+	}
+	return v
+}
+
+func ProjectionRebuildToPb(database string, rebuild *query.ProjectionRebuild) *system_pb.ViewRebuild {
+	return &system_pb.ViewRebuild{
+		Database:       database,
+		ViewName:       rebuild.ProjectionName,
+		State:          rebuildStateToPb(rebuild.State),
+		InstancesTotal: rebuild.InstancesTotal,
+		InstancesDone:  rebuild.InstancesDone,
+		Error:          rebuild.Error,
+		StartedAt:      timestamppb.New(rebuild.StartedAt),
+		ChangedAt:      timestamppb.New(rebuild.ChangedAt),
+	}
+}
+
+func rebuildStateToPb(state handler.RebuildState) system_pb.ViewRebuildState {
+	switch state {
+	case handler.RebuildStateBuilding:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_BUILDING
+	case handler.RebuildStateSwapping:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_SWAPPING
+	case handler.RebuildStateDone:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_DONE
+	case handler.RebuildStateFailed:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_FAILED
+	case handler.RebuildStateUnspecified:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_UNSPECIFIED
+	default:
+		return system_pb.ViewRebuildState_VIEW_REBUILD_STATE_UNSPECIFIED
+	}
+}
@@ -955,3 +955,27 @@ func FooterTextPbToDomain(text *text_pb.FooterText) domain.FooterText {
 		SupportEmail:  text.SupportEmail,
 	}
 }
+
+func NotificationTemplatesToPb(templates []*query.NotificationTemplate) []*text_pb.NotificationTemplate {
+	result := make([]*text_pb.NotificationTemplate, len(templates))
+	for i, template := range templates {
+		result[i] = NotificationTemplateToPb(template)
+	}
+	return result
+}
+
+func NotificationTemplateToPb(template *query.NotificationTemplate) *text_pb.NotificationTemplate {
+	return &text_pb.NotificationTemplate{
+		Details: object.ToViewDetailsPb(
+			template.Sequence,
+			template.CreationDate,
+			template.ChangeDate,
+			template.AggregateID,
+		),
+		MessageType: template.MessageType,
+		Language:    template.Language.String(),
+		Subject:     template.Subject,
+		Html:        template.HTML,
+		Text:        template.Text,
+	}
+}
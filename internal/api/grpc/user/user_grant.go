@@ -42,6 +42,8 @@ func UserGrantToPb(assetPrefix string, grant *query.UserGrant) *user_pb.UserGran
 		GrantedOrgId:       grant.GrantedOrgID,
 		GrantedOrgName:     grant.GrantedOrgName,
 		GrantedOrgDomain:   grant.GrantedOrgDomain,
+		ValidFrom:          object.OptionalTimestampToPb(grant.ValidFrom),
+		ValidUntil:         object.OptionalTimestampToPb(grant.ValidUntil),
 		Details: object.ToViewDetailsPb(
 			grant.Sequence,
 			grant.CreationDate,
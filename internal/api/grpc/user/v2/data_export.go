@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2"
+)
+
+func (s *Server) ExportUserData(ctx context.Context, req *user.ExportUserDataRequest) (_ *user.ExportUserDataResponse, err error) {
+	// users are always allowed to export their own data
+	existing, err := s.query.GetUserByIDWithPermission(ctx, true, req.GetUserId(), s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	export, err := s.query.UserDataExport(ctx, existing.ID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := userDataExportToPb(export)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ExportUserDataResponse{Data: data}, nil
+}
+
+func userDataExportToPb(export *query.UserDataExport) (*structpb.Struct, error) {
+	raw, err := json.Marshal(export)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "USERv2-Gdpr1", "Errors.Internal")
+	}
+	data := new(structpb.Struct)
+	if err := data.UnmarshalJSON(raw); err != nil {
+		return nil, zerrors.ThrowInternal(err, "USERv2-Gdpr2", "Errors.Internal")
+	}
+	return data, nil
+}
@@ -0,0 +1,401 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2"
+)
+
+const (
+	// importUsersBatchSize is the amount of records, which are imported (pushed) together
+	importUsersBatchSize = 100
+	// exportUsersPageSize is the amount of users, which are queried together
+	exportUsersPageSize = 100
+)
+
+func (s *Server) ImportUsers(stream user.UserService_ImportUsersServer) error {
+	ctx := stream.Context()
+	batch := &importUsersBatch{
+		resourceOwner: authz.GetCtxData(ctx).OrgID,
+	}
+	orgs := make(map[string]string)
+	var position, checkpoint uint64
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return s.importUsersBatch(ctx, stream, batch)
+		}
+		if err != nil {
+			return err
+		}
+		position++
+		if position == 1 {
+			checkpoint = req.GetCheckpoint()
+		}
+		if position <= checkpoint {
+			continue
+		}
+		record := &importUsersRecord{
+			position:   position,
+			externalID: req.GetUser().GetExternalId(),
+		}
+		resourceOwner, err := s.importUsersResourceOwner(ctx, orgs, req.GetUser().GetUser().GetOrganization())
+		if err == nil {
+			record.user, err = importUserRecordToCommand(req)
+		}
+		if err != nil {
+			record.result = importUserFailedResult(record, err)
+		} else if resourceOwner != batch.resourceOwner {
+			// a batch is always imported into a single organization
+			if err := s.importUsersBatch(ctx, stream, batch); err != nil {
+				return err
+			}
+			batch.resourceOwner = resourceOwner
+		}
+		batch.records = append(batch.records, record)
+		if len(batch.records) >= importUsersBatchSize {
+			if err := s.importUsersBatch(ctx, stream, batch); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type importUsersBatch struct {
+	resourceOwner string
+	records       []*importUsersRecord
+}
+
+type importUsersRecord struct {
+	position   uint64
+	externalID string
+	user       *command.ImportUser
+	// result is already set if the record is invalid
+	result *user.ImportUserResult
+}
+
+func (s *Server) importUsersBatch(ctx context.Context, stream user.UserService_ImportUsersServer, batch *importUsersBatch) error {
+	if len(batch.records) == 0 {
+		return nil
+	}
+	users := make([]*command.ImportUser, 0, len(batch.records))
+	for _, record := range batch.records {
+		if record.result == nil {
+			users = append(users, record.user)
+		}
+	}
+	var results []*command.ImportUserResult
+	if len(users) > 0 {
+		var err error
+		results, err = s.command.ImportUsers(ctx, batch.resourceOwner, users, s.userCodeAlg)
+		if err != nil {
+			return err
+		}
+	}
+	resp := &user.ImportUsersResponse{
+		Checkpoint: batch.records[len(batch.records)-1].position,
+		Results:    make([]*user.ImportUserResult, len(batch.records)),
+	}
+	for i, record := range batch.records {
+		if record.result == nil {
+			record.result = importUserResultToPb(record, results[0])
+			results = results[1:]
+		}
+		resp.Results[i] = record.result
+	}
+	batch.records = batch.records[:0]
+	return stream.Send(resp)
+}
+
+func (s *Server) importUsersResourceOwner(ctx context.Context, orgs map[string]string, org *object_pb.Organization) (string, error) {
+	if id := org.GetOrgId(); id != "" {
+		return id, nil
+	}
+	orgDomain := org.GetOrgDomain()
+	if orgDomain == "" {
+		return authz.GetCtxData(ctx).OrgID, nil
+	}
+	if id, ok := orgs[orgDomain]; ok {
+		return id, nil
+	}
+	resourceOwner, err := s.query.OrgByVerifiedDomain(ctx, orgDomain)
+	if err != nil {
+		return "", err
+	}
+	orgs[orgDomain] = resourceOwner.ID
+	return resourceOwner.ID, nil
+}
+
+func importUserRecordToCommand(req *user.ImportUsersRequest) (*command.ImportUser, error) {
+	// the messages of a stream are not validated by the interceptors
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	human, err := AddUserRequestToAddHuman(req.GetUser().GetUser())
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]*command.ImportUserGrant, len(req.GetUser().GetGrants()))
+	for i, grant := range req.GetUser().GetGrants() {
+		grants[i] = &command.ImportUserGrant{
+			ProjectID:      grant.GetProjectId(),
+			ProjectGrantID: grant.GetProjectGrantId(),
+			RoleKeys:       grant.GetRoleKeys(),
+		}
+	}
+	return &command.ImportUser{
+		ExternalID: req.GetUser().GetExternalId(),
+		Human:      human,
+		Grants:     grants,
+	}, nil
+}
+
+func importUserResultToPb(record *importUsersRecord, result *command.ImportUserResult) *user.ImportUserResult {
+	if result.Err != nil {
+		res := importUserFailedResult(record, result.Err)
+		if result.UserID != "" {
+			res.UserId = gu.Ptr(result.UserID)
+		}
+		return res
+	}
+	state := user.ImportUserResultState_IMPORT_USER_RESULT_STATE_IMPORTED
+	if result.Skipped {
+		state = user.ImportUserResultState_IMPORT_USER_RESULT_STATE_SKIPPED
+	}
+	return &user.ImportUserResult{
+		Record:     record.position,
+		ExternalId: record.externalID,
+		UserId:     gu.Ptr(result.UserID),
+		State:      state,
+	}
+}
+
+func importUserFailedResult(record *importUsersRecord, err error) *user.ImportUserResult {
+	return &user.ImportUserResult{
+		Record:     record.position,
+		ExternalId: record.externalID,
+		State:      user.ImportUserResultState_IMPORT_USER_RESULT_STATE_FAILED,
+		Error:      gu.Ptr(err.Error()),
+	}
+}
+
+func (s *Server) ExportUsers(req *user.ExportUsersRequest, stream user.UserService_ExportUsersServer) error {
+	ctx := stream.Context()
+	checkpoint := req.GetCheckpoint()
+	for {
+		users, err := s.exportUsersPage(ctx, req.GetOrganizationId(), checkpoint)
+		if err != nil {
+			return err
+		}
+		records, err := s.exportUserRecords(ctx, users, req.GetIncludePasswordHashes())
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if err := stream.Send(record); err != nil {
+				return err
+			}
+		}
+		if len(users) < exportUsersPageSize {
+			return nil
+		}
+		checkpoint = users[len(users)-1].ID
+	}
+}
+
+// exportUsersPage returns the next page of human users (ordered by their ID) after the checkpoint.
+// The permissions are checked afterward, so the page can be used to determine the next checkpoint.
+func (s *Server) exportUsersPage(ctx context.Context, orgID, checkpoint string) ([]*query.User, error) {
+	typeQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	queries := []query.SearchQuery{typeQuery}
+	if orgID != "" {
+		orgQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, orgQuery)
+	}
+	if checkpoint != "" {
+		checkpointQuery, err := query.NewUserIDAfterSearchQuery(checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, checkpointQuery)
+	}
+	users, err := s.query.SearchUsers(ctx, &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Limit:         exportUsersPageSize,
+			SortingColumn: query.UserIDCol,
+			Asc:           true,
+		},
+		Queries: queries,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return users.Users, nil
+}
+
+func (s *Server) exportUserRecords(ctx context.Context, users []*query.User, includePasswordHashes bool) ([]*user.ExportUsersResponse, error) {
+	records := make([]*user.ExportUsersResponse, 0, len(users))
+	userIDs := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.Human == nil || s.checkPermission(ctx, domain.PermissionUserRead, u.ResourceOwner, u.ID) != nil {
+			continue
+		}
+		userIDs = append(userIDs, u.ID)
+		records = append(records, &user.ExportUsersResponse{
+			Checkpoint: u.ID,
+			User: &user.ImportUserRecord{
+				ExternalId: u.ID,
+				User:       exportHumanToPb(u),
+			},
+		})
+	}
+	if len(records) == 0 {
+		return records, nil
+	}
+	externalIDs, err := s.query.UserImportExternalIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.exportUserIDPLinks(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.exportUserGrants(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.exportUserMetadata(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		userID := record.GetUser().GetUser().GetUserId()
+		if externalID, ok := externalIDs[userID]; ok {
+			record.User.ExternalId = externalID
+		}
+		record.User.User.IdpLinks = links[userID]
+		record.User.Grants = grants[userID]
+		resourceOwner := record.GetUser().GetUser().GetOrganization().GetOrgId()
+		record.User.User.Metadata = metadata[userID]
+		if !includePasswordHashes || s.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID) != nil {
+			continue
+		}
+		hash, err := s.query.GetHumanPassword(ctx, resourceOwner, userID)
+		if err != nil {
+			return nil, err
+		}
+		if hash != "" {
+			record.User.User.PasswordType = &user.AddHumanUserRequest_HashedPassword{
+				HashedPassword: &user.HashedPassword{Hash: hash},
+			}
+		}
+	}
+	return records, nil
+}
+
+func exportHumanToPb(u *query.User) *user.AddHumanUserRequest {
+	human := &user.AddHumanUserRequest{
+		UserId:   gu.Ptr(u.ID),
+		Username: gu.Ptr(u.Username),
+		Organization: &object_pb.Organization{
+			Org: &object_pb.Organization_OrgId{OrgId: u.ResourceOwner},
+		},
+		Profile: &user.SetHumanProfile{
+			GivenName:         u.Human.FirstName,
+			FamilyName:        u.Human.LastName,
+			NickName:          gu.Ptr(u.Human.NickName),
+			DisplayName:       gu.Ptr(u.Human.DisplayName),
+			PreferredLanguage: gu.Ptr(u.Human.PreferredLanguage.String()),
+			Gender:            gu.Ptr(genderToPb(u.Human.Gender)),
+		},
+		Email: &user.SetHumanEmail{
+			Email:        string(u.Human.Email),
+			Verification: &user.SetHumanEmail_IsVerified{IsVerified: u.Human.IsEmailVerified},
+		},
+	}
+	if u.Human.Phone != "" {
+		human.Phone = &user.SetHumanPhone{
+			Phone: string(u.Human.Phone),
+		}
+		if u.Human.IsPhoneVerified {
+			human.Phone.Verification = &user.SetHumanPhone_IsVerified{IsVerified: true}
+		}
+	}
+	return human
+}
+
+func (s *Server) exportUserIDPLinks(ctx context.Context, userIDs []string) (map[string][]*user.IDPLink, error) {
+	userIDsQuery, err := query.NewIDPUserLinksUserIDsSearchQuery(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.query.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{userIDsQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	userLinks := make(map[string][]*user.IDPLink, len(userIDs))
+	for _, link := range links.Links {
+		userLinks[link.UserID] = append(userLinks[link.UserID], &user.IDPLink{
+			IdpId:    link.IDPID,
+			UserId:   link.ProvidedUserID,
+			UserName: link.ProvidedUsername,
+		})
+	}
+	return userLinks, nil
+}
+
+func (s *Server) exportUserMetadata(ctx context.Context, userIDs []string) (map[string][]*user.SetMetadataEntry, error) {
+	metadata, err := s.query.UsersMetadata(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMetadata := make(map[string][]*user.SetMetadataEntry, len(metadata))
+	for userID, entries := range metadata {
+		userMetadata[userID] = make([]*user.SetMetadataEntry, len(entries))
+		for i, entry := range entries {
+			userMetadata[userID][i] = &user.SetMetadataEntry{
+				Key:   entry.Key,
+				Value: entry.Value,
+			}
+		}
+	}
+	return userMetadata, nil
+}
+
+func (s *Server) exportUserGrants(ctx context.Context, userIDs []string) (map[string][]*user.ImportUserGrant, error) {
+	userIDsQuery, err := query.NewUserGrantUserIDsSearchQuery(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDsQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	userGrants := make(map[string][]*user.ImportUserGrant, len(userIDs))
+	for _, grant := range grants.UserGrants {
+		importGrant := &user.ImportUserGrant{
+			ProjectId: grant.ProjectID,
+			RoleKeys:  grant.Roles,
+		}
+		if grant.GrantID != "" {
+			importGrant.ProjectGrantId = gu.Ptr(grant.GrantID)
+		}
+		userGrants[grant.UserID] = append(userGrants[grant.UserID], importGrant)
+	}
+	return userGrants, nil
+}
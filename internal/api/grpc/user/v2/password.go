@@ -67,3 +67,13 @@ func (s *Server) SetPassword(ctx context.Context, req *user.SetPasswordRequest)
 		Details: object.DomainToDetailsPb(details),
 	}, nil
 }
+
+func (s *Server) ReportPasswordCompromised(ctx context.Context, req *user.ReportPasswordCompromisedRequest) (_ *user.ReportPasswordCompromisedResponse, err error) {
+	details, err := s.command.ReportPasswordCompromised(ctx, req.GetUserId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &user.ReportPasswordCompromisedResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
@@ -3,6 +3,7 @@ package idp
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -40,12 +41,15 @@ const (
 
 	idpPrefix = "/{" + varIDPID + ":[0-9]+}"
 
-	callbackPath    = "/callback"
-	metadataPath    = idpPrefix + "/saml/metadata"
-	acsPath         = idpPrefix + "/saml/acs"
-	certificatePath = idpPrefix + "/saml/certificate"
+	callbackPath       = "/callback"
+	logoutCallbackPath = "/logout/callback"
+	metadataPath       = idpPrefix + "/saml/metadata"
+	acsPath            = idpPrefix + "/saml/acs"
+	certificatePath    = idpPrefix + "/saml/certificate"
+	sloPath            = idpPrefix + "/saml/slo"
 
 	paramIntentID         = "id"
+	paramState            = "state"
 	paramToken            = "token"
 	paramUserID           = "user"
 	paramError            = "error"
@@ -87,6 +91,14 @@ func CallbackURL() func(ctx context.Context) string {
 	}
 }
 
+// LogoutCallbackURL generates the instance specific URL to the logout callback handler,
+// where identity providers return the user after a federated logout (OIDC post_logout_redirect_uri)
+func LogoutCallbackURL() func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		return http_utils.DomainContext(ctx).Origin() + HandlerPrefix + logoutCallbackPath
+	}
+}
+
 func SAMLRootURL() func(ctx context.Context, idpID string) string {
 	return func(ctx context.Context, idpID string) string {
 		return http_utils.DomainContext(ctx).Origin() + HandlerPrefix + "/" + idpID + "/"
@@ -118,9 +130,11 @@ func NewHandler(
 	router := mux.NewRouter()
 	router.Use(instanceInterceptor)
 	router.HandleFunc(callbackPath, h.handleCallback)
+	router.HandleFunc(logoutCallbackPath, h.handleLogoutCallback)
 	router.HandleFunc(metadataPath, h.handleMetadata)
 	router.HandleFunc(certificatePath, h.handleCertificate)
 	router.HandleFunc(acsPath, h.handleACS)
+	router.HandleFunc(sloPath, h.handleSLO)
 	return router
 }
 
@@ -295,6 +309,56 @@ func (h *Handler) handleACS(w http.ResponseWriter, r *http.Request) {
 	redirectToSuccessURL(w, r, intent, token, userID)
 }
 
+// handleSLO handles the LogoutResponse of a SAML identity provider after a federated logout
+// and redirects the user to the (encrypted) post logout redirect uri passed as RelayState.
+func (h *Handler) handleSLO(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	data := parseSAMLRequest(r)
+
+	provider, err := h.getProvider(ctx, data.IDPID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	samlProvider, ok := provider.(*saml2.Provider)
+	if !ok {
+		err := zerrors.ThrowInvalidArgument(nil, "SAML-Eish7", "Errors.Intent.IDPInvalid")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sp, err := samlProvider.GetSP()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = sp.ServiceProvider.ValidateLogoutResponseRequest(r); err != nil {
+		http.Error(w, zerrors.ThrowInvalidArgument(err, "SAML-ooY3e", "Errors.Intent.IDPInvalid").Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, h.postLogoutRedirectURI(data.RelayState), http.StatusFound)
+}
+
+// handleLogoutCallback handles the return of the user from an OIDC identity provider after a federated logout
+// and redirects the user to the (encrypted) post logout redirect uri passed as state.
+func (h *Handler) handleLogoutCallback(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, h.postLogoutRedirectURI(r.FormValue(paramState)), http.StatusFound)
+}
+
+func (h *Handler) postLogoutRedirectURI(relayState string) string {
+	if relayState == "" {
+		return login.DefaultLoggedOutPath
+	}
+	encrypted, err := base64.RawURLEncoding.DecodeString(relayState)
+	if err != nil {
+		return login.DefaultLoggedOutPath
+	}
+	redirectURI, err := h.encryptionAlgorithm.DecryptString(encrypted, h.encryptionAlgorithm.EncryptionKeyID())
+	if err != nil || redirectURI == "" {
+		return login.DefaultLoggedOutPath
+	}
+	return redirectURI
+}
+
 func (h *Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	data, err := h.parseCallbackRequest(r)
@@ -1,13 +1,18 @@
 package idp
 
 import (
+	"encoding/base64"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
+	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/form"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -218,3 +223,39 @@ func Test_parseCallbackRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_handleLogoutCallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		want  string
+	}{
+		{
+			name: "no state",
+			want: login.DefaultLoggedOutPath,
+		},
+		{
+			name:  "invalid state",
+			state: "not base64!",
+			want:  login.DefaultLoggedOutPath,
+		},
+		{
+			name:  "post logout redirect uri",
+			state: base64.RawURLEncoding.EncodeToString([]byte("https://app.example.com/logged-out")),
+			want:  "https://app.example.com/logged-out",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				encryptionAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			req := httptest.NewRequest("GET", "https://example.com/idps/logout/callback?"+url.Values{"state": {tt.state}}.Encode(), nil)
+			resp := httptest.NewRecorder()
+
+			h.handleLogoutCallback(resp, req)
+			assert.Equal(t, http.StatusFound, resp.Code)
+			assert.Equal(t, tt.want, resp.Header().Get("Location"))
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/idp"
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/handler"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -265,6 +266,14 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	if err != nil {
 		return "", err
 	}
+	// if the user authenticated through an identity provider with federated logout enabled,
+	// the session at the identity provider needs to be terminated as well,
+	// which will then return the user to ZITADEL, where they're redirected to the post_logout_redirect_uri
+	logoutURL, err := o.command.IDPLogoutURL(ctx, endSessionRequest.IDTokenHintClaims.SessionID, endSessionRequest.RedirectURI, idp.LogoutCallbackURL(), idp.SAMLRootURL())
+	logging.WithFields("session", endSessionRequest.IDTokenHintClaims.SessionID).OnError(err).Warn("unable to create idp logout url")
+	if logoutURL != "" {
+		return logoutURL, nil
+	}
 	return endSessionRequest.RedirectURI, nil
 }
 
@@ -35,6 +35,8 @@ const (
 	ClaimProjectRolesFormat         = "urn:zitadel:iam:org:project:%s:roles"
 	ScopeUserMetaData               = "urn:zitadel:iam:user:metadata"
 	ClaimUserMetaData               = ScopeUserMetaData
+	ScopeUserGroups                 = "urn:zitadel:iam:user:groups"
+	ClaimUserGroups                 = ScopeUserGroups
 	ScopeResourceOwner              = "urn:zitadel:iam:user:resourceowner"
 	ClaimResourceOwnerID            = ScopeResourceOwner + ":id"
 	ClaimResourceOwnerName          = ScopeResourceOwner + ":name"
@@ -814,16 +816,24 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 	if err != nil {
 		return nil, nil, err
 	}
+	validityQuery, err := query.NewUserGrantValidityQuery()
+	if err != nil {
+		return nil, nil, err
+	}
 	grants, err := o.query.UserGrants(ctx, &query.UserGrantsQueries{
 		Queries: []query.SearchQuery{
 			projectQuery,
 			userIDQuery,
 			activeQuery,
+			validityQuery,
 		},
 	}, true)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err = o.query.ExpandUserGrantRoles(ctx, grants); err != nil {
+		return nil, nil, err
+	}
 	roles := new(projectsRoles)
 	// if specific roles where requested, check if they are granted and append them in the roles list
 	if len(requestedRoles) > 0 {
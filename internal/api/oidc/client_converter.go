@@ -250,6 +250,9 @@ func isScopeAllowed(scope string, allowedScopes ...string) bool {
 	if scope == ScopeUserMetaData {
 		return true
 	}
+	if scope == ScopeUserGroups {
+		return true
+	}
 	if scope == ScopeResourceOwner {
 		return true
 	}
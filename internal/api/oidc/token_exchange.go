@@ -58,6 +58,17 @@ func (s *Server) tokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 		return nil, oidc.ErrInvalidRequest().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("subject_token invalid")
 	}
 
+	if idpID := r.Form.Get(RequestedIssuerParam); idpID != "" {
+		if r.Data.ActorToken != "" || (subjectToken.tokenType != oidc.AccessTokenType && subjectToken.tokenType != oidc.IDTokenType) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("requested_issuer is only supported for access and id tokens without actor_token")
+		}
+		resp, err := s.exchangeIDPToken(ctx, client, subjectToken, idpID, r.Data.RequestedTokenType)
+		if err != nil {
+			return nil, err
+		}
+		return op.NewResponse(resp), nil
+	}
+
 	actorToken := subjectToken // see [createExchangeTokens] comment.
 	if subjectToken.tokenType == UserIDTokenType || subjectToken.tokenType == oidc.JWTTokenType || r.Data.ActorToken != "" {
 		if !authz.GetInstance(ctx).EnableImpersonation() {
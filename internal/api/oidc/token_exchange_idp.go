@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// RequestedIssuerParam allows to exchange a ZITADEL token of the user for the access token
+// of the external identity provider (identified by its ID) the user authenticated with.
+const RequestedIssuerParam = "requested_issuer"
+
+// exchangeIDPToken returns the stored access token of the external identity provider of the subject.
+// Only the client the subject token was issued for, is allowed to retrieve the token.
+// Additionally, the client must be explicitly allowed on the identity provider, which is checked by the command.
+func (s *Server) exchangeIDPToken(ctx context.Context, client *Client, subjectToken *exchangeToken, idpID string, requestedTokenType oidc.TokenType) (*oidc.TokenExchangeResponse, error) {
+	if requestedTokenType != "" && requestedTokenType != oidc.AccessTokenType {
+		return nil, zerrors.ThrowInvalidArgument(nil, "OIDC-Ahj3u", "Errors.TokenExchange.Token.TypeNotSupported")
+	}
+	if !slices.Contains(subjectToken.audience, client.GetID()) && !slices.Contains(subjectToken.audience, client.client.ProjectID) {
+		return nil, zerrors.ThrowPermissionDenied(nil, "OIDC-eeK5a", "Errors.TokenExchange.Token.Invalid")
+	}
+	token, err := s.command.GetUserIDPLinkAccessToken(authz.SetCtxData(ctx, authz.CtxData{
+		UserID: subjectToken.userID,
+		OrgID:  subjectToken.resourceOwner,
+	}), subjectToken.userID, subjectToken.resourceOwner, idpID, client.GetID())
+	if err != nil {
+		return nil, err
+	}
+	resp := &oidc.TokenExchangeResponse{
+		AccessToken:     token.AccessToken,
+		IssuedTokenType: oidc.AccessTokenType,
+		TokenType:       token.TokenType,
+	}
+	if resp.TokenType == "" {
+		resp.TokenType = oidc.BearerToken
+	}
+	if !token.Expiry.IsZero() {
+		resp.ExpiresIn = uint64(time.Until(token.Expiry).Seconds())
+	}
+	return resp, nil
+}
@@ -193,6 +193,8 @@ func userInfoToOIDC(user *query.OIDCUserInfo, userInfoAssertion bool, scope []st
 			// TODO: handle address for human users as soon as implemented
 		case ScopeUserMetaData:
 			setUserInfoMetadata(user.Metadata, out)
+		case ScopeUserGroups:
+			setUserInfoGroups(user.Groups, out)
 		case ScopeResourceOwner:
 			setUserInfoOrgClaims(user, out)
 		default:
@@ -273,6 +275,18 @@ func setUserInfoMetadata(metadata []query.UserMetadata, out *oidc.UserInfo) {
 	out.AppendClaims(ClaimUserMetaData, mdmap)
 }
 
+// setUserInfoGroups asserts the groups of the user as map of the group ID to its name.
+func setUserInfoGroups(groups []query.UserInfoGroup, out *oidc.UserInfo) {
+	if len(groups) == 0 {
+		return
+	}
+	groupMap := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupMap[group.ID] = group.Name
+	}
+	out.AppendClaims(ClaimUserGroups, groupMap)
+}
+
 func setUserInfoOrgClaims(user *query.OIDCUserInfo, out *oidc.UserInfo) {
 	if org := user.Org; org != nil {
 		out.AppendClaims(ClaimResourceOwnerID, org.ID)
@@ -207,6 +207,13 @@ func Test_userInfoToOIDC(t *testing.T) {
 		},
 		Metadata: metadata,
 		Org:      organization,
+		Groups: []query.UserInfoGroup{
+			{
+				ID:            "group1",
+				Name:          "developers",
+				ResourceOwner: "orgID",
+			},
+		},
 		UserGrants: []query.UserGrant{
 			{
 				ID:                "ug1",
@@ -459,6 +466,31 @@ func Test_userInfoToOIDC(t *testing.T) {
 				Subject: "machine1",
 			},
 		},
+		{
+			name: "human, scope groups",
+			args: args{
+				user:  humanUserInfo,
+				scope: []string{ScopeUserGroups},
+			},
+			want: &oidc.UserInfo{
+				Subject: "human1",
+				Claims: map[string]any{
+					ClaimUserGroups: map[string]string{
+						"group1": "developers",
+					},
+				},
+			},
+		},
+		{
+			name: "machine, scope groups, none found",
+			args: args{
+				user:  machineUserInfo,
+				scope: []string{ScopeUserGroups},
+			},
+			want: &oidc.UserInfo{
+				Subject: "machine1",
+			},
+		},
 		{
 			name: "machine, scope resource owner",
 			args: args{
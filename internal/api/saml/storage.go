@@ -334,13 +334,25 @@ func (p *Storage) getGrants(ctx context.Context, userID, applicationID string) (
 	if err != nil {
 		return nil, err
 	}
-	return p.query.UserGrants(ctx, &query.UserGrantsQueries{
+	validityQuery, err := query.NewUserGrantValidityQuery()
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.query.UserGrants(ctx, &query.UserGrantsQueries{
 		Queries: []query.SearchQuery{
 			projectQuery,
 			userIDQuery,
 			activeQuery,
+			validityQuery,
 		},
 	}, true)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.query.ExpandUserGrantRoles(ctx, grants); err != nil {
+		return nil, err
+	}
+	return grants, nil
 }
 
 type customAttribute struct {
@@ -0,0 +1,418 @@
+package ssf
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ConfigurationPath = "/.well-known/ssf-configuration"
+	HandlerPrefix     = "/ssf"
+
+	streamsPath      = HandlerPrefix + "/streams"
+	statusPath       = HandlerPrefix + "/status"
+	verificationPath = HandlerPrefix + "/verify"
+	// keysPath is the JWKS endpoint of the OIDC provider, which serves the keys used to sign the security event tokens
+	keysPath = "/oauth/v2/keys"
+
+	paramStreamID = "stream_id"
+
+	permissionRead   = "iam.ssf.read"
+	permissionWrite  = "iam.ssf.write"
+	permissionDelete = "iam.ssf.delete"
+)
+
+// Prefixes are the path prefixes the handler has to be registered on
+var Prefixes = []string{ConfigurationPath, HandlerPrefix}
+
+type Handler struct {
+	commands   *command.Commands
+	queries    *query.Queries
+	verifier   authz.APITokenVerifier
+	authConfig authz.Config
+	translator *i18n.Translator
+}
+
+// NewHandler returns the Shared Signals Framework transmitter API,
+// which allows receivers to manage their streams.
+func NewHandler(
+	commands *command.Commands,
+	queries *query.Queries,
+	verifier authz.APITokenVerifier,
+	authConfig authz.Config,
+	instanceInterceptor func(next http.Handler) http.Handler,
+) http.Handler {
+	translator, err := i18n.NewZitadelTranslator(language.English)
+	logging.OnError(err).Panic("unable to get translator")
+	h := &Handler{
+		commands:   commands,
+		queries:    queries,
+		verifier:   verifier,
+		authConfig: authConfig,
+		translator: translator,
+	}
+
+	router := mux.NewRouter()
+	router.Use(instanceInterceptor)
+	router.HandleFunc(ConfigurationPath, h.handleConfiguration).Methods(http.MethodGet)
+	router.HandleFunc(streamsPath, h.authorize(permissionRead, h.handleGetStreams)).Methods(http.MethodGet)
+	router.HandleFunc(streamsPath, h.authorize(permissionWrite, h.handleCreateStream)).Methods(http.MethodPost)
+	router.HandleFunc(streamsPath, h.authorize(permissionWrite, h.handleUpdateStream)).Methods(http.MethodPatch)
+	router.HandleFunc(streamsPath, h.authorize(permissionWrite, h.handleReplaceStream)).Methods(http.MethodPut)
+	router.HandleFunc(streamsPath, h.authorize(permissionDelete, h.handleDeleteStream)).Methods(http.MethodDelete)
+	router.HandleFunc(statusPath, h.authorize(permissionRead, h.handleGetStatus)).Methods(http.MethodGet)
+	router.HandleFunc(statusPath, h.authorize(permissionWrite, h.handleUpdateStatus)).Methods(http.MethodPost)
+	router.HandleFunc(verificationPath, h.authorize(permissionWrite, h.handleVerification)).Methods(http.MethodPost)
+	return http_utils.CopyHeadersToContext(router)
+}
+
+type httpReq struct{}
+
+// authorize checks the bearer token of the request for the required instance permission.
+func (h *Handler) authorize(permission string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.NewServerInterceptorSpan(r.Context())
+		token := http_utils.GetAuthorization(r)
+		if token == "" {
+			span.End()
+			h.writeError(w, r, zerrors.ThrowUnauthenticated(nil, "SSF-Sf8a1", "auth header missing"))
+			return
+		}
+		ctxSetter, err := authz.CheckUserAuthorization(ctx, &httpReq{}, token, "", "", h.verifier, h.authConfig, authz.Option{Permission: permission}, r.URL.Path)
+		span.EndWithError(err)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		next(w, r.WithContext(ctxSetter(r.Context())))
+	}
+}
+
+// configuration is the transmitter configuration metadata as defined by the SSF specification.
+type configuration struct {
+	SpecVersion              string                `json:"spec_version"`
+	Issuer                   string                `json:"issuer"`
+	JWKSURI                  string                `json:"jwks_uri"`
+	DeliveryMethodsSupported []string              `json:"delivery_methods_supported"`
+	ConfigurationEndpoint    string                `json:"configuration_endpoint"`
+	StatusEndpoint           string                `json:"status_endpoint"`
+	VerificationEndpoint     string                `json:"verification_endpoint"`
+	AuthorizationSchemes     []authorizationScheme `json:"authorization_schemes"`
+}
+
+type authorizationScheme struct {
+	SpecURN string `json:"spec_urn"`
+}
+
+func (h *Handler) handleConfiguration(w http.ResponseWriter, r *http.Request) {
+	issuer := http_utils.DomainContext(r.Context()).Origin()
+	http_utils.MarshalJSON(w, &configuration{
+		SpecVersion:              "1_0",
+		Issuer:                   issuer,
+		JWKSURI:                  issuer + keysPath,
+		DeliveryMethodsSupported: []string{domain.SSFDeliveryMethodPush},
+		ConfigurationEndpoint:    issuer + streamsPath,
+		StatusEndpoint:           issuer + statusPath,
+		VerificationEndpoint:     issuer + verificationPath,
+		AuthorizationSchemes:     []authorizationScheme{{SpecURN: "urn:ietf:rfc:6749"}},
+	}, nil, http.StatusOK)
+}
+
+// streamConfiguration is the stream configuration as defined by the SSF specification.
+// The authorization header is only accepted as input and never returned.
+type streamConfiguration struct {
+	StreamID        string    `json:"stream_id,omitempty"`
+	Issuer          string    `json:"iss,omitempty"`
+	Audience        audience  `json:"aud,omitempty"`
+	EventsSupported []string  `json:"events_supported,omitempty"`
+	EventsRequested *[]string `json:"events_requested,omitempty"`
+	EventsDelivered []string  `json:"events_delivered,omitempty"`
+	Delivery        *delivery `json:"delivery,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+}
+
+type delivery struct {
+	Method              string  `json:"method"`
+	EndpointURL         *string `json:"endpoint_url,omitempty"`
+	AuthorizationHeader *string `json:"authorization_header,omitempty"`
+}
+
+// audience is either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (h *Handler) streamConfigurationFromQuery(r *http.Request, stream *query.SSFStream) *streamConfiguration {
+	return &streamConfiguration{
+		StreamID:        stream.ID,
+		Issuer:          http_utils.DomainContext(r.Context()).Origin(),
+		Audience:        audience(stream.Audience),
+		EventsSupported: domain.SSFSupportedEventTypes,
+		EventsRequested: (*[]string)(&stream.EventsRequested),
+		EventsDelivered: stream.EventsDelivered(),
+		Delivery: &delivery{
+			Method:      stream.DeliveryMethod,
+			EndpointURL: &stream.EndpointURL,
+		},
+		Description: &stream.Description,
+	}
+}
+
+func (h *Handler) handleGetStreams(w http.ResponseWriter, r *http.Request) {
+	streamID := r.URL.Query().Get(paramStreamID)
+	if streamID != "" {
+		stream, err := h.queries.SSFStreamByID(r.Context(), true, streamID)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		http_utils.MarshalJSON(w, h.streamConfigurationFromQuery(r, stream), nil, http.StatusOK)
+		return
+	}
+	streams, err := h.queries.SearchSSFStreams(r.Context(), &query.SSFStreamSearchQueries{})
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	configs := make([]*streamConfiguration, len(streams.Streams))
+	for i, stream := range streams.Streams {
+		configs[i] = h.streamConfigurationFromQuery(r, stream)
+	}
+	http_utils.MarshalJSON(w, configs, nil, http.StatusOK)
+}
+
+func (h *Handler) handleCreateStream(w http.ResponseWriter, r *http.Request) {
+	config, err := parseStreamConfiguration(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	stream := &domain.SSFStream{
+		Audience: config.Audience,
+	}
+	// the receiver is the audience of the stream, if it's not specified otherwise
+	if len(stream.Audience) == 0 {
+		stream.Audience = []string{authz.GetCtxData(r.Context()).UserID}
+	}
+	if config.Description != nil {
+		stream.Description = *config.Description
+	}
+	if config.EventsRequested != nil {
+		stream.EventsRequested = *config.EventsRequested
+	}
+	if config.Delivery != nil {
+		stream.DeliveryMethod = config.Delivery.Method
+		if config.Delivery.EndpointURL != nil {
+			stream.EndpointURL = *config.Delivery.EndpointURL
+		}
+		if config.Delivery.AuthorizationHeader != nil {
+			stream.AuthorizationHeader = *config.Delivery.AuthorizationHeader
+		}
+	}
+	if _, err = h.commands.AddSSFStream(r.Context(), stream); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeStream(w, r, stream.AggregateID, http.StatusCreated)
+}
+
+func (h *Handler) handleUpdateStream(w http.ResponseWriter, r *http.Request) {
+	config, err := parseStreamConfiguration(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.changeStream(w, r, changeFromStreamConfiguration(config))
+}
+
+// handleReplaceStream replaces the whole configuration,
+// so properties which are not present are reset.
+func (h *Handler) handleReplaceStream(w http.ResponseWriter, r *http.Request) {
+	config, err := parseStreamConfiguration(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	change := changeFromStreamConfiguration(config)
+	if change.Audience == nil {
+		change.Audience = []string{authz.GetCtxData(r.Context()).UserID}
+	}
+	if change.Description == nil {
+		change.Description = new(string)
+	}
+	if change.EventsRequested == nil {
+		change.EventsRequested = []string{}
+	}
+	if change.AuthorizationHeader == nil {
+		change.AuthorizationHeader = new(string)
+	}
+	if change.EndpointURL == nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(nil, "SSF-Sf8e2", "Errors.SSF.InvalidURL"))
+		return
+	}
+	h.changeStream(w, r, change)
+}
+
+func (h *Handler) changeStream(w http.ResponseWriter, r *http.Request, change *command.ChangeSSFStream) {
+	if _, err := h.commands.ChangeSSFStream(r.Context(), change); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeStream(w, r, change.StreamID, http.StatusOK)
+}
+
+func changeFromStreamConfiguration(config *streamConfiguration) *command.ChangeSSFStream {
+	change := &command.ChangeSSFStream{
+		StreamID:    config.StreamID,
+		Description: config.Description,
+		Audience:    config.Audience,
+	}
+	if config.EventsRequested != nil {
+		change.EventsRequested = *config.EventsRequested
+		// an empty list must be distinguishable from no list
+		if change.EventsRequested == nil {
+			change.EventsRequested = []string{}
+		}
+	}
+	if config.Delivery != nil {
+		change.EndpointURL = config.Delivery.EndpointURL
+		change.AuthorizationHeader = config.Delivery.AuthorizationHeader
+	}
+	return change
+}
+
+func (h *Handler) handleDeleteStream(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.commands.RemoveSSFStream(r.Context(), r.URL.Query().Get(paramStreamID)); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// streamStatus is the stream status as defined by the SSF specification.
+type streamStatus struct {
+	StreamID string `json:"stream_id"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func (h *Handler) handleGetStatus(w http.ResponseWriter, r *http.Request) {
+	stream, err := h.queries.SSFStreamByID(r.Context(), true, r.URL.Query().Get(paramStreamID))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	http_utils.MarshalJSON(w, &streamStatus{
+		StreamID: stream.ID,
+		Status:   stream.State.String(),
+		Reason:   stream.StatusReason,
+	}, nil, http.StatusOK)
+}
+
+func (h *Handler) handleUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	status := new(streamStatus)
+	if err := json.NewDecoder(r.Body).Decode(status); err != nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "SSF-Sf8s3", "Errors.SSF.InvalidRequest"))
+		return
+	}
+	state, ok := domain.SSFStreamStateFromString(status.Status)
+	if !ok {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(nil, "SSF-Sf8s4", "Errors.SSF.InvalidStatus"))
+		return
+	}
+	if _, err := h.commands.UpdateSSFStreamStatus(r.Context(), status.StreamID, state, status.Reason); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	http_utils.MarshalJSON(w, status, nil, http.StatusOK)
+}
+
+type verificationRequest struct {
+	StreamID string `json:"stream_id"`
+	State    string `json:"state,omitempty"`
+}
+
+// handleVerification requests a verification event, which is delivered asynchronously.
+func (h *Handler) handleVerification(w http.ResponseWriter, r *http.Request) {
+	verification := new(verificationRequest)
+	if err := json.NewDecoder(r.Body).Decode(verification); err != nil {
+		h.writeError(w, r, zerrors.ThrowInvalidArgument(err, "SSF-Sf8v5", "Errors.SSF.InvalidRequest"))
+		return
+	}
+	if _, err := h.commands.RequestSSFStreamVerification(r.Context(), verification.StreamID, verification.State); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseStreamConfiguration(r *http.Request) (*streamConfiguration, error) {
+	config := new(streamConfiguration)
+	if err := json.NewDecoder(r.Body).Decode(config); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SSF-Sf8p6", "Errors.SSF.InvalidRequest")
+	}
+	return config, nil
+}
+
+func (h *Handler) writeStream(w http.ResponseWriter, r *http.Request, streamID string, statusCode int) {
+	stream, err := h.queries.SSFStreamByID(r.Context(), true, streamID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(statusCode)
+	err = json.NewEncoder(w).Encode(h.streamConfigurationFromQuery(r, stream))
+	logging.WithFields("logID", "SSF-Sf8w7").OnError(err).Error("error writing response")
+}
+
+type errorResponse struct {
+	Error       string `json:"err"`
+	Description string `json:"description"`
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	logging.WithFields("uri", r.URL.Path).WithError(err).Debug("error occurred on ssf api")
+	code, ok := http_utils.ZitadelErrorToHTTPStatusCode(err)
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	description := err.Error()
+	zErr := new(zerrors.ZitadelError)
+	if errors.As(err, &zErr) {
+		description = h.translator.LocalizeFromCtx(r.Context(), zErr.GetMessage(), nil)
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	err = json.NewEncoder(w).Encode(&errorResponse{
+		Error:       http.StatusText(code),
+		Description: description,
+	})
+	logging.WithFields("logID", "SSF-Sf8w8").OnError(err).Error("error writing response")
+}
@@ -0,0 +1,138 @@
+package ssf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+)
+
+func TestMain(m *testing.M) {
+	i18n.SupportLanguages(language.English)
+	m.Run()
+}
+
+func TestHandler_handleConfiguration(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, ConfigurationPath, nil)
+	req = req.WithContext(http_utils.WithDomainContext(req.Context(), &http_utils.DomainCtx{InstanceHost: "issuer.zitadel.cloud", Protocol: "https"}))
+	resp := httptest.NewRecorder()
+
+	new(Handler).handleConfiguration(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	got := new(configuration)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), got))
+	assert.Equal(t, &configuration{
+		SpecVersion:              "1_0",
+		Issuer:                   "https://issuer.zitadel.cloud",
+		JWKSURI:                  "https://issuer.zitadel.cloud/oauth/v2/keys",
+		DeliveryMethodsSupported: []string{domain.SSFDeliveryMethodPush},
+		ConfigurationEndpoint:    "https://issuer.zitadel.cloud/ssf/streams",
+		StatusEndpoint:           "https://issuer.zitadel.cloud/ssf/status",
+		VerificationEndpoint:     "https://issuer.zitadel.cloud/ssf/verify",
+		AuthorizationSchemes:     []authorizationScheme{{SpecURN: "urn:ietf:rfc:6749"}},
+	}, got)
+}
+
+func TestHandler_authorize(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, streamsPath, nil)
+	resp := httptest.NewRecorder()
+	translator, err := i18n.NewZitadelTranslator(language.English)
+	require.NoError(t, err)
+	h := &Handler{translator: translator}
+
+	h.authorize(permissionRead, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler must not be called without authorization")
+	})(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func Test_audience_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    audience
+		wantErr bool
+	}{
+		{
+			name: "single",
+			data: `"receiver"`,
+			want: audience{"receiver"},
+		},
+		{
+			name: "multiple",
+			data: `["receiver1","receiver2"]`,
+			want: audience{"receiver1", "receiver2"},
+		},
+		{
+			name:    "invalid",
+			data:    `1`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got audience
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_changeFromStreamConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   *command.ChangeSSFStream
+	}{
+		{
+			name:   "only stream id",
+			config: `{"stream_id":"stream1"}`,
+			want:   &command.ChangeSSFStream{StreamID: "stream1"},
+		},
+		{
+			name:   "remove requested events",
+			config: `{"stream_id":"stream1","events_requested":[]}`,
+			want: &command.ChangeSSFStream{
+				StreamID:        "stream1",
+				EventsRequested: []string{},
+			},
+		},
+		{
+			name: "all",
+			config: `{"stream_id":"stream1","aud":"receiver","description":"desc","events_requested":["` + domain.CAEPEventTypeSessionRevoked + `"],` +
+				`"delivery":{"method":"urn:ietf:rfc:8935","endpoint_url":"https://receiver.com/events","authorization_header":""}}`,
+			want: &command.ChangeSSFStream{
+				StreamID:            "stream1",
+				Description:         gu.Ptr("desc"),
+				Audience:            []string{"receiver"},
+				EventsRequested:     []string{domain.CAEPEventTypeSessionRevoked},
+				EndpointURL:         gu.Ptr("https://receiver.com/events"),
+				AuthorizationHeader: gu.Ptr(""),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := new(streamConfiguration)
+			require.NoError(t, json.Unmarshal([]byte(tt.config), config))
+			assert.Equal(t, tt.want, changeFromStreamConfiguration(config))
+		})
+	}
+}
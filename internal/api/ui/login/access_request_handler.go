@@ -0,0 +1,144 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	tmplAccessRequest     = "accessrequest"
+	tmplAccessRequestDone = "accessrequestdone"
+)
+
+type accessRequestFormData struct {
+	RoleKeys []string `schema:"roleKeys"`
+	Reason   string   `schema:"reason"`
+}
+
+type accessRequestData struct {
+	userData
+	ProjectName string
+	Roles       []*query.ProjectRole
+	Pending     *query.AccessRequest
+}
+
+// renderAccessRequest replaces the former "access denied" error if the user has no grant on the project.
+// The user can request roles of the project, the request is decided by the approvers of the project.
+func (l *Login) renderAccessRequest(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	ctx := r.Context()
+	project, err := l.query.ProjectByClientID(ctx, authReq.ApplicationID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	translator := l.getTranslator(ctx, authReq)
+	data := &accessRequestData{
+		userData:    l.getUserData(r, authReq, translator, "AccessRequest.Title", "AccessRequest.Description", errID, errMessage),
+		ProjectName: project.Name,
+	}
+	data.Pending, err = l.pendingAccessRequest(r, authReq, project.ID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if data.Pending == nil {
+		data.Roles, err = l.requestableRoles(r, authReq, project.ID)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplAccessRequest], data, nil)
+}
+
+func (l *Login) handleAccessRequest(w http.ResponseWriter, r *http.Request) {
+	data := new(accessRequestFormData)
+	authReq, err := l.ensureAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	ctx := r.Context()
+	project, err := l.query.ProjectByClientID(ctx, authReq.ApplicationID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	resourceOwner, projectGrantID, err := l.query.UserGrantTargetOfOrg(ctx, project.ID, authReq.UserOrgID)
+	if err != nil {
+		l.renderAccessRequest(w, r, authReq, err)
+		return
+	}
+	_, err = l.command.AddAccessRequest(setUserContext(ctx, authReq.UserID, authReq.UserOrgID), &domain.AccessRequest{
+		UserID:         authReq.UserID,
+		ProjectID:      project.ID,
+		ProjectGrantID: projectGrantID,
+		RoleKeys:       data.RoleKeys,
+		Reason:         data.Reason,
+	}, resourceOwner)
+	if err != nil {
+		l.renderAccessRequest(w, r, authReq, err)
+		return
+	}
+	l.renderAccessRequestDone(w, r, authReq)
+}
+
+func (l *Login) renderAccessRequestDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
+	var errID, errMessage string
+	translator := l.getTranslator(r.Context(), authReq)
+	data := l.getUserData(r, authReq, translator, "AccessRequestDone.Title", "AccessRequestDone.Description", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplAccessRequestDone], data, nil)
+}
+
+func (l *Login) pendingAccessRequest(r *http.Request, authReq *domain.AuthRequest, projectID string) (*query.AccessRequest, error) {
+	userQuery, err := query.NewAccessRequestUserIDSearchQuery(authReq.UserID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := query.NewAccessRequestProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	stateQuery, err := query.NewAccessRequestStateSearchQuery(domain.AccessRequestStatePending)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := l.query.SearchAccessRequests(r.Context(), &query.AccessRequestSearchQueries{
+		Queries: []query.SearchQuery{userQuery, projectQuery, stateQuery},
+	})
+	if err != nil || len(requests.AccessRequests) == 0 {
+		return nil, err
+	}
+	return requests.AccessRequests[0], nil
+}
+
+// requestableRoles are the roles of the project,
+// or the granted roles if the organization of the user has a grant on the project.
+func (l *Login) requestableRoles(r *http.Request, authReq *domain.AuthRequest, projectID string) ([]*query.ProjectRole, error) {
+	ctx := r.Context()
+	_, projectGrantID, err := l.query.UserGrantTargetOfOrg(ctx, projectID, authReq.UserOrgID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.ProjectRoleSearchQueries{Queries: []query.SearchQuery{projectQuery}}
+	var roles *query.ProjectRoles
+	if projectGrantID != "" {
+		roles, err = l.query.SearchGrantedProjectRoles(ctx, projectGrantID, authReq.UserOrgID, queries)
+	} else {
+		roles, err = l.query.SearchProjectRoles(ctx, false, queries)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return roles.ProjectRoles, nil
+}
@@ -396,6 +396,12 @@ func (l *Login) handleExternalUserAuthenticated(
 	callback func(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest),
 ) {
 	externalUser := mapIDPUserToExternalUser(user, provider.ID)
+	groupClaims, err := l.externalUserGroupClaims(r.Context(), provider, session, user)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	externalUser.GroupClaims = groupClaims
 	// ensure the linked IDP is added to the login policy
 	if err := l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, provider.ID, authReq.AgentID); err != nil {
 		l.renderError(w, r, authReq, err)
@@ -418,7 +424,6 @@ func (l *Login) handleExternalUserAuthenticated(
 			externalErr = nil
 		}
 	}
-	var err error
 	// read current auth request state (incl. authorized user)
 	authReq, err = l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
 	if err != nil {
@@ -449,9 +454,83 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
+	if err = l.syncExternalUserGroups(r.Context(), authReq, externalUser); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if sessionTokens := tokens(session); sessionTokens != nil {
+		err = l.command.SetUserIDPLinkTokens(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, provider.ID, externalUser.ExternalUserID, sessionTokens.Token)
+		logging.WithFields("authReq", authReq.ID, "idp", provider.ID).OnError(err).Warn("unable to store idp tokens of user")
+	}
 	callback(w, r, authReq)
 }
 
+// externalUserGroupClaims returns the values of the claims (attributes) used by the group mappings of the IdP,
+// based on the external user, the id_token or the LDAP entry of the session
+func (l *Login) externalUserGroupClaims(ctx context.Context, provider *query.IDPTemplate, session idp.Session, user idp.User) (map[string][]string, error) {
+	mappings, err := l.query.IDPGroupMappingsByIDPID(ctx, provider.ID)
+	if err != nil {
+		return nil, err
+	}
+	claims := idp.ClaimValues(user, domain.IDPGroupMappingsClaims(mappings.Mappings)...)
+	for _, claim := range domain.IDPGroupMappingsClaims(mappings.Mappings) {
+		if _, ok := claims[claim]; ok {
+			continue
+		}
+		if values := sessionClaimValues(session, claim); len(values) > 0 {
+			claims[claim] = values
+		}
+	}
+	return claims, nil
+}
+
+// syncExternalUserGroups grants the roles of the group mappings of the IdP, based on the groups (claims / attributes) of the external user
+func (l *Login) syncExternalUserGroups(ctx context.Context, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) error {
+	if authReq.UserID == "" || externalUser.IDPConfigID == "" {
+		return nil
+	}
+	return l.command.SyncUserIDPGroups(setContext(ctx, authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, externalUser.IDPConfigID, externalUser.GroupClaims)
+}
+
+// syncLinkedUsersGroups syncs the groups of all external users, which were linked to the (selected) user of the auth request
+func (l *Login) syncLinkedUsersGroups(ctx context.Context, authReq *domain.AuthRequest, linkedUsers []*domain.ExternalUser) error {
+	for _, linkedUser := range linkedUsers {
+		if err := l.syncExternalUserGroups(ctx, authReq, linkedUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionClaimValues returns the values of the claim from the id_token or the LDAP entry of the session
+func sessionClaimValues(session idp.Session, claim string) []string {
+	if ldapSession, ok := session.(*ldap.Session); ok {
+		if ldapSession.Entry == nil {
+			return nil
+		}
+		return ldapSession.Entry.GetAttributeValues(claim)
+	}
+	sessionTokens := tokens(session)
+	if sessionTokens == nil || sessionTokens.IDTokenClaims == nil {
+		return nil
+	}
+	switch value := sessionTokens.IDTokenClaims.Claims[claim].(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
 // checkAutoLinking checks if a user with the provided information (username or email) already exists within ZITADEL.
 // The decision, which information will be checked is based on the IdP template option.
 // The function returns a boolean whether a user was found or not.
@@ -510,10 +589,21 @@ func (l *Login) autoLinkUser(w http.ResponseWriter, r *http.Request, authReq *do
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	linkingUsers := authReq.LinkingUsers
 	if err := l.authRepo.LinkExternalUsers(r.Context(), authReq.ID, authReq.AgentID, domain.BrowserInfoFromRequest(r)); err != nil {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	// read auth request again to get current state including the selected user
+	authReq, err := l.authRepo.AuthRequestByID(r.Context(), authReq.ID, authReq.AgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if err = l.syncLinkedUsersGroups(r.Context(), authReq, linkingUsers); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -691,6 +781,7 @@ func (l *Login) handleExternalNotFoundOptionCheck(w http.ResponseWriter, r *http
 		return
 	}
 	linkingUser := mapExternalNotFoundOptionFormDataToLoginUser(data)
+	linkingUser.GroupClaims = linkingUserGroupClaims(authReq.LinkingUsers, linkingUser)
 	l.registerExternalUser(w, r, authReq, linkingUser)
 }
 
@@ -724,6 +815,10 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if err = l.syncExternalUserGroups(r.Context(), authReq, externalUser); err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
 	userGrants, err := l.runPostCreationActions(authReq.UserID, authReq, r, resourceOwner, domain.FlowTypeExternalAuthentication)
 	if err != nil {
 		l.renderError(w, r, authReq, err)
@@ -885,6 +980,13 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 		return nil, err
 	}
 	var opts []ldap.ProviderOpts
+	groupMappings, err := l.query.IDPGroupMappingsByIDPID(ctx, identityProvider.ID)
+	if err != nil {
+		return nil, err
+	}
+	if claims := domain.IDPGroupMappingsClaims(groupMappings.Mappings); len(claims) > 0 {
+		opts = append(opts, ldap.WithAdditionalAttributes(claims...))
+	}
 	if !identityProvider.LDAPIDPTemplate.StartTLS {
 		opts = append(opts, ldap.WithoutStartTLS())
 	}
@@ -1274,6 +1376,17 @@ func mapExternalNotFoundOptionFormDataToLoginUser(formData *externalNotFoundOpti
 	}
 }
 
+// linkingUserGroupClaims returns the group claims of the external user (from the auth request),
+// since they are not part of the (editable) form data
+func linkingUserGroupClaims(linkingUsers []*domain.ExternalUser, externalUser *domain.ExternalUser) map[string][]string {
+	for _, linkingUser := range linkingUsers {
+		if linkingUser.IDPConfigID == externalUser.IDPConfigID && linkingUser.ExternalUserID == externalUser.ExternalUserID {
+			return linkingUser.GroupClaims
+		}
+	}
+	return nil
+}
+
 func (l *Login) sessionParamsFromAuthRequest(ctx context.Context, authReq *domain.AuthRequest, identityProviderID string) []idp.Parameter {
 	params := make([]idp.Parameter, 1, 2)
 	params[0] = idp.UserAgentID(authReq.AgentID)
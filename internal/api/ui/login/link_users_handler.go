@@ -14,6 +14,9 @@ const (
 func (l *Login) linkUsers(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	err = l.authRepo.LinkExternalUsers(setContext(r.Context(), authReq.UserOrgID), authReq.ID, userAgentID, domain.BrowserInfoFromRequest(r))
+	if err == nil {
+		err = l.syncLinkedUsersGroups(r.Context(), authReq, authReq.LinkingUsers)
+	}
 	l.renderLinkUsersDone(w, r, authReq, err)
 }
 
@@ -84,6 +84,8 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplLDAPLogin:                    "ldap_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
 		tmplDeviceAuthAction:             "device_action.html",
+		tmplAccessRequest:                "access_request.html",
+		tmplAccessRequestDone:            "access_request_done.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -212,6 +214,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"changeUsernameUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangeUsername)
 		},
+		"accessRequestUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointAccessRequest)
+		},
 		"externalNotFoundOptionUrl": func(action string) string {
 			return path.Join(r.pathPrefix, EndpointExternalNotFoundOption+"?"+action+"=true")
 		},
@@ -330,7 +335,7 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 	case *domain.ExternalLoginStep:
 		l.handleExternalLoginStep(w, r, authReq, step.SelectedIDPConfigID)
 	case *domain.GrantRequiredStep:
-		l.renderInternalError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "APP-asb43", "Errors.User.GrantRequired"))
+		l.renderAccessRequest(w, r, authReq, nil)
 	case *domain.ProjectRequiredStep:
 		l.renderInternalError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "APP-m92d", "Errors.User.ProjectRequired"))
 	case *domain.VerifyInviteStep:
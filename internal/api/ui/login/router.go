@@ -48,6 +48,7 @@ const (
 	EndpointLogoutDone                    = "/logout/done"
 	EndpointLoginSuccess                  = "/login/success"
 	EndpointExternalNotFoundOption        = "/externaluser/option"
+	EndpointAccessRequest                 = "/access/request"
 
 	EndpointResources        = "/resources"
 	EndpointDynamicResources = "/resources/dynamic"
@@ -122,6 +123,7 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrg).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrgCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
+	router.HandleFunc(EndpointAccessRequest, login.handleAccessRequest).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
@@ -23,29 +23,16 @@ func (repo *UserMembershipRepo) SearchMyMemberships(ctx context.Context, orgID s
 	return userMembershipsToMemberships(memberships), nil
 }
 
+func (repo *UserMembershipRepo) SearchCustomRoles(ctx context.Context, orgID string) (_ []authz.RoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return repo.Queries.CustomRoleMappingsOfOrg(ctx, orgID)
+}
+
 func (repo *UserMembershipRepo) searchUserMemberships(ctx context.Context, orgID string, shouldTriggerBulk bool) (_ []*query.Membership, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	ctxData := authz.GetCtxData(ctx)
-	userIDQuery, err := query.NewMembershipUserIDQuery(ctxData.UserID)
-	if err != nil {
-		return nil, err
-	}
-	orgIDsQuery, err := query.NewMembershipResourceOwnersSearchQuery(orgID, authz.GetInstance(ctx).InstanceID())
-	if err != nil {
-		return nil, err
-	}
-	grantedIDQuery, err := query.NewMembershipGrantedOrgIDSearchQuery(orgID)
-	if err != nil {
-		return nil, err
-	}
-	memberships, err := repo.Queries.Memberships(ctx, &query.MembershipSearchQuery{
-		Queries: []query.SearchQuery{userIDQuery, query.Or(orgIDsQuery, grantedIDQuery)},
-	}, shouldTriggerBulk)
-	if err != nil {
-		return nil, err
-	}
-	return memberships.Memberships, nil
+	return repo.Queries.UserMembershipsOfOrg(ctx, orgID, authz.GetCtxData(ctx).UserID, shouldTriggerBulk)
 }
 
 func userMembershipToMembership(membership *query.Membership) *authz.Membership {
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddAccessRequest requests the roles for the user.
+// The resource owner is the organization the user grant would belong to,
+// which is the organization of the project or the granted organization.
+func (c *Commands) AddAccessRequest(ctx context.Context, request *domain.AccessRequest, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" || !request.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ar1vA", "Errors.AccessRequest.Invalid")
+	}
+	// the request must be grantable as requested, else it could never be approved
+	if err = c.checkUserGrantPreCondition(ctx, request.UserGrant(), resourceOwner); err != nil {
+		return nil, err
+	}
+	request.AggregateID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	wm := NewAccessRequestWriteModel(request.AggregateID, resourceOwner)
+	pushedEvents, err := c.eventstore.Push(ctx, accessrequest.NewAddedEvent(ctx,
+		AccessRequestAggregateFromWriteModel(&wm.WriteModel),
+		request.UserID,
+		request.ProjectID,
+		request.ProjectGrantID,
+		request.RoleKeys,
+		request.Reason,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(wm, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// WithdrawAccessRequest cancels a pending request of the user.
+func (c *Commands) WithdrawAccessRequest(ctx context.Context, id, userID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.pendingAccessRequestWriteModel(ctx, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if wm.UserID != userID {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ar2nF", "Errors.AccessRequest.NotFound")
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, accessrequest.NewWithdrawnEvent(ctx,
+		AccessRequestAggregateFromWriteModel(&wm.WriteModel),
+		wm.UserID,
+		wm.ProjectID,
+		wm.ProjectGrantID,
+	))
+}
+
+// ApproveAccessRequest grants the requested roles by creating the user grant
+// or by adding them to the existing user grant of the user on the project (grant).
+// The user grant and the approval are pushed together.
+// Users can't approve their own requests, even if they're allowed to approve the requests of others.
+func (c *Commands) ApproveAccessRequest(ctx context.Context, id, resourceOwner, comment string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.pendingAccessRequestWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.UserID == authz.GetCtxData(ctx).UserID {
+		return nil, zerrors.ThrowPermissionDenied(nil, "COMMAND-Ar3sA", "Errors.AccessRequest.SelfApproval")
+	}
+	userGrantCmd, userGrantID, err := c.approvedUserGrant(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	cmds := make([]eventstore.Command, 0, 2)
+	if userGrantCmd != nil {
+		cmds = append(cmds, userGrantCmd)
+	}
+	cmds = append(cmds, accessrequest.NewApprovedEvent(ctx,
+		AccessRequestAggregateFromWriteModel(&wm.WriteModel),
+		wm.UserID,
+		wm.ProjectID,
+		wm.ProjectGrantID,
+		userGrantID,
+		comment,
+	))
+	return c.pushAppendAndReduceDetails(ctx, wm, cmds...)
+}
+
+// approvedUserGrant returns the command granting the requested roles and the id of the user grant.
+// If the user already holds a grant on the project (grant), the requested roles are added to it.
+// No command is returned if the user already holds all requested roles.
+func (c *Commands) approvedUserGrant(ctx context.Context, wm *AccessRequestWriteModel) (eventstore.Command, string, error) {
+	existing, err := c.activeUserGrantByProject(ctx, wm.UserID, wm.ProjectID, wm.ProjectGrantID, wm.ResourceOwner)
+	if err != nil {
+		return nil, "", err
+	}
+	if existing == nil {
+		userGrantAdded, userGrant, err := c.addUserGrant(ctx, wm.userGrant(), wm.ResourceOwner)
+		if err != nil {
+			return nil, "", err
+		}
+		return userGrantAdded, userGrant.AggregateID, nil
+	}
+	roles := slices.Clone(existing.RoleKeys)
+	for _, role := range wm.RoleKeys {
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == len(existing.RoleKeys) {
+		return nil, existing.AggregateID, nil
+	}
+	userGrant := wm.userGrant()
+	userGrant.RoleKeys = roles
+	if err = c.checkUserGrantPreCondition(ctx, userGrant, existing.ResourceOwner); err != nil {
+		return nil, "", err
+	}
+	return usergrant.NewUserGrantChangedEvent(ctx, UserGrantAggregateFromWriteModel(&existing.WriteModel), roles), existing.AggregateID, nil
+}
+
+func (c *Commands) DenyAccessRequest(ctx context.Context, id, resourceOwner, comment string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.pendingAccessRequestWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, accessrequest.NewDeniedEvent(ctx,
+		AccessRequestAggregateFromWriteModel(&wm.WriteModel),
+		wm.UserID,
+		wm.ProjectID,
+		wm.ProjectGrantID,
+		comment,
+	))
+}
+
+func (c *Commands) pendingAccessRequestWriteModel(ctx context.Context, id, resourceOwner string) (*AccessRequestWriteModel, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ar3iD", "Errors.IDMissing")
+	}
+	wm := NewAccessRequestWriteModel(id, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if wm.State == domain.AccessRequestStateUnspecified {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ar4nF", "Errors.AccessRequest.NotFound")
+	}
+	if wm.State != domain.AccessRequestStatePending {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ar5nP", "Errors.AccessRequest.NotPending")
+	}
+	return wm, nil
+}
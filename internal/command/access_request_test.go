@@ -0,0 +1,530 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func accessRequestPreConditionEvents() []eventstore.Event {
+	return []eventstore.Event{
+		eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username1",
+				"firstname1",
+				"lastname1",
+				"nickname1",
+				"displayname1",
+				language.German,
+				domain.GenderMale,
+				"email1",
+				true,
+			),
+		),
+		eventFromEventPusher(
+			project.NewProjectAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"projectname1", true, true, true,
+				domain.PrivateLabelingSettingUnspecified,
+			),
+		),
+		eventFromEventPusher(
+			project.NewRoleAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"rolekey1",
+				"rolekey",
+				"",
+			),
+		),
+	}
+}
+
+func existingUserGrantTestEvent(roleKeys ...string) eventstore.Event {
+	return eventFromEventPusher(
+		usergrant.NewUserGrantAddedEvent(context.Background(),
+			&usergrant.NewAggregate("usergrant0", "org1").Aggregate,
+			"user1",
+			"project1",
+			"",
+			roleKeys,
+		),
+	)
+}
+
+func accessRequestAddedTestEvent() eventstore.Event {
+	return eventFromEventPusher(
+		accessrequest.NewAddedEvent(context.Background(),
+			&accessrequest.NewAggregate("request1", "org1").Aggregate,
+			"user1",
+			"project1",
+			"",
+			[]string{"rolekey1"},
+			"reason",
+		),
+	)
+}
+
+func TestCommandSide_AddAccessRequest(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		request       *domain.AccessRequest
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no roles, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				request: &domain.AccessRequest{
+					UserID:    "user1",
+					ProjectID: "project1",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "role not found, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestPreConditionEvents()...),
+				),
+			},
+			args: args{
+				request: &domain.AccessRequest{
+					UserID:    "user1",
+					ProjectID: "project1",
+					RoleKeys:  []string{"rolekey2"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add access request, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestPreConditionEvents()...),
+					expectPush(
+						accessrequest.NewAddedEvent(context.Background(),
+							&accessrequest.NewAggregate("request1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+							"reason",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "request1"),
+			},
+			args: args{
+				request: &domain.AccessRequest{
+					UserID:    "user1",
+					ProjectID: "project1",
+					RoleKeys:  []string{"rolekey1"},
+					Reason:    "reason",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.AddAccessRequest(context.Background(), tt.args.request, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_WithdrawAccessRequest(t *testing.T) {
+	type args struct {
+		id     string
+		userID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name: "request not found, not found error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			args: args{
+				id:     "request1",
+				userID: "user1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "request of other user, not found error",
+			eventstore: expectEventstore(
+				expectFilter(accessRequestAddedTestEvent()),
+			),
+			args: args{
+				id:     "request1",
+				userID: "user2",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "request already withdrawn, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					accessRequestAddedTestEvent(),
+					eventFromEventPusher(
+						accessrequest.NewWithdrawnEvent(context.Background(),
+							&accessrequest.NewAggregate("request1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+						),
+					),
+				),
+			),
+			args: args{
+				id:     "request1",
+				userID: "user1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "withdraw request, ok",
+			eventstore: expectEventstore(
+				expectFilter(accessRequestAddedTestEvent()),
+				expectPush(
+					accessrequest.NewWithdrawnEvent(context.Background(),
+						&accessrequest.NewAggregate("request1", "org1").Aggregate,
+						"user1",
+						"project1",
+						"",
+					),
+				),
+			),
+			args: args{
+				id:     "request1",
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := r.WithdrawAccessRequest(context.Background(), tt.args.id, tt.args.userID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ApproveAccessRequest(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		ctx    context.Context
+		res    res
+	}{
+		{
+			name: "request denied, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						accessRequestAddedTestEvent(),
+						eventFromEventPusher(
+							accessrequest.NewDeniedEvent(context.Background(),
+								&accessrequest.NewAggregate("request1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"",
+								"",
+							),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "own request, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestAddedTestEvent()),
+				),
+			},
+			ctx: authz.NewMockContext("instance1", "org1", "user1"),
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "approve request, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestAddedTestEvent()),
+					expectFilter(),
+					expectFilter(accessRequestPreConditionEvents()...),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						),
+						accessrequest.NewApprovedEvent(context.Background(),
+							&accessrequest.NewAggregate("request1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							"usergrant1",
+							"comment",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+		{
+			name: "approve request, roles added to existing user grant",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestAddedTestEvent()),
+					expectFilter(existingUserGrantTestEvent("rolekey0")),
+					expectFilter(existingUserGrantTestEvent("rolekey0")),
+					expectFilter(append(accessRequestPreConditionEvents(),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey0",
+								"rolekey",
+								"",
+							),
+						),
+					)...),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant0", "org1").Aggregate,
+							[]string{"rolekey0", "rolekey1"},
+						),
+						accessrequest.NewApprovedEvent(context.Background(),
+							&accessrequest.NewAggregate("request1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							"usergrant0",
+							"comment",
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+		{
+			name: "approve request, roles already granted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(accessRequestAddedTestEvent()),
+					expectFilter(existingUserGrantTestEvent("rolekey1")),
+					expectFilter(existingUserGrantTestEvent("rolekey1")),
+					expectPush(
+						accessrequest.NewApprovedEvent(context.Background(),
+							&accessrequest.NewAggregate("request1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							"usergrant0",
+							"comment",
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := r.ApproveAccessRequest(ctx, "request1", "org1", "comment")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_DenyAccessRequest(t *testing.T) {
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		id         string
+		res        res
+	}{
+		{
+			name:       "missing id, invalid argument error",
+			eventstore: expectEventstore(),
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "deny request, ok",
+			eventstore: expectEventstore(
+				expectFilter(accessRequestAddedTestEvent()),
+				expectPush(
+					accessrequest.NewDeniedEvent(context.Background(),
+						&accessrequest.NewAggregate("request1", "org1").Aggregate,
+						"user1",
+						"project1",
+						"",
+						"comment",
+					),
+				),
+			),
+			id: "request1",
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "request1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := r.DenyAccessRequest(context.Background(), tt.id, "org1", "comment")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
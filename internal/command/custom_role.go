@@ -0,0 +1,221 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/customrole"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AddInstanceCustomRole adds a role for instance members (IAM_ prefix)
+// or organization members of all organizations (ORG_ prefix).
+func (c *Commands) AddInstanceCustomRole(ctx context.Context, role *domain.CustomRole) (_ *domain.ObjectDetails, err error) {
+	return c.addCustomRole(ctx, "", role)
+}
+
+// AddOrgCustomRole adds a role (ORG_ prefix) for the members of the organization.
+func (c *Commands) AddOrgCustomRole(ctx context.Context, orgID string, role *domain.CustomRole) (_ *domain.ObjectDetails, err error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cr3ow", "Errors.ResourceOwnerMissing")
+	}
+	return c.addCustomRole(ctx, orgID, role)
+}
+
+func (c *Commands) addCustomRole(ctx context.Context, orgID string, role *domain.CustomRole) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if !role.IsValid(customRolePrefixes(orgID)...) || role.IsStaticRole(c.zitadelRoles) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cr4kv", "Errors.CustomRole.Invalid")
+	}
+	if orgID != "" {
+		if err := c.checkOrgExists(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+	wm, err := c.customRolesWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	// the key of an organization role must not shadow a role of the instance
+	if wm.Exists(role.Key) {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Cr5ae", "Errors.CustomRole.AlreadyExists")
+	}
+	if err := c.checkCustomRolePermissions(ctx, wm, role.Permissions); err != nil {
+		return nil, err
+	}
+	var event eventstore.Command
+	if orgID != "" {
+		event = org.NewCustomRoleAddedEvent(ctx, wm.Aggregate(), role.Key, role.DisplayName, role.Permissions)
+	} else {
+		event = instance.NewCustomRoleAddedEvent(ctx, wm.Aggregate(), role.Key, role.DisplayName, role.Permissions)
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, event)
+}
+
+// ChangeInstanceCustomRole changes the display name and / or the permissions of the role.
+// The permissions replace the existing permissions.
+func (c *Commands) ChangeInstanceCustomRole(ctx context.Context, key string, displayName *string, permissions []string) (_ *domain.ObjectDetails, err error) {
+	return c.changeCustomRole(ctx, "", key, displayName, permissions)
+}
+
+// ChangeOrgCustomRole changes the display name and / or the permissions of the role.
+// The permissions replace the existing permissions.
+func (c *Commands) ChangeOrgCustomRole(ctx context.Context, orgID, key string, displayName *string, permissions []string) (_ *domain.ObjectDetails, err error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cr6ow", "Errors.ResourceOwnerMissing")
+	}
+	return c.changeCustomRole(ctx, orgID, key, displayName, permissions)
+}
+
+func (c *Commands) changeCustomRole(ctx context.Context, orgID, key string, displayName *string, permissions []string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if key == "" || (permissions != nil && len(permissions) == 0) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cr7kv", "Errors.CustomRole.Invalid")
+	}
+	wm, err := c.customRolesWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	existing, ok := wm.Roles()[key]
+	if !ok {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Cr8nf", "Errors.CustomRole.NotFound")
+	}
+	changes := make([]customrole.Changes, 0, 2)
+	if displayName != nil && existing.DisplayName != *displayName {
+		changes = append(changes, customrole.ChangeDisplayName(*displayName))
+	}
+	if permissions != nil && !slices.Equal(existing.Permissions, permissions) {
+		if err := c.checkCustomRolePermissions(ctx, wm, permissions); err != nil {
+			return nil, err
+		}
+		changes = append(changes, customrole.ChangePermissions(permissions))
+	}
+	if len(changes) == 0 {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	var event eventstore.Command
+	if orgID != "" {
+		event = org.NewCustomRoleChangedEvent(ctx, wm.Aggregate(), key, changes)
+	} else {
+		event = instance.NewCustomRoleChangedEvent(ctx, wm.Aggregate(), key, changes)
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, event)
+}
+
+// RemoveInstanceCustomRole removes the role.
+// Members keep the role assigned, but it no longer grants any permission.
+func (c *Commands) RemoveInstanceCustomRole(ctx context.Context, key string) (_ *domain.ObjectDetails, err error) {
+	return c.removeCustomRole(ctx, "", key)
+}
+
+// RemoveOrgCustomRole removes the role.
+// Members keep the role assigned, but it no longer grants any permission.
+func (c *Commands) RemoveOrgCustomRole(ctx context.Context, orgID, key string) (_ *domain.ObjectDetails, err error) {
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cr9ow", "Errors.ResourceOwnerMissing")
+	}
+	return c.removeCustomRole(ctx, orgID, key)
+}
+
+func (c *Commands) removeCustomRole(ctx context.Context, orgID, key string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if key == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Cs1kv", "Errors.CustomRole.Invalid")
+	}
+	wm, err := c.customRolesWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wm.Roles()[key]; !ok {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Cs2nf", "Errors.CustomRole.NotFound")
+	}
+	var event eventstore.Command
+	if orgID != "" {
+		event = org.NewCustomRoleRemovedEvent(ctx, wm.Aggregate(), key)
+	} else {
+		event = instance.NewCustomRoleRemovedEvent(ctx, wm.Aggregate(), key)
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, event)
+}
+
+func (c *Commands) customRolesWriteModel(ctx context.Context, orgID string) (*CustomRolesWriteModel, error) {
+	wm := NewCustomRolesWriteModel(authz.GetInstance(ctx).InstanceID(), orgID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// checkCustomRolePermissions ensures the permissions are part of the static roles
+// and the creator of the role holds all of them, so no one can grant more permissions than they have.
+// Permissions limited to a project do not count, the creator must hold them on the instance or organization.
+func (c *Commands) checkCustomRolePermissions(ctx context.Context, wm *CustomRolesWriteModel, permissions []string) error {
+	if len(domain.InvalidCustomRolePermissions(permissions, c.zitadelRoles)) > 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Cs3pi", "Errors.CustomRole.PermissionInvalid")
+	}
+	for _, permission := range permissions {
+		if err := c.checkPermission(ctx, permission, wm.ResourceOwner, wm.ResourceOwner); err != nil {
+			return zerrors.ThrowPermissionDenied(err, "COMMAND-Cs4pd", "Errors.CustomRole.PermissionNotHeld")
+		}
+	}
+	return nil
+}
+
+func customRolePrefixes(orgID string) []string {
+	if orgID != "" {
+		return []string{domain.OrgRolePrefix}
+	}
+	return []string{domain.IAMRolePrefix, domain.OrgRolePrefix}
+}
+
+// checkMemberRoles checks the roles are either configured static roles
+// or custom roles of the instance or the organization (if provided).
+// Custom roles are only loaded if any of the roles is not a static role.
+// The caller must hold all permissions of the assigned custom roles.
+func (c *Commands) checkMemberRoles(ctx context.Context, filter preparation.FilterToQueryReducer, orgID, rolePrefix string, roles []string) (valid bool, err error) {
+	invalidRoles := domain.CheckForInvalidRoles(roles, rolePrefix, c.zitadelRoles)
+	if len(invalidRoles) == 0 {
+		return true, nil
+	}
+	for _, role := range invalidRoles {
+		if !domain.IsCustomRoleKey(role, rolePrefix) {
+			return false, nil
+		}
+	}
+	wm := NewCustomRolesWriteModel(authz.GetInstance(ctx).InstanceID(), orgID)
+	events, err := filter(ctx, wm.Query())
+	if err != nil {
+		return false, err
+	}
+	wm.AppendEvents(events...)
+	if err := wm.Reduce(); err != nil {
+		return false, err
+	}
+	for _, role := range invalidRoles {
+		if !wm.Exists(role) {
+			return false, nil
+		}
+	}
+	// the caller must not be able to grant more permissions than they hold
+	for _, role := range invalidRoles {
+		for _, permission := range wm.Role(role).Permissions {
+			if err := c.checkPermission(ctx, permission, wm.ResourceOwner, wm.ResourceOwner); err != nil {
+				return false, zerrors.ThrowPermissionDenied(err, "COMMAND-Cs5pd", "Errors.CustomRole.PermissionNotHeld")
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/customrole"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// CustomRolesWriteModel contains the custom roles of the instance
+// and, if an organization is provided, the custom roles of the organization.
+// Changes are pushed to the organization if provided, else to the instance.
+type CustomRolesWriteModel struct {
+	eventstore.WriteModel
+
+	instanceID    string
+	orgID         string
+	InstanceRoles map[string]*domain.CustomRole
+	OrgRoles      map[string]*domain.CustomRole
+}
+
+func NewCustomRolesWriteModel(instanceID, orgID string) *CustomRolesWriteModel {
+	aggregateID := instanceID
+	if orgID != "" {
+		aggregateID = orgID
+	}
+	return &CustomRolesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   aggregateID,
+			ResourceOwner: aggregateID,
+			InstanceID:    instanceID,
+		},
+		instanceID:    instanceID,
+		orgID:         orgID,
+		InstanceRoles: make(map[string]*domain.CustomRole),
+		OrgRoles:      make(map[string]*domain.CustomRole),
+	}
+}
+
+func (wm *CustomRolesWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *CustomRolesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.CustomRoleAddedEvent:
+			reduceCustomRoleAdded(wm.InstanceRoles, &e.AddedEvent)
+		case *instance.CustomRoleChangedEvent:
+			reduceCustomRoleChanged(wm.InstanceRoles, &e.ChangedEvent)
+		case *instance.CustomRoleRemovedEvent:
+			delete(wm.InstanceRoles, e.Key)
+		case *org.CustomRoleAddedEvent:
+			reduceCustomRoleAdded(wm.OrgRoles, &e.AddedEvent)
+		case *org.CustomRoleChangedEvent:
+			reduceCustomRoleChanged(wm.OrgRoles, &e.ChangedEvent)
+		case *org.CustomRoleRemovedEvent:
+			delete(wm.OrgRoles, e.Key)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func reduceCustomRoleAdded(roles map[string]*domain.CustomRole, e *customrole.AddedEvent) {
+	roles[e.Key] = &domain.CustomRole{
+		Key:         e.Key,
+		DisplayName: e.DisplayName,
+		Permissions: e.Permissions,
+	}
+}
+
+func reduceCustomRoleChanged(roles map[string]*domain.CustomRole, e *customrole.ChangedEvent) {
+	role, ok := roles[e.Key]
+	if !ok {
+		return
+	}
+	if e.DisplayName != nil {
+		role.DisplayName = *e.DisplayName
+	}
+	if len(e.Permissions) > 0 {
+		role.Permissions = e.Permissions
+	}
+}
+
+func (wm *CustomRolesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.instanceID).
+		EventTypes(
+			instance.CustomRoleAddedEventType,
+			instance.CustomRoleChangedEventType,
+			instance.CustomRoleRemovedEventType,
+		)
+	if wm.orgID == "" {
+		return query.Builder()
+	}
+	return query.
+		Or().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.orgID).
+		EventTypes(
+			org.CustomRoleAddedEventType,
+			org.CustomRoleChangedEventType,
+			org.CustomRoleRemovedEventType,
+		).
+		Builder()
+}
+
+// Roles returns the custom roles of the aggregate the write model pushes to.
+func (wm *CustomRolesWriteModel) Roles() map[string]*domain.CustomRole {
+	if wm.orgID != "" {
+		return wm.OrgRoles
+	}
+	return wm.InstanceRoles
+}
+
+// Exists checks if the role is defined by the instance or the organization.
+func (wm *CustomRolesWriteModel) Exists(key string) bool {
+	_, ok := wm.InstanceRoles[key]
+	if ok {
+		return true
+	}
+	_, ok = wm.OrgRoles[key]
+	return ok
+}
+
+// Role returns the role defined by the organization or the instance.
+func (wm *CustomRolesWriteModel) Role(key string) *domain.CustomRole {
+	if role, ok := wm.OrgRoles[key]; ok {
+		return role
+	}
+	return wm.InstanceRoles[key]
+}
+
+func (wm *CustomRolesWriteModel) Aggregate() *eventstore.Aggregate {
+	if wm.orgID != "" {
+		return &org.NewAggregate(wm.orgID).Aggregate
+	}
+	return &instance.NewAggregate(wm.instanceID).Aggregate
+}
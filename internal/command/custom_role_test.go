@@ -0,0 +1,555 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/customrole"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var customRoleTestStaticRoles = []authz.RoleMapping{
+	{
+		Role:        domain.RoleIAMOwner,
+		Permissions: []string{"iam.write", "org.read", "user.write", "user.credential.write"},
+	},
+	{
+		Role:        domain.RoleOrgOwner,
+		Permissions: []string{"org.read", "user.write", "user.credential.write"},
+	},
+}
+
+func TestCommands_AddOrgCustomRole(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		orgID string
+		role  *domain.CustomRole
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no org, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				role: &domain.CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "instance prefix, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "static role, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: domain.RoleOrgOwner, Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "instance role with same key, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "ORG_HELPDESK", "", []string{"user.write"}),
+						),
+					),
+				),
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "unknown permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write", "system.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "permission not held by creator, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+				),
+				checkPermission: func(_ context.Context, permission, orgID, resourceID string) error {
+					if permission == "user.write" {
+						return zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+					}
+					return nil
+				},
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write", "user.write"}},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "add, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						org.NewCustomRoleAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+					),
+				),
+				checkPermission: func(_ context.Context, permission, orgID, resourceID string) error {
+					if permission != "user.credential.write" || orgID != "org1" || resourceID != "org1" {
+						return zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+					}
+					return nil
+				},
+			},
+			args: args{
+				orgID: "org1",
+				role:  &domain.CustomRole{Key: "ORG_HELPDESK", DisplayName: "Help Desk", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+				zitadelRoles:    customRoleTestStaticRoles,
+			}
+			details, err := c.AddOrgCustomRole(ctx, tt.args.orgID, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_AddInstanceCustomRole(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		role *domain.CustomRole
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid key, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				role: &domain.CustomRole{Key: "HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK", "", []string{"user.write"}),
+						),
+					),
+				),
+			},
+			args: args{
+				role: &domain.CustomRole{Key: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewCustomRoleAddedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK", "", []string{"user.credential.write"}),
+					),
+				),
+				checkPermission: func(_ context.Context, permission, orgID, resourceID string) error {
+					if orgID != "instance1" || resourceID != "instance1" {
+						return zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+					}
+					return nil
+				},
+			},
+			args: args{
+				role: &domain.CustomRole{Key: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+				zitadelRoles:    customRoleTestStaticRoles,
+			}
+			details, err := c.AddInstanceCustomRole(ctx, tt.args.role)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeOrgCustomRole(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		key         string
+		displayName *string
+		permissions []string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty permissions, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				key:         "ORG_HELPDESK",
+				permissions: []string{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				key:         "ORG_HELPDESK",
+				displayName: gu.Ptr("Help Desk"),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "permission not held by creator, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomRoleAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "", []string{"user.credential.write"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				key:         "ORG_HELPDESK",
+				permissions: []string{"user.credential.write", "user.write"},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomRoleAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+						),
+					),
+				),
+			},
+			args: args{
+				key:         "ORG_HELPDESK",
+				displayName: gu.Ptr("Help Desk"),
+				permissions: []string{"user.credential.write"},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomRoleAddedEvent(ctx, &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "", []string{"user.credential.write"}),
+						),
+					),
+					expectPush(
+						org.NewCustomRoleChangedEvent(ctx, &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", []customrole.Changes{
+							customrole.ChangeDisplayName("Help Desk"),
+							customrole.ChangePermissions([]string{"user.credential.write", "user.write"}),
+						}),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				key:         "ORG_HELPDESK",
+				displayName: gu.Ptr("Help Desk"),
+				permissions: []string{"user.credential.write", "user.write"},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+				zitadelRoles:    customRoleTestStaticRoles,
+			}
+			details, err := c.ChangeOrgCustomRole(ctx, "org1", tt.args.key, tt.args.displayName, tt.args.permissions)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveInstanceCustomRole(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		key string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no key, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "already removed, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK", "", []string{"user.credential.write"}),
+						),
+						eventFromEventPusher(
+							instance.NewCustomRoleRemovedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK"),
+						),
+					),
+				),
+			},
+			args: args{
+				key: "IAM_HELPDESK",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK", "", []string{"user.credential.write"}),
+						),
+					),
+					expectPush(
+						instance.NewCustomRoleRemovedEvent(ctx, &instance.NewAggregate("instance1").Aggregate, "IAM_HELPDESK"),
+					),
+				),
+			},
+			args: args{
+				key: "IAM_HELPDESK",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.RemoveInstanceCustomRole(ctx, tt.args.key)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type AddGroup struct {
+	models.ObjectRoot
+
+	Name        string
+	Description string
+}
+
+func (g *AddGroup) IsValid() error {
+	if g.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gq3nf", "Errors.Group.Invalid")
+	}
+	return nil
+}
+
+// AddGroup creates a new group in the organization.
+// If no ID is provided, a new one is generated and set on the AddGroup.
+func (c *Commands) AddGroup(ctx context.Context, add *AddGroup, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.ResourceOwnerMissing")
+	}
+	if err := add.IsValid(); err != nil {
+		return nil, err
+	}
+	if err := c.checkOrgExists(ctx, resourceOwner); err != nil {
+		return nil, err
+	}
+	if add.AggregateID == "" {
+		add.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, add.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State != domain.GroupStateUnspecified {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Gp2ka", "Errors.Group.AlreadyExists")
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		group.NewAddedEvent(ctx, GroupAggregateFromWriteModel(&wm.WriteModel), add.Name, add.Description),
+	)
+}
+
+type ChangeGroup struct {
+	models.ObjectRoot
+
+	Name        *string
+	Description *string
+}
+
+func (g *ChangeGroup) IsValid() error {
+	if g.AggregateID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gm3kd", "Errors.IDMissing")
+	}
+	if g.Name != nil && *g.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gl4ns", "Errors.Group.Invalid")
+	}
+	return nil
+}
+
+func (c *Commands) ChangeGroup(ctx context.Context, change *ChangeGroup, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+	existing, err := c.existingGroupWriteModel(ctx, change.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	changedEvent := existing.NewChangedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), change.Name, change.Description)
+	if changedEvent == nil {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing, changedEvent)
+}
+
+// RemoveGroup removes the group, its memberships and grants.
+func (c *Commands) RemoveGroup(ctx context.Context, id, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gn2la", "Errors.IDMissing")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewRemovedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), existing.Name),
+	)
+}
+
+// AddGroupMember adds the user to the group, which grants them all roles granted to the group.
+// The user can belong to any organization of the instance, as it's the case for user grants.
+func (c *Commands) AddGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gu8rt", "Errors.Group.Member.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsMember(userID) {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Gm2pq", "Errors.Group.Member.AlreadyExists")
+	}
+	if err := c.checkUserExists(ctx, userID, ""); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewMemberAddedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), userID),
+	)
+}
+
+func (c *Commands) RemoveGroupMember(ctx context.Context, groupID, userID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gz7wq", "Errors.Group.Member.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.IsMember(userID) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gx4mz", "Errors.Group.Member.NotFound")
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewMemberRemovedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), userID),
+	)
+}
+
+// AddGroupGrant grants roles of a project (or of a project granted to the organization) to the group.
+// It returns the ID of the created grant.
+func (c *Commands) AddGroupGrant(ctx context.Context, groupID string, grant *GroupGrant, resourceOwner string) (_ string, _ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || grant.ProjectID == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gg5ve", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := c.checkGroupGrantPreCondition(ctx, grant.ProjectID, grant.ProjectGrantID, grant.RoleKeys, existing.ResourceOwner); err != nil {
+		return "", nil, err
+	}
+	grantID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	details, err := c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewGrantAddedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), grantID, grant.ProjectID, grant.ProjectGrantID, grant.RoleKeys),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	return grantID, details, nil
+}
+
+// ChangeGroupGrant replaces the roles of the grant.
+func (c *Commands) ChangeGroupGrant(ctx context.Context, groupID, grantID string, roleKeys []string, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gc9sn", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	grant, ok := existing.Grants[grantID]
+	if !ok {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gf2md", "Errors.Group.Grant.NotFound")
+	}
+	if slices.Equal(grant.RoleKeys, roleKeys) {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if err := c.checkGroupGrantPreCondition(ctx, grant.ProjectID, grant.ProjectGrantID, roleKeys, existing.ResourceOwner); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewGrantChangedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), grantID, roleKeys),
+	)
+}
+
+func (c *Commands) RemoveGroupGrant(ctx context.Context, groupID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gr6sq", "Errors.Group.Grant.Invalid")
+	}
+	existing, err := c.existingGroupWriteModel(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := existing.Grants[grantID]; !ok {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gt3kw", "Errors.Group.Grant.NotFound")
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		group.NewGrantRemovedEvent(ctx, GroupAggregateFromWriteModel(&existing.WriteModel), grantID),
+	)
+}
+
+// checkGroupGrantPreCondition checks that the project (or the project grant to the organization)
+// and all roles exist, the same way as for user grants.
+func (c *Commands) checkGroupGrantPreCondition(ctx context.Context, projectID, projectGrantID string, roleKeys []string, resourceOwner string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	preConditions := NewUserGrantPreConditionReadModel("", projectID, projectGrantID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, preConditions); err != nil {
+		return err
+	}
+	if projectGrantID == "" && !preConditions.ProjectExists {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gp8xa", "Errors.Project.NotFound")
+	}
+	if projectGrantID != "" && !preConditions.ProjectGrantExists {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gp9xb", "Errors.Project.Grant.NotFound")
+	}
+	for _, roleKey := range roleKeys {
+		if !slices.Contains(preConditions.ExistingRoleKeys, roleKey) {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gr4ol", "Errors.Project.Role.NotFound")
+		}
+	}
+	return nil
+}
+
+func (c *Commands) existingGroupWriteModel(ctx context.Context, id, resourceOwner string) (*GroupWriteModel, error) {
+	wm, err := c.getGroupWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gn4tf", "Errors.Group.NotFound")
+	}
+	return wm, nil
+}
+
+func (c *Commands) getGroupWriteModelByID(ctx context.Context, id, resourceOwner string) (*GroupWriteModel, error) {
+	wm := NewGroupWriteModel(id, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+)
+
+type GroupWriteModel struct {
+	eventstore.WriteModel
+
+	Name        string
+	Description string
+	State       domain.GroupState
+
+	// Members contains the IDs of the users which are member of the group
+	Members []string
+	// Grants by their grant ID
+	Grants map[string]*GroupGrant
+}
+
+type GroupGrant struct {
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func NewGroupWriteModel(id, resourceOwner string) *GroupWriteModel {
+	return &GroupWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		Grants: make(map[string]*GroupGrant),
+	}
+}
+
+func (wm *GroupWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *GroupWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.Name = e.Name
+			wm.Description = e.Description
+			wm.State = domain.GroupStateActive
+		case *group.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+		case *group.RemovedEvent:
+			wm.State = domain.GroupStateRemoved
+			wm.Members = nil
+			wm.Grants = make(map[string]*GroupGrant)
+		case *group.MemberAddedEvent:
+			wm.Members = append(wm.Members, e.UserID)
+		case *group.MemberRemovedEvent:
+			wm.Members = slices.DeleteFunc(wm.Members, func(userID string) bool {
+				return userID == e.UserID
+			})
+		case *group.GrantAddedEvent:
+			wm.Grants[e.GrantID] = &GroupGrant{
+				ProjectID:      e.ProjectID,
+				ProjectGrantID: e.ProjectGrantID,
+				RoleKeys:       e.RoleKeys,
+			}
+		case *group.GrantChangedEvent:
+			if grant, ok := wm.Grants[e.GrantID]; ok {
+				grant.RoleKeys = e.RoleKeys
+			}
+		case *group.GrantRemovedEvent:
+			delete(wm.Grants, e.GrantID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			group.AddedEventType,
+			group.ChangedEventType,
+			group.RemovedEventType,
+			group.MemberAddedEventType,
+			group.MemberRemovedEventType,
+			group.GrantAddedEventType,
+			group.GrantChangedEventType,
+			group.GrantRemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *GroupWriteModel) IsMember(userID string) bool {
+	return slices.Contains(wm.Members, userID)
+}
+
+func (wm *GroupWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name,
+	description *string,
+) *group.ChangedEvent {
+	changes := make([]group.Changes, 0, 2)
+	if name != nil && wm.Name != *name {
+		changes = append(changes, group.ChangeName(wm.Name, *name))
+	}
+	if description != nil && wm.Description != *description {
+		changes = append(changes, group.ChangeDescription(*description))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return group.NewChangedEvent(ctx, agg, changes)
+}
+
+func GroupAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            wm.AggregateID,
+		Type:          group.AggregateType,
+		ResourceOwner: wm.ResourceOwner,
+		InstanceID:    wm.InstanceID,
+		Version:       group.AggregateVersion,
+	}
+}
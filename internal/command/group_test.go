@@ -0,0 +1,845 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddGroup(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		add           *AddGroup
+		resourceOwner string
+	}
+	type res struct {
+		id      string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no resource owner, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				add: &AddGroup{Name: "group"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no name, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				add:           &AddGroup{},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				add:           &AddGroup{Name: "group"},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+				),
+			},
+			args: args{
+				add:           &AddGroup{ObjectRoot: models.ObjectRoot{AggregateID: "group1"}, Name: "group"},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", "description"),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+			},
+			args: args{
+				add:           &AddGroup{Name: "group", Description: "description"},
+				resourceOwner: "org1",
+			},
+			res: res{
+				id: "group1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			details, err := c.AddGroup(context.Background(), tt.args.add, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, tt.args.add.AggregateID)
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeGroup(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		change *ChangeGroup
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "empty name, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				change: &ChangeGroup{ObjectRoot: models.ObjectRoot{AggregateID: "group1"}, Name: gu.Ptr("")},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				change: &ChangeGroup{ObjectRoot: models.ObjectRoot{AggregateID: "group1"}, Name: gu.Ptr("new")},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+				),
+			},
+			args: args{
+				change: &ChangeGroup{ObjectRoot: models.ObjectRoot{AggregateID: "group1"}, Name: gu.Ptr("group")},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectPush(
+						group.NewChangedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate,
+							[]group.Changes{
+								group.ChangeName("group", "new"),
+								group.ChangeDescription("description"),
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				change: &ChangeGroup{ObjectRoot: models.ObjectRoot{AggregateID: "group1"}, Name: gu.Ptr("new"), Description: gu.Ptr("description")},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.ChangeGroup(context.Background(), tt.args.change, "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroup(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "already removed, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectPush(
+						group.NewRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group"),
+					),
+				),
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.RemoveGroup(context.Background(), "group1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_AddGroupMember(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "group not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "already member, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "user not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add member, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user2"),
+						),
+						eventFromEventPusher(
+							group.NewMemberRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							newAddHumanEvent("", false, true, "", language.English),
+						),
+					),
+					expectPush(
+						group.NewMemberAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+					),
+				),
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.AddGroupMember(context.Background(), "group1", "user1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroupMember(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "not member, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+						),
+						eventFromEventPusher(
+							group.NewMemberRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove member, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+						),
+					),
+					expectPush(
+						group.NewMemberRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "user1"),
+					),
+				),
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.RemoveGroupMember(context.Background(), "group1", "user1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_AddGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		grant *GroupGrant
+	}
+	type res struct {
+		grantID string
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no project, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				grant: &GroupGrant{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project of other org, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org2").Aggregate, "project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args: args{
+				grant: &GroupGrant{ProjectID: "project1"},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "role not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "role1", "Role 1", ""),
+						),
+					),
+				),
+			},
+			args: args{
+				grant: &GroupGrant{ProjectID: "project1", RoleKeys: []string{"role1", "role2"}},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "project grant, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org2").Aggregate, "project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org2").Aggregate, "role1", "Role 1", ""),
+						),
+						eventFromEventPusher(
+							project.NewGrantAddedEvent(context.Background(), &project.NewAggregate("project1", "org2").Aggregate, "projectgrant1", "org1", []string{"role1"}),
+						),
+					),
+					expectPush(
+						group.NewGrantAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1", "project1", "projectgrant1", []string{"role1"}),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "grant1"),
+			},
+			args: args{
+				grant: &GroupGrant{ProjectID: "project1", ProjectGrantID: "projectgrant1", RoleKeys: []string{"role1"}},
+			},
+			res: res{
+				grantID: "grant1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			grantID, details, err := c.AddGroupGrant(context.Background(), "group1", tt.args.grant, "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.grantID, grantID)
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		grantID  string
+		roleKeys []string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	groupWithGrant := expectFilter(
+		eventFromEventPusher(
+			group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+		),
+		eventFromEventPusher(
+			group.NewGrantAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1", "project1", "", []string{"role1"}),
+		),
+	)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "grant not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					groupWithGrant,
+				),
+			},
+			args: args{
+				grantID:  "grant2",
+				roleKeys: []string{"role1"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					groupWithGrant,
+				),
+			},
+			args: args{
+				grantID:  "grant1",
+				roleKeys: []string{"role1"},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					groupWithGrant,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "role1", "Role 1", ""),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "role2", "Role 2", ""),
+						),
+					),
+					expectPush(
+						group.NewGrantChangedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1", []string{"role1", "role2"}),
+					),
+				),
+			},
+			args: args{
+				grantID:  "grant1",
+				roleKeys: []string{"role1", "role2"},
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.ChangeGroupGrant(context.Background(), "group1", tt.args.grantID, tt.args.roleKeys, "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "grant already removed, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewGrantAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1", "project1", "", []string{"role1"}),
+						),
+						eventFromEventPusher(
+							group.NewGrantRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewGrantAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1", "project1", "", []string{"role1"}),
+						),
+					),
+					expectPush(
+						group.NewGrantRemovedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate, "grant1"),
+					),
+				),
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			details, err := c.RemoveGroupGrant(context.Background(), "group1", "grant1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
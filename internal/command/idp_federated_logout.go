@@ -0,0 +1,225 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+
+	"github.com/crewjam/saml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPFederatedLogout enables or disables the federated logout of the instance IdP
+func (c *Commands) SetInstanceIDPFederatedLogout(ctx context.Context, id string, enabled bool) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetInstanceIDPFederatedLogout(instanceAgg, id, enabled))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// SetOrgIDPFederatedLogout enables or disables the federated logout of the organization IdP
+func (c *Commands) SetOrgIDPFederatedLogout(ctx context.Context, resourceOwner, id string, enabled bool) (*domain.ObjectDetails, error) {
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetOrgIDPFederatedLogout(orgAgg, id, enabled))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareSetInstanceIDPFederatedLogout(a *instance.Aggregate, id string, enabled bool) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Fl2k1", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			exists, err := ExistsInstanceIDP(ctx, filter, id)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Fl3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPFederatedLogoutChanged(ctx, filter, id, enabled); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewIDPFederatedLogoutSetEvent(ctx, &a.Aggregate, id, enabled),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareSetOrgIDPFederatedLogout(a *org.Aggregate, id string, enabled bool) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Fl2k1", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgIDPRemoveWriteModel(a.ID, id)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "ORG-Fl3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPFederatedLogoutChanged(ctx, filter, id, enabled); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewIDPFederatedLogoutSetEvent(ctx, &a.Aggregate, id, enabled),
+			}, nil
+		}, nil
+	}
+}
+
+func ensureIDPFederatedLogoutChanged(ctx context.Context, filter preparation.FilterToQueryReducer, id string, enabled bool) error {
+	writeModel := NewIDPFederatedLogoutWriteModel(id)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return err
+	}
+	writeModel.AppendEvents(events...)
+	if err = writeModel.Reduce(); err != nil {
+		return err
+	}
+	if writeModel.Enabled == enabled {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Fl4k1", "Errors.NoChangesFound")
+	}
+	return nil
+}
+
+// IDPLogoutURL returns the URL to terminate the session of the user at the identity provider
+// the user authenticated with (federated logout).
+// Depending on the type of the identity provider, this is either the end_session_endpoint (OIDC RP-initiated logout)
+// or a LogoutRequest using the HTTP-Redirect binding (SAML).
+// The identity provider only knows the URLs of ZITADEL, so it will always return the user to ZITADEL
+// (the logout callback for OIDC or the single logout endpoint for SAML),
+// which then redirects to the (encrypted) post logout redirect uri.
+// An empty URL is returned if the session was not authenticated through an identity provider
+// or the federated logout is not enabled on the identity provider.
+// Since the URL contains the id_token of the identity provider, it's only returned for terminated sessions.
+// The caller is therefore responsible to terminate the session (and check the permission) first.
+func (c *Commands) IDPLogoutURL(ctx context.Context, sessionID, postLogoutRedirectURI string, logoutCallbackURL func(ctx context.Context) string, samlRootURL func(ctx context.Context, idpID string) string) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel); err != nil {
+		return "", err
+	}
+	idpSession := sessionWriteModel.IDPSession
+	if sessionWriteModel.State != domain.SessionStateTerminated || idpSession == nil {
+		return "", nil
+	}
+	logoutWriteModel := NewIDPFederatedLogoutWriteModel(idpSession.IDPID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, logoutWriteModel); err != nil {
+		return "", err
+	}
+	if !logoutWriteModel.Enabled {
+		return "", nil
+	}
+	provider, err := c.GetProvider(ctx, idpSession.IDPID, "", samlRootURL(ctx, idpSession.IDPID))
+	if err != nil {
+		return "", err
+	}
+	relayState, err := idpLogoutRelayState(postLogoutRedirectURI, c.idpConfigEncryption)
+	if err != nil {
+		return "", err
+	}
+	var logoutURL string
+	switch p := provider.(type) {
+	case interface {
+		EndSessionURL(idTokenHint, postLogoutRedirectURI, state string) (string, error)
+	}:
+		if idpSession.IDTokenHint == nil {
+			return "", nil
+		}
+		idTokenHint, err := crypto.DecryptString(idpSession.IDTokenHint, c.idpConfigEncryption)
+		if err != nil {
+			return "", err
+		}
+		logoutURL, err = p.EndSessionURL(idTokenHint, logoutCallbackURL(ctx), relayState)
+		if err != nil {
+			return "", zerrors.ThrowPreconditionFailed(err, "COMMAND-Fl5k1", "Errors.Intent.IDPInvalid")
+		}
+	case interface {
+		LogoutRequestURL(nameID, sessionIndex, relayState string) (string, error)
+	}:
+		if idpSession.NameID == "" {
+			return "", nil
+		}
+		logoutURL, err = p.LogoutRequestURL(idpSession.NameID, idpSession.SessionIndex, relayState)
+		if err != nil {
+			return "", err
+		}
+	}
+	return logoutURL, nil
+}
+
+// idpLogoutRelayState encrypts the post logout redirect uri, so the logout callback (OIDC) or single logout endpoint (SAML)
+// is able to redirect the user after the logout without being misused as open redirect.
+func idpLogoutRelayState(postLogoutRedirectURI string, alg crypto.EncryptionAlgorithm) (string, error) {
+	if postLogoutRedirectURI == "" {
+		return "", nil
+	}
+	encrypted, err := alg.Encrypt([]byte(postLogoutRedirectURI))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encrypted), nil
+}
+
+// idpSessionFromIntent returns the identifiers of the session at the identity provider:
+// the (encrypted) id_token for OIDC based providers and the NameID and SessionIndex of the assertion for SAML.
+func idpSessionFromIntent(intent *IDPIntentWriteModel, alg crypto.EncryptionAlgorithm) (idTokenHint *crypto.CryptoValue, nameID, sessionIndex string, err error) {
+	if intent.IDPIDToken != "" {
+		idTokenHint, err = crypto.Encrypt([]byte(intent.IDPIDToken), alg)
+		return idTokenHint, "", "", err
+	}
+	if intent.Assertion == nil {
+		return nil, "", "", nil
+	}
+	data, err := crypto.Decrypt(intent.Assertion, alg)
+	if err != nil {
+		return nil, "", "", err
+	}
+	assertion := new(saml.Assertion)
+	if err = xml.Unmarshal(data, assertion); err != nil {
+		return nil, "", "", zerrors.ThrowInternal(err, "COMMAND-Fl6k1", "Errors.Internal")
+	}
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		nameID = assertion.Subject.NameID.Value
+	}
+	for _, statement := range assertion.AuthnStatements {
+		if statement.SessionIndex != "" {
+			sessionIndex = statement.SessionIndex
+			break
+		}
+	}
+	return nil, nameID, sessionIndex, nil
+}
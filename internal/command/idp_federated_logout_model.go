@@ -0,0 +1,64 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// IDPFederatedLogoutWriteModel contains the federated logout setting of an IdP template,
+// independent of whether it's defined on the instance or an organization.
+type IDPFederatedLogoutWriteModel struct {
+	eventstore.WriteModel
+
+	ID      string
+	Enabled bool
+}
+
+func NewIDPFederatedLogoutWriteModel(id string) *IDPFederatedLogoutWriteModel {
+	return &IDPFederatedLogoutWriteModel{
+		ID: id,
+	}
+}
+
+func (wm *IDPFederatedLogoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.IDPFederatedLogoutSetEvent:
+			wm.reduceSet(e.ID, e.Enabled)
+		case *org.IDPFederatedLogoutSetEvent:
+			wm.reduceSet(e.ID, e.Enabled)
+		case *instance.IDPRemovedEvent:
+			wm.reduceSet(e.ID, false)
+		case *org.IDPRemovedEvent:
+			wm.reduceSet(e.ID, false)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPFederatedLogoutWriteModel) reduceSet(id string, enabled bool) {
+	if wm.ID != id {
+		return
+	}
+	wm.Enabled = enabled
+}
+
+func (wm *IDPFederatedLogoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPFederatedLogoutSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPFederatedLogoutSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
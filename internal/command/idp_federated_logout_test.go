@@ -0,0 +1,479 @@
+package command
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceIDPFederatedLogout(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx     context.Context
+		id      string
+		enabled bool
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				enabled: true,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewGoogleIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "enable ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewGoogleIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPFederatedLogoutSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							true,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:     authz.WithInstanceID(context.Background(), "instance1"),
+				id:      "id1",
+				enabled: true,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPFederatedLogout(tt.args.ctx, tt.args.id, tt.args.enabled)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgIDPFederatedLogout(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		enabled       bool
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				enabled:       true,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "disable ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewGoogleIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPFederatedLogoutSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								true,
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPFederatedLogoutSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							false,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgIDPFederatedLogout(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.enabled)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_IDPLogoutURL(t *testing.T) {
+	sessionAgg := &session.NewAggregate("sessionID", "instance1").Aggregate
+	userAgent := &domain.UserAgent{
+		FingerprintID: gu.Ptr("fp1"),
+		IP:            net.ParseIP("1.2.3.4"),
+		Description:   gu.Ptr("firefox"),
+		Header:        http.Header{"foo": []string{"bar"}},
+	}
+	idTokenHint := &crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("idToken")}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want string
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "session not terminated",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), sessionAgg, userAgent),
+						),
+						eventFromEventPusher(
+							session.NewIDPSessionSetEvent(context.Background(), sessionAgg, "idp1", idTokenHint, "", ""),
+						),
+					),
+				),
+			},
+			res: res{
+				want: "",
+			},
+		},
+		{
+			name: "no idp session",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), sessionAgg, userAgent),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(), sessionAgg),
+						),
+					),
+				),
+			},
+			res: res{
+				want: "",
+			},
+		},
+		{
+			name: "federated logout not enabled",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), sessionAgg, userAgent),
+						),
+						eventFromEventPusher(
+							session.NewIDPSessionSetEvent(context.Background(), sessionAgg, "idp1", idTokenHint, "", ""),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(), sessionAgg),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPFederatedLogoutSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPFederatedLogoutSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								false,
+							),
+						),
+					),
+				),
+			},
+			res: res{
+				want: "",
+			},
+		},
+		{
+			name: "idp removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), sessionAgg, userAgent),
+						),
+						eventFromEventPusher(
+							session.NewIDPSessionSetEvent(context.Background(), sessionAgg, "idp1", idTokenHint, "", ""),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(), sessionAgg),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPFederatedLogoutSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPRemovedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+							),
+						),
+					),
+				),
+			},
+			res: res{
+				want: "",
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(context.Background(), sessionAgg, userAgent),
+						),
+						eventFromEventPusher(
+							session.NewIDPSessionSetEvent(context.Background(), sessionAgg, "idp1", idTokenHint, "", ""),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(context.Background(), sessionAgg),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPFederatedLogoutSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								true,
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.IDPLogoutURL(authz.WithInstanceID(context.Background(), "instance1"), "sessionID", "https://example.com/logout",
+				func(ctx context.Context) string {
+					return "https://login.example.com/idps/logout/callback"
+				},
+				func(ctx context.Context, idpID string) string {
+					return "https://login.example.com/idps/" + idpID + "/"
+				},
+			)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func Test_idpSessionFromIntent(t *testing.T) {
+	type want struct {
+		idTokenHint  *crypto.CryptoValue
+		nameID       string
+		sessionIndex string
+		err          func(error) bool
+	}
+	tests := []struct {
+		name   string
+		intent *IDPIntentWriteModel
+		want   want
+	}{
+		{
+			name:   "no idp session",
+			intent: &IDPIntentWriteModel{},
+		},
+		{
+			name: "oidc",
+			intent: &IDPIntentWriteModel{
+				IDPIDToken: "idToken",
+			},
+			want: want{
+				idTokenHint: &crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("idToken")},
+			},
+		},
+		{
+			name: "saml",
+			intent: &IDPIntentWriteModel{
+				Assertion: &crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte(
+					`<Assertion xmlns="urn:oasis:names:tc:SAML:2.0:assertion"><Subject><NameID>nameID</NameID></Subject><AuthnStatement SessionIndex="sessionIndex"></AuthnStatement></Assertion>`,
+				)},
+			},
+			want: want{
+				nameID:       "nameID",
+				sessionIndex: "sessionIndex",
+			},
+		},
+		{
+			name: "saml invalid assertion",
+			intent: &IDPIntentWriteModel{
+				Assertion: &crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("<Assertion")},
+			},
+			want: want{
+				err: zerrors.IsInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idTokenHint, nameID, sessionIndex, err := idpSessionFromIntent(tt.intent, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.idTokenHint, idTokenHint)
+			assert.Equal(t, tt.want.nameID, nameID)
+			assert.Equal(t, tt.want.sessionIndex, sessionIndex)
+		})
+	}
+}
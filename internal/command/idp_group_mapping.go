@@ -0,0 +1,413 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPGroupMappings replaces all group mappings of the instance IdP
+func (c *Commands) SetInstanceIDPGroupMappings(ctx context.Context, id string, mappings []*domain.IDPGroupMapping) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareSetInstanceIDPGroupMappings(instanceAgg, id, mappings))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// SetOrgIDPGroupMappings replaces all group mappings of the organization IdP
+func (c *Commands) SetOrgIDPGroupMappings(ctx context.Context, resourceOwner, id string, mappings []*domain.IDPGroupMapping) (*domain.ObjectDetails, error) {
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, c.prepareSetOrgIDPGroupMappings(orgAgg, id, mappings))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) prepareSetInstanceIDPGroupMappings(a *instance.Aggregate, id string, mappings []*domain.IDPGroupMapping) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Gm2k1", "Errors.IDMissing")
+		}
+		if err := c.validateIDPGroupMappings(mappings); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			exists, err := ExistsInstanceIDP(ctx, filter, id)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Gm3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPGroupMappingsChanged(ctx, filter, id, mappings); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewIDPGroupMappingsSetEvent(ctx, &a.Aggregate, id, groupMappingsToEvent(mappings)),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) prepareSetOrgIDPGroupMappings(a *org.Aggregate, id string, mappings []*domain.IDPGroupMapping) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Gm2k1", "Errors.IDMissing")
+		}
+		if err := c.validateIDPGroupMappings(mappings); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgIDPRemoveWriteModel(a.ID, id)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "ORG-Gm3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPGroupMappingsChanged(ctx, filter, id, mappings); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewIDPGroupMappingsSetEvent(ctx, &a.Aggregate, id, groupMappingsToEvent(mappings)),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) validateIDPGroupMappings(mappings []*domain.IDPGroupMapping) error {
+	for _, mapping := range mappings {
+		if !mapping.IsValid() {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gm4k1", "Errors.IDPConfig.GroupMappingInvalid")
+		}
+		if len(domain.CheckForInvalidRoles(mapping.OrgMemberRoles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gm5k1", "Errors.IDPConfig.GroupMappingInvalid")
+		}
+	}
+	return nil
+}
+
+func ensureIDPGroupMappingsChanged(ctx context.Context, filter preparation.FilterToQueryReducer, id string, mappings []*domain.IDPGroupMapping) error {
+	writeModel := NewIDPGroupMappingsWriteModel(id)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return err
+	}
+	writeModel.AppendEvents(events...)
+	if err = writeModel.Reduce(); err != nil {
+		return err
+	}
+	if !writeModel.HasChanged(mappings) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gm6k1", "Errors.NoChangesFound")
+	}
+	return nil
+}
+
+// SyncUserIDPGroups reconciles the user grants and org memberships of the user with the group mappings of the IdP
+// based on the claims (attributes) provided by the IdP on the login.
+// Roles, which are no longer mapped, will only be removed if they were granted by a previous sync,
+// manually granted roles will be kept.
+// The org member roles are always granted on the organization of the user.
+func (c *Commands) SyncUserIDPGroups(ctx context.Context, userID, resourceOwner, idpID string, claims map[string][]string) (err error) {
+	cmds, err := c.syncUserIDPGroups(ctx, userID, resourceOwner, idpID, func([]string) map[string][]string {
+		return claims
+	})
+	if err != nil || len(cmds) == 0 {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, cmds...)
+	return err
+}
+
+// syncIntentUserIDPGroups returns the commands to reconcile the user grants and org memberships of the user with the group mappings of the IdP
+// based on the user information (and LDAP attributes) stored on the succeeded intent.
+// The commands are pushed together with the events of the session checking the intent.
+func (c *Commands) syncIntentUserIDPGroups(ctx context.Context, userID, resourceOwner string, intent *IDPIntentWriteModel) ([]eventstore.Command, error) {
+	return c.syncUserIDPGroups(ctx, userID, resourceOwner, intent.IDPID, func(mappedClaims []string) map[string][]string {
+		claims := idp.ClaimValuesFromJSON(intent.IDPUser, mappedClaims...)
+		for _, claim := range mappedClaims {
+			if _, ok := claims[claim]; ok {
+				continue
+			}
+			if values := intent.IDPEntryAttributes[claim]; len(values) > 0 {
+				claims[claim] = values
+			}
+		}
+		return claims
+	})
+}
+
+func (c *Commands) syncUserIDPGroups(ctx context.Context, userID, resourceOwner, idpID string, claimValues func(mappedClaims []string) map[string][]string) (_ []eventstore.Command, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || resourceOwner == "" || idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gs1k2", "Errors.IDMissing")
+	}
+	mappings := NewIDPGroupMappingsWriteModel(idpID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, mappings); err != nil {
+		return nil, err
+	}
+	synced := NewUserIDPGroupsWriteModel(userID, idpID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, synced); err != nil {
+		return nil, err
+	}
+	if len(mappings.Mappings) == 0 && len(synced.Grants) == 0 && len(synced.OrgMemberRoles) == 0 {
+		return nil, nil
+	}
+
+	claims := claimValues(domain.IDPGroupMappingsClaims(mappings.Mappings))
+	desiredGrants, desiredMemberRoles := mappedIDPGroupRoles(mappings.Mappings, claims)
+	for _, previous := range synced.Grants {
+		if !slices.ContainsFunc(desiredGrants, func(grant *user.IDPGroupGrant) bool {
+			return grant.ProjectID == previous.ProjectID && grant.ProjectGrantID == previous.ProjectGrantID
+		}) {
+			desiredGrants = append(desiredGrants, &user.IDPGroupGrant{ProjectID: previous.ProjectID, ProjectGrantID: previous.ProjectGrantID})
+		}
+	}
+
+	cmds := make([]eventstore.Command, 0, len(desiredGrants)+2)
+	grants := make([]*user.IDPGroupGrant, 0, len(desiredGrants))
+	for _, desired := range desiredGrants {
+		cmd, grant, err := c.syncUserIDPGroupGrant(ctx, userID, resourceOwner, synced.grant(desired.ProjectID, desired.ProjectGrantID), desired)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if grant != nil {
+			grants = append(grants, grant)
+		}
+	}
+	memberCmd, memberRoles, err := c.syncUserIDPGroupOrgMember(ctx, userID, resourceOwner, synced.OrgMemberRoles, desiredMemberRoles)
+	if err != nil {
+		return nil, err
+	}
+	if memberCmd != nil {
+		cmds = append(cmds, memberCmd)
+	}
+	if len(cmds) == 0 && idpGroupGrantsEqual(synced.Grants, grants) && slices.Equal(synced.OrgMemberRoles, memberRoles) {
+		return nil, nil
+	}
+	return append(cmds, user.NewUserIDPGroupsSyncedEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, idpID, grants, memberRoles)), nil
+}
+
+// syncUserIDPGroupGrant computes the change of the user grant of the project (grant), so that all desired roles are granted
+// and all previously synced roles, which are not desired anymore, are removed.
+// It returns the roles which were granted by the sync (and not manually), so they can be removed on a later sync.
+func (c *Commands) syncUserIDPGroupGrant(ctx context.Context, userID, resourceOwner string, previous, desired *user.IDPGroupGrant) (eventstore.Command, *user.IDPGroupGrant, error) {
+	var previousRoles []string
+	if previous != nil {
+		previousRoles = previous.RoleKeys
+	}
+	existing, err := c.activeUserGrantByProject(ctx, userID, desired.ProjectID, desired.ProjectGrantID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing == nil {
+		if len(desired.RoleKeys) == 0 {
+			return nil, nil, nil
+		}
+		grant := &domain.UserGrant{
+			UserID:         userID,
+			ProjectID:      desired.ProjectID,
+			ProjectGrantID: desired.ProjectGrantID,
+			RoleKeys:       desired.RoleKeys,
+		}
+		if err = c.checkUserGrantPreCondition(ctx, grant, resourceOwner); err != nil {
+			logging.WithFields("project", desired.ProjectID, "grant", desired.ProjectGrantID).WithError(err).Warn("unable to grant roles of idp group mapping")
+			return nil, nil, nil
+		}
+		grantID, err := c.idGenerator.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+		desired.UserGrantID = grantID
+		return usergrant.NewUserGrantAddedEvent(
+			ctx,
+			UserGrantAggregateFromWriteModel(&NewUserGrantWriteModel(grantID, resourceOwner).WriteModel),
+			userID,
+			desired.ProjectID,
+			desired.ProjectGrantID,
+			desired.RoleKeys,
+		), desired, nil
+	}
+
+	roles := make([]string, 0, len(existing.RoleKeys)+len(desired.RoleKeys))
+	managed := make([]string, 0, len(desired.RoleKeys))
+	for _, role := range existing.RoleKeys {
+		// previously synced roles are removed if they're not mapped anymore
+		if slices.Contains(previousRoles, role) && !slices.Contains(desired.RoleKeys, role) {
+			continue
+		}
+		roles = append(roles, role)
+		// manually granted roles will not be managed
+		if slices.Contains(previousRoles, role) {
+			managed = append(managed, role)
+		}
+	}
+	for _, role := range desired.RoleKeys {
+		if slices.Contains(roles, role) {
+			continue
+		}
+		roles = append(roles, role)
+		managed = append(managed, role)
+	}
+	var grant *user.IDPGroupGrant
+	if len(managed) > 0 {
+		grant = &user.IDPGroupGrant{
+			UserGrantID:    existing.AggregateID,
+			ProjectID:      desired.ProjectID,
+			ProjectGrantID: desired.ProjectGrantID,
+			RoleKeys:       managed,
+		}
+	}
+	grantAgg := UserGrantAggregateFromWriteModel(&existing.WriteModel)
+	if len(roles) == 0 {
+		return usergrant.NewUserGrantRemovedEvent(ctx, grantAgg, userID, existing.ProjectID, existing.ProjectGrantID), grant, nil
+	}
+	if slices.Equal(existing.RoleKeys, roles) {
+		return nil, grant, nil
+	}
+	if err = c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+		UserID:         userID,
+		ProjectID:      existing.ProjectID,
+		ProjectGrantID: existing.ProjectGrantID,
+		RoleKeys:       roles,
+	}, existing.ResourceOwner); err != nil {
+		logging.WithFields("project", desired.ProjectID, "grant", desired.ProjectGrantID).WithError(err).Warn("unable to grant roles of idp group mapping")
+		return nil, previous, nil
+	}
+	return usergrant.NewUserGrantChangedEvent(ctx, grantAgg, roles), grant, nil
+}
+
+// syncUserIDPGroupOrgMember computes the change of the org membership of the user, the same way as [syncUserIDPGroupGrant]
+func (c *Commands) syncUserIDPGroupOrgMember(ctx context.Context, userID, orgID string, previousRoles, desiredRoles []string) (eventstore.Command, []string, error) {
+	existing := NewOrgMemberWriteModel(orgID, userID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, nil, err
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existing.WriteModel)
+	if existing.State != domain.MemberStateActive {
+		if len(desiredRoles) == 0 {
+			return nil, nil, nil
+		}
+		return org.NewMemberAddedEvent(ctx, orgAgg, userID, desiredRoles...), desiredRoles, nil
+	}
+
+	roles := make([]string, 0, len(existing.Roles)+len(desiredRoles))
+	managed := make([]string, 0, len(desiredRoles))
+	for _, role := range existing.Roles {
+		if slices.Contains(previousRoles, role) && !slices.Contains(desiredRoles, role) {
+			continue
+		}
+		roles = append(roles, role)
+		if slices.Contains(previousRoles, role) {
+			managed = append(managed, role)
+		}
+	}
+	for _, role := range desiredRoles {
+		if slices.Contains(roles, role) {
+			continue
+		}
+		roles = append(roles, role)
+		managed = append(managed, role)
+	}
+	if len(managed) == 0 {
+		managed = nil
+	}
+	if len(roles) == 0 {
+		return org.NewMemberRemovedEvent(ctx, orgAgg, userID), managed, nil
+	}
+	if slices.Equal(existing.Roles, roles) {
+		return nil, managed, nil
+	}
+	return org.NewMemberChangedEvent(ctx, orgAgg, userID, roles...), managed, nil
+}
+
+// activeUserGrantByProject returns the (not removed) user grant of the user on the project (grant) or nil if there is none
+func (c *Commands) activeUserGrantByProject(ctx context.Context, userID, projectID, projectGrantID, resourceOwner string) (*UserGrantWriteModel, error) {
+	ids := newUserGrantIDsWriteModel(userID, projectID, projectGrantID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, ids); err != nil {
+		return nil, err
+	}
+	for i := len(ids.UserGrantIDs) - 1; i >= 0; i-- {
+		grant, err := c.userGrantWriteModelByID(ctx, ids.UserGrantIDs[i], resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if grant.State == domain.UserGrantStateActive || grant.State == domain.UserGrantStateInactive {
+			return grant, nil
+		}
+	}
+	return nil, nil
+}
+
+// mappedIDPGroupRoles returns the roles of all mappings matching the provided claims, grouped by project (grant)
+func mappedIDPGroupRoles(mappings []*domain.IDPGroupMapping, claims map[string][]string) (grants []*user.IDPGroupGrant, memberRoles []string) {
+	for _, mapping := range mappings {
+		if !mapping.Matches(claims) {
+			continue
+		}
+		for _, role := range mapping.OrgMemberRoles {
+			if !slices.Contains(memberRoles, role) {
+				memberRoles = append(memberRoles, role)
+			}
+		}
+		if len(mapping.RoleKeys) == 0 {
+			continue
+		}
+		index := slices.IndexFunc(grants, func(grant *user.IDPGroupGrant) bool {
+			return grant.ProjectID == mapping.ProjectID && grant.ProjectGrantID == mapping.ProjectGrantID
+		})
+		if index < 0 {
+			grants = append(grants, &user.IDPGroupGrant{ProjectID: mapping.ProjectID, ProjectGrantID: mapping.ProjectGrantID})
+			index = len(grants) - 1
+		}
+		for _, role := range mapping.RoleKeys {
+			if !slices.Contains(grants[index].RoleKeys, role) {
+				grants[index].RoleKeys = append(grants[index].RoleKeys, role)
+			}
+		}
+	}
+	return grants, memberRoles
+}
+
+func idpGroupGrantsEqual(a, b []*user.IDPGroupGrant) bool {
+	return slices.EqualFunc(a, b, func(a, b *user.IDPGroupGrant) bool {
+		return a.UserGrantID == b.UserGrantID &&
+			a.ProjectID == b.ProjectID &&
+			a.ProjectGrantID == b.ProjectGrantID &&
+			slices.Equal(a.RoleKeys, b.RoleKeys)
+	})
+}
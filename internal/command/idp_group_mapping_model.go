@@ -0,0 +1,221 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// IDPGroupMappingsWriteModel contains the group mappings of an IdP template,
+// independent of whether it's defined on the instance or an organization.
+type IDPGroupMappingsWriteModel struct {
+	eventstore.WriteModel
+
+	ID       string
+	Mappings []*domain.IDPGroupMapping
+}
+
+func NewIDPGroupMappingsWriteModel(id string) *IDPGroupMappingsWriteModel {
+	return &IDPGroupMappingsWriteModel{
+		ID: id,
+	}
+}
+
+func (wm *IDPGroupMappingsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.IDPGroupMappingsSetEvent:
+			wm.reduceSet(&e.GroupMappingsSetEvent)
+		case *org.IDPGroupMappingsSetEvent:
+			wm.reduceSet(&e.GroupMappingsSetEvent)
+		case *instance.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		case *org.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPGroupMappingsWriteModel) reduceSet(e *idp.GroupMappingsSetEvent) {
+	if wm.ID != e.ID {
+		return
+	}
+	wm.Mappings = groupMappingsToDomain(e.Mappings)
+}
+
+func (wm *IDPGroupMappingsWriteModel) reduceRemoved(id string) {
+	if wm.ID != id {
+		return
+	}
+	wm.Mappings = nil
+}
+
+func (wm *IDPGroupMappingsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPGroupMappingsSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPGroupMappingsSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *IDPGroupMappingsWriteModel) HasChanged(mappings []*domain.IDPGroupMapping) bool {
+	return !slices.EqualFunc(wm.Mappings, mappings, func(a, b *domain.IDPGroupMapping) bool {
+		return a.Claim == b.Claim &&
+			a.Group == b.Group &&
+			a.ProjectID == b.ProjectID &&
+			a.ProjectGrantID == b.ProjectGrantID &&
+			slices.Equal(a.RoleKeys, b.RoleKeys) &&
+			slices.Equal(a.OrgMemberRoles, b.OrgMemberRoles)
+	})
+}
+
+func groupMappingsToDomain(mappings []idp.GroupMapping) []*domain.IDPGroupMapping {
+	result := make([]*domain.IDPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = &domain.IDPGroupMapping{
+			Claim:          mapping.Claim,
+			Group:          mapping.Group,
+			ProjectID:      mapping.ProjectID,
+			ProjectGrantID: mapping.ProjectGrantID,
+			RoleKeys:       mapping.RoleKeys,
+			OrgMemberRoles: mapping.OrgMemberRoles,
+		}
+	}
+	return result
+}
+
+func groupMappingsToEvent(mappings []*domain.IDPGroupMapping) []idp.GroupMapping {
+	result := make([]idp.GroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		result[i] = idp.GroupMapping{
+			Claim:          mapping.Claim,
+			Group:          mapping.Group,
+			ProjectID:      mapping.ProjectID,
+			ProjectGrantID: mapping.ProjectGrantID,
+			RoleKeys:       mapping.RoleKeys,
+			OrgMemberRoles: mapping.OrgMemberRoles,
+		}
+	}
+	return result
+}
+
+// UserIDPGroupsWriteModel contains the grants and org member roles the user received from the group mappings of an IdP
+type UserIDPGroupsWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID    string
+	Grants         []*user.IDPGroupGrant
+	OrgMemberRoles []string
+}
+
+func NewUserIDPGroupsWriteModel(userID, idpConfigID, resourceOwner string) *UserIDPGroupsWriteModel {
+	return &UserIDPGroupsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID: idpConfigID,
+	}
+}
+
+func (wm *UserIDPGroupsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPGroupsSyncedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.Grants = e.Grants
+			wm.OrgMemberRoles = e.OrgMemberRoles
+		case *user.UserRemovedEvent:
+			wm.Grants = nil
+			wm.OrgMemberRoles = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPGroupsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPGroupsSyncedType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
+
+func (wm *UserIDPGroupsWriteModel) grant(projectID, projectGrantID string) *user.IDPGroupGrant {
+	for _, grant := range wm.Grants {
+		if grant.ProjectID == projectID && grant.ProjectGrantID == projectGrantID {
+			return grant
+		}
+	}
+	return nil
+}
+
+// userGrantIDsWriteModel collects the ids of the user grants of a user on a project (grant)
+type userGrantIDsWriteModel struct {
+	eventstore.WriteModel
+
+	UserID         string
+	ProjectID      string
+	ProjectGrantID string
+	UserGrantIDs   []string
+}
+
+func newUserGrantIDsWriteModel(userID, projectID, projectGrantID, resourceOwner string) *userGrantIDsWriteModel {
+	return &userGrantIDsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		UserID:         userID,
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+	}
+}
+
+func (wm *userGrantIDsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		e, ok := event.(*usergrant.UserGrantAddedEvent)
+		if !ok || e.ProjectGrantID != wm.ProjectGrantID {
+			continue
+		}
+		wm.UserGrantIDs = append(wm.UserGrantIDs, e.Aggregate().ID)
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *userGrantIDsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{
+			"userId":    wm.UserID,
+			"projectId": wm.ProjectID,
+		}).
+		Builder()
+}
@@ -0,0 +1,566 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceIDPGroupMappings(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		mappings []*domain.IDPGroupMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid mapping",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:    "groups",
+						Group:    "devs",
+						RoleKeys: []string{"dev"},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid org member role",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{"IAM_OWNER"},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{domain.RoleOrgOwner},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewGoogleIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPGroupMappingsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								[]idp.GroupMapping{
+									{
+										Claim:          "groups",
+										Group:          "admins",
+										OrgMemberRoles: []string{domain.RoleOrgOwner},
+									},
+								},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{domain.RoleOrgOwner},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewGoogleIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewIDPGroupMappingsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							[]idp.GroupMapping{
+								{
+									Claim:          "groups",
+									Group:          "admins",
+									OrgMemberRoles: []string{domain.RoleOrgOwner},
+								},
+								{
+									Claim:     "groups",
+									Group:     "devs",
+									ProjectID: "project1",
+									RoleKeys:  []string{"dev"},
+								},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{domain.RoleOrgOwner},
+					},
+					{
+						Claim:     "groups",
+						Group:     "devs",
+						ProjectID: "project1",
+						RoleKeys:  []string{"dev"},
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				zitadelRoles: []authz.RoleMapping{{Role: domain.RoleOrgOwner}, {Role: domain.RoleIAMOwner}},
+			}
+			got, err := c.SetInstanceIDPGroupMappings(tt.args.ctx, tt.args.id, tt.args.mappings)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgIDPGroupMappings(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		mappings      []*domain.IDPGroupMapping
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "idp not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{domain.RoleOrgOwner},
+					},
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove all mappings ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewGoogleIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1", "", "clientID", nil, nil, idp.Options{},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								[]idp.GroupMapping{
+									{
+										Claim:          "groups",
+										Group:          "admins",
+										OrgMemberRoles: []string{domain.RoleOrgOwner},
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							[]idp.GroupMapping{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				zitadelRoles: []authz.RoleMapping{{Role: domain.RoleOrgOwner}},
+			}
+			got, err := c.SetOrgIDPGroupMappings(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.mappings)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SyncUserIDPGroups(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		claims map[string][]string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no mappings, no sync",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				claims: map[string][]string{"groups": {"admins"}},
+			},
+		},
+		{
+			name: "group matches, org member added",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"idp1",
+								[]idp.GroupMapping{
+									{
+										Claim:          "groups",
+										Group:          "admins",
+										OrgMemberRoles: []string{domain.RoleOrgOwner},
+									},
+								},
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						org.NewMemberAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "user1", domain.RoleOrgOwner),
+						user.NewUserIDPGroupsSyncedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							[]*user.IDPGroupGrant{},
+							[]string{domain.RoleOrgOwner},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				claims: map[string][]string{"groups": {"admins", "others"}},
+			},
+		},
+		{
+			name: "group no longer matches, synced org member removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"idp1",
+								[]idp.GroupMapping{
+									{
+										Claim:          "groups",
+										Group:          "admins",
+										OrgMemberRoles: []string{domain.RoleOrgOwner},
+									},
+								},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPGroupsSyncedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								[]*user.IDPGroupGrant{},
+								[]string{domain.RoleOrgOwner},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewMemberAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "user1", domain.RoleOrgOwner),
+						),
+					),
+					expectPush(
+						org.NewMemberRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "user1"),
+						user.NewUserIDPGroupsSyncedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							[]*user.IDPGroupGrant{},
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				claims: map[string][]string{"groups": {"others"}},
+			},
+		},
+		{
+			name: "group matches, manually granted role kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"idp1",
+								[]idp.GroupMapping{
+									{
+										Claim:     "groups",
+										Group:     "devs",
+										ProjectID: "project1",
+										RoleKeys:  []string{"rolekey2"},
+									},
+								},
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"", []string{"rolekey1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey2",
+								"rolekey 2",
+								"",
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							[]string{"rolekey1", "rolekey2"},
+						),
+						user.NewUserIDPGroupsSyncedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							[]*user.IDPGroupGrant{
+								{
+									UserGrantID: "usergrant1",
+									ProjectID:   "project1",
+									RoleKeys:    []string{"rolekey2"},
+								},
+							},
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				claims: map[string][]string{"groups": {"devs"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := c.SyncUserIDPGroups(tt.args.ctx, "user1", "org1", "idp1", tt.args.claims)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
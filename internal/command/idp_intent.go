@@ -9,6 +9,7 @@ import (
 
 	"github.com/crewjam/saml"
 	"github.com/crewjam/saml/samlsp"
+	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -167,6 +168,13 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 	if err != nil {
 		return "", err
 	}
+	var tokens *idpintent.IDPTokens
+	if sessionTokens := idpSessionTokens(idpSession); sessionTokens != nil {
+		tokens, err = c.encryptIDPTokens(sessionTokens.Token)
+		if err != nil {
+			return "", err
+		}
+	}
 	idpInfo, err := json.Marshal(idpUser)
 	if err != nil {
 		return "", err
@@ -180,11 +188,20 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 		userID,
 		accessToken,
 		idToken,
+		tokens,
 	)
 	err = c.pushAppendAndReduce(ctx, writeModel, cmd)
 	if err != nil {
 		return "", err
 	}
+	// the tokens of an intent of an unknown user are bound when the user is created or linked and checks the intent
+	if userID != "" {
+		tokensCmd, err := bindIntentIDPTokens(ctx, c.eventstore.FilterToQueryReducer, writeModel, userID, "")
+		if err == nil && tokensCmd != nil {
+			_, err = c.eventstore.Push(ctx, tokensCmd)
+		}
+		logging.WithFields("intent", writeModel.AggregateID).OnError(err).Warn("unable to store idp tokens of user")
+	}
 	return token, nil
 }
 
@@ -283,24 +300,30 @@ func (c *Commands) GetIntentWriteModel(ctx context.Context, id, resourceOwner st
 
 // tokensForSucceededIDPIntent extracts the oidc.Tokens if available (and encrypts the access_token) for the succeeded event payload
 func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
-	var tokens *oidc.Tokens[*oidc.IDTokenClaims]
+	tokens := idpSessionTokens(session)
+	if tokens == nil {
+		return nil, "", nil
+	}
+	if tokens.Token == nil || tokens.AccessToken == "" {
+		return nil, tokens.IDToken, nil
+	}
+	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
+	return accessToken, tokens.IDToken, err
+}
+
+func idpSessionTokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 	switch s := session.(type) {
 	case *oauth.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *openid.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *jwt.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	case *azuread.Session:
-		tokens = s.Tokens()
+		return s.Tokens()
 	case *apple.Session:
-		tokens = s.Tokens
+		return s.Tokens
 	default:
-		return nil, "", nil
-	}
-	if tokens.Token == nil || tokens.AccessToken == "" {
-		return nil, tokens.IDToken, nil
+		return nil
 	}
-	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
-	return accessToken, tokens.IDToken, err
 }
@@ -2,6 +2,7 @@ package command
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -22,6 +23,8 @@ type IDPIntentWriteModel struct {
 
 	IDPAccessToken *crypto.CryptoValue
 	IDPIDToken     string
+	IDPTokens      *idpintent.IDPTokens
+	SucceededDate  time.Time
 
 	IDPEntryAttributes map[string][]string
 
@@ -109,6 +112,8 @@ func (wm *IDPIntentWriteModel) reduceOAuthSucceededEvent(e *idpintent.SucceededE
 	wm.IDPUserName = e.IDPUserName
 	wm.IDPAccessToken = e.IDPAccessToken
 	wm.IDPIDToken = e.IDPIDToken
+	wm.IDPTokens = e.Tokens
+	wm.SucceededDate = e.CreatedAt()
 	wm.State = domain.IDPIntentStateSucceeded
 }
 
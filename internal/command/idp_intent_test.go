@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/crewjam/saml"
 	"github.com/muhlemmer/gu"
@@ -30,6 +31,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -728,6 +730,7 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 	type fields struct {
 		eventstore          func(t *testing.T) *eventstore.Eventstore
 		idpConfigEncryption crypto.EncryptionAlgorithm
+		idpTokenEncryption  crypto.EncryptionAlgorithm
 	}
 	type args struct {
 		ctx        context.Context
@@ -813,11 +816,20 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 									Crypted:    []byte("accessToken"),
 								},
 								"idToken",
+								&idpintent.IDPTokens{
+									AccessToken: &crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("accessToken"),
+									},
+								},
 							)
 							return event
 						}(),
 					),
 				),
+				idpTokenEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args{
 				ctx:        context.Background(),
@@ -841,12 +853,105 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 				token: "aWQ",
 			},
 		},
+		{
+			"push with user, tokens stored on link",
+			fields{
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				idpTokenEncryption:  crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				eventstore: expectEventstore(
+					expectPush(
+						idpintent.NewSucceededEvent(
+							context.Background(),
+							&idpintent.NewAggregate("id", "instance").Aggregate,
+							[]byte(`{"sub":"id","preferred_username":"username"}`),
+							"id",
+							"username",
+							"userID",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("accessToken"),
+							},
+							"",
+							&idpintent.IDPTokens{
+								AccessToken: &crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("accessToken"),
+								},
+								RefreshToken: &crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("refreshToken"),
+								},
+							},
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"idpID", "username", "id"),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"idpID",
+							"id",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("accessToken"),
+							},
+							"",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("refreshToken"),
+							},
+							time.Time{},
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				writeModel: func() *IDPIntentWriteModel {
+					wm := NewIDPIntentWriteModel("id", "instance")
+					wm.IDPID = "idpID"
+					return wm
+				}(),
+				idpSession: &oauth.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken:  "accessToken",
+							RefreshToken: "refreshToken",
+						},
+					},
+				},
+				idpUser: openid.NewUser(&oidc.UserInfo{
+					Subject: "id",
+					UserInfoProfile: oidc.UserInfoProfile{
+						PreferredUsername: "username",
+					},
+				}),
+				userID: "userID",
+			},
+			res{
+				token: "aWQ",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
 				eventstore:          tt.fields.eventstore(t),
 				idpConfigEncryption: tt.fields.idpConfigEncryption,
+				idpTokenEncryption:  tt.fields.idpTokenEncryption,
 			}
 			got, err := c.SucceedIDPIntent(tt.args.ctx, tt.args.writeModel, tt.args.idpUser, tt.args.idpSession, tt.args.userID)
 			require.ErrorIs(t, err, tt.res.err)
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPTokenExchangeClients replaces the clients, which are allowed to exchange a token of a user
+// for the (stored) access token of the instance IdP
+func (c *Commands) SetInstanceIDPTokenExchangeClients(ctx context.Context, id string, clientIDs []string) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetInstanceIDPTokenExchangeClients(instanceAgg, id, clientIDs))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// SetOrgIDPTokenExchangeClients replaces the clients, which are allowed to exchange a token of a user
+// for the (stored) access token of the organization IdP
+func (c *Commands) SetOrgIDPTokenExchangeClients(ctx context.Context, resourceOwner, id string, clientIDs []string) (*domain.ObjectDetails, error) {
+	orgAgg := org.NewAggregate(resourceOwner)
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareSetOrgIDPTokenExchangeClients(orgAgg, id, clientIDs))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func prepareSetInstanceIDPTokenExchangeClients(a *instance.Aggregate, id string, clientIDs []string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Tx2k1", "Errors.IDMissing")
+		}
+		if slices.Contains(clientIDs, "") {
+			return nil, zerrors.ThrowInvalidArgument(nil, "INST-Tx5k1", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			exists, err := ExistsInstanceIDP(ctx, filter, id)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, zerrors.ThrowNotFound(nil, "INST-Tx3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPTokenExchangeClientsChanged(ctx, filter, id, clientIDs); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				instance.NewIDPTokenExchangeClientsSetEvent(ctx, &a.Aggregate, id, clientIDs),
+			}, nil
+		}, nil
+	}
+}
+
+func prepareSetOrgIDPTokenExchangeClients(a *org.Aggregate, id string, clientIDs []string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if id == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Tx2k1", "Errors.IDMissing")
+		}
+		if slices.Contains(clientIDs, "") {
+			return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Tx5k1", "Errors.IDMissing")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewOrgIDPRemoveWriteModel(a.ID, id)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if !writeModel.State.Exists() {
+				return nil, zerrors.ThrowNotFound(nil, "ORG-Tx3k1", "Errors.IDPConfig.NotExisting")
+			}
+			if err = ensureIDPTokenExchangeClientsChanged(ctx, filter, id, clientIDs); err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				org.NewIDPTokenExchangeClientsSetEvent(ctx, &a.Aggregate, id, clientIDs),
+			}, nil
+		}, nil
+	}
+}
+
+func ensureIDPTokenExchangeClientsChanged(ctx context.Context, filter preparation.FilterToQueryReducer, id string, clientIDs []string) error {
+	writeModel := NewIDPTokenExchangeClientsWriteModel(id)
+	events, err := filter(ctx, writeModel.Query())
+	if err != nil {
+		return err
+	}
+	writeModel.AppendEvents(events...)
+	if err = writeModel.Reduce(); err != nil {
+		return err
+	}
+	if slices.Equal(writeModel.ClientIDs, clientIDs) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tx4k1", "Errors.NoChangesFound")
+	}
+	return nil
+}
+
+// checkIDPTokenExchangeClient ensures the client was explicitly allowed to retrieve the access token of the IdP.
+// Without an explicit opt-in, no client is able to retrieve the token.
+func (c *Commands) checkIDPTokenExchangeClient(ctx context.Context, idpID, clientID string) error {
+	writeModel := NewIDPTokenExchangeClientsWriteModel(idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return err
+	}
+	if !writeModel.IsAllowed(clientID) {
+		return zerrors.ThrowPermissionDenied(nil, "COMMAND-Tx6k1", "Errors.User.ExternalIDP.TokenExchangeNotAllowed")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// IDPTokenExchangeClientsWriteModel contains the clients which are allowed to exchange a token of a user
+// for the access token of the IdP template, independent of whether it's defined on the instance or an organization.
+type IDPTokenExchangeClientsWriteModel struct {
+	eventstore.WriteModel
+
+	ID        string
+	ClientIDs []string
+}
+
+func NewIDPTokenExchangeClientsWriteModel(id string) *IDPTokenExchangeClientsWriteModel {
+	return &IDPTokenExchangeClientsWriteModel{
+		ID: id,
+	}
+}
+
+func (wm *IDPTokenExchangeClientsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.IDPTokenExchangeClientsSetEvent:
+			wm.reduceSet(e.ID, e.ClientIDs)
+		case *org.IDPTokenExchangeClientsSetEvent:
+			wm.reduceSet(e.ID, e.ClientIDs)
+		case *instance.IDPRemovedEvent:
+			wm.reduceSet(e.ID, nil)
+		case *org.IDPRemovedEvent:
+			wm.reduceSet(e.ID, nil)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPTokenExchangeClientsWriteModel) reduceSet(id string, clientIDs []string) {
+	if wm.ID != id {
+		return
+	}
+	wm.ClientIDs = clientIDs
+}
+
+func (wm *IDPTokenExchangeClientsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		EventTypes(
+			instance.IDPTokenExchangeClientsSetEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Or().
+		AggregateTypes(org.AggregateType).
+		EventTypes(
+			org.IDPTokenExchangeClientsSetEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+// IsAllowed returns whether the client was explicitly allowed to retrieve the access token of the IdP
+func (wm *IDPTokenExchangeClientsWriteModel) IsAllowed(clientID string) bool {
+	return slices.Contains(wm.ClientIDs, clientID)
+}
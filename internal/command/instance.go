@@ -86,7 +86,13 @@ type InstanceSetup struct {
 		MultiFactorCheckLifetime   time.Duration
 	}
 	NotificationPolicy struct {
-		PasswordChange bool
+		PasswordChange           bool
+		NewDeviceSignIn          bool
+		MFAAdded                 bool
+		MFARemoved               bool
+		EmailChange              bool
+		AccountLocked            bool
+		PersonalAccessTokenAdded bool
 	}
 	PrivacyPolicy struct {
 		TOSLink        string
@@ -360,7 +366,15 @@ func setupInstanceElements(instanceAgg *instance.Aggregate, setup *InstanceSetup
 		prepareAddMultiFactorToDefaultLoginPolicy(instanceAgg, domain.MultiFactorTypeU2FWithPIN),
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail, setup.PrivacyPolicy.DocsLink, setup.PrivacyPolicy.CustomLink, setup.PrivacyPolicy.CustomLinkText),
-		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
+		prepareAddDefaultNotificationPolicy(instanceAgg, &domain.NotificationPolicy{
+			PasswordChange:           setup.NotificationPolicy.PasswordChange,
+			NewDeviceSignIn:          setup.NotificationPolicy.NewDeviceSignIn,
+			MFAAdded:                 setup.NotificationPolicy.MFAAdded,
+			MFARemoved:               setup.NotificationPolicy.MFARemoved,
+			EmailChange:              setup.NotificationPolicy.EmailChange,
+			AccountLocked:            setup.NotificationPolicy.AccountLocked,
+			PersonalAccessTokenAdded: setup.NotificationPolicy.PersonalAccessTokenAdded,
+		}),
 		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxPasswordAttempts, setup.LockoutPolicy.MaxOTPAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
 
 		prepareAddDefaultLabelPolicy(
@@ -18,10 +18,14 @@ func (c *Commands) AddInstanceMemberCommand(a *instance.Aggregate, userID string
 		if userID == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "INSTA-SDSfs", "Errors.Invalid.Argument")
 		}
-		if len(domain.CheckForInvalidRoles(roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
-			return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-4m0fS", "Errors.IAM.MemberInvalid")
-		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+				valid, err := c.checkMemberRoles(ctx, filter, "", domain.IAMRolePrefix, roles)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-4m0fS", "Errors.IAM.MemberInvalid")
+				}
 				if exists, err := ExistsUser(ctx, filter, userID, ""); err != nil || !exists {
 					return nil, zerrors.ThrowPreconditionFailed(err, "INSTA-GSXOn", "Errors.User.NotFound")
 				}
@@ -92,7 +96,11 @@ func (c *Commands) ChangeInstanceMember(ctx context.Context, member *domain.Memb
 	if !member.IsIAMValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-LiaZi", "Errors.IAM.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.IAMRolePrefix, c.zitadelRoles)) > 0 {
+	valid, err := c.checkMemberRoles(ctx, c.eventstore.Filter, "", domain.IAMRolePrefix, member.Roles)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
 		return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-3m9fs", "Errors.IAM.MemberInvalid")
 	}
 
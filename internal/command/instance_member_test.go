@@ -18,8 +18,9 @@ import (
 
 func TestCommandSide_AddIAMMember(t *testing.T) {
 	type fields struct {
-		eventstore   *eventstore.Eventstore
-		zitadelRoles []authz.RoleMapping
+		eventstore      *eventstore.Eventstore
+		zitadelRoles    []authz.RoleMapping
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx    context.Context
@@ -55,6 +56,7 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -66,6 +68,28 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "custom role with permission not held, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate, "IAM_HELPDESK", "Help Desk", []string{"iam.member.write"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+				roles:  []string{"IAM_HELPDESK"},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 		{
 			name: "user not existing, precondition error",
 			fields: fields{
@@ -236,8 +260,9 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:   tt.fields.eventstore,
-				zitadelRoles: tt.fields.zitadelRoles,
+				eventstore:      tt.fields.eventstore,
+				zitadelRoles:    tt.fields.zitadelRoles,
+				checkPermission: tt.fields.checkPermission,
 			}
 			got, err := r.AddInstanceMember(tt.args.ctx, tt.args.userID, tt.args.roles...)
 			if tt.res.err == nil {
@@ -255,8 +280,9 @@ func TestCommandSide_AddIAMMember(t *testing.T) {
 
 func TestCommandSide_ChangeIAMMember(t *testing.T) {
 	type fields struct {
-		eventstore   *eventstore.Eventstore
-		zitadelRoles []authz.RoleMapping
+		eventstore      *eventstore.Eventstore
+		zitadelRoles    []authz.RoleMapping
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx        context.Context
@@ -293,6 +319,7 @@ func TestCommandSide_ChangeIAMMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -306,6 +333,30 @@ func TestCommandSide_ChangeIAMMember(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "custom role with permission not held, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewCustomRoleAddedEvent(context.Background(), &instance.NewAggregate("INSTANCE").Aggregate, "IAM_HELPDESK", "Help Desk", []string{"iam.member.write"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				member: &domain.Member{
+					UserID: "user1",
+					Roles:  []string{"IAM_HELPDESK"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 		{
 			name: "member not existing, not found error",
 			fields: fields{
@@ -416,8 +467,9 @@ func TestCommandSide_ChangeIAMMember(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:   tt.fields.eventstore,
-				zitadelRoles: tt.fields.zitadelRoles,
+				eventstore:      tt.fields.eventstore,
+				zitadelRoles:    tt.fields.zitadelRoles,
+				checkPermission: tt.fields.checkPermission,
 			}
 			got, err := r.ChangeInstanceMember(tt.args.ctx, tt.args.member)
 			if tt.res.err == nil {
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetDefaultInactivityPolicy adds or changes the inactivity policy of the instance.
+// As the policy was introduced after the instance setup, it does not exist on every instance.
+func (c *Commands) SetDefaultInactivityPolicy(ctx context.Context, policy *domain.InactivityPolicy) (_ *domain.InactivityPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if !policy.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "INSTANCE-Ina1v", "Errors.IAM.InactivityPolicy.Invalid")
+	}
+	existingPolicy, err := defaultInactivityPolicyWriteModelByID(ctx, c.eventstore.FilterToQueryReducer)
+	if err != nil {
+		return nil, err
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.InactivityPolicyWriteModel.WriteModel)
+	var event eventstore.Command
+	if existingPolicy.State != domain.PolicyStateActive {
+		event = instance.NewInactivityPolicyAddedEvent(ctx, instanceAgg, policy.InactivityPeriod, policy.WarningPeriod, policy.DryRun)
+	} else {
+		changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy.InactivityPeriod, policy.WarningPeriod, policy.DryRun)
+		if !hasChanged {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "INSTANCE-Ina2c", "Errors.IAM.InactivityPolicy.NotChanged")
+		}
+		event = changedEvent
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToInactivityPolicy(&existingPolicy.InactivityPolicyWriteModel), nil
+}
+
+func defaultInactivityPolicyWriteModelByID(ctx context.Context, reducer func(ctx context.Context, r eventstore.QueryReducer) error) (policy *InstanceInactivityPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstanceInactivityPolicyWriteModel(ctx)
+	err = reducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
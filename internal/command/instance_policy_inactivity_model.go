@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceInactivityPolicyWriteModel struct {
+	InactivityPolicyWriteModel
+}
+
+func NewInstanceInactivityPolicyWriteModel(ctx context.Context) *InstanceInactivityPolicyWriteModel {
+	return &InstanceInactivityPolicyWriteModel{
+		InactivityPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+				InstanceID:    authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceInactivityPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.InactivityPolicyAddedEvent:
+			wm.InactivityPolicyWriteModel.AppendEvents(&e.InactivityPolicyAddedEvent)
+		case *instance.InactivityPolicyChangedEvent:
+			wm.InactivityPolicyWriteModel.AppendEvents(&e.InactivityPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceInactivityPolicyWriteModel) Reduce() error {
+	return wm.InactivityPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceInactivityPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.InactivityPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.InactivityPolicyAddedEventType,
+			instance.InactivityPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceInactivityPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	inactivityPeriod,
+	warningPeriod time.Duration,
+	dryRun bool,
+) (*instance.InactivityPolicyChangedEvent, bool) {
+	changes := wm.changes(inactivityPeriod, warningPeriod, dryRun)
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewInactivityPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -0,0 +1,188 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetDefaultInactivityPolicy(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		policy *domain.InactivityPolicy
+	}
+	type res struct {
+		want *domain.InactivityPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid policy, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 24 * time.Hour,
+					WarningPeriod:    48 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add policy, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewInactivityPolicyAddedEvent(context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							90*24*time.Hour,
+							7*24*time.Hour,
+							true,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					DryRun:           true,
+				},
+			},
+			res: res{
+				want: &domain.InactivityPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+						InstanceID:    "INSTANCE",
+					},
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					DryRun:           true,
+				},
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewInactivityPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					DryRun:           true,
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change policy, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewInactivityPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								true,
+							),
+						),
+					),
+					expectPush(
+						newDefaultInactivityPolicyChangedEvent(context.Background(),
+							[]policy.InactivityPolicyChanges{
+								policy.ChangeInactivityPeriod(30 * 24 * time.Hour),
+								policy.ChangeInactivityDryRun(false),
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 30 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					DryRun:           false,
+				},
+			},
+			res: res{
+				want: &domain.InactivityPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+						InstanceID:    "INSTANCE",
+					},
+					InactivityPeriod: 30 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+					DryRun:           false,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.SetDefaultInactivityPolicy(tt.args.ctx, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newDefaultInactivityPolicyChangedEvent(ctx context.Context, changes []policy.InactivityPolicyChanges) *instance.InactivityPolicyChangedEvent {
+	event, _ := instance.NewInactivityPolicyChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		changes,
+	)
+	return event
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *domain.NotificationPolicy) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultNotificationPolicy(instanceAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultNotificationPolicy(instanceAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -23,9 +23,9 @@ func (c *Commands) AddDefaultNotificationPolicy(ctx context.Context, resourceOwn
 	return pushedEventsToObjectDetails(pushedEvents), nil
 }
 
-func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *domain.NotificationPolicy) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultNotificationPolicy(instanceAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeDefaultNotificationPolicy(instanceAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (c *Commands) ChangeDefaultNotificationPolicy(ctx context.Context, resource
 
 func prepareAddDefaultNotificationPolicy(
 	a *instance.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -55,7 +55,7 @@ func prepareAddDefaultNotificationPolicy(
 				return nil, zerrors.ThrowAlreadyExists(nil, "INSTANCE-xpo1bj", "Errors.Instance.NotificationPolicy.AlreadyExists")
 			}
 			return []eventstore.Command{
-				instance.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange),
+				instance.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, notificationPolicy),
 			}, nil
 		}, nil
 	}
@@ -63,7 +63,7 @@ func prepareAddDefaultNotificationPolicy(
 
 func prepareChangeDefaultNotificationPolicy(
 	a *instance.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -80,7 +80,7 @@ func prepareChangeDefaultNotificationPolicy(
 			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
 				return nil, zerrors.ThrowNotFound(nil, "INSTANCE-x891na", "Errors.IAM.NotificationPolicy.NotFound")
 			}
-			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange)
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, notificationPolicy)
 			if !hasChanged {
 				return nil, zerrors.ThrowPreconditionFailed(nil, "INSTANCE-29x02n", "Errors.IAM.NotificationPolicy.NotChanged")
 			}
@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type InstanceNotificationPolicyWriteModel struct {
@@ -54,13 +54,9 @@ func (wm *InstanceNotificationPolicyWriteModel) Query() *eventstore.SearchQueryB
 func (wm *InstanceNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) (*instance.NotificationPolicyChangedEvent, bool) {
-
-	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
-	}
+	changes := wm.changes(notificationPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
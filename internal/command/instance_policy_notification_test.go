@@ -18,9 +18,9 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                context.Context
+		resourceOwner      string
+		notificationPolicy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -41,16 +41,16 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorAlreadyExists,
@@ -65,15 +65,15 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 					expectPush(
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
-							true,
+							&domain.NotificationPolicy{PasswordChange: true},
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -90,15 +90,15 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 					expectPush(
 						instance.NewNotificationPolicyAddedEvent(context.Background(),
 							&instance.NewAggregate("INSTANCE").Aggregate,
-							true,
+							&domain.NotificationPolicy{PasswordChange: true},
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -112,7 +112,7 @@ func TestCommandSide_AddDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.AddDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -131,9 +131,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		resourceOwner  string
-		passwordChange bool
+		ctx                context.Context
+		resourceOwner      string
+		notificationPolicy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -154,9 +154,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsNotFound,
@@ -171,16 +171,16 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsPreconditionFailed,
@@ -195,7 +195,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							instance.NewNotificationPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
-								false,
+								&domain.NotificationPolicy{PasswordChange: false},
 							),
 						),
 					),
@@ -207,9 +207,9 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				resourceOwner:  "INSTANCE",
-				passwordChange: true,
+				ctx:                context.Background(),
+				resourceOwner:      "INSTANCE",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -223,7 +223,7 @@ func TestCommandSide_ChangeDefaultNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.passwordChange)
+			got, err := r.ChangeDefaultNotificationPolicy(tt.args.ctx, tt.args.resourceOwner, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
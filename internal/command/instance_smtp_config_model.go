@@ -17,6 +17,8 @@ type IAMSMTPConfigWriteModel struct {
 
 	SMTPConfig *SMTPConfig
 	HTTPConfig *HTTPConfig
+	// DeliverySecret authenticates the delivery status callbacks of the provider
+	DeliverySecret *crypto.CryptoValue
 
 	State domain.SMTPConfigState
 
@@ -33,6 +35,21 @@ type SMTPConfig struct {
 	SenderAddress  string
 	SenderName     string
 	ReplyToAddress string
+	XOAuth2        *SMTPXOAuth2
+	DKIM           *SMTPDKIM
+}
+
+type SMTPXOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  *crypto.CryptoValue
+	Scopes        []string
+}
+
+type SMTPDKIM struct {
+	Domain     string
+	Selector   string
+	PrivateKey *crypto.CryptoValue
 }
 
 func NewIAMSMTPConfigWriteModel(instanceID, id, domain string) *IAMSMTPConfigWriteModel {
@@ -87,6 +104,40 @@ func (wm *IAMSMTPConfigWriteModel) Reduce() error {
 			if e.Password != nil {
 				wm.SMTPConfig.Password = e.Password
 			}
+		case *instance.SMTPConfigXOAuth2SetEvent:
+			if wm.ID != e.ID || wm.SMTPConfig == nil {
+				continue
+			}
+			wm.SMTPConfig.XOAuth2 = &SMTPXOAuth2{
+				TokenEndpoint: e.TokenEndpoint,
+				ClientID:      e.ClientID,
+				ClientSecret:  e.ClientSecret,
+				Scopes:        e.Scopes,
+			}
+		case *instance.SMTPConfigXOAuth2RemovedEvent:
+			if wm.ID != e.ID || wm.SMTPConfig == nil {
+				continue
+			}
+			wm.SMTPConfig.XOAuth2 = nil
+		case *instance.SMTPConfigDKIMSetEvent:
+			if wm.ID != e.ID || wm.SMTPConfig == nil {
+				continue
+			}
+			wm.SMTPConfig.DKIM = &SMTPDKIM{
+				Domain:     e.Domain,
+				Selector:   e.Selector,
+				PrivateKey: e.PrivateKey,
+			}
+		case *instance.SMTPConfigDKIMRemovedEvent:
+			if wm.ID != e.ID || wm.SMTPConfig == nil {
+				continue
+			}
+			wm.SMTPConfig.DKIM = nil
+		case *instance.SMTPConfigDeliverySecretSetEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.DeliverySecret = e.Secret
 		case *instance.SMTPConfigHTTPAddedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -145,6 +196,11 @@ func (wm *IAMSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigRemovedEventType,
 			instance.SMTPConfigChangedEventType,
 			instance.SMTPConfigPasswordChangedEventType,
+			instance.SMTPConfigXOAuth2SetEventType,
+			instance.SMTPConfigXOAuth2RemovedEventType,
+			instance.SMTPConfigDKIMSetEventType,
+			instance.SMTPConfigDKIMRemovedEventType,
+			instance.SMTPConfigDeliverySecretSetEventType,
 			instance.SMTPConfigHTTPAddedEventType,
 			instance.SMTPConfigHTTPChangedEventType,
 			instance.SMTPConfigActivatedEventType,
@@ -327,6 +383,7 @@ func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigRemovedEvent(e *instance.SMTP
 	wm.Description = ""
 	wm.HTTPConfig = nil
 	wm.SMTPConfig = nil
+	wm.DeliverySecret = nil
 	wm.State = domain.SMTPConfigStateRemoved
 
 	// If ID has empty value we're dealing with the old and unique smtp settings
@@ -5,11 +5,15 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type NotificationRequest struct {
@@ -129,11 +133,53 @@ func (c *Commands) NotificationCanceled(ctx context.Context, tx *sql.Tx, id, res
 }
 
 // NotificationSent writes a new notification.SentEvent with the notification.Aggregate to the eventstore
-func (c *Commands) NotificationSent(ctx context.Context, tx *sql.Tx, id, resourceOwner string) error {
-	_, err := c.eventstore.PushWithClient(ctx, tx, notification.NewSentEvent(ctx, &notification.NewAggregate(id, resourceOwner).Aggregate))
+// The recipient of the delivery info is masked before it's stored.
+func (c *Commands) NotificationSent(ctx context.Context, tx *sql.Tx, id, resourceOwner string, deliveryInfo *senders.DeliveryInfo) error {
+	var delivery notification.Delivery
+	if deliveryInfo != nil {
+		delivery = notification.Delivery{
+			ProviderID:        deliveryInfo.ProviderID,
+			ProviderType:      deliveryInfo.ProviderType,
+			Recipient:         domain.MaskRecipient(deliveryInfo.Recipient),
+			ProviderMessageID: deliveryInfo.MessageID,
+		}
+	}
+	_, err := c.eventstore.PushWithClient(ctx, tx, notification.NewSentEvent(ctx, &notification.NewAggregate(id, resourceOwner).Aggregate, delivery))
 	return err
 }
 
+// ReportNotificationDelivery writes a new notification.DeliveryReportedEvent with the notification.Aggregate to the eventstore
+// Only sent notifications of the instance can be reported, reporting the current status again is ignored.
+func (c *Commands) ReportNotificationDelivery(ctx context.Context, id string, status domain.NotificationDeliveryStatus, reason string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Nd1iD", "Errors.IDMissing")
+	}
+	if !status.IsReportable() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Nd2sI", "Errors.Notification.Delivery.InvalidStatus")
+	}
+	wm := NewNotificationDeliveryWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if wm.Status == domain.NotificationDeliveryStatusUnspecified {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Nd3nF", "Errors.Notification.Delivery.NotFound")
+	}
+	if !wm.Status.IsSent() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Nd4nS", "Errors.Notification.Delivery.NotSent")
+	}
+	if wm.Status == status && wm.Reason == reason {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, notification.NewDeliveryReportedEvent(ctx,
+		&notification.NewAggregate(wm.AggregateID, wm.ResourceOwner).Aggregate,
+		status,
+		reason,
+	))
+}
+
 // NotificationRetryRequested writes a new notification.RetryRequestEvent with the notification.Aggregate to the eventstore
 func (c *Commands) NotificationRetryRequested(ctx context.Context, tx *sql.Tx, id, resourceOwner string, request *NotificationRetryRequest, requestError error) error {
 	var errorMessage string
@@ -0,0 +1,61 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+)
+
+type NotificationDeliveryWriteModel struct {
+	eventstore.WriteModel
+
+	Status domain.NotificationDeliveryStatus
+	Reason string
+}
+
+func NewNotificationDeliveryWriteModel(id, resourceOwner string) *NotificationDeliveryWriteModel {
+	return &NotificationDeliveryWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *NotificationDeliveryWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *NotificationDeliveryWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *notification.RequestedEvent,
+			*notification.RetryRequestedEvent:
+			wm.Status = domain.NotificationDeliveryStatusPending
+		case *notification.SentEvent:
+			wm.Status = domain.NotificationDeliveryStatusSent
+		case *notification.CanceledEvent:
+			wm.Status = domain.NotificationDeliveryStatusFailed
+		case *notification.DeliveryReportedEvent:
+			wm.Status = e.Status
+			wm.Reason = e.Reason
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *NotificationDeliveryWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(notification.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			notification.RequestedType,
+			notification.RetryRequestedType,
+			notification.SentType,
+			notification.CanceledType,
+			notification.DeliveryReportedType,
+		).
+		Builder()
+}
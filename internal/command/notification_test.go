@@ -0,0 +1,223 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/notification"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func notificationRequestedTestEvent() eventstore.Event {
+	return eventFromEventPusher(
+		notification.NewRequestedEvent(context.Background(),
+			&notification.NewAggregate("notification1", "instance1").Aggregate,
+			"user1",
+			"org1",
+			"",
+			"",
+			"https://example.com",
+			"",
+			nil,
+			0,
+			"user.human.initialization.code.added",
+			domain.NotificationTypeEmail,
+			domain.InitCodeMessageType,
+			false,
+			false,
+			false,
+			nil,
+		),
+	)
+}
+
+func notificationSentTestEvent() eventstore.Event {
+	return eventFromEventPusher(
+		notification.NewSentEvent(context.Background(),
+			&notification.NewAggregate("notification1", "instance1").Aggregate,
+			notification.Delivery{
+				ProviderID:        "provider1",
+				ProviderType:      "smtp",
+				Recipient:         "g***@zitadel.com",
+				ProviderMessageID: "<message1@zitadel.com>",
+			},
+		),
+	)
+}
+
+func TestCommands_ReportNotificationDelivery(t *testing.T) {
+	type args struct {
+		id     string
+		status domain.NotificationDeliveryStatus
+		reason string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "missing id, invalid argument error",
+			eventstore: expectEventstore(),
+			args: args{
+				status: domain.NotificationDeliveryStatusDelivered,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "status not reportable, invalid argument error",
+			eventstore: expectEventstore(),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusSent,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, not found error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusDelivered,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "not sent, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					notificationRequestedTestEvent(),
+				),
+			),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusDelivered,
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "report delivered, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					notificationRequestedTestEvent(),
+					notificationSentTestEvent(),
+				),
+				expectPush(
+					notification.NewDeliveryReportedEvent(context.Background(),
+						&notification.NewAggregate("notification1", "instance1").Aggregate,
+						domain.NotificationDeliveryStatusDelivered,
+						"",
+					),
+				),
+			),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusDelivered,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+					ID:            "notification1",
+				},
+			},
+		},
+		{
+			name: "report bounced after delivered, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					notificationRequestedTestEvent(),
+					notificationSentTestEvent(),
+					eventFromEventPusher(
+						notification.NewDeliveryReportedEvent(context.Background(),
+							&notification.NewAggregate("notification1", "instance1").Aggregate,
+							domain.NotificationDeliveryStatusDelivered,
+							"",
+						),
+					),
+				),
+				expectPush(
+					notification.NewDeliveryReportedEvent(context.Background(),
+						&notification.NewAggregate("notification1", "instance1").Aggregate,
+						domain.NotificationDeliveryStatusBounced,
+						"mailbox full",
+					),
+				),
+			),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusBounced,
+				reason: "mailbox full",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+					ID:            "notification1",
+				},
+			},
+		},
+		{
+			name: "already reported, no push",
+			eventstore: expectEventstore(
+				expectFilter(
+					notificationRequestedTestEvent(),
+					notificationSentTestEvent(),
+					eventFromEventPusher(
+						notification.NewDeliveryReportedEvent(context.Background(),
+							&notification.NewAggregate("notification1", "instance1").Aggregate,
+							domain.NotificationDeliveryStatusBounced,
+							"mailbox full",
+						),
+					),
+				),
+			),
+			args: args{
+				id:     "notification1",
+				status: domain.NotificationDeliveryStatusBounced,
+				reason: "mailbox full",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+					ID:            "notification1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.ReportNotificationDelivery(authz.WithInstanceID(context.Background(), "instance1"), tt.args.id, tt.args.status, tt.args.reason)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
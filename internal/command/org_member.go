@@ -20,14 +20,18 @@ func (c *Commands) AddOrgMemberCommand(a *org.Aggregate, userID string, roles ..
 		if len(roles) == 0 {
 			return nil, zerrors.ThrowInvalidArgument(nil, "V2-PfYhb", "Errors.Invalid.Argument")
 		}
-
-		if len(domain.CheckForInvalidRoles(roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 && len(domain.CheckForInvalidRoles(roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) > 0 {
-			return nil, zerrors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
-		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
 				ctx, span := tracing.NewSpan(ctx)
 				defer func() { span.EndWithError(err) }()
 
+				valid, err := c.checkOrgMemberRoles(ctx, filter, a.ID, roles)
+				if err != nil {
+					return nil, err
+				}
+				if !valid {
+					return nil, zerrors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
+				}
+
 				if exists, err := ExistsUser(ctx, filter, userID, ""); err != nil || !exists {
 					return nil, zerrors.ThrowPreconditionFailed(err, "ORG-GoXOn", "Errors.User.NotFound")
 				}
@@ -40,6 +44,14 @@ func (c *Commands) AddOrgMemberCommand(a *org.Aggregate, userID string, roles ..
 	}
 }
 
+// checkOrgMemberRoles allows the roles (static and custom) of the organization or the global self management role.
+func (c *Commands) checkOrgMemberRoles(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string, roles []string) (bool, error) {
+	if len(domain.CheckForInvalidRoles(roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) == 0 {
+		return true, nil
+	}
+	return c.checkMemberRoles(ctx, filter, orgID, domain.OrgRolePrefix, roles)
+}
+
 func IsOrgMember(ctx context.Context, filter preparation.FilterToQueryReducer, orgID, userID string) (isMember bool, err error) {
 	events, err := filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(orgID).
@@ -101,10 +113,14 @@ func (c *Commands) addOrgMember(ctx context.Context, orgAgg *eventstore.Aggregat
 	if !member.IsValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "Org-W8m4l", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 && len(domain.CheckForInvalidRoles(member.Roles, domain.RoleSelfManagementGlobal, c.zitadelRoles)) > 0 {
+	valid, err := c.checkOrgMemberRoles(ctx, c.eventstore.Filter, orgAgg.ID, member.Roles)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
 		return nil, zerrors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid")
 	}
-	err := c.eventstore.FilterToQueryReducer(ctx, addedMember)
+	err = c.eventstore.FilterToQueryReducer(ctx, addedMember)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +136,11 @@ func (c *Commands) ChangeOrgMember(ctx context.Context, member *domain.Member) (
 	if !member.IsValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "Org-LiaZi", "Errors.Org.MemberInvalid")
 	}
-	if len(domain.CheckForInvalidRoles(member.Roles, domain.OrgRolePrefix, c.zitadelRoles)) > 0 {
+	valid, err := c.checkMemberRoles(ctx, c.eventstore.Filter, member.AggregateID, domain.OrgRolePrefix, member.Roles)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
 		return nil, zerrors.ThrowInvalidArgument(nil, "IAM-m9fG8", "Errors.Org.MemberInvalid")
 	}
 
@@ -20,11 +20,12 @@ import (
 
 func TestAddMember(t *testing.T) {
 	type args struct {
-		a            *org.Aggregate
-		userID       string
-		roles        []string
-		zitadelRoles []authz.RoleMapping
-		filter       preparation.FilterToQueryReducer
+		a               *org.Aggregate
+		userID          string
+		roles           []string
+		zitadelRoles    []authz.RoleMapping
+		filter          preparation.FilterToQueryReducer
+		checkPermission domain.PermissionCheck
 	}
 
 	ctx := context.Background()
@@ -56,14 +57,29 @@ func TestAddMember(t *testing.T) {
 			},
 		},
 		{
-			name: "TODO: invalid roles",
+			name: "invalid roles",
 			args: args{
 				a:      agg,
 				userID: "123",
 				roles:  []string{"ORG_OWNER"},
+				filter: NewMultiFilter().Append(
+					func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).Filter(),
+			},
+			want: Want{
+				CreateErr: zerrors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid"),
+			},
+		},
+		{
+			name: "invalid role prefix",
+			args: args{
+				a:      agg,
+				userID: "123",
+				roles:  []string{"IAM_OWNER"},
 			},
 			want: Want{
-				ValidationErr: zerrors.ThrowInvalidArgument(nil, "Org-4N8es", ""),
+				CreateErr: zerrors.ThrowInvalidArgument(nil, "Org-4N8es", "Errors.Org.MemberInvalid"),
 			},
 		},
 		{
@@ -162,10 +178,76 @@ func TestAddMember(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "correct, custom role",
+			args: args{
+				a:      agg,
+				userID: "userID",
+				roles:  []string{"ORG_OWNER", "ORG_HELPDESK"},
+				zitadelRoles: []authz.RoleMapping{
+					{
+						Role: "ORG_OWNER",
+					},
+				},
+				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							org.NewCustomRoleAddedEvent(ctx, &agg.Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							user.NewMachineAddedEvent(
+								ctx,
+								&user.NewAggregate("id", "ro").Aggregate,
+								"userName",
+								"name",
+								"description",
+								true,
+								domain.OIDCTokenTypeBearer,
+							),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).
+					Filter(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			want: Want{
+				Commands: []eventstore.Command{
+					org.NewMemberAddedEvent(ctx, &agg.Aggregate, "userID", "ORG_OWNER", "ORG_HELPDESK"),
+				},
+			},
+		},
+		{
+			name: "custom role with permission not held",
+			args: args{
+				a:      agg,
+				userID: "userID",
+				roles:  []string{"ORG_OWNER", "ORG_HELPDESK"},
+				zitadelRoles: []authz.RoleMapping{
+					{
+						Role: "ORG_OWNER",
+					},
+				},
+				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							org.NewCustomRoleAddedEvent(ctx, &agg.Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+						}, nil
+					}).
+					Filter(),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			want: Want{
+				CreateErr: zerrors.ThrowPermissionDenied(nil, "COMMAND-Cs5pd", "Errors.CustomRole.PermissionNotHeld"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			AssertValidation(t, context.Background(), (&Commands{zitadelRoles: tt.args.zitadelRoles}).AddOrgMemberCommand(tt.args.a, tt.args.userID, tt.args.roles...), tt.args.filter, tt.want)
+			AssertValidation(t, context.Background(), (&Commands{zitadelRoles: tt.args.zitadelRoles, checkPermission: tt.args.checkPermission}).AddOrgMemberCommand(tt.args.a, tt.args.userID, tt.args.roles...), tt.args.filter, tt.want)
 		})
 	}
 }
@@ -287,8 +369,9 @@ func TestIsMember(t *testing.T) {
 
 func TestCommandSide_AddOrgMember(t *testing.T) {
 	type fields struct {
-		eventstore   *eventstore.Eventstore
-		zitadelRoles []authz.RoleMapping
+		eventstore      *eventstore.Eventstore
+		zitadelRoles    []authz.RoleMapping
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx    context.Context
@@ -326,6 +409,7 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 			fields: fields{
 				eventstore: eventstoreExpect(
 					t,
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -338,6 +422,29 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "custom role with permission not held, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomRoleAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				orgID:  "org1",
+				userID: "user1",
+				roles:  []string{"ORG_HELPDESK"},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 		{
 			name: "user not existing, precondition error",
 			fields: fields{
@@ -510,8 +617,9 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:   tt.fields.eventstore,
-				zitadelRoles: tt.fields.zitadelRoles,
+				eventstore:      tt.fields.eventstore,
+				zitadelRoles:    tt.fields.zitadelRoles,
+				checkPermission: tt.fields.checkPermission,
 			}
 			got, err := r.AddOrgMember(tt.args.ctx, tt.args.orgID, tt.args.userID, tt.args.roles...)
 			if tt.res.err == nil {
@@ -529,8 +637,9 @@ func TestCommandSide_AddOrgMember(t *testing.T) {
 
 func TestCommandSide_ChangeOrgMember(t *testing.T) {
 	type fields struct {
-		eventstore   *eventstore.Eventstore
-		zitadelRoles []authz.RoleMapping
+		eventstore      *eventstore.Eventstore
+		zitadelRoles    []authz.RoleMapping
+		checkPermission domain.PermissionCheck
 	}
 	type args struct {
 		ctx    context.Context
@@ -586,6 +695,33 @@ func TestCommandSide_ChangeOrgMember(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "custom role with permission not held, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewCustomRoleAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "ORG_HELPDESK", "Help Desk", []string{"user.credential.write"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				member: &domain.Member{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "org1",
+					},
+					UserID: "user1",
+					Roles:  []string{"ORG_HELPDESK"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
 		{
 			name: "member not existing, not found error",
 			fields: fields{
@@ -704,8 +840,9 @@ func TestCommandSide_ChangeOrgMember(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:   tt.fields.eventstore,
-				zitadelRoles: tt.fields.zitadelRoles,
+				eventstore:      tt.fields.eventstore,
+				zitadelRoles:    tt.fields.zitadelRoles,
+				checkPermission: tt.fields.checkPermission,
 			}
 			got, err := r.ChangeOrgMember(tt.args.ctx, tt.args.member)
 			if tt.res.err == nil {
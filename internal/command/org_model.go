@@ -44,6 +44,12 @@ func (wm *OrgWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotReducer].
+// It must be increased if the reduce logic changes.
+func (wm *OrgWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *OrgWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
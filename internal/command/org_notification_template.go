@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetOrgNotificationTemplate replaces the custom template of the message type in the language.
+// The template is validated by rendering it with sample data.
+func (c *Commands) SetOrgNotificationTemplate(ctx context.Context, resourceOwner string, template *domain.NotificationTemplate) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nt4rO", "Errors.ResourceOwnerMissing")
+	}
+	if err := template.IsValid(i18n.SupportedLanguages()); err != nil {
+		return nil, err
+	}
+	if err := templates.ValidateCustomTemplate(template.Subject, template.HTML, template.Text); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.orgNotificationTemplateWriteModel(ctx, resourceOwner, template.MessageType, template.Language)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.HasChanged(template) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationTemplateSetEvent(
+		ctx,
+		&org.NewAggregate(resourceOwner).Aggregate,
+		template.MessageType,
+		template.Language,
+		template.Subject,
+		template.HTML,
+		template.Text,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveOrgNotificationTemplate removes the custom template of the message type in the language,
+// so the default template is used again.
+func (c *Commands) RemoveOrgNotificationTemplate(ctx context.Context, resourceOwner, messageType string, lang language.Tag) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nt5rO", "Errors.ResourceOwnerMissing")
+	}
+	if messageType == "" || lang == language.Und {
+		return nil, zerrors.ThrowInvalidArgument(nil, "ORG-Nt6iA", "Errors.NotificationTemplate.Invalid")
+	}
+	writeModel, err := c.orgNotificationTemplateWriteModel(ctx, resourceOwner, messageType, lang)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Nt7nF", "Errors.NotificationTemplate.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewNotificationTemplateRemovedEvent(
+		ctx,
+		OrgAggregateFromWriteModel(&writeModel.WriteModel),
+		messageType,
+		lang,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(writeModel, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) orgNotificationTemplateWriteModel(ctx context.Context, orgID, messageType string, lang language.Tag) (_ *OrgNotificationTemplateWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewOrgNotificationTemplateWriteModel(orgID, messageType, lang)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type OrgNotificationTemplateWriteModel struct {
+	eventstore.WriteModel
+
+	MessageType string
+	Language    language.Tag
+	Subject     string
+	HTML        string
+	Text        string
+	State       domain.PolicyState
+}
+
+func NewOrgNotificationTemplateWriteModel(orgID, messageType string, lang language.Tag) *OrgNotificationTemplateWriteModel {
+	return &OrgNotificationTemplateWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+		MessageType: messageType,
+		Language:    lang,
+	}
+}
+
+func (wm *OrgNotificationTemplateWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.NotificationTemplateSetEvent:
+			if e.MessageType != wm.MessageType || e.Language != wm.Language {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.NotificationTemplateRemovedEvent:
+			if e.MessageType != wm.MessageType || e.Language != wm.Language {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *org.OrgRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgNotificationTemplateWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.NotificationTemplateSetEvent:
+			wm.Subject = e.Subject
+			wm.HTML = e.HTML
+			wm.Text = e.Text
+			wm.State = domain.PolicyStateActive
+		case *org.NotificationTemplateRemovedEvent, *org.OrgRemovedEvent:
+			wm.Subject = ""
+			wm.HTML = ""
+			wm.Text = ""
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgNotificationTemplateWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.NotificationTemplateSetEventType,
+			org.NotificationTemplateRemovedEventType,
+			org.OrgRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgNotificationTemplateWriteModel) Exists() bool {
+	return wm.State == domain.PolicyStateActive
+}
+
+func (wm *OrgNotificationTemplateWriteModel) HasChanged(template *domain.NotificationTemplate) bool {
+	return !wm.Exists() ||
+		wm.Subject != template.Subject ||
+		wm.HTML != template.HTML ||
+		wm.Text != template.Text
+}
@@ -0,0 +1,338 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetOrgNotificationTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		template      *domain.NotificationTemplate
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no resource owner, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:      context.Background(),
+				template: &domain.NotificationTemplate{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown message type, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: "Unknown",
+					Language:    AllowedLanguage,
+					Text:        "{{.Text}}",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "empty template, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Language:    AllowedLanguage,
+					Subject:     "{{.Subject}}",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unsupported language, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Language:    UnsupportedLanguage,
+					Text:        "{{.Text}}",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid template, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Language:    AllowedLanguage,
+					HTML:        "<p>{{.Unknown}}</p>",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "template set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						org.NewNotificationTemplateSetEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+							"{{.Subject}}",
+							"<p>{{.Args.Code}}</p>",
+							"{{.Args.Code}}",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Subject:     "{{.Subject}}",
+					HTML:        "<p>{{.Args.Code}}</p>",
+					Text:        "{{.Args.Code}}",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "template unchanged, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewNotificationTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"",
+								"",
+								"{{.Args.Code}}",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				template: &domain.NotificationTemplate{
+					MessageType: domain.InitCodeMessageType,
+					Language:    language.English,
+					Text:        "{{.Args.Code}}",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetOrgNotificationTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.template)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveOrgNotificationTemplate(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		messageType   string
+		lang          language.Tag
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no resource owner, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:         context.Background(),
+				messageType: domain.InitCodeMessageType,
+				lang:        language.English,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "undefined language, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewNotificationTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.German,
+								"",
+								"",
+								"{{.Args.Code}}",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewNotificationTemplateSetEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								domain.InitCodeMessageType,
+								language.English,
+								"",
+								"",
+								"{{.Args.Code}}",
+							),
+						),
+					),
+					expectPush(
+						org.NewNotificationTemplateRemovedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							domain.InitCodeMessageType,
+							language.English,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				messageType:   domain.InitCodeMessageType,
+				lang:          language.English,
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.RemoveOrgNotificationTemplate(tt.args.ctx, tt.args.resourceOwner, tt.args.messageType, tt.args.lang)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// maxOrgHierarchyDepth limits the number of ancestors of an organization.
+// It also prevents endless loops in case of a (concurrently created) cycle.
+const maxOrgHierarchyDepth = 32
+
+// SetOrgParent places the organization below the parent organization.
+// The caller must be allowed to manage both organizations.
+func (c *Commands) SetOrgParent(ctx context.Context, orgID, parentOrgID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" || parentOrgID == "" || orgID == parentOrgID {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Op1rt", "Errors.Org.Parent.Invalid")
+	}
+	existing, err := c.orgParentWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrgStateExists(existing.State) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Op2rt", "Errors.Org.NotFound")
+	}
+	if existing.ParentOrgID == parentOrgID {
+		return writeModelToObjectDetails(&existing.WriteModel), nil
+	}
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, parentOrgID, parentOrgID); err != nil {
+		return nil, err
+	}
+	parent, err := c.orgParentWriteModel(ctx, parentOrgID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrgStateExists(parent.State) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Op3rt", "Errors.Org.Parent.NotFound")
+	}
+	ancestors, err := orgAncestors(ctx, c.eventstore.Filter, parent)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(ancestors, orgID) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Op4rt", "Errors.Org.Parent.Cycle")
+	}
+	// the descendants of the organization are moved as well
+	height, err := c.orgSubtreeHeight(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ancestors)+height >= maxOrgHierarchyDepth {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Op5rt", "Errors.Org.Parent.TooDeep")
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		org.NewOrgParentSetEvent(ctx, OrgAggregateFromWriteModel(&existing.WriteModel), parentOrgID),
+	)
+}
+
+// RemoveOrgParent makes the organization a root organization again.
+// The caller must be allowed to manage both the organization and its current parent,
+// so the organization can't escape the administration of its parent on its own.
+func (c *Commands) RemoveOrgParent(ctx context.Context, orgID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Op6rt", "Errors.Org.Parent.Invalid")
+	}
+	existing, err := c.orgParentWriteModel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOrgStateExists(existing.State) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Op7rt", "Errors.Org.NotFound")
+	}
+	if existing.ParentOrgID == "" {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Op8rt", "Errors.Org.Parent.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, existing.ParentOrgID, existing.ParentOrgID); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, existing,
+		org.NewOrgParentRemovedEvent(ctx, OrgAggregateFromWriteModel(&existing.WriteModel), existing.ParentOrgID),
+	)
+}
+
+// orgAncestorIDs returns the IDs of the ancestors of the organization, beginning with its parent.
+func orgAncestorIDs(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string) ([]string, error) {
+	wm, err := orgParentWriteModel(ctx, filter, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return orgAncestors(ctx, filter, wm)
+}
+
+// orgAncestors returns the IDs of the ancestors of the organization, beginning with its parent.
+// Removed organizations end the hierarchy.
+func orgAncestors(ctx context.Context, filter preparation.FilterToQueryReducer, wm *OrgParentWriteModel) ([]string, error) {
+	var ancestors []string
+	for wm.ParentOrgID != "" && len(ancestors) < maxOrgHierarchyDepth {
+		if slices.Contains(ancestors, wm.ParentOrgID) {
+			break
+		}
+		parent, err := orgParentWriteModel(ctx, filter, wm.ParentOrgID)
+		if err != nil {
+			return nil, err
+		}
+		if !isOrgStateExists(parent.State) {
+			break
+		}
+		ancestors = append(ancestors, parent.AggregateID)
+		wm = parent
+	}
+	return ancestors, nil
+}
+
+// orgSubtreeHeight returns the number of levels of descendants below the organization.
+// The search stops once the maximum depth is reached.
+func (c *Commands) orgSubtreeHeight(ctx context.Context, orgID string) (height int, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	visited := []string{orgID}
+	level := []string{orgID}
+	for height < maxOrgHierarchyDepth {
+		candidates := NewOrgChildCandidatesWriteModel(level)
+		if err := c.eventstore.FilterToQueryReducer(ctx, candidates); err != nil {
+			return 0, err
+		}
+		if len(candidates.OrgIDs) == 0 {
+			return height, nil
+		}
+		parents := NewOrgParentsWriteModel(candidates.OrgIDs)
+		if err := c.eventstore.FilterToQueryReducer(ctx, parents); err != nil {
+			return 0, err
+		}
+		children := make([]string, 0, len(candidates.OrgIDs))
+		for _, childID := range candidates.OrgIDs {
+			if slices.Contains(level, parents.ParentOrgIDs[childID]) && !slices.Contains(visited, childID) {
+				children = append(children, childID)
+			}
+		}
+		if len(children) == 0 {
+			return height, nil
+		}
+		visited = append(visited, children...)
+		level = children
+		height++
+	}
+	return height, nil
+}
+
+func (c *Commands) orgParentWriteModel(ctx context.Context, orgID string) (_ *OrgParentWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm := NewOrgParentWriteModel(orgID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+func orgParentWriteModel(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string) (*OrgParentWriteModel, error) {
+	wm := NewOrgParentWriteModel(orgID)
+	events, err := filter(ctx, wm.Query())
+	if err != nil {
+		return nil, err
+	}
+	wm.AppendEvents(events...)
+	return wm, wm.Reduce()
+}
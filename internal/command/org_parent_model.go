@@ -0,0 +1,146 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// OrgParentWriteModel reduces the parent of a single organization.
+type OrgParentWriteModel struct {
+	eventstore.WriteModel
+
+	State       domain.OrgState
+	ParentOrgID string
+}
+
+func NewOrgParentWriteModel(orgID string) *OrgParentWriteModel {
+	return &OrgParentWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   orgID,
+			ResourceOwner: orgID,
+		},
+	}
+}
+
+func (wm *OrgParentWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *OrgParentWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.OrgAddedEvent:
+			wm.State = domain.OrgStateActive
+		case *org.OrgDeactivatedEvent:
+			wm.State = domain.OrgStateInactive
+		case *org.OrgReactivatedEvent:
+			wm.State = domain.OrgStateActive
+		case *org.OrgRemovedEvent:
+			wm.State = domain.OrgStateRemoved
+			wm.ParentOrgID = ""
+		case *org.OrgParentSetEvent:
+			wm.ParentOrgID = e.ParentOrgID
+		case *org.OrgParentRemovedEvent:
+			wm.ParentOrgID = ""
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgParentWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.OrgAddedEventType,
+			org.OrgDeactivatedEventType,
+			org.OrgReactivatedEventType,
+			org.OrgRemovedEventType,
+			org.OrgParentSetEventType,
+			org.OrgParentRemovedEventType,
+		).
+		Builder()
+}
+
+// OrgChildCandidatesWriteModel collects the organizations which have been placed below one of the parent organizations at any time.
+// The current parent of the candidates is reduced by [OrgParentsWriteModel].
+type OrgChildCandidatesWriteModel struct {
+	eventstore.WriteModel
+
+	ParentOrgIDs []string
+	OrgIDs       []string
+}
+
+func NewOrgChildCandidatesWriteModel(parentOrgIDs []string) *OrgChildCandidatesWriteModel {
+	return &OrgChildCandidatesWriteModel{
+		ParentOrgIDs: parentOrgIDs,
+	}
+}
+
+func (wm *OrgChildCandidatesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if _, ok := event.(*org.OrgParentSetEvent); ok && !slices.Contains(wm.OrgIDs, event.Aggregate().ID) {
+			wm.OrgIDs = append(wm.OrgIDs, event.Aggregate().ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgChildCandidatesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent)
+	for _, parentOrgID := range wm.ParentOrgIDs {
+		query = query.
+			AddQuery().
+			AggregateTypes(org.AggregateType).
+			EventTypes(org.OrgParentSetEventType).
+			EventData(map[string]interface{}{"parentOrgId": parentOrgID}).
+			Builder()
+	}
+	return query
+}
+
+// OrgParentsWriteModel reduces the current parent of multiple organizations.
+// Removed organizations have no parent.
+type OrgParentsWriteModel struct {
+	eventstore.WriteModel
+
+	OrgIDs       []string
+	ParentOrgIDs map[string]string
+}
+
+func NewOrgParentsWriteModel(orgIDs []string) *OrgParentsWriteModel {
+	return &OrgParentsWriteModel{
+		OrgIDs:       orgIDs,
+		ParentOrgIDs: make(map[string]string, len(orgIDs)),
+	}
+}
+
+func (wm *OrgParentsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *org.OrgParentSetEvent:
+			wm.ParentOrgIDs[e.Aggregate().ID] = e.ParentOrgID
+		case *org.OrgParentRemovedEvent, *org.OrgRemovedEvent:
+			delete(wm.ParentOrgIDs, e.Aggregate().ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *OrgParentsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.OrgIDs...).
+		EventTypes(
+			org.OrgRemovedEventType,
+			org.OrgParentSetEventType,
+			org.OrgParentRemovedEventType,
+		).
+		Builder()
+}
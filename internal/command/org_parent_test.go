@@ -0,0 +1,409 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SetOrgParent(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		orgID       string
+		parentOrgID string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "parent equals org, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "parent already set, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+					),
+				),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "no permission on parent, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+				),
+				checkPermission: func(_ context.Context, _, orgID, _ string) error {
+					if orgID == "parent1" {
+						return zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+					}
+					return nil
+				},
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "parent not existing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "parent is descendant, cycle error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "parent"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "subtree too deep, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					append([]expect{
+						expectFilter(
+							eventFromEventPusher(
+								org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+							),
+						),
+						expectFilter(
+							eventFromEventPusher(
+								org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "parent"),
+							),
+						),
+					}, expectOrgSubtree("org1", maxOrgHierarchyDepth)...)...,
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set parent, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "parent"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "root1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("root1").Aggregate, "root"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("child1").Aggregate, "org1"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("child2").Aggregate, "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("child1").Aggregate, "org1"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("child2").Aggregate, "org1"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentRemovedEvent(context.Background(), &org.NewAggregate("child2").Aggregate, "org1"),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				orgID:       "org1",
+				parentOrgID: "parent1",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.SetOrgParent(context.Background(), tt.args.orgID, tt.args.parentOrgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveOrgParent(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "no parent, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "no permission on parent, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+					),
+				),
+				checkPermission: func(_ context.Context, _, orgID, _ string) error {
+					if orgID == "parent1" {
+						return zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+					}
+					return nil
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "remove parent, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						),
+					),
+					expectPush(
+						org.NewOrgParentRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.RemoveOrgParent(context.Background(), "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, got)
+			}
+		})
+	}
+}
+
+// expectOrgSubtree expects the queries of the descendants of the organization,
+// each level consisting of a single child of the previous one
+func expectOrgSubtree(orgID string, height int) []expect {
+	expects := make([]expect, 0, 2*height)
+	parentID := orgID
+	for i := 0; i < height; i++ {
+		childID := fmt.Sprintf("%s-child%d", orgID, i)
+		childSet := eventFromEventPusher(
+			org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate(childID).Aggregate, parentID),
+		)
+		expects = append(expects, expectFilter(childSet), expectFilter(childSet))
+		parentID = childID
+	}
+	return expects
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func (c *Commands) AddInactivityPolicy(ctx context.Context, resourceOwner string, policy *domain.InactivityPolicy) (_ *domain.InactivityPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ina1r", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ina2v", "Errors.Org.InactivityPolicy.Invalid")
+	}
+	addedPolicy, err := orgInactivityPolicyWriteModelByID(ctx, resourceOwner, c.eventstore.FilterToQueryReducer)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, zerrors.ThrowAlreadyExists(nil, "ORG-Ina3e", "Errors.Org.InactivityPolicy.AlreadyExists")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&addedPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewInactivityPolicyAddedEvent(
+		ctx,
+		orgAgg,
+		policy.InactivityPeriod,
+		policy.WarningPeriod,
+		policy.DryRun,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToInactivityPolicy(&addedPolicy.InactivityPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeInactivityPolicy(ctx context.Context, resourceOwner string, policy *domain.InactivityPolicy) (_ *domain.InactivityPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ina4r", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ina5v", "Errors.Org.InactivityPolicy.Invalid")
+	}
+	existingPolicy, err := orgInactivityPolicyWriteModelByID(ctx, resourceOwner, c.eventstore.FilterToQueryReducer)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Ina6n", "Errors.Org.InactivityPolicy.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.InactivityPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, orgAgg, policy.InactivityPeriod, policy.WarningPeriod, policy.DryRun)
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "ORG-Ina7c", "Errors.Org.InactivityPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToInactivityPolicy(&existingPolicy.InactivityPolicyWriteModel), nil
+}
+
+func (c *Commands) RemoveInactivityPolicy(ctx context.Context, orgID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "Org-Ina8r", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := orgInactivityPolicyWriteModelByID(ctx, orgID, c.eventstore.FilterToQueryReducer)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "ORG-Ina9n", "Errors.Org.InactivityPolicy.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewInactivityPolicyRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingPolicy.InactivityPolicyWriteModel.WriteModel), nil
+}
+
+func orgInactivityPolicyWriteModelByID(ctx context.Context, orgID string, queryReducer func(ctx context.Context, r eventstore.QueryReducer) error) (_ *OrgInactivityPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	policy := NewOrgInactivityPolicyWriteModel(orgID)
+	err = queryReducer(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,369 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_AddInactivityPolicy(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		orgID  string
+		policy *domain.InactivityPolicy
+	}
+	type res struct {
+		want *domain.InactivityPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "warning period not before inactivity period, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 7 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy already existing, already exists error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewInactivityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add policy, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewInactivityPolicyAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							90*24*time.Hour,
+							7*24*time.Hour,
+							false,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				want: &domain.InactivityPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.AddInactivityPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeInactivityPolicy(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		orgID  string
+		policy *domain.InactivityPolicy
+	}
+	type res struct {
+		want *domain.InactivityPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "policy not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewInactivityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    7 * 24 * time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewInactivityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								false,
+							),
+						),
+					),
+					expectPush(
+						newInactivityPolicyChangedEvent(context.Background(), "org1",
+							[]policy.InactivityPolicyChanges{
+								policy.ChangeInactivityWarningPeriod(14 * 24 * time.Hour),
+								policy.ChangeInactivityDryRun(true),
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.InactivityPolicy{
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    14 * 24 * time.Hour,
+					DryRun:           true,
+				},
+			},
+			res: res{
+				want: &domain.InactivityPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					InactivityPeriod: 90 * 24 * time.Hour,
+					WarningPeriod:    14 * 24 * time.Hour,
+					DryRun:           true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.ChangeInactivityPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveInactivityPolicy(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		orgID string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "policy not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewInactivityPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								90*24*time.Hour,
+								7*24*time.Hour,
+								false,
+							),
+						),
+					),
+					expectPush(
+						org.NewInactivityPolicyRemovedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RemoveInactivityPolicy(tt.args.ctx, tt.args.orgID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newInactivityPolicyChangedEvent(ctx context.Context, orgID string, changes []policy.InactivityPolicyChanges) *org.InactivityPolicyChangedEvent {
+	event, _ := org.NewInactivityPolicyChangedEvent(ctx,
+		&org.NewAggregate(orgID).Aggregate,
+		changes,
+	)
+	return event
+}
@@ -96,6 +96,19 @@ func (c *Commands) getOrgLoginPolicy(ctx context.Context, orgID string) (*domain
 	if policy.State == domain.PolicyStateActive {
 		return writeModelToLoginPolicy(&policy.LoginPolicyWriteModel), nil
 	}
+	ancestors, err := orgAncestorIDs(ctx, c.eventstore.Filter, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestorID := range ancestors {
+		policy, err = c.orgLoginPolicyWriteModelByID(ctx, ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		if policy.State == domain.PolicyStateActive {
+			return writeModelToLoginPolicy(&policy.LoginPolicyWriteModel), nil
+		}
+	}
 	return c.getDefaultLoginPolicy(ctx)
 }
 
@@ -10,12 +10,12 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *domain.NotificationPolicy) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "Org-x801sk2i", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddNotificationPolicy(orgAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func (c *Commands) AddNotificationPolicy(ctx context.Context, resourceOwner stri
 
 func prepareAddNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -45,18 +45,18 @@ func prepareAddNotificationPolicy(
 				return nil, zerrors.ThrowAlreadyExists(nil, "Org-xa08n2", "Errors.Org.NotificationPolicy.AlreadyExists")
 			}
 			return []eventstore.Command{
-				org.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, passwordChange),
+				org.NewNotificationPolicyAddedEvent(ctx, &a.Aggregate, notificationPolicy),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, passwordChange bool) (*domain.ObjectDetails, error) {
+func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner string, notificationPolicy *domain.NotificationPolicy) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "Org-x091n1g", "Errors.ResourceOwnerMissing")
 	}
 	orgAgg := org.NewAggregate(resourceOwner)
-	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, passwordChange))
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareChangeNotificationPolicy(orgAgg, notificationPolicy))
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +69,7 @@ func (c *Commands) ChangeNotificationPolicy(ctx context.Context, resourceOwner s
 
 func prepareChangeNotificationPolicy(
 	a *org.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
@@ -86,7 +86,7 @@ func prepareChangeNotificationPolicy(
 			if writeModel.State == domain.PolicyStateUnspecified || writeModel.State == domain.PolicyStateRemoved {
 				return nil, zerrors.ThrowNotFound(nil, "ORG-x029n3", "Errors.Org.NotificationPolicy.NotFound")
 			}
-			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, passwordChange)
+			change, hasChanged := writeModel.NewChangedEvent(ctx, &a.Aggregate, notificationPolicy)
 			if !hasChanged {
 				return nil, zerrors.ThrowPreconditionFailed(nil, "Org-ioqnxz", "Errors.Org.NotificationPolicy.NotChanged")
 			}
@@ -3,9 +3,9 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/org"
-	"github.com/zitadel/zitadel/internal/repository/policy"
 )
 
 type OrgNotificationPolicyWriteModel struct {
@@ -55,13 +55,9 @@ func (wm *OrgNotificationPolicyWriteModel) Query() *eventstore.SearchQueryBuilde
 func (wm *OrgNotificationPolicyWriteModel) NewChangedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) (*org.NotificationPolicyChangedEvent, bool) {
-
-	changes := make([]policy.NotificationPolicyChanges, 0)
-	if wm.PasswordChange != passwordChange {
-		changes = append(changes, policy.ChangePasswordChange(passwordChange))
-	}
+	changes := wm.changes(notificationPolicy)
 	if len(changes) == 0 {
 		return nil, false
 	}
@@ -18,9 +18,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                context.Context
+		orgID              string
+		notificationPolicy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -40,9 +40,9 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorInvalidArgument,
@@ -57,16 +57,16 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorAlreadyExists,
@@ -81,15 +81,15 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 					expectPush(
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
-							true,
+							&domain.NotificationPolicy{PasswordChange: true},
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -106,15 +106,15 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 					expectPush(
 						org.NewNotificationPolicyAddedEvent(context.Background(),
 							&org.NewAggregate("org1").Aggregate,
-							false,
+							&domain.NotificationPolicy{PasswordChange: false},
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: false},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -128,7 +128,7 @@ func TestCommandSide_AddNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.AddNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -147,9 +147,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 		eventstore *eventstore.Eventstore
 	}
 	type args struct {
-		ctx            context.Context
-		orgID          string
-		passwordChange bool
+		ctx                context.Context
+		orgID              string
+		notificationPolicy *domain.NotificationPolicy
 	}
 	type res struct {
 		want *domain.ObjectDetails
@@ -169,8 +169,8 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				passwordChange: true,
+				ctx:                context.Background(),
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsErrorInvalidArgument,
@@ -185,9 +185,9 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsNotFound,
@@ -202,16 +202,16 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: true,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: true},
 			},
 			res: res{
 				err: zerrors.IsPreconditionFailed,
@@ -226,7 +226,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
@@ -236,9 +236,53 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 				),
 			},
 			args: args{
-				ctx:            context.Background(),
-				orgID:          "org1",
-				passwordChange: false,
+				ctx:                context.Background(),
+				orgID:              "org1",
+				notificationPolicy: &domain.NotificationPolicy{PasswordChange: false},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "change security notifications, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewNotificationPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								&domain.NotificationPolicy{PasswordChange: true},
+							),
+						),
+					),
+					expectPush(
+						func() *org.NotificationPolicyChangedEvent {
+							event, _ := org.NewNotificationPolicyChangedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								[]policy.NotificationPolicyChanges{
+									policy.ChangeNewDeviceSignIn(true),
+									policy.ChangeMFARemoved(true),
+									policy.ChangeAccountLocked(true),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				notificationPolicy: &domain.NotificationPolicy{
+					PasswordChange:  true,
+					NewDeviceSignIn: true,
+					MFARemoved:      true,
+					AccountLocked:   true,
+				},
 			},
 			res: res{
 				want: &domain.ObjectDetails{
@@ -252,7 +296,7 @@ func TestCommandSide_ChangeNotificationPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.passwordChange)
+			got, err := r.ChangeNotificationPolicy(tt.args.ctx, tt.args.orgID, tt.args.notificationPolicy)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -323,7 +367,7 @@ func TestCommandSide_RemoveNotificationPolicy(t *testing.T) {
 						eventFromEventPusher(
 							org.NewNotificationPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
-								true,
+								&domain.NotificationPolicy{PasswordChange: true},
 							),
 						),
 					),
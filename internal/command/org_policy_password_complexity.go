@@ -20,6 +20,19 @@ func (c *Commands) getOrgPasswordComplexityPolicy(ctx context.Context, orgID str
 	if policy.State == domain.PolicyStateActive {
 		return orgWriteModelToPasswordComplexityPolicy(policy), nil
 	}
+	ancestors, err := orgAncestorIDs(ctx, c.eventstore.Filter, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestorID := range ancestors {
+		policy, err = c.orgPasswordComplexityPolicyWriteModelByID(ctx, ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		if policy.State == domain.PolicyStateActive {
+			return orgWriteModelToPasswordComplexityPolicy(policy), nil
+		}
+	}
 	return c.getDefaultPasswordComplexityPolicy(ctx)
 }
 
@@ -154,6 +154,7 @@ func TestCommandSide_AddOrg(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username1",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type InactivityPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	InactivityPeriod time.Duration
+	WarningPeriod    time.Duration
+	DryRun           bool
+	State            domain.PolicyState
+}
+
+func (wm *InactivityPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.InactivityPolicyAddedEvent:
+			wm.InactivityPeriod = e.InactivityPeriod
+			wm.WarningPeriod = e.WarningPeriod
+			wm.DryRun = e.DryRun
+			wm.State = domain.PolicyStateActive
+		case *policy.InactivityPolicyChangedEvent:
+			if e.InactivityPeriod != nil {
+				wm.InactivityPeriod = *e.InactivityPeriod
+			}
+			if e.WarningPeriod != nil {
+				wm.WarningPeriod = *e.WarningPeriod
+			}
+			if e.DryRun != nil {
+				wm.DryRun = *e.DryRun
+			}
+		case *policy.InactivityPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InactivityPolicyWriteModel) changes(inactivityPeriod, warningPeriod time.Duration, dryRun bool) []policy.InactivityPolicyChanges {
+	changes := make([]policy.InactivityPolicyChanges, 0, 3)
+	if wm.InactivityPeriod != inactivityPeriod {
+		changes = append(changes, policy.ChangeInactivityPeriod(inactivityPeriod))
+	}
+	if wm.WarningPeriod != warningPeriod {
+		changes = append(changes, policy.ChangeInactivityWarningPeriod(warningPeriod))
+	}
+	if wm.DryRun != dryRun {
+		changes = append(changes, policy.ChangeInactivityDryRun(dryRun))
+	}
+	return changes
+}
+
+func writeModelToInactivityPolicy(wm *InactivityPolicyWriteModel) *domain.InactivityPolicy {
+	return &domain.InactivityPolicy{
+		ObjectRoot:       writeModelToObjectRoot(wm.WriteModel),
+		InactivityPeriod: wm.InactivityPeriod,
+		WarningPeriod:    wm.WarningPeriod,
+		DryRun:           wm.DryRun,
+	}
+}
@@ -9,8 +9,14 @@ import (
 type NotificationPolicyWriteModel struct {
 	eventstore.WriteModel
 
-	PasswordChange bool
-	State          domain.PolicyState
+	PasswordChange           bool
+	NewDeviceSignIn          bool
+	MFAAdded                 bool
+	MFARemoved               bool
+	EmailChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
+	State                    domain.PolicyState
 }
 
 func (wm *NotificationPolicyWriteModel) Reduce() error {
@@ -18,14 +24,64 @@ func (wm *NotificationPolicyWriteModel) Reduce() error {
 		switch e := event.(type) {
 		case *policy.NotificationPolicyAddedEvent:
 			wm.PasswordChange = e.PasswordChange
+			wm.NewDeviceSignIn = e.NewDeviceSignIn
+			wm.MFAAdded = e.MFAAdded
+			wm.MFARemoved = e.MFARemoved
+			wm.EmailChange = e.EmailChange
+			wm.AccountLocked = e.AccountLocked
+			wm.PersonalAccessTokenAdded = e.PersonalAccessTokenAdded
 			wm.State = domain.PolicyStateActive
 		case *policy.NotificationPolicyChangedEvent:
 			if e.PasswordChange != nil {
 				wm.PasswordChange = *e.PasswordChange
 			}
+			if e.NewDeviceSignIn != nil {
+				wm.NewDeviceSignIn = *e.NewDeviceSignIn
+			}
+			if e.MFAAdded != nil {
+				wm.MFAAdded = *e.MFAAdded
+			}
+			if e.MFARemoved != nil {
+				wm.MFARemoved = *e.MFARemoved
+			}
+			if e.EmailChange != nil {
+				wm.EmailChange = *e.EmailChange
+			}
+			if e.AccountLocked != nil {
+				wm.AccountLocked = *e.AccountLocked
+			}
+			if e.PersonalAccessTokenAdded != nil {
+				wm.PersonalAccessTokenAdded = *e.PersonalAccessTokenAdded
+			}
 		case *policy.NotificationPolicyRemovedEvent:
 			wm.State = domain.PolicyStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
+
+func (wm *NotificationPolicyWriteModel) changes(notificationPolicy *domain.NotificationPolicy) []policy.NotificationPolicyChanges {
+	changes := make([]policy.NotificationPolicyChanges, 0)
+	if wm.PasswordChange != notificationPolicy.PasswordChange {
+		changes = append(changes, policy.ChangePasswordChange(notificationPolicy.PasswordChange))
+	}
+	if wm.NewDeviceSignIn != notificationPolicy.NewDeviceSignIn {
+		changes = append(changes, policy.ChangeNewDeviceSignIn(notificationPolicy.NewDeviceSignIn))
+	}
+	if wm.MFAAdded != notificationPolicy.MFAAdded {
+		changes = append(changes, policy.ChangeMFAAdded(notificationPolicy.MFAAdded))
+	}
+	if wm.MFARemoved != notificationPolicy.MFARemoved {
+		changes = append(changes, policy.ChangeMFARemoved(notificationPolicy.MFARemoved))
+	}
+	if wm.EmailChange != notificationPolicy.EmailChange {
+		changes = append(changes, policy.ChangeEmailChange(notificationPolicy.EmailChange))
+	}
+	if wm.AccountLocked != notificationPolicy.AccountLocked {
+		changes = append(changes, policy.ChangeAccountLocked(notificationPolicy.AccountLocked))
+	}
+	if wm.PersonalAccessTokenAdded != notificationPolicy.PersonalAccessTokenAdded {
+		changes = append(changes, policy.ChangePersonalAccessTokenAdded(notificationPolicy.PersonalAccessTokenAdded))
+	}
+	return changes
+}
@@ -17,11 +17,12 @@ func projectWriteModelToProject(writeModel *ProjectWriteModel) *domain.Project {
 
 func projectGrantWriteModelToProjectGrant(writeModel *ProjectGrantWriteModel) *domain.ProjectGrant {
 	return &domain.ProjectGrant{
-		ObjectRoot:   writeModelToObjectRoot(writeModel.WriteModel),
-		GrantID:      writeModel.GrantID,
-		GrantedOrgID: writeModel.GrantedOrgID,
-		RoleKeys:     writeModel.RoleKeys,
-		State:        writeModel.State,
+		ObjectRoot:    writeModelToObjectRoot(writeModel.WriteModel),
+		GrantID:       writeModel.GrantID,
+		GrantedOrgID:  writeModel.GrantedOrgID,
+		RoleKeys:      writeModel.RoleKeys,
+		State:         writeModel.State,
+		GrantValidity: writeModel.Validity,
 	}
 }
 
@@ -76,10 +77,11 @@ func apiWriteModelToAPIConfig(writeModel *APIApplicationWriteModel) *domain.APIA
 
 func roleWriteModelToRole(writeModel *ProjectRoleWriteModel) *domain.ProjectRole {
 	return &domain.ProjectRole{
-		ObjectRoot:  writeModelToObjectRoot(writeModel.WriteModel),
-		Key:         writeModel.Key,
-		DisplayName: writeModel.DisplayName,
-		Group:       writeModel.Group,
+		ObjectRoot:    writeModelToObjectRoot(writeModel.WriteModel),
+		Key:           writeModel.Key,
+		DisplayName:   writeModel.DisplayName,
+		Group:         writeModel.Group,
+		IncludedRoles: writeModel.IncludedRoles,
 	}
 }
 
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -20,6 +21,9 @@ func (c *Commands) AddProjectGrantWithID(ctx context.Context, grant *domain.Proj
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	if !isGrantValidityValid(grant.GrantValidity) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Gw3iv", "Errors.Project.Grant.ValidityInvalid")
+	}
 	return c.addProjectGrantWithID(ctx, grant, grantID, resourceOwner)
 }
 
@@ -27,6 +31,9 @@ func (c *Commands) AddProjectGrant(ctx context.Context, grant *domain.ProjectGra
 	if !grant.IsValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-3b8fs", "Errors.Project.Grant.Invalid")
 	}
+	if !isGrantValidityValid(grant.GrantValidity) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Gw4iv", "Errors.Project.Grant.ValidityInvalid")
+	}
 	err = c.checkProjectGrantPreCondition(ctx, grant, resourceOwner)
 	if err != nil {
 		return nil, err
@@ -45,9 +52,13 @@ func (c *Commands) addProjectGrantWithID(ctx context.Context, grant *domain.Proj
 
 	addedGrant := NewProjectGrantWriteModel(grant.GrantID, grant.AggregateID, resourceOwner)
 	projectAgg := ProjectAggregateFromWriteModel(&addedGrant.WriteModel)
-	pushedEvents, err := c.eventstore.Push(
-		ctx,
-		project.NewGrantAddedEvent(ctx, projectAgg, grant.GrantID, grant.GrantedOrgID, grant.RoleKeys))
+	events := []eventstore.Command{
+		project.NewGrantAddedEvent(ctx, projectAgg, grant.GrantID, grant.GrantedOrgID, grant.RoleKeys),
+	}
+	if !grant.GrantValidity.IsZero() {
+		events = append(events, project.NewGrantValidityChangedEvent(ctx, projectAgg, grant.GrantID, grant.ValidFrom, grant.ValidUntil))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +200,9 @@ func (c *Commands) ReactivateProjectGrant(ctx context.Context, projectID, grantI
 	if existingGrant.State != domain.ProjectGrantStateInactive {
 		return details, zerrors.ThrowPreconditionFailed(nil, "PROJECT-47fu8", "Errors.Project.Grant.NotInactive")
 	}
+	if existingGrant.Validity.IsExpiredAt(time.Now()) {
+		return details, zerrors.ThrowPreconditionFailed(nil, "PROJECT-Gw5ex", "Errors.Project.Grant.Expired")
+	}
 	projectAgg := ProjectAggregateFromWriteModel(&existingGrant.WriteModel)
 	pushedEvents, err := c.eventstore.Push(ctx, project.NewGrantReactivatedEvent(ctx, projectAgg, grantID))
 	if err != nil {
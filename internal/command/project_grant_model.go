@@ -14,6 +14,11 @@ type ProjectGrantWriteModel struct {
 	GrantedOrgID string
 	RoleKeys     []string
 	State        domain.ProjectGrantState
+	Validity     domain.GrantValidity
+	// ExpiryWarned is reset as soon as the validity changes
+	ExpiryWarned bool
+	// Expired is set if the grant was deactivated because its validity ended
+	Expired bool
 }
 
 func NewProjectGrantWriteModel(grantID, projectID, resourceOwner string) *ProjectGrantWriteModel {
@@ -53,6 +58,18 @@ func (wm *ProjectGrantWriteModel) AppendEvents(events ...eventstore.Event) {
 			if e.GrantID == wm.GrantID {
 				wm.WriteModel.AppendEvents(e)
 			}
+		case *project.GrantValidityChangedEvent:
+			if e.GrantID == wm.GrantID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *project.GrantExpiryWarnedEvent:
+			if e.GrantID == wm.GrantID {
+				wm.WriteModel.AppendEvents(e)
+			}
+		case *project.GrantExpiredEvent:
+			if e.GrantID == wm.GrantID {
+				wm.WriteModel.AppendEvents(e)
+			}
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -81,6 +98,18 @@ func (wm *ProjectGrantWriteModel) Reduce() error {
 				continue
 			}
 			wm.State = domain.ProjectGrantStateActive
+			wm.Expired = false
+		case *project.GrantValidityChangedEvent:
+			wm.Validity = domain.GrantValidity{ValidFrom: e.ValidFrom, ValidUntil: e.ValidUntil}
+			wm.ExpiryWarned = false
+		case *project.GrantExpiryWarnedEvent:
+			wm.ExpiryWarned = true
+		case *project.GrantExpiredEvent:
+			if wm.State == domain.ProjectGrantStateRemoved {
+				continue
+			}
+			wm.State = domain.ProjectGrantStateInactive
+			wm.Expired = true
 		case *project.GrantRemovedEvent:
 			wm.State = domain.ProjectGrantStateRemoved
 		case *project.ProjectRemovedEvent:
@@ -101,6 +130,9 @@ func (wm *ProjectGrantWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.GrantCascadeChangedType,
 			project.GrantDeactivatedType,
 			project.GrantReactivatedType,
+			project.GrantValidityChangedType,
+			project.GrantExpiryWarnedType,
+			project.GrantExpiredType,
 			project.GrantRemovedType,
 			project.ProjectRemovedType).
 		Builder()
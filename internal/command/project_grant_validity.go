@@ -0,0 +1,124 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetProjectGrantValidity restricts the project grant to the time window of the validity.
+// A grant, which was deactivated because it expired, is reactivated by a validity ending in the future.
+func (c *Commands) SetProjectGrantValidity(ctx context.Context, projectID, grantID, resourceOwner string, validity domain.GrantValidity) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if grantID == "" || projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Gv1id", "Errors.IDMissing")
+	}
+	if !isGrantValidityValid(validity) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Gv2iv", "Errors.Project.Grant.ValidityInvalid")
+	}
+	existingGrant, err := c.projectGrantWriteModelByID(ctx, grantID, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGrant.Validity.Equal(validity) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJECT-Gv3nc", "Errors.NoChangesFound")
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&existingGrant.WriteModel)
+	events := []eventstore.Command{
+		project.NewGrantValidityChangedEvent(ctx, projectAgg, grantID, validity.ValidFrom, validity.ValidUntil),
+	}
+	if existingGrant.Expired {
+		events = append(events, project.NewGrantReactivatedEvent(ctx, projectAgg, grantID))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+// WarnProjectGrantExpiry notifies the owners of the project's organization that the active project grant will expire.
+// The owners are only warned once per validity.
+// It's used by the grant expiry worker and therefore doesn't check any permission.
+func (c *Commands) WarnProjectGrantExpiry(ctx context.Context, projectID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	existingGrant, err := c.activeProjectGrantWithValidity(ctx, projectID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGrant.ExpiryWarned {
+		return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewGrantExpiryWarnedEvent(ctx,
+		ProjectAggregateFromWriteModel(&existingGrant.WriteModel),
+		grantID,
+		existingGrant.GrantedOrgID,
+		existingGrant.Validity.ValidUntil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+// ExpireProjectGrant deactivates the project grant after its validity ended.
+// It's used by the grant expiry worker and therefore doesn't check any permission.
+func (c *Commands) ExpireProjectGrant(ctx context.Context, projectID, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	existingGrant, err := c.activeProjectGrantWithValidity(ctx, projectID, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingGrant.Validity.IsExpiredAt(time.Now()) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJECT-Gv4ne", "Errors.Project.Grant.NotExpired")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewGrantExpiredEvent(ctx,
+		ProjectAggregateFromWriteModel(&existingGrant.WriteModel),
+		grantID,
+		existingGrant.Validity.ValidUntil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingGrant.WriteModel), nil
+}
+
+func (c *Commands) activeProjectGrantWithValidity(ctx context.Context, projectID, grantID, resourceOwner string) (*ProjectGrantWriteModel, error) {
+	if grantID == "" || projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Gv5id", "Errors.IDMissing")
+	}
+	existingGrant, err := c.projectGrantWriteModelByID(ctx, grantID, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingGrant.State != domain.ProjectGrantStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJECT-Gv6na", "Errors.Project.Grant.NotActive")
+	}
+	if existingGrant.Validity.ValidUntil.IsZero() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "PROJECT-Gv7nu", "Errors.Project.Grant.NoValidUntil")
+	}
+	return existingGrant, nil
+}
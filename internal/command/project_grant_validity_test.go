@@ -0,0 +1,390 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func projectGrantAddedTestEvent() eventstore.Event {
+	return eventFromEventPusher(project.NewGrantAddedEvent(context.Background(),
+		&project.NewAggregate("project1", "org1").Aggregate,
+		"projectgrant1",
+		"grantedorg1",
+		[]string{"key1"},
+	))
+}
+
+func projectGrantValidityChangedTestEvent(validUntil time.Time) eventstore.Event {
+	return eventFromEventPusher(project.NewGrantValidityChangedEvent(context.Background(),
+		&project.NewAggregate("project1", "org1").Aggregate,
+		"projectgrant1",
+		time.Time{},
+		validUntil,
+	))
+}
+
+func TestCommandSide_SetProjectGrantValidity(t *testing.T) {
+	validFrom := time.Now().Add(-time.Hour).UTC()
+	validUntil := time.Now().Add(24 * time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		projectID     string
+		grantID       string
+		resourceOwner string
+		validity      domain.GrantValidity
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing projectid, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "validity ends before it starts, invalid error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				projectID:     "project1",
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidFrom: validUntil, ValidUntil: validFrom},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "projectgrant not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "validity not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntil),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "validity set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+					),
+					expectPush(
+						project.NewGrantValidityChangedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							validFrom,
+							validUntil,
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidFrom: validFrom, ValidUntil: validUntil},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "expired grant extended, reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validFrom),
+						eventFromEventPusher(project.NewGrantExpiredEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							validFrom,
+						)),
+					),
+					expectPush(
+						project.NewGrantValidityChangedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							time.Time{},
+							validUntil,
+						),
+						project.NewGrantReactivatedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+						),
+					),
+				),
+			},
+			args: args{
+				projectID:     "project1",
+				grantID:       "projectgrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetProjectGrantValidity(context.Background(), tt.args.projectID, tt.args.grantID, tt.args.resourceOwner, tt.args.validity)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_WarnProjectGrantExpiry(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "projectgrant without end, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already warned, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntil),
+						eventFromEventPusher(project.NewGrantExpiryWarnedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							"grantedorg1",
+							validUntil,
+						)),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "warned, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntil),
+					),
+					expectPush(
+						project.NewGrantExpiryWarnedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							"grantedorg1",
+							validUntil,
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.WarnProjectGrantExpiry(context.Background(), "project1", "projectgrant1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ExpireProjectGrant(t *testing.T) {
+	validUntilPast := time.Now().Add(-time.Minute).UTC()
+	validUntilFuture := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "not yet expired, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntilFuture),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "projectgrant inactive, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntilPast),
+						eventFromEventPusher(project.NewGrantDeactivateEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+						)),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "expired, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						projectGrantAddedTestEvent(),
+						projectGrantValidityChangedTestEvent(validUntilPast),
+					),
+					expectPush(
+						project.NewGrantExpiredEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"projectgrant1",
+							validUntilPast,
+						),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ExpireProjectGrant(context.Background(), "project1", "projectgrant1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
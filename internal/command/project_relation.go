@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetProjectAuthorizationModel replaces the object types and relations of the project.
+// Relation tuples which no longer match the model are kept but cannot be added again.
+func (c *Commands) SetProjectAuthorizationModel(ctx context.Context, projectID, resourceOwner string, model *domain.AuthorizationModel) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rm1pI", "Errors.Project.ProjectIDMissing")
+	}
+	if err = model.Validate(); err != nil {
+		return nil, err
+	}
+	wm, err := c.projectAuthorizationModelWriteModel(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.DeepEqual(wm.Model, model) {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, project.NewAuthorizationModelSetEvent(ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		model,
+	))
+}
+
+// AddRelationTuple relates the subject to the object, the authorization model of the project must allow the tuple.
+func (c *Commands) AddRelationTuple(ctx context.Context, projectID, resourceOwner string, tuple *domain.RelationTuple) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rt1pI", "Errors.Project.ProjectIDMissing")
+	}
+	if !tuple.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rt2vA", "Errors.Project.RelationTuple.Invalid")
+	}
+	modelWM, err := c.projectAuthorizationModelWriteModel(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = modelWM.Model.ValidateTuple(tuple); err != nil {
+		return nil, err
+	}
+	wm := NewRelationTupleWriteModel(projectID, modelWM.ResourceOwner, tuple)
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if wm.Exists {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Rt3aE", "Errors.Project.RelationTuple.AlreadyExists")
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, project.NewRelationTupleAddedEvent(ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		tuple,
+	))
+}
+
+// RemoveRelationTuple removes the tuple regardless of the current authorization model.
+func (c *Commands) RemoveRelationTuple(ctx context.Context, projectID, resourceOwner string, tuple *domain.RelationTuple) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rt4pI", "Errors.Project.ProjectIDMissing")
+	}
+	if !tuple.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Rt5vA", "Errors.Project.RelationTuple.Invalid")
+	}
+	wm := NewRelationTupleWriteModel(projectID, resourceOwner, tuple)
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if !wm.Exists {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rt6nF", "Errors.Project.RelationTuple.NotFound")
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, project.NewRelationTupleRemovedEvent(ctx,
+		ProjectAggregateFromWriteModel(&wm.WriteModel),
+		tuple,
+	))
+}
+
+func (c *Commands) projectAuthorizationModelWriteModel(ctx context.Context, projectID, resourceOwner string) (*ProjectAuthorizationModelWriteModel, error) {
+	wm := NewProjectAuthorizationModelWriteModel(projectID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	if wm.State == domain.ProjectStateUnspecified || wm.State == domain.ProjectStateRemoved {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Rm2nF", "Errors.Project.NotFound")
+	}
+	return wm, nil
+}
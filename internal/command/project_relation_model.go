@@ -0,0 +1,110 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ProjectAuthorizationModelWriteModel struct {
+	eventstore.WriteModel
+
+	Model *domain.AuthorizationModel
+	State domain.ProjectState
+}
+
+func NewProjectAuthorizationModelWriteModel(projectID, resourceOwner string) *ProjectAuthorizationModelWriteModel {
+	return &ProjectAuthorizationModelWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *ProjectAuthorizationModelWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *ProjectAuthorizationModelWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			wm.State = domain.ProjectStateActive
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.ProjectStateRemoved
+			wm.Model = nil
+		case *project.AuthorizationModelSetEvent:
+			wm.Model = e.Model
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ProjectAuthorizationModelWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.AuthorizationModelSetType,
+		).
+		Builder()
+}
+
+// RelationTupleWriteModel only reduces the events of a single tuple.
+type RelationTupleWriteModel struct {
+	eventstore.WriteModel
+
+	Tuple  project.RelationTuple
+	Exists bool
+}
+
+func NewRelationTupleWriteModel(projectID, resourceOwner string, tuple *domain.RelationTuple) *RelationTupleWriteModel {
+	return &RelationTupleWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		Tuple: project.RelationTupleFromDomain(tuple),
+	}
+}
+
+func (wm *RelationTupleWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *RelationTupleWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.RelationTupleAddedEvent:
+			wm.Exists = e.RelationTuple == wm.Tuple
+		case *project.RelationTupleRemovedEvent:
+			wm.Exists = wm.Exists && e.RelationTuple != wm.Tuple
+		case *project.ProjectRemovedEvent:
+			wm.Exists = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *RelationTupleWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.RelationTupleAddedType,
+			project.RelationTupleRemovedType,
+		).
+		EventData(wm.Tuple.EventData()).
+		Or().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(project.ProjectRemovedType).
+		Builder()
+}
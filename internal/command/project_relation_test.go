@@ -0,0 +1,387 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func testAuthorizationModel() *domain.AuthorizationModel {
+	return &domain.AuthorizationModel{
+		Types: []*domain.AuthorizationModelType{
+			{
+				Name: "document",
+				Relations: []*domain.AuthorizationModelRelation{
+					{
+						Name:           "editor",
+						DirectSubjects: []*domain.RelationSubjectType{{Type: domain.RelationTypeUser}},
+					},
+					{
+						Name:      "viewer",
+						ImpliedBy: []string{"editor"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testRelationTuple() *domain.RelationTuple {
+	return &domain.RelationTuple{
+		ObjectType:  "document",
+		ObjectID:    "doc1",
+		Relation:    "editor",
+		SubjectType: domain.RelationTypeUser,
+		SubjectID:   "user1",
+	}
+}
+
+func relationProjectAddedEvent() eventstore.Event {
+	return eventFromEventPusher(
+		project.NewProjectAddedEvent(context.Background(),
+			&project.NewAggregate("project1", "org1").Aggregate,
+			"projectname1", true, true, true,
+			domain.PrivateLabelingSettingUnspecified,
+		),
+	)
+}
+
+func authorizationModelSetEvent() eventstore.Event {
+	return eventFromEventPusher(
+		project.NewAuthorizationModelSetEvent(context.Background(),
+			&project.NewAggregate("project1", "org1").Aggregate,
+			testAuthorizationModel(),
+		),
+	)
+}
+
+func TestCommandSide_SetProjectAuthorizationModel(t *testing.T) {
+	type args struct {
+		projectID string
+		model     *domain.AuthorizationModel
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "missing project id, invalid argument error",
+			eventstore: expectEventstore(),
+			args: args{
+				model: testAuthorizationModel(),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name:       "invalid model, invalid argument error",
+			eventstore: expectEventstore(),
+			args: args{
+				projectID: "project1",
+				model:     &domain.AuthorizationModel{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not found, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			args: args{
+				projectID: "project1",
+				model:     testAuthorizationModel(),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "model unchanged, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+					authorizationModelSetEvent(),
+				),
+			),
+			args: args{
+				projectID: "project1",
+				model:     testAuthorizationModel(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "project1",
+				},
+			},
+		},
+		{
+			name: "set model, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+				),
+				expectPush(
+					project.NewAuthorizationModelSetEvent(context.Background(),
+						&project.NewAggregate("project1", "org1").Aggregate,
+						testAuthorizationModel(),
+					),
+				),
+			),
+			args: args{
+				projectID: "project1",
+				model:     testAuthorizationModel(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "project1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := r.SetProjectAuthorizationModel(context.Background(), tt.args.projectID, "org1", tt.args.model)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddRelationTuple(t *testing.T) {
+	type args struct {
+		tuple *domain.RelationTuple
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			name:       "invalid tuple, invalid argument error",
+			eventstore: expectEventstore(),
+			args: args{
+				tuple: &domain.RelationTuple{ObjectType: "document"},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no model, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+				),
+			),
+			args: args{
+				tuple: testRelationTuple(),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "computed relation, precondition error",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+					authorizationModelSetEvent(),
+				),
+			),
+			args: args{
+				tuple: &domain.RelationTuple{
+					ObjectType:  "document",
+					ObjectID:    "doc1",
+					Relation:    "viewer",
+					SubjectType: domain.RelationTypeUser,
+					SubjectID:   "user1",
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "tuple exists, already exists error",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+					authorizationModelSetEvent(),
+				),
+				expectFilter(
+					eventFromEventPusher(
+						project.NewRelationTupleAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							testRelationTuple(),
+						),
+					),
+				),
+			),
+			args: args{
+				tuple: testRelationTuple(),
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "add tuple, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					relationProjectAddedEvent(),
+					authorizationModelSetEvent(),
+				),
+				expectFilter(),
+				expectPush(
+					project.NewRelationTupleAddedEvent(context.Background(),
+						&project.NewAggregate("project1", "org1").Aggregate,
+						testRelationTuple(),
+					),
+				),
+			),
+			args: args{
+				tuple: testRelationTuple(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "project1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := r.AddRelationTuple(context.Background(), "project1", "org1", tt.args.tuple)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveRelationTuple(t *testing.T) {
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		res        res
+	}{
+		{
+			name: "tuple not found, not found error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "tuple already removed, not found error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewRelationTupleAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							testRelationTuple(),
+						),
+					),
+					eventFromEventPusher(
+						project.NewRelationTupleRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							testRelationTuple(),
+						),
+					),
+				),
+			),
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "remove tuple, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(
+						project.NewRelationTupleAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							testRelationTuple(),
+						),
+					),
+				),
+				expectPush(
+					project.NewRelationTupleRemovedEvent(context.Background(),
+						&project.NewAggregate("project1", "org1").Aggregate,
+						testRelationTuple(),
+					),
+				),
+			),
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "project1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := r.RemoveRelationTuple(context.Background(), "project1", "org1", testRelationTuple())
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/logging"
 
@@ -63,24 +64,52 @@ func (c *Commands) BulkAddProjectRole(ctx context.Context, projectID, resourceOw
 }
 
 func (c *Commands) addProjectRoles(ctx context.Context, projectAgg *eventstore.Aggregate, projectRoles ...*domain.ProjectRole) ([]eventstore.Command, error) {
+	hierarchy, err := c.projectRoleHierarchyOfRoles(ctx, projectAgg.ID, projectAgg.ResourceOwner, projectRoles...)
+	if err != nil {
+		return nil, err
+	}
 	var events []eventstore.Command
 	for _, projectRole := range projectRoles {
 		projectRole.AggregateID = projectAgg.ID
 		if !projectRole.IsValid() {
 			return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-4m9vS", "Errors.Project.Role.Invalid")
 		}
+		if hierarchy != nil {
+			// roles can include the roles added before them
+			if err = hierarchy.ValidateIncludedRoles(projectRole.Key, projectRole.IncludedRoles); err != nil {
+				return nil, err
+			}
+			hierarchy[projectRole.Key] = projectRole.IncludedRoles
+		}
 		events = append(events, project.NewRoleAddedEvent(
 			ctx,
 			projectAgg,
 			projectRole.Key,
 			projectRole.DisplayName,
 			projectRole.Group,
+			projectRole.IncludedRoles...,
 		))
 	}
 
 	return events, nil
 }
 
+// projectRoleHierarchyOfRoles returns the role hierarchy of the project
+// if at least one of the roles includes other roles, nil otherwise.
+func (c *Commands) projectRoleHierarchyOfRoles(ctx context.Context, projectID, resourceOwner string, projectRoles ...*domain.ProjectRole) (domain.ProjectRoleHierarchy, error) {
+	includesRoles := slices.ContainsFunc(projectRoles, func(role *domain.ProjectRole) bool {
+		return len(role.IncludedRoles) > 0
+	})
+	if !includesRoles {
+		return nil, nil
+	}
+	writeModel := NewProjectRoleHierarchyWriteModel(projectID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	return writeModel.Hierarchy, nil
+}
+
 func (c *Commands) ChangeProjectRole(ctx context.Context, projectRole *domain.ProjectRole, resourceOwner string) (_ *domain.ProjectRole, err error) {
 	if !projectRole.IsValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-2ilfW", "Errors.Project.Invalid")
@@ -98,9 +127,19 @@ func (c *Commands) ChangeProjectRole(ctx context.Context, projectRole *domain.Pr
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-vv8M9", "Errors.Project.Role.NotExisting")
 	}
 
+	if !slices.Equal(existingRole.IncludedRoles, projectRole.IncludedRoles) {
+		hierarchy, err := c.projectRoleHierarchyOfRoles(ctx, projectRole.AggregateID, resourceOwner, projectRole)
+		if err != nil {
+			return nil, err
+		}
+		if err = hierarchy.ValidateIncludedRoles(projectRole.Key, projectRole.IncludedRoles); err != nil {
+			return nil, err
+		}
+	}
+
 	projectAgg := ProjectAggregateFromWriteModel(&existingRole.WriteModel)
 
-	changeEvent, changed, err := existingRole.NewProjectRoleChangedEvent(ctx, projectAgg, projectRole.Key, projectRole.DisplayName, projectRole.Group)
+	changeEvent, changed, err := existingRole.NewProjectRoleChangedEvent(ctx, projectAgg, projectRole.Key, projectRole.DisplayName, projectRole.Group, projectRole.IncludedRoles)
 	if err != nil {
 		return nil, err
 	}
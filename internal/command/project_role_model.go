@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -11,10 +12,11 @@ import (
 type ProjectRoleWriteModel struct {
 	eventstore.WriteModel
 
-	Key         string
-	DisplayName string
-	Group       string
-	State       domain.ProjectRoleState
+	Key           string
+	DisplayName   string
+	Group         string
+	IncludedRoles []string
+	State         domain.ProjectRoleState
 }
 
 func NewProjectRoleWriteModelWithKey(key, projectID, resourceOwner string) *ProjectRoleWriteModel {
@@ -48,9 +50,8 @@ func (wm *ProjectRoleWriteModel) AppendEvents(events ...eventstore.Event) {
 				wm.WriteModel.AppendEvents(e)
 			}
 		case *project.RoleRemovedEvent:
-			if e.Key == wm.Key {
-				wm.WriteModel.AppendEvents(e)
-			}
+			// the removal of other roles is needed to drop them from the included roles
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -64,6 +65,7 @@ func (wm *ProjectRoleWriteModel) Reduce() error {
 			wm.Key = e.Key
 			wm.DisplayName = e.DisplayName
 			wm.Group = e.Group
+			wm.IncludedRoles = e.IncludedRoles
 			wm.State = domain.ProjectRoleStateActive
 		case *project.RoleChangedEvent:
 			wm.Key = e.Key
@@ -73,7 +75,14 @@ func (wm *ProjectRoleWriteModel) Reduce() error {
 			if e.Group != nil {
 				wm.Group = *e.Group
 			}
+			if e.IncludedRoles != nil {
+				wm.IncludedRoles = *e.IncludedRoles
+			}
 		case *project.RoleRemovedEvent:
+			if e.Key != wm.Key {
+				wm.IncludedRoles = slices.DeleteFunc(slices.Clone(wm.IncludedRoles), func(key string) bool { return key == e.Key })
+				continue
+			}
 			wm.State = domain.ProjectRoleStateRemoved
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.ProjectRoleStateRemoved
@@ -102,6 +111,7 @@ func (wm *ProjectRoleWriteModel) NewProjectRoleChangedEvent(
 	key,
 	displayName,
 	group string,
+	includedRoles []string,
 ) (*project.RoleChangedEvent, bool, error) {
 	changes := make([]project.RoleChanges, 0)
 	var err error
@@ -112,6 +122,9 @@ func (wm *ProjectRoleWriteModel) NewProjectRoleChangedEvent(
 	if wm.Group != group {
 		changes = append(changes, project.ChangeGroup(group))
 	}
+	if !slices.Equal(wm.IncludedRoles, includedRoles) {
+		changes = append(changes, project.ChangeIncludedRoles(includedRoles))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -121,3 +134,55 @@ func (wm *ProjectRoleWriteModel) NewProjectRoleChangedEvent(
 	}
 	return changeEvent, true, nil
 }
+
+// ProjectRoleHierarchyWriteModel collects the included roles of all roles of a project.
+type ProjectRoleHierarchyWriteModel struct {
+	eventstore.WriteModel
+
+	Hierarchy domain.ProjectRoleHierarchy
+}
+
+func NewProjectRoleHierarchyWriteModel(projectID, resourceOwner string) *ProjectRoleHierarchyWriteModel {
+	return &ProjectRoleHierarchyWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		Hierarchy: make(domain.ProjectRoleHierarchy),
+	}
+}
+
+func (wm *ProjectRoleHierarchyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.RoleAddedEvent:
+			wm.Hierarchy[e.Key] = e.IncludedRoles
+		case *project.RoleChangedEvent:
+			if e.IncludedRoles != nil {
+				wm.Hierarchy[e.Key] = *e.IncludedRoles
+			}
+		case *project.RoleRemovedEvent:
+			delete(wm.Hierarchy, e.Key)
+			for key, included := range wm.Hierarchy {
+				wm.Hierarchy[key] = slices.DeleteFunc(slices.Clone(included), func(k string) bool { return k == e.Key })
+			}
+		case *project.ProjectRemovedEvent:
+			wm.Hierarchy = make(domain.ProjectRoleHierarchy)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ProjectRoleHierarchyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.RoleAddedType,
+			project.RoleChangedType,
+			project.RoleRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
@@ -189,6 +189,116 @@ func TestCommandSide_AddProjectRole(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "included role not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Key:           "admin",
+					IncludedRoles: []string{"editor"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add composite role, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"editor",
+								"editor",
+								"",
+								"viewer",
+							),
+						),
+					),
+					expectPush(
+						project.NewRoleAddedEvent(
+							context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"admin",
+							"admin",
+							"",
+							"editor",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Key:           "admin",
+					DisplayName:   "admin",
+					IncludedRoles: []string{"editor"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					Key:           "admin",
+					DisplayName:   "admin",
+					IncludedRoles: []string{"editor"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -648,6 +758,145 @@ func TestCommandSide_ChangeProjectRole(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "included roles cycle, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"editor",
+								"editor",
+								"",
+								"viewer",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Key:           "viewer",
+					DisplayName:   "viewer",
+					IncludedRoles: []string{"editor"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change included roles, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"projectname1", true, true, true,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"editor",
+								"editor",
+								"",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"viewer",
+								"viewer",
+								"",
+							),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"editor",
+								"editor",
+								"",
+							),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewRoleChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"editor",
+								[]project.RoleChanges{project.ChangeIncludedRoles([]string{"viewer"})},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				role: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					Key:           "editor",
+					DisplayName:   "editor",
+					IncludedRoles: []string{"viewer"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ProjectRole{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					Key:           "editor",
+					DisplayName:   "editor",
+					IncludedRoles: []string{"viewer"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -114,7 +114,15 @@ func CheckIntent(intentID, token string) SessionCommand {
 				return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-O8xk3w", "Errors.Intent.OtherUser")
 			}
 		}
+		tokensCmd, err := bindIntentIDPTokens(ctx, cmd.eventstore.FilterToQueryReducer, cmd.intentWriteModel, cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if tokensCmd != nil {
+			cmd.eventCommands = append(cmd.eventCommands, tokensCmd)
+		}
 		cmd.IntentChecked(ctx, cmd.now())
+		cmd.IDPSessionSet(ctx)
 		return nil, nil
 	}
 }
@@ -170,6 +178,21 @@ func (s *SessionCommands) IntentChecked(ctx context.Context, checkedAt time.Time
 	s.eventCommands = append(s.eventCommands, session.NewIntentCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
+// IDPSessionSet stores the identifiers of the session at the identity provider of the checked intent,
+// so the session can be terminated at the identity provider as well (federated logout).
+func (s *SessionCommands) IDPSessionSet(ctx context.Context) {
+	idTokenHint, nameID, sessionIndex, err := idpSessionFromIntent(s.intentWriteModel, s.intentAlg)
+	if err != nil {
+		// the federated logout must not prevent the user from authenticating
+		logging.WithFields("intent", s.intentWriteModel.AggregateID).OnError(err).Warn("unable to read idp session from intent")
+		return
+	}
+	if idTokenHint == nil && nameID == "" {
+		return
+	}
+	s.eventCommands = append(s.eventCommands, session.NewIDPSessionSetEvent(ctx, s.sessionWriteModel.aggregate, s.intentWriteModel.IDPID, idTokenHint, nameID, sessionIndex))
+}
+
 func (s *SessionCommands) WebAuthNChallenged(ctx context.Context, challenge string, allowedCrentialIDs [][]byte, userVerification domain.UserVerificationRequirement, rpid string) {
 	s.eventCommands = append(s.eventCommands, session.NewWebAuthNChallengedEvent(ctx, s.sessionWriteModel.aggregate, challenge, allowedCrentialIDs, userVerification, rpid))
 }
@@ -348,6 +371,14 @@ func (c *Commands) updateSession(ctx context.Context, checks *SessionCommands, m
 		}
 		return nil, err
 	}
+	if checks.intentWriteModel != nil && checks.intentWriteModel.IDPID != "" {
+		// the group sync is pushed together with the session, so the session is only checked if the roles are synced
+		groupCmds, err := c.syncIntentUserIDPGroups(ctx, checks.sessionWriteModel.UserID, checks.sessionWriteModel.UserResourceOwner, checks.intentWriteModel)
+		if err != nil {
+			return nil, err
+		}
+		checks.eventCommands = append(checks.eventCommands, groupCmds...)
+	}
 	checks.ChangeMetadata(ctx, metadata)
 	err = checks.SetLifetime(ctx, lifetime)
 	if err != nil {
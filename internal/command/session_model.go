@@ -27,6 +27,14 @@ type OTPCode struct {
 	VerificationID string
 }
 
+// IDPSessionModel contains the identifiers of the session at the external identity provider
+type IDPSessionModel struct {
+	IDPID        string
+	IDTokenHint  *crypto.CryptoValue
+	NameID       string
+	SessionIndex string
+}
+
 func (p *WebAuthNChallengeModel) WebAuthNLogin(human *domain.Human, credentialAssertionData []byte) *domain.WebAuthNLogin {
 	return &domain.WebAuthNLogin{
 		ObjectRoot:              human.ObjectRoot,
@@ -61,6 +69,7 @@ type SessionWriteModel struct {
 	WebAuthNChallenge     *WebAuthNChallengeModel
 	OTPSMSCodeChallenge   *OTPCode
 	OTPEmailCodeChallenge *OTPCode
+	IDPSession            *IDPSessionModel
 
 	aggregate *eventstore.Aggregate
 }
@@ -86,6 +95,8 @@ func (wm *SessionWriteModel) Reduce() error {
 			wm.reducePasswordChecked(e)
 		case *session.IntentCheckedEvent:
 			wm.reduceIntentChecked(e)
+		case *session.IDPSessionSetEvent:
+			wm.reduceIDPSessionSet(e)
 		case *session.WebAuthNChallengedEvent:
 			wm.reduceWebAuthNChallenged(e)
 		case *session.WebAuthNCheckedEvent:
@@ -123,6 +134,7 @@ func (wm *SessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			session.UserCheckedType,
 			session.PasswordCheckedType,
 			session.IntentCheckedType,
+			session.IDPSessionSetType,
 			session.WebAuthNChallengedType,
 			session.WebAuthNCheckedType,
 			session.TOTPCheckedType,
@@ -164,6 +176,15 @@ func (wm *SessionWriteModel) reduceIntentChecked(e *session.IntentCheckedEvent)
 	wm.IntentCheckedAt = e.CheckedAt
 }
 
+func (wm *SessionWriteModel) reduceIDPSessionSet(e *session.IDPSessionSetEvent) {
+	wm.IDPSession = &IDPSessionModel{
+		IDPID:        e.IDPID,
+		IDTokenHint:  e.IDTokenHint,
+		NameID:       e.NameID,
+		SessionIndex: e.SessionIndex,
+	}
+}
+
 func (wm *SessionWriteModel) reduceWebAuthNChallenged(e *session.WebAuthNChallengedEvent) {
 	wm.WebAuthNChallenge = &WebAuthNChallengeModel{
 		Challenge:          e.Challenge,
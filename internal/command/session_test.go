@@ -21,6 +21,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/session"
@@ -85,7 +86,7 @@ func TestSessionCommands_getHumanWriteModel(t *testing.T) {
 						eventFromEventPusher(
 							user.NewUserRemovedEvent(context.Background(),
 								userAggr,
-								"", nil, true,
+								"", nil, "", true,
 							),
 						),
 					),
@@ -695,6 +696,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"userID2",
 								nil,
 								"",
+								nil,
 							),
 						),
 					),
@@ -775,6 +777,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"userID",
 								nil,
 								"",
+								nil,
 							),
 						),
 					),
@@ -822,6 +825,79 @@ func TestCommands_updateSession(t *testing.T) {
 				},
 			},
 		},
+		{
+			"set user, intent with idp session",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							idpintent.NewStartedEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								nil,
+								nil,
+								"idpID",
+							),
+						),
+						eventFromEventPusher(
+							idpintent.NewSucceededEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								nil,
+								"idpUserID",
+								"idpUsername",
+								"userID",
+								nil,
+								"idToken",
+								nil,
+							),
+						),
+					),
+					expectFilter(), // idp group mappings
+					expectFilter(), // synced idp groups
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, &language.Afrikaans),
+						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							testNow),
+						session.NewIDPSessionSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"idpID",
+							&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("idToken")},
+							"",
+							"",
+						),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID"),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1", &language.Afrikaans),
+						CheckIntent("intent", "aW50ZW50"),
+					},
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					intentAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
 		{
 			"set user, intent (user not linked yet)",
 			fields{
@@ -848,6 +924,104 @@ func TestCommands_updateSession(t *testing.T) {
 								"",
 								nil,
 								"",
+								nil,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"idpID",
+								"idpUsername",
+								"idpUserID",
+							),
+						),
+					),
+					expectFilter(), // idp group mappings
+					expectFilter(), // synced idp groups
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, &language.Afrikaans),
+						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							testNow),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID"),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1", &language.Afrikaans),
+						CheckIntent("intent", "aW50ZW50"),
+					},
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					intentAlg: decryption(nil),
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
+		{
+			"set user, intent with tokens (user not linked yet)",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"username", "", "", "", "", language.English, domain.GenderUnspecified, "", false),
+						),
+						eventFromEventPusher(
+							idpintent.NewStartedEvent(context.Background(),
+								&idpintent.NewAggregate("id", "instance1").Aggregate,
+								nil,
+								nil,
+								"idpID",
+							),
+						),
+						eventFromEventPusher(
+							idpintent.NewSucceededEvent(context.Background(),
+								&idpintent.NewAggregate("id", "instance1").Aggregate,
+								nil,
+								"idpUserID",
+								"idpUsername",
+								"",
+								nil,
+								"",
+								&idpintent.IDPTokens{
+									AccessToken: &crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("accessToken"),
+									},
+									TokenType: "Bearer",
+								},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"idpID",
+								"idpUsername",
+								"idpUserID",
 							),
 						),
 					),
@@ -860,11 +1034,113 @@ func TestCommands_updateSession(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(), // idp group mappings
+					expectFilter(), // synced idp groups
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, &language.Afrikaans),
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"idpID",
+							"idpUserID",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("accessToken"),
+							},
+							"Bearer",
+							nil,
+							time.Time{},
+						),
+						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							testNow),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID"),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1", &language.Afrikaans),
+						CheckIntent("intent", "aW50ZW50"),
+					},
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					intentAlg: decryption(nil),
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
+		{
+			"set user, intent with idp group mapping",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							idpintent.NewStartedEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								nil,
+								nil,
+								"idpID",
+							),
+						),
+						eventFromEventPusher(
+							idpintent.NewSucceededEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								[]byte(`{"groups":["admins"]}`),
+								"idpUserID",
+								"idpUsername",
+								"userID",
+								nil,
+								"",
+								nil,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPGroupMappingsSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"idpID",
+								[]idp.GroupMapping{
+									{
+										Claim:          "groups",
+										Group:          "admins",
+										OrgMemberRoles: []string{domain.RoleOrgOwner},
+									},
+								},
+							),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
 					expectPush(
 						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
 							"userID", "org1", testNow, &language.Afrikaans),
 						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
 							testNow),
+						org.NewMemberAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "userID", domain.RoleOrgOwner),
+						user.NewUserIDPGroupsSyncedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"idpID",
+							[]*user.IDPGroupGrant{},
+							[]string{domain.RoleOrgOwner},
+						),
 						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
 							"tokenID"),
 					),
@@ -2,9 +2,13 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -150,6 +154,276 @@ func (c *Commands) ChangeSMSConfigTwilioToken(ctx context.Context, resourceOwner
 	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
 }
 
+type AddVonageConfig struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description  string
+	APIKey       string
+	APISecret    string
+	SenderNumber string
+	VerifyBrand  string
+}
+
+func (c *Commands) AddSMSConfigVonage(ctx context.Context, config *AddVonageConfig) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn3kq9Lw2d", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		config.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, config.ResourceOwner, config.ID)
+	if err != nil {
+		return err
+	}
+
+	var apiSecret *crypto.CryptoValue
+	if config.APISecret != "" {
+		apiSecret, err = crypto.Encrypt([]byte(config.APISecret), c.smsEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.pushAppendAndReduce(ctx,
+		smsConfigWriteModel,
+		instance.NewSMSConfigVonageAddedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+			config.ID,
+			config.Description,
+			config.APIKey,
+			apiSecret,
+			config.SenderNumber,
+			config.VerifyBrand,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+	return nil
+}
+
+type ChangeVonageConfig struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description  *string
+	APIKey       *string
+	SenderNumber *string
+	VerifyBrand  *string
+}
+
+func (c *Commands) ChangeSMSConfigVonage(ctx context.Context, config *ChangeVonageConfig) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn8fWq2pLs", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn2lQp0sXe", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, config.ResourceOwner, config.ID)
+	if err != nil {
+		return err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Vonage == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Vn5mRk1oTz", "Errors.SMSConfig.NotFound")
+	}
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewVonageChangedEvent(
+		ctx,
+		InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+		config.ID,
+		config.Description,
+		config.APIKey,
+		config.SenderNumber,
+		config.VerifyBrand,
+	)
+	if err != nil {
+		return err
+	}
+	if !hasChanged {
+		config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+		return nil
+	}
+	err = c.pushAppendAndReduce(ctx, smsConfigWriteModel, changedEvent)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+	return nil
+}
+
+func (c *Commands) ChangeSMSConfigVonageAPISecret(ctx context.Context, resourceOwner, id, apiSecret string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn7cJx4bNa", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Vn9hTe3wQk", "Errors.IDMissing")
+	}
+
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.Vonage == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Vn1dYu6rMv", "Errors.SMSConfig.NotFound")
+	}
+	newSecret, err := crypto.Encrypt([]byte(apiSecret), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	err = c.pushAppendAndReduce(ctx,
+		smsConfigWriteModel,
+		instance.NewSMSConfigVonageAPISecretChangedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+			id,
+			newSecret,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
+type AddMessageBirdConfig struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description string
+	AccessKey   string
+	Originator  string
+	Verify      bool
+}
+
+func (c *Commands) AddSMSConfigMessageBird(ctx context.Context, config *AddMessageBirdConfig) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Mb4pWz8sKq", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		config.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, config.ResourceOwner, config.ID)
+	if err != nil {
+		return err
+	}
+
+	var accessKey *crypto.CryptoValue
+	if config.AccessKey != "" {
+		accessKey, err = crypto.Encrypt([]byte(config.AccessKey), c.smsEncryption)
+		if err != nil {
+			return err
+		}
+	}
+	err = c.pushAppendAndReduce(ctx,
+		smsConfigWriteModel,
+		instance.NewSMSConfigMessageBirdAddedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+			config.ID,
+			config.Description,
+			accessKey,
+			config.Originator,
+			config.Verify,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+	return nil
+}
+
+type ChangeMessageBirdConfig struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description *string
+	Originator  *string
+	Verify      *bool
+}
+
+func (c *Commands) ChangeSMSConfigMessageBird(ctx context.Context, config *ChangeMessageBirdConfig) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Mb6tGh2nLc", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Mb1vXk9eRw", "Errors.IDMissing")
+	}
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, config.ResourceOwner, config.ID)
+	if err != nil {
+		return err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.MessageBird == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Mb3sQj7uPy", "Errors.SMSConfig.NotFound")
+	}
+	changedEvent, hasChanged, err := smsConfigWriteModel.NewMessageBirdChangedEvent(
+		ctx,
+		InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+		config.ID,
+		config.Description,
+		config.Originator,
+		config.Verify,
+	)
+	if err != nil {
+		return err
+	}
+	if !hasChanged {
+		config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+		return nil
+	}
+	err = c.pushAppendAndReduce(ctx, smsConfigWriteModel, changedEvent)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smsConfigWriteModel.WriteModel)
+	return nil
+}
+
+func (c *Commands) ChangeSMSConfigMessageBirdAccessKey(ctx context.Context, resourceOwner, id, accessKey string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Mb8aZr5mHd", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMS-Mb2kFo6cVt", "Errors.IDMissing")
+	}
+
+	smsConfigWriteModel, err := c.getSMSConfig(ctx, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.MessageBird == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Mb9wLi3pEs", "Errors.SMSConfig.NotFound")
+	}
+	newAccessKey, err := crypto.Encrypt([]byte(accessKey), c.smsEncryption)
+	if err != nil {
+		return nil, err
+	}
+	err = c.pushAppendAndReduce(ctx,
+		smsConfigWriteModel,
+		instance.NewSMSConfigMessageBirdAccessKeyChangedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
+			id,
+			newAccessKey,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smsConfigWriteModel.WriteModel), nil
+}
+
 type AddSMSHTTP struct {
 	Details       *domain.ObjectDetails
 	ResourceOwner string
@@ -157,6 +431,11 @@ type AddSMSHTTP struct {
 
 	Description string
 	Endpoint    string
+	// Template renders the request body, if empty the message is sent as JSON
+	Template    string
+	ContentType string
+	// Headers are sent with each request, they are stored encrypted
+	Headers http.Header
 }
 
 func (c *Commands) AddSMSConfigHTTP(ctx context.Context, config *AddSMSHTTP) (err error) {
@@ -169,11 +448,21 @@ func (c *Commands) AddSMSConfigHTTP(ctx context.Context, config *AddSMSHTTP) (er
 			return err
 		}
 	}
+	if err := validateSMSHTTPTemplate(config.Template); err != nil {
+		return err
+	}
 	smsConfigWriteModel, err := c.getSMSConfig(ctx, config.ResourceOwner, config.ID)
 	if err != nil {
 		return err
 	}
 
+	var headers *crypto.CryptoValue
+	if len(config.Headers) > 0 {
+		headers, err = c.encryptSMSHTTPHeaders(config.Headers)
+		if err != nil {
+			return err
+		}
+	}
 	err = c.pushAppendAndReduce(ctx,
 		smsConfigWriteModel,
 		instance.NewSMSConfigHTTPAddedEvent(
@@ -182,6 +471,9 @@ func (c *Commands) AddSMSConfigHTTP(ctx context.Context, config *AddSMSHTTP) (er
 			config.ID,
 			config.Description,
 			config.Endpoint,
+			config.Template,
+			config.ContentType,
+			headers,
 		),
 	)
 	if err != nil {
@@ -198,6 +490,10 @@ type ChangeSMSHTTP struct {
 
 	Description *string
 	Endpoint    *string
+	Template    *string
+	ContentType *string
+	// Headers replace the existing headers if not nil, an empty header removes them
+	Headers http.Header
 }
 
 func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, config *ChangeSMSHTTP) (err error) {
@@ -214,12 +510,25 @@ func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, config *ChangeSMSHTT
 	if !smsConfigWriteModel.State.Exists() || smsConfigWriteModel.HTTP == nil {
 		return zerrors.ThrowNotFound(nil, "COMMAND-6NW4I5Kqzj", "Errors.SMSConfig.NotFound")
 	}
+	if config.Template != nil {
+		if err := validateSMSHTTPTemplate(*config.Template); err != nil {
+			return err
+		}
+	}
+	headers, err := c.changedSMSHTTPHeaders(smsConfigWriteModel.HTTP.Headers, config.Headers)
+	if err != nil {
+		return err
+	}
 	changedEvent, hasChanged, err := smsConfigWriteModel.NewHTTPChangedEvent(
 		ctx,
 		InstanceAggregateFromWriteModel(&smsConfigWriteModel.WriteModel),
 		config.ID,
 		config.Description,
-		config.Endpoint)
+		config.Endpoint,
+		config.Template,
+		config.ContentType,
+		headers,
+	)
 	if err != nil {
 		return err
 	}
@@ -235,6 +544,46 @@ func (c *Commands) ChangeSMSConfigHTTP(ctx context.Context, config *ChangeSMSHTT
 	return nil
 }
 
+func validateSMSHTTPTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	_, err := webhook.ParseTemplate(template)
+	return err
+}
+
+func (c *Commands) encryptSMSHTTPHeaders(headers http.Header) (*crypto.CryptoValue, error) {
+	marshalled, err := json.Marshal(headers)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Hd7sPq2xLm", "Errors.Internal")
+	}
+	return crypto.Encrypt(marshalled, c.smsEncryption)
+}
+
+// changedSMSHTTPHeaders returns the encrypted headers if they differ from the existing ones
+func (c *Commands) changedSMSHTTPHeaders(existing *crypto.CryptoValue, headers http.Header) (*crypto.CryptoValue, error) {
+	if headers == nil {
+		return nil, nil
+	}
+	if existing == nil && len(headers) == 0 {
+		return nil, nil
+	}
+	if existing != nil {
+		decrypted, err := crypto.Decrypt(existing, c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+		existingHeaders := make(http.Header)
+		if err = json.Unmarshal(decrypted, &existingHeaders); err != nil {
+			return nil, zerrors.ThrowInternal(err, "COMMAND-Hd3kVn8qWs", "Errors.Internal")
+		}
+		if reflect.DeepEqual(existingHeaders, headers) {
+			return nil, nil
+		}
+	}
+	return c.encryptSMSHTTPHeaders(headers)
+}
+
 func (c *Commands) ActivateSMSConfig(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-EFgoOg997V", "Errors.ResourceOwnerMissing")
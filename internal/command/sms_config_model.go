@@ -15,6 +15,8 @@ type IAMSMSConfigWriteModel struct {
 	ID          string
 	Description string
 	Twilio      *TwilioConfig
+	Vonage      *VonageConfig
+	MessageBird *MessageBirdConfig
 	HTTP        *HTTPConfig
 	State       domain.SMSConfigState
 }
@@ -26,8 +28,24 @@ type TwilioConfig struct {
 	VerifyServiceSID string
 }
 
+type VonageConfig struct {
+	APIKey       string
+	APISecret    *crypto.CryptoValue
+	SenderNumber string
+	VerifyBrand  string
+}
+
+type MessageBirdConfig struct {
+	AccessKey  *crypto.CryptoValue
+	Originator string
+	Verify     bool
+}
+
 type HTTPConfig struct {
-	Endpoint string
+	Endpoint    string
+	Template    string
+	ContentType string
+	Headers     *crypto.CryptoValue
 }
 
 func NewIAMSMSConfigWriteModel(instanceID, id string) *IAMSMSConfigWriteModel {
@@ -82,7 +100,10 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.HTTP = &HTTPConfig{
-				Endpoint: e.Endpoint,
+				Endpoint:    e.Endpoint,
+				Template:    e.Template,
+				ContentType: e.ContentType,
+				Headers:     e.Headers,
 			}
 			wm.Description = e.Description
 			wm.State = domain.SMSConfigStateInactive
@@ -96,6 +117,77 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 			if e.Endpoint != nil {
 				wm.HTTP.Endpoint = *e.Endpoint
 			}
+			if e.Template != nil {
+				wm.HTTP.Template = *e.Template
+			}
+			if e.ContentType != nil {
+				wm.HTTP.ContentType = *e.ContentType
+			}
+			if e.Headers != nil {
+				wm.HTTP.Headers = e.Headers
+			}
+		case *instance.SMSConfigVonageAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Vonage = &VonageConfig{
+				APIKey:       e.APIKey,
+				APISecret:    e.APISecret,
+				SenderNumber: e.SenderNumber,
+				VerifyBrand:  e.VerifyBrand,
+			}
+			wm.Description = e.Description
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigVonageChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.APIKey != nil {
+				wm.Vonage.APIKey = *e.APIKey
+			}
+			if e.SenderNumber != nil {
+				wm.Vonage.SenderNumber = *e.SenderNumber
+			}
+			if e.VerifyBrand != nil {
+				wm.Vonage.VerifyBrand = *e.VerifyBrand
+			}
+		case *instance.SMSConfigVonageAPISecretChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.Vonage.APISecret = e.APISecret
+		case *instance.SMSConfigMessageBirdAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.MessageBird = &MessageBirdConfig{
+				AccessKey:  e.AccessKey,
+				Originator: e.Originator,
+				Verify:     e.Verify,
+			}
+			wm.Description = e.Description
+			wm.State = domain.SMSConfigStateInactive
+		case *instance.SMSConfigMessageBirdChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.Originator != nil {
+				wm.MessageBird.Originator = *e.Originator
+			}
+			if e.Verify != nil {
+				wm.MessageBird.Verify = *e.Verify
+			}
+		case *instance.SMSConfigMessageBirdAccessKeyChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.MessageBird.AccessKey = e.AccessKey
 		case *instance.SMSConfigTwilioActivatedEvent:
 			if wm.ID != e.ID {
 				wm.State = domain.SMSConfigStateInactive
@@ -112,6 +204,8 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.Vonage = nil
+			wm.MessageBird = nil
 			wm.HTTP = nil
 			wm.State = domain.SMSConfigStateRemoved
 		case *instance.SMSConfigActivatedEvent:
@@ -130,6 +224,8 @@ func (wm *IAMSMSConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.Twilio = nil
+			wm.Vonage = nil
+			wm.MessageBird = nil
 			wm.HTTP = nil
 			wm.State = domain.SMSConfigStateRemoved
 		}
@@ -149,6 +245,12 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMSConfigTwilioTokenChangedEventType,
 			instance.SMSConfigHTTPAddedEventType,
 			instance.SMSConfigHTTPChangedEventType,
+			instance.SMSConfigVonageAddedEventType,
+			instance.SMSConfigVonageChangedEventType,
+			instance.SMSConfigVonageAPISecretChangedEventType,
+			instance.SMSConfigMessageBirdAddedEventType,
+			instance.SMSConfigMessageBirdChangedEventType,
+			instance.SMSConfigMessageBirdAccessKeyChangedEventType,
 			instance.SMSConfigTwilioActivatedEventType,
 			instance.SMSConfigTwilioDeactivatedEventType,
 			instance.SMSConfigTwilioRemovedEventType,
@@ -158,6 +260,20 @@ func (wm *IAMSMSConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 		Builder()
 }
 
+// generatesCode returns true if the provider generates and verifies the codes itself
+func (wm *IAMSMSConfigWriteModel) generatesCode() bool {
+	switch {
+	case wm.Twilio != nil:
+		return wm.Twilio.VerifyServiceSID != ""
+	case wm.Vonage != nil:
+		return wm.Vonage.VerifyBrand != ""
+	case wm.MessageBird != nil:
+		return wm.MessageBird.Verify
+	default:
+		return false
+	}
+}
+
 func (wm *IAMSMSConfigWriteModel) NewTwilioChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, description, sid, senderNumber, verifyServiceSID *string) (*instance.SMSConfigTwilioChangedEvent, bool, error) {
 	changes := make([]instance.SMSConfigTwilioChanges, 0)
 	var err error
@@ -189,7 +305,66 @@ func (wm *IAMSMSConfigWriteModel) NewTwilioChangedEvent(ctx context.Context, agg
 	return changeEvent, true, nil
 }
 
-func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, description, endpoint *string) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
+func (wm *IAMSMSConfigWriteModel) NewVonageChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, description, apiKey, senderNumber, verifyBrand *string) (*instance.SMSConfigVonageChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigVonageChanges, 0)
+	var err error
+
+	if wm.Vonage == nil {
+		return nil, false, nil
+	}
+
+	if description != nil && wm.Description != *description {
+		changes = append(changes, instance.ChangeSMSConfigVonageDescription(*description))
+	}
+	if apiKey != nil && wm.Vonage.APIKey != *apiKey {
+		changes = append(changes, instance.ChangeSMSConfigVonageAPIKey(*apiKey))
+	}
+	if senderNumber != nil && wm.Vonage.SenderNumber != *senderNumber {
+		changes = append(changes, instance.ChangeSMSConfigVonageSenderNumber(*senderNumber))
+	}
+	if verifyBrand != nil && wm.Vonage.VerifyBrand != *verifyBrand {
+		changes = append(changes, instance.ChangeSMSConfigVonageVerifyBrand(*verifyBrand))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigVonageChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func (wm *IAMSMSConfigWriteModel) NewMessageBirdChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, description, originator *string, verify *bool) (*instance.SMSConfigMessageBirdChangedEvent, bool, error) {
+	changes := make([]instance.SMSConfigMessageBirdChanges, 0)
+	var err error
+
+	if wm.MessageBird == nil {
+		return nil, false, nil
+	}
+
+	if description != nil && wm.Description != *description {
+		changes = append(changes, instance.ChangeSMSConfigMessageBirdDescription(*description))
+	}
+	if originator != nil && wm.MessageBird.Originator != *originator {
+		changes = append(changes, instance.ChangeSMSConfigMessageBirdOriginator(*originator))
+	}
+	if verify != nil && wm.MessageBird.Verify != *verify {
+		changes = append(changes, instance.ChangeSMSConfigMessageBirdVerify(*verify))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMSConfigMessageBirdChangedEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string, description, endpoint, template, contentType *string, headers *crypto.CryptoValue) (*instance.SMSConfigHTTPChangedEvent, bool, error) {
 	changes := make([]instance.SMSConfigHTTPChanges, 0)
 	var err error
 
@@ -203,6 +378,16 @@ func (wm *IAMSMSConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggre
 	if endpoint != nil && wm.HTTP.Endpoint != *endpoint {
 		changes = append(changes, instance.ChangeSMSConfigHTTPEndpoint(*endpoint))
 	}
+	if template != nil && wm.HTTP.Template != *template {
+		changes = append(changes, instance.ChangeSMSConfigHTTPTemplate(*template))
+	}
+	if contentType != nil && wm.HTTP.ContentType != *contentType {
+		changes = append(changes, instance.ChangeSMSConfigHTTPContentType(*contentType))
+	}
+	// the encrypted headers can't be compared here, they are only passed if changed
+	if headers != nil {
+		changes = append(changes, instance.ChangeSMSConfigHTTPHeaders(headers))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
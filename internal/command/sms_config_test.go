@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/muhlemmer/gu"
@@ -351,10 +352,72 @@ func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
 							"providerid",
 							"description",
 							"endpoint",
+							"",
+							"",
+							nil,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+			},
+			args: args{
+				ctx: context.Background(),
+				http: &AddSMSHTTP{
+					ResourceOwner: "INSTANCE",
+					Description:   "description",
+					Endpoint:      "endpoint",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "add sms config http, invalid template",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+			},
+			args: args{
+				ctx: context.Background(),
+				http: &AddSMSHTTP{
+					ResourceOwner: "INSTANCE",
+					Description:   "description",
+					Endpoint:      "endpoint",
+					Template:      "{{.ContextInfo",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add sms config http, template and headers, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewSMSConfigHTTPAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							"description",
+							"endpoint",
+							`{"to":{{json .ContextInfo.recipientPhoneNumber}}}`,
+							"application/json",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(`{"Authorization":["Bearer token"]}`),
+							},
 						),
 					),
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 			},
 			args: args{
 				ctx: context.Background(),
@@ -362,6 +425,9 @@ func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
 					ResourceOwner: "INSTANCE",
 					Description:   "description",
 					Endpoint:      "endpoint",
+					Template:      `{"to":{{json .ContextInfo.recipientPhoneNumber}}}`,
+					ContentType:   "application/json",
+					Headers:       http.Header{"Authorization": {"Bearer token"}},
 				},
 			},
 			res: res{
@@ -395,6 +461,7 @@ func TestCommandSide_AddSMSConfigHTTP(t *testing.T) {
 func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
 	type fields struct {
 		eventstore func(*testing.T) *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
 	}
 	type args struct {
 		ctx  context.Context
@@ -474,6 +541,9 @@ func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
 								"providerid",
 								"description",
 								"endpoint",
+								"",
+								"",
+								nil,
 							),
 						),
 					),
@@ -505,6 +575,9 @@ func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
 								"providerid",
 								"description",
 								"endpoint",
+								"",
+								"",
+								nil,
 							),
 						),
 					),
@@ -533,11 +606,145 @@ func TestCommandSide_ChangeSMSConfigHTTP(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "headers unchanged, no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"endpoint",
+								"",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte(`{"X-Api-Key":["key"]}`),
+								},
+							),
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				http: &ChangeSMSHTTP{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					Headers:       http.Header{"X-Api-Key": {"key"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "invalid template, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"endpoint",
+								"",
+								"",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				http: &ChangeSMSHTTP{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					Template:      gu.Ptr("{{end}}"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "sms config http change template and headers, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"endpoint",
+								"",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte(`{"X-Api-Key":["key"]}`),
+								},
+							),
+						),
+					),
+					expectPush(
+						func() *instance.SMSConfigHTTPChangedEvent {
+							event, _ := instance.NewSMSConfigHTTPChangedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								[]instance.SMSConfigHTTPChanges{
+									instance.ChangeSMSConfigHTTPTemplate("to={{urlquery .ContextInfo.recipientPhoneNumber}}"),
+									instance.ChangeSMSConfigHTTPContentType("application/x-www-form-urlencoded"),
+									instance.ChangeSMSConfigHTTPHeaders(&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte(`{"X-Api-Key":["key2"]}`),
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				http: &ChangeSMSHTTP{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					Template:      gu.Ptr("to={{urlquery .ContextInfo.recipientPhoneNumber}}"),
+					ContentType:   gu.Ptr("application/x-www-form-urlencoded"),
+					Headers:       http.Header{"X-Api-Key": {"key2"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore: tt.fields.eventstore(t),
+				eventstore:    tt.fields.eventstore(t),
+				smsEncryption: tt.fields.alg,
 			}
 			err := r.ChangeSMSConfigHTTP(tt.args.ctx, tt.args.http)
 			if tt.res.err == nil {
@@ -707,6 +914,9 @@ func TestCommandSide_ActivateSMSConfig(t *testing.T) {
 								"providerid",
 								"description",
 								"endpoint",
+								"",
+								"",
+								nil,
 							),
 						),
 					),
@@ -917,6 +1127,9 @@ func TestCommandSide_DeactivateSMSConfig(t *testing.T) {
 								"providerid",
 								"description",
 								"endpoint",
+								"",
+								"",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -1083,6 +1296,9 @@ func TestCommandSide_RemoveSMSConfig(t *testing.T) {
 								"providerid",
 								"description",
 								"endpoint",
+								"",
+								"",
+								nil,
 							),
 						),
 					),
@@ -1153,3 +1369,588 @@ func newSMSConfigHTTPChangedEvent(ctx context.Context, id, endpoint, description
 	)
 	return event
 }
+
+func TestCommandSide_AddSMSConfigVonage(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx context.Context
+		sms *AddVonageConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "add sms config vonage, missing resourceowner",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &AddVonageConfig{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn3kq9Lw2d", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "add sms config vonage, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewSMSConfigVonageAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							"description",
+							"apikey",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("apisecret"),
+							},
+							"senderNumber",
+							"ZITADEL",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &AddVonageConfig{
+					ResourceOwner: "INSTANCE",
+					Description:   "description",
+					APIKey:        "apikey",
+					APISecret:     "apisecret",
+					SenderNumber:  "senderNumber",
+					VerifyBrand:   "ZITADEL",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore(t),
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			err := r.AddSMSConfigVonage(tt.args.ctx, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.sms.Details)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigVonage(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		sms *ChangeVonageConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeVonageConfig{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn2lQp0sXe", "Errors.IDMissing"))
+				},
+			},
+		},
+		{
+			name: "sms config of other provider, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"endpoint",
+								"",
+								"",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeVonageConfig{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Vn5mRk1oTz", "Errors.SMSConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"apikey",
+								nil,
+								"senderNumber",
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeVonageConfig{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					APIKey:        gu.Ptr("apikey"),
+					SenderNumber:  gu.Ptr("senderNumber"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "sms config vonage change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"apikey",
+								nil,
+								"senderNumber",
+								"",
+							),
+						),
+					),
+					expectPush(
+						func() *instance.SMSConfigVonageChangedEvent {
+							event, _ := instance.NewSMSConfigVonageChangedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								[]instance.SMSConfigVonageChanges{
+									instance.ChangeSMSConfigVonageDescription("description2"),
+									instance.ChangeSMSConfigVonageAPIKey("apikey2"),
+									instance.ChangeSMSConfigVonageVerifyBrand("ZITADEL"),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeVonageConfig{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					Description:   gu.Ptr("description2"),
+					APIKey:        gu.Ptr("apikey2"),
+					SenderNumber:  gu.Ptr("senderNumber"),
+					VerifyBrand:   gu.Ptr("ZITADEL"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := r.ChangeSMSConfigVonage(tt.args.ctx, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.sms.Details)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigVonageAPISecret(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		apiSecret     string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "sms config not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				id:            "providerid",
+				apiSecret:     "apisecret",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Vn1dYu6rMv", "Errors.SMSConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "change api secret, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigVonageAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								"apikey",
+								nil,
+								"senderNumber",
+								"",
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMSConfigVonageAPISecretChangedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("apisecret"),
+							},
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "INSTANCE",
+				id:            "providerid",
+				apiSecret:     "apisecret",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore(t),
+				smsEncryption: tt.fields.alg,
+			}
+			got, err := r.ChangeSMSConfigVonageAPISecret(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.apiSecret)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddSMSConfigMessageBird(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx context.Context
+		sms *AddMessageBirdConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "add sms config messagebird, missing resourceowner",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &AddMessageBirdConfig{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Mb4pWz8sKq", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "add sms config messagebird, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewSMSConfigMessageBirdAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"providerid",
+							"description",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("accesskey"),
+							},
+							"ZITADEL",
+							true,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "providerid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &AddMessageBirdConfig{
+					ResourceOwner: "INSTANCE",
+					Description:   "description",
+					AccessKey:     "accesskey",
+					Originator:    "ZITADEL",
+					Verify:        true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:    tt.fields.eventstore(t),
+				idGenerator:   tt.fields.idGenerator,
+				smsEncryption: tt.fields.alg,
+			}
+			err := r.AddSMSConfigMessageBird(tt.args.ctx, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.sms.Details)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMSConfigMessageBird(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		sms *ChangeMessageBirdConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "sms not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeMessageBirdConfig{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Mb3sQj7uPy", "Errors.SMSConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "sms config messagebird change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMSConfigMessageBirdAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								"description",
+								nil,
+								"ZITADEL",
+								true,
+							),
+						),
+					),
+					expectPush(
+						func() *instance.SMSConfigMessageBirdChangedEvent {
+							event, _ := instance.NewSMSConfigMessageBirdChangedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"providerid",
+								[]instance.SMSConfigMessageBirdChanges{
+									instance.ChangeSMSConfigMessageBirdOriginator("+41791234567"),
+									instance.ChangeSMSConfigMessageBirdVerify(false),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				sms: &ChangeMessageBirdConfig{
+					ResourceOwner: "INSTANCE",
+					ID:            "providerid",
+					Description:   gu.Ptr("description"),
+					Originator:    gu.Ptr("+41791234567"),
+					Verify:        gu.Ptr(false),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := r.ChangeSMSConfigMessageBird(tt.args.ctx, tt.args.sms)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.sms.Details)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package command
 import (
 	"context"
 	"net"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -223,6 +226,269 @@ func (c *Commands) ChangeSMTPConfigPassword(ctx context.Context, resourceOwner,
 	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
 }
 
+type SetSMTPConfigXOAuth2 struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	TokenEndpoint string
+	ClientID      string
+	// ClientSecret can be left empty to keep the current secret
+	ClientSecret string
+	Scopes       []string
+}
+
+// SetSMTPConfigXOAuth2 authenticates the user of the SMTP config with XOAUTH2 instead of the password.
+func (c *Commands) SetSMTPConfigXOAuth2(ctx context.Context, config *SetSMTPConfigXOAuth2) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2RmQv7Ls", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2IdK4nWp", "Errors.IDMissing")
+	}
+	tokenEndpoint := strings.TrimSpace(config.TokenEndpoint)
+	if endpoint, err := url.Parse(tokenEndpoint); err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Xo2EpT8cZa", "Errors.SMTPConfig.XOAuth2.InvalidTokenEndpoint")
+	}
+	clientID := strings.TrimSpace(config.ClientID)
+	if clientID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2CiD3sHf", "Errors.SMTPConfig.XOAuth2.ClientIDMissing")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, config.ResourceOwner, config.ID, "")
+	if err != nil {
+		return err
+	}
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.SMTPConfig == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Xo2NfW6bYe", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.SMTPConfig.User == "" {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Xo2UsR9kJd", "Errors.SMTPConfig.XOAuth2.UserMissing")
+	}
+
+	current := smtpConfigWriteModel.SMTPConfig.XOAuth2
+	var clientSecret *crypto.CryptoValue
+	switch {
+	case config.ClientSecret != "":
+		clientSecret, err = crypto.Encrypt([]byte(config.ClientSecret), c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+	case current == nil:
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2CsM5tGh", "Errors.SMTPConfig.XOAuth2.ClientSecretMissing")
+	case current.TokenEndpoint == tokenEndpoint && current.ClientID == clientID && slices.Equal(current.Scopes, config.Scopes):
+		config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+		return nil
+	default:
+		clientSecret = current.ClientSecret
+	}
+
+	err = c.pushAppendAndReduce(ctx,
+		smtpConfigWriteModel,
+		instance.NewSMTPConfigXOAuth2SetEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+			config.ID,
+			tokenEndpoint,
+			clientID,
+			clientSecret,
+			config.Scopes,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+	return nil
+}
+
+func (c *Commands) RemoveSMTPConfigXOAuth2(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2rRoP3qe", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2rIdV8ns", "Errors.IDMissing")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, resourceOwner, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.SMTPConfig == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Xo2rNf2Wcm", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.SMTPConfig.XOAuth2 == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Xo2rNs7Kaz", "Errors.SMTPConfig.XOAuth2.NotFound")
+	}
+
+	err = c.pushAppendAndReduce(ctx,
+		smtpConfigWriteModel,
+		instance.NewSMTPConfigXOAuth2RemovedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+			id,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+var dkimSelectorRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+type SetSMTPConfigDKIM struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	// Domain defaults to the domain of the sender address
+	Domain   string
+	Selector string
+	// PrivateKey is the PEM encoded RSA or Ed25519 key,
+	// which can be left empty to keep the current key
+	PrivateKey []byte
+}
+
+// SetSMTPConfigDKIM signs all emails sent with the SMTP config.
+func (c *Commands) SetSMTPConfigDKIM(ctx context.Context, config *SetSMTPConfigDKIM) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1RmBv4Xs", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1IdQ7pLe", "Errors.IDMissing")
+	}
+	selector := strings.TrimSpace(config.Selector)
+	if !dkimSelectorRegex.MatchString(selector) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1SeL2hTy", "Errors.SMTPConfig.DKIM.InvalidSelector")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, config.ResourceOwner, config.ID, "")
+	if err != nil {
+		return err
+	}
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.SMTPConfig == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Dk1NfG5cWu", "Errors.SMTPConfig.NotFound")
+	}
+
+	signingDomain := strings.ToLower(strings.TrimSpace(config.Domain))
+	if signingDomain == "" {
+		senderAddress := smtpConfigWriteModel.SMTPConfig.SenderAddress
+		signingDomain = strings.ToLower(senderAddress[strings.LastIndex(senderAddress, "@")+1:])
+	}
+	if signingDomain == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1DoM8rKq", "Errors.SMTPConfig.DKIM.InvalidDomain")
+	}
+
+	current := smtpConfigWriteModel.SMTPConfig.DKIM
+	var privateKey *crypto.CryptoValue
+	switch {
+	case len(config.PrivateKey) > 0:
+		if _, err := smtp.ParseDKIMPrivateKey(config.PrivateKey); err != nil {
+			return err
+		}
+		privateKey, err = crypto.Encrypt(config.PrivateKey, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+	case current == nil:
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1PkM3fNv", "Errors.SMTPConfig.DKIM.InvalidKey")
+	case current.Domain == signingDomain && current.Selector == selector:
+		config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+		return nil
+	default:
+		privateKey = current.PrivateKey
+	}
+
+	err = c.pushAppendAndReduce(ctx,
+		smtpConfigWriteModel,
+		instance.NewSMTPConfigDKIMSetEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+			config.ID,
+			signingDomain,
+			selector,
+			privateKey,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+	return nil
+}
+
+func (c *Commands) RemoveSMTPConfigDKIM(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1rRoC6wa", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1rIdJ2xp", "Errors.IDMissing")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, resourceOwner, id, "")
+	if err != nil {
+		return nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.SMTPConfig == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Dk1rNf9Hbs", "Errors.SMTPConfig.NotFound")
+	}
+	if smtpConfigWriteModel.SMTPConfig.DKIM == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Dk1rNs4Tgc", "Errors.SMTPConfig.DKIM.NotFound")
+	}
+
+	err = c.pushAppendAndReduce(ctx,
+		smtpConfigWriteModel,
+		instance.NewSMTPConfigDKIMRemovedEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+			id,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
+// GenerateSMTPConfigDeliverySecret generates a new secret, which the provider of the config has to send
+// when it reports the delivery status of emails.
+// The secret is only returned once, a previous secret is replaced.
+func (c *Commands) GenerateSMTPConfigDeliverySecret(ctx context.Context, resourceOwner, id string) (_ string, _ *domain.ObjectDetails, err error) {
+	if resourceOwner == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ds1rRo4Vbn", "Errors.ResourceOwnerMissing")
+	}
+	if id == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ds1IdW8mKc", "Errors.IDMissing")
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, resourceOwner, id, "")
+	if err != nil {
+		return "", nil, err
+	}
+	if !smtpConfigWriteModel.State.Exists() {
+		return "", nil, zerrors.ThrowNotFound(nil, "COMMAND-Ds1NfT3pQe", "Errors.SMTPConfig.NotFound")
+	}
+
+	secret, err := c.newSigningKey(ctx, c.eventstore.Filter, c.smtpEncryption) //nolint
+	if err != nil {
+		return "", nil, err
+	}
+	err = c.pushAppendAndReduce(ctx,
+		smtpConfigWriteModel,
+		instance.NewSMTPConfigDeliverySecretSetEvent(
+			ctx,
+			InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+			id,
+			secret.Crypted,
+		),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret.PlainCode(), writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel), nil
+}
+
 type AddSMTPConfigHTTP struct {
 	Details       *domain.ObjectDetails
 	ResourceOwner string
@@ -419,9 +685,7 @@ func (c *Commands) TestSMTPConfig(ctx context.Context, instanceID, id, email str
 		return zerrors.ThrowInvalidArgument(nil, "SMTP-p9kj", "Errors.SMTPConfig.TestPassword")
 	}
 
-	// If the password is not sent it'd mean that the password hasn't been changed for
-	// the stored configuration identified by its id so we can try to retrieve it
-	if id != "" && password == "" {
+	if id != "" {
 		smtpConfigWriteModel, err := c.getSMTPConfig(ctx, instanceID, id, "")
 		if err != nil {
 			return err
@@ -430,8 +694,16 @@ func (c *Commands) TestSMTPConfig(ctx context.Context, instanceID, id, email str
 			return zerrors.ThrowNotFound(nil, "SMTP-p9cc", "Errors.SMTPConfig.NotFound")
 		}
 
-		password, err = crypto.DecryptString(smtpConfigWriteModel.SMTPConfig.Password, c.smtpEncryption)
-		if err != nil {
+		// If the password is not sent it'd mean that the password hasn't been changed for
+		// the stored configuration identified by its id so we can try to retrieve it
+		if password == "" && smtpConfigWriteModel.SMTPConfig.Password != nil {
+			password, err = crypto.DecryptString(smtpConfigWriteModel.SMTPConfig.Password, c.smtpEncryption)
+			if err != nil {
+				return err
+			}
+		}
+		// XOAUTH2 and DKIM are managed separately and always used from the stored configuration
+		if err = c.setSMTPXOAuth2AndDKIM(config, smtpConfigWriteModel.SMTPConfig); err != nil {
 			return err
 		}
 	}
@@ -465,9 +737,12 @@ func (c *Commands) TestSMTPConfigById(ctx context.Context, instanceID, id, email
 		return zerrors.ThrowNotFound(nil, "SMTP-99klw", "Errors.SMTPConfig.NotFound")
 	}
 
-	password, err := crypto.DecryptString(smtpConfigWriteModel.SMTPConfig.Password, c.smtpEncryption)
-	if err != nil {
-		return err
+	var password string
+	if smtpConfigWriteModel.SMTPConfig.Password != nil {
+		password, err = crypto.DecryptString(smtpConfigWriteModel.SMTPConfig.Password, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
 	}
 
 	smtpConfig := &smtp.Config{
@@ -480,6 +755,9 @@ func (c *Commands) TestSMTPConfigById(ctx context.Context, instanceID, id, email
 			Password: password,
 		},
 	}
+	if err = c.setSMTPXOAuth2AndDKIM(smtpConfig, smtpConfigWriteModel.SMTPConfig); err != nil {
+		return err
+	}
 
 	// Try to send an email
 	err = smtp.TestConfiguration(smtpConfig, email)
@@ -490,6 +768,33 @@ func (c *Commands) TestSMTPConfigById(ctx context.Context, instanceID, id, email
 	return nil
 }
 
+func (c *Commands) setSMTPXOAuth2AndDKIM(config *smtp.Config, stored *SMTPConfig) error {
+	if stored.XOAuth2 != nil {
+		clientSecret, err := crypto.DecryptString(stored.XOAuth2.ClientSecret, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+		config.SMTP.XOAuth2 = &smtp.XOAuth2{
+			TokenEndpoint: stored.XOAuth2.TokenEndpoint,
+			ClientID:      stored.XOAuth2.ClientID,
+			ClientSecret:  clientSecret,
+			Scopes:        stored.XOAuth2.Scopes,
+		}
+	}
+	if stored.DKIM != nil {
+		privateKey, err := crypto.Decrypt(stored.DKIM.PrivateKey, c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+		config.DKIM = &smtp.DKIM{
+			Domain:     stored.DKIM.Domain,
+			Selector:   stored.DKIM.Selector,
+			PrivateKey: privateKey,
+		}
+	}
+	return nil
+}
+
 func checkSenderAddress(writeModel *IAMSMTPConfigWriteModel) error {
 	if !writeModel.smtpSenderAddressMatchesInstanceDomain {
 		return nil
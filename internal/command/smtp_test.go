@@ -2,10 +2,15 @@ package command
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -900,6 +905,719 @@ func TestCommandSide_ChangeSMTPConfigPassword(t *testing.T) {
 	}
 }
 
+func TestCommandSide_SetSMTPConfigXOAuth2(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		config *SetSMTPConfigXOAuth2
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	smtpConfigAdded := func(user string) eventstore.Event {
+		return eventFromEventPusher(
+			instance.NewSMTPConfigAddedEvent(
+				context.Background(),
+				&instance.NewAggregate("INSTANCE").Aggregate,
+				"ID",
+				"test",
+				true,
+				"from@zitadel.com",
+				"name",
+				"",
+				"host:587",
+				user,
+				nil,
+			),
+		)
+	}
+	xoauth2Set := func(clientSecret *crypto.CryptoValue) *instance.SMTPConfigXOAuth2SetEvent {
+		return instance.NewSMTPConfigXOAuth2SetEvent(
+			context.Background(),
+			&instance.NewAggregate("INSTANCE").Aggregate,
+			"ID",
+			"https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+			"client",
+			clientSecret,
+			[]string{"https://outlook.office365.com/.default"},
+		)
+	}
+	storedSecret := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("secret"),
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceOwner empty, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2RmQv7Ls", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "invalid token endpoint, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "token",
+					ClientID:      "client",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "smtp config not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client",
+					ClientSecret:  "secret",
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user missing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded(""),
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client",
+					ClientSecret:  "secret",
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "client secret missing, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded("user@zitadel.com"),
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set xoauth2, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded("user@zitadel.com"),
+					),
+					expectPush(
+						xoauth2Set(storedSecret),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client",
+					ClientSecret:  "secret",
+					Scopes:        []string{"https://outlook.office365.com/.default"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "change client id, keep secret, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded("user@zitadel.com"),
+						eventFromEventPusher(
+							xoauth2Set(storedSecret),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigXOAuth2SetEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"ID",
+							"https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+							"client2",
+							storedSecret,
+							[]string{"https://outlook.office365.com/.default"},
+						),
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client2",
+					Scopes:        []string{"https://outlook.office365.com/.default"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded("user@zitadel.com"),
+						eventFromEventPusher(
+							xoauth2Set(storedSecret),
+						),
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigXOAuth2{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+					ClientID:      "client",
+					Scopes:        []string{"https://outlook.office365.com/.default"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: tt.fields.alg,
+			}
+			err := r.SetSMTPConfigXOAuth2(context.Background(), tt.args.config)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.config.Details)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveSMTPConfigXOAuth2(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		instanceID string
+		id         string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	smtpConfigAdded := eventFromEventPusher(
+		instance.NewSMTPConfigAddedEvent(
+			context.Background(),
+			&instance.NewAggregate("INSTANCE").Aggregate,
+			"ID",
+			"test",
+			true,
+			"from@zitadel.com",
+			"name",
+			"",
+			"host:587",
+			"user@zitadel.com",
+			nil,
+		),
+	)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xo2rIdV8ns", "Errors.IDMissing"))
+				},
+			},
+		},
+		{
+			name: "xoauth2 not set, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded,
+					),
+				),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+				id:         "ID",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Xo2rNs7Kaz", "Errors.SMTPConfig.XOAuth2.NotFound"))
+				},
+			},
+		},
+		{
+			name: "remove xoauth2, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded,
+						eventFromEventPusher(
+							instance.NewSMTPConfigXOAuth2SetEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ID",
+								"https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+								"client",
+								&crypto.CryptoValue{},
+								nil,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigXOAuth2RemovedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"ID",
+						),
+					),
+				),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+				id:         "ID",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.RemoveSMTPConfigXOAuth2(context.Background(), tt.args.instanceID, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetSMTPConfigDKIM(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		config *SetSMTPConfigDKIM
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)))
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	encryptedKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    privateKey,
+	}
+	smtpConfigAdded := eventFromEventPusher(
+		instance.NewSMTPConfigAddedEvent(
+			context.Background(),
+			&instance.NewAggregate("INSTANCE").Aggregate,
+			"ID",
+			"test",
+			true,
+			"from@Zitadel.com",
+			"name",
+			"",
+			"host:587",
+			"user",
+			&crypto.CryptoValue{},
+		),
+	)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceOwner empty, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1RmBv4Xs", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "invalid selector, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Selector:      "mail; d=attacker.com",
+					PrivateKey:    privateKey,
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dk1SeL2hTy", "Errors.SMTPConfig.DKIM.InvalidSelector"))
+				},
+			},
+		},
+		{
+			name: "smtp config not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Selector:      "mail",
+					PrivateKey:    privateKey,
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "invalid key, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded,
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Selector:      "mail",
+					PrivateKey:    []byte("key"),
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "set dkim with domain of sender address, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded,
+					),
+					expectPush(
+						instance.NewSMTPConfigDKIMSetEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"ID",
+							"zitadel.com",
+							"mail",
+							encryptedKey,
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Selector:      "mail",
+					PrivateKey:    privateKey,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "change selector, keep key, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						smtpConfigAdded,
+						eventFromEventPusher(
+							instance.NewSMTPConfigDKIMSetEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ID",
+								"zitadel.com",
+								"mail",
+								encryptedKey,
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigDKIMSetEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"ID",
+							"mail.zitadel.com",
+							"2024.mail",
+							encryptedKey,
+						),
+					),
+				),
+			},
+			args: args{
+				config: &SetSMTPConfigDKIM{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Domain:        "mail.zitadel.com",
+					Selector:      "2024.mail",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: tt.fields.alg,
+			}
+			err := r.SetSMTPConfigDKIM(context.Background(), tt.args.config)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.config.Details)
+			}
+		})
+	}
+}
+
+func TestCommandSide_GenerateSMTPConfigDeliverySecret(t *testing.T) {
+	type fields struct {
+		eventstore                  func(t *testing.T) *eventstore.Eventstore
+		newEncryptedCodeWithDefault encryptedCodeWithDefaultFunc
+	}
+	type args struct {
+		instanceID string
+		id         string
+	}
+	type res struct {
+		secret string
+		want   *domain.ObjectDetails
+		err    func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "id empty, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ds1IdW8mKc", "Errors.IDMissing"))
+				},
+			},
+		},
+		{
+			name: "config not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+				id:         "ID",
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Ds1NfT3pQe", "Errors.SMTPConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "generate secret, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ID",
+								"test",
+								"endpoint",
+							),
+						),
+					),
+					expectPush(
+						instance.NewSMTPConfigDeliverySecretSetEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"ID",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("secret"),
+							},
+						),
+					),
+				),
+				newEncryptedCodeWithDefault: mockEncryptedCodeWithDefault("secret", time.Hour),
+			},
+			args: args{
+				instanceID: "INSTANCE",
+				id:         "ID",
+			},
+			res: res{
+				secret: "secret",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:                  tt.fields.eventstore(t),
+				newEncryptedCodeWithDefault: tt.fields.newEncryptedCodeWithDefault,
+				defaultSecretGenerators:     &SecretGenerators{},
+			}
+			secret, got, err := r.GenerateSMTPConfigDeliverySecret(context.Background(), tt.args.instanceID, tt.args.id)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.secret, secret)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_AddSMTPConfigHTTP(t *testing.T) {
 	type fields struct {
 		eventstore  func(t *testing.T) *eventstore.Eventstore
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Test_snapshotReducerVersions fails if the methods of a snapshotted write model change
+// without increasing its SnapshotVersion, as snapshots of the previous logic would be reused.
+// After increasing the version, update the version and hash below.
+func Test_snapshotReducerVersions(t *testing.T) {
+	want := map[string]struct {
+		reducer eventstore.SnapshotReducer
+		version uint16
+		hash    string
+	}{
+		"OrgWriteModel":     {reducer: new(OrgWriteModel), version: 1, hash: "7ee9a898af080753"},
+		"HumanWriteModel":   {reducer: new(HumanWriteModel), version: 1, hash: "907911fdb4a5f585"},
+		"MachineWriteModel": {reducer: new(MachineWriteModel), version: 1, hash: "2e01412baa547256"},
+	}
+	got := snapshotReducerMethodHashes(t)
+	gotTypes := make([]string, 0, len(got))
+	for typ := range got {
+		gotTypes = append(gotTypes, typ)
+	}
+	sort.Strings(gotTypes)
+	for _, typ := range gotTypes {
+		t.Run(typ, func(t *testing.T) {
+			w, ok := want[typ]
+			require.Truef(t, ok, "%s is a snapshot reducer, add it to this test", typ)
+			if got[typ] != w.hash && w.reducer.SnapshotVersion() == w.version {
+				assert.Failf(t, "reduce logic changed", "the methods of %s changed, increase its SnapshotVersion and update the version and hash (%s) of this test", typ, got[typ])
+				return
+			}
+			assert.Equal(t, w.version, w.reducer.SnapshotVersion(), "update the version of this test")
+			assert.Equal(t, w.hash, got[typ], "update the hash of this test")
+		})
+	}
+}
+
+// snapshotReducerMethodHashes hashes the methods of all types of the package implementing SnapshotVersion.
+// Comments and formatting are not part of the hash.
+func snapshotReducerMethodHashes(t *testing.T) map[string]string {
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	fset := token.NewFileSet()
+	methods := make(map[string][]*ast.FuncDecl)
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(name)
+		require.NoError(t, err)
+		file, err := parser.ParseFile(fset, name, src, 0)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			typ := fn.Recv.List[0].Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if ident, ok := typ.(*ast.Ident); ok {
+				methods[ident.Name] = append(methods[ident.Name], fn)
+			}
+		}
+	}
+	hashes := make(map[string]string)
+	for typ, fns := range methods {
+		if !slices.ContainsFunc(fns, func(fn *ast.FuncDecl) bool { return fn.Name.Name == "SnapshotVersion" }) {
+			continue
+		}
+		sort.Slice(fns, func(i, j int) bool { return fns[i].Name.Name < fns[j].Name.Name })
+		hash := sha256.New()
+		for _, fn := range fns {
+			var buf bytes.Buffer
+			require.NoError(t, printer.Fprint(&buf, fset, &ast.FuncDecl{Recv: fn.Recv, Name: fn.Name, Type: fn.Type, Body: fn.Body}))
+			hash.Write(buf.Bytes())
+		}
+		hashes[typ] = hex.EncodeToString(hash.Sum(nil))[:16]
+	}
+	return hashes
+}
@@ -0,0 +1,252 @@
+package command
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func validateSSFEndpointURL(endpointURL string) error {
+	u, err := url.Parse(endpointURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Sf3u1", "Errors.SSF.InvalidURL")
+	}
+	return nil
+}
+
+func validateSSFStream(stream *domain.SSFStream) error {
+	if stream.DeliveryMethod == "" {
+		stream.DeliveryMethod = domain.SSFDeliveryMethodPush
+	}
+	if stream.DeliveryMethod != domain.SSFDeliveryMethodPush {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3d2", "Errors.SSF.DeliveryMethodNotSupported")
+	}
+	if len(stream.Audience) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3a3", "Errors.SSF.NoAudience")
+	}
+	return validateSSFEndpointURL(stream.EndpointURL)
+}
+
+// AddSSFStream registers a new stream of a Shared Signals Framework receiver on the instance.
+// The stream is enabled immediately.
+func (c *Commands) AddSSFStream(ctx context.Context, stream *domain.SSFStream) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := validateSSFStream(stream); err != nil {
+		return nil, err
+	}
+	if stream.AggregateID == "" {
+		stream.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	wm, err := c.getSSFStreamWriteModelByID(ctx, stream.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State != domain.SSFStreamStateUnspecified {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Sf3e4", "Errors.SSF.AlreadyExists")
+	}
+	var authorizationHeader *crypto.CryptoValue
+	if stream.AuthorizationHeader != "" {
+		authorizationHeader, err = crypto.Encrypt([]byte(stream.AuthorizationHeader), c.keyAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, ssf.NewAddedEvent(
+		ctx,
+		SSFStreamAggregateFromWriteModel(&wm.WriteModel),
+		stream.Description,
+		stream.Audience,
+		stream.EventsRequested,
+		stream.DeliveryMethod,
+		stream.EndpointURL,
+		authorizationHeader,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+type ChangeSSFStream struct {
+	StreamID string
+
+	Description     *string
+	Audience        []string
+	EventsRequested []string
+	EndpointURL     *string
+	// AuthorizationHeader will be removed if set to an empty string
+	AuthorizationHeader *string
+}
+
+func (change *ChangeSSFStream) IsValid() error {
+	if change.StreamID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3i5", "Errors.IDMissing")
+	}
+	if change.Audience != nil && len(change.Audience) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3a6", "Errors.SSF.NoAudience")
+	}
+	if change.EndpointURL != nil {
+		return validateSSFEndpointURL(*change.EndpointURL)
+	}
+	return nil
+}
+
+// ChangeSSFStream updates the configuration of an existing stream.
+// Only the fields which are set will be changed.
+func (c *Commands) ChangeSSFStream(ctx context.Context, change *ChangeSSFStream) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+	wm, err := c.getSSFStreamWriteModelByID(ctx, change.StreamID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sf3n7", "Errors.SSF.NotFound")
+	}
+	var authorizationHeader *crypto.CryptoValue
+	if change.AuthorizationHeader != nil {
+		authorizationHeader, err = c.changedSSFAuthorizationHeader(wm.AuthorizationHeader, *change.AuthorizationHeader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	changedEvent := wm.NewChangedEvent(ctx, SSFStreamAggregateFromWriteModel(&wm.WriteModel), change, authorizationHeader)
+	if changedEvent == nil {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, changedEvent); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// changedSSFAuthorizationHeader returns the encrypted header if it changed,
+// an empty value if it was removed and nil if nothing changed.
+func (c *Commands) changedSSFAuthorizationHeader(existing *crypto.CryptoValue, header string) (*crypto.CryptoValue, error) {
+	if header == "" {
+		if existing == nil {
+			return nil, nil
+		}
+		return &crypto.CryptoValue{}, nil
+	}
+	if existing != nil {
+		plain, err := crypto.DecryptString(existing, c.keyAlgorithm)
+		if err == nil && plain == header {
+			return nil, nil
+		}
+	}
+	return crypto.Encrypt([]byte(header), c.keyAlgorithm)
+}
+
+// UpdateSSFStreamStatus enables, pauses or disables the delivery of events on the stream.
+func (c *Commands) UpdateSSFStreamStatus(ctx context.Context, streamID string, state domain.SSFStreamState, reason string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if streamID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3i8", "Errors.IDMissing")
+	}
+	if !state.Exists() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf3s9", "Errors.SSF.InvalidStatus")
+	}
+	wm, err := c.getSSFStreamWriteModelByID(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sf4n0", "Errors.SSF.NotFound")
+	}
+	if wm.State == state {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, ssf.NewStatusChangedEvent(ctx, SSFStreamAggregateFromWriteModel(&wm.WriteModel), state, reason)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// RemoveSSFStream removes the stream, no further events will be delivered.
+func (c *Commands) RemoveSSFStream(ctx context.Context, streamID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if streamID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf4i1", "Errors.IDMissing")
+	}
+	wm, err := c.getSSFStreamWriteModelByID(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sf4n2", "Errors.SSF.NotFound")
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, ssf.NewRemovedEvent(ctx, SSFStreamAggregateFromWriteModel(&wm.WriteModel))); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// RequestSSFStreamVerification requests a verification event, which will be sent to the receiver asynchronously.
+// The state is an opaque value and will be returned to the receiver in the verification event.
+func (c *Commands) RequestSSFStreamVerification(ctx context.Context, streamID, state string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if streamID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Sf4i3", "Errors.IDMissing")
+	}
+	wm, err := c.getSSFStreamWriteModelByID(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Sf4n4", "Errors.SSF.NotFound")
+	}
+	if wm.State != domain.SSFStreamStateEnabled {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Sf4e5", "Errors.SSF.NotEnabled")
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, ssf.NewVerificationRequestedEvent(ctx, SSFStreamAggregateFromWriteModel(&wm.WriteModel), state)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// SSFSETDelivered tracks a security event token accepted by the receiver of the stream.
+func (c *Commands) SSFSETDelivered(ctx context.Context, streamID, jti, eventType, subjectID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	_, err = c.eventstore.Push(ctx, ssf.NewSETDeliveredEvent(ctx, ssf.NewAggregate(streamID, authz.GetInstance(ctx).InstanceID()), jti, eventType, subjectID))
+	return err
+}
+
+// SSFSETFailed tracks a security event token which could not be delivered to the receiver of the stream.
+func (c *Commands) SSFSETFailed(ctx context.Context, streamID, jti, eventType, subjectID, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	_, err = c.eventstore.Push(ctx, ssf.NewSETFailedEvent(ctx, ssf.NewAggregate(streamID, authz.GetInstance(ctx).InstanceID()), jti, eventType, subjectID, reason))
+	return err
+}
+
+func (c *Commands) getSSFStreamWriteModelByID(ctx context.Context, streamID string) (*SSFStreamWriteModel, error) {
+	wm := NewSSFStreamWriteModel(streamID, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+)
+
+type SSFStreamWriteModel struct {
+	eventstore.WriteModel
+
+	Description         string
+	Audience            []string
+	EventsRequested     []string
+	DeliveryMethod      string
+	EndpointURL         string
+	AuthorizationHeader *crypto.CryptoValue
+
+	State domain.SSFStreamState
+}
+
+func NewSSFStreamWriteModel(streamID, instanceID string) *SSFStreamWriteModel {
+	return &SSFStreamWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   streamID,
+			ResourceOwner: instanceID,
+			InstanceID:    instanceID,
+		},
+	}
+}
+
+func (wm *SSFStreamWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *ssf.AddedEvent:
+			wm.Description = e.Description
+			wm.Audience = e.Audience
+			wm.EventsRequested = e.EventsRequested
+			wm.DeliveryMethod = e.DeliveryMethod
+			wm.EndpointURL = e.EndpointURL
+			wm.AuthorizationHeader = e.AuthorizationHeader
+			wm.State = domain.SSFStreamStateEnabled
+		case *ssf.ChangedEvent:
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+			if e.Audience != nil {
+				wm.Audience = *e.Audience
+			}
+			if e.EventsRequested != nil {
+				wm.EventsRequested = *e.EventsRequested
+			}
+			if e.EndpointURL != nil {
+				wm.EndpointURL = *e.EndpointURL
+			}
+			if e.AuthorizationHeader != nil {
+				wm.AuthorizationHeader = e.AuthorizationHeader
+				// an empty value removes the header
+				if len(e.AuthorizationHeader.Crypted) == 0 {
+					wm.AuthorizationHeader = nil
+				}
+			}
+		case *ssf.StatusChangedEvent:
+			wm.State = e.State
+		case *ssf.RemovedEvent:
+			wm.State = domain.SSFStreamStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SSFStreamWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(ssf.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			ssf.AddedEventType,
+			ssf.ChangedEventType,
+			ssf.StatusChangedEventType,
+			ssf.RemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *SSFStreamWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	change *ChangeSSFStream,
+	authorizationHeader *crypto.CryptoValue,
+) *ssf.ChangedEvent {
+	changes := make([]ssf.Changes, 0, 5)
+	if change.Description != nil && wm.Description != *change.Description {
+		changes = append(changes, ssf.ChangeDescription(*change.Description))
+	}
+	if change.Audience != nil && !slices.Equal(wm.Audience, change.Audience) {
+		changes = append(changes, ssf.ChangeAudience(change.Audience))
+	}
+	if change.EventsRequested != nil && !slices.Equal(wm.EventsRequested, change.EventsRequested) {
+		changes = append(changes, ssf.ChangeEventsRequested(change.EventsRequested))
+	}
+	if change.EndpointURL != nil && wm.EndpointURL != *change.EndpointURL {
+		changes = append(changes, ssf.ChangeEndpointURL(*change.EndpointURL))
+	}
+	if authorizationHeader != nil {
+		changes = append(changes, ssf.ChangeAuthorizationHeader(authorizationHeader))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return ssf.NewChangedEvent(ctx, agg, changes)
+}
+
+func SSFStreamAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return ssf.NewAggregate(wm.AggregateID, wm.ResourceOwner)
+}
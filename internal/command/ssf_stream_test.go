@@ -0,0 +1,522 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func ssfStreamAddedEvent(ctx context.Context, authorizationHeader *crypto.CryptoValue) *ssf.AddedEvent {
+	return ssf.NewAddedEvent(ctx,
+		ssf.NewAggregate("stream1", "instance"),
+		"description",
+		[]string{"audience"},
+		[]string{domain.CAEPEventTypeSessionRevoked},
+		domain.SSFDeliveryMethodPush,
+		"https://receiver.example.com/events",
+		authorizationHeader,
+	)
+}
+
+func TestCommands_AddSSFStream(t *testing.T) {
+	ctx := authz.NewMockContext("instance", "org1", "user1")
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		stream *domain.SSFStream
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"poll delivery, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				stream: &domain.SSFStream{
+					Audience:       []string{"audience"},
+					DeliveryMethod: domain.SSFDeliveryMethodPoll,
+					EndpointURL:    "https://receiver.example.com/events",
+				},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no audience, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				stream: &domain.SSFStream{
+					EndpointURL: "https://receiver.example.com/events",
+				},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid endpoint, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				stream: &domain.SSFStream{
+					Audience:    []string{"audience"},
+					EndpointURL: "ftp://receiver.example.com/events",
+				},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"already exists, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "stream1"),
+			},
+			args{
+				stream: &domain.SSFStream{
+					Audience:    []string{"audience"},
+					EndpointURL: "https://receiver.example.com/events",
+				},
+			},
+			res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"push, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						ssfStreamAddedEvent(ctx, &crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "enc",
+							KeyID:      "id",
+							Crypted:    []byte("Bearer token"),
+						}),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "stream1"),
+			},
+			args{
+				stream: &domain.SSFStream{
+					Description:         "description",
+					Audience:            []string{"audience"},
+					EventsRequested:     []string{domain.CAEPEventTypeSessionRevoked},
+					EndpointURL:         "https://receiver.example.com/events",
+					AuthorizationHeader: "Bearer token",
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+					ID:            "stream1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.fields.eventstore(t),
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			details, err := c.AddSSFStream(ctx, tt.args.stream)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+				assert.Equal(t, domain.SSFDeliveryMethodPush, tt.args.stream.DeliveryMethod)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeSSFStream(t *testing.T) {
+	ctx := authz.NewMockContext("instance", "org1", "user1")
+	existingHeader := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("Bearer token"),
+	}
+	type args struct {
+		change *ChangeSSFStream
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			"no id, error",
+			expectEventstore(),
+			args{
+				change: &ChangeSSFStream{},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"empty audience, error",
+			expectEventstore(),
+			args{
+				change: &ChangeSSFStream{
+					StreamID: "stream1",
+					Audience: []string{},
+				},
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			expectEventstore(
+				expectFilter(),
+			),
+			args{
+				change: &ChangeSSFStream{
+					StreamID:    "stream1",
+					Description: gu.Ptr("changed"),
+				},
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"removed, error",
+			expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+					eventFromEventPusher(ssf.NewRemovedEvent(ctx, ssf.NewAggregate("stream1", "instance"))),
+				),
+			),
+			args{
+				change: &ChangeSSFStream{
+					StreamID:    "stream1",
+					Description: gu.Ptr("changed"),
+				},
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"no changes, ok",
+			expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, existingHeader)),
+				),
+			),
+			args{
+				change: &ChangeSSFStream{
+					StreamID:            "stream1",
+					Description:         gu.Ptr("description"),
+					AuthorizationHeader: gu.Ptr("Bearer token"),
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+					ID:            "stream1",
+				},
+			},
+		},
+		{
+			"change, ok",
+			expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, existingHeader)),
+				),
+				expectPush(
+					ssf.NewChangedEvent(ctx,
+						ssf.NewAggregate("stream1", "instance"),
+						[]ssf.Changes{
+							ssf.ChangeAudience([]string{"audience", "other"}),
+							ssf.ChangeEndpointURL("https://other.example.com/events"),
+							ssf.ChangeAuthorizationHeader(&crypto.CryptoValue{}),
+						},
+					),
+				),
+			),
+			args{
+				change: &ChangeSSFStream{
+					StreamID:            "stream1",
+					Audience:            []string{"audience", "other"},
+					EndpointURL:         gu.Ptr("https://other.example.com/events"),
+					AuthorizationHeader: gu.Ptr(""),
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+					ID:            "stream1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.eventstore(t),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			details, err := c.ChangeSSFStream(ctx, tt.args.change)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_UpdateSSFStreamStatus(t *testing.T) {
+	ctx := authz.NewMockContext("instance", "org1", "user1")
+	type args struct {
+		streamID string
+		state    domain.SSFStreamState
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     func(error) bool
+	}
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		args       args
+		res        res
+	}{
+		{
+			"invalid state, error",
+			expectEventstore(),
+			args{
+				streamID: "stream1",
+				state:    domain.SSFStreamStateRemoved,
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			expectEventstore(
+				expectFilter(),
+			),
+			args{
+				streamID: "stream1",
+				state:    domain.SSFStreamStatePaused,
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"unchanged, ok",
+			expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+				),
+			),
+			args{
+				streamID: "stream1",
+				state:    domain.SSFStreamStateEnabled,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+					ID:            "stream1",
+				},
+			},
+		},
+		{
+			"pause, ok",
+			expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+				),
+				expectPush(
+					ssf.NewStatusChangedEvent(ctx, ssf.NewAggregate("stream1", "instance"), domain.SSFStreamStatePaused, "maintenance"),
+				),
+			),
+			args{
+				streamID: "stream1",
+				state:    domain.SSFStreamStatePaused,
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance",
+					ID:            "stream1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			details, err := c.UpdateSSFStreamStatus(ctx, tt.args.streamID, tt.args.state, "maintenance")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveSSFStream(t *testing.T) {
+	ctx := authz.NewMockContext("instance", "org1", "user1")
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		streamID   string
+		err        func(error) bool
+	}{
+		{
+			name:       "no id, error",
+			eventstore: expectEventstore(),
+			err:        zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "not found, error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			streamID: "stream1",
+			err:      zerrors.IsNotFound,
+		},
+		{
+			name: "remove, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+				),
+				expectPush(
+					ssf.NewRemovedEvent(ctx, ssf.NewAggregate("stream1", "instance")),
+				),
+			),
+			streamID: "stream1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			_, err := c.RemoveSSFStream(ctx, tt.streamID)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_RequestSSFStreamVerification(t *testing.T) {
+	ctx := authz.NewMockContext("instance", "org1", "user1")
+	tests := []struct {
+		name       string
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		err        func(error) bool
+	}{
+		{
+			name: "not found, error",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			err: zerrors.IsNotFound,
+		},
+		{
+			name: "paused, error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+					eventFromEventPusher(ssf.NewStatusChangedEvent(ctx, ssf.NewAggregate("stream1", "instance"), domain.SSFStreamStatePaused, "")),
+				),
+			),
+			err: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "request, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(ssfStreamAddedEvent(ctx, nil)),
+				),
+				expectPush(
+					ssf.NewVerificationRequestedEvent(ctx, ssf.NewAggregate("stream1", "instance"), "state"),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			_, err := c.RequestSSFStreamVerification(ctx, "stream1", "state")
+			if tt.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.err != nil && !tt.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -15,15 +16,23 @@ import (
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
-func (c *Commands) AddUserGrant(ctx context.Context, usergrant *domain.UserGrant, resourceOwner string) (_ *domain.UserGrant, err error) {
+func (c *Commands) AddUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (_ *domain.UserGrant, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	event, addedUserGrant, err := c.addUserGrant(ctx, usergrant, resourceOwner)
+	event, addedUserGrant, err := c.addUserGrant(ctx, userGrant, resourceOwner)
 	if err != nil {
 		return nil, err
 	}
-	pushedEvents, err := c.eventstore.Push(ctx, event)
+	events := []eventstore.Command{event}
+	if !userGrant.GrantValidity.IsZero() {
+		events = append(events, usergrant.NewUserGrantValidityChangedEvent(ctx,
+			UserGrantAggregateFromWriteModel(&addedUserGrant.WriteModel),
+			userGrant.ValidFrom,
+			userGrant.ValidUntil,
+		))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
 		return nil, err
 	}
@@ -39,6 +48,9 @@ func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant
 	if !userGrant.IsValid() {
 		return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-kVfMa", "Errors.UserGrant.Invalid")
 	}
+	if !isGrantValidityValid(userGrant.GrantValidity) {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gw1iv", "Errors.UserGrant.ValidityInvalid")
+	}
 	err = c.checkUserGrantPreCondition(ctx, userGrant, resourceOwner)
 	if err != nil {
 		return nil, nil, err
@@ -192,6 +204,9 @@ func (c *Commands) ReactivateUserGrant(ctx context.Context, grantID, resourceOwn
 	if existingUserGrant.State != domain.UserGrantStateInactive {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-1ML0v", "Errors.UserGrant.NotInactive")
 	}
+	if existingUserGrant.Validity.IsExpiredAt(time.Now()) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gw2ex", "Errors.UserGrant.Expired")
+	}
 	err = checkExplicitProjectPermission(ctx, existingUserGrant.ProjectGrantID, existingUserGrant.ProjectID)
 	if err != nil {
 		return nil, err
@@ -299,8 +314,11 @@ func (c *Commands) checkUserGrantPreCondition(ctx context.Context, usergrant *do
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	if err := c.checkUserExists(ctx, usergrant.UserID, ""); err != nil {
-		return err
+	// the preconditions of grants for users added in the same push are checked without a user
+	if usergrant.UserID != "" {
+		if err := c.checkUserExists(ctx, usergrant.UserID, ""); err != nil {
+			return err
+		}
 	}
 	existingRoleKeys, err := c.searchUserGrantPreConditionState(ctx, usergrant, resourceOwner)
 	if err != nil {
@@ -434,7 +452,7 @@ func (c *Commands) checkUserGrantPreConditionOld(ctx context.Context, usergrant
 	if err != nil {
 		return err
 	}
-	if !preConditions.UserExists {
+	if usergrant.UserID != "" && !preConditions.UserExists {
 		return zerrors.ThrowPreconditionFailed(err, "COMMAND-4f8sg", "Errors.User.NotFound")
 	}
 	if usergrant.ProjectGrantID == "" && !preConditions.ProjectExists {
@@ -16,6 +16,11 @@ type UserGrantWriteModel struct {
 	ProjectGrantID string
 	RoleKeys       []string
 	State          domain.UserGrantState
+	Validity       domain.GrantValidity
+	// ExpiryWarned is reset as soon as the validity changes
+	ExpiryWarned bool
+	// Expired is set if the grant was deactivated because its validity ended
+	Expired bool
 }
 
 func NewUserGrantWriteModel(userGrantID string, resourceOwner string) *UserGrantWriteModel {
@@ -50,6 +55,18 @@ func (wm *UserGrantWriteModel) Reduce() error {
 				continue
 			}
 			wm.State = domain.UserGrantStateActive
+			wm.Expired = false
+		case *usergrant.UserGrantValidityChangedEvent:
+			wm.Validity = domain.GrantValidity{ValidFrom: e.ValidFrom, ValidUntil: e.ValidUntil}
+			wm.ExpiryWarned = false
+		case *usergrant.UserGrantExpiryWarnedEvent:
+			wm.ExpiryWarned = true
+		case *usergrant.UserGrantExpiredEvent:
+			if wm.State == domain.UserGrantStateRemoved {
+				continue
+			}
+			wm.State = domain.UserGrantStateInactive
+			wm.Expired = true
 		case *usergrant.UserGrantRemovedEvent:
 			wm.State = domain.UserGrantStateRemoved
 		case *usergrant.UserGrantCascadeRemovedEvent:
@@ -69,6 +86,9 @@ func (wm *UserGrantWriteModel) Query() *eventstore.SearchQueryBuilder {
 			usergrant.UserGrantCascadeChangedType,
 			usergrant.UserGrantDeactivatedType,
 			usergrant.UserGrantReactivatedType,
+			usergrant.UserGrantValidityChangedType,
+			usergrant.UserGrantExpiryWarnedType,
+			usergrant.UserGrantExpiredType,
 			usergrant.UserGrantRemovedType,
 			usergrant.UserGrantCascadeRemovedType).
 		Builder()
@@ -159,18 +179,24 @@ func (wm *UserGrantPreConditionReadModel) Reduce() error {
 }
 
 func (wm *UserGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder {
-	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent)
+	// the preconditions of group grants are checked without a user
+	if wm.UserID != "" {
+		query = query.
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(wm.UserID).
+			EventTypes(
+				user.UserV1AddedType,
+				user.HumanAddedType,
+				user.UserV1RegisteredType,
+				user.HumanRegisteredType,
+				user.MachineAddedEventType,
+				user.UserRemovedType).
+			Builder()
+	}
+	return query.
 		AddQuery().
-		AggregateTypes(user.AggregateType).
-		AggregateIDs(wm.UserID).
-		EventTypes(
-			user.UserV1AddedType,
-			user.HumanAddedType,
-			user.UserV1RegisteredType,
-			user.HumanRegisteredType,
-			user.MachineAddedEventType,
-			user.UserRemovedType).
-		Or().
 		AggregateTypes(project.AggregateType).
 		AggregateIDs(wm.ProjectID).
 		EventTypes(
@@ -182,5 +208,4 @@ func (wm *UserGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder
 			project.RoleAddedType,
 			project.RoleRemovedType).
 		Builder()
-	return query
 }
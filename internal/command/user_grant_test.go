@@ -83,6 +83,7 @@ func TestCommandSide_AddUserGrant(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username1",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -752,6 +753,7 @@ func TestCommandSide_ChangeUserGrant(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username1",
 								nil,
+								"",
 								true,
 							),
 						),
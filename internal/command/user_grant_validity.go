@@ -0,0 +1,139 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetUserGrantValidity restricts the user grant to the time window of the validity.
+// A grant, which was deactivated because it expired, is reactivated by a validity ending in the future.
+func (c *Commands) SetUserGrantValidity(ctx context.Context, grantID, resourceOwner string, validity domain.GrantValidity) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gv1id", "Errors.UserGrant.IDMissing")
+	}
+	if !isGrantValidityValid(validity) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gv2iv", "Errors.UserGrant.ValidityInvalid")
+	}
+	existingUserGrant, err := c.userGrantWriteModelByID(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUserGrant.State == domain.UserGrantStateUnspecified || existingUserGrant.State == domain.UserGrantStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gv3nf", "Errors.UserGrant.NotFound")
+	}
+	err = checkExplicitProjectPermission(ctx, existingUserGrant.ProjectGrantID, existingUserGrant.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if existingUserGrant.Validity.Equal(validity) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gv9nc", "Errors.UserGrant.NotChanged")
+	}
+	userGrantAgg := UserGrantAggregateFromWriteModel(&existingUserGrant.WriteModel)
+	events := []eventstore.Command{
+		usergrant.NewUserGrantValidityChangedEvent(ctx, userGrantAgg, validity.ValidFrom, validity.ValidUntil),
+	}
+	if existingUserGrant.Expired {
+		events = append(events, usergrant.NewUserGrantReactivatedEvent(ctx, userGrantAgg))
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUserGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+}
+
+// WarnUserGrantExpiry notifies the owners of the organization that the active user grant will expire.
+// The owners are only warned once per validity.
+// It's used by the grant expiry worker and therefore doesn't check any permission.
+func (c *Commands) WarnUserGrantExpiry(ctx context.Context, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	existingUserGrant, err := c.activeUserGrantWithValidity(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUserGrant.ExpiryWarned {
+		return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, usergrant.NewUserGrantExpiryWarnedEvent(ctx,
+		UserGrantAggregateFromWriteModel(&existingUserGrant.WriteModel),
+		existingUserGrant.UserID,
+		existingUserGrant.ProjectID,
+		existingUserGrant.Validity.ValidUntil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUserGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+}
+
+// ExpireUserGrant deactivates the user grant after its validity ended.
+// It's used by the grant expiry worker and therefore doesn't check any permission.
+func (c *Commands) ExpireUserGrant(ctx context.Context, grantID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	existingUserGrant, err := c.activeUserGrantWithValidity(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !existingUserGrant.Validity.IsExpiredAt(time.Now()) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gv4ne", "Errors.UserGrant.NotExpired")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, usergrant.NewUserGrantExpiredEvent(ctx,
+		UserGrantAggregateFromWriteModel(&existingUserGrant.WriteModel),
+		existingUserGrant.Validity.ValidUntil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUserGrant, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUserGrant.WriteModel), nil
+}
+
+func (c *Commands) activeUserGrantWithValidity(ctx context.Context, grantID, resourceOwner string) (*UserGrantWriteModel, error) {
+	if grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gv5id", "Errors.UserGrant.IDMissing")
+	}
+	existingUserGrant, err := c.userGrantWriteModelByID(ctx, grantID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUserGrant.State == domain.UserGrantStateUnspecified || existingUserGrant.State == domain.UserGrantStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Gv6nf", "Errors.UserGrant.NotFound")
+	}
+	if existingUserGrant.State != domain.UserGrantStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gv7na", "Errors.UserGrant.NotActive")
+	}
+	if existingUserGrant.Validity.ValidUntil.IsZero() {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Gv8nu", "Errors.UserGrant.NoValidUntil")
+	}
+	return existingUserGrant, nil
+}
+
+// isGrantValidityValid ensures the window doesn't end before it starts
+// and grants aren't created or changed to be expired already.
+func isGrantValidityValid(validity domain.GrantValidity) bool {
+	return validity.IsValid() && !validity.IsExpiredAt(time.Now())
+}
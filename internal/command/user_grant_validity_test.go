@@ -0,0 +1,519 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func userGrantAddedTestEvent() eventstore.Event {
+	return eventFromEventPusher(
+		usergrant.NewUserGrantAddedEvent(context.Background(),
+			&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+			"user1",
+			"project1",
+			"", []string{"rolekey1"}),
+	)
+}
+
+func TestCommandSide_SetUserGrantValidity(t *testing.T) {
+	validFrom := time.Now().Add(-time.Hour).UTC()
+	validUntil := time.Now().Add(24 * time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userGrantID   string
+		resourceOwner string
+		validity      domain.GrantValidity
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing usergrantID, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "validity ends before it starts, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidFrom: validUntil, ValidUntil: validFrom},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "validity already ended, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validFrom},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "usergrant not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no permissions, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "validity not changed, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntil),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "validity set, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+					),
+					expectPush(
+						usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							validFrom, validUntil),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidFrom: validFrom, ValidUntil: validUntil},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "expired grant extended, reactivated",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validFrom),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantExpiredEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								validFrom),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							time.Time{}, validUntil),
+						usergrant.NewUserGrantReactivatedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+				validity:      domain.GrantValidity{ValidUntil: validUntil},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetUserGrantValidity(tt.args.ctx, tt.args.userGrantID, tt.args.resourceOwner, tt.args.validity)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_WarnUserGrantExpiry(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		userGrantID   string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "usergrant not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "usergrant without end, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "usergrant inactive, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntil),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantDeactivatedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate),
+						),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already warned, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntil),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantExpiryWarnedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1", "project1", validUntil),
+						),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "warned, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntil),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantExpiryWarnedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1", "project1", validUntil),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.WarnUserGrantExpiry(context.Background(), tt.args.userGrantID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ExpireUserGrant(t *testing.T) {
+	validUntilPast := time.Now().Add(-time.Minute).UTC()
+	validUntilFuture := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		userGrantID   string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing usergrantID, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not yet expired, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntilFuture),
+						),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already expired, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntilPast),
+						),
+						eventFromEventPusher(
+							usergrant.NewUserGrantExpiredEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								validUntilPast),
+						),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "expired, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userGrantAddedTestEvent(),
+						eventFromEventPusher(
+							usergrant.NewUserGrantValidityChangedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								time.Time{}, validUntilPast),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantExpiredEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							validUntilPast),
+					),
+				),
+			},
+			args: args{
+				userGrantID:   "usergrant1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ExpireUserGrant(context.Background(), tt.args.userGrantID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -97,6 +97,12 @@ func (wm *HumanWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotReducer].
+// It must be increased if the reduce logic changes.
+func (wm *HumanWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *HumanWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -1648,6 +1648,7 @@ func TestCommandSide_CheckPassword(t *testing.T) {
 				eventstore: expectEventstore(
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -9,7 +9,10 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels/messagebird"
+	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -117,10 +120,20 @@ func (c *Commands) phoneCodeVerifierFromConfig(ctx context.Context, id string) (
 	if config.State != domain.SMSConfigStateActive {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-M0odsf", "Errors.SMSConfig.NotFound")
 	}
-	if config.Twilio != nil {
-		if config.Twilio.VerifyServiceSID == "" {
+	switch {
+	case config.Twilio != nil, config.Vonage != nil, config.MessageBird != nil:
+		if !config.generatesCode() {
 			return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Sgb4h", "Errors.SMSConfig.NotExternalVerification")
 		}
+		return c.smsNativeProvider(config)
+	}
+	return nil, nil
+}
+
+// smsNativeProvider returns the provider of the config with the decrypted secrets
+func (c *Commands) smsNativeProvider(config *IAMSMSConfigWriteModel) (sms.NativeProvider, error) {
+	switch {
+	case config.Twilio != nil:
 		token, err := crypto.DecryptString(config.Twilio.Token, c.smsEncryption)
 		if err != nil {
 			return nil, err
@@ -131,8 +144,30 @@ func (c *Commands) phoneCodeVerifierFromConfig(ctx context.Context, id string) (
 			SenderNumber:     config.Twilio.SenderNumber,
 			VerifyServiceSID: config.Twilio.VerifyServiceSID,
 		}, nil
+	case config.Vonage != nil:
+		apiSecret, err := crypto.DecryptString(config.Vonage.APISecret, c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+		return &vonage.Config{
+			APIKey:       config.Vonage.APIKey,
+			APISecret:    apiSecret,
+			SenderNumber: config.Vonage.SenderNumber,
+			VerifyBrand:  config.Vonage.VerifyBrand,
+		}, nil
+	case config.MessageBird != nil:
+		accessKey, err := crypto.DecryptString(config.MessageBird.AccessKey, c.smsEncryption)
+		if err != nil {
+			return nil, err
+		}
+		return &messagebird.Config{
+			AccessKey:  accessKey,
+			Originator: config.MessageBird.Originator,
+			Verify:     config.MessageBird.Verify,
+		}, nil
+	default:
+		return nil, nil
 	}
-	return nil, nil
 }
 
 func (c *Commands) activeSMSProvider(ctx context.Context) (string, error) {
@@ -140,7 +175,7 @@ func (c *Commands) activeSMSProvider(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if config.State == domain.SMSConfigStateActive && config.Twilio != nil && config.Twilio.VerifyServiceSID != "" {
+	if config.State == domain.SMSConfigStateActive && config.generatesCode() {
 		return config.ID, nil
 	}
 	return "", err
@@ -192,6 +192,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 					),
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
 				),
 			},
 			args: args{
@@ -1518,6 +1519,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 							),
 							expectFilter(),
 							expectFilter(),
+							expectFilter(),
 						),
 					},
 					args{
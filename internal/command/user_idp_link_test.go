@@ -807,6 +807,7 @@ func TestCommandSide_RemoveUserIDPLink(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -993,6 +994,7 @@ func TestCommandSide_ExternalLoginCheck(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zitadel/logging"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpintent"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// idpTokenExpirySkew is used to refresh tokens shortly before they actually expire,
+// so the client is able to use them
+const idpTokenExpirySkew = 10 * time.Second
+
+// IDPLinkAccessToken is the (decrypted) access token of the external IdP of a user
+type IDPLinkAccessToken struct {
+	AccessToken string
+	TokenType   string
+	Expiry      time.Time
+}
+
+// SetUserIDPLinkTokens stores the tokens received from the external IdP (encrypted) on the link of the user.
+// If the IdP did not return a new refresh token, the previous one will be kept.
+func (c *Commands) SetUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpConfigID, externalUserID string, token *oauth2.Token) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpConfigID == "" || externalUserID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Tk1s2", "Errors.IDMissing")
+	}
+	tokens, err := c.encryptIDPTokens(token)
+	if err != nil || tokens == nil {
+		return err
+	}
+	writeModel := NewUserIDPLinkTokensWriteModel(userID, idpConfigID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return err
+	}
+	if !writeModel.IsLinked(externalUserID) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Tk2s2", "Errors.User.ExternalIDP.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, userIDPLinkTokensSetEvent(ctx, writeModel, externalUserID, tokens))
+	return err
+}
+
+// encryptIDPTokens encrypts the tokens of the IdP with the key dedicated to them.
+// If there's no access token, nil is returned.
+func (c *Commands) encryptIDPTokens(token *oauth2.Token) (*idpintent.IDPTokens, error) {
+	if token == nil || token.AccessToken == "" {
+		return nil, nil
+	}
+	accessToken, err := crypto.Encrypt([]byte(token.AccessToken), c.idpTokenEncryption)
+	if err != nil {
+		return nil, err
+	}
+	var refreshToken *crypto.CryptoValue
+	if token.RefreshToken != "" {
+		refreshToken, err = crypto.Encrypt([]byte(token.RefreshToken), c.idpTokenEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &idpintent.IDPTokens{
+		AccessToken:  accessToken,
+		TokenType:    token.TokenType,
+		RefreshToken: refreshToken,
+		Expiry:       token.Expiry,
+	}, nil
+}
+
+// userIDPLinkTokensSetEvent stores the encrypted tokens on the link of the user.
+// If there's no new refresh token, the previous one of the same link is kept.
+func userIDPLinkTokensSetEvent(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel, externalUserID string, tokens *idpintent.IDPTokens) eventstore.Command {
+	refreshToken := tokens.RefreshToken
+	if refreshToken == nil && writeModel.ExternalUserID == externalUserID {
+		refreshToken = writeModel.RefreshToken
+	}
+	return user.NewUserIDPLinkTokensSetEvent(
+		ctx,
+		UserAggregateFromWriteModel(&writeModel.WriteModel),
+		writeModel.IDPConfigID,
+		externalUserID,
+		tokens.AccessToken,
+		tokens.TokenType,
+		refreshToken,
+		tokens.Expiry,
+	)
+}
+
+// bindIntentIDPTokens returns the command to store the tokens of the intent on the link of the user,
+// so the tokens of an intent, which created or was linked to the user afterward, can be used for the token exchange.
+// Tokens of the link stored after the intent succeeded are newer and therefore not replaced.
+func bindIntentIDPTokens(ctx context.Context, queryReducer func(ctx context.Context, r eventstore.QueryReducer) error, intent *IDPIntentWriteModel, userID, resourceOwner string) (eventstore.Command, error) {
+	if intent.IDPTokens == nil {
+		return nil, nil
+	}
+	writeModel := NewUserIDPLinkTokensWriteModel(userID, intent.IDPID, resourceOwner)
+	if err := queryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.IsLinked(intent.IDPUserID) || intent.SucceededDate.Before(writeModel.TokensDate) {
+		return nil, nil
+	}
+	return userIDPLinkTokensSetEvent(ctx, writeModel, intent.IDPUserID, intent.IDPTokens), nil
+}
+
+// GetUserIDPLinkAccessToken returns the stored access token of the external IdP of the user for the client.
+// The client must be explicitly allowed on the IdP (see [Commands.SetInstanceIDPTokenExchangeClients]).
+// If the access token is expired, it will be refreshed using the stored refresh token.
+// Every retrieval is audited on the user.
+func (c *Commands) GetUserIDPLinkAccessToken(ctx context.Context, userID, resourceOwner, idpConfigID, clientID string) (_ *IDPLinkAccessToken, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpConfigID == "" || clientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Tk3s2", "Errors.IDMissing")
+	}
+	if err = c.checkIDPTokenExchangeClient(ctx, idpConfigID, clientID); err != nil {
+		return nil, err
+	}
+	writeModel := NewUserIDPLinkTokensWriteModel(userID, idpConfigID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.HasTokens() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Tk4s2", "Errors.User.ExternalIDP.TokenNotFound")
+	}
+	accessToken, err := crypto.DecryptString(writeModel.AccessToken, c.idpTokenEncryption)
+	if err != nil {
+		return nil, err
+	}
+	token := &IDPLinkAccessToken{
+		AccessToken: accessToken,
+		TokenType:   writeModel.TokenType,
+		Expiry:      writeModel.Expiry,
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	cmds := make([]eventstore.Command, 0, 2)
+	refreshed := !token.Expiry.IsZero() && token.Expiry.Before(time.Now().Add(idpTokenExpirySkew))
+	if refreshed {
+		newToken, refreshErr := c.refreshUserIDPLinkTokens(ctx, writeModel)
+		if refreshErr != nil {
+			if zerrors.IsPreconditionFailed(refreshErr) {
+				_, err = c.eventstore.Push(ctx, user.NewUserIDPLinkTokensRemovedEvent(ctx, userAgg, idpConfigID, writeModel.ExternalUserID))
+				logging.OnError(err).Warn("unable to remove invalid idp tokens")
+			}
+			return nil, refreshErr
+		}
+		tokens, err := c.encryptIDPTokens(newToken)
+		if err != nil {
+			return nil, err
+		}
+		if tokens == nil {
+			return nil, zerrors.ThrowInternal(nil, "COMMAND-Tk9s2", "Errors.Internal")
+		}
+		cmds = append(cmds, userIDPLinkTokensSetEvent(ctx, writeModel, writeModel.ExternalUserID, tokens))
+		token = &IDPLinkAccessToken{
+			AccessToken: newToken.AccessToken,
+			TokenType:   newToken.TokenType,
+			Expiry:      newToken.Expiry,
+		}
+	}
+	cmds = append(cmds, user.NewUserIDPLinkTokenRetrievedEvent(ctx, userAgg, idpConfigID, writeModel.ExternalUserID, clientID, refreshed))
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// refreshUserIDPLinkTokens uses the stored refresh token to retrieve a new access token from the IdP.
+// It returns a precondition failed error if there is no (valid) refresh token.
+func (c *Commands) refreshUserIDPLinkTokens(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel) (*oauth2.Token, error) {
+	if writeModel.RefreshToken == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tk5s2", "Errors.User.ExternalIDP.TokenExpired")
+	}
+	refreshToken, err := crypto.DecryptString(writeModel.RefreshToken, c.idpTokenEncryption)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := c.GetProvider(ctx, writeModel.IDPConfigID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	oauthProvider, ok := provider.(interface{ OAuthConfig() *oauth2.Config })
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tk6s2", "Errors.User.ExternalIDP.TokenExpired")
+	}
+	token, err := oauthProvider.OAuthConfig().TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-Tk7s2", "Errors.User.ExternalIDP.TokenExpired")
+		}
+		return nil, zerrors.ThrowInternal(err, "COMMAND-Tk8s2", "Errors.Internal")
+	}
+	return token, nil
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserIDPLinkTokensWriteModel contains the latest stored tokens of all active links of the user to the IdP
+type UserIDPLinkTokensWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID     string
+	ExternalUserIDs []string
+
+	ExternalUserID string
+	AccessToken    *crypto.CryptoValue
+	TokenType      string
+	RefreshToken   *crypto.CryptoValue
+	Expiry         time.Time
+	// TokensDate is the creation date of the latest stored tokens
+	TokensDate time.Time
+}
+
+func NewUserIDPLinkTokensWriteModel(userID, idpConfigID, resourceOwner string) *UserIDPLinkTokensWriteModel {
+	return &UserIDPLinkTokensWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID: idpConfigID,
+	}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLinkAddedEvent:
+			if e.IDPConfigID == wm.IDPConfigID {
+				wm.ExternalUserIDs = append(wm.ExternalUserIDs, e.ExternalUserID)
+			}
+		case *user.UserIDPExternalIDMigratedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			if i := slices.Index(wm.ExternalUserIDs, e.PreviousID); i >= 0 {
+				wm.ExternalUserIDs[i] = e.NewID
+			}
+			if wm.ExternalUserID == e.PreviousID {
+				wm.ExternalUserID = e.NewID
+			}
+		case *user.UserIDPLinkRemovedEvent:
+			wm.reduceLinkRemoved(e.IDPConfigID, e.ExternalUserID)
+		case *user.UserIDPLinkCascadeRemovedEvent:
+			wm.reduceLinkRemoved(e.IDPConfigID, e.ExternalUserID)
+		case *user.UserIDPLinkTokensSetEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.ExternalUserID = e.ExternalUserID
+			wm.AccessToken = e.AccessToken
+			wm.TokenType = e.TokenType
+			wm.RefreshToken = e.RefreshToken
+			wm.Expiry = e.Expiry
+			wm.TokensDate = e.CreatedAt()
+		case *user.UserIDPLinkTokensRemovedEvent:
+			if e.IDPConfigID == wm.IDPConfigID && e.ExternalUserID == wm.ExternalUserID {
+				wm.clearTokens()
+			}
+		case *user.UserRemovedEvent:
+			wm.ExternalUserIDs = nil
+			wm.clearTokens()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPLinkTokensWriteModel) reduceLinkRemoved(idpConfigID, externalUserID string) {
+	if idpConfigID != wm.IDPConfigID {
+		return
+	}
+	wm.ExternalUserIDs = slices.DeleteFunc(wm.ExternalUserIDs, func(id string) bool {
+		return id == externalUserID
+	})
+	if wm.ExternalUserID == externalUserID {
+		wm.clearTokens()
+	}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) clearTokens() {
+	wm.ExternalUserID = ""
+	wm.AccessToken = nil
+	wm.TokenType = ""
+	wm.RefreshToken = nil
+	wm.Expiry = time.Time{}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPLinkAddedType,
+			user.UserIDPExternalIDMigratedType,
+			user.UserIDPLinkRemovedType,
+			user.UserIDPLinkCascadeRemovedType,
+			user.UserIDPLinkTokensSetType,
+			user.UserIDPLinkTokensRemovedType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
+
+// IsLinked checks if the user has an active link with the external user id to the IdP
+func (wm *UserIDPLinkTokensWriteModel) IsLinked(externalUserID string) bool {
+	return slices.Contains(wm.ExternalUserIDs, externalUserID)
+}
+
+// HasTokens checks if there are stored tokens of an active link
+func (wm *UserIDPLinkTokensWriteModel) HasTokens() bool {
+	return wm.AccessToken != nil && wm.IsLinked(wm.ExternalUserID)
+}
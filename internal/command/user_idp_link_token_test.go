@@ -0,0 +1,323 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetUserIDPLinkTokens(t *testing.T) {
+	expiry := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx            context.Context
+		externalUserID string
+		token          *oauth2.Token
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing external user id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no access token, ignored",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:            context.Background(),
+				externalUserID: "externalID",
+				token:          &oauth2.Token{},
+			},
+		},
+		{
+			name: "link not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "externalID"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				externalUserID: "externalID",
+				token:          &oauth2.Token{AccessToken: "accessToken"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "set, previous refresh token kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								"externalID",
+								&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("oldAccessToken")},
+								"Bearer",
+								&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("refreshToken")},
+								expiry,
+							),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp1",
+							"externalID",
+							&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("accessToken")},
+							"Bearer",
+							&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("refreshToken")},
+							expiry.Add(time.Hour),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				externalUserID: "externalID",
+				token:          &oauth2.Token{AccessToken: "accessToken", TokenType: "Bearer", Expiry: expiry.Add(time.Hour)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				idpTokenEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.SetUserIDPLinkTokens(tt.args.ctx, "user1", "org1", "idp1", tt.args.externalUserID, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommandSide_GetUserIDPLinkAccessToken(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC()
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type res struct {
+		want *IDPLinkAccessToken
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "client not allowed on idp",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "client not listed on idp",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPTokenExchangeClientsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", []string{"client2"}),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "no tokens",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPTokenExchangeClientsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", []string{"client1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "link removed, no tokens",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPTokenExchangeClientsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", []string{"client1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								"externalID",
+								&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("accessToken")},
+								"Bearer",
+								nil,
+								expiry,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "externalID"),
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "expired without refresh token, removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPTokenExchangeClientsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", []string{"client1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								"externalID",
+								&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("accessToken")},
+								"Bearer",
+								nil,
+								time.Now().Add(-time.Hour),
+							),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "externalID"),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "valid token, retrieval audited",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPTokenExchangeClientsSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", []string{"client1"}),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "name", "externalID"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp1",
+								"externalID",
+								&crypto.CryptoValue{CryptoType: crypto.TypeEncryption, Algorithm: "enc", KeyID: "id", Crypted: []byte("accessToken")},
+								"Bearer",
+								nil,
+								expiry,
+							),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokenRetrievedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp1", "externalID", "client1", false),
+					),
+				),
+			},
+			res: res{
+				want: &IDPLinkAccessToken{
+					AccessToken: "accessToken",
+					TokenType:   "Bearer",
+					Expiry:      expiry,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				idpTokenEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.GetUserIDPLinkAccessToken(context.Background(), "user1", "org1", "idp1", "client1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want.AccessToken, got.AccessToken)
+				assert.Equal(t, tt.res.want.TokenType, got.TokenType)
+				assert.WithinDuration(t, tt.res.want.Expiry, got.Expiry, time.Second)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WarnInactiveUser records that the active user will be deactivated at the deactivationDate
+// because of missing authentications, which notifies the user.
+// The user is only warned once per inactivity period, which starts with the lastActivity.
+func (c *Commands) WarnInactiveUser(ctx context.Context, userID, resourceOwner string, lastActivity, deactivationDate time.Time) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ina1u", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ina2n", "Errors.User.NotFound")
+	}
+	if existingUser.UserState != domain.UserStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ina3s", "Errors.User.NotActive")
+	}
+	warning := NewUserInactivityWarningWriteModel(userID, existingUser.ResourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, warning); err != nil {
+		return nil, err
+	}
+	if warning.AlreadyWarned(lastActivity) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ina6w", "Errors.User.Inactivity.AlreadyWarned")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewHumanInactivityWarnedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), lastActivity, deactivationDate),
+	)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingUser, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
+
+func (c *Commands) InactivityWarningSent(ctx context.Context, orgID, userID string) (err error) {
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ina4u", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ina5n", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanInactivityWarningSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+	return err
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserInactivityWarningWriteModel reduces the last inactivity warning of the user.
+type UserInactivityWarningWriteModel struct {
+	eventstore.WriteModel
+
+	Warned bool
+	// LastActivity is the last activity the user was warned for
+	LastActivity time.Time
+}
+
+func NewUserInactivityWarningWriteModel(userID, resourceOwner string) *UserInactivityWarningWriteModel {
+	return &UserInactivityWarningWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *UserInactivityWarningWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*user.HumanInactivityWarnedEvent); ok {
+			wm.Warned = true
+			wm.LastActivity = e.LastActivity
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserInactivityWarningWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanInactivityWarnedType).
+		Builder()
+}
+
+// AlreadyWarned returns true if the user was already warned in the inactivity period starting with the last activity.
+func (wm *UserInactivityWarningWriteModel) AlreadyWarned(lastActivity time.Time) bool {
+	return wm.Warned && !lastActivity.After(wm.LastActivity)
+}
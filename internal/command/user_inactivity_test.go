@@ -0,0 +1,181 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_WarnInactiveUser(t *testing.T) {
+	lastActivity := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deactivationDate := lastActivity.Add(90 * 24 * time.Hour)
+	humanAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username",
+				"firstname",
+				"lastname",
+				"nickname",
+				"displayname",
+				language.German,
+				domain.GenderUnspecified,
+				"email@test.ch",
+				true,
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID string
+		orgID  string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user inactive, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						humanAddedEvent(),
+						eventFromEventPusher(
+							user.NewUserDeactivatedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already warned in inactivity period, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						humanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanInactivityWarnedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								lastActivity,
+								deactivationDate,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "warn user, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						humanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanInactivityWarnedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								lastActivity.Add(-90*24*time.Hour),
+								lastActivity,
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanInactivityWarnedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							lastActivity,
+							deactivationDate,
+						),
+					),
+				),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := r.WarnInactiveUser(context.Background(), tt.args.userID, tt.args.orgID, lastActivity, deactivationDate)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -80,6 +80,12 @@ func (wm *MachineWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotVersion implements [eventstore.SnapshotReducer].
+// It must be increased if the reduce logic changes.
+func (wm *MachineWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
 func (wm *MachineWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
@@ -15,6 +15,8 @@ type UserWriteModel struct {
 	IDPLinks  []*domain.UserIDPLink
 	UserState domain.UserState
 	UserType  domain.UserType
+	// ImportExternalID is the ID of the user in the system it was imported from
+	ImportExternalID string
 }
 
 func NewUserWriteModel(userID, resourceOwner string) *UserWriteModel {
@@ -82,6 +84,8 @@ func (wm *UserWriteModel) Reduce() error {
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateActive
 			}
+		case *user.UserImportedEvent:
+			wm.ImportExternalID = e.ExternalID
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 		}
@@ -109,6 +113,7 @@ func (wm *UserWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.UserUnlockedType,
 			user.UserDeactivatedType,
 			user.UserReactivatedType,
+			user.UserImportedType,
 			user.UserRemovedType,
 			user.UserV1AddedType,
 			user.UserV1RegisteredType,
@@ -20,8 +20,32 @@ func passwordComplexityPolicyWriteModel(ctx context.Context, filter preparation.
 	return nil, zerrors.ThrowInternal(nil, "USER-uQ96e", "Errors.Internal")
 }
 
+// customPasswordComplexityPolicy returns the policy of the organization
+// or the one inherited from the nearest ancestor defining a policy.
 func customPasswordComplexityPolicy(ctx context.Context, filter preparation.FilterToQueryReducer) (*PasswordComplexityPolicyWriteModel, error) {
-	policy := NewOrgPasswordComplexityPolicyWriteModel(authz.GetCtxData(ctx).OrgID)
+	orgID := authz.GetCtxData(ctx).OrgID
+	wm, err := orgPasswordComplexityPolicy(ctx, filter, orgID)
+	if err != nil || wm != nil && wm.State.Exists() {
+		return wm, err
+	}
+	ancestors, err := orgAncestorIDs(ctx, filter, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ancestorID := range ancestors {
+		ancestorPolicy, err := orgPasswordComplexityPolicy(ctx, filter, ancestorID)
+		if err != nil {
+			return nil, err
+		}
+		if ancestorPolicy != nil && ancestorPolicy.State.Exists() {
+			return ancestorPolicy, nil
+		}
+	}
+	return wm, nil
+}
+
+func orgPasswordComplexityPolicy(ctx context.Context, filter preparation.FilterToQueryReducer, orgID string) (*PasswordComplexityPolicyWriteModel, error) {
+	policy := NewOrgPasswordComplexityPolicyWriteModel(orgID)
 	events, err := filter(ctx, policy.Query())
 	if err != nil {
 		return nil, err
@@ -221,10 +221,60 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "inherited from parent",
+			args: args{
+				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							org.NewOrgParentSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "parent1"),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("parent1").Aggregate, "parent"),
+						}, nil
+					}).
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							org.NewPasswordComplexityPolicyAddedEvent(
+								context.Background(),
+								&org.NewAggregate("parent1").Aggregate,
+								12,
+								true,
+								true,
+								true,
+								true,
+							),
+						}, nil
+					}).
+					Filter(),
+			},
+			want: &PasswordComplexityPolicyWriteModel{
+				WriteModel: eventstore.WriteModel{
+					AggregateID:   "parent1",
+					ResourceOwner: "parent1",
+					Events:        []eventstore.Event{},
+				},
+				MinLength:    12,
+				HasLowercase: true,
+				HasUppercase: true,
+				HasNumber:    true,
+				HasSymbol:    true,
+				State:        domain.PolicyStateActive,
+			},
+			wantErr: false,
+		},
 		{
 			name: "err from filter default",
 			args: args{
 				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).
 					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
 						return nil, nil
 					}).
@@ -240,6 +290,9 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 			name: "default found",
 			args: args{
 				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return nil, nil
+					}).
 					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
 						return nil, nil
 					}).
@@ -43,6 +43,8 @@ type ChangeUserSchema struct {
 	Type                   *string
 	Schema                 json.RawMessage
 	PossibleAuthenticators []domain.AuthenticatorType
+	// Migration transforms the data of the existing users to the new revision of the schema.
+	Migration *domain_schema.Migration
 }
 
 func (s *ChangeUserSchema) Valid() error {
@@ -60,7 +62,7 @@ func (s *ChangeUserSchema) Valid() error {
 			return zerrors.ThrowInvalidArgument(nil, "COMMA-WF4hg", "Errors.UserSchema.Authenticator.Invalid")
 		}
 	}
-	return nil
+	return s.Migration.Valid()
 }
 
 func (c *Commands) CreateUserSchema(ctx context.Context, userSchema *CreateUserSchema) error {
@@ -101,13 +103,17 @@ func (c *Commands) ChangeUserSchema(ctx context.Context, userSchema *ChangeUserS
 	if writeModel.State != domain.UserSchemaStateActive {
 		return zerrors.ThrowPreconditionFailed(nil, "COMMA-HB3e1", "Errors.UserSchema.NotActive")
 	}
-	updatedEvent := writeModel.NewUpdatedEvent(
+	updatedEvent, err := writeModel.NewUpdatedEvent(
 		ctx,
 		UserSchemaAggregateFromWriteModel(&writeModel.WriteModel),
 		userSchema.Type,
 		userSchema.Schema,
 		userSchema.PossibleAuthenticators,
+		userSchema.Migration,
 	)
+	if err != nil {
+		return err
+	}
 	if updatedEvent == nil {
 		userSchema.Details = writeModelToObjectDetails(&writeModel.WriteModel)
 		return nil
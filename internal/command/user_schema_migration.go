@@ -0,0 +1,177 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
+	"github.com/zitadel/zitadel/internal/repository/user/schema"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// StartUserSchemaMigration starts the revalidation and migration of all users of the schema to its current revision.
+// The users are migrated asynchronously, the progress is reported on the schema.
+func (c *Commands) StartUserSchemaMigration(ctx context.Context, id, resourceOwner string) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMA-Mig2i", "Errors.IDMissing")
+	}
+	writeModel, err := c.getSchemaWriteModelByID(ctx, resourceOwner, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.UserSchemaStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig3a", "Errors.UserSchema.NotActive")
+	}
+	if writeModel.MigrationRunning {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig4r", "Errors.UserSchema.Migration.AlreadyRunning")
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel,
+		schema.NewMigrationStartedEvent(ctx, UserSchemaAggregateFromWriteModel(&writeModel.WriteModel), writeModel.SchemaRevision),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// MigrateSchemaUsers revalidates and migrates the users to the current revision of the schema.
+// Users which could not be migrated are reported as failures and stay on their revision.
+// The result of the batch is reported as progress of the running migration.
+func (c *Commands) MigrateSchemaUsers(ctx context.Context, schemaID, resourceOwner string, userIDs []string) (*domain.ObjectDetails, error) {
+	if schemaID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMA-Mig5i", "Errors.IDMissing")
+	}
+	schemaWriteModel, err := c.getSchemaWriteModelByID(ctx, resourceOwner, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if !schemaWriteModel.MigrationRunning {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig6n", "Errors.UserSchema.Migration.NotRunning")
+	}
+	var (
+		migrated, valid uint64
+		failures        []*schema.MigrationFailure
+		lastUserID      string
+	)
+	for _, userID := range userIDs {
+		lastUserID = userID
+		result, err := c.migrateSchemaUser(ctx, schemaWriteModel, userID)
+		if err != nil {
+			failures = append(failures, &schema.MigrationFailure{UserID: userID, Reason: migrationFailureReason(err)})
+			continue
+		}
+		switch result {
+		case schemaUserMigrated:
+			migrated++
+		case schemaUserValid:
+			valid++
+		case schemaUserSkipped:
+		}
+	}
+	if err := c.pushAppendAndReduce(ctx, schemaWriteModel,
+		schema.NewMigrationProgressedEvent(ctx,
+			UserSchemaAggregateFromWriteModel(&schemaWriteModel.WriteModel),
+			migrated, valid, failures, lastUserID,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&schemaWriteModel.WriteModel), nil
+}
+
+// FinishUserSchemaMigration marks the running migration of the schema as done.
+func (c *Commands) FinishUserSchemaMigration(ctx context.Context, schemaID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if schemaID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMA-Mig7i", "Errors.IDMissing")
+	}
+	writeModel, err := c.getSchemaWriteModelByID(ctx, resourceOwner, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.MigrationRunning {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel,
+		schema.NewMigrationDoneEvent(ctx, UserSchemaAggregateFromWriteModel(&writeModel.WriteModel)),
+	); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+type schemaUserMigrationResult int
+
+const (
+	schemaUserSkipped schemaUserMigrationResult = iota
+	schemaUserValid
+	schemaUserMigrated
+)
+
+func (c *Commands) migrateSchemaUser(ctx context.Context, schemaWriteModel *UserSchemaWriteModel, userID string) (schemaUserMigrationResult, error) {
+	writeModel := NewUserV3WriteModel("", userID, nil)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return schemaUserSkipped, err
+	}
+	// the user might have been deleted or moved to another schema in the meantime
+	if !writeModel.Exists() || writeModel.SchemaID != schemaWriteModel.AggregateID {
+		return schemaUserSkipped, nil
+	}
+	data, err := schemaWriteModel.MigrateData(writeModel.Data, writeModel.SchemaRevision)
+	if err != nil {
+		return schemaUserSkipped, err
+	}
+	if err := validateSchemaUserData(schemaWriteModel.Schema, data); err != nil {
+		return schemaUserSkipped, err
+	}
+	changes := make([]schemauser.Changes, 0, 3)
+	if writeModel.SchemaRevision != schemaWriteModel.SchemaRevision {
+		changes = append(changes, schemauser.ChangeSchemaRevision(schemaWriteModel.SchemaRevision))
+	}
+	if !bytes.Equal(writeModel.Data, data) {
+		changes = append(changes, schemauser.ChangeData(data))
+	}
+	if len(changes) == 0 {
+		return schemaUserValid, nil
+	}
+	indexChange, err := writeModel.indexChange(schemaWriteModel, schemaWriteModel, data)
+	if err != nil {
+		return schemaUserSkipped, err
+	}
+	if indexChange != nil {
+		changes = append(changes, indexChange)
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel,
+		schemauser.NewUpdatedEvent(ctx, UserV3AggregateFromWriteModel(&writeModel.WriteModel), changes),
+	); err != nil {
+		return schemaUserSkipped, err
+	}
+	return schemaUserMigrated, nil
+}
+
+// validateSchemaUserData validates the stored data against the schema regardless of the field permissions.
+func validateSchemaUserData(userSchema, data json.RawMessage) error {
+	s, err := domain_schema.NewSchema(domain_schema.RoleSystem, bytes.NewReader(userSchema))
+	if err != nil {
+		return err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return zerrors.ThrowInvalidArgument(nil, "COMMA-Mig8u", "Errors.User.Invalid")
+	}
+	if err := s.Validate(v); err != nil {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig9d", "Errors.UserSchema.Data.Invalid")
+	}
+	return nil
+}
+
+// migrationFailureReason returns the i18n key of the error if possible.
+func migrationFailureReason(err error) string {
+	zitadelErr := new(zerrors.ZitadelError)
+	if errors.As(err, &zitadelErr) {
+		return zitadelErr.GetMessage()
+	}
+	return err.Error()
+}
@@ -0,0 +1,415 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user/schema"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func migrationSchemaEvents() []eventstore.Event {
+	return []eventstore.Event{
+		eventFromEventPusher(
+			schema.NewCreatedEvent(
+				context.Background(),
+				&schema.NewAggregate("id1", "instanceID").Aggregate,
+				"type",
+				json.RawMessage(`{
+					"$schema": "urn:zitadel:schema:v1",
+					"type": "object",
+					"properties": {
+						"name": {
+							"type": "string"
+						}
+					}
+				}`),
+				[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+			),
+		),
+		eventFromEventPusher(
+			schema.NewUpdatedEvent(
+				context.Background(),
+				&schema.NewAggregate("id1", "instanceID").Aggregate,
+				[]schema.Changes{
+					schema.IncreaseRevision(1),
+					schema.ChangeSchema(json.RawMessage(`{
+						"$schema": "urn:zitadel:schema:v1",
+						"type": "object",
+						"properties": {
+							"fullName": {
+								"type": "string"
+							}
+						},
+						"required": ["fullName"]
+					}`)),
+					schema.SetMigration(&domain_schema.Migration{
+						Renames: []*domain_schema.MigrationRename{{From: "/name", To: "/fullName"}},
+					}),
+				},
+			),
+		),
+	}
+}
+
+func TestCommands_StartUserSchemaMigration(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing id, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMA-Mig2i", "Errors.IDMissing"),
+			},
+		},
+		{
+			"not active / exists, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				id:  "id1",
+			},
+			res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig3a", "Errors.UserSchema.NotActive"),
+			},
+		},
+		{
+			"already running, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(migrationSchemaEvents(),
+							eventFromEventPusher(
+								schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+							),
+						)...,
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				id:  "id1",
+			},
+			res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig4r", "Errors.UserSchema.Migration.AlreadyRunning"),
+			},
+		},
+		{
+			"start, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						migrationSchemaEvents()...,
+					),
+					expectPush(
+						schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				id:  "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
+		{
+			"restart after done, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(migrationSchemaEvents(),
+							eventFromEventPusher(
+								schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+							),
+							eventFromEventPusher(
+								schema.NewMigrationDoneEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate),
+							),
+						)...,
+					),
+					expectPush(
+						schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				id:  "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.StartUserSchemaMigration(tt.args.ctx, tt.args.id, "")
+			assert.ErrorIs(t, err, tt.res.err)
+			assertObjectDetails(t, tt.res.details, got)
+		})
+	}
+}
+
+func TestCommands_MigrateSchemaUsers(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		schemaID string
+		userIDs  []string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing id, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMA-Mig5i", "Errors.IDMissing"),
+			},
+		},
+		{
+			"not running, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						migrationSchemaEvents()...,
+					),
+				),
+			},
+			args{
+				ctx:      authz.NewMockContext("instanceID", "", ""),
+				schemaID: "id1",
+				userIDs:  []string{"user1"},
+			},
+			res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig6n", "Errors.UserSchema.Migration.NotRunning"),
+			},
+		},
+		{
+			"migrate users, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(migrationSchemaEvents(),
+							eventFromEventPusher(
+								schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+							),
+						)...,
+					),
+					// user1 is migrated
+					expectFilter(
+						eventFromEventPusher(
+							schemauser.NewCreatedEvent(
+								context.Background(),
+								&schemauser.NewAggregate("user1", "org1").Aggregate,
+								"id1", 1, json.RawMessage(`{"name": "gigi"}`),
+							),
+						),
+					),
+					expectPush(
+						schemauser.NewUpdatedEvent(
+							context.Background(),
+							&schemauser.NewAggregate("user1", "org1").Aggregate,
+							[]schemauser.Changes{
+								schemauser.ChangeSchemaRevision(2),
+								schemauser.ChangeData(json.RawMessage(`{"fullName":"gigi"}`)),
+							},
+						),
+					),
+					// user2 cannot be migrated
+					expectFilter(
+						eventFromEventPusher(
+							schemauser.NewCreatedEvent(
+								context.Background(),
+								&schemauser.NewAggregate("user2", "org1").Aggregate,
+								"id1", 1, json.RawMessage(`{"nickName": "gigi"}`),
+							),
+						),
+					),
+					// user3 is already valid
+					expectFilter(
+						eventFromEventPusher(
+							schemauser.NewCreatedEvent(
+								context.Background(),
+								&schemauser.NewAggregate("user3", "org1").Aggregate,
+								"id1", 2, json.RawMessage(`{"fullName": "gigi"}`),
+							),
+						),
+					),
+					// user4 does not exist anymore
+					expectFilter(),
+					expectPush(
+						schema.NewMigrationProgressedEvent(context.Background(),
+							&schema.NewAggregate("id1", "instanceID").Aggregate,
+							1, 1,
+							[]*schema.MigrationFailure{{UserID: "user2", Reason: "Errors.UserSchema.Data.Invalid"}},
+							"user4",
+						),
+					),
+				),
+			},
+			args{
+				ctx:      authz.NewMockContext("instanceID", "", ""),
+				schemaID: "id1",
+				userIDs:  []string{"user1", "user2", "user3", "user4"},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.MigrateSchemaUsers(tt.args.ctx, tt.args.schemaID, "", tt.args.userIDs)
+			assert.ErrorIs(t, err, tt.res.err)
+			assertObjectDetails(t, tt.res.details, got)
+		})
+	}
+}
+
+func TestCommands_FinishUserSchemaMigration(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		schemaID string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"missing id, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMA-Mig7i", "Errors.IDMissing"),
+			},
+		},
+		{
+			"not running, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						migrationSchemaEvents()...,
+					),
+				),
+			},
+			args{
+				ctx:      authz.NewMockContext("instanceID", "", ""),
+				schemaID: "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
+		{
+			"finish, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						append(migrationSchemaEvents(),
+							eventFromEventPusher(
+								schema.NewMigrationStartedEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate, 2),
+							),
+						)...,
+					),
+					expectPush(
+						schema.NewMigrationDoneEvent(context.Background(), &schema.NewAggregate("id1", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      authz.NewMockContext("instanceID", "", ""),
+				schemaID: "id1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.FinishUserSchemaMigration(tt.args.ctx, tt.args.schemaID, "")
+			assert.ErrorIs(t, err, tt.res.err)
+			assertObjectDetails(t, tt.res.details, got)
+		})
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/user/schema"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type UserSchemaWriteModel struct {
@@ -20,6 +23,13 @@ type UserSchemaWriteModel struct {
 	PossibleAuthenticators []domain.AuthenticatorType
 	State                  domain.UserSchemaState
 	SchemaRevision         uint64
+	// Migrations contains the migration of the data from the previous revision by revision
+	Migrations map[uint64]*domain_schema.Migration
+	// Schemas contains the schema by revision
+	Schemas map[uint64]json.RawMessage
+
+	MigrationRunning  bool
+	MigrationRevision uint64
 }
 
 func NewUserSchemaWriteModel(resourceOwner, schemaID string) *UserSchemaWriteModel {
@@ -40,6 +50,7 @@ func (wm *UserSchemaWriteModel) Reduce() error {
 			wm.PossibleAuthenticators = e.PossibleAuthenticators
 			wm.State = domain.UserSchemaStateActive
 			wm.SchemaRevision = 1
+			wm.Schemas = map[uint64]json.RawMessage{1: e.Schema}
 		case *schema.UpdatedEvent:
 			if e.SchemaType != nil {
 				wm.SchemaType = *e.SchemaType
@@ -49,10 +60,25 @@ func (wm *UserSchemaWriteModel) Reduce() error {
 			}
 			if len(e.Schema) > 0 {
 				wm.Schema = e.Schema
+				if wm.Schemas == nil {
+					wm.Schemas = make(map[uint64]json.RawMessage)
+				}
+				wm.Schemas[wm.SchemaRevision] = e.Schema
 			}
 			if len(e.PossibleAuthenticators) > 0 {
 				wm.PossibleAuthenticators = e.PossibleAuthenticators
 			}
+			if e.SchemaRevision != nil && !e.Migration.IsEmpty() {
+				if wm.Migrations == nil {
+					wm.Migrations = make(map[uint64]*domain_schema.Migration)
+				}
+				wm.Migrations[*e.SchemaRevision] = e.Migration
+			}
+		case *schema.MigrationStartedEvent:
+			wm.MigrationRunning = true
+			wm.MigrationRevision = e.SchemaRevision
+		case *schema.MigrationDoneEvent:
+			wm.MigrationRunning = false
 		case *schema.DeactivatedEvent:
 			wm.State = domain.UserSchemaStateInactive
 		case *schema.ReactivatedEvent:
@@ -76,6 +102,8 @@ func (wm *UserSchemaWriteModel) Query() *eventstore.SearchQueryBuilder {
 			schema.DeactivatedType,
 			schema.ReactivatedType,
 			schema.DeletedType,
+			schema.MigrationStartedType,
+			schema.MigrationDoneType,
 		)
 
 	return query.Builder()
@@ -86,7 +114,8 @@ func (wm *UserSchemaWriteModel) NewUpdatedEvent(
 	schemaType *string,
 	userSchema json.RawMessage,
 	possibleAuthenticators []domain.AuthenticatorType,
-) *schema.UpdatedEvent {
+	migration *domain_schema.Migration,
+) (*schema.UpdatedEvent, error) {
 	changes := make([]schema.Changes, 0)
 	if schemaType != nil && wm.SchemaType != *schemaType {
 		changes = append(changes, schema.ChangeSchemaType(wm.SchemaType, *schemaType))
@@ -95,14 +124,56 @@ func (wm *UserSchemaWriteModel) NewUpdatedEvent(
 		changes = append(changes, schema.ChangeSchema(userSchema))
 		// change revision if the content of the schema changed
 		changes = append(changes, schema.IncreaseRevision(wm.SchemaRevision))
+		if !migration.IsEmpty() {
+			changes = append(changes, schema.SetMigration(migration))
+		}
+	} else if !migration.IsEmpty() {
+		// a migration is always attached to a new revision
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig1s", "Errors.UserSchema.Migration.SchemaNotChanged")
 	}
 	if len(possibleAuthenticators) > 0 && slices.Compare(wm.PossibleAuthenticators, possibleAuthenticators) != 0 {
 		changes = append(changes, schema.ChangePossibleAuthenticators(possibleAuthenticators))
 	}
 	if len(changes) == 0 {
-		return nil
+		return nil, nil
+	}
+	return schema.NewUpdatedEvent(ctx, agg, changes), nil
+}
+
+// MigrateData migrates the data of a user from the revision to the current revision of the schema.
+// Revisions without a migration do not change the data.
+func (wm *UserSchemaWriteModel) MigrateData(data json.RawMessage, revision uint64) (_ json.RawMessage, err error) {
+	for rev := revision + 1; rev <= wm.SchemaRevision; rev++ {
+		migration, ok := wm.Migrations[rev]
+		if !ok {
+			continue
+		}
+		if data, err = migration.Apply(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// IndexedValues returns the values of the indexed fields of the data based on the revision of the schema.
+// If the revision is unknown, the current schema is used.
+func (wm *UserSchemaWriteModel) IndexedValues(revision uint64, data json.RawMessage) (*schemauser.IndexedValues, error) {
+	userSchema, ok := wm.Schemas[revision]
+	if !ok {
+		userSchema = wm.Schema
+	}
+	fields, err := domain_schema.IndexedFields(userSchema)
+	if err != nil {
+		return nil, err
+	}
+	values, err := domain_schema.IndexedValues(fields, data)
+	if err != nil {
+		return nil, err
 	}
-	return schema.NewUpdatedEvent(ctx, agg, changes)
+	return &schemauser.IndexedValues{
+		SchemaID: wm.AggregateID,
+		Values:   values,
+	}, nil
 }
 
 func UserSchemaAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/id/mock"
@@ -615,6 +616,102 @@ func TestCommands_ChangeUserSchema(t *testing.T) {
 				},
 			},
 		},
+		{
+			"invalid migration, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				userSchema: &ChangeUserSchema{
+					ID:     "id1",
+					Schema: json.RawMessage(`{}`),
+					Migration: &domain_schema.Migration{
+						Renames: []*domain_schema.MigrationRename{{From: "name", To: "/fullName"}},
+					},
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig1r", "Errors.UserSchema.Migration.Invalid"),
+			},
+		},
+		{
+			"migration without schema change, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("id1", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				userSchema: &ChangeUserSchema{
+					ID:     "id1",
+					Schema: json.RawMessage(`{}`),
+					Migration: &domain_schema.Migration{
+						Renames: []*domain_schema.MigrationRename{{From: "/name", To: "/fullName"}},
+					},
+				},
+			},
+			res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMA-Mig1s", "Errors.UserSchema.Migration.SchemaNotChanged"),
+			},
+		},
+		{
+			"update schema with migration",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("id1", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+					expectPush(
+						schema.NewUpdatedEvent(
+							context.Background(),
+							&schema.NewAggregate("id1", "instanceID").Aggregate,
+							[]schema.Changes{
+								schema.IncreaseRevision(1),
+								schema.ChangeSchema(json.RawMessage(`{"type": "object"}`)),
+								schema.SetMigration(&domain_schema.Migration{
+									Renames: []*domain_schema.MigrationRename{{From: "/name", To: "/fullName"}},
+								}),
+							},
+						),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				userSchema: &ChangeUserSchema{
+					ID:     "id1",
+					Schema: json.RawMessage(`{"type": "object"}`),
+					Migration: &domain_schema.Migration{
+						Renames: []*domain_schema.MigrationRename{{From: "/name", To: "/fullName"}},
+					},
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instanceID",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SecurityNotificationSent records that the user was notified about a security relevant change of their account,
+// so that the notification is not sent again.
+// The sessionID is only set if the notification was triggered by a session.
+func (c *Commands) SecurityNotificationSent(ctx context.Context, orgID, userID, messageType, sessionID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sn1u0", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, orgID)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Sn2n4", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanSecurityNotificationSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), messageType, sessionID))
+	return err
+}
+
+// SignInDeviceAdded records the device the user signed in with through a session, if it is not known yet.
+func (c *Commands) SignInDeviceAdded(ctx context.Context, orgID, userID, deviceID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sd1u0", "Errors.User.UserIDMissing")
+	}
+	if deviceID == "" {
+		return nil
+	}
+	wm := NewUserSignInDevicesWriteModel(userID, orgID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return err
+	}
+	if wm.Known(deviceID) {
+		return nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanSignInDeviceAddedEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel), deviceID))
+	return err
+}
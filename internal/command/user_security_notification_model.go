@@ -0,0 +1,47 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserSignInDevicesWriteModel collects the devices the user signed in with through a session.
+type UserSignInDevicesWriteModel struct {
+	eventstore.WriteModel
+
+	DeviceIDs []string
+}
+
+func NewUserSignInDevicesWriteModel(userID, resourceOwner string) *UserSignInDevicesWriteModel {
+	return &UserSignInDevicesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *UserSignInDevicesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*user.HumanSignInDeviceAddedEvent); ok {
+			wm.DeviceIDs = append(wm.DeviceIDs, e.DeviceID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserSignInDevicesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanSignInDeviceAddedType).
+		Builder()
+}
+
+func (wm *UserSignInDevicesWriteModel) Known(deviceID string) bool {
+	return slices.Contains(wm.DeviceIDs, deviceID)
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_SecurityNotificationSent(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID      string
+		orgID       string
+		messageType string
+		sessionID   string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID:       "org1",
+				messageType: domain.MFAAddedMessageType,
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:      "user1",
+				orgID:       "org1",
+				messageType: domain.MFAAddedMessageType,
+			},
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "notification sent, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanSecurityNotificationSentEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							domain.NewDeviceSignInMessageType,
+							"session1",
+						),
+					),
+				),
+			},
+			args: args{
+				userID:      "user1",
+				orgID:       "org1",
+				messageType: domain.NewDeviceSignInMessageType,
+				sessionID:   "session1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := r.SecurityNotificationSent(context.Background(), tt.args.orgID, tt.args.userID, tt.args.messageType, tt.args.sessionID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+		})
+	}
+}
+
+func TestCommands_SignInDeviceAdded(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID   string
+		orgID    string
+		deviceID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr func(error) bool
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID:    "org1",
+				deviceID: "device1",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "no device, ok",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "user1",
+				orgID:  "org1",
+			},
+		},
+		{
+			name: "device known, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanSignInDeviceAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"device1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:   "user1",
+				orgID:    "org1",
+				deviceID: "device1",
+			},
+		},
+		{
+			name: "device added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanSignInDeviceAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"device1",
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanSignInDeviceAddedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"device2",
+						),
+					),
+				),
+			},
+			args: args{
+				userID:   "user1",
+				orgID:    "org1",
+				deviceID: "device2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := r.SignInDeviceAdded(context.Background(), tt.args.orgID, tt.args.userID, tt.args.deviceID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "got wrong err: %v", err)
+		})
+	}
+}
@@ -1230,6 +1230,7 @@ func TestCommandSide_RemoveUser(t *testing.T) {
 							&user.NewAggregate("user1", "org1").Aggregate,
 							"username",
 							nil,
+							"",
 							true,
 						),
 					),
@@ -1296,6 +1297,7 @@ func TestCommandSide_RemoveUser(t *testing.T) {
 									ExternalUserID: "externalUserID",
 								},
 							},
+							"",
 							true,
 						),
 					),
@@ -1349,6 +1351,7 @@ func TestCommandSide_RemoveUser(t *testing.T) {
 							&user.NewAggregate("user1", "org1").Aggregate,
 							"username",
 							nil,
+							"",
 							true,
 						),
 						instance.NewMemberCascadeRemovedEvent(context.Background(),
@@ -1788,6 +1791,7 @@ func TestExistsUser(t *testing.T) {
 							&user.NewAggregate("removed", "ro").Aggregate,
 							"userName",
 							nil,
+							"",
 							true,
 						),
 					}, nil
@@ -104,18 +104,38 @@ func (h *ChangeHuman) Changed() bool {
 }
 
 func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human *AddHuman, allowInitMail bool, alg crypto.EncryptionAlgorithm) (err error) {
+	existingHuman, cmds, err := c.addUserHumanCommands(ctx, resourceOwner, human, allowInitMail, alg)
+	if err != nil {
+		return err
+	}
+	if len(cmds) == 0 {
+		human.Details = writeModelToObjectDetails(&existingHuman.WriteModel)
+		return nil
+	}
+
+	err = c.pushAppendAndReduce(ctx, existingHuman, cmds...)
+	if err != nil {
+		return err
+	}
+	human.Details = writeModelToObjectDetails(&existingHuman.WriteModel)
+	return nil
+}
+
+// addUserHumanCommands validates the human and returns the commands to create it without pushing them,
+// so they can also be pushed together with the commands of other users (e.g. on bulk imports)
+func (c *Commands) addUserHumanCommands(ctx context.Context, resourceOwner string, human *AddHuman, allowInitMail bool, alg crypto.EncryptionAlgorithm) (_ *UserV2WriteModel, _ []eventstore.Command, err error) {
 	if resourceOwner == "" {
-		return zerrors.ThrowInvalidArgument(nil, "COMMA-095xh8fll1", "Errors.Internal")
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMA-095xh8fll1", "Errors.Internal")
 	}
 
 	if err := human.Validate(c.userPasswordHasher); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	if human.ID == "" {
 		human.ID, err = c.idGenerator.Next()
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
@@ -125,15 +145,15 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 		human.ID,
 	)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	if isUserStateExists(existingHuman.UserState) {
-		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-7yiox1isql", "Errors.User.AlreadyExisting")
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-7yiox1isql", "Errors.User.AlreadyExisting")
 	}
 	// check for permission to create user on resourceOwner
 	if !human.Register {
 		if err := c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, human.ID); err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 	// add resourceowner for the events with the aggregate
@@ -141,11 +161,11 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 
 	domainPolicy, err := c.domainPolicyWriteModel(ctx, resourceOwner)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	if err = c.userValidateDomain(ctx, resourceOwner, human.Username, domainPolicy.UserLoginMustBeDomain); err != nil {
-		return err
+		return nil, nil, err
 	}
 	var createCmd humanCreationCommand
 	if human.Register {
@@ -186,7 +206,7 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 	// separated to change when old user logic is not used anymore
 	filter := c.eventstore.Filter //nolint:staticcheck
 	if err := addHumanCommandPassword(ctx, filter, createCmd, human, c.userPasswordHasher); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	cmds := make([]eventstore.Command, 0, 3)
@@ -194,12 +214,12 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 
 	cmds, err = c.addHumanCommandEmail(ctx, filter, cmds, existingHuman.Aggregate(), human, alg, allowInitMail)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	cmds, err = c.addHumanCommandPhone(ctx, filter, cmds, existingHuman.Aggregate(), human, alg)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	for _, metadataEntry := range human.Metadata {
@@ -213,7 +233,7 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 	for _, link := range human.Links {
 		cmd, err := addLink(ctx, filter, existingHuman.Aggregate(), link)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		cmds = append(cmds, cmd)
 	}
@@ -221,7 +241,7 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 	if human.TOTPSecret != "" {
 		encryptedSecret, err := crypto.Encrypt([]byte(human.TOTPSecret), c.multifactors.OTP.CryptoMFA)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		cmds = append(cmds,
 			user.NewHumanOTPAddedEvent(ctx, &existingHuman.Aggregate().Aggregate, encryptedSecret),
@@ -229,17 +249,7 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 		)
 	}
 
-	if len(cmds) == 0 {
-		human.Details = writeModelToObjectDetails(&existingHuman.WriteModel)
-		return nil
-	}
-
-	err = c.pushAppendAndReduce(ctx, existingHuman, cmds...)
-	if err != nil {
-		return err
-	}
-	human.Details = writeModelToObjectDetails(&existingHuman.WriteModel)
-	return nil
+	return existingHuman, cmds, nil
 }
 
 func (c *Commands) ChangeUserHuman(ctx context.Context, human *ChangeHuman, alg crypto.EncryptionAlgorithm) (err error) {
@@ -200,6 +200,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 					),
 					expectFilter(),
 					expectFilter(),
+					expectFilter(),
 				),
 				checkPermission: newMockPermissionCheckAllowed(),
 			},
@@ -0,0 +1,204 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ImportUser is a single record of a bulk import of users
+type ImportUser struct {
+	// ExternalID is the ID of the user in the system it is imported from.
+	// It is used to skip users, which have already been imported into the organization.
+	ExternalID string
+	Human      *AddHuman
+	Grants     []*ImportUserGrant
+}
+
+type ImportUserGrant struct {
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+// ImportUserResult reports the outcome of the import of a single record
+type ImportUserResult struct {
+	ExternalID string
+	UserID     string
+	// Skipped is true if the user was already imported previously
+	Skipped bool
+	// Err is set if the record could not be imported.
+	// The user and its grants are imported together, so there are no partially imported records.
+	Err error
+}
+
+// ImportUsers imports a batch of users into the organization.
+// The events of all users and their grants are pushed together, failures are reported per record.
+// Users, which have already been imported with the same external ID, are skipped,
+// so an interrupted import can safely be repeated.
+func (c *Commands) ImportUsers(ctx context.Context, resourceOwner string, users []*ImportUser, alg crypto.EncryptionAlgorithm) (_ []*ImportUserResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Im1p2", "Errors.ResourceOwnerMissing")
+	}
+	externalIDs := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.ExternalID != "" {
+			externalIDs = append(externalIDs, u.ExternalID)
+		}
+	}
+	imported, err := c.importedUsers(ctx, resourceOwner, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ImportUserResult, len(users))
+	userCmds := make([][]eventstore.Command, len(users))
+	seen := make(map[string]struct{}, len(users))
+	for i, u := range users {
+		results[i] = &ImportUserResult{ExternalID: u.ExternalID}
+		if u.ExternalID == "" || u.Human == nil {
+			results[i].Err = zerrors.ThrowInvalidArgument(nil, "COMMAND-Im2p2", "Errors.User.Import.Invalid")
+			continue
+		}
+		if _, ok := seen[u.ExternalID]; ok {
+			results[i].Err = zerrors.ThrowInvalidArgument(nil, "COMMAND-Im3p2", "Errors.User.Import.DuplicateExternalID")
+			continue
+		}
+		seen[u.ExternalID] = struct{}{}
+		if userID, ok := imported[u.ExternalID]; ok {
+			results[i].UserID = userID
+			results[i].Skipped = true
+			continue
+		}
+		existingHuman, cmds, err := c.addUserHumanCommands(ctx, resourceOwner, u.Human, false, alg)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		cmds = append(cmds, user.NewUserImportedEvent(ctx, &existingHuman.Aggregate().Aggregate, u.ExternalID))
+		// the grants are pushed together with the user,
+		// so a user is only ever marked as imported with all its grants
+		grantCmds, err := c.importUserGrantCommands(ctx, resourceOwner, existingHuman.Aggregate().ID, u.Grants)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		userCmds[i] = append(cmds, grantCmds...)
+	}
+	for i, err := range c.pushImportCommands(ctx, userCmds) {
+		if userCmds[i] == nil {
+			continue
+		}
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].UserID = users[i].Human.ID
+	}
+	return results, nil
+}
+
+// importedUsers returns the IDs of the (still existing) users previously imported with the external IDs
+func (c *Commands) importedUsers(ctx context.Context, resourceOwner string, externalIDs []string) (map[string]string, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+	importsWriteModel := NewUserImportsWriteModel(resourceOwner, externalIDs)
+	if err := c.eventstore.FilterToQueryReducer(ctx, importsWriteModel); err != nil {
+		return nil, err
+	}
+	if len(importsWriteModel.UserIDs) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]string, 0, len(importsWriteModel.UserIDs))
+	for _, userID := range importsWriteModel.UserIDs {
+		userIDs = append(userIDs, userID)
+	}
+	removedWriteModel := NewUsersRemovedWriteModel(resourceOwner, userIDs)
+	if err := c.eventstore.FilterToQueryReducer(ctx, removedWriteModel); err != nil {
+		return nil, err
+	}
+	imported := make(map[string]string, len(importsWriteModel.UserIDs))
+	for externalID, userID := range importsWriteModel.UserIDs {
+		if !removedWriteModel.Removed[userID] {
+			imported[externalID] = userID
+		}
+	}
+	return imported, nil
+}
+
+func (c *Commands) importUserGrantCommands(ctx context.Context, resourceOwner, userID string, grants []*ImportUserGrant) ([]eventstore.Command, error) {
+	if len(grants) == 0 {
+		return nil, nil
+	}
+	if err := c.checkPermission(ctx, domain.PermissionUserGrantWrite, resourceOwner, userID); err != nil {
+		return nil, err
+	}
+	cmds := make([]eventstore.Command, 0, len(grants))
+	for _, grant := range grants {
+		userGrant := &domain.UserGrant{
+			UserID:         userID,
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		}
+		if !userGrant.IsValid() {
+			return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Im4p2", "Errors.UserGrant.Invalid")
+		}
+		// the user doesn't exist yet, as it's pushed together with the grants,
+		// so only the preconditions of the project (grant) are checked
+		if err := c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		}, resourceOwner); err != nil {
+			return nil, err
+		}
+		grantID, err := c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, usergrant.NewUserGrantAddedEvent(
+			ctx,
+			UserGrantAggregateFromWriteModel(&NewUserGrantWriteModel(grantID, resourceOwner).WriteModel),
+			userID,
+			grant.ProjectID,
+			grant.ProjectGrantID,
+			grant.RoleKeys,
+		))
+	}
+	return cmds, nil
+}
+
+// pushImportCommands pushes the commands of all records at once.
+// If this fails, e.g. because a unique constraint of a single record is violated,
+// the records are pushed one by one, so only the failing ones are reported.
+func (c *Commands) pushImportCommands(ctx context.Context, cmds [][]eventstore.Command) []error {
+	errs := make([]error, len(cmds))
+	all := make([]eventstore.Command, 0, len(cmds))
+	for _, recordCmds := range cmds {
+		all = append(all, recordCmds...)
+	}
+	if len(all) == 0 {
+		return errs
+	}
+	if _, err := c.eventstore.Push(ctx, all...); err == nil {
+		return errs
+	}
+	for i, recordCmds := range cmds {
+		if len(recordCmds) == 0 {
+			continue
+		}
+		_, errs[i] = c.eventstore.Push(ctx, recordCmds...)
+	}
+	return errs
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserImportsWriteModel maps the external IDs of previous imports into the organization to the IDs of the imported users
+type UserImportsWriteModel struct {
+	eventstore.WriteModel
+
+	ExternalIDs []string
+	UserIDs     map[string]string
+}
+
+func NewUserImportsWriteModel(resourceOwner string, externalIDs []string) *UserImportsWriteModel {
+	return &UserImportsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		ExternalIDs: externalIDs,
+		UserIDs:     make(map[string]string, len(externalIDs)),
+	}
+}
+
+func (wm *UserImportsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*user.UserImportedEvent); ok {
+			wm.UserIDs[e.ExternalID] = e.Aggregate().ID
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserImportsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner)
+	for _, externalID := range wm.ExternalIDs {
+		query = query.
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			EventTypes(user.UserImportedType).
+			EventData(map[string]interface{}{"externalId": externalID}).
+			Builder()
+	}
+	return query
+}
+
+// UsersRemovedWriteModel collects which of the users have been removed
+type UsersRemovedWriteModel struct {
+	eventstore.WriteModel
+
+	UserIDs []string
+	Removed map[string]bool
+}
+
+func NewUsersRemovedWriteModel(resourceOwner string, userIDs []string) *UsersRemovedWriteModel {
+	return &UsersRemovedWriteModel{
+		WriteModel: eventstore.WriteModel{
+			ResourceOwner: resourceOwner,
+		},
+		UserIDs: userIDs,
+		Removed: make(map[string]bool, len(userIDs)),
+	}
+}
+
+func (wm *UsersRemovedWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if _, ok := event.(*user.UserRemovedEvent); ok {
+			wm.Removed[event.Aggregate().ID] = true
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UsersRemovedWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.UserIDs...).
+		EventTypes(user.UserRemovedType).
+		Builder()
+}
@@ -0,0 +1,317 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_ImportUsers(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		users         []*ImportUser
+	}
+	type res struct {
+		want []*ImportUserResult
+		// wantErrs checks the error of the result with the same index
+		wantErrs []func(error) bool
+		err      func(error) bool
+	}
+	userAgg := user.NewAggregate("user1", "org1")
+	newHuman := func() *AddHuman {
+		return &AddHuman{
+			Username:  "username",
+			FirstName: "firstname",
+			LastName:  "lastname",
+			Email: Email{
+				Address:  "email@test.ch",
+				Verified: true,
+			},
+			PreferredLanguage: language.English,
+		}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resource owner missing, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid and duplicate records, reported",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserImportedEvent(context.Background(), &userAgg.Aggregate, "ext1"),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{Human: newHuman()},
+					{ExternalID: "ext1", Human: newHuman()},
+					{ExternalID: "ext1", Human: newHuman()},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{},
+					{ExternalID: "ext1", UserID: "user1", Skipped: true},
+					{ExternalID: "ext1"},
+				},
+				wantErrs: []func(error) bool{
+					zerrors.IsErrorInvalidArgument,
+					nil,
+					zerrors.IsErrorInvalidArgument,
+				},
+			},
+		},
+		{
+			name: "previously imported user removed, imported again",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserImportedEvent(context.Background(), &user.NewAggregate("user0", "org1").Aggregate, "ext1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserRemovedEvent(context.Background(), &user.NewAggregate("user0", "org1").Aggregate, "username", nil, "", true),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(), &userAgg.Aggregate, true, true, true),
+						),
+					),
+					expectPush(
+						newAddHumanEvent("", false, true, "", language.English),
+						user.NewHumanEmailVerifiedEvent(context.Background(), &userAgg.Aggregate),
+						user.NewUserImportedEvent(context.Background(), &userAgg.Aggregate, "ext1"),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{ExternalID: "ext1", Human: newHuman()},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{ExternalID: "ext1", UserID: "user1"},
+				},
+			},
+		},
+		{
+			name: "user with grant, pushed together",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(), &userAgg.Aggregate, true, true, true),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "projectname", true, true, true, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "role1", "role", ""),
+						),
+					),
+					expectPush(
+						newAddHumanEvent("", false, true, "", language.English),
+						user.NewHumanEmailVerifiedEvent(context.Background(), &userAgg.Aggregate),
+						user.NewUserImportedEvent(context.Background(), &userAgg.Aggregate, "ext1"),
+						usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate, "user1", "project1", "", []string{"role1"}),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1", "grant1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{ExternalID: "ext1", Human: newHuman(), Grants: []*ImportUserGrant{{ProjectID: "project1", RoleKeys: []string{"role1"}}}},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{ExternalID: "ext1", UserID: "user1"},
+				},
+			},
+		},
+		{
+			name: "grant precondition failed, user not imported",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(), &userAgg.Aggregate, true, true, true),
+						),
+					),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{ExternalID: "ext1", Human: newHuman(), Grants: []*ImportUserGrant{{ProjectID: "project1", RoleKeys: []string{"role1"}}}},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{ExternalID: "ext1"},
+				},
+				wantErrs: []func(error) bool{
+					zerrors.IsPreconditionFailed,
+				},
+			},
+		},
+		{
+			name: "no permission, reported",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{ExternalID: "ext1", Human: newHuman()},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{ExternalID: "ext1"},
+				},
+				wantErrs: []func(error) bool{
+					zerrors.IsPermissionDenied,
+				},
+			},
+		},
+		{
+			name: "push failed, records pushed one by one and reported",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(), &userAgg.Aggregate, true, true, true),
+						),
+					),
+					expectPushFailed(zerrors.ThrowAlreadyExists(nil, "ERROR", "username taken"),
+						newAddHumanEvent("", false, true, "", language.English),
+						user.NewHumanEmailVerifiedEvent(context.Background(), &userAgg.Aggregate),
+						user.NewUserImportedEvent(context.Background(), &userAgg.Aggregate, "ext1"),
+					),
+					expectPushFailed(zerrors.ThrowAlreadyExists(nil, "ERROR", "username taken"),
+						newAddHumanEvent("", false, true, "", language.English),
+						user.NewHumanEmailVerifiedEvent(context.Background(), &userAgg.Aggregate),
+						user.NewUserImportedEvent(context.Background(), &userAgg.Aggregate, "ext1"),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				users: []*ImportUser{
+					{ExternalID: "ext1", Human: newHuman()},
+				},
+			},
+			res: res{
+				want: []*ImportUserResult{
+					{ExternalID: "ext1"},
+				},
+				wantErrs: []func(error) bool{
+					zerrors.IsErrorAlreadyExists,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				idGenerator:        tt.fields.idGenerator,
+				checkPermission:    tt.fields.checkPermission,
+				userPasswordHasher: mockPasswordHasher("x"),
+			}
+			got, err := r.ImportUsers(tt.args.ctx, tt.args.resourceOwner, tt.args.users, crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "got wrong err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.res.want))
+			for i, want := range tt.res.want {
+				assert.Equal(t, want.ExternalID, got[i].ExternalID)
+				assert.Equal(t, want.UserID, got[i].UserID)
+				assert.Equal(t, want.Skipped, got[i].Skipped)
+				if i < len(tt.res.wantErrs) && tt.res.wantErrs[i] != nil {
+					assert.True(t, tt.res.wantErrs[i](got[i].Err), "got wrong err: %v", got[i].Err)
+					continue
+				}
+				assert.NoError(t, got[i].Err)
+			}
+		})
+	}
+}
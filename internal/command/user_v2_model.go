@@ -73,6 +73,9 @@ type UserV2WriteModel struct {
 
 	IDPLinkWriteModel bool
 	IDPLinks          []*domain.UserIDPLink
+
+	// ImportExternalID is the ID of the user in the system it was imported from
+	ImportExternalID string
 }
 
 func NewUserExistsWriteModel(userID, resourceOwner string) *UserV2WriteModel {
@@ -258,6 +261,8 @@ func (wm *UserV2WriteModel) Reduce() error {
 		case *user.UserReactivatedEvent:
 			wm.UserState = domain.UserStateActive
 
+		case *user.UserImportedEvent:
+			wm.ImportExternalID = e.ExternalID
 		case *user.UserRemovedEvent:
 			wm.UserState = domain.UserStateDeleted
 
@@ -356,9 +361,11 @@ func (wm *UserV2WriteModel) SetPasswordCodeSent(e *user.HumanPasswordCodeSentEve
 func (wm *UserV2WriteModel) Query() *eventstore.SearchQueryBuilder {
 	// remove events are always processed
 	// and username is based for machine and human
+	// the import is needed to release the external ID on removal
 	eventTypes := []eventstore.EventType{
 		user.UserRemovedType,
 		user.UserUserNameChangedType,
+		user.UserImportedType,
 	}
 
 	if wm.HumanWriteModel {
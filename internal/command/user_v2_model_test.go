@@ -388,6 +388,7 @@ func TestCommandSide_userExistsWriteModel(t *testing.T) {
 								&userAgg.Aggregate,
 								"username",
 								[]*domain.UserIDPLink{},
+								"",
 								true,
 							),
 						),
@@ -434,6 +435,7 @@ func TestCommandSide_userExistsWriteModel(t *testing.T) {
 								&userAgg.Aggregate,
 								"username",
 								[]*domain.UserIDPLink{},
+								"",
 								true,
 							),
 						),
@@ -72,3 +72,25 @@ func (c *Commands) requestPasswordReset(ctx context.Context, userID string, retu
 	}
 	return writeModelToObjectDetails(&model.WriteModel), plainCode, nil
 }
+
+// ReportPasswordCompromised marks the current password of the user as compromised.
+// The password itself stays unchanged, it's up to the user or an administrator to set a new one.
+func (c *Commands) ReportPasswordCompromised(ctx context.Context, userID, reason string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Kp3sQ", "Errors.User.IDMissing")
+	}
+	model, err := c.getHumanWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !model.UserState.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Kp4sQ", "Errors.User.NotFound")
+	}
+	if err = c.checkPermissionUpdateUser(ctx, model.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	if err = c.pushAppendAndReduce(ctx, model, user.NewHumanPasswordCompromisedEvent(ctx, UserAggregateFromWriteModelCtx(ctx, &model.WriteModel), reason)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
@@ -726,3 +726,117 @@ func TestCommands_requestPasswordReset(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_ReportPasswordCompromised(t *testing.T) {
+	type fields struct {
+		checkPermission domain.PermissionCheck
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		userID string
+		reason string
+	}
+	type res struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing userID",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Kp3sQ", "Errors.User.IDMissing"),
+			},
+		},
+		{
+			name: "user not existing",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Kp4sQ", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "missing permission",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"username", "firstname", "lastname", "nickname", "displayname",
+								language.English, domain.GenderUnspecified, "email", false),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			res: res{
+				err: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+			},
+		},
+		{
+			name: "reported",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"username", "firstname", "lastname", "nickname", "displayname",
+								language.English, domain.GenderUnspecified, "email", false),
+						),
+					),
+					expectPush(
+						user.NewHumanPasswordCompromisedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"leaked",
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+				reason: "leaked",
+			},
+			res: res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				checkPermission: tt.fields.checkPermission,
+				eventstore:      tt.fields.eventstore(t),
+			}
+			got, err := c.ReportPasswordCompromised(tt.args.ctx, tt.args.userID, tt.args.reason)
+			require.ErrorIs(t, err, tt.res.err)
+			assertObjectDetails(t, tt.res.details, got)
+		})
+	}
+}
@@ -1161,6 +1161,7 @@ func TestCommandSide_RemoveUserV2(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -1213,6 +1214,65 @@ func TestCommandSide_RemoveUserV2(t *testing.T) {
 							&user.NewAggregate("user1", "org1").Aggregate,
 							"username",
 							nil,
+							"",
+							true,
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "remove imported user, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserImportedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"external1",
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectPush(
+						user.NewUserRemovedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"username",
+							nil,
+							"external1",
 							true,
 						),
 					),
@@ -1287,6 +1347,7 @@ func TestCommandSide_RemoveUserV2(t *testing.T) {
 								&user.NewAggregate("user1", "org1").Aggregate,
 								"username",
 								nil,
+								"",
 								true,
 							),
 						),
@@ -1335,6 +1396,7 @@ func TestCommandSide_RemoveUserV2(t *testing.T) {
 							&user.NewAggregate("user1", "org1").Aggregate,
 							"username",
 							nil,
+							"",
 							true,
 						),
 					),
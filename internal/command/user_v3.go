@@ -24,6 +24,8 @@ type CreateSchemaUser struct {
 	ReturnCodeEmail *string
 	Phone           *Phone
 	ReturnCodePhone *string
+
+	indexedValues *schemauser.IndexedValues
 }
 
 func (s *CreateSchemaUser) Valid(ctx context.Context, c *Commands) (err error) {
@@ -70,6 +72,10 @@ func (s *CreateSchemaUser) Valid(ctx context.Context, c *Commands) (err error) {
 		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-SlKXqLSeL6", "Errors.UserSchema.Data.Invalid")
 	}
 
+	if s.indexedValues, err = schemaWriteModel.IndexedValues(s.schemaRevision, s.Data); err != nil {
+		return err
+	}
+
 	if s.Email != nil && s.Email.Address != "" {
 		if err := s.Email.Validate(); err != nil {
 			return err
@@ -85,6 +91,11 @@ func (s *CreateSchemaUser) Valid(ctx context.Context, c *Commands) (err error) {
 	return nil
 }
 
+// SchemaRevision returns the revision of the schema the created user conforms to.
+func (s *CreateSchemaUser) SchemaRevision() uint64 {
+	return s.schemaRevision
+}
+
 func (c *Commands) getSchemaRoleForWrite(ctx context.Context, resourceOwner, userID string) (domain_schema.Role, error) {
 	if userID == authz.GetCtxData(ctx).UserID {
 		return domain_schema.RoleSelf, nil
@@ -109,6 +120,7 @@ func (c *Commands) CreateSchemaUser(ctx context.Context, user *CreateSchemaUser)
 		user.SchemaID,
 		user.schemaRevision,
 		user.Data,
+		user.indexedValues,
 		user.Email,
 		user.Phone,
 		func(ctx context.Context) (*EncryptedCode, error) {
@@ -134,12 +146,21 @@ func (c *Commands) DeleteSchemaUser(ctx context.Context, resourceOwner, id strin
 	if id == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vs4wJCME7T", "Errors.IDMissing")
 	}
-	writeModel, err := c.getSchemaUserExists(ctx, resourceOwner, id)
+	writeModel, err := c.getSchemaUserWriteModelByID(ctx, resourceOwner, id)
 	if err != nil {
 		return nil, err
 	}
 
-	events, err := writeModel.NewDelete(ctx)
+	// the schema is needed to release the unique values of the user
+	var schemaWM *UserSchemaWriteModel
+	if writeModel.Exists() {
+		schemaWM, err = c.getSchemaWriteModelByID(ctx, "", writeModel.SchemaID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	events, err := writeModel.NewDelete(ctx, schemaWM)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +170,7 @@ func (c *Commands) DeleteSchemaUser(ctx context.Context, resourceOwner, id strin
 
 type ChangeSchemaUser struct {
 	schemaWriteModel *UserSchemaWriteModel
+	schemaRevision   uint64
 
 	ResourceOwner string
 	ID            string
@@ -161,6 +183,11 @@ type ChangeSchemaUser struct {
 	ReturnCodePhone *string
 }
 
+// SchemaRevision returns the revision of the schema the user conforms to after the change.
+func (s *ChangeSchemaUser) SchemaRevision() uint64 {
+	return s.schemaRevision
+}
+
 type SchemaUser struct {
 	SchemaID string
 	Data     json.RawMessage
@@ -215,7 +242,17 @@ func (c *Commands) ChangeSchemaUser(ctx context.Context, user *ChangeSchemaUser)
 		schemaWM = schemaWriteModel
 	}
 
+	// the previous schema is needed to replace the indexed values of the user
+	previousSchemaWM := schemaWM
+	if schemaWM != nil && schemaID != writeModel.SchemaID {
+		previousSchemaWM, err = c.getSchemaWriteModelByID(ctx, "", writeModel.SchemaID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	events, codeEmail, codePhone, err := writeModel.NewUpdate(ctx,
+		previousSchemaWM,
 		schemaWM,
 		user.SchemaUser,
 		user.Email,
@@ -237,7 +274,12 @@ func (c *Commands) ChangeSchemaUser(ctx context.Context, user *ChangeSchemaUser)
 	if codePhone != "" {
 		user.ReturnCodePhone = &codePhone
 	}
-	return c.pushAppendAndReduceDetails(ctx, writeModel, events...)
+	details, err := c.pushAppendAndReduceDetails(ctx, writeModel, events...)
+	if err != nil {
+		return nil, err
+	}
+	user.schemaRevision = writeModel.SchemaRevision
+	return details, nil
 }
 
 func (c *Commands) checkPermissionUpdateUserState(ctx context.Context, resourceOwner, userID string) error {
@@ -220,6 +220,7 @@ func (wm *UserV3WriteModel) NewCreated(
 	schemaID string,
 	schemaRevision uint64,
 	data json.RawMessage,
+	indexedValues *schemauser.IndexedValues,
 	email *Email,
 	phone *Phone,
 	emailCode func(context.Context) (*EncryptedCode, error),
@@ -231,12 +232,14 @@ func (wm *UserV3WriteModel) NewCreated(
 	if wm.Exists() {
 		return nil, "", "", zerrors.ThrowPreconditionFailed(nil, "COMMAND-Nn8CRVlkeZ", "Errors.User.AlreadyExists")
 	}
-	events := []eventstore.Command{
-		schemauser.NewCreatedEvent(ctx,
-			UserV3AggregateFromWriteModel(&wm.WriteModel),
-			schemaID, schemaRevision, data,
-		),
+	created := schemauser.NewCreatedEvent(ctx,
+		UserV3AggregateFromWriteModel(&wm.WriteModel),
+		schemaID, schemaRevision, data,
+	)
+	if indexedValues != nil && len(indexedValues.Values) > 0 {
+		created.WithIndexedValues(indexedValues)
 	}
+	events := []eventstore.Command{created}
 	if email != nil {
 		emailEvents, plainCodeEmail, err := wm.NewEmailCreate(ctx,
 			email,
@@ -307,6 +310,7 @@ func (wm *UserV3WriteModel) validateData(ctx context.Context, data []byte, schem
 
 func (wm *UserV3WriteModel) NewUpdate(
 	ctx context.Context,
+	previousSchemaWM *UserSchemaWriteModel,
 	schemaWM *UserSchemaWriteModel,
 	user *SchemaUser,
 	email *Email,
@@ -326,10 +330,15 @@ func (wm *UserV3WriteModel) NewUpdate(
 		if err != nil {
 			return nil, "", "", err
 		}
+		indexChange, err := wm.indexChange(previousSchemaWM, schemaWM, user.Data)
+		if err != nil {
+			return nil, "", "", err
+		}
 		userEvents := wm.newUpdatedEvents(ctx,
 			schemaID,
 			schemaRevision,
 			user.Data,
+			indexChange,
 		)
 		events = append(events, userEvents...)
 	}
@@ -369,6 +378,7 @@ func (wm *UserV3WriteModel) newUpdatedEvents(
 	schemaID string,
 	schemaRevision uint64,
 	data json.RawMessage,
+	indexChange schemauser.Changes,
 ) []eventstore.Command {
 	changes := make([]schemauser.Changes, 0)
 	if wm.SchemaID != schemaID {
@@ -383,11 +393,40 @@ func (wm *UserV3WriteModel) newUpdatedEvents(
 	if len(changes) == 0 {
 		return nil
 	}
+	if indexChange != nil {
+		changes = append(changes, indexChange)
+	}
 	return []eventstore.Command{schemauser.NewUpdatedEvent(ctx, UserV3AggregateFromWriteModel(&wm.WriteModel), changes)}
 }
 
+// indexChange returns the change of the indexed values from the current data to the passed data.
+// If data is nil, the current data is used for the new schema.
+func (wm *UserV3WriteModel) indexChange(previousSchemaWM, schemaWM *UserSchemaWriteModel, data json.RawMessage) (schemauser.Changes, error) {
+	if data == nil {
+		data = wm.Data
+	}
+	previous, err := previousSchemaWM.IndexedValues(wm.SchemaRevision, wm.Data)
+	if err != nil {
+		return nil, err
+	}
+	values, err := schemaWM.IndexedValues(schemaWM.SchemaRevision, data)
+	if err != nil {
+		return nil, err
+	}
+	return indexedValuesChange(previous, values), nil
+}
+
+// indexedValuesChange returns the change of the indexed values, if the previous or the new schema declares any.
+func indexedValuesChange(previous, values *schemauser.IndexedValues) schemauser.Changes {
+	if len(previous.Values) == 0 && len(values.Values) == 0 {
+		return nil
+	}
+	return schemauser.ChangeIndexedValues(previous, values)
+}
+
 func (wm *UserV3WriteModel) NewDelete(
 	ctx context.Context,
+	schemaWM *UserSchemaWriteModel,
 ) (_ []eventstore.Command, err error) {
 	if !wm.Exists() {
 		return nil, zerrors.ThrowNotFound(nil, "COMMAND-syHyCsGmvM", "Errors.User.NotFound")
@@ -395,8 +434,15 @@ func (wm *UserV3WriteModel) NewDelete(
 	if err := wm.checkPermissionDelete(ctx, wm.ResourceOwner, wm.AggregateID); err != nil {
 		return nil, err
 	}
-	return []eventstore.Command{schemauser.NewDeletedEvent(ctx, UserV3AggregateFromWriteModel(&wm.WriteModel))}, nil
-
+	deleted := schemauser.NewDeletedEvent(ctx, UserV3AggregateFromWriteModel(&wm.WriteModel))
+	indexedValues, err := schemaWM.IndexedValues(wm.SchemaRevision, wm.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(indexedValues.Values) > 0 {
+		deleted.WithIndexedValues(indexedValues)
+	}
+	return []eventstore.Command{deleted}, nil
 }
 
 func UserV3AggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
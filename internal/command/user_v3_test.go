@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/id/mock"
@@ -1046,6 +1047,124 @@ func TestCommands_CreateSchemaUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			"user created, unique field",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("type", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{
+								"$schema": "urn:zitadel:schema:v1",
+								"type": "object",
+								"properties": {
+									"employeeNumber": {
+										"type": "string",
+										"urn:zitadel:schema:index": {
+											"unique": "organization"
+										}
+									}
+								}
+							}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						schemauser.NewCreatedEvent(
+							context.Background(),
+							&schemauser.NewAggregate("id1", "org1").Aggregate,
+							"type",
+							1,
+							json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+						).WithIndexedValues(employeeNumberIndexedValues("type", "E-1")),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+				idGenerator:     mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				user: &CreateSchemaUser{
+					ResourceOwner: "org1",
+					SchemaID:      "type",
+					Data: json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "id1",
+				},
+			},
+		},
+		{
+			"user create, unique field already used",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("type", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{
+								"$schema": "urn:zitadel:schema:v1",
+								"type": "object",
+								"properties": {
+									"employeeNumber": {
+										"type": "string",
+										"urn:zitadel:schema:index": {
+											"unique": "organization"
+										}
+									}
+								}
+							}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+					expectFilter(),
+					expectPushFailed(
+						zerrors.ThrowAlreadyExists(nil, "id", "Errors.UserSchema.Data.NotUnique"),
+						schemauser.NewCreatedEvent(
+							context.Background(),
+							&schemauser.NewAggregate("id1", "org1").Aggregate,
+							"type",
+							1,
+							json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+						).WithIndexedValues(employeeNumberIndexedValues("type", "E-1")),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+				idGenerator:     mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				user: &CreateSchemaUser{
+					ResourceOwner: "org1",
+					SchemaID:      "type",
+					Data: json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+				},
+			},
+			res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowAlreadyExists(nil, "id", "Errors.UserSchema.Data.NotUnique"))
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1187,6 +1306,7 @@ func TestCommandSide_DeleteSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						schemauser.NewDeletedEvent(context.Background(),
 							&schemauser.NewAggregate("user1", "org1").Aggregate,
@@ -1221,6 +1341,7 @@ func TestCommandSide_DeleteSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 				checkPermission: newMockPermissionCheckNotAllowed(),
 			},
@@ -1250,6 +1371,7 @@ func TestCommandSide_DeleteSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						schemauser.NewDeletedEvent(authz.NewMockContext("instanceID", "org1", "user1"),
 							&schemauser.NewAggregate("user1", "org1").Aggregate,
@@ -1267,6 +1389,62 @@ func TestCommandSide_DeleteSchemaUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "remove user, unique field released",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schemauser.NewCreatedEvent(context.Background(),
+								&schemauser.NewAggregate("user1", "org1").Aggregate,
+								"type",
+								1,
+								json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("type", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{
+								"$schema": "urn:zitadel:schema:v1",
+								"type": "object",
+								"properties": {
+									"employeeNumber": {
+										"type": "string",
+										"urn:zitadel:schema:index": {
+											"unique": "organization"
+										}
+									}
+								}
+							}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+					expectPush(
+						schemauser.NewDeletedEvent(context.Background(),
+							&schemauser.NewAggregate("user1", "org1").Aggregate,
+						).WithIndexedValues(employeeNumberIndexedValues("type", "E-1")),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "user1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2415,6 +2593,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						schemauser.NewUpdatedEvent(
 							context.Background(),
@@ -2478,6 +2657,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						schemauser.NewUpdatedEvent(
 							context.Background(),
@@ -2637,6 +2817,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 					expectPush(
 						schemauser.NewUpdatedEvent(
 							context.Background(),
@@ -2771,6 +2952,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 			},
 			args{
@@ -2827,6 +3009,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 				checkPermission: newMockPermissionCheckAllowed(),
 			},
@@ -2957,6 +3140,7 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 							),
 						),
 					),
+					expectFilter(),
 				),
 				checkPermission: newMockPermissionCheckAllowed(),
 			},
@@ -3489,6 +3673,80 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			"user updated, unique field changed",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							schemauser.NewCreatedEvent(
+								context.Background(),
+								&schemauser.NewAggregate("user1", "org1").Aggregate,
+								"type",
+								1,
+								json.RawMessage(`{
+						"employeeNumber": "E-1"
+					}`),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							schema.NewCreatedEvent(
+								context.Background(),
+								&schema.NewAggregate("type", "instanceID").Aggregate,
+								"type",
+								json.RawMessage(`{
+								"$schema": "urn:zitadel:schema:v1",
+								"type": "object",
+								"properties": {
+									"employeeNumber": {
+										"type": "string",
+										"urn:zitadel:schema:index": {
+											"unique": "organization"
+										}
+									}
+								}
+							}`),
+								[]domain.AuthenticatorType{domain.AuthenticatorTypeUsername},
+							),
+						),
+					),
+					expectPush(
+						schemauser.NewUpdatedEvent(
+							context.Background(),
+							&schemauser.NewAggregate("user1", "org1").Aggregate,
+							[]schemauser.Changes{
+								schemauser.ChangeData(json.RawMessage(`{
+						"employeeNumber": "E-2"
+					}`)),
+								schemauser.ChangeIndexedValues(
+									employeeNumberIndexedValues("type", "E-1"),
+									employeeNumberIndexedValues("type", "E-2"),
+								),
+							},
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args{
+				ctx: authz.NewMockContext("instanceID", "", ""),
+				user: &ChangeSchemaUser{
+					ID: "user1",
+					SchemaUser: &SchemaUser{
+						Data: json.RawMessage(`{
+						"employeeNumber": "E-2"
+					}`),
+					},
+				},
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3522,3 +3780,18 @@ func TestCommands_ChangeSchemaUser(t *testing.T) {
 		})
 	}
 }
+
+func employeeNumberIndexedValues(schemaID, employeeNumber string) *schemauser.IndexedValues {
+	return &schemauser.IndexedValues{
+		SchemaID: schemaID,
+		Values: []*domain_schema.IndexedValue{
+			{
+				Field: &domain_schema.IndexedField{
+					Path:   "/employeeNumber",
+					Unique: domain_schema.UniqueScopeOrganization,
+				},
+				Value: employeeNumber,
+			},
+		},
+	}
+}
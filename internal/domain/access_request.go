@@ -0,0 +1,65 @@
+package domain
+
+import (
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// AccessRequest is the request of a user for roles on a project or granted project.
+// If it's approved, a user grant is created.
+type AccessRequest struct {
+	es_models.ObjectRoot
+
+	State          AccessRequestState
+	UserID         string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+	Reason         string
+	UserGrantID    string
+	Comment        string
+}
+
+func (r *AccessRequest) IsValid() bool {
+	return r.UserID != "" && r.ProjectID != "" && len(r.RoleKeys) > 0
+}
+
+// UserGrant returns the user grant which is created if the request is approved.
+func (r *AccessRequest) UserGrant() *UserGrant {
+	return &UserGrant{
+		UserID:         r.UserID,
+		ProjectID:      r.ProjectID,
+		ProjectGrantID: r.ProjectGrantID,
+		RoleKeys:       r.RoleKeys,
+	}
+}
+
+type AccessRequestState int32
+
+const (
+	AccessRequestStateUnspecified AccessRequestState = iota
+	AccessRequestStatePending
+	AccessRequestStateApproved
+	AccessRequestStateDenied
+	AccessRequestStateWithdrawn
+
+	accessRequestStateCount
+)
+
+func (s AccessRequestState) Valid() bool {
+	return s > AccessRequestStateUnspecified && s < accessRequestStateCount
+}
+
+// IsDecided returns true if the request is no longer pending.
+func (s AccessRequestState) IsDecided() bool {
+	return s == AccessRequestStateApproved || s == AccessRequestStateDenied || s == AccessRequestStateWithdrawn
+}
+
+// AccessRequestApproverRoles returns the member roles which are notified about new requests.
+// For requests on a granted project these are the roles of the project grant members,
+// otherwise the roles of the project members.
+func AccessRequestApproverRoles(projectGrantID string) []string {
+	if projectGrantID != "" {
+		return []string{RoleProjectGrantOwner, RoleProjectGrantAccessApprover}
+	}
+	return []string{RoleProjectOwner, RoleProjectOwnerGlobal, RoleProjectAccessApprover}
+}
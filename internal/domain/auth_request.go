@@ -102,6 +102,9 @@ type ExternalUser struct {
 	Phone             PhoneNumber
 	IsPhoneVerified   bool
 	Metadatas         []*Metadata
+	// GroupClaims are the values of the claims (attributes) used by the group mappings of the IdP,
+	// so the groups can be synced after the user was registered or linked.
+	GroupClaims map[string][]string
 }
 
 type Prompt int32
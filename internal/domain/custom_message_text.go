@@ -18,6 +18,15 @@ const (
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
 	PasswordChangeMessageType           = "PasswordChange"
 	InviteUserMessageType               = "InviteUser"
+	InactivityWarningMessageType        = "InactivityWarning"
+	GrantExpiryWarningMessageType       = "GrantExpiryWarning"
+	AccessRequestMessageType            = "AccessRequest"
+	NewDeviceSignInMessageType          = "NewDeviceSignIn"
+	MFAAddedMessageType                 = "MFAAdded"
+	MFARemovedMessageType               = "MFARemoved"
+	EmailChangeMessageType              = "EmailChange"
+	AccountLockedMessageType            = "AccountLocked"
+	PersonalAccessTokenAddedMessageType = "PersonalAccessTokenAdded"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -63,5 +72,14 @@ func IsMessageTextType(textType string) bool {
 		textType == DomainClaimedMessageType ||
 		textType == PasswordlessRegistrationMessageType ||
 		textType == PasswordChangeMessageType ||
-		textType == InviteUserMessageType
+		textType == InviteUserMessageType ||
+		textType == InactivityWarningMessageType ||
+		textType == GrantExpiryWarningMessageType ||
+		textType == AccessRequestMessageType ||
+		textType == NewDeviceSignInMessageType ||
+		textType == MFAAddedMessageType ||
+		textType == MFARemovedMessageType ||
+		textType == EmailChangeMessageType ||
+		textType == AccountLockedMessageType ||
+		textType == PersonalAccessTokenAddedMessageType
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type CustomRoleState int32
+
+const (
+	CustomRoleStateUnspecified CustomRoleState = iota
+	CustomRoleStateActive
+	CustomRoleStateRemoved
+
+	customRoleStateCount
+)
+
+func (s CustomRoleState) Valid() bool {
+	return s >= 0 && s < customRoleStateCount
+}
+
+func (s CustomRoleState) Exists() bool {
+	return s != CustomRoleStateUnspecified && s != CustomRoleStateRemoved
+}
+
+var customRoleKeyRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// CustomRole bundles permissions of the static member roles into a role,
+// which can be assigned to the members of an instance or organization.
+type CustomRole struct {
+	models.ObjectRoot
+
+	Key         string
+	DisplayName string
+	Permissions []string
+}
+
+// IsValid checks the key starts with one of the prefixes (e.g. ORG_)
+// and the role grants at least one permission.
+func (r *CustomRole) IsValid(prefixes ...string) bool {
+	return IsCustomRoleKey(r.Key, prefixes...) && len(r.Permissions) > 0
+}
+
+// IsCustomRoleKey checks the key only consists of upper case letters, digits and underscores
+// and starts with one of the prefixes followed by an underscore.
+func IsCustomRoleKey(key string, prefixes ...string) bool {
+	if !customRoleKeyRegex.MatchString(key) {
+		return false
+	}
+	return slices.ContainsFunc(prefixes, func(prefix string) bool {
+		return strings.HasPrefix(key, prefix+"_")
+	})
+}
+
+// IsStaticRole returns true if the key is used by one of the roles of the configuration.
+func (r *CustomRole) IsStaticRole(staticRoles []authz.RoleMapping) bool {
+	return slices.ContainsFunc(staticRoles, func(role authz.RoleMapping) bool {
+		return role.Role == r.Key
+	})
+}
+
+// InvalidCustomRolePermissions returns the permissions which are not granted by any of the static roles.
+func InvalidCustomRolePermissions(permissions []string, staticRoles []authz.RoleMapping) []string {
+	invalid := make([]string, 0)
+	for _, permission := range permissions {
+		if !slices.ContainsFunc(staticRoles, func(role authz.RoleMapping) bool {
+			return slices.Contains(role.Permissions, permission)
+		}) {
+			invalid = append(invalid, permission)
+		}
+	}
+	return invalid
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+)
+
+func TestCustomRole_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     *CustomRole
+		prefixes []string
+		want     bool
+	}{
+		{
+			name:     "valid",
+			role:     &CustomRole{Key: "ORG_HELPDESK", Permissions: []string{"user.credential.write"}},
+			prefixes: []string{OrgRolePrefix},
+			want:     true,
+		},
+		{
+			name:     "wrong prefix",
+			role:     &CustomRole{Key: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+			prefixes: []string{OrgRolePrefix},
+			want:     false,
+		},
+		{
+			name:     "prefix without separator",
+			role:     &CustomRole{Key: "ORGHELPDESK", Permissions: []string{"user.credential.write"}},
+			prefixes: []string{OrgRolePrefix},
+			want:     false,
+		},
+		{
+			name:     "lowercase",
+			role:     &CustomRole{Key: "ORG_helpdesk", Permissions: []string{"user.credential.write"}},
+			prefixes: []string{OrgRolePrefix},
+			want:     false,
+		},
+		{
+			name:     "no permissions",
+			role:     &CustomRole{Key: "ORG_HELPDESK"},
+			prefixes: []string{OrgRolePrefix},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.IsValid(tt.prefixes...))
+		})
+	}
+}
+
+func TestInvalidCustomRolePermissions(t *testing.T) {
+	staticRoles := []authz.RoleMapping{
+		{Role: "ORG_OWNER", Permissions: []string{"org.read", "user.write"}},
+		{Role: "ORG_USER_MANAGER", Permissions: []string{"user.credential.write"}},
+	}
+	assert.Empty(t, InvalidCustomRolePermissions([]string{"user.write", "user.credential.write"}, staticRoles))
+	assert.Equal(t, []string{"iam.write"}, InvalidCustomRolePermissions([]string{"user.write", "iam.write"}, staticRoles))
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// GrantValidity restricts a user or project grant to a time window.
+// A zero ValidFrom or ValidUntil leaves the window open on that side.
+type GrantValidity struct {
+	ValidFrom  time.Time
+	ValidUntil time.Time
+}
+
+func (v GrantValidity) IsZero() bool {
+	return v.ValidFrom.IsZero() && v.ValidUntil.IsZero()
+}
+
+// IsValid checks that the window does not end before it starts.
+func (v GrantValidity) IsValid() bool {
+	return v.ValidFrom.IsZero() || v.ValidUntil.IsZero() || v.ValidUntil.After(v.ValidFrom)
+}
+
+// Equal ignores the location and monotonic clock of the timestamps.
+func (v GrantValidity) Equal(other GrantValidity) bool {
+	return v.ValidFrom.Equal(other.ValidFrom) && v.ValidUntil.Equal(other.ValidUntil)
+}
+
+// IsActiveAt returns true if t is within the window.
+func (v GrantValidity) IsActiveAt(t time.Time) bool {
+	return (v.ValidFrom.IsZero() || !t.Before(v.ValidFrom)) && !v.IsExpiredAt(t)
+}
+
+// IsExpiredAt returns true if the window ended at or before t.
+func (v GrantValidity) IsExpiredAt(t time.Time) bool {
+	return !v.ValidUntil.IsZero() && !t.Before(v.ValidUntil)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantValidity(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		validity    GrantValidity
+		wantValid   bool
+		wantActive  bool
+		wantExpired bool
+	}{
+		{
+			name:       "unbounded",
+			validity:   GrantValidity{},
+			wantValid:  true,
+			wantActive: true,
+		},
+		{
+			name:       "started",
+			validity:   GrantValidity{ValidFrom: now.Add(-time.Hour)},
+			wantValid:  true,
+			wantActive: true,
+		},
+		{
+			name:       "starts exactly now",
+			validity:   GrantValidity{ValidFrom: now},
+			wantValid:  true,
+			wantActive: true,
+		},
+		{
+			name:      "not yet started",
+			validity:  GrantValidity{ValidFrom: now.Add(time.Hour)},
+			wantValid: true,
+		},
+		{
+			name:       "not yet expired",
+			validity:   GrantValidity{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(time.Hour)},
+			wantValid:  true,
+			wantActive: true,
+		},
+		{
+			name:        "expires exactly now",
+			validity:    GrantValidity{ValidUntil: now},
+			wantValid:   true,
+			wantExpired: true,
+		},
+		{
+			name:        "expired",
+			validity:    GrantValidity{ValidFrom: now.Add(-2 * time.Hour), ValidUntil: now.Add(-time.Hour)},
+			wantValid:   true,
+			wantExpired: true,
+		},
+		{
+			name:        "ends before it starts",
+			validity:    GrantValidity{ValidFrom: now.Add(-time.Hour), ValidUntil: now.Add(-2 * time.Hour)},
+			wantExpired: true,
+		},
+		{
+			name:     "ends when it starts",
+			validity: GrantValidity{ValidFrom: now.Add(time.Hour), ValidUntil: now.Add(time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantValid, tt.validity.IsValid(), "IsValid")
+			assert.Equal(t, tt.wantActive, tt.validity.IsActiveAt(now), "IsActiveAt")
+			assert.Equal(t, tt.wantExpired, tt.validity.IsExpiredAt(now), "IsExpiredAt")
+		})
+	}
+}
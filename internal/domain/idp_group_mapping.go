@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"slices"
+)
+
+// IDPGroupMapping maps a group provided by an identity provider (as claim or attribute value)
+// to roles of a project and / or member roles of the organization of the user.
+type IDPGroupMapping struct {
+	// Claim is the name of the claim (OIDC, OAuth, JWT) or attribute (SAML, LDAP) containing the groups, e.g. `groups` or `memberOf`
+	Claim string
+	// Group is the value which has to be present in the claim for the mapping to apply
+	Group string
+
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+	OrgMemberRoles []string
+}
+
+func (m *IDPGroupMapping) IsValid() bool {
+	if m == nil || m.Claim == "" || m.Group == "" {
+		return false
+	}
+	if len(m.RoleKeys) == 0 && len(m.OrgMemberRoles) == 0 {
+		return false
+	}
+	return len(m.RoleKeys) == 0 || m.ProjectID != ""
+}
+
+// Matches checks if the group of the mapping is part of the provided claims
+func (m *IDPGroupMapping) Matches(claims map[string][]string) bool {
+	return slices.Contains(claims[m.Claim], m.Group)
+}
+
+// IDPGroupMappingsClaims returns the distinct claims (attributes) used by the mappings
+func IDPGroupMappingsClaims(mappings []*IDPGroupMapping) []string {
+	claims := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		if !slices.Contains(claims, mapping.Claim) {
+			claims = append(claims, mapping.Claim)
+		}
+	}
+	return claims
+}
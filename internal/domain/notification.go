@@ -45,6 +45,12 @@ type NotificationArguments struct {
 	CodeID          string        `json:"codeID,omitempty"`
 	SessionID       string        `json:"sessionID,omitempty"`
 	AuthRequestID   string        `json:"authRequestID,omitempty"`
+	// DeactivationDate is the formatted date an inactive user will be deactivated at.
+	DeactivationDate string `json:"deactivationDate,omitempty"`
+	// PreviousEmail is the address a notification about a changed email is sent to instead of the current one.
+	PreviousEmail string `json:"previousEmail,omitempty"`
+	// AuthMethod is the name of the multi-factor authentication method a notification is about.
+	AuthMethod string `json:"authMethod,omitempty"`
 }
 
 // ToMap creates a type safe map of the notification arguments.
@@ -62,5 +68,8 @@ func (n *NotificationArguments) ToMap() map[string]interface{} {
 	m["CodeID"] = n.CodeID
 	m["SessionID"] = n.SessionID
 	m["AuthRequestID"] = n.AuthRequestID
+	m["DeactivationDate"] = n.DeactivationDate
+	m["PreviousEmail"] = n.PreviousEmail
+	m["AuthMethod"] = n.AuthMethod
 	return m
 }
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+type NotificationDeliveryStatus int32
+
+const (
+	NotificationDeliveryStatusUnspecified NotificationDeliveryStatus = iota
+	// NotificationDeliveryStatusPending is set until the notification was handed over to the provider,
+	// including failed attempts which are retried.
+	NotificationDeliveryStatusPending
+	// NotificationDeliveryStatusSent is set after the provider accepted the notification.
+	NotificationDeliveryStatusSent
+	// NotificationDeliveryStatusFailed is set if the notification was canceled without being sent.
+	NotificationDeliveryStatusFailed
+	// NotificationDeliveryStatusDelivered is reported by the provider after the recipient received the notification.
+	NotificationDeliveryStatusDelivered
+	// NotificationDeliveryStatusBounced is reported by the provider if the notification could not be delivered.
+	NotificationDeliveryStatusBounced
+
+	notificationDeliveryStatusCount
+)
+
+func (s NotificationDeliveryStatus) Valid() bool {
+	return s > NotificationDeliveryStatusUnspecified && s < notificationDeliveryStatusCount
+}
+
+// IsReportable returns true if the status can be reported by a provider.
+func (s NotificationDeliveryStatus) IsReportable() bool {
+	return s == NotificationDeliveryStatusDelivered || s == NotificationDeliveryStatusBounced
+}
+
+// IsSent returns true if the notification was handed over to the provider.
+func (s NotificationDeliveryStatus) IsSent() bool {
+	return s == NotificationDeliveryStatusSent || s.IsReportable()
+}
+
+// MaskRecipient hides most of an email address or phone number,
+// so delivery records can be investigated without exposing the recipient.
+// Of email addresses the first character of the local part and the domain are kept,
+// of phone numbers the leading plus and the last two digits.
+func MaskRecipient(recipient string) string {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return ""
+	}
+	if at := strings.LastIndex(recipient, "@"); at >= 0 {
+		local := recipient[:at]
+		if local == "" {
+			return recipient
+		}
+		first, _ := utf8.DecodeRuneInString(local)
+		return string(first) + "***" + recipient[at:]
+	}
+	masked := []rune(recipient)
+	visible := 2
+	for i := len(masked) - 1; i >= 0; i-- {
+		if masked[i] < '0' || masked[i] > '9' {
+			continue
+		}
+		if visible > 0 {
+			visible--
+			continue
+		}
+		masked[i] = '*'
+	}
+	return string(masked)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskRecipient(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		want      string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name:      "email",
+			recipient: "gigi@zitadel.com",
+			want:      "g***@zitadel.com",
+		},
+		{
+			name:      "email unicode",
+			recipient: "élise@zitadel.com",
+			want:      "é***@zitadel.com",
+		},
+		{
+			name:      "email without local part",
+			recipient: "@zitadel.com",
+			want:      "@zitadel.com",
+		},
+		{
+			name:      "phone",
+			recipient: "+41791234567",
+			want:      "+*********67",
+		},
+		{
+			name:      "phone formatted",
+			recipient: "+41 79 123 45 67",
+			want:      "+** ** *** ** 67",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskRecipient(tt.recipient))
+		})
+	}
+}
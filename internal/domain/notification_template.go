@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// NotificationTemplateMaxLength limits the size of each part of a notification template.
+	NotificationTemplateMaxLength = 100 * 1024
+)
+
+// NotificationTemplate replaces the rendering of a message type in a specific language.
+// HTML is used for emails, Text for SMS and for emails if no HTML is set.
+// Subject replaces the translated subject of emails if set.
+// All parts are Go templates, see the templates package for the data model and the available functions.
+type NotificationTemplate struct {
+	models.ObjectRoot
+
+	State       PolicyState
+	MessageType string
+	Language    language.Tag
+	Subject     string
+	HTML        string
+	Text        string
+}
+
+func (t *NotificationTemplate) IsValid(supportedLanguages []language.Tag) error {
+	if !IsMessageTextType(t.MessageType) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Nt1mT", "Errors.NotificationTemplate.Invalid")
+	}
+	if t.HTML == "" && t.Text == "" {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Nt2eM", "Errors.NotificationTemplate.Empty")
+	}
+	if len(t.Subject) > NotificationTemplateMaxLength || len(t.HTML) > NotificationTemplateMaxLength || len(t.Text) > NotificationTemplateMaxLength {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Nt3lG", "Errors.NotificationTemplate.TooLong")
+	}
+	if err := LanguageIsDefined(t.Language); err != nil {
+		return err
+	}
+	return LanguagesAreSupported(supportedLanguages, t.Language)
+}
@@ -31,9 +31,11 @@ const (
 	PermissionUserRead            = "user.read"
 	PermissionUserDelete          = "user.delete"
 	PermissionUserCredentialWrite = "user.credential.write"
+	PermissionUserGrantWrite      = "user.grant.write"
 	PermissionSessionWrite        = "session.write"
 	PermissionSessionDelete       = "session.delete"
 	PermissionOrgRead             = "org.read"
+	PermissionOrgWrite            = "org.write"
 	PermissionIDPRead             = "iam.idp.read"
 	PermissionOrgIDPRead          = "org.idp.read"
 )
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type InactivityPolicy struct {
+	models.ObjectRoot
+
+	Default bool
+	// InactivityPeriod after which a user without a successful authentication is deactivated.
+	// Zero disables the deactivation.
+	InactivityPeriod time.Duration
+	// WarningPeriod before the deactivation in which the user is notified.
+	// Zero disables the notification.
+	WarningPeriod time.Duration
+	// DryRun only reports the users which would be warned or deactivated.
+	DryRun bool
+}
+
+func (p *InactivityPolicy) IsValid() bool {
+	return p.InactivityPeriod >= 0 && p.WarningPeriod >= 0 &&
+		(p.InactivityPeriod == 0 || p.WarningPeriod < p.InactivityPeriod)
+}
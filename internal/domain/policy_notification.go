@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// NotificationPolicy defines which notifications about changes to their account are sent to the users
+type NotificationPolicy struct {
+	models.ObjectRoot
+
+	Default        bool
+	PasswordChange bool
+	// NewDeviceSignIn notifies users about a sign-in from a device they never signed in from before
+	NewDeviceSignIn bool
+	MFAAdded        bool
+	MFARemoved      bool
+	// EmailChange notifies users on their previous email address that it was changed
+	EmailChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
+}
@@ -9,6 +9,7 @@ type ProjectGrant struct {
 	GrantedOrgID string
 	State        ProjectGrantState
 	RoleKeys     []string
+	GrantValidity
 }
 
 type ProjectGrantState int32
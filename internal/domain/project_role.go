@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"slices"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type ProjectRole struct {
@@ -10,6 +13,8 @@ type ProjectRole struct {
 	Key         string
 	DisplayName string
 	Group       string
+	// IncludedRoles are the keys of the roles of the same project a grant of the role includes.
+	IncludedRoles []string
 }
 
 type ProjectRoleState int32
@@ -28,6 +33,42 @@ func (p *ProjectRole) IsValid() bool {
 	return p.AggregateID != "" && p.Key != ""
 }
 
+// ProjectRoleHierarchy maps the keys of the roles of a project to the keys of their included roles.
+type ProjectRoleHierarchy map[string][]string
+
+// Expand returns the keys and all keys they include, directly or through other included roles.
+// The keys are returned in the order they are found, each only once.
+func (h ProjectRoleHierarchy) Expand(keys ...string) []string {
+	expanded := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	pending := slices.Clone(keys)
+	for len(pending) > 0 {
+		key := pending[0]
+		pending = pending[1:]
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		expanded = append(expanded, key)
+		pending = append(pending, h[key]...)
+	}
+	return expanded
+}
+
+// ValidateIncludedRoles checks that the included roles exist
+// and that including them in the role does not create a cycle.
+func (h ProjectRoleHierarchy) ValidateIncludedRoles(key string, includedRoles []string) error {
+	for _, included := range includedRoles {
+		if _, ok := h[included]; !ok {
+			return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Ri1nE", "Errors.Project.Role.IncludedRoleNotExisting")
+		}
+		if slices.Contains(h.Expand(included), key) {
+			return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Ri2cY", "Errors.Project.Role.IncludedRoleCycle")
+		}
+	}
+	return nil
+}
+
 func containsRoleKey(roleKey string, validRoles []string) bool {
 	for _, validRole := range validRoles {
 		if roleKey == validRole {
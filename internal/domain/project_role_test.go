@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestProjectRoleHierarchy_Expand(t *testing.T) {
+	hierarchy := ProjectRoleHierarchy{
+		"admin":  {"editor"},
+		"editor": {"viewer"},
+		"viewer": nil,
+		"owner":  {"admin", "viewer"},
+	}
+	tests := []struct {
+		name string
+		keys []string
+		want []string
+	}{
+		{
+			name: "no keys",
+			want: []string{},
+		},
+		{
+			name: "flat role",
+			keys: []string{"viewer"},
+			want: []string{"viewer"},
+		},
+		{
+			name: "transitive",
+			keys: []string{"admin"},
+			want: []string{"admin", "editor", "viewer"},
+		},
+		{
+			name: "duplicates",
+			keys: []string{"owner", "editor"},
+			want: []string{"owner", "editor", "admin", "viewer"},
+		},
+		{
+			name: "unknown role",
+			keys: []string{"unknown"},
+			want: []string{"unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hierarchy.Expand(tt.keys...))
+		})
+	}
+}
+
+func TestProjectRoleHierarchy_ValidateIncludedRoles(t *testing.T) {
+	hierarchy := ProjectRoleHierarchy{
+		"admin":  {"editor"},
+		"editor": {"viewer"},
+		"viewer": nil,
+	}
+	tests := []struct {
+		name     string
+		key      string
+		included []string
+		err      func(error) bool
+	}{
+		{
+			name:     "new role",
+			key:      "owner",
+			included: []string{"admin"},
+		},
+		{
+			name:     "change role",
+			key:      "editor",
+			included: []string{"viewer"},
+		},
+		{
+			name:     "not existing",
+			key:      "owner",
+			included: []string{"unknown"},
+			err:      zerrors.IsPreconditionFailed,
+		},
+		{
+			name:     "self",
+			key:      "admin",
+			included: []string{"admin"},
+			err:      zerrors.IsPreconditionFailed,
+		},
+		{
+			name:     "cycle",
+			key:      "viewer",
+			included: []string{"admin"},
+			err:      zerrors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hierarchy.ValidateIncludedRoles(tt.key, tt.included)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "unexpected error %v", err)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Built-in object types are derived from existing data instead of relation tuples
+// and cannot be defined in an authorization model:
+//   - user:<userID> is the subject of every check
+//   - org:<orgID>#member holds the users of the organization
+//   - org:<orgID>#<roleKey> holds the users granted the role of the project by a grant of the organization
+//   - group:<groupID>#member holds the members of the group
+//   - project:<projectID>#<roleKey> holds the users granted the role of the project by any grant
+//   - project:<projectID>#granted holds the users of organizations the project is granted to
+const (
+	RelationTypeUser    = "user"
+	RelationTypeOrg     = "org"
+	RelationTypeGroup   = "group"
+	RelationTypeProject = "project"
+
+	RelationMember  = "member"
+	RelationGranted = "granted"
+)
+
+var relationNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_\-.]{1,64}$`)
+
+func IsBuiltInRelationType(objectType string) bool {
+	return objectType == RelationTypeUser ||
+		objectType == RelationTypeOrg ||
+		objectType == RelationTypeGroup ||
+		objectType == RelationTypeProject
+}
+
+// AuthorizationModel defines the object types of a project
+// and how subjects are related to their objects.
+type AuthorizationModel struct {
+	Types []*AuthorizationModelType `json:"types,omitempty"`
+}
+
+type AuthorizationModelType struct {
+	Name      string                        `json:"name"`
+	Relations []*AuthorizationModelRelation `json:"relations,omitempty"`
+}
+
+type AuthorizationModelRelation struct {
+	Name string `json:"name"`
+	// DirectSubjects are the subjects relation tuples of the relation can be written for.
+	DirectSubjects []*RelationSubjectType `json:"directSubjects,omitempty"`
+	// ImpliedBy are relations on the same object which include this relation,
+	// e.g. editors are also viewers.
+	ImpliedBy []string `json:"impliedBy,omitempty"`
+	// FromParents include the subjects holding a relation on a parent object,
+	// e.g. viewers of the folder are viewers of its documents.
+	FromParents []*RelationFromParent `json:"fromParents,omitempty"`
+}
+
+// RelationSubjectType is a type of subject,
+// either an object itself (empty Relation) or all subjects holding the Relation on an object of the Type.
+type RelationSubjectType struct {
+	Type     string `json:"type"`
+	Relation string `json:"relation,omitempty"`
+}
+
+func (s *RelationSubjectType) String() string {
+	if s.Relation == "" {
+		return s.Type
+	}
+	return s.Type + "#" + s.Relation
+}
+
+// RelationFromParent references the parent objects by the Tupleset relation on the same object.
+type RelationFromParent struct {
+	Tupleset string `json:"tupleset"`
+	Relation string `json:"relation"`
+}
+
+func (m *AuthorizationModel) Type(name string) *AuthorizationModelType {
+	if m == nil {
+		return nil
+	}
+	for _, t := range m.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func (t *AuthorizationModelType) Relation(name string) *AuthorizationModelRelation {
+	if t == nil {
+		return nil
+	}
+	for _, r := range t.Relations {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Relation returns the definition of the relation, nil if the type or relation is not defined.
+func (m *AuthorizationModel) Relation(objectType, relation string) *AuthorizationModelRelation {
+	return m.Type(objectType).Relation(relation)
+}
+
+// Validate checks that all names are unique and all references point to defined types and relations.
+func (m *AuthorizationModel) Validate() error {
+	if m == nil || len(m.Types) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Rm1tY", "Errors.Project.AuthorizationModel.Invalid")
+	}
+	types := make(map[string]struct{}, len(m.Types))
+	for _, t := range m.Types {
+		if !relationNameRegexp.MatchString(t.Name) || IsBuiltInRelationType(t.Name) {
+			return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm2tN", "Errors.Project.AuthorizationModel.Invalid: type %s", t.Name)
+		}
+		if _, ok := types[t.Name]; ok {
+			return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm3tD", "Errors.Project.AuthorizationModel.Invalid: type %s defined twice", t.Name)
+		}
+		types[t.Name] = struct{}{}
+	}
+	for _, t := range m.Types {
+		if err := m.validateType(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *AuthorizationModel) validateType(t *AuthorizationModelType) error {
+	relations := make(map[string]struct{}, len(t.Relations))
+	for _, r := range t.Relations {
+		if !relationNameRegexp.MatchString(r.Name) {
+			return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm4rN", "Errors.Project.AuthorizationModel.Invalid: relation %s of %s", r.Name, t.Name)
+		}
+		if _, ok := relations[r.Name]; ok {
+			return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm5rD", "Errors.Project.AuthorizationModel.Invalid: relation %s of %s defined twice", r.Name, t.Name)
+		}
+		relations[r.Name] = struct{}{}
+	}
+	for _, r := range t.Relations {
+		if len(r.DirectSubjects) == 0 && len(r.ImpliedBy) == 0 && len(r.FromParents) == 0 {
+			return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm6rE", "Errors.Project.AuthorizationModel.Invalid: relation %s of %s has no subjects", r.Name, t.Name)
+		}
+		for _, subject := range r.DirectSubjects {
+			if !m.isValidSubjectType(subject) {
+				return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm7sT", "Errors.Project.AuthorizationModel.Invalid: subject %s of %s#%s", subject, t.Name, r.Name)
+			}
+		}
+		for _, implied := range r.ImpliedBy {
+			if implied == r.Name || t.Relation(implied) == nil {
+				return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm8iB", "Errors.Project.AuthorizationModel.Invalid: implied relation %s of %s#%s", implied, t.Name, r.Name)
+			}
+		}
+		for _, parent := range r.FromParents {
+			if !m.isValidParent(t, parent) {
+				return zerrors.ThrowInvalidArgumentf(nil, "DOMAIN-Rm9pR", "Errors.Project.AuthorizationModel.Invalid: parent %s->%s of %s#%s", parent.Tupleset, parent.Relation, t.Name, r.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m *AuthorizationModel) isValidSubjectType(subject *RelationSubjectType) bool {
+	switch subject.Type {
+	case RelationTypeUser:
+		return subject.Relation == ""
+	case RelationTypeOrg, RelationTypeProject:
+		// the roles of a project can change, so any relation name is accepted
+		return relationNameRegexp.MatchString(subject.Relation)
+	case RelationTypeGroup:
+		return subject.Relation == RelationMember
+	}
+	t := m.Type(subject.Type)
+	if t == nil {
+		return false
+	}
+	return subject.Relation == "" || t.Relation(subject.Relation) != nil
+}
+
+// isValidParent checks that the tupleset relation references objects
+// and the relation is defined on at least one of their types.
+func (m *AuthorizationModel) isValidParent(t *AuthorizationModelType, parent *RelationFromParent) bool {
+	tupleset := t.Relation(parent.Tupleset)
+	if tupleset == nil || len(tupleset.DirectSubjects) == 0 {
+		return false
+	}
+	var found bool
+	for _, subject := range tupleset.DirectSubjects {
+		if subject.Relation != "" || IsBuiltInRelationType(subject.Type) {
+			return false
+		}
+		found = found || m.Relation(subject.Type, parent.Relation) != nil
+	}
+	return found
+}
+
+// RelationTuple relates the subject to the object,
+// in the notation <objectType>:<objectID>#<relation>@<subjectType>:<subjectID>[#<subjectRelation>]
+type RelationTuple struct {
+	ObjectType      string
+	ObjectID        string
+	Relation        string
+	SubjectType     string
+	SubjectID       string
+	SubjectRelation string
+}
+
+func (t *RelationTuple) String() string {
+	subject := t.SubjectType + ":" + t.SubjectID
+	if t.SubjectRelation != "" {
+		subject += "#" + t.SubjectRelation
+	}
+	return t.ObjectType + ":" + t.ObjectID + "#" + t.Relation + "@" + subject
+}
+
+func (t *RelationTuple) IsValid() bool {
+	return t != nil &&
+		t.ObjectType != "" && t.ObjectID != "" && t.Relation != "" &&
+		t.SubjectType != "" && t.SubjectID != ""
+}
+
+// Subject returns the type of the subject of the tuple.
+func (t *RelationTuple) Subject() *RelationSubjectType {
+	return &RelationSubjectType{Type: t.SubjectType, Relation: t.SubjectRelation}
+}
+
+// ValidateTuple checks that the model allows the tuple.
+func (m *AuthorizationModel) ValidateTuple(tuple *RelationTuple) error {
+	if !tuple.IsValid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Rt1vA", "Errors.Project.RelationTuple.Invalid")
+	}
+	relation := m.Relation(tuple.ObjectType, tuple.Relation)
+	if relation == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Rt2nM", "Errors.Project.RelationTuple.NotInModel")
+	}
+	allowed := slices.ContainsFunc(relation.DirectSubjects, func(subject *RelationSubjectType) bool {
+		return *subject == *tuple.Subject()
+	})
+	if !allowed {
+		return zerrors.ThrowPreconditionFailed(nil, "DOMAIN-Rt3sN", "Errors.Project.RelationTuple.NotInModel")
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func testDocumentModel() *AuthorizationModel {
+	return &AuthorizationModel{
+		Types: []*AuthorizationModelType{
+			{
+				Name: "folder",
+				Relations: []*AuthorizationModelRelation{
+					{
+						Name: "viewer",
+						DirectSubjects: []*RelationSubjectType{
+							{Type: RelationTypeUser},
+							{Type: RelationTypeGroup, Relation: RelationMember},
+						},
+					},
+				},
+			},
+			{
+				Name: "document",
+				Relations: []*AuthorizationModelRelation{
+					{
+						Name:           "parent",
+						DirectSubjects: []*RelationSubjectType{{Type: "folder"}},
+					},
+					{
+						Name: "editor",
+						DirectSubjects: []*RelationSubjectType{
+							{Type: RelationTypeUser},
+							{Type: RelationTypeProject, Relation: "writer"},
+						},
+					},
+					{
+						Name:        "viewer",
+						ImpliedBy:   []string{"editor"},
+						FromParents: []*RelationFromParent{{Tupleset: "parent", Relation: "viewer"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAuthorizationModel_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*AuthorizationModel)
+		valid  bool
+	}{
+		{
+			name:   "valid",
+			modify: func(*AuthorizationModel) {},
+			valid:  true,
+		},
+		{
+			name:   "no types",
+			modify: func(m *AuthorizationModel) { m.Types = nil },
+		},
+		{
+			name:   "built-in type",
+			modify: func(m *AuthorizationModel) { m.Types[0].Name = RelationTypeProject },
+		},
+		{
+			name:   "duplicate type",
+			modify: func(m *AuthorizationModel) { m.Types[1].Name = "folder" },
+		},
+		{
+			name:   "invalid relation name",
+			modify: func(m *AuthorizationModel) { m.Types[0].Relations[0].Name = "view er" },
+		},
+		{
+			name:   "duplicate relation",
+			modify: func(m *AuthorizationModel) { m.Types[1].Relations[1].Name = "parent" },
+		},
+		{
+			name: "relation without subjects",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].ImpliedBy = nil
+				m.Types[1].Relations[2].FromParents = nil
+			},
+		},
+		{
+			name: "unknown subject type",
+			modify: func(m *AuthorizationModel) {
+				m.Types[0].Relations[0].DirectSubjects[0].Type = "team"
+			},
+		},
+		{
+			name: "user subject with relation",
+			modify: func(m *AuthorizationModel) {
+				m.Types[0].Relations[0].DirectSubjects[0].Relation = "member"
+			},
+		},
+		{
+			name: "group subject without member",
+			modify: func(m *AuthorizationModel) {
+				m.Types[0].Relations[0].DirectSubjects[1].Relation = "owner"
+			},
+		},
+		{
+			name: "unknown implied relation",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].ImpliedBy = []string{"owner"}
+			},
+		},
+		{
+			name: "self implied relation",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].ImpliedBy = []string{"viewer"}
+			},
+		},
+		{
+			name: "unknown tupleset",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].FromParents[0].Tupleset = "owner"
+			},
+		},
+		{
+			name: "tupleset of users",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].FromParents[0].Tupleset = "editor"
+			},
+		},
+		{
+			name: "relation not on parent",
+			modify: func(m *AuthorizationModel) {
+				m.Types[1].Relations[2].FromParents[0].Relation = "editor"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := testDocumentModel()
+			tt.modify(model)
+			err := model.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, zerrors.IsErrorInvalidArgument(err), "expected invalid argument, got %v", err)
+		})
+	}
+}
+
+func TestAuthorizationModel_ValidateTuple(t *testing.T) {
+	tests := []struct {
+		name  string
+		tuple *RelationTuple
+		err   func(error) bool
+	}{
+		{
+			name:  "user",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeUser, SubjectID: "user1"},
+		},
+		{
+			name:  "project role",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeProject, SubjectID: "project1", SubjectRelation: "writer"},
+		},
+		{
+			name:  "parent",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "parent", SubjectType: "folder", SubjectID: "folder1"},
+		},
+		{
+			name:  "missing subject",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeUser},
+			err:   zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:  "unknown relation",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "owner", SubjectType: RelationTypeUser, SubjectID: "user1"},
+			err:   zerrors.IsPreconditionFailed,
+		},
+		{
+			name:  "computed relation",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "viewer", SubjectType: RelationTypeUser, SubjectID: "user1"},
+			err:   zerrors.IsPreconditionFailed,
+		},
+		{
+			name:  "subject relation not allowed",
+			tuple: &RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeProject, SubjectID: "project1", SubjectRelation: "reader"},
+			err:   zerrors.IsPreconditionFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testDocumentModel().ValidateTuple(tt.tuple)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestRelationTuple_String(t *testing.T) {
+	assert.Equal(t, "document:1#editor@user:user1",
+		(&RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeUser, SubjectID: "user1"}).String())
+	assert.Equal(t, "document:1#editor@group:group1#member",
+		(&RelationTuple{ObjectType: "document", ObjectID: "1", Relation: "editor", SubjectType: RelationTypeGroup, SubjectID: "group1", SubjectRelation: RelationMember}).String())
+}
@@ -19,6 +19,14 @@ const (
 	RoleSelfManagementGlobal = "SELF_MANAGEMENT_GLOBAL"
 )
 
+const (
+	RoleProjectGrantOwner = "PROJECT_GRANT_OWNER"
+	// RoleProjectAccessApprover can decide on the access requests of a project.
+	RoleProjectAccessApprover = "PROJECT_ACCESS_APPROVER"
+	// RoleProjectGrantAccessApprover can decide on the access requests of a granted project.
+	RoleProjectGrantAccessApprover = "PROJECT_GRANT_ACCESS_APPROVER"
+)
+
 func CheckForInvalidRoles(roles []string, rolePrefix string, validRoles []authz.RoleMapping) []string {
 	invalidRoles := make([]string, 0)
 	for _, role := range roles {
@@ -0,0 +1,174 @@
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed index.schema.v1.json
+	indexJSON string
+
+	indexSchema = jsonschema.MustCompileString(IndexSchemaID, indexJSON)
+)
+
+const (
+	IndexSchemaID = "urn:zitadel:schema:index-schema:v1"
+	IndexProperty = "urn:zitadel:schema:index"
+)
+
+// UniqueScope defines within which resource the value of a field must be unique.
+type UniqueScope int32
+
+const (
+	UniqueScopeNone UniqueScope = iota
+	UniqueScopeOrganization
+	UniqueScopeInstance
+)
+
+// IndexedField is a field of the user data annotated with the index extension.
+type IndexedField struct {
+	// Path is the JSON pointer (RFC 6901) of the field in the data of the user.
+	Path       string
+	Unique     UniqueScope
+	Searchable bool
+}
+
+// Indexed returns if the value of the field is stored to search users by it.
+// Unique fields are always indexed.
+func (f *IndexedField) Indexed() bool {
+	return f.Searchable || f.Unique != UniqueScopeNone
+}
+
+// IndexedValue is the primitive value of an indexed field in the data of a user.
+type IndexedValue struct {
+	Field *IndexedField
+	// Value is either a string, float64 or bool
+	Value any
+}
+
+type indexExtension struct{}
+
+// Compile implements the [jsonschema.ExtCompiler] interface.
+// It parses the index schema extension / annotation of the passed field.
+// The annotation does not validate the data, it's evaluated by [IndexedFields].
+func (indexExtension) Compile(_ jsonschema.CompilerContext, m map[string]interface{}) (jsonschema.ExtSchema, error) {
+	index, ok := m[IndexProperty]
+	if !ok {
+		return nil, nil
+	}
+	_, err := mapIndex("", index)
+	return nil, err
+}
+
+func mapIndex(path string, value any) (*IndexedField, error) {
+	index, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SCHEMA-Idx1t", "invalid index")
+	}
+	field := &IndexedField{Path: path}
+	for key, value := range index {
+		switch key {
+		case "unique":
+			switch value {
+			case "organization":
+				field.Unique = UniqueScopeOrganization
+			case "instance":
+				field.Unique = UniqueScopeInstance
+			default:
+				return nil, zerrors.ThrowInvalidArgumentf(nil, "SCHEMA-Idx2u", "invalid unique scope %v", value)
+			}
+		case "searchable":
+			searchable, ok := value.(bool)
+			if !ok {
+				return nil, zerrors.ThrowInvalidArgumentf(nil, "SCHEMA-Idx3s", "invalid searchable %v", value)
+			}
+			field.Searchable = searchable
+		default:
+			return nil, zerrors.ThrowInvalidArgumentf(nil, "SCHEMA-Idx4k", "invalid index property %s", key)
+		}
+	}
+	return field, nil
+}
+
+// IndexedFields returns the fields of the schema which are annotated with the index extension.
+// Only fields reachable through nested `properties` are considered.
+func IndexedFields(schema json.RawMessage) ([]*IndexedField, error) {
+	if len(schema) == 0 {
+		return nil, nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SCHEMA-Idx5j", "Errors.UserSchema.Invalid")
+	}
+	var fields []*IndexedField
+	if err := collectIndexedFields(root, "", &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func collectIndexedFields(node map[string]any, path string, fields *[]*IndexedField) error {
+	if index, ok := node[IndexProperty]; ok && path != "" {
+		field, err := mapIndex(path, index)
+		if err != nil {
+			return err
+		}
+		if field.Indexed() {
+			*fields = append(*fields, field)
+		}
+	}
+	properties, ok := node["properties"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		property, ok := properties[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		if err := collectIndexedFields(property, path+"/"+escapePointerToken(name), fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// IndexedValues returns the primitive values of the indexed fields in the data.
+// Missing fields, null values, objects and arrays are not indexed.
+func IndexedValues(fields []*IndexedField, data json.RawMessage) ([]*IndexedValue, error) {
+	if len(fields) == 0 || len(data) == 0 {
+		return nil, nil
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SCHEMA-Idx6d", "Errors.User.Invalid")
+	}
+	values := make([]*IndexedValue, 0, len(fields))
+	for _, field := range fields {
+		value, ok := get(doc, field.Path)
+		if !ok {
+			continue
+		}
+		switch value.(type) {
+		case string, float64, bool:
+			values = append(values, &IndexedValue{Field: field, Value: value})
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,166 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexExtension(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{
+			"valid index",
+			`{
+				"type": "object",
+				"properties": {
+					"employeeNumber": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"unique": "organization",
+							"searchable": true
+						}
+					}
+				}
+			}`,
+			false,
+		},
+		{
+			"invalid unique scope",
+			`{
+				"type": "object",
+				"properties": {
+					"employeeNumber": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"unique": "project"
+						}
+					}
+				}
+			}`,
+			true,
+		},
+		{
+			"invalid searchable",
+			`{
+				"type": "object",
+				"properties": {
+					"employeeNumber": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"searchable": "yes"
+						}
+					}
+				}
+			}`,
+			true,
+		},
+		{
+			"unknown property",
+			`{
+				"type": "object",
+				"properties": {
+					"employeeNumber": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"sortable": true
+						}
+					}
+				}
+			}`,
+			true,
+		},
+		{
+			"invalid by meta schema",
+			`{
+				"$schema": "urn:zitadel:schema:v1",
+				"type": "object",
+				"properties": {
+					"employeeNumber": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"unique": "project"
+						}
+					}
+				}
+			}`,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSchema(RoleOwner, strings.NewReader(tt.schema))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestIndexedFields(t *testing.T) {
+	schema := json.RawMessage(`{
+		"$schema": "urn:zitadel:schema:v1",
+		"type": "object",
+		"properties": {
+			"name": {
+				"type": "string"
+			},
+			"employeeNumber": {
+				"type": "string",
+				"urn:zitadel:schema:index": {
+					"unique": "organization"
+				}
+			},
+			"socialSecurityNumber": {
+				"type": "number",
+				"urn:zitadel:schema:index": {
+					"unique": "instance"
+				}
+			},
+			"address": {
+				"type": "object",
+				"properties": {
+					"city/town": {
+						"type": "string",
+						"urn:zitadel:schema:index": {
+							"searchable": true
+						}
+					}
+				}
+			},
+			"nickName": {
+				"type": "string",
+				"urn:zitadel:schema:index": {
+					"searchable": false
+				}
+			}
+		}
+	}`)
+	fields, err := IndexedFields(schema)
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexedField{
+		{Path: "/address/city~1town", Searchable: true},
+		{Path: "/employeeNumber", Unique: UniqueScopeOrganization},
+		{Path: "/socialSecurityNumber", Unique: UniqueScopeInstance},
+	}, fields)
+
+	values, err := IndexedValues(fields, json.RawMessage(`{
+		"name": "Gigi",
+		"employeeNumber": "E-123",
+		"socialSecurityNumber": 756,
+		"address": {"city/town": ["Zurich"]}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexedValue{
+		{Field: fields[1], Value: "E-123"},
+		{Field: fields[2], Value: float64(756)},
+	}, values)
+}
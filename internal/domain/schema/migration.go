@@ -0,0 +1,372 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Migration transforms the data of a user from the previous revision of a schema to the revision it's attached to.
+// The steps are applied in the order: renames, defaults, patch.
+// All paths are JSON pointers (RFC 6901), e.g. "/address/street".
+type Migration struct {
+	// Renames move the value of a field to a new path.
+	// Renames of fields which do not exist are ignored.
+	Renames []*MigrationRename `json:"renames,omitempty"`
+	// Defaults set the value of a field if it does not exist.
+	Defaults []*MigrationDefault `json:"defaults,omitempty"`
+	// Patch is a JSON Patch (RFC 6902) style list of operations.
+	Patch []*PatchOperation `json:"patch,omitempty"`
+}
+
+type MigrationRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type MigrationDefault struct {
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type PatchOperationType string
+
+const (
+	PatchOperationAdd     PatchOperationType = "add"
+	PatchOperationRemove  PatchOperationType = "remove"
+	PatchOperationReplace PatchOperationType = "replace"
+	PatchOperationMove    PatchOperationType = "move"
+	PatchOperationCopy    PatchOperationType = "copy"
+	PatchOperationTest    PatchOperationType = "test"
+)
+
+type PatchOperation struct {
+	Op    PatchOperationType `json:"op"`
+	Path  string             `json:"path"`
+	From  string             `json:"from,omitempty"`
+	Value json.RawMessage    `json:"value,omitempty"`
+}
+
+func (m *Migration) IsEmpty() bool {
+	return m == nil || len(m.Renames) == 0 && len(m.Defaults) == 0 && len(m.Patch) == 0
+}
+
+// Valid checks the syntax of the migration, it does not check if it can be applied to any data.
+func (m *Migration) Valid() error {
+	if m == nil {
+		return nil
+	}
+	for _, rename := range m.Renames {
+		if !validPointer(rename.From) || !validPointer(rename.To) || rename.From == "" || rename.To == "" {
+			return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig1r", "Errors.UserSchema.Migration.Invalid")
+		}
+	}
+	for _, def := range m.Defaults {
+		if !validPointer(def.Path) || def.Path == "" || !json.Valid(def.Value) {
+			return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig2d", "Errors.UserSchema.Migration.Invalid")
+		}
+	}
+	for _, op := range m.Patch {
+		if err := op.valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (op *PatchOperation) valid() error {
+	if !validPointer(op.Path) {
+		return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig3p", "Errors.UserSchema.Migration.Invalid")
+	}
+	switch op.Op {
+	case PatchOperationAdd, PatchOperationReplace, PatchOperationTest:
+		if !json.Valid(op.Value) {
+			return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig4v", "Errors.UserSchema.Migration.Invalid")
+		}
+	case PatchOperationMove, PatchOperationCopy:
+		if !validPointer(op.From) {
+			return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig5f", "Errors.UserSchema.Migration.Invalid")
+		}
+	case PatchOperationRemove:
+	default:
+		return zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig6o", "Errors.UserSchema.Migration.Invalid")
+	}
+	return nil
+}
+
+// Apply returns the migrated data.
+func (m *Migration) Apply(data json.RawMessage) (json.RawMessage, error) {
+	if m.IsEmpty() {
+		return data, nil
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SCHEMA-Mig7u", "Errors.User.Invalid")
+	}
+	var err error
+	for _, rename := range m.Renames {
+		value, ok := get(doc, rename.From)
+		if !ok {
+			continue
+		}
+		if doc, err = remove(doc, rename.From); err != nil {
+			return nil, err
+		}
+		if doc, err = add(doc, rename.To, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, def := range m.Defaults {
+		if _, ok := get(doc, def.Path); ok {
+			continue
+		}
+		value, err := unmarshalValue(def.Value)
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = add(doc, def.Path, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, op := range m.Patch {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, err
+		}
+	}
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SCHEMA-Mig8m", "Errors.Internal")
+	}
+	return migrated, nil
+}
+
+func (op *PatchOperation) apply(doc any) (any, error) {
+	switch op.Op {
+	case PatchOperationAdd:
+		value, err := unmarshalValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, value)
+	case PatchOperationRemove:
+		return remove(doc, op.Path)
+	case PatchOperationReplace:
+		value, err := unmarshalValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := get(doc, op.Path); !ok {
+			return nil, errPatchNotApplicable()
+		}
+		if doc, err = remove(doc, op.Path); err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, value)
+	case PatchOperationMove:
+		value, ok := get(doc, op.From)
+		if !ok {
+			return nil, errPatchNotApplicable()
+		}
+		doc, err := remove(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, value)
+	case PatchOperationCopy:
+		value, ok := get(doc, op.From)
+		if !ok {
+			return nil, errPatchNotApplicable()
+		}
+		return add(doc, op.Path, deepCopy(value))
+	case PatchOperationTest:
+		expected, err := unmarshalValue(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := get(doc, op.Path)
+		if !ok || !reflect.DeepEqual(value, expected) {
+			return nil, errPatchNotApplicable()
+		}
+		return doc, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "SCHEMA-Mig9o", "Errors.UserSchema.Migration.Invalid")
+	}
+}
+
+func errPatchNotApplicable() error {
+	return zerrors.ThrowPreconditionFailed(nil, "SCHEMA-Mig10", "Errors.UserSchema.Migration.NotApplicable")
+}
+
+func unmarshalValue(raw json.RawMessage) (value any, err error) {
+	if err = json.Unmarshal(raw, &value); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SCHEMA-Mig11", "Errors.UserSchema.Migration.Invalid")
+	}
+	return value, nil
+}
+
+func validPointer(pointer string) bool {
+	return pointer == "" || strings.HasPrefix(pointer, "/")
+}
+
+// splitPointer returns the unescaped reference tokens of the JSON pointer.
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func get(doc any, pointer string) (any, bool) {
+	current := doc
+	for _, token := range splitPointer(pointer) {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// add sets the value at the pointer, missing parent objects are created.
+// Array elements are inserted at the index, "-" appends to the array.
+func add(doc any, pointer string, value any) (any, error) {
+	tokens := splitPointer(pointer)
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return addTokens(doc, tokens, value)
+}
+
+func addTokens(node any, tokens []string, value any) (any, error) {
+	token := tokens[0]
+	switch n := node.(type) {
+	case nil:
+		if len(tokens) == 1 {
+			return map[string]any{token: value}, nil
+		}
+		child, err := addTokens(nil, tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{token: child}, nil
+	case map[string]any:
+		if len(tokens) == 1 {
+			n[token] = value
+			return n, nil
+		}
+		child, err := addTokens(n[token], tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []any:
+		if len(tokens) == 1 {
+			if token == "-" {
+				return append(n, value), nil
+			}
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i > len(n) {
+				return nil, errPatchNotApplicable()
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(n) {
+			return nil, errPatchNotApplicable()
+		}
+		child, err := addTokens(n[i], tokens[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	default:
+		return nil, errPatchNotApplicable()
+	}
+}
+
+func remove(doc any, pointer string) (any, error) {
+	tokens := splitPointer(pointer)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return removeTokens(doc, tokens)
+}
+
+func removeTokens(node any, tokens []string) (any, error) {
+	token := tokens[0]
+	switch n := node.(type) {
+	case map[string]any:
+		if _, ok := n[token]; !ok {
+			return nil, errPatchNotApplicable()
+		}
+		if len(tokens) == 1 {
+			delete(n, token)
+			return n, nil
+		}
+		child, err := removeTokens(n[token], tokens[1:])
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []any:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(n) {
+			return nil, errPatchNotApplicable()
+		}
+		if len(tokens) == 1 {
+			return append(n[:i], n[i+1:]...), nil
+		}
+		child, err := removeTokens(n[i], tokens[1:])
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	default:
+		return nil, errPatchNotApplicable()
+	}
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for key, child := range v {
+			c[key] = deepCopy(child)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, child := range v {
+			c[i] = deepCopy(child)
+		}
+		return c
+	default:
+		return v
+	}
+}
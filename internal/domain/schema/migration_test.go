@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestMigration_Valid(t *testing.T) {
+	tests := []struct {
+		name      string
+		migration *Migration
+		wantErr   bool
+	}{
+		{
+			"nil",
+			nil,
+			false,
+		},
+		{
+			"valid",
+			&Migration{
+				Renames:  []*MigrationRename{{From: "/name", To: "/fullName"}},
+				Defaults: []*MigrationDefault{{Path: "/active", Value: json.RawMessage(`true`)}},
+				Patch: []*PatchOperation{
+					{Op: PatchOperationRemove, Path: "/legacy"},
+					{Op: PatchOperationCopy, From: "/fullName", Path: "/displayName"},
+				},
+			},
+			false,
+		},
+		{
+			"rename without pointer",
+			&Migration{
+				Renames: []*MigrationRename{{From: "name", To: "/fullName"}},
+			},
+			true,
+		},
+		{
+			"default with invalid value",
+			&Migration{
+				Defaults: []*MigrationDefault{{Path: "/active", Value: json.RawMessage(`tru`)}},
+			},
+			true,
+		},
+		{
+			"unknown operation",
+			&Migration{
+				Patch: []*PatchOperation{{Op: "merge", Path: "/name"}},
+			},
+			true,
+		},
+		{
+			"move without from",
+			&Migration{
+				Patch: []*PatchOperation{{Op: PatchOperationMove, From: "name", Path: "/name"}},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.migration.Valid()
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMigration_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		migration *Migration
+		data      string
+		want      string
+		wantErr   func(error) bool
+	}{
+		{
+			"empty migration",
+			&Migration{},
+			`{"name":"gigi"}`,
+			`{"name":"gigi"}`,
+			nil,
+		},
+		{
+			"rename",
+			&Migration{
+				Renames: []*MigrationRename{
+					{From: "/name", To: "/profile/fullName"},
+					{From: "/missing", To: "/other"},
+				},
+			},
+			`{"name":"gigi","age":5}`,
+			`{"age":5,"profile":{"fullName":"gigi"}}`,
+			nil,
+		},
+		{
+			"defaults only for missing fields",
+			&Migration{
+				Defaults: []*MigrationDefault{
+					{Path: "/active", Value: json.RawMessage(`true`)},
+					{Path: "/name", Value: json.RawMessage(`"default"`)},
+				},
+			},
+			`{"name":"gigi"}`,
+			`{"active":true,"name":"gigi"}`,
+			nil,
+		},
+		{
+			"patch",
+			&Migration{
+				Patch: []*PatchOperation{
+					{Op: PatchOperationTest, Path: "/type", Value: json.RawMessage(`"giraffe"`)},
+					{Op: PatchOperationReplace, Path: "/type", Value: json.RawMessage(`"animal"`)},
+					{Op: PatchOperationAdd, Path: "/tags/-", Value: json.RawMessage(`"tall"`)},
+					{Op: PatchOperationAdd, Path: "/tags/0", Value: json.RawMessage(`"first"`)},
+					{Op: PatchOperationMove, From: "/nick", Path: "/nickName"},
+					{Op: PatchOperationCopy, From: "/nickName", Path: "/displayName"},
+					{Op: PatchOperationRemove, Path: "/legacy"},
+				},
+			},
+			`{"type":"giraffe","tags":["spotted"],"nick":"gigi","legacy":{"a":1}}`,
+			`{"type":"animal","tags":["first","spotted","tall"],"nickName":"gigi","displayName":"gigi"}`,
+			nil,
+		},
+		{
+			"escaped pointer",
+			&Migration{
+				Renames: []*MigrationRename{{From: "/a~1b", To: "/c~0d"}},
+			},
+			`{"a/b":1}`,
+			`{"c~d":1}`,
+			nil,
+		},
+		{
+			"failed test",
+			&Migration{
+				Patch: []*PatchOperation{{Op: PatchOperationTest, Path: "/type", Value: json.RawMessage(`"cat"`)}},
+			},
+			`{"type":"giraffe"}`,
+			"",
+			zerrors.IsPreconditionFailed,
+		},
+		{
+			"remove missing field",
+			&Migration{
+				Patch: []*PatchOperation{{Op: PatchOperationRemove, Path: "/missing"}},
+			},
+			`{"type":"giraffe"}`,
+			"",
+			zerrors.IsPreconditionFailed,
+		},
+		{
+			"invalid data",
+			&Migration{
+				Patch: []*PatchOperation{{Op: PatchOperationRemove, Path: "/missing"}},
+			},
+			`{"type":`,
+			"",
+			zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.migration.Apply(json.RawMessage(tt.data))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -26,6 +26,9 @@ const (
 	RoleUnspecified Role = iota
 	RoleSelf
 	RoleOwner
+	// RoleSystem ignores the permissions and only validates the data against the schema,
+	// e.g. to revalidate existing data during a migration.
+	RoleSystem
 )
 
 type permissionExtension struct {
@@ -82,6 +85,8 @@ func (s permissionExtensionConfig) Validate(ctx jsonschema.ValidationContext, v
 			return ctx.Error("permission", "missing required permission")
 		}
 		return nil
+	case RoleSystem:
+		return nil
 	case RoleUnspecified:
 		fallthrough
 	default:
@@ -189,6 +189,49 @@ func TestPermissionExtension(t *testing.T) {
 				validationErr: false,
 			},
 		},
+		{
+			"system role ignores permissions, ok",
+			args{
+				role: RoleSystem,
+				schema: `{
+							"type": "object",
+							"properties": {
+								"name": {
+									"type": "string",
+									"urn:zitadel:schema:permission": {
+										"owner": "r",
+										"self": "r"
+									}
+								}
+							}
+						}`,
+				instance: `{ "name": "test"}`,
+			},
+			want{
+				validationErr: false,
+			},
+		},
+		{
+			"system role invalid type, validation err",
+			args{
+				role: RoleSystem,
+				schema: `{
+							"type": "object",
+							"properties": {
+								"name": {
+									"type": "string",
+									"urn:zitadel:schema:permission": {
+										"owner": "r"
+									}
+								}
+							}
+						}`,
+				instance: `{ "name": 1}`,
+			},
+			want{
+				validationErr: true,
+			},
+		},
 		{
 			"no Role, validation err",
 			args{
@@ -24,12 +24,16 @@ func NewSchema(role Role, r io.Reader) (*jsonschema.Schema, error) {
 	if err := c.AddResource(PermissionSchemaID, strings.NewReader(permissionJSON)); err != nil {
 		return nil, err
 	}
+	if err := c.AddResource(IndexSchemaID, strings.NewReader(indexJSON)); err != nil {
+		return nil, err
+	}
 	if err := c.AddResource(MetaSchemaID, strings.NewReader(zitadelJSON)); err != nil {
 		return nil, err
 	}
 	c.RegisterExtension(PermissionSchemaID, permissionSchema, permissionExtension{
 		role,
 	})
+	c.RegisterExtension(IndexSchemaID, indexSchema, indexExtension{})
 	if err := c.AddResource("schema.json", r); err != nil {
 		return nil, zerrors.ThrowInvalidArgument(err, "COMMA-Frh42", "Errors.UserSchema.Invalid")
 	}
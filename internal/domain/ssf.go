@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"slices"
+
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// SSFStreamState is the state of a Shared Signals Framework stream.
+// Enabled, paused and disabled correspond to the stream status of the SSF specification.
+type SSFStreamState int32
+
+const (
+	SSFStreamStateUnspecified SSFStreamState = iota
+	SSFStreamStateEnabled
+	SSFStreamStatePaused
+	SSFStreamStateDisabled
+	SSFStreamStateRemoved
+)
+
+func (s SSFStreamState) Exists() bool {
+	return s != SSFStreamStateUnspecified && s != SSFStreamStateRemoved
+}
+
+// String returns the status value used by the SSF specification.
+func (s SSFStreamState) String() string {
+	switch s {
+	case SSFStreamStateEnabled:
+		return "enabled"
+	case SSFStreamStatePaused:
+		return "paused"
+	case SSFStreamStateDisabled:
+		return "disabled"
+	case SSFStreamStateUnspecified, SSFStreamStateRemoved:
+		return ""
+	}
+	return ""
+}
+
+// SSFStreamStateFromString parses the status value used by the SSF specification.
+func SSFStreamStateFromString(status string) (SSFStreamState, bool) {
+	switch status {
+	case "enabled":
+		return SSFStreamStateEnabled, true
+	case "paused":
+		return SSFStreamStatePaused, true
+	case "disabled":
+		return SSFStreamStateDisabled, true
+	}
+	return SSFStreamStateUnspecified, false
+}
+
+const (
+	// SSFDeliveryMethodPush is the push based SET delivery using HTTP (RFC 8935)
+	SSFDeliveryMethodPush = "urn:ietf:rfc:8935"
+	// SSFDeliveryMethodPoll is the poll based SET delivery using HTTP (RFC 8936), which is not supported
+	SSFDeliveryMethodPoll = "urn:ietf:rfc:8936"
+)
+
+const (
+	SSFEventTypeVerification  = "https://schemas.openid.net/secevent/ssf/event-type/verification"
+	SSFEventTypeStreamUpdated = "https://schemas.openid.net/secevent/ssf/event-type/stream-updated"
+
+	CAEPEventTypeSessionRevoked       = "https://schemas.openid.net/secevent/caep/event-type/session-revoked"
+	CAEPEventTypeCredentialChange     = "https://schemas.openid.net/secevent/caep/event-type/credential-change"
+	CAEPEventTypeAssuranceLevelChange = "https://schemas.openid.net/secevent/caep/event-type/assurance-level-change"
+
+	RISCEventTypeAccountDisabled      = "https://schemas.openid.net/secevent/risc/event-type/account-disabled"
+	RISCEventTypeCredentialCompromise = "https://schemas.openid.net/secevent/risc/event-type/credential-compromise"
+)
+
+// SSFSupportedEventTypes are the CAEP and RISC event types a receiver can request.
+// Verification and stream updated events are always delivered and don't need to be requested.
+var SSFSupportedEventTypes = []string{
+	CAEPEventTypeSessionRevoked,
+	CAEPEventTypeCredentialChange,
+	CAEPEventTypeAssuranceLevelChange,
+	RISCEventTypeAccountDisabled,
+	RISCEventTypeCredentialCompromise,
+}
+
+// SSFEventsDelivered returns the requested event types which are supported by ZITADEL.
+func SSFEventsDelivered(requested []string) []string {
+	delivered := make([]string, 0, len(requested))
+	for _, eventType := range SSFSupportedEventTypes {
+		if slices.Contains(requested, eventType) {
+			delivered = append(delivered, eventType)
+		}
+	}
+	return delivered
+}
+
+// SSFStream is the configuration of a stream registered by a receiver.
+type SSFStream struct {
+	es_models.ObjectRoot
+
+	Description     string
+	Audience        []string
+	EventsRequested []string
+	DeliveryMethod  string
+	EndpointURL     string
+	// AuthorizationHeader is sent as is in the Authorization header when pushing SETs to the receiver
+	AuthorizationHeader string
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSFEventsDelivered(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		want      []string
+	}{
+		{
+			name: "none requested",
+			want: []string{},
+		},
+		{
+			name:      "unsupported requested",
+			requested: []string{"https://schemas.openid.net/secevent/risc/event-type/account-purged"},
+			want:      []string{},
+		},
+		{
+			name: "supported and unsupported requested",
+			requested: []string{
+				RISCEventTypeAccountDisabled,
+				"https://schemas.openid.net/secevent/risc/event-type/account-purged",
+				CAEPEventTypeSessionRevoked,
+			},
+			want: []string{
+				CAEPEventTypeSessionRevoked,
+				RISCEventTypeAccountDisabled,
+			},
+		},
+		{
+			name:      "verification is not requestable",
+			requested: []string{SSFEventTypeVerification},
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SSFEventsDelivered(tt.requested))
+		})
+	}
+}
+
+func TestSSFStreamStateFromString(t *testing.T) {
+	tests := []struct {
+		status string
+		want   SSFStreamState
+		wantOk bool
+	}{
+		{status: "enabled", want: SSFStreamStateEnabled, wantOk: true},
+		{status: "paused", want: SSFStreamStatePaused, wantOk: true},
+		{status: "disabled", want: SSFStreamStateDisabled, wantOk: true},
+		{status: "removed", want: SSFStreamStateUnspecified, wantOk: false},
+		{status: "", want: SSFStreamStateUnspecified, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			got, ok := SSFStreamStateFromString(tt.status)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+			if ok {
+				assert.Equal(t, tt.status, got.String())
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ type UserGrant struct {
 	ProjectID      string
 	ProjectGrantID string
 	RoleKeys       []string
+	GrantValidity
 }
 
 type UserGrantState int32
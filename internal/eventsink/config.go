@@ -0,0 +1,109 @@
+package eventsink
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	// Sinks publish the selected events to their output.
+	// Each sink stores its own position, so sinks can be added at any time
+	// and start with the first selected event.
+	Sinks []*SinkConfig
+}
+
+type SinkConfig struct {
+	// Name identifies the sink.
+	// The position of the sink is stored under this name, renaming a sink publishes all events again.
+	Name    string
+	Enabled bool
+	// Instances restricts the sink to the given instance ids.
+	// If empty, the events of all instances are published.
+	Instances []string
+	// Events maps the aggregate types to the event types which are published.
+	Events map[string][]string
+	// Exactly one output must be configured
+	File  *FileConfig
+	NATS  *NATSConfig
+	Kafka *KafkaConfig
+	HTTP  *HTTPConfig
+}
+
+type FileConfig struct {
+	// Path of the JSON lines file the messages are appended to.
+	Path string
+	// MaxSize in bytes after which the file is rotated.
+	// Rotated files are suffixed with the time of the rotation.
+	MaxSize int64
+	// MaxBackups is the number of rotated files which are kept, 0 keeps all.
+	MaxBackups int
+}
+
+type NATSConfig struct {
+	// URL of the server, for example nats://localhost:4222 or tls://localhost:4222
+	URL string
+	// SubjectPrefix is prepended to the subject of the messages,
+	// which is built as <SubjectPrefix>.<instance id>.<event type>
+	SubjectPrefix string
+	Username      string
+	Password      string
+	Token         string
+	Timeout       time.Duration
+}
+
+type KafkaConfig struct {
+	// Brokers used to discover the cluster, for example localhost:9092
+	Brokers []string
+	// Topic the messages are produced to, the records are keyed by the aggregate
+	Topic string
+	// TLS enables TLS for the connections to the brokers
+	TLS bool
+	// SASLMechanism is one of PLAIN, SCRAM-SHA-256 and SCRAM-SHA-512.
+	// If empty, SASL authentication is disabled.
+	SASLMechanism string
+	Username      string
+	Password      string
+	Timeout       time.Duration
+}
+
+type HTTPConfig struct {
+	// URL the messages are posted to
+	URL     string
+	Headers http.Header
+	Timeout time.Duration
+}
+
+var sinkName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func (c *SinkConfig) validate() error {
+	if !sinkName.MatchString(c.Name) {
+		return zerrors.ThrowInvalidArgumentf(nil, "SINK-Wq3nd", "invalid sink name %q, only lower case letters, digits and underscores are allowed", c.Name)
+	}
+	if len(c.Events) == 0 {
+		return zerrors.ThrowInvalidArgumentf(nil, "SINK-p0Ckz", "sink %s selects no events", c.Name)
+	}
+	outputs := 0
+	for _, configured := range []bool{c.File != nil, c.NATS != nil, c.Kafka != nil, c.HTTP != nil} {
+		if configured {
+			outputs++
+		}
+	}
+	if outputs != 1 {
+		return zerrors.ThrowInvalidArgumentf(nil, "SINK-8hFvX", "sink %s must configure exactly one output", c.Name)
+	}
+	return nil
+}
+
+func (c *SinkConfig) eventTypes() map[eventstore.AggregateType][]eventstore.EventType {
+	eventTypes := make(map[eventstore.AggregateType][]eventstore.EventType, len(c.Events))
+	for aggregateType, types := range c.Events {
+		for _, eventType := range types {
+			eventTypes[eventstore.AggregateType(aggregateType)] = append(eventTypes[eventstore.AggregateType(aggregateType)], eventstore.EventType(eventType))
+		}
+	}
+	return eventTypes
+}
@@ -0,0 +1,230 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	defaultFileMaxSize  = 100 << 20
+	rotatedFileTimeForm = "20060102T150405.000000000"
+)
+
+// FileOutput appends the messages as JSON lines to a file.
+// Messages which were already written to the current file are skipped,
+// so an event published again after a restart is written once.
+type FileOutput struct {
+	config *FileConfig
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	// lastPosition and lastIDs are the position and the ids of the messages at this position written last
+	lastPosition float64
+	lastIDs      []string
+	now          nowFunc
+}
+
+// nowFunc makes [time.Now] mockable
+type nowFunc func() time.Time
+
+func NewFileOutput(config *FileConfig) (*FileOutput, error) {
+	if config.Path == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SINK-4m2Rs", "file path is empty")
+	}
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultFileMaxSize
+	}
+	output := &FileOutput{
+		config: config,
+		now:    time.Now,
+	}
+	if err := output.open(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// open opens the file and reads the messages written last.
+func (o *FileOutput) open() (err error) {
+	if err = os.MkdirAll(filepath.Dir(o.config.Path), 0o750); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Xc8qa", "unable to create directory")
+	}
+	o.file, err = os.OpenFile(o.config.Path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o640)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Ml2aW", "unable to open file")
+	}
+	o.lastPosition = 0
+	o.lastIDs = nil
+	if err = o.readWritten(o.file); err != nil {
+		return err
+	}
+	if err = o.terminateLastLine(); err != nil {
+		return err
+	}
+	if len(o.lastIDs) > 0 {
+		return nil
+	}
+	// the file was rotated right before the last message was written
+	backups, err := o.backups()
+	if err != nil || len(backups) == 0 {
+		return err
+	}
+	backup, err := os.Open(backups[len(backups)-1])
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-i3VbC", "unable to open rotated file")
+	}
+	defer backup.Close()
+	return o.readWritten(backup)
+}
+
+// readWritten reads the position and the ids of the messages written last.
+func (o *FileOutput) readWritten(file *os.File) error {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		message := new(Message)
+		// lines which were written partially are ignored
+		if err := json.Unmarshal(scanner.Bytes(), message); err != nil {
+			continue
+		}
+		o.written(message)
+	}
+	if err := scanner.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Q1xfm", "unable to read file")
+	}
+	return nil
+}
+
+// terminateLastLine ends a line which was written partially,
+// so the next message starts on a new line.
+func (o *FileOutput) terminateLastLine() error {
+	info, err := o.file.Stat()
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-n7Twe", "unable to stat file")
+	}
+	o.size = info.Size()
+	if o.size == 0 {
+		return nil
+	}
+	last := make([]byte, 1)
+	if _, err = o.file.ReadAt(last, o.size-1); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Jz5uR", "unable to read file")
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	if _, err = o.file.Write([]byte{'\n'}); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Y4bLo", "unable to write file")
+	}
+	o.size++
+	return nil
+}
+
+func (o *FileOutput) written(message *Message) {
+	if message.Position != o.lastPosition {
+		o.lastPosition = message.Position
+		o.lastIDs = o.lastIDs[:0]
+	}
+	o.lastIDs = append(o.lastIDs, message.ID)
+}
+
+// alreadyWritten returns true for messages older than the last message,
+// the messages are published in the order of their position.
+func (o *FileOutput) alreadyWritten(message *Message) bool {
+	return message.Position < o.lastPosition ||
+		message.Position == o.lastPosition && slices.Contains(o.lastIDs, message.ID)
+}
+
+// Publish implements [Output]
+func (o *FileOutput) Publish(_ context.Context, message *Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.alreadyWritten(message) {
+		return nil
+	}
+	line, err := json.Marshal(message)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	line = append(line, '\n')
+	if o.size > 0 && o.size+int64(len(line)) > o.config.MaxSize {
+		if err = o.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err = o.file.Write(line); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-0rSkd", "unable to write message")
+	}
+	// the message must be persisted before the position of the sink is stored
+	if err = o.file.Sync(); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-sV1qE", "unable to sync file")
+	}
+	o.size += int64(len(line))
+	o.written(message)
+	return nil
+}
+
+// rotate renames the current file and opens a new one.
+// The messages written last are read from the rotated file, so they aren't written to the new file again.
+func (o *FileOutput) rotate() error {
+	if err := o.file.Close(); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-3uYhT", "unable to close file")
+	}
+	ext := filepath.Ext(o.config.Path)
+	rotated := strings.TrimSuffix(o.config.Path, ext) + "-" + o.now().UTC().Format(rotatedFileTimeForm) + ext
+	if err := os.Rename(o.config.Path, rotated); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-dE6kr", "unable to rotate file")
+	}
+	if err := o.open(); err != nil {
+		return err
+	}
+	return o.removeBackups()
+}
+
+// removeBackups removes the oldest rotated files exceeding [FileConfig.MaxBackups].
+func (o *FileOutput) removeBackups() error {
+	if o.config.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := o.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > o.config.MaxBackups {
+		if err = os.Remove(backups[0]); err != nil {
+			return zerrors.ThrowInternal(err, "SINK-Lk1eN", "unable to remove rotated file")
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backups returns the rotated files, the oldest first.
+func (o *FileOutput) backups() ([]string, error) {
+	ext := filepath.Ext(o.config.Path)
+	backups, err := filepath.Glob(strings.TrimSuffix(o.config.Path, ext) + "-*" + ext)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SINK-Vb0zq", "unable to list rotated files")
+	}
+	// the time format sorts lexically
+	slices.Sort(backups)
+	return backups, nil
+}
+
+// Close implements [Output]
+func (o *FileOutput) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Close()
+}
@@ -0,0 +1,130 @@
+package eventsink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readMessages(t *testing.T, path string) []*Message {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var messages []*Message
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message := new(Message)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), message))
+		messages = append(messages, message)
+	}
+	require.NoError(t, scanner.Err())
+	return messages
+}
+
+func testMessage(t *testing.T, sequence uint64, position float64) *Message {
+	message, err := messageFromEvent(testEvent("instance1", sequence, position))
+	require.NoError(t, err)
+	return message
+}
+
+func TestFileOutput_Publish(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events", "events.jsonl")
+	output, err := NewFileOutput(&FileConfig{Path: path})
+	require.NoError(t, err)
+
+	require.NoError(t, output.Publish(ctx, testMessage(t, 1, 1)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 2, 2)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 3, 2)))
+	require.NoError(t, output.Close())
+
+	// the events published again after a restart are skipped
+	output, err = NewFileOutput(&FileConfig{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, output.Publish(ctx, testMessage(t, 2, 2)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 3, 2)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 4, 2)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 5, 3)))
+	require.NoError(t, output.Close())
+
+	messages := readMessages(t, path)
+	require.Len(t, messages, 5)
+	for i, message := range messages {
+		assert.Equal(t, uint64(i+1), message.Sequence)
+	}
+}
+
+func TestFileOutput_partialLine(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	output, err := NewFileOutput(&FileConfig{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, output.Publish(ctx, testMessage(t, 1, 1)))
+	_, err = output.file.Write([]byte(`{"id":"instance1:user:user1:2","pos`))
+	require.NoError(t, err)
+	require.NoError(t, output.Close())
+
+	output, err = NewFileOutput(&FileConfig{Path: path})
+	require.NoError(t, err)
+	require.NoError(t, output.Publish(ctx, testMessage(t, 2, 2)))
+	require.NoError(t, output.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// the partial line is terminated, the message is written to the next line
+	lines := bytes.Split(bytes.TrimSuffix(content, []byte{'\n'}), []byte{'\n'})
+	require.Len(t, lines, 3)
+	message := new(Message)
+	require.NoError(t, json.Unmarshal(lines[2], message))
+	assert.Equal(t, uint64(2), message.Sequence)
+}
+
+func TestFileOutput_rotate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	line, err := json.Marshal(testMessage(t, 1, 1))
+	require.NoError(t, err)
+	output, err := NewFileOutput(&FileConfig{
+		Path: path,
+		// two messages fit into a file
+		MaxSize:    int64(2*len(line) + 10),
+		MaxBackups: 1,
+	})
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	output.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, output.Publish(ctx, testMessage(t, i, float64(i))))
+	}
+	require.NoError(t, output.Close())
+
+	backups, err := filepath.Glob(filepath.Join(dir, "events-*.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "events-20240101T000002.000000000.jsonl")}, backups)
+	assert.Len(t, readMessages(t, backups[0]), 2)
+	assert.Len(t, readMessages(t, path), 1)
+
+	// the messages of the rotated file are skipped after a restart
+	require.NoError(t, os.Truncate(path, 0))
+	output, err = NewFileOutput(&FileConfig{Path: path, MaxBackups: 1})
+	require.NoError(t, err)
+	require.NoError(t, output.Publish(ctx, testMessage(t, 4, 4)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 5, 5)))
+	require.NoError(t, output.Close())
+	messages := readMessages(t, path)
+	require.Len(t, messages, 1)
+	assert.Equal(t, uint64(5), messages[0].Sequence)
+}
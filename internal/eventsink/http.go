@@ -0,0 +1,97 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	defaultHTTPTimeout = 10 * time.Second
+	// idempotencyKeyHeader contains the id of the message, so the receiver can deduplicate messages
+	idempotencyKeyHeader = "Idempotency-Key"
+	// maxErrorBody limits the response body added to errors
+	maxErrorBody = 1 << 10
+)
+
+// HTTPOutput posts the messages as JSON to an endpoint.
+// The id of the message is sent as Idempotency-Key header.
+type HTTPOutput struct {
+	config *HTTPConfig
+	client *http.Client
+}
+
+func NewHTTPOutput(config *HTTPConfig) *HTTPOutput {
+	if config.Timeout == 0 {
+		config.Timeout = defaultHTTPTimeout
+	}
+	return &HTTPOutput{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Publish implements [Output]
+func (o *HTTPOutput) Publish(ctx context.Context, message *Message) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	_, err = post(ctx, o.client, o.config.URL, "application/json", body, func(req *http.Request) {
+		setHeaders(req, o.config.Headers)
+		req.Header.Set(idempotencyKeyHeader, message.ID)
+	})
+	return err
+}
+
+// Close implements [Output]
+func (o *HTTPOutput) Close() error {
+	o.client.CloseIdleConnections()
+	return nil
+}
+
+func setHeaders(req *http.Request, headers http.Header) {
+	for key, values := range headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+}
+
+// post sends the body and returns the response body of successful requests.
+// Client errors except timeouts and rate limits are returned as [permanentError].
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte, prepare func(*http.Request)) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, &permanentError{err: zerrors.ThrowInvalidArgument(err, "SINK-fK2wz", "unable to create request")}
+	}
+	req.Header.Set("Content-Type", contentType)
+	prepare(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "SINK-3Rm9d", "request failed")
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SINK-Cw7oi", "unable to read response")
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return respBody, nil
+	}
+	if len(respBody) > maxErrorBody {
+		respBody = respBody[:maxErrorBody]
+	}
+	err = zerrors.ThrowInternalf(nil, "SINK-Pa1xs", "unexpected status %d: %s", resp.StatusCode, respBody)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout &&
+		resp.StatusCode != http.StatusTooManyRequests {
+		return nil, &permanentError{err: err}
+	}
+	return nil, err
+}
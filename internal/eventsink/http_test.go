@@ -0,0 +1,75 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPOutput_Publish(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{
+			name:   "ok",
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+		{
+			name:    "rate limited",
+			status:  http.StatusTooManyRequests,
+			wantErr: true,
+		},
+		{
+			name:          "client error",
+			status:        http.StatusBadRequest,
+			wantErr:       true,
+			wantPermanent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			message := new(Message)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(message))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			output := NewHTTPOutput(&HTTPConfig{
+				URL:     server.URL,
+				Headers: http.Header{"Authorization": {"Bearer token"}},
+			})
+			defer output.Close()
+
+			err := output.Publish(context.Background(), testMessage(t, 1, 1))
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", received.Header.Get("Authorization"))
+			assert.Equal(t, "instance1:user:user1:1", received.Header.Get(idempotencyKeyHeader))
+			assert.Equal(t, "instance1:user:user1:1", message.ID)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			var permanent *permanentError
+			assert.Equal(t, tt.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
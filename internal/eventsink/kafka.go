@@ -0,0 +1,109 @@
+package eventsink
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	defaultKafkaTimeout = 10 * time.Second
+	// kafkaIDHeader contains the id of the message, so consumers can deduplicate messages
+	kafkaIDHeader = "id"
+)
+
+// KafkaOutput produces the messages to a topic of a Kafka cluster.
+// The producer is idempotent, so retries of the client don't write a record twice
+// and the records of a partition stay in order.
+// The records are keyed by the aggregate, so the events of an aggregate are stored in order on the same partition.
+// A message is only published, after all in-sync replicas acknowledged it.
+type KafkaOutput struct {
+	config *KafkaConfig
+	client *kgo.Client
+}
+
+func NewKafkaOutput(config *KafkaConfig) (*KafkaOutput, error) {
+	if config.Timeout == 0 {
+		config.Timeout = defaultKafkaTimeout
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.Brokers...),
+		kgo.DefaultProduceTopic(config.Topic),
+		kgo.ClientID("zitadel-eventsink"),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.ProducerLinger(0),
+		kgo.DialTimeout(config.Timeout),
+	}
+	if config.TLS {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if config.SASLMechanism != "" {
+		mechanism, err := kafkaSASLMechanism(config)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, kgo.SASL(mechanism))
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SINK-Kq2xd", "invalid Kafka configuration")
+	}
+	return &KafkaOutput{
+		config: config,
+		client: client,
+	}, nil
+}
+
+func kafkaSASLMechanism(config *KafkaConfig) (sasl.Mechanism, error) {
+	switch config.SASLMechanism {
+	case "PLAIN":
+		return plain.Auth{User: config.Username, Pass: config.Password}.AsMechanism(), nil
+	case "SCRAM-SHA-256":
+		return scram.Auth{User: config.Username, Pass: config.Password}.AsSha256Mechanism(), nil
+	case "SCRAM-SHA-512":
+		return scram.Auth{User: config.Username, Pass: config.Password}.AsSha512Mechanism(), nil
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "SINK-Zb7vf", "unsupported Kafka SASL mechanism %q", config.SASLMechanism)
+	}
+}
+
+// Publish implements [Output]
+func (o *KafkaOutput) Publish(ctx context.Context, message *Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.config.Timeout)
+	defer cancel()
+
+	err = o.client.ProduceSync(ctx, &kgo.Record{
+		Key:   []byte(message.InstanceID + ":" + message.AggregateType + ":" + message.AggregateID),
+		Value: payload,
+		Headers: []kgo.RecordHeader{
+			{Key: kafkaIDHeader, Value: []byte(message.ID)},
+		},
+	}).FirstErr()
+	if errors.Is(err, kerr.MessageTooLarge) || errors.Is(err, kerr.TopicAuthorizationFailed) {
+		return &permanentError{err: zerrors.ThrowPreconditionFailed(err, "SINK-Ue5zi", "Kafka rejected the record")}
+	}
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Hn4gT", "record not acknowledged by Kafka")
+	}
+	return nil
+}
+
+// Close implements [Output]
+func (o *KafkaOutput) Close() error {
+	o.client.Close()
+	return nil
+}
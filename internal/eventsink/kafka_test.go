@@ -0,0 +1,85 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func newKafkaCluster(t *testing.T, topics ...string) *kfake.Cluster {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(2, topics...))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+	return cluster
+}
+
+func TestKafkaOutput_Publish(t *testing.T) {
+	ctx := context.Background()
+	cluster := newKafkaCluster(t, "zitadel.events")
+	output, err := NewKafkaOutput(&KafkaConfig{
+		Brokers: cluster.ListenAddrs(),
+		Topic:   "zitadel.events",
+	})
+	require.NoError(t, err)
+	defer output.Close()
+
+	require.NoError(t, output.Publish(ctx, testMessage(t, 1, 1)))
+	require.NoError(t, output.Publish(ctx, testMessage(t, 2, 2)))
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("zitadel.events"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer consumer.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	var records []*kgo.Record
+	for len(records) < 2 {
+		fetches := consumer.PollFetches(ctx)
+		require.NoError(t, fetches.Err())
+		records = append(records, fetches.Records()...)
+	}
+	require.Len(t, records, 2)
+	// the records of an aggregate are produced to the same partition in order
+	assert.Equal(t, records[0].Partition, records[1].Partition)
+	for i, record := range records {
+		assert.Equal(t, "instance1:user:user1", string(record.Key))
+		message := new(Message)
+		require.NoError(t, json.Unmarshal(record.Value, message))
+		assert.Equal(t, testMessage(t, uint64(i+1), float64(i+1)).ID, message.ID)
+		require.Len(t, record.Headers, 1)
+		assert.Equal(t, kafkaIDHeader, record.Headers[0].Key)
+		assert.Equal(t, message.ID, string(record.Headers[0].Value))
+	}
+}
+
+func TestKafkaOutput_notAcknowledged(t *testing.T) {
+	cluster := newKafkaCluster(t, "other")
+	output, err := NewKafkaOutput(&KafkaConfig{
+		Brokers: cluster.ListenAddrs(),
+		Topic:   "zitadel.events",
+		Timeout: 500 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer output.Close()
+
+	assert.Error(t, output.Publish(context.Background(), testMessage(t, 1, 1)))
+}
+
+func TestNewKafkaOutput_invalidSASLMechanism(t *testing.T) {
+	_, err := NewKafkaOutput(&KafkaConfig{
+		Brokers:       []string{"localhost:9092"},
+		Topic:         "zitadel.events",
+		SASLMechanism: "GSSAPI",
+	})
+	assert.Error(t, err)
+}
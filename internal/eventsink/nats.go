@@ -0,0 +1,122 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	defaultNATSTimeout = 5 * time.Second
+)
+
+// NATSOutput publishes the messages to a JetStream stream of a NATS server.
+// A message is only published, after the stream acknowledged that it stored it.
+// The id of the message is sent as Nats-Msg-Id header,
+// so streams deduplicate messages published again within their duplicate window.
+//
+// The stream must be created upfront and capture the subjects of the messages.
+type NATSOutput struct {
+	config *NATSConfig
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+func NewNATSOutput(config *NATSConfig) *NATSOutput {
+	if config.Timeout == 0 {
+		config.Timeout = defaultNATSTimeout
+	}
+	return &NATSOutput{config: config}
+}
+
+// Publish implements [Output]
+func (o *NATSOutput) Publish(ctx context.Context, message *Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	js, err := o.jetStream()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.config.Timeout)
+	defer cancel()
+
+	_, err = js.PublishMsg(
+		&nats.Msg{
+			Subject: o.subject(message),
+			Data:    payload,
+		},
+		nats.MsgId(message.ID),
+		nats.Context(ctx),
+	)
+	if errors.Is(err, nats.ErrNoStreamResponse) {
+		return &permanentError{err: zerrors.ThrowPreconditionFailedf(err, "SINK-Tz8fu", "no JetStream stream captures the subject %s", o.subject(message))}
+	}
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-v3Qa0", "message not acknowledged by JetStream")
+	}
+	return nil
+}
+
+// subject is built as <SubjectPrefix>.<instance id>.<event type>
+func (o *NATSOutput) subject(message *Message) string {
+	subject := message.InstanceID + "." + message.EventType
+	if o.config.SubjectPrefix == "" {
+		return subject
+	}
+	return o.config.SubjectPrefix + "." + subject
+}
+
+// jetStream connects to the server on first use.
+// Afterward the client reconnects on its own, if the connection is lost.
+func (o *NATSOutput) jetStream() (nats.JetStreamContext, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.js != nil {
+		return o.js, nil
+	}
+	opts := []nats.Option{
+		nats.Name("zitadel-eventsink"),
+		nats.Timeout(o.config.Timeout),
+		nats.NoEcho(),
+		nats.MaxReconnects(-1),
+	}
+	if o.config.Username != "" {
+		opts = append(opts, nats.UserInfo(o.config.Username, o.config.Password))
+	}
+	if o.config.Token != "" {
+		opts = append(opts, nats.Token(o.config.Token))
+	}
+	conn, err := nats.Connect(o.config.URL, opts...)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "SINK-Bq5Pw", "unable to connect to NATS")
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, zerrors.ThrowInternal(err, "SINK-Ne6vX", "unable to create JetStream context")
+	}
+	o.conn, o.js = conn, js
+	return o.js, nil
+}
+
+// Close implements [Output]
+func (o *NATSOutput) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.conn == nil {
+		return nil
+	}
+	o.conn.Close()
+	o.conn, o.js = nil, nil
+	return nil
+}
@@ -0,0 +1,191 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type natsMessage struct {
+	subject string
+	headers string
+	payload []byte
+}
+
+type natsAck int
+
+const (
+	// natsAckStored acknowledges the message as stored by the stream
+	natsAckStored natsAck = iota
+	// natsAckError answers with a JetStream error
+	natsAckError
+	// natsAckNone doesn't answer, like a server without a stream capturing the subject
+	natsAckNone
+)
+
+// natsServer is a stand-in for a NATS server with a JetStream stream,
+// which captures all subjects and acknowledges the published messages
+type natsServer struct {
+	listener net.Listener
+	token    string
+
+	mu       sync.Mutex
+	ack      natsAck
+	messages []*natsMessage
+}
+
+func newNATSServer(t *testing.T, token string) *natsServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &natsServer{listener: listener, token: token}
+	t.Cleanup(func() { _ = listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *natsServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *natsServer) setAck(ack natsAck) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ack = ack
+}
+
+func (s *natsServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *natsServer) handle(conn net.Conn) {
+	defer conn.Close()
+	_, _ = fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"max_payload\":1048576,\"auth_required\":%t}\r\n", s.token != "")
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		switch args[0] {
+		case "CONNECT":
+			connect := new(struct {
+				Token string `json:"auth_token"`
+			})
+			if json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), connect) != nil || connect.Token != s.token {
+				_, _ = conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+				return
+			}
+		case "PING":
+			_, _ = conn.Write([]byte("PONG\r\n"))
+		case "SUB":
+			// the subscription of the client for the acknowledgements,
+			// the sid is used for all answers, as the client only subscribes once
+		case "HPUB":
+			// HPUB <subject> <reply> <header length> <total length>
+			headerLen, _ := strconv.Atoi(args[3])
+			totalLen, _ := strconv.Atoi(args[4])
+			data := make([]byte, totalLen+2)
+			if _, err = io.ReadFull(reader, data); err != nil {
+				return
+			}
+			s.mu.Lock()
+			ack := s.ack
+			if ack == natsAckStored {
+				s.messages = append(s.messages, &natsMessage{
+					subject: args[1],
+					headers: string(data[:headerLen]),
+					payload: data[headerLen:totalLen],
+				})
+			}
+			s.mu.Unlock()
+			var answer string
+			switch ack {
+			case natsAckStored:
+				answer = `{"stream":"events","seq":1}`
+			case natsAckError:
+				answer = `{"error":{"code":503,"err_code":10077,"description":"maximum messages exceeded"}}`
+			case natsAckNone:
+				continue
+			}
+			_, _ = fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", args[2], len(answer), answer)
+		}
+	}
+}
+
+func TestNATSOutput_Publish(t *testing.T) {
+	ctx := context.Background()
+	server := newNATSServer(t, "secret")
+	output := NewNATSOutput(&NATSConfig{
+		URL:           server.url(),
+		SubjectPrefix: "zitadel.events",
+		Token:         "secret",
+		Timeout:       time.Second,
+	})
+	defer output.Close()
+
+	require.NoError(t, output.Publish(ctx, testMessage(t, 1, 1)))
+
+	// messages are only published if the stream acknowledged them
+	server.setAck(natsAckError)
+	require.Error(t, output.Publish(ctx, testMessage(t, 2, 2)))
+	server.setAck(natsAckStored)
+	require.NoError(t, output.Publish(ctx, testMessage(t, 2, 2)))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.messages, 2)
+	assert.Equal(t, "zitadel.events.instance1.user.human.added", server.messages[0].subject)
+	assert.Contains(t, server.messages[0].headers, "Nats-Msg-Id: instance1:user:user1:1\r\n")
+	message := new(Message)
+	require.NoError(t, json.Unmarshal(server.messages[1].payload, message))
+	assert.Equal(t, "instance1:user:user1:2", message.ID)
+}
+
+func TestNATSOutput_notAcknowledged(t *testing.T) {
+	server := newNATSServer(t, "")
+	server.setAck(natsAckNone)
+	output := NewNATSOutput(&NATSConfig{
+		URL:     server.url(),
+		Timeout: 100 * time.Millisecond,
+	})
+	defer output.Close()
+
+	err := output.Publish(context.Background(), testMessage(t, 1, 1))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNATSOutput_unauthorized(t *testing.T) {
+	server := newNATSServer(t, "secret")
+	output := NewNATSOutput(&NATSConfig{
+		URL:   server.url(),
+		Token: "wrong",
+	})
+	defer output.Close()
+
+	err := output.Publish(context.Background(), testMessage(t, 1, 1))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, nats.ErrAuthorization)
+}
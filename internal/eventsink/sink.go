@@ -0,0 +1,214 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	ProjectionTablePrefix = "projections.eventsinks_"
+)
+
+var (
+	sinks   []*handler.Handler
+	outputs []Output
+)
+
+// Message is the representation of an event published by a sink.
+type Message struct {
+	// ID is unique per event and stays the same if the message is published again.
+	// Outputs use it to deduplicate messages.
+	ID            string          `json:"id"`
+	InstanceID    string          `json:"instanceId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	Sequence      uint64          `json:"sequence"`
+	Position      float64         `json:"position"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Creator       string          `json:"creator"`
+	EventType     string          `json:"eventType"`
+	Revision      uint16          `json:"revision"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func messageFromEvent(event eventstore.Event) (*Message, error) {
+	var payload json.RawMessage
+	if err := event.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+	aggregate := event.Aggregate()
+	return &Message{
+		ID:            fmt.Sprintf("%s:%s:%s:%d", aggregate.InstanceID, aggregate.Type, aggregate.ID, event.Sequence()),
+		InstanceID:    aggregate.InstanceID,
+		AggregateType: string(aggregate.Type),
+		AggregateID:   aggregate.ID,
+		ResourceOwner: aggregate.ResourceOwner,
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		CreatedAt:     event.CreatedAt(),
+		Creator:       event.Creator(),
+		EventType:     string(event.Type()),
+		Revision:      event.Revision(),
+		Payload:       payload,
+	}, nil
+}
+
+// Output publishes the messages of a sink.
+// A message might be published more than once, if the position of the sink couldn't be stored after publishing.
+type Output interface {
+	Publish(ctx context.Context, message *Message) error
+	Close() error
+}
+
+// permanentError is returned by outputs if publishing the message again would fail the same way,
+// until the output or its configuration is fixed.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Register creates the handlers of the enabled sinks.
+// The handlers are started by [Start].
+func Register(ctx context.Context, config Config, customConfig projection.CustomConfig) error {
+	handlerConfig := projection.ApplyCustomConfig(customConfig)
+	for _, sinkConfig := range config.Sinks {
+		if !sinkConfig.Enabled {
+			continue
+		}
+		if err := sinkConfig.validate(); err != nil {
+			return err
+		}
+		output, err := newOutput(sinkConfig)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, output)
+		sinks = append(sinks, newSink(ctx, sinkConfig, handlerConfig, output))
+	}
+	return nil
+}
+
+// Start starts the handlers of the sinks and closes the outputs as soon as ctx is done.
+func Start(ctx context.Context) {
+	for _, sink := range sinks {
+		sink.Start(ctx)
+	}
+	go func() {
+		<-ctx.Done()
+		for _, output := range outputs {
+			logging.OnError(output.Close()).Warn("unable to close event sink output")
+		}
+	}()
+}
+
+func newOutput(config *SinkConfig) (Output, error) {
+	switch {
+	case config.File != nil:
+		return NewFileOutput(config.File)
+	case config.NATS != nil:
+		return NewNATSOutput(config.NATS), nil
+	case config.Kafka != nil:
+		return NewKafkaOutput(config.Kafka)
+	default:
+		return NewHTTPOutput(config.HTTP), nil
+	}
+}
+
+type sink struct {
+	name       string
+	instances  []string
+	eventTypes map[eventstore.AggregateType][]eventstore.EventType
+	output     Output
+}
+
+// staticInstances triggers the sink for the configured instances only.
+type staticInstances []string
+
+func (i staticInstances) ActiveInstances() []string {
+	return i
+}
+
+func newSink(ctx context.Context, config *SinkConfig, handlerConfig handler.Config, output Output) *handler.Handler {
+	if len(config.Instances) > 0 {
+		handlerConfig.ActiveInstancer = staticInstances(config.Instances)
+	}
+	return handler.NewHandler(ctx, &handlerConfig, newProjection(config, output))
+}
+
+func newProjection(config *SinkConfig, output Output) *sink {
+	return &sink{
+		name:       config.Name,
+		instances:  config.Instances,
+		eventTypes: config.eventTypes(),
+		output:     output,
+	}
+}
+
+func (s *sink) Name() string {
+	return ProjectionTablePrefix + s.name
+}
+
+func (s *sink) Reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, 0, len(s.eventTypes))
+	for aggregateType, eventTypes := range s.eventTypes {
+		eventReducers := make([]handler.EventReducer, len(eventTypes))
+		for i, eventType := range eventTypes {
+			eventReducers[i] = handler.EventReducer{
+				Event:  eventType,
+				Reduce: s.reduce,
+			}
+		}
+		reducers = append(reducers, handler.AggregateReducer{
+			Aggregate:     aggregateType,
+			EventReducers: eventReducers,
+		})
+	}
+	return reducers
+}
+
+func (s *sink) reduce(event eventstore.Event) (*handler.Statement, error) {
+	if len(s.instances) > 0 && !slices.Contains(s.instances, event.Aggregate().InstanceID) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	// the position of the sink is stored in the same transaction after the statement was executed
+	return handler.NewStatement(event, func(ex handler.Executer, _ string) error {
+		return s.publish(handler.ExecuterContext(ex), event)
+	}), nil
+}
+
+// publish publishes the event once, it's not retried inside the transaction of the handler, which holds the lock of the sink.
+// The error is never skipped by the handler, so the sink stops at an unpublished event
+// and the handler retries it after RetryFailedAfter until the output accepts it.
+func (s *sink) publish(ctx context.Context, event eventstore.Event) error {
+	message, err := messageFromEvent(event)
+	if err == nil {
+		err = s.output.Publish(ctx, message)
+	}
+	if err == nil {
+		return nil
+	}
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		logging.WithFields("sink", s.name, "instance", event.Aggregate().InstanceID, "position", event.Position()).WithError(err).
+			Error("publish failed permanently, the sink stops until the output accepts the message")
+	}
+	return &handler.NotSkippableError{Err: err}
+}
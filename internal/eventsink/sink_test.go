@@ -0,0 +1,198 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+type testOutput struct {
+	errs     []error
+	attempts int
+	messages []*Message
+}
+
+func (o *testOutput) Publish(_ context.Context, message *Message) error {
+	o.attempts++
+	if len(o.errs) > 0 {
+		err := o.errs[0]
+		o.errs = o.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+func (o *testOutput) Close() error {
+	return nil
+}
+
+func testEvent(instanceID string, sequence uint64, position float64) *eventstore.BaseEvent {
+	return &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "user1",
+			Type:          "user",
+			ResourceOwner: "org1",
+			InstanceID:    instanceID,
+			Version:       "v2",
+		},
+		EventType: "user.human.added",
+		Seq:       sequence,
+		Pos:       position,
+		Creation:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:      "editor",
+		Data:      []byte(`{"userName":"gigi"}`),
+	}
+}
+
+func TestSinkConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SinkConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			config: &SinkConfig{
+				Name:   "audit_log",
+				Events: map[string][]string{"user": {"user.human.added"}},
+				HTTP:   &HTTPConfig{URL: "http://localhost"},
+			},
+		},
+		{
+			name: "invalid name",
+			config: &SinkConfig{
+				Name:   "Audit Log",
+				Events: map[string][]string{"user": {"user.human.added"}},
+				HTTP:   &HTTPConfig{URL: "http://localhost"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no events",
+			config: &SinkConfig{
+				Name: "audit",
+				HTTP: &HTTPConfig{URL: "http://localhost"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no output",
+			config: &SinkConfig{
+				Name:   "audit",
+				Events: map[string][]string{"user": {"user.human.added"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple outputs",
+			config: &SinkConfig{
+				Name:   "audit",
+				Events: map[string][]string{"user": {"user.human.added"}},
+				HTTP:   &HTTPConfig{URL: "http://localhost"},
+				NATS:   &NATSConfig{URL: "nats://localhost:4222"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_sink_reduce(t *testing.T) {
+	output := new(testOutput)
+	s := newProjection(&SinkConfig{
+		Name:      "audit",
+		Instances: []string{"instance1"},
+		Events:    map[string][]string{"user": {"user.human.added"}},
+	}, output)
+
+	assert.Equal(t, "projections.eventsinks_audit", s.Name())
+	require.Len(t, s.Reducers(), 1)
+	assert.Equal(t, eventstore.AggregateType("user"), s.Reducers()[0].Aggregate)
+
+	// events of other instances are skipped
+	stmt, err := s.reduce(testEvent("instance2", 1, 1))
+	require.NoError(t, err)
+	assert.Nil(t, stmt.Execute)
+
+	stmt, err = s.reduce(testEvent("instance1", 2, 1.5))
+	require.NoError(t, err)
+	require.NoError(t, stmt.Execute(nil, s.Name()))
+	require.Len(t, output.messages, 1)
+	assert.Equal(t, &Message{
+		ID:            "instance1:user:user1:2",
+		InstanceID:    "instance1",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		ResourceOwner: "org1",
+		Sequence:      2,
+		Position:      1.5,
+		CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Creator:       "editor",
+		EventType:     "user.human.added",
+		Revision:      2,
+		Payload:       []byte(`{"userName":"gigi"}`),
+	}, output.messages[0])
+}
+
+func Test_sink_publish(t *testing.T) {
+	errPublish := errors.New("publish failed")
+	tests := []struct {
+		name    string
+		errs    []error
+		wantErr error
+	}{
+		{
+			name: "published",
+		},
+		{
+			name:    "failed",
+			errs:    []error{errPublish},
+			wantErr: errPublish,
+		},
+		{
+			name:    "permanent error",
+			errs:    []error{&permanentError{err: errPublish}},
+			wantErr: errPublish,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &testOutput{errs: tt.errs}
+			s := newProjection(&SinkConfig{
+				Name:   "audit",
+				Events: map[string][]string{"user": {"user.human.added"}},
+			}, output)
+
+			err := s.publish(context.Background(), testEvent("instance1", 1, 1))
+			// publishing isn't retried inside the transaction of the handler
+			assert.Equal(t, 1, output.attempts)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			// the handler must not skip the event after its MaxFailureCount
+			var notSkippable *handler.NotSkippableError
+			assert.ErrorAs(t, err, &notSkippable)
+		})
+	}
+}
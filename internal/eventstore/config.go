@@ -2,13 +2,26 @@ package eventstore
 
 import (
 	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
 )
 
 type Config struct {
 	PushTimeout time.Duration
 	MaxRetries  uint32
+	// SnapshotThreshold is the minimum amount of events reduced by a [SnapshotReducer],
+	// before its state is stored as snapshot.
+	// Snapshots are disabled if it's 0.
+	SnapshotThreshold uint32
+
+	Pusher      Pusher
+	Querier     Querier
+	Searcher    Searcher
+	Snapshotter Snapshotter
 
-	Pusher   Pusher
-	Querier  Querier
-	Searcher Searcher
+	// DataKeys stores the data keys used to encrypt the personal data of events.
+	// Personal data is only encrypted if DataKeys and PersonalDataEncryption are set.
+	DataKeys DataKeyStorage
+	// PersonalDataEncryption encrypts the data keys
+	PersonalDataEncryption crypto.EncryptionAlgorithm
 }
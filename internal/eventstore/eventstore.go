@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -20,9 +21,15 @@ type Eventstore struct {
 	PushTimeout time.Duration
 	maxRetries  int
 
-	pusher   Pusher
-	querier  Querier
-	searcher Searcher
+	pusher      Pusher
+	querier     Querier
+	searcher    Searcher
+	snapshotter Snapshotter
+
+	snapshotThreshold uint32
+
+	dataKeys               DataKeyStorage
+	personalDataEncryption crypto.EncryptionAlgorithm
 }
 
 var (
@@ -53,6 +60,9 @@ func RegisterFilterEventMapper(aggregateType AggregateType, eventType EventType,
 
 type eventTypeInterceptors struct {
 	eventMapper func(Event) (Event, error)
+
+	personalDataFields []string
+	shredsPersonalData bool
 }
 
 func NewEventstore(config *Config) *Eventstore {
@@ -60,9 +70,15 @@ func NewEventstore(config *Config) *Eventstore {
 		PushTimeout: config.PushTimeout,
 		maxRetries:  int(config.MaxRetries),
 
-		pusher:   config.Pusher,
-		querier:  config.Querier,
-		searcher: config.Searcher,
+		pusher:      config.Pusher,
+		querier:     config.Querier,
+		searcher:    config.Searcher,
+		snapshotter: config.Snapshotter,
+
+		snapshotThreshold: config.SnapshotThreshold,
+
+		dataKeys:               config.DataKeys,
+		personalDataEncryption: config.PersonalDataEncryption,
 	}
 }
 
@@ -89,10 +105,11 @@ func (es *Eventstore) PushWithClient(ctx context.Context, client database.Contex
 		ctx, cancel = context.WithTimeout(ctx, es.PushTimeout)
 		defer cancel()
 	}
-	var (
-		events []Event
-		err    error
-	)
+	cmds, keys, err := es.encryptPersonalData(ctx, cmds)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
 
 	// Retry when there is a collision of the sequence as part of the primary key.
 	// "duplicate key value violates unique constraint \"events2_pkey\" (SQLSTATE 23505)"
@@ -121,6 +138,9 @@ retry:
 	if err != nil {
 		return nil, err
 	}
+	if err = es.decryptEvents(ctx, keys, events); err != nil {
+		return nil, err
+	}
 
 	mappedEvents, err := es.mapEvents(events)
 	if err != nil {
@@ -163,14 +183,14 @@ func (es *Eventstore) Search(ctx context.Context, conditions ...map[FieldType]an
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
 	events := make([]Event, 0, searchQuery.GetLimit())
 	searchQuery.ensureInstanceID(ctx)
-	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
+	err := es.querier.FilterToReducer(ctx, searchQuery, es.decryptPersonalData(ctx, nil, func(event Event) error {
 		event, err := es.mapEvent(event)
 		if err != nil {
 			return err
 		}
 		events = append(events, event)
 		return nil
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -219,14 +239,14 @@ type reducer interface {
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
 	searchQuery.ensureInstanceID(ctx)
-	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
+	return es.querier.FilterToReducer(ctx, searchQuery, es.decryptPersonalData(ctx, nil, func(event Event) error {
 		event, err := es.mapEvent(event)
 		if err != nil {
 			return err
 		}
 		r.AppendEvents(event)
 		return r.Reduce()
-	})
+	}))
 }
 
 // LatestSequence filters the latest sequence for the given search query
@@ -255,9 +275,16 @@ type QueryReducer interface {
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if snapshotReducer, ok := r.(SnapshotReducer); ok && es.snapshotsEnabled() {
+		return es.filterToSnapshotReducer(ctx, snapshotReducer)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
+func (es *Eventstore) snapshotsEnabled() bool {
+	return es.snapshotter != nil && es.snapshotThreshold > 0
+}
+
 type Reducer func(event Event) error
 
 type Querier interface {
@@ -3,6 +3,8 @@ package handler
 import (
 	"database/sql"
 	_ "embed"
+	"errors"
+	"math"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -47,16 +49,37 @@ func failureFromStatement(statement *Statement, err error) *failure {
 	}
 }
 
+// NotSkippableError is returned by statements whose event must not be skipped after [Config.MaxFailureCount] failures.
+// The handler keeps its position before the event and retries it in the next iteration.
+type NotSkippableError struct {
+	Err error
+}
+
+func (e *NotSkippableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NotSkippableError) Unwrap() error {
+	return e.Err
+}
+
 func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool) {
 	failureCount, err := h.failureCount(tx, f)
 	if err != nil {
 		h.logFailure(f).WithError(err).Warn("unable to get failure count")
 		return false
 	}
-	failureCount += 1
+	// the count of events which are not skipped would overflow
+	if failureCount < math.MaxUint8 {
+		failureCount += 1
+	}
 	err = h.setFailureCount(tx, failureCount, f)
 	h.logFailure(f).OnError(err).Warn("unable to update failure count")
 
+	var notSkippable *NotSkippableError
+	if errors.As(f.err, &notSkippable) {
+		return false
+	}
 	return failureCount >= h.maxFailureCount
 }
 
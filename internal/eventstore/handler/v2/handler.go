@@ -265,8 +265,8 @@ func (h *Handler) triggerInstances(ctx context.Context, instances []string, trig
 		_, err := h.Trigger(instanceCtx, triggerOpts...)
 		h.log().WithField("instance", instance).OnError(err).Debug("trigger failed")
 		time.Sleep(h.retryFailedAfter)
-		// retry if trigger failed
-		for ; err != nil; _, err = h.Trigger(instanceCtx, triggerOpts...) {
+		// retry if trigger failed, until the handler is stopped
+		for ; err != nil && ctx.Err() == nil; _, err = h.Trigger(instanceCtx, triggerOpts...) {
 			time.Sleep(h.retryFailedAfter)
 			h.log().WithField("instance", instance).OnError(err).Debug("trigger failed")
 		}
@@ -625,7 +625,7 @@ func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, statement *S
 		return err
 	}
 
-	if err = statement.Execute(tx, h.projection.Name()); err != nil {
+	if err = statement.Execute(&contextExecuter{Tx: tx, ctx: ctx}, h.projection.Name()); err != nil {
 		h.log().WithError(err).Error("statement execution failed")
 
 		_, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT exec_stmt")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -252,7 +253,7 @@ func NewViewCheck(selectStmt string, secondaryTables ...*SuffixedTable) *handler
 		for _, table := range secondaryTables {
 			stmt += createTableStatement(&table.Table, config.tableName, "_"+table.suffix)
 		}
-		stmt += createViewStatement(config.tableName, selectStmt)
+		stmt += createViewStatement(config.tableName, viewSelect(selectStmt, config.tableName, secondaryTables))
 		return stmt
 	}
 
@@ -263,6 +264,16 @@ func NewViewCheck(selectStmt string, secondaryTables ...*SuffixedTable) *handler
 	}
 }
 
+// viewSelect references the secondary tables in the schema of the view,
+// so that a view rebuilt in another schema selects from the rebuilt tables
+func viewSelect(selectStmt, viewName string, secondaryTables []*SuffixedTable) string {
+	for _, table := range secondaryTables {
+		name := tableNameWithoutSchema(viewName) + "_" + table.suffix
+		selectStmt = regexp.MustCompile(`\b\w+\.`+regexp.QuoteMeta(name)+`\b`).ReplaceAllString(selectStmt, viewName+"_"+table.suffix)
+	}
+	return selectStmt
+}
+
 func execNextIfExists(config execConfig, q query, opts []execOption, executeNext bool) func(handler.Executer, string) (bool, error) {
 	return func(handler handler.Executer, name string) (shouldExecuteNext bool, err error) {
 		_, err = handler.Exec("SAVEPOINT exec_stmt")
@@ -0,0 +1,450 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ShadowSchema contains the tables of the projections which are currently rebuilt.
+const ShadowSchema = "projections_shadow"
+
+const (
+	// rebuildHeartbeat is the interval in which a running rebuild reports that it's alive
+	rebuildHeartbeat = 30 * time.Second
+	// staleRebuildAfter is the duration after which a rebuild which didn't report can be started again
+	staleRebuildAfter = 2 * time.Minute
+	// maxSwapAttempts limits how often the rebuilt projection tries to catch up with the live projection
+	maxSwapAttempts = 10
+)
+
+type RebuildState int16
+
+const (
+	RebuildStateUnspecified RebuildState = iota
+	RebuildStateBuilding
+	RebuildStateSwapping
+	RebuildStateDone
+	RebuildStateFailed
+)
+
+var (
+	//go:embed rebuild_start.sql
+	startRebuildStmt string
+	//go:embed rebuild_set.sql
+	setRebuildStmt string
+	//go:embed rebuild_relations.sql
+	relationsStmt string
+	//go:embed rebuild_positions.sql
+	lockPositionsStmt string
+
+	rebuildHeartbeatStmt = "UPDATE projections.rebuilds SET changed_at = now() WHERE projection_name = $1"
+
+	deleteStatesStmt       = "DELETE FROM projections.current_states WHERE projection_name = $1"
+	renameStatesStmt       = "UPDATE projections.current_states SET projection_name = $1 WHERE projection_name = $2"
+	deleteFailedEventsStmt = "DELETE FROM projections.failed_events2 WHERE projection_name = $1"
+	renameFailedEventsStmt = "UPDATE projections.failed_events2 SET projection_name = $1 WHERE projection_name = $2"
+)
+
+// shadowProjection writes the statements and tables of the projection into the [ShadowSchema].
+type shadowProjection struct {
+	Projection
+}
+
+// Name implements [Projection]
+func (p *shadowProjection) Name() string {
+	return ShadowSchema + "." + tableNameWithoutSchema(p.Projection.Name())
+}
+
+// Init implements initializer
+func (p *shadowProjection) Init() *handler.Check {
+	if init, ok := p.Projection.(initializer); ok {
+		return init.Init()
+	}
+	return new(handler.Check)
+}
+
+type rebuildProgress struct {
+	state          RebuildState
+	instancesTotal int
+	instancesDone  int
+	err            error
+}
+
+type relation struct {
+	name   string
+	isView bool
+}
+
+// Rebuild reduces all events of the projection into new tables in the [ShadowSchema].
+// The live projection keeps serving and processing events while the tables are built.
+// As soon as the rebuilt tables caught up with the live projection they replace the live tables in a single transaction.
+// The progress is stored in projections.rebuilds.
+func (h *Handler) Rebuild(ctx context.Context) error {
+	if err := h.startRebuild(ctx); err != nil {
+		return err
+	}
+	return h.rebuild(ctx)
+}
+
+// RebuildInBackground starts the rebuild like [Handler.Rebuild] but doesn't wait for it to finish.
+// The rebuild isn't stopped if ctx is cancelled.
+func (h *Handler) RebuildInBackground(ctx context.Context) error {
+	if err := h.startRebuild(ctx); err != nil {
+		return err
+	}
+	go func() {
+		err := h.rebuild(context.WithoutCancel(ctx))
+		h.log().OnError(err).Error("rebuild failed")
+	}()
+	return nil
+}
+
+func (h *Handler) rebuild(ctx context.Context) (err error) {
+	stopHeartbeat := h.rebuildHeartbeat(ctx)
+	progress := &rebuildProgress{state: RebuildStateBuilding}
+	defer func() {
+		stopHeartbeat()
+		progress.state, progress.err = RebuildStateDone, err
+		if err != nil {
+			progress.state = RebuildStateFailed
+		}
+		setErr := h.setRebuildProgress(context.WithoutCancel(ctx), progress)
+		h.log().OnError(setErr).Warn("unable to set rebuild progress")
+	}()
+
+	shadow := h.shadow()
+	if err = shadow.dropShadow(ctx); err != nil {
+		return err
+	}
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+
+	instances, err := h.existingInstances(ctx)
+	if err != nil {
+		return err
+	}
+	progress.instancesTotal = len(instances)
+	if err = h.setRebuildProgress(ctx, progress); err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		if err = shadow.triggerRebuild(ctx, instance); err != nil {
+			return err
+		}
+		progress.instancesDone++
+		if err = h.setRebuildProgress(ctx, progress); err != nil {
+			return err
+		}
+		h.log().WithField("instance", instance).WithField("done", progress.instancesDone).WithField("total", progress.instancesTotal).Info("instance rebuilt")
+	}
+
+	progress.state = RebuildStateSwapping
+	if err = h.setRebuildProgress(ctx, progress); err != nil {
+		return err
+	}
+	var swapped bool
+	for attempt := 1; attempt <= maxSwapAttempts; attempt++ {
+		swapped, err = h.swap(ctx, shadow)
+		if err != nil || swapped {
+			return err
+		}
+		h.log().WithField("attempt", attempt).Info("rebuilt projection not caught up yet")
+	}
+	return zerrors.ThrowInternal(nil, "V2-pMv5a", "rebuilt projection did not catch up")
+}
+
+// shadow returns a handler which builds the projection into the [ShadowSchema].
+// The handler is not started, it's only triggered during the rebuild.
+func (h *Handler) shadow() *Handler {
+	return &Handler{
+		client:               h.client,
+		projection:           &shadowProjection{Projection: h.projection},
+		es:                   h.es,
+		bulkLimit:            h.bulkLimit,
+		eventTypes:           h.eventTypes,
+		maxFailureCount:      h.maxFailureCount,
+		retryFailedAfter:     h.retryFailedAfter,
+		requeueEvery:         h.requeueEvery,
+		txDuration:           h.txDuration,
+		now:                  h.now,
+		triggerWithoutEvents: h.triggerWithoutEvents,
+		queryInstances:       h.queryInstances,
+	}
+}
+
+func (h *Handler) startRebuild(ctx context.Context) error {
+	res, err := h.client.ExecContext(ctx, startRebuildStmt,
+		h.projection.Name(),
+		RebuildStateBuilding,
+		RebuildStateDone,
+		RebuildStateFailed,
+		staleRebuildAfter.Seconds(),
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Dq3ib", "unable to start rebuild")
+	}
+	if affected, err := res.RowsAffected(); affected == 0 || err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "V2-bW8gY", "projection is already rebuilding")
+	}
+	return nil
+}
+
+func (h *Handler) setRebuildProgress(ctx context.Context, progress *rebuildProgress) error {
+	var rebuildErr *string
+	if progress.err != nil {
+		msg := progress.err.Error()
+		rebuildErr = &msg
+	}
+	_, err := h.client.ExecContext(ctx, setRebuildStmt,
+		h.projection.Name(),
+		progress.state,
+		progress.instancesTotal,
+		progress.instancesDone,
+		rebuildErr,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-lO4Ji", "unable to set rebuild progress")
+	}
+	return nil
+}
+
+// rebuildHeartbeat regularly reports that the rebuild is alive until the returned function is called.
+func (h *Handler) rebuildHeartbeat(ctx context.Context) (stop func()) {
+	ctx, stop = context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(rebuildHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, err := h.client.ExecContext(ctx, rebuildHeartbeatStmt, h.projection.Name())
+				h.log().OnError(err).Debug("unable to report rebuild heartbeat")
+			}
+		}
+	}()
+	return stop
+}
+
+// triggerRebuild reduces all events of the instance.
+// Failed statements are retried until they are skipped after the max failure count.
+func (h *Handler) triggerRebuild(ctx context.Context, instance string) (err error) {
+	instanceCtx := authz.WithInstanceID(ctx, instance)
+	for attempt := 0; ; attempt++ {
+		_, err = h.Trigger(instanceCtx, WithAwaitRunning())
+		if err == nil || attempt >= int(h.maxFailureCount) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(h.retryFailedAfter):
+		}
+	}
+}
+
+// dropShadow removes the leftovers of a previous rebuild of the shadow projection.
+func (h *Handler) dropShadow(ctx context.Context) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-fG4sx", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			h.log().OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	relations, err := queryRelations(tx, h.projection.Name())
+	if err != nil {
+		return err
+	}
+	if err = dropRelations(tx, h.projection.Name(), relations); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(deleteStatesStmt, h.projection.Name()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Y7oKd", "unable to delete states")
+	}
+	if _, err = tx.Exec(deleteFailedEventsStmt, h.projection.Name()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Xs2Lw", "unable to delete failed events")
+	}
+	return nil
+}
+
+// swap replaces the live tables with the tables of the shadow projection if the shadow projection caught up.
+// swapped is false if the live projection processed events which are not yet reduced by the shadow projection.
+func (h *Handler) swap(ctx context.Context, shadow *Handler) (swapped bool, err error) {
+	instances, err := h.existingInstances(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range instances {
+		if err = shadow.triggerRebuild(ctx, instance); err != nil {
+			return false, err
+		}
+	}
+
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "V2-v3iSd", "begin failed")
+	}
+	defer func() {
+		if err != nil || !swapped {
+			h.log().OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the live projection cannot process events until the transaction ends
+	swapped, err = h.caughtUp(tx, shadow.projection.Name(), instances)
+	if err != nil || !swapped {
+		return false, err
+	}
+	return true, h.swapTables(tx, shadow.projection.Name())
+}
+
+// caughtUp locks the states of the live projection and checks if the shadow projection reached their positions.
+func (h *Handler) caughtUp(tx *sql.Tx, shadowName string, instances []string) (bool, error) {
+	live, err := lockPositions(tx, h.projection.Name())
+	if err != nil {
+		return false, err
+	}
+	shadow, err := lockPositions(tx, shadowName)
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range instances {
+		if shadow[instance] < live[instance] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// swapTables moves the tables of the shadow projection into the schema of the live projection
+// and takes over the states and failed events of the shadow projection.
+func (h *Handler) swapTables(tx *sql.Tx, shadowName string) error {
+	liveName := h.projection.Name()
+	schemaEnd := strings.LastIndex(liveName, ".")
+	if schemaEnd < 0 {
+		return zerrors.ThrowInternal(nil, "V2-Ef9jr", "projection name must contain the schema")
+	}
+
+	live, err := queryRelations(tx, liveName)
+	if err != nil {
+		return err
+	}
+	if err = dropRelations(tx, liveName, live); err != nil {
+		return err
+	}
+	shadow, err := queryRelations(tx, shadowName)
+	if err != nil {
+		return err
+	}
+	for _, relation := range shadow {
+		kind := "TABLE"
+		if relation.isView {
+			kind = "VIEW"
+		}
+		if _, err = tx.Exec("ALTER " + kind + " " + ShadowSchema + "." + relation.name + " SET SCHEMA " + liveName[:schemaEnd]); err != nil {
+			return zerrors.ThrowInternal(err, "V2-c2Wbq", "unable to move rebuilt table")
+		}
+	}
+
+	if _, err = tx.Exec(deleteStatesStmt, liveName); err != nil {
+		return zerrors.ThrowInternal(err, "V2-I6xEo", "unable to delete states")
+	}
+	if _, err = tx.Exec(renameStatesStmt, liveName, shadowName); err != nil {
+		return zerrors.ThrowInternal(err, "V2-ZB0aS", "unable to take over states")
+	}
+	if _, err = tx.Exec(deleteFailedEventsStmt, liveName); err != nil {
+		return zerrors.ThrowInternal(err, "V2-zpH7s", "unable to delete failed events")
+	}
+	if _, err = tx.Exec(renameFailedEventsStmt, liveName, shadowName); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Ah3Yj", "unable to take over failed events")
+	}
+	return nil
+}
+
+func lockPositions(tx *sql.Tx, projectionName string) (map[string]float64, error) {
+	rows, err := tx.Query(lockPositionsStmt, projectionName)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Wq1ds", "unable to lock states")
+	}
+	defer rows.Close()
+
+	positions := make(map[string]float64)
+	for rows.Next() {
+		var (
+			instance string
+			position sql.NullFloat64
+		)
+		if err = rows.Scan(&instance, &position); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-v0Sdb", "unable to scan state")
+		}
+		positions[instance] = position.Float64
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-dU7cX", "unable to lock states")
+	}
+	return positions, nil
+}
+
+// queryRelations returns the tables and views of the projection.
+func queryRelations(tx *sql.Tx, projectionName string) (relations []*relation, err error) {
+	schemaEnd := max(strings.LastIndex(projectionName, "."), 0)
+	rows, err := tx.Query(relationsStmt, projectionName[:schemaEnd], tableNameWithoutSchema(projectionName))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Lh1dq", "unable to query tables")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		relation := new(relation)
+		if err = rows.Scan(&relation.name, &relation.isView); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-pO2Xx", "unable to scan table")
+		}
+		relations = append(relations, relation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-z8gDe", "unable to query tables")
+	}
+	return relations, nil
+}
+
+// dropRelations drops the views before the tables they select from.
+// The tables are dropped in a single statement so that foreign keys between them don't matter.
+func dropRelations(tx *sql.Tx, projectionName string, relations []*relation) error {
+	schema := projectionName[:max(strings.LastIndex(projectionName, "."), 0)]
+	var views, tables []string
+	for _, relation := range relations {
+		if relation.isView {
+			views = append(views, schema+"."+relation.name)
+			continue
+		}
+		tables = append(tables, schema+"."+relation.name)
+	}
+	if len(views) > 0 {
+		if _, err := tx.Exec("DROP VIEW " + strings.Join(views, ", ")); err != nil {
+			return zerrors.ThrowInternal(err, "V2-xC0kV", "unable to drop views")
+		}
+	}
+	if len(tables) > 0 {
+		if _, err := tx.Exec("DROP TABLE " + strings.Join(tables, ", ")); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Q4hBn", "unable to drop tables")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+SELECT
+    instance_id
+    , "position"
+FROM
+    projections.current_states
+WHERE
+    projection_name = $1
+FOR UPDATE;
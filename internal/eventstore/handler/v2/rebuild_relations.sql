@@ -0,0 +1,13 @@
+SELECT
+    table_name
+    , table_type = 'VIEW'
+FROM
+    information_schema.tables
+WHERE
+    table_schema = $1
+    AND (
+        table_name = $2::TEXT
+        -- secondary tables are suffixed with the name of the projection
+        OR left(table_name, length($2::TEXT) + 1) = $2::TEXT || '_'
+    )
+;
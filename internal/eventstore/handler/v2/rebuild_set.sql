@@ -0,0 +1,9 @@
+UPDATE projections.rebuilds SET
+    "state" = $2
+    , instances_total = $3
+    , instances_done = $4
+    , error = $5
+    , changed_at = now()
+WHERE
+    projection_name = $1
+;
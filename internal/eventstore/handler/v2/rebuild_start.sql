@@ -0,0 +1,24 @@
+INSERT INTO projections.rebuilds (
+    projection_name
+    , "state"
+    , started_at
+    , changed_at
+) VALUES (
+    $1
+    , $2
+    , now()
+    , now()
+) ON CONFLICT (
+    projection_name
+) DO UPDATE SET
+    "state" = EXCLUDED."state"
+    , instances_total = 0
+    , instances_done = 0
+    , error = NULL
+    , started_at = EXCLUDED.started_at
+    , changed_at = EXCLUDED.changed_at
+WHERE
+    -- a running rebuild is only replaced if it stopped reporting
+    rebuilds."state" IN ($3, $4)
+    OR rebuilds.changed_at < now() - $5 * INTERVAL '1 second'
+;
@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestHandler_startRebuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		isErr func(err error) bool
+	}{
+		{
+			name: "already rebuilding",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(startRebuildStmt,
+					mock.WithExecArgs("projections.projection", RebuildStateBuilding, RebuildStateDone, RebuildStateFailed, staleRebuildAfter.Seconds()),
+					mock.WithExecNoRowsAffected(),
+				),
+			),
+			isErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "started",
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(startRebuildStmt,
+					mock.WithExecArgs("projections.projection", RebuildStateBuilding, RebuildStateDone, RebuildStateFailed, staleRebuildAfter.Seconds()),
+					mock.WithExecRowsAffected(1),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:     &database.DB{DB: tt.mock.DB},
+				projection: &projection{name: "projections.projection"},
+			}
+			err := h.startRebuild(context.Background())
+			if tt.isErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.isErr(err), "unexpected error: %v", err)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_caughtUp(t *testing.T) {
+	tests := []struct {
+		name      string
+		live      [][]driver.Value
+		shadow    [][]driver.Value
+		instances []string
+		want      bool
+	}{
+		{
+			name:      "behind",
+			live:      [][]driver.Value{{"instance1", 2.0}, {"instance2", 3.0}},
+			shadow:    [][]driver.Value{{"instance1", 2.0}, {"instance2", 2.5}},
+			instances: []string{"instance1", "instance2"},
+			want:      false,
+		},
+		{
+			name:      "new instance not reduced",
+			live:      [][]driver.Value{{"instance1", 2.0}, {"instance2", 3.0}},
+			shadow:    [][]driver.Value{{"instance1", 2.0}},
+			instances: []string{"instance1", "instance2"},
+			want:      false,
+		},
+		{
+			name:      "caught up",
+			live:      [][]driver.Value{{"instance1", 2.0}, {"instance2", nil}, {"removed", 4.0}},
+			shadow:    [][]driver.Value{{"instance1", 2.5}},
+			instances: []string{"instance1", "instance2"},
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlMock := mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(lockPositionsStmt,
+					mock.WithQueryArgs("projections.projection"),
+					mock.WithQueryResult([]string{"instance_id", "position"}, tt.live),
+				),
+				mock.ExpectQuery(lockPositionsStmt,
+					mock.WithQueryArgs("projections_shadow.projection"),
+					mock.WithQueryResult([]string{"instance_id", "position"}, tt.shadow),
+				),
+			)
+			tx, err := sqlMock.DB.Begin()
+			require.NoError(t, err)
+			h := &Handler{projection: &projection{name: "projections.projection"}}
+
+			got, err := h.caughtUp(tx, "projections_shadow.projection", tt.instances)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			sqlMock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_swapTables(t *testing.T) {
+	errMove := errors.New("move failed")
+	relationColumns := []string{"table_name", "is_view"}
+	tests := []struct {
+		name    string
+		mock    *mock.SQLMock
+		wantErr error
+	}{
+		{
+			name: "swapped",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(relationsStmt,
+					mock.WithQueryArgs("projections", "projection"),
+					mock.WithQueryResult(relationColumns, [][]driver.Value{{"projection", true}, {"projection_users", false}, {"projection_domains", false}}),
+				),
+				mock.ExcpectExec("DROP VIEW projections.projection", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("DROP TABLE projections.projection_users, projections.projection_domains", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(relationsStmt,
+					mock.WithQueryArgs("projections_shadow", "projection"),
+					mock.WithQueryResult(relationColumns, [][]driver.Value{{"projection", true}, {"projection_users", false}, {"projection_domains", false}}),
+				),
+				mock.ExcpectExec("ALTER VIEW projections_shadow.projection SET SCHEMA projections", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER TABLE projections_shadow.projection_users SET SCHEMA projections", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("ALTER TABLE projections_shadow.projection_domains SET SCHEMA projections", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec(deleteStatesStmt, mock.WithExecArgs("projections.projection"), mock.WithExecRowsAffected(2)),
+				mock.ExcpectExec(renameStatesStmt, mock.WithExecArgs("projections.projection", "projections_shadow.projection"), mock.WithExecRowsAffected(2)),
+				mock.ExcpectExec(deleteFailedEventsStmt, mock.WithExecArgs("projections.projection"), mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec(renameFailedEventsStmt, mock.WithExecArgs("projections.projection", "projections_shadow.projection"), mock.WithExecNoRowsAffected()),
+			),
+		},
+		{
+			name: "move fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectQuery(relationsStmt,
+					mock.WithQueryArgs("projections", "projection"),
+					mock.WithQueryResult(relationColumns, [][]driver.Value{{"projection", false}}),
+				),
+				mock.ExcpectExec("DROP TABLE projections.projection", mock.WithExecNoRowsAffected()),
+				mock.ExpectQuery(relationsStmt,
+					mock.WithQueryArgs("projections_shadow", "projection"),
+					mock.WithQueryResult(relationColumns, [][]driver.Value{{"projection", false}}),
+				),
+				mock.ExcpectExec("ALTER TABLE projections_shadow.projection SET SCHEMA projections", mock.WithExecErr(errMove)),
+			),
+			wantErr: errMove,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.mock.DB.Begin()
+			require.NoError(t, err)
+			h := &Handler{projection: &projection{name: "projections.projection"}}
+
+			err = h.swapTables(tx, "projections_shadow.projection")
+			assert.ErrorIs(t, err, tt.wantErr)
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func Test_shadowProjection(t *testing.T) {
+	shadow := &shadowProjection{Projection: &projection{name: "projections.projection"}}
+	assert.Equal(t, "projections_shadow.projection", shadow.Name())
+	assert.True(t, shadow.Init().IsNoop())
+}
+
+func Test_existingRowTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     string
+		tableName string
+		want      string
+	}{
+		{
+			name:      "live",
+			table:     "projections.projection",
+			tableName: "projections.projection",
+			want:      "projections.projection",
+		},
+		{
+			name:      "shadow",
+			table:     "projections.projection",
+			tableName: "projections_shadow.projection",
+			want:      "projections_shadow.projection",
+		},
+		{
+			name:      "shadow suffixed",
+			table:     "projections.projection_users",
+			tableName: "projections_shadow.projection_users",
+			want:      "projections_shadow.projection_users",
+		},
+		{
+			name:      "without schema",
+			table:     "projection",
+			tableName: "projection",
+			want:      "projection",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, existingRowTable(tt.table, tt.tableName))
+		})
+	}
+}
+
+func Test_viewSelect(t *testing.T) {
+	selectStmt := "SELECT u.id, d.name FROM projections.projection_users AS u LEFT JOIN projections.projection_domains AS d ON u.org = d.org LEFT JOIN projections.orgs AS o ON o.id = u.org"
+	secondaryTables := []*SuffixedTable{{suffix: "users"}, {suffix: "domains"}}
+	tests := []struct {
+		name     string
+		viewName string
+		want     string
+	}{
+		{
+			name:     "live",
+			viewName: "projections.projection",
+			want:     selectStmt,
+		},
+		{
+			name:     "shadow",
+			viewName: "projections_shadow.projection",
+			want:     "SELECT u.id, d.name FROM projections_shadow.projection_users AS u LEFT JOIN projections_shadow.projection_domains AS d ON u.org = d.org LEFT JOIN projections.orgs AS o ON o.id = u.org",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, viewSelect(selectStmt, tt.viewName, secondaryTables))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -148,13 +149,15 @@ func NewUpsertStatement(event eventstore.Event, conflictCols []Column, values []
 		config.err = ErrNoValues
 	}
 
-	updateCols, updateVals, args := getUpdateCols(values, conflictTarget, params, args)
+	updateCols, updateVals, args := getUpdateCols(values, conflictTarget, params, args, "")
 	if len(updateCols) == 0 || len(updateVals) == 0 {
 		config.err = ErrNoValues
 	}
 	config.args = args
 
 	q := func(config execConfig) string {
+		// the existing row is referenced by the table the statement is executed on
+		updateCols, updateVals, _ := getUpdateCols(values, conflictTarget, params, nil, config.tableName)
 		var updateStmt string
 		// the postgres standard does not allow to update a single column using a multi-column update
 		// discussion: https://www.postgresql.org/message-id/17451.1509381766%40sss.pgh.pa.us
@@ -243,7 +246,7 @@ func OnlySetValueInCase(table string, value interface{}, condition Condition) *o
 	}
 }
 
-func getUpdateCols(cols []Column, conflictTarget, params []string, args []interface{}) (updateCols, updateVals []string, updatedArgs []interface{}) {
+func getUpdateCols(cols []Column, conflictTarget, params []string, args []interface{}, tableName string) (updateCols, updateVals []string, updatedArgs []interface{}) {
 	updateCols = make([]string, len(cols))
 	updateVals = make([]string, len(cols))
 	updatedArgs = args
@@ -253,11 +256,11 @@ func getUpdateCols(cols []Column, conflictTarget, params []string, args []interf
 		updateCols[i] = col.Name
 		switch v := col.Value.(type) {
 		case *onlySetValueOnInsert:
-			updateVals[i] = v.Table + "." + col.Name
+			updateVals[i] = existingRowTable(v.Table, tableName) + "." + col.Name
 		case *onlySetValueInCase:
 			s, condArgs := v.Condition(strconv.Itoa(len(params) + 1))
 			updatedArgs = append(updatedArgs, condArgs...)
-			updateVals[i] = fmt.Sprintf("CASE WHEN %[1]s THEN EXCLUDED.%[2]s ELSE %[3]s.%[2]s END", s, col.Name, v.Table)
+			updateVals[i] = fmt.Sprintf("CASE WHEN %[1]s THEN EXCLUDED.%[2]s ELSE %[3]s.%[2]s END", s, col.Name, existingRowTable(v.Table, tableName))
 		default:
 			updateVals[i] = "EXCLUDED" + "." + col.Name
 		}
@@ -279,6 +282,16 @@ func getUpdateCols(cols []Column, conflictTarget, params []string, args []interf
 	return updateCols, updateVals, updatedArgs
 }
 
+// existingRowTable moves table into the schema of tableName,
+// so that the existing row is also found if the projection is rebuilt in another schema
+func existingRowTable(table, tableName string) string {
+	schemaEnd := strings.LastIndex(tableName, ".")
+	if schemaEnd < 0 {
+		return table
+	}
+	return tableName[:schemaEnd+1] + tableNameWithoutSchema(table)
+}
+
 func NewUpdateStatement(event eventstore.Event, values []Column, conditions []Condition, opts ...execOption) *Statement {
 	cols, params, args := columnsToQuery(values)
 	wheres, whereArgs := conditionsToWhere(conditions, len(args)+1)
@@ -652,6 +665,21 @@ type Executer interface {
 	Exec(string, ...interface{}) (sql.Result, error)
 }
 
+// contextExecuter passes the context of the handler to the statements
+type contextExecuter struct {
+	*sql.Tx
+	ctx context.Context
+}
+
+// ExecuterContext returns the context of the handler executing the statement.
+// Statements with side effects use it, so they are canceled as soon as the handler stops or its transaction times out.
+func ExecuterContext(ex Executer) context.Context {
+	if executer, ok := ex.(*contextExecuter); ok {
+		return executer.ctx
+	}
+	return context.Background()
+}
+
 type execOption func(*execConfig)
 type execConfig struct {
 	tableName string
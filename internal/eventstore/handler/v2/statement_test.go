@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"reflect"
@@ -1732,3 +1733,14 @@ func TestParameterOpts(t *testing.T) {
 // 		})
 // 	}
 // }
+
+func TestExecuterContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "handler")
+	if got := ExecuterContext(&contextExecuter{ctx: ctx}).Value(ctxKey{}); got != "handler" {
+		t.Errorf("ExecuterContext() = %v, want context of the handler", got)
+	}
+	if got := ExecuterContext(&wantExecuter{}); got != context.Background() {
+		t.Errorf("ExecuterContext() = %v, want background context", got)
+	}
+}
@@ -0,0 +1,489 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// RedactedPersonalData replaces the personal data of events whose data key was shredded.
+	RedactedPersonalData = "[redacted]"
+
+	// encryptedPersonalDataField is the field of the payload containing the encrypted personal data
+	encryptedPersonalDataField = "encryptedPersonalData"
+
+	dataKeyLength = 32
+)
+
+// RegisterPersonalDataFields registers the fields of the payload of the event type which contain personal data.
+// The fields must be strings on the top level of the payload.
+//
+// If personal data encryption is enabled, the fields are encrypted with the data key of the aggregate before the event is stored
+// and decrypted when the event is filtered.
+func RegisterPersonalDataFields(eventType EventType, fields ...string) {
+	if eventType == "" || len(fields) == 0 {
+		return
+	}
+	if eventInterceptors == nil {
+		eventInterceptors = make(map[EventType]eventTypeInterceptors)
+	}
+	interceptor := eventInterceptors[eventType]
+	interceptor.personalDataFields = fields
+	eventInterceptors[eventType] = interceptor
+}
+
+// RegisterPersonalDataShredder registers an event type which shreds the data key of its aggregate.
+// The personal data of all events of the aggregate is redacted afterwards.
+func RegisterPersonalDataShredder(eventType EventType) {
+	if eventType == "" {
+		return
+	}
+	if eventInterceptors == nil {
+		eventInterceptors = make(map[EventType]eventTypeInterceptors)
+	}
+	interceptor := eventInterceptors[eventType]
+	interceptor.shredsPersonalData = true
+	eventInterceptors[eventType] = interceptor
+}
+
+// PersonalDataFields returns the fields registered by [RegisterPersonalDataFields]
+func PersonalDataFields(eventType EventType) []string {
+	return eventInterceptors[eventType].personalDataFields
+}
+
+// ShredsPersonalData returns if the event type was registered by [RegisterPersonalDataShredder]
+func ShredsPersonalData(eventType EventType) bool {
+	return eventInterceptors[eventType].shredsPersonalData
+}
+
+// PersonalDataEventTypes returns the event types registered by [RegisterPersonalDataFields]
+func PersonalDataEventTypes() []EventType {
+	return personalDataEventTypes(func(interceptor eventTypeInterceptors) bool {
+		return len(interceptor.personalDataFields) > 0
+	})
+}
+
+// PersonalDataShredderTypes returns the event types registered by [RegisterPersonalDataShredder]
+func PersonalDataShredderTypes() []EventType {
+	return personalDataEventTypes(func(interceptor eventTypeInterceptors) bool {
+		return interceptor.shredsPersonalData
+	})
+}
+
+func personalDataEventTypes(filter func(eventTypeInterceptors) bool) []EventType {
+	types := make([]EventType, 0)
+	for typ, interceptor := range eventInterceptors {
+		if filter(interceptor) {
+			types = append(types, typ)
+		}
+	}
+	slices.Sort(types)
+	return types
+}
+
+// DataKey is the key used to encrypt the personal data of an aggregate
+type DataKey struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	// ID identifies the key, if a new key is created after the previous one was shredded
+	// the personal data encrypted with the previous key stays redacted.
+	ID string
+	// Key is encrypted by the personal data encryption key
+	Key *crypto.CryptoValue
+}
+
+type DataKeyStorage interface {
+	// DataKey returns the data key of the aggregate.
+	// If the aggregate has no key, because it was never created or was shredded, nil is returned.
+	DataKey(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateID string) (*DataKey, error)
+	// AddDataKey stores the key if the aggregate has no key yet.
+	// The key of the aggregate is returned, which is not the passed key if another one was stored before.
+	AddDataKey(ctx context.Context, key *DataKey) (*DataKey, error)
+}
+
+// encryptedPersonalData replaces the personal data fields in the payload
+type encryptedPersonalData struct {
+	KeyID string `json:"keyId,omitempty"`
+	// Fields are the names of the encrypted fields, so they can be redacted after the key was shredded
+	Fields  []string `json:"fields"`
+	Crypted []byte   `json:"crypted,omitempty"`
+}
+
+type dataKeyID struct {
+	instanceID    string
+	aggregateType AggregateType
+	aggregateID   string
+}
+
+// dataKeys caches the decrypted data keys during a push or filter.
+// Missing keys are cached as nil.
+type dataKeys map[dataKeyID]*decryptedDataKey
+
+type decryptedDataKey struct {
+	id  string
+	key string
+}
+
+func (es *Eventstore) personalDataEnabled() bool {
+	return es.dataKeys != nil && es.personalDataEncryption != nil
+}
+
+// encryptPersonalData replaces the payload of the commands containing personal data by the encrypted payload.
+// The used data keys are returned, so the pushed events can be decrypted without querying the keys again.
+func (es *Eventstore) encryptPersonalData(ctx context.Context, cmds []Command) ([]Command, dataKeys, error) {
+	if !es.personalDataEnabled() {
+		return cmds, nil, nil
+	}
+	keys := make(dataKeys)
+	encrypted := make([]Command, len(cmds))
+	for i, cmd := range cmds {
+		encrypted[i] = cmd
+		fields := PersonalDataFields(cmd.Type())
+		if len(fields) == 0 || cmd.Payload() == nil {
+			continue
+		}
+		payload, err := json.Marshal(cmd.Payload())
+		if err != nil {
+			return nil, nil, zerrors.ThrowInternal(err, "EVENT-Ouf5e", "Errors.Internal")
+		}
+		instanceID := cmd.Aggregate().InstanceID
+		if instanceID == "" {
+			instanceID = authz.GetInstance(ctx).InstanceID()
+		}
+		key, err := es.ensureDataKey(ctx, keys, dataKeyID{instanceID: instanceID, aggregateType: cmd.Aggregate().Type, aggregateID: cmd.Aggregate().ID})
+		if err != nil {
+			return nil, nil, err
+		}
+		payload, err = encryptPayload(payload, fields, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		encrypted[i] = &personalDataCommand{Command: cmd, payload: payload}
+	}
+	return encrypted, keys, nil
+}
+
+// personalDataCommand overwrites the payload of the command with the encrypted one
+type personalDataCommand struct {
+	Command
+	payload json.RawMessage
+}
+
+func (cmd *personalDataCommand) Payload() any {
+	return cmd.payload
+}
+
+// decryptPersonalData wraps reduce, so the personal data of the events is decrypted before they are reduced.
+// If keys is nil, the data keys are only cached for the returned reducer.
+func (es *Eventstore) decryptPersonalData(ctx context.Context, keys dataKeys, reduce Reducer) Reducer {
+	if !es.personalDataEnabled() {
+		return reduce
+	}
+	if keys == nil {
+		keys = make(dataKeys)
+	}
+	return func(event Event) error {
+		event, err := es.decryptEvent(ctx, keys, event)
+		if err != nil {
+			return err
+		}
+		return reduce(event)
+	}
+}
+
+func (es *Eventstore) decryptEvents(ctx context.Context, keys dataKeys, events []Event) (err error) {
+	if !es.personalDataEnabled() {
+		return nil
+	}
+	for i, event := range events {
+		events[i], err = es.decryptEvent(ctx, keys, event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptEvent returns the event with the decrypted personal data.
+// If the data key was shredded the personal data is redacted.
+// Events stored before personal data was encrypted are returned unchanged.
+func (es *Eventstore) decryptEvent(ctx context.Context, keys dataKeys, event Event) (Event, error) {
+	if len(PersonalDataFields(event.Type())) == 0 || len(event.DataAsBytes()) == 0 {
+		return event, nil
+	}
+	payload := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(event.DataAsBytes(), &payload); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-iev4O", "Errors.Internal")
+	}
+	data, ok := payload[encryptedPersonalDataField]
+	if !ok {
+		return event, nil
+	}
+	encrypted := new(encryptedPersonalData)
+	if err := json.Unmarshal(data, encrypted); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Xoh3a", "Errors.Internal")
+	}
+	aggregate := event.Aggregate()
+	key, err := es.dataKey(ctx, keys, dataKeyID{instanceID: aggregate.InstanceID, aggregateType: aggregate.Type, aggregateID: aggregate.ID})
+	if err != nil {
+		return nil, err
+	}
+	fields, err := decryptFields(encrypted, key)
+	if err != nil {
+		return nil, err
+	}
+	delete(payload, encryptedPersonalDataField)
+	for name, value := range fields {
+		payload[name] = value
+	}
+	decrypted := BaseEventFromRepo(event)
+	decrypted.Data, err = json.Marshal(payload)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Eeph4", "Errors.Internal")
+	}
+	return decrypted, nil
+}
+
+// encryptSnapshotPayload encrypts the payload of a snapshot with the data key of its aggregate,
+// so the personal data reduced into the snapshot is unreadable after the key was shredded.
+// Snapshots of aggregates without data key are not encrypted.
+func (es *Eventstore) encryptSnapshotPayload(ctx context.Context, keys dataKeys, aggregate *Aggregate, payload []byte) ([]byte, error) {
+	if !es.personalDataEnabled() {
+		return payload, nil
+	}
+	key, err := es.dataKey(ctx, keys, dataKeyID{instanceID: aggregate.InstanceID, aggregateType: aggregate.Type, aggregateID: aggregate.ID})
+	if err != nil || key == nil {
+		return payload, err
+	}
+	crypted, err := crypto.EncryptAES(payload, key.key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-ooR4u", "Errors.Internal")
+	}
+	payload, err = json.Marshal(&encryptedSnapshot{
+		Encrypted: &encryptedPersonalData{
+			KeyID:   key.id,
+			Crypted: crypted,
+		},
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Zoo2e", "Errors.Internal")
+	}
+	return payload, nil
+}
+
+// decryptSnapshot returns the snapshot with the decrypted payload.
+// nil is returned if the snapshot can't be decrypted anymore, so the state is reduced from the events.
+func (es *Eventstore) decryptSnapshot(ctx context.Context, keys dataKeys, snapshot *Snapshot) (*Snapshot, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	encrypted := new(encryptedSnapshot)
+	if err := json.Unmarshal(snapshot.Payload, encrypted); err != nil || encrypted.Encrypted == nil {
+		return snapshot, nil
+	}
+	if !es.personalDataEnabled() {
+		return nil, nil
+	}
+	key, err := es.dataKey(ctx, keys, dataKeyID{instanceID: snapshot.InstanceID, aggregateType: snapshot.AggregateType, aggregateID: snapshot.AggregateID})
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.id != encrypted.Encrypted.KeyID {
+		return nil, nil
+	}
+	payload, err := crypto.DecryptAES(encrypted.Encrypted.Crypted, key.key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Ahx4i", "Errors.Internal")
+	}
+	decrypted := *snapshot
+	decrypted.Payload = payload
+	return &decrypted, nil
+}
+
+// encryptedSnapshot is the payload of a snapshot encrypted by a data key
+type encryptedSnapshot struct {
+	Encrypted *encryptedPersonalData `json:"encryptedPersonalData"`
+}
+
+// EncryptPersonalDataPayload encrypts the personal data of a payload which was stored without encryption.
+// If shredded is true, the personal data is redacted instead, because the aggregate was already removed.
+// It is used to migrate events stored before personal data was encrypted.
+func (es *Eventstore) EncryptPersonalDataPayload(ctx context.Context, aggregate *Aggregate, eventType EventType, payload []byte, shredded bool) ([]byte, error) {
+	fields := PersonalDataFields(eventType)
+	if !es.personalDataEnabled() || len(fields) == 0 || len(payload) == 0 {
+		return payload, nil
+	}
+	if shredded {
+		return encryptPayload(payload, fields, nil)
+	}
+	key, err := es.ensureDataKey(ctx, make(dataKeys), dataKeyID{instanceID: aggregate.InstanceID, aggregateType: aggregate.Type, aggregateID: aggregate.ID})
+	if err != nil {
+		return nil, err
+	}
+	return encryptPayload(payload, fields, key)
+}
+
+// encryptPayload moves the personal data fields of the payload into the encrypted personal data.
+// If the payload was already encrypted before further fields were registered,
+// the plain fields are added to the encrypted ones.
+// If key is nil, the fields are redacted.
+func encryptPayload(payload []byte, fields []string, key *decryptedDataKey) ([]byte, error) {
+	data := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-ahW3u", "Errors.Internal")
+	}
+	personalData := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		value, ok := data[field]
+		if !ok {
+			continue
+		}
+		personalData[field] = value
+		delete(data, field)
+	}
+	if len(personalData) == 0 {
+		return payload, nil
+	}
+	encrypted := &encryptedPersonalData{
+		Fields: make([]string, 0, len(personalData)),
+	}
+	for _, field := range fields {
+		if _, ok := personalData[field]; ok {
+			encrypted.Fields = append(encrypted.Fields, field)
+		}
+	}
+	if previous, ok := data[encryptedPersonalDataField]; ok {
+		previouslyEncrypted := new(encryptedPersonalData)
+		if err := json.Unmarshal(previous, previouslyEncrypted); err != nil {
+			return nil, zerrors.ThrowInternal(err, "EVENT-Ieg3a", "Errors.Internal")
+		}
+		previousFields, err := decryptFields(previouslyEncrypted, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range previouslyEncrypted.Fields {
+			if _, ok := personalData[field]; ok {
+				continue
+			}
+			personalData[field] = previousFields[field]
+			encrypted.Fields = append(encrypted.Fields, field)
+		}
+	}
+	if key != nil {
+		plain, err := json.Marshal(personalData)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "EVENT-aiK3o", "Errors.Internal")
+		}
+		encrypted.KeyID = key.id
+		encrypted.Crypted, err = crypto.EncryptAES(plain, key.key)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "EVENT-Ohp8e", "Errors.Internal")
+		}
+	}
+	var err error
+	data[encryptedPersonalDataField], err = json.Marshal(encrypted)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Ji7ie", "Errors.Internal")
+	}
+	payload, err = json.Marshal(data)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-keeT0", "Errors.Internal")
+	}
+	return payload, nil
+}
+
+// decryptFields returns the personal data fields.
+// If the key is missing or was replaced, the fields are redacted.
+func decryptFields(encrypted *encryptedPersonalData, key *decryptedDataKey) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage, len(encrypted.Fields))
+	if key == nil || encrypted.KeyID == "" || key.id != encrypted.KeyID {
+		redacted, _ := json.Marshal(RedactedPersonalData)
+		for _, field := range encrypted.Fields {
+			fields[field] = redacted
+		}
+		return fields, nil
+	}
+	plain, err := crypto.DecryptAES(encrypted.Crypted, key.key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Uo6oo", "Errors.Internal")
+	}
+	if err = json.Unmarshal(plain, &fields); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-ieX9a", "Errors.Internal")
+	}
+	return fields, nil
+}
+
+// dataKey returns the decrypted data key of the aggregate or nil if it has none
+func (es *Eventstore) dataKey(ctx context.Context, keys dataKeys, id dataKeyID) (*decryptedDataKey, error) {
+	if key, ok := keys[id]; ok {
+		return key, nil
+	}
+	stored, err := es.dataKeys.DataKey(ctx, id.instanceID, id.aggregateType, id.aggregateID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := es.decryptDataKey(stored)
+	if err != nil {
+		return nil, err
+	}
+	keys[id] = key
+	return key, nil
+}
+
+// ensureDataKey returns the decrypted data key of the aggregate and creates it if it has none
+func (es *Eventstore) ensureDataKey(ctx context.Context, keys dataKeys, id dataKeyID) (*decryptedDataKey, error) {
+	key, err := es.dataKey(ctx, keys, id)
+	if err != nil || key != nil {
+		return key, err
+	}
+	value := make([]byte, dataKeyLength)
+	if _, err = rand.Read(value); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-eiT0e", "Errors.Internal")
+	}
+	keyID := make([]byte, 16)
+	if _, err = rand.Read(keyID); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Ka3ph", "Errors.Internal")
+	}
+	encrypted, err := crypto.Encrypt(value, es.personalDataEncryption)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := es.dataKeys.AddDataKey(ctx, &DataKey{
+		InstanceID:    id.instanceID,
+		AggregateType: id.aggregateType,
+		AggregateID:   id.aggregateID,
+		ID:            base64.RawURLEncoding.EncodeToString(keyID),
+		Key:           encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	key, err = es.decryptDataKey(stored)
+	if err != nil {
+		return nil, err
+	}
+	keys[id] = key
+	return key, nil
+}
+
+func (es *Eventstore) decryptDataKey(stored *DataKey) (*decryptedDataKey, error) {
+	if stored == nil {
+		return nil, nil
+	}
+	key, err := crypto.Decrypt(stored.Key, es.personalDataEncryption)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptedDataKey{
+		id:  stored.ID,
+		key: string(key),
+	}, nil
+}
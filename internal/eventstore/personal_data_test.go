@@ -0,0 +1,383 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+const (
+	personalDataEventType EventType = "test.personal.data"
+	shredderEventType     EventType = "test.personal.shredded"
+)
+
+// registerPersonalDataTestEvents registers the test events in each test,
+// because other tests of the package reset the registered interceptors
+func registerPersonalDataTestEvents() {
+	RegisterPersonalDataFields(personalDataEventType, "name", "email")
+	RegisterPersonalDataShredder(shredderEventType)
+}
+
+type personalDataPayload struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Language string `json:"language,omitempty"`
+}
+
+type personalDataTestEvent struct {
+	BaseEvent
+	payload any
+}
+
+func (e *personalDataTestEvent) Payload() any {
+	return e.payload
+}
+
+func (e *personalDataTestEvent) UniqueConstraints() []*UniqueConstraint {
+	return nil
+}
+
+func newPersonalDataTestEvent(ctx context.Context, typ EventType, payload any) *personalDataTestEvent {
+	return &personalDataTestEvent{
+		BaseEvent: *NewBaseEventForPush(ctx, &Aggregate{
+			ID:            "user",
+			Type:          "test.user",
+			ResourceOwner: "org",
+			InstanceID:    "instance",
+			Version:       "v1",
+		}, typ),
+		payload: payload,
+	}
+}
+
+// testDataKeys stores the data keys in memory
+type testDataKeys struct {
+	keys map[string]*DataKey
+}
+
+func newTestDataKeys() *testDataKeys {
+	return &testDataKeys{keys: make(map[string]*DataKey)}
+}
+
+func (s *testDataKeys) DataKey(_ context.Context, instanceID string, aggregateType AggregateType, aggregateID string) (*DataKey, error) {
+	return s.keys[instanceID+string(aggregateType)+aggregateID], nil
+}
+
+func (s *testDataKeys) AddDataKey(_ context.Context, key *DataKey) (*DataKey, error) {
+	id := key.InstanceID + string(key.AggregateType) + key.AggregateID
+	if stored, ok := s.keys[id]; ok {
+		return stored, nil
+	}
+	s.keys[id] = key
+	return key, nil
+}
+
+func (s *testDataKeys) shred(instanceID string, aggregateType AggregateType, aggregateID string) {
+	delete(s.keys, instanceID+string(aggregateType)+aggregateID)
+}
+
+// storingPusher stores the pushed events, so they can be filtered afterwards
+type storingPusher struct {
+	events []Event
+}
+
+func (*storingPusher) Health(context.Context) error {
+	return nil
+}
+
+func (*storingPusher) Client() *database.DB {
+	return nil
+}
+
+func (p *storingPusher) Push(_ context.Context, _ database.ContextQueryExecuter, commands ...Command) ([]Event, error) {
+	events := make([]Event, len(commands))
+	for i, command := range commands {
+		payload, err := json.Marshal(command.Payload())
+		if err != nil {
+			return nil, err
+		}
+		events[i] = &BaseEvent{
+			EventType: command.Type(),
+			Agg:       command.Aggregate(),
+			Seq:       uint64(len(p.events) + 1),
+			Data:      payload,
+		}
+		p.events = append(p.events, events[i])
+	}
+	return events, nil
+}
+
+func (p *storingPusher) FilterToReducer(_ context.Context, _ *SearchQueryBuilder, reduce Reducer) error {
+	for _, event := range p.events {
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*storingPusher) LatestSequence(context.Context, *SearchQueryBuilder) (float64, error) {
+	return 0, nil
+}
+
+func (*storingPusher) InstanceIDs(context.Context, *SearchQueryBuilder) ([]string, error) {
+	return nil, nil
+}
+
+func unmarshalPersonalData(t *testing.T, event Event) *personalDataPayload {
+	payload := new(personalDataPayload)
+	require.NoError(t, event.Unmarshal(payload))
+	return payload
+}
+
+func TestEventstore_personalData(t *testing.T) {
+	registerPersonalDataTestEvents()
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	storage := &storingPusher{}
+	keyStorage := newTestDataKeys()
+	es := NewEventstore(&Config{
+		Pusher:                 storage,
+		Querier:                storage,
+		DataKeys:               keyStorage,
+		PersonalDataEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	})
+	added := &personalDataPayload{Name: "Gigi Giraffe", Email: "gigi@zitadel.com", Language: "en"}
+
+	pushed, err := es.Push(ctx, newPersonalDataTestEvent(ctx, personalDataEventType, added))
+	require.NoError(t, err)
+	require.Len(t, pushed, 1)
+	// the pushed events are returned decrypted
+	assert.Equal(t, added, unmarshalPersonalData(t, pushed[0]))
+
+	// the personal data is stored encrypted
+	stored := string(storage.events[0].DataAsBytes())
+	assert.NotContains(t, stored, "Gigi Giraffe")
+	assert.NotContains(t, stored, "gigi@zitadel.com")
+	assert.Contains(t, stored, `"language":"en"`)
+	assert.Contains(t, stored, encryptedPersonalDataField)
+
+	filtered, err := es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("test.user").Builder())
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, added, unmarshalPersonalData(t, filtered[0]))
+
+	// the key is shredded by the storage when the shredder event is pushed
+	_, err = es.Push(ctx, newPersonalDataTestEvent(ctx, shredderEventType, nil))
+	require.NoError(t, err)
+	keyStorage.shred("instance", "test.user", "user")
+
+	filtered, err = es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("test.user").Builder())
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, &personalDataPayload{Name: RedactedPersonalData, Email: RedactedPersonalData, Language: "en"}, unmarshalPersonalData(t, filtered[0]))
+
+	// a new key doesn't make the personal data readable again
+	_, err = es.Push(ctx, newPersonalDataTestEvent(ctx, personalDataEventType, &personalDataPayload{Name: "Gigi"}))
+	require.NoError(t, err)
+	filtered, err = es.Filter(ctx, NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("test.user").Builder())
+	require.NoError(t, err)
+	require.Len(t, filtered, 3)
+	assert.Equal(t, RedactedPersonalData, unmarshalPersonalData(t, filtered[0]).Name)
+	assert.Equal(t, "Gigi", unmarshalPersonalData(t, filtered[2]).Name)
+}
+
+func TestEventstore_personalData_disabled(t *testing.T) {
+	registerPersonalDataTestEvents()
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	storage := &storingPusher{}
+	es := NewEventstore(&Config{
+		Pusher:  storage,
+		Querier: storage,
+	})
+	added := &personalDataPayload{Name: "Gigi Giraffe", Email: "gigi@zitadel.com"}
+
+	_, err := es.Push(ctx, newPersonalDataTestEvent(ctx, personalDataEventType, added))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Gigi Giraffe","email":"gigi@zitadel.com"}`, string(storage.events[0].DataAsBytes()))
+}
+
+func Test_encryptPayload(t *testing.T) {
+	registerPersonalDataTestEvents()
+	key := &decryptedDataKey{id: "key", key: "01234567890123456789012345678901"}
+	tests := []struct {
+		name       string
+		payload    string
+		key        *decryptedDataKey
+		want       string
+		wantFields []string
+		redacted   bool
+	}{
+		{
+			name:    "no personal data",
+			payload: `{"language":"en"}`,
+			key:     key,
+			want:    `{"language":"en"}`,
+		},
+		{
+			name:    "already encrypted",
+			payload: `{"encryptedPersonalData":{"fields":["name"]}}`,
+			key:     key,
+			want:    `{"encryptedPersonalData":{"fields":["name"]}}`,
+		},
+		{
+			name:       "encrypted",
+			payload:    `{"name":"Gigi","language":"en"}`,
+			key:        key,
+			want:       `{"name":"Gigi","language":"en"}`,
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "empty values encrypted",
+			payload:    `{"name":"","email":"gigi@zitadel.com"}`,
+			key:        key,
+			want:       `{"name":"","email":"gigi@zitadel.com"}`,
+			wantFields: []string{"name", "email"},
+		},
+		{
+			name:       "redacted without key",
+			payload:    `{"name":"Gigi","language":"en"}`,
+			want:       `{"name":"[redacted]","language":"en"}`,
+			wantFields: []string{"name"},
+			redacted:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encryptPayload([]byte(tt.payload), []string{"name", "email"}, tt.key)
+			require.NoError(t, err)
+			if tt.wantFields == nil {
+				assert.JSONEq(t, tt.want, string(got))
+				return
+			}
+			payload := make(map[string]json.RawMessage)
+			require.NoError(t, json.Unmarshal(got, &payload))
+			encrypted := new(encryptedPersonalData)
+			require.NoError(t, json.Unmarshal(payload[encryptedPersonalDataField], encrypted))
+			assert.Equal(t, tt.wantFields, encrypted.Fields)
+			if tt.redacted {
+				assert.Empty(t, encrypted.Crypted)
+			}
+
+			fields, err := decryptFields(encrypted, tt.key)
+			require.NoError(t, err)
+			delete(payload, encryptedPersonalDataField)
+			for name, value := range fields {
+				payload[name] = value
+			}
+			decrypted, err := json.Marshal(payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(decrypted))
+		})
+	}
+}
+
+func Test_encryptPayload_registeredLater(t *testing.T) {
+	registerPersonalDataTestEvents()
+	key := &decryptedDataKey{id: "key", key: "01234567890123456789012345678901"}
+	payload, err := encryptPayload([]byte(`{"name":"Gigi","email":"gigi@zitadel.com"}`), []string{"name"}, key)
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), "gigi@zitadel.com")
+
+	// the email registered later is added to the encrypted name
+	payload, err = encryptPayload(payload, []string{"name", "email"}, key)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "gigi@zitadel.com")
+	data := make(map[string]*encryptedPersonalData)
+	require.NoError(t, json.Unmarshal(payload, &data))
+	assert.Equal(t, []string{"email", "name"}, data[encryptedPersonalDataField].Fields)
+
+	fields, err := decryptFields(data[encryptedPersonalDataField], key)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{"name": json.RawMessage(`"Gigi"`), "email": json.RawMessage(`"gigi@zitadel.com"`)}, fields)
+}
+
+func Test_decryptFields_otherKey(t *testing.T) {
+	registerPersonalDataTestEvents()
+	key := &decryptedDataKey{id: "key", key: "01234567890123456789012345678901"}
+	payload, err := encryptPayload([]byte(`{"name":"Gigi"}`), []string{"name"}, key)
+	require.NoError(t, err)
+	data := make(map[string]*encryptedPersonalData)
+	require.NoError(t, json.Unmarshal(payload, &data))
+
+	fields, err := decryptFields(data[encryptedPersonalDataField], &decryptedDataKey{id: "other", key: "98765432109876543210987654321098"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{"name": json.RawMessage(`"[redacted]"`)}, fields)
+}
+
+func TestEventstore_snapshotEncryption(t *testing.T) {
+	registerPersonalDataTestEvents()
+	ctx := authz.WithInstanceID(context.Background(), "instance")
+	keyStorage := newTestDataKeys()
+	es := NewEventstore(&Config{
+		DataKeys:               keyStorage,
+		PersonalDataEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	})
+	aggregate := &Aggregate{ID: "user", Type: "test.user", InstanceID: "instance"}
+	snapshot := func(payload []byte) *Snapshot {
+		return &Snapshot{InstanceID: "instance", AggregateType: "test.user", AggregateID: "user", Payload: payload}
+	}
+
+	// snapshots of aggregates without data key are not encrypted
+	payload, err := es.encryptSnapshotPayload(ctx, make(dataKeys), aggregate, []byte(`{"Name":"Gigi"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"Name":"Gigi"}`, string(payload))
+
+	_, err = es.ensureDataKey(ctx, make(dataKeys), dataKeyID{instanceID: "instance", aggregateType: "test.user", aggregateID: "user"})
+	require.NoError(t, err)
+	payload, err = es.encryptSnapshotPayload(ctx, make(dataKeys), aggregate, []byte(`{"Name":"Gigi"}`))
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "Gigi")
+
+	decrypted, err := es.decryptSnapshot(ctx, make(dataKeys), snapshot(payload))
+	require.NoError(t, err)
+	assert.Equal(t, snapshot([]byte(`{"Name":"Gigi"}`)), decrypted)
+
+	// the snapshot is ignored after the key was shredded
+	keyStorage.shred("instance", "test.user", "user")
+	decrypted, err = es.decryptSnapshot(ctx, make(dataKeys), snapshot(payload))
+	require.NoError(t, err)
+	assert.Nil(t, decrypted)
+}
+
+func TestEventstore_EncryptPersonalDataPayload(t *testing.T) {
+	registerPersonalDataTestEvents()
+	ctx := context.Background()
+	keyStorage := newTestDataKeys()
+	es := NewEventstore(&Config{
+		DataKeys:               keyStorage,
+		PersonalDataEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+	})
+	aggregate := &Aggregate{ID: "user", Type: "test.user", InstanceID: "instance"}
+
+	payload, err := es.EncryptPersonalDataPayload(ctx, aggregate, personalDataEventType, []byte(`{"name":"Gigi"}`), false)
+	require.NoError(t, err)
+	assert.NotContains(t, string(payload), "Gigi")
+	assert.Len(t, keyStorage.keys, 1)
+
+	event := &BaseEvent{EventType: personalDataEventType, Agg: aggregate, Data: payload}
+	decrypted, err := es.decryptEvent(ctx, make(dataKeys), event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Gigi"}`, string(decrypted.DataAsBytes()))
+
+	// removed aggregates are redacted
+	payload, err = es.EncryptPersonalDataPayload(ctx, &Aggregate{ID: "removed", Type: "test.user", InstanceID: "instance"}, personalDataEventType, []byte(`{"name":"Gigi"}`), true)
+	require.NoError(t, err)
+	assert.Len(t, keyStorage.keys, 1)
+	event = &BaseEvent{EventType: personalDataEventType, Agg: &Aggregate{ID: "removed", Type: "test.user", InstanceID: "instance"}, Data: payload}
+	decrypted, err = es.decryptEvent(ctx, make(dataKeys), event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"[redacted]"}`, string(decrypted.DataAsBytes()))
+
+	// events without personal data are not changed
+	payload, err = es.EncryptPersonalDataPayload(ctx, aggregate, "test.other", []byte(`{"name":"Gigi"}`), false)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"Gigi"}`, string(payload))
+}
@@ -0,0 +1,216 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/build"
+)
+
+// SnapshotReducer is a [QueryReducer] whose state can be stored as snapshot.
+// Only the events newer than the latest snapshot are reduced when filtering.
+//
+// The reducer must be serializable as JSON and embed [WriteModel].
+// Its query must only select events of a single aggregate and must not depend on the reduced state.
+type SnapshotReducer interface {
+	QueryReducer
+	// SnapshotVersion must be increased whenever the reduce logic changes,
+	// so snapshots of previous versions are ignored.
+	// Snapshots stored by other builds are ignored as well.
+	SnapshotVersion() uint16
+	writeModel() *WriteModel
+}
+
+// Snapshot is the serialized state of a [SnapshotReducer] after reducing all events up to the sequence of the aggregate.
+type Snapshot struct {
+	InstanceID    string
+	ResourceOwner string
+	AggregateType AggregateType
+	AggregateID   string
+	// Type identifies the reducer
+	Type string
+	// Version is the version of the reducer code, snapshots of other versions are invalid
+	Version string
+
+	Sequence   uint64
+	Position   float64
+	ChangeDate time.Time
+	Payload    []byte
+}
+
+type Snapshotter interface {
+	// LatestSnapshot returns the latest snapshot of the aggregate for the given type and version.
+	// If no valid snapshot exists, nil is returned.
+	// The resource owner is only checked if it's set.
+	LatestSnapshot(ctx context.Context, instanceID, resourceOwner string, aggregateType AggregateType, aggregateID, snapshotType, version string) (*Snapshot, error)
+	// StoreSnapshot stores the snapshot, a previous snapshot of the same type is replaced
+	StoreSnapshot(ctx context.Context, snapshot *Snapshot) error
+}
+
+// filterToSnapshotReducer loads the latest snapshot into the reducer and reduces the newer events.
+// A new snapshot is stored, if at least [Config.SnapshotThreshold] events were reduced.
+func (es *Eventstore) filterToSnapshotReducer(ctx context.Context, r SnapshotReducer) error {
+	searchQuery := r.Query()
+	searchQuery.ensureInstanceID(ctx)
+	aggregateType, aggregateID, ok := snapshotAggregate(searchQuery)
+	if !ok {
+		return es.FilterToReducer(ctx, searchQuery, r)
+	}
+	snapshotType, version := snapshotTypeAndVersion(r)
+
+	snapshot, err := es.snapshotter.LatestSnapshot(ctx, *searchQuery.instanceID, searchQuery.resourceOwner, aggregateType, aggregateID, snapshotType, version)
+	if err != nil {
+		return err
+	}
+	keys := make(dataKeys)
+	snapshot, err = es.decryptSnapshot(ctx, keys, snapshot)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		if err = restoreSnapshot(r, snapshot); err != nil {
+			return err
+		}
+		searchQuery.SequenceGreater(snapshot.Sequence)
+	}
+
+	var (
+		reduced   uint32
+		lastEvent Event
+	)
+	err = es.querier.FilterToReducer(ctx, searchQuery, es.decryptPersonalData(ctx, keys, func(event Event) error {
+		event, err := es.mapEvent(event)
+		if err != nil {
+			return err
+		}
+		reduced++
+		lastEvent = event
+		r.AppendEvents(event)
+		return r.Reduce()
+	}))
+	if err != nil || lastEvent == nil || reduced < es.snapshotThreshold {
+		return err
+	}
+	es.storeSnapshot(ctx, keys, r, lastEvent, snapshotType, version)
+	return nil
+}
+
+// storeSnapshot stores the current state of the reducer.
+// Failing to store a snapshot doesn't fail the filter, as the state can always be reduced from the events.
+func (es *Eventstore) storeSnapshot(ctx context.Context, keys dataKeys, r SnapshotReducer, lastEvent Event, snapshotType, version string) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		logging.WithFields("type", snapshotType).WithError(err).Warn("unable to marshal snapshot")
+		return
+	}
+	wm := r.writeModel()
+	aggregate := lastEvent.Aggregate()
+	payload, err = es.encryptSnapshotPayload(ctx, keys, aggregate, payload)
+	if err != nil {
+		logging.WithFields("type", snapshotType, "aggregateID", aggregate.ID).WithError(err).Warn("unable to encrypt snapshot")
+		return
+	}
+	err = es.snapshotter.StoreSnapshot(ctx, &Snapshot{
+		InstanceID:    aggregate.InstanceID,
+		ResourceOwner: aggregate.ResourceOwner,
+		AggregateType: aggregate.Type,
+		AggregateID:   aggregate.ID,
+		Type:          snapshotType,
+		Version:       version,
+		Sequence:      lastEvent.Sequence(),
+		Position:      lastEvent.Position(),
+		ChangeDate:    wm.ChangeDate,
+		Payload:       payload,
+	})
+	logging.WithFields("type", snapshotType, "aggregateID", aggregate.ID).OnError(err).Warn("unable to store snapshot")
+}
+
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
+
+// restoreSnapshot sets the state of the reducer.
+// The fields of [WriteModel] are not serialized, so they are restored from the snapshot itself.
+func restoreSnapshot(r SnapshotReducer, snapshot *Snapshot) error {
+	if err := json.Unmarshal(snapshot.Payload, r); err != nil {
+		return err
+	}
+	wm := r.writeModel()
+	wm.AggregateID = snapshot.AggregateID
+	wm.ResourceOwner = snapshot.ResourceOwner
+	wm.InstanceID = snapshot.InstanceID
+	wm.ProcessedSequence = snapshot.Sequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return nil
+}
+
+// snapshotAggregate returns the aggregate of the search query, if snapshots can be used for it.
+// This is only the case for queries of a single aggregate, which select the whole history of it.
+func snapshotAggregate(searchQuery *SearchQueryBuilder) (AggregateType, string, bool) {
+	if searchQuery.instanceID == nil ||
+		len(searchQuery.queries) != 1 ||
+		searchQuery.tx != nil ||
+		searchQuery.desc ||
+		searchQuery.limit > 0 ||
+		searchQuery.offset > 0 ||
+		searchQuery.allowTimeTravel ||
+		searchQuery.positionAfter > 0 ||
+		searchQuery.eventSequenceGreater > 0 ||
+		!searchQuery.creationDateAfter.IsZero() ||
+		!searchQuery.creationDateBefore.IsZero() ||
+		searchQuery.excludeAggregateIDs != nil {
+		return "", "", false
+	}
+	query := searchQuery.queries[0]
+	if len(query.aggregateTypes) != 1 || len(query.aggregateIDs) != 1 || query.positionAfter > 0 {
+		return "", "", false
+	}
+	return query.aggregateTypes[0], query.aggregateIDs[0], true
+}
+
+// snapshotTypeAndVersion identifies the reducer and the version of its state.
+// Besides the version of the reducer, the version contains the version of the build
+// and a fingerprint of the fields including nested types,
+// so snapshots are invalidated automatically if the state or the code changes.
+func snapshotTypeAndVersion(r SnapshotReducer) (snapshotType, version string) {
+	typ := reflect.TypeOf(r)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	fingerprint := fnv.New32a()
+	fingerprintType(fingerprint, typ, make(map[reflect.Type]bool))
+	return typ.PkgPath() + "." + typ.Name(), fmt.Sprintf("%d.%x.%s", r.SnapshotVersion(), fingerprint.Sum32(), build.Version())
+}
+
+// fingerprintType writes the structure of the type to the hash.
+// Each struct type is only described once, which also stops the recursion of self-referencing types.
+func fingerprintType(w io.Writer, typ reflect.Type, seen map[reflect.Type]bool) {
+	// errors of the writer are ignored, as the hash never fails
+	_, _ = fmt.Fprintf(w, "%s(", typ.String())
+	defer func() { _, _ = io.WriteString(w, ")") }()
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		fingerprintType(w, typ.Elem(), seen)
+	case reflect.Map:
+		fingerprintType(w, typ.Key(), seen)
+		fingerprintType(w, typ.Elem(), seen)
+	case reflect.Struct:
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			_, _ = fmt.Fprintf(w, "%s:%q:", field.Name, field.Tag.Get("json"))
+			fingerprintType(w, field.Type, seen)
+			_, _ = io.WriteString(w, ";")
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+// snapshotWriteModel counts the reduced events
+type snapshotWriteModel struct {
+	WriteModel
+
+	Count int
+}
+
+func (wm *snapshotWriteModel) Reduce() error {
+	wm.Count += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *snapshotWriteModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *snapshotWriteModel) SnapshotVersion() uint16 {
+	return 1
+}
+
+// snapshotWriteModelV2 has the same fields as snapshotWriteModel, but a different state
+type snapshotWriteModelV2 struct {
+	WriteModel
+
+	Count string
+}
+
+func (wm *snapshotWriteModelV2) Reduce() error              { return wm.WriteModel.Reduce() }
+func (wm *snapshotWriteModelV2) Query() *SearchQueryBuilder { return nil }
+func (wm *snapshotWriteModelV2) SnapshotVersion() uint16    { return 1 }
+
+type testSnapshotter struct {
+	snapshot *Snapshot
+	err      error
+	stored   *Snapshot
+}
+
+func (s *testSnapshotter) LatestSnapshot(_ context.Context, instanceID, resourceOwner string, aggregateType AggregateType, aggregateID, snapshotType, version string) (*Snapshot, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.snapshot == nil ||
+		s.snapshot.InstanceID != instanceID ||
+		s.snapshot.AggregateType != aggregateType ||
+		s.snapshot.AggregateID != aggregateID ||
+		s.snapshot.Type != snapshotType ||
+		s.snapshot.Version != version ||
+		(resourceOwner != "" && s.snapshot.ResourceOwner != resourceOwner) {
+		return nil, nil
+	}
+	return s.snapshot, nil
+}
+
+func (s *testSnapshotter) StoreSnapshot(_ context.Context, snapshot *Snapshot) error {
+	s.stored = snapshot
+	return nil
+}
+
+// sequenceQuerier only returns the events newer than the requested sequence
+type sequenceQuerier struct {
+	testQuerier
+}
+
+func (repo *sequenceQuerier) FilterToReducer(_ context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	for _, event := range repo.events {
+		if event.Sequence() <= searchQuery.GetEventSequenceGreater() {
+			continue
+		}
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*sequenceQuerier) Client() *database.DB {
+	return nil
+}
+
+func snapshotTestEvents(count int) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			EventType: "test.snapshot.event",
+			Agg: &Aggregate{
+				ID:            "aggregate",
+				Type:          "test.aggregate",
+				ResourceOwner: "ro",
+				InstanceID:    "instance",
+			},
+			Seq:      uint64(i + 1),
+			Pos:      float64(i + 1),
+			Creation: time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+	}
+	return events
+}
+
+func TestEventstore_FilterToQueryReducer_snapshot(t *testing.T) {
+	snapshotType, version := snapshotTypeAndVersion(new(snapshotWriteModel))
+	existingSnapshot := &Snapshot{
+		InstanceID:    "instance",
+		ResourceOwner: "ro",
+		AggregateType: "test.aggregate",
+		AggregateID:   "aggregate",
+		Type:          snapshotType,
+		Version:       version,
+		Sequence:      2,
+		Position:      2,
+		ChangeDate:    time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC),
+		Payload:       []byte(`{"Count":2}`),
+	}
+	type fields struct {
+		threshold   uint32
+		snapshotter *testSnapshotter
+	}
+	type want struct {
+		err               error
+		count             int
+		processedSequence uint64
+		stored            *Snapshot
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   want
+	}{
+		{
+			name: "snapshots disabled",
+			fields: fields{
+				threshold:   0,
+				snapshotter: &testSnapshotter{snapshot: existingSnapshot},
+			},
+			want: want{
+				count:             3,
+				processedSequence: 3,
+			},
+		},
+		{
+			name: "no snapshot, threshold not reached",
+			fields: fields{
+				threshold:   5,
+				snapshotter: &testSnapshotter{},
+			},
+			want: want{
+				count:             3,
+				processedSequence: 3,
+			},
+		},
+		{
+			name: "no snapshot, threshold reached",
+			fields: fields{
+				threshold:   3,
+				snapshotter: &testSnapshotter{},
+			},
+			want: want{
+				count:             3,
+				processedSequence: 3,
+				stored: &Snapshot{
+					InstanceID:    "instance",
+					ResourceOwner: "ro",
+					AggregateType: "test.aggregate",
+					AggregateID:   "aggregate",
+					Type:          snapshotType,
+					Version:       version,
+					Sequence:      3,
+					Position:      3,
+					ChangeDate:    time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+					Payload:       []byte(`{"Count":3}`),
+				},
+			},
+		},
+		{
+			name: "snapshot, newer events reduced",
+			fields: fields{
+				threshold:   2,
+				snapshotter: &testSnapshotter{snapshot: existingSnapshot},
+			},
+			want: want{
+				count:             3,
+				processedSequence: 3,
+			},
+		},
+		{
+			name: "snapshot of other version ignored",
+			fields: fields{
+				threshold: 5,
+				snapshotter: &testSnapshotter{snapshot: &Snapshot{
+					InstanceID:    "instance",
+					ResourceOwner: "ro",
+					AggregateType: "test.aggregate",
+					AggregateID:   "aggregate",
+					Type:          snapshotType,
+					Version:       "0.0",
+					Sequence:      2,
+					Payload:       []byte(`{"Count":100}`),
+				}},
+			},
+			want: want{
+				count:             3,
+				processedSequence: 3,
+			},
+		},
+		{
+			name: "snapshotter error",
+			fields: fields{
+				threshold:   2,
+				snapshotter: &testSnapshotter{err: errors.New("failed")},
+			},
+			want: want{
+				err: errors.New("failed"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := NewEventstore(&Config{
+				Querier:           &sequenceQuerier{testQuerier: testQuerier{events: snapshotTestEvents(3)}},
+				Snapshotter:       tt.fields.snapshotter,
+				SnapshotThreshold: tt.fields.threshold,
+			})
+			wm := &snapshotWriteModel{WriteModel: WriteModel{AggregateID: "aggregate"}}
+			err := es.FilterToQueryReducer(authz.WithInstanceID(context.Background(), "instance"), wm)
+			if tt.want.err != nil {
+				assert.Equal(t, tt.want.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.count, wm.Count)
+			assert.Equal(t, tt.want.processedSequence, wm.ProcessedSequence)
+			assert.Equal(t, "ro", wm.ResourceOwner)
+			assert.Equal(t, tt.want.stored, tt.fields.snapshotter.stored)
+		})
+	}
+}
+
+func Test_snapshotAggregate(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         *SearchQueryBuilder
+		wantType      AggregateType
+		wantID        string
+		wantSnapshots bool
+	}{
+		{
+			name: "single aggregate",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate").
+				Builder(),
+			wantType:      "test.aggregate",
+			wantID:        "aggregate",
+			wantSnapshots: true,
+		},
+		{
+			name: "multiple aggregates",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate1", "aggregate2").
+				Builder(),
+		},
+		{
+			name: "multiple queries",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate").
+				Or().
+				AggregateTypes("other.aggregate").
+				AggregateIDs("aggregate").
+				Builder(),
+		},
+		{
+			name: "limited",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				Limit(1).
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate").
+				Builder(),
+		},
+		{
+			name: "descending",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderDesc().
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate").
+				Builder(),
+		},
+		{
+			name: "no instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().
+				AggregateTypes("test.aggregate").
+				AggregateIDs("aggregate").
+				Builder(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotID, ok := snapshotAggregate(tt.query)
+			assert.Equal(t, tt.wantSnapshots, ok)
+			assert.Equal(t, tt.wantType, gotType)
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+}
+
+func Test_snapshotTypeAndVersion(t *testing.T) {
+	typ, version := snapshotTypeAndVersion(new(snapshotWriteModel))
+	assert.Equal(t, "github.com/zitadel/zitadel/internal/eventstore.snapshotWriteModel", typ)
+
+	// the version changes with the fields, even if the declared version is the same
+	typV2, versionV2 := snapshotTypeAndVersion(new(snapshotWriteModelV2))
+	assert.NotEqual(t, typ, typV2)
+	assert.NotEqual(t, version, versionV2)
+
+	// the version is stable within the same build
+	_, versionAgain := snapshotTypeAndVersion(new(snapshotWriteModel))
+	assert.Equal(t, version, versionAgain)
+}
+
+func Test_fingerprintType(t *testing.T) {
+	fingerprint := func(typ reflect.Type) string {
+		var b strings.Builder
+		fingerprintType(&b, typ, make(map[reflect.Type]bool))
+		return b.String()
+	}
+	// the types have the same names and top level fields, only the fields of the nested type differ
+	v1 := func() reflect.Type {
+		type nested struct{ Name string }
+		type writeModel struct {
+			WriteModel
+			Nested map[string]*nested
+		}
+		return reflect.TypeOf(writeModel{})
+	}()
+	v2 := func() reflect.Type {
+		type nested struct{ Name int }
+		type writeModel struct {
+			WriteModel
+			Nested map[string]*nested
+		}
+		return reflect.TypeOf(writeModel{})
+	}()
+	assert.NotEqual(t, fingerprint(v1), fingerprint(v2))
+
+	type selfReferencing struct {
+		Children []*selfReferencing
+	}
+	assert.Contains(t, fingerprint(reflect.TypeOf(selfReferencing{})), "Children")
+}
@@ -0,0 +1,94 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	//go:embed data_key.sql
+	dataKeyStmt string
+	//go:embed data_key_add.sql
+	addDataKeyStmt string
+	//go:embed data_key_shred.sql
+	shredDataKeyStmt string
+
+	_ eventstore.DataKeyStorage = (*Eventstore)(nil)
+)
+
+// DataKey implements [eventstore.DataKeyStorage]
+func (es *Eventstore) DataKey(ctx context.Context, instanceID string, aggregateType eventstore.AggregateType, aggregateID string) (_ *eventstore.DataKey, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	key := &eventstore.DataKey{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Key:           new(crypto.CryptoValue),
+	}
+	err = es.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&key.ID, key.Key)
+		},
+		dataKeyStmt,
+		instanceID,
+		aggregateType,
+		aggregateID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// AddDataKey implements [eventstore.DataKeyStorage]
+func (es *Eventstore) AddDataKey(ctx context.Context, key *eventstore.DataKey) (_ *eventstore.DataKey, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	_, err = es.client.ExecContext(ctx, addDataKeyStmt,
+		key.InstanceID,
+		key.AggregateType,
+		key.AggregateID,
+		key.ID,
+		key.Key,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the key of a concurrent push could have been stored first
+	return es.DataKey(ctx, key.InstanceID, key.AggregateType, key.AggregateID)
+}
+
+// shredDataKeys deletes the data keys of the aggregates of commands registered by [eventstore.RegisterPersonalDataShredder].
+// It's executed in the push transaction, so the key is gone as soon as the event is stored.
+func shredDataKeys(ctx context.Context, tx database.Tx, commands []eventstore.Command) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	for _, command := range commands {
+		if !eventstore.ShredsPersonalData(command.Type()) {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, shredDataKeyStmt,
+			command.Aggregate().InstanceID,
+			command.Aggregate().Type,
+			command.Aggregate().ID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+SELECT
+    key_id
+    , data_key
+FROM
+    eventstore.data_keys
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
@@ -0,0 +1,9 @@
+INSERT INTO eventstore.data_keys (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , key_id
+    , data_key
+) VALUES (
+    $1, $2, $3, $4, $5
+) ON CONFLICT (instance_id, aggregate_type, aggregate_id) DO NOTHING
@@ -0,0 +1,11 @@
+-- the snapshots are deleted as well, because they contain the reduced personal data
+WITH shredded AS (
+    DELETE FROM eventstore.data_keys
+    WHERE instance_id = $1
+        AND aggregate_type = $2
+        AND aggregate_id = $3
+)
+DELETE FROM eventstore.snapshots
+WHERE instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
@@ -69,6 +69,10 @@ func (es *Eventstore) writeCommands(ctx context.Context, client database.Context
 		return nil, err
 	}
 
+	if err = shredDataKeys(ctx, tx, commands); err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
@@ -0,0 +1,79 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	//go:embed snapshot_latest.sql
+	latestSnapshotStmt string
+	//go:embed snapshot_store.sql
+	storeSnapshotStmt string
+
+	_ eventstore.Snapshotter = (*Eventstore)(nil)
+)
+
+// LatestSnapshot implements [eventstore.Snapshotter]
+func (es *Eventstore) LatestSnapshot(ctx context.Context, instanceID, resourceOwner string, aggregateType eventstore.AggregateType, aggregateID, snapshotType, version string) (_ *eventstore.Snapshot, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	snapshot := &eventstore.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          snapshotType,
+		Version:       version,
+	}
+	err = es.client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(
+				&snapshot.ResourceOwner,
+				&snapshot.Sequence,
+				&snapshot.Position,
+				&snapshot.ChangeDate,
+				&snapshot.Payload,
+			)
+		},
+		latestSnapshotStmt,
+		instanceID,
+		aggregateType,
+		aggregateID,
+		snapshotType,
+		version,
+		resourceOwner,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// StoreSnapshot implements [eventstore.Snapshotter]
+func (es *Eventstore) StoreSnapshot(ctx context.Context, snapshot *eventstore.Snapshot) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	_, err = es.client.ExecContext(ctx, storeSnapshotStmt,
+		snapshot.InstanceID,
+		snapshot.AggregateType,
+		snapshot.AggregateID,
+		snapshot.Type,
+		snapshot.ResourceOwner,
+		snapshot.Version,
+		snapshot.Sequence,
+		snapshot.Position,
+		snapshot.ChangeDate,
+		snapshot.Payload,
+	)
+	return err
+}
@@ -0,0 +1,15 @@
+SELECT
+    resource_owner
+    , "sequence"
+    , "position"
+    , change_date
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
+    AND snapshot_type = $4
+    AND "version" = $5
+    AND ($6 = '' OR resource_owner = $6)
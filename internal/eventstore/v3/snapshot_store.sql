@@ -0,0 +1,24 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , snapshot_type
+    , resource_owner
+    , "version"
+    , "sequence"
+    , "position"
+    , change_date
+    , payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) ON CONFLICT (instance_id, aggregate_type, aggregate_id, snapshot_type) DO UPDATE SET
+    resource_owner = EXCLUDED.resource_owner
+    , "version" = EXCLUDED."version"
+    , "sequence" = EXCLUDED."sequence"
+    , "position" = EXCLUDED."position"
+    , change_date = EXCLUDED.change_date
+    , payload = EXCLUDED.payload
+    , created_at = NOW()
+-- an older snapshot must not replace a newer one of the same version
+WHERE eventstore.snapshots."version" <> EXCLUDED."version"
+    OR eventstore.snapshots."sequence" < EXCLUDED."sequence"
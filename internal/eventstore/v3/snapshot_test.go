@@ -0,0 +1,124 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestEventstore_LatestSnapshot(t *testing.T) {
+	changeDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		resourceOwner string
+	}
+	tests := []struct {
+		name    string
+		mock    func(t *testing.T) *mock.SQLMock
+		args    args
+		want    *eventstore.Snapshot
+		wantErr error
+	}{
+		{
+			name: "no snapshot",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(latestSnapshotStmt,
+						mock.WithQueryArgs("instance", eventstore.AggregateType("user"), "aggregate", "type", "1.0", ""),
+						mock.WithQueryErr(sql.ErrNoRows),
+					),
+				)
+			},
+			want: nil,
+		},
+		{
+			name: "query error",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(latestSnapshotStmt,
+						mock.WithQueryArgs("instance", eventstore.AggregateType("user"), "aggregate", "type", "1.0", ""),
+						mock.WithQueryErr(sql.ErrConnDone),
+					),
+				)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+		{
+			name: "snapshot of resource owner",
+			mock: func(t *testing.T) *mock.SQLMock {
+				return mock.NewSQLMock(t,
+					mock.ExpectQuery(latestSnapshotStmt,
+						mock.WithQueryArgs("instance", eventstore.AggregateType("user"), "aggregate", "type", "1.0", "ro"),
+						mock.WithQueryResult(
+							[]string{"resource_owner", "sequence", "position", "change_date", "payload"},
+							[][]driver.Value{{"ro", uint64(5), float64(5.5), changeDate, []byte(`{}`)}},
+						),
+					),
+				)
+			},
+			args: args{
+				resourceOwner: "ro",
+			},
+			want: &eventstore.Snapshot{
+				InstanceID:    "instance",
+				ResourceOwner: "ro",
+				AggregateType: "user",
+				AggregateID:   "aggregate",
+				Type:          "type",
+				Version:       "1.0",
+				Sequence:      5,
+				Position:      5.5,
+				ChangeDate:    changeDate,
+				Payload:       []byte(`{}`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbMock := tt.mock(t)
+			es := &Eventstore{client: &database.DB{DB: dbMock.DB}}
+			got, err := es.LatestSnapshot(context.Background(), "instance", tt.args.resourceOwner, "user", "aggregate", "type", "1.0")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			dbMock.Assert(t)
+		})
+	}
+}
+
+func TestEventstore_StoreSnapshot(t *testing.T) {
+	changeDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dbMock := mock.NewSQLMock(t,
+		mock.ExcpectExec(storeSnapshotStmt,
+			mock.WithExecArgs("instance", eventstore.AggregateType("user"), "aggregate", "type", "ro", "1.0", uint64(5), float64(5.5), changeDate, []byte(`{}`)),
+			mock.WithExecRowsAffected(1),
+		),
+	)
+	es := &Eventstore{client: &database.DB{DB: dbMock.DB}}
+	err := es.StoreSnapshot(context.Background(), &eventstore.Snapshot{
+		InstanceID:    "instance",
+		ResourceOwner: "ro",
+		AggregateType: "user",
+		AggregateID:   "aggregate",
+		Type:          "type",
+		Version:       "1.0",
+		Sequence:      5,
+		Position:      5.5,
+		ChangeDate:    changeDate,
+		Payload:       []byte(`{}`),
+	})
+	require.NoError(t, err)
+	dbMock.Assert(t)
+}
@@ -0,0 +1,179 @@
+package grantexpiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WorkerUserID is set as editor of the events pushed by the worker.
+const WorkerUserID = "GRANT_EXPIRY"
+
+const (
+	locksTable = "projections.locks"
+	lockName   = "grant_expiry_worker"
+)
+
+type Config struct {
+	// Enabled starts the worker, which warns the owners about expiring user and project grants
+	// and deactivates the grants after their validity ended.
+	Enabled bool
+	// RequeueEvery is the time interval between the runs of the worker.
+	RequeueEvery time.Duration
+	// WarnBefore is the duration before the end of the validity in which the owners are warned.
+	WarnBefore time.Duration
+}
+
+type Queries interface {
+	ActiveInstances() []string
+	ExpiringUserGrants(ctx context.Context, warnBefore time.Duration, now time.Time) ([]*query.ExpiringUserGrant, error)
+	ExpiringProjectGrants(ctx context.Context, warnBefore time.Duration, now time.Time) ([]*query.ExpiringProjectGrant, error)
+}
+
+type Commands interface {
+	WarnUserGrantExpiry(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+	ExpireUserGrant(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+	WarnProjectGrantExpiry(ctx context.Context, projectID, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+	ExpireProjectGrant(ctx context.Context, projectID, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+// nowFunc makes [time.Now] mockable
+type nowFunc func() time.Time
+
+type Worker struct {
+	config   Config
+	commands Commands
+	queries  Queries
+	locker   crdb.Locker
+	now      nowFunc
+}
+
+func NewWorker(config Config, commands Commands, queries Queries, locker crdb.Locker) *Worker {
+	return &Worker{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+		locker:   locker,
+		now:      time.Now,
+	}
+}
+
+// NewLocker returns the lock, which ensures that each instance is only handled by a single worker
+// in case of multiple replicas.
+func NewLocker(client *database.DB) crdb.Locker {
+	return crdb.NewLocker(client.DB, locksTable, lockName)
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	if !w.config.Enabled {
+		return
+	}
+	go w.schedule(ctx)
+}
+
+func (w *Worker) schedule(ctx context.Context) {
+	t := time.NewTimer(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			logging.Info("grant expiry worker stopped")
+			return
+		case <-t.C:
+			w.triggerInstances(call.WithTimestamp(ctx), w.queries.ActiveInstances())
+			t.Reset(w.config.RequeueEvery)
+		}
+	}
+}
+
+func (w *Worker) triggerInstances(ctx context.Context, instances []string) {
+	for _, instance := range instances {
+		instanceCtx := authz.WithInstanceID(ctx, instance)
+
+		err := w.lockAndTrigger(instanceCtx)
+		logging.WithFields("instance", instance).OnError(err).Info("grant expiry worker trigger failed")
+	}
+}
+
+// lockAndTrigger only handles the instance if it's not locked by the worker of another replica.
+// The lock is held (and renewed while handling the instance) for the interval of the runs,
+// so the instance is handled once per interval.
+func (w *Worker) lockAndTrigger(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := w.locker.Lock(ctx, w.config.RequeueEvery, authz.GetInstance(ctx).InstanceID())
+	err, ok := <-errs
+	if err != nil || !ok {
+		if zerrors.IsErrorAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	go func() {
+		for err := range errs {
+			logging.OnError(err).Warn("unable to renew grant expiry worker lock")
+		}
+	}()
+	return w.trigger(ctx)
+}
+
+func (w *Worker) trigger(ctx context.Context) error {
+	now := w.now()
+	userGrants, err := w.queries.ExpiringUserGrants(ctx, w.config.WarnBefore, now)
+	if err != nil {
+		return err
+	}
+	for _, grant := range userGrants {
+		err = w.handleUserGrant(authz.SetCtxData(ctx, authz.CtxData{UserID: WorkerUserID, OrgID: grant.ResourceOwner}), grant)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "userGrant", grant.ID).OnError(err).Info("unable to handle expiring user grant")
+	}
+	projectGrants, err := w.queries.ExpiringProjectGrants(ctx, w.config.WarnBefore, now)
+	if err != nil {
+		return err
+	}
+	for _, grant := range projectGrants {
+		err = w.handleProjectGrant(authz.SetCtxData(ctx, authz.CtxData{UserID: WorkerUserID, OrgID: grant.ResourceOwner}), grant)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "projectGrant", grant.GrantID).OnError(err).Info("unable to handle expiring project grant")
+	}
+	return nil
+}
+
+func (w *Worker) handleUserGrant(ctx context.Context, grant *query.ExpiringUserGrant) (err error) {
+	switch grant.Action {
+	case query.ExpiringGrantActionExpire:
+		_, err = w.commands.ExpireUserGrant(ctx, grant.ID, grant.ResourceOwner)
+	case query.ExpiringGrantActionWarn:
+		// the owners are only warned once, a new validity resets the warning
+		if grant.ExpiryWarned {
+			return nil
+		}
+		_, err = w.commands.WarnUserGrantExpiry(ctx, grant.ID, grant.ResourceOwner)
+	case query.ExpiringGrantActionUnspecified:
+	}
+	return err
+}
+
+func (w *Worker) handleProjectGrant(ctx context.Context, grant *query.ExpiringProjectGrant) (err error) {
+	switch grant.Action {
+	case query.ExpiringGrantActionExpire:
+		_, err = w.commands.ExpireProjectGrant(ctx, grant.ProjectID, grant.GrantID, grant.ResourceOwner)
+	case query.ExpiringGrantActionWarn:
+		if grant.ExpiryWarned {
+			return nil
+		}
+		_, err = w.commands.WarnProjectGrantExpiry(ctx, grant.ProjectID, grant.GrantID, grant.ResourceOwner)
+	case query.ExpiringGrantActionUnspecified:
+	}
+	return err
+}
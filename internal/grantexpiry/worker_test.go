@@ -0,0 +1,164 @@
+package grantexpiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type queriesMock struct {
+	userGrants    []*query.ExpiringUserGrant
+	projectGrants []*query.ExpiringProjectGrant
+}
+
+func (q *queriesMock) ActiveInstances() []string {
+	return []string{"instance"}
+}
+
+func (q *queriesMock) ExpiringUserGrants(context.Context, time.Duration, time.Time) ([]*query.ExpiringUserGrant, error) {
+	return q.userGrants, nil
+}
+
+func (q *queriesMock) ExpiringProjectGrants(context.Context, time.Duration, time.Time) ([]*query.ExpiringProjectGrant, error) {
+	return q.projectGrants, nil
+}
+
+type commandsMock struct {
+	warned  []string
+	expired []string
+}
+
+func (c *commandsMock) WarnUserGrantExpiry(_ context.Context, grantID, _ string) (*domain.ObjectDetails, error) {
+	c.warned = append(c.warned, grantID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *commandsMock) ExpireUserGrant(_ context.Context, grantID, _ string) (*domain.ObjectDetails, error) {
+	c.expired = append(c.expired, grantID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *commandsMock) WarnProjectGrantExpiry(_ context.Context, _, grantID, _ string) (*domain.ObjectDetails, error) {
+	c.warned = append(c.warned, grantID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *commandsMock) ExpireProjectGrant(_ context.Context, _, grantID, _ string) (*domain.ObjectDetails, error) {
+	c.expired = append(c.expired, grantID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func TestWorker_trigger(t *testing.T) {
+	queries := &queriesMock{
+		userGrants: []*query.ExpiringUserGrant{
+			{
+				ID:            "usergrant-warn",
+				ResourceOwner: "org",
+				Action:        query.ExpiringGrantActionWarn,
+			},
+			{
+				ID:            "usergrant-warned",
+				ResourceOwner: "org",
+				ExpiryWarned:  true,
+				Action:        query.ExpiringGrantActionWarn,
+			},
+			{
+				ID:            "usergrant-expire",
+				ResourceOwner: "org",
+				ExpiryWarned:  true,
+				Action:        query.ExpiringGrantActionExpire,
+			},
+		},
+		projectGrants: []*query.ExpiringProjectGrant{
+			{
+				ProjectID:     "project",
+				GrantID:       "projectgrant-warn",
+				ResourceOwner: "org",
+				Action:        query.ExpiringGrantActionWarn,
+			},
+			{
+				ProjectID:     "project",
+				GrantID:       "projectgrant-expire",
+				ResourceOwner: "org",
+				Action:        query.ExpiringGrantActionExpire,
+			},
+		},
+	}
+	commands := new(commandsMock)
+	w := NewWorker(Config{Enabled: true, WarnBefore: 7 * 24 * time.Hour}, commands, queries, nil)
+	err := w.trigger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"usergrant-warn", "projectgrant-warn"}, commands.warned)
+	assert.Equal(t, []string{"usergrant-expire", "projectgrant-expire"}, commands.expired)
+}
+
+type lockerMock struct {
+	err error
+}
+
+func (l *lockerMock) Lock(ctx context.Context, _ time.Duration, _ ...string) <-chan error {
+	errs := make(chan error, 1)
+	errs <- l.err
+	go func() {
+		<-ctx.Done()
+		close(errs)
+	}()
+	return errs
+}
+
+func (l *lockerMock) Unlock(...string) error {
+	return nil
+}
+
+func TestWorker_lockAndTrigger(t *testing.T) {
+	queries := &queriesMock{
+		userGrants: []*query.ExpiringUserGrant{
+			{
+				ID:            "usergrant-warn",
+				ResourceOwner: "org",
+				Action:        query.ExpiringGrantActionWarn,
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		lockErr    error
+		wantErr    bool
+		wantWarned []string
+	}{
+		{
+			name:       "lock acquired, handled",
+			wantWarned: []string{"usergrant-warn"},
+		},
+		{
+			name:    "locked by other worker, skipped",
+			lockErr: zerrors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked"),
+		},
+		{
+			name:    "lock failed, error",
+			lockErr: zerrors.ThrowInternal(nil, "CRDB-uaDoR", "unable to execute lock"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(commandsMock)
+			w := NewWorker(Config{Enabled: true, RequeueEvery: 5 * time.Minute, WarnBefore: 7 * 24 * time.Hour}, commands, queries, &lockerMock{err: tt.lockErr})
+			err := w.lockAndTrigger(authz.WithInstanceID(context.Background(), "instance"))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWarned, commands.warned)
+		})
+	}
+}
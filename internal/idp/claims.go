@@ -0,0 +1,65 @@
+package idp
+
+import (
+	"encoding/json"
+)
+
+// ClaimValues returns the values of the requested claims of the federated [User].
+// The claims are looked up on the top level of the (JSON) representation of the user
+// and in its `attributes` (e.g. SAML) or `RawInfo` (e.g. OAuth).
+// Claims can either be a single string or a list of strings, all other types are ignored.
+func ClaimValues(user User, claims ...string) map[string][]string {
+	if user == nil || len(claims) == 0 {
+		return make(map[string][]string)
+	}
+	data, err := json.Marshal(user)
+	if err != nil {
+		return make(map[string][]string)
+	}
+	return ClaimValuesFromJSON(data, claims...)
+}
+
+// ClaimValuesFromJSON returns the values of the requested claims of the JSON representation of a federated [User],
+// e.g. the user information stored on a succeeded intent.
+func ClaimValuesFromJSON(data []byte, claims ...string) map[string][]string {
+	values := make(map[string][]string, len(claims))
+	if len(data) == 0 || len(claims) == 0 {
+		return values
+	}
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return values
+	}
+	nested := make([]map[string]json.RawMessage, 0, 2)
+	for _, key := range []string{"attributes", "RawInfo"} {
+		attributes := make(map[string]json.RawMessage)
+		if rawAttributes, ok := raw[key]; ok && json.Unmarshal(rawAttributes, &attributes) == nil {
+			nested = append(nested, attributes)
+		}
+	}
+	for _, claim := range claims {
+		value, ok := raw[claim]
+		for i := 0; !ok && i < len(nested); i++ {
+			value, ok = nested[i][claim]
+		}
+		if !ok {
+			continue
+		}
+		if parsed := claimValue(value); len(parsed) > 0 {
+			values[claim] = parsed
+		}
+	}
+	return values
+}
+
+func claimValue(raw json.RawMessage) []string {
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil && single != "" {
+		return []string{single}
+	}
+	return nil
+}
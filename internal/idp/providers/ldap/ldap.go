@@ -44,6 +44,7 @@ type Provider struct {
 	preferredLanguageAttribute string
 	avatarURLAttribute         string
 	profileAttribute           string
+	additionalAttributes       []string
 }
 
 type ProviderOpts func(provider *Provider)
@@ -175,6 +176,13 @@ func WithProfileAttribute(name string) ProviderOpts {
 	}
 }
 
+// WithAdditionalAttributes configures to additionally request the LDAP attributes, e.g. `memberOf` for group mappings
+func WithAdditionalAttributes(names ...string) ProviderOpts {
+	return func(p *Provider) {
+		p.additionalAttributes = append(p.additionalAttributes, names...)
+	}
+}
+
 func New(
 	name string,
 	servers []string,
@@ -283,5 +291,6 @@ func (p *Provider) getNecessaryAttributes() []string {
 	if p.profileAttribute != "" {
 		attributes = append(attributes, p.profileAttribute)
 	}
+	attributes = append(attributes, p.additionalAttributes...)
 	return attributes
 }
@@ -2,6 +2,8 @@ package oidc
 
 import (
 	"context"
+	"errors"
+	"net/url"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
@@ -12,6 +14,8 @@ import (
 
 var _ idp.Provider = (*Provider)(nil)
 
+var ErrNoEndSessionEndpoint = errors.New("no end_session_endpoint provided")
+
 // Provider is the [idp.Provider] implementation for a generic OIDC provider
 type Provider struct {
 	rp.RelyingParty
@@ -134,6 +138,31 @@ func (p *Provider) Name() string {
 	return p.name
 }
 
+// EndSessionURL returns the URL of the end_session_endpoint of the provider to terminate the session of the user
+// (RP-initiated logout), using the id_token received on authentication as hint.
+// The provider will return the state to the post_logout_redirect_uri.
+func (p *Provider) EndSessionURL(idTokenHint, postLogoutRedirectURI, state string) (string, error) {
+	endpoint := p.GetEndSessionEndpoint()
+	if endpoint == "" {
+		return "", ErrNoEndSessionEndpoint
+	}
+	logoutURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	query := logoutURL.Query()
+	query.Set("id_token_hint", idTokenHint)
+	query.Set("client_id", p.OAuthConfig().ClientID)
+	if postLogoutRedirectURI != "" {
+		query.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	logoutURL.RawQuery = query.Encode()
+	return logoutURL.String(), nil
+}
+
 // BeginAuth implements the [idp.Provider] interface.
 // It will create a [Session] with an OIDC authorization request as AuthURL.
 func (p *Provider) BeginAuth(ctx context.Context, state string, params ...idp.Parameter) (idp.Session, error) {
@@ -235,6 +235,37 @@ func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Paramet
 	}, nil
 }
 
+// LogoutRequestURL returns the URL to terminate the session of the user at the provider
+// by sending a LogoutRequest for the NameID and SessionIndex using the HTTP-Redirect binding.
+func (p *Provider) LogoutRequestURL(nameID, sessionIndex, relayState string) (string, error) {
+	m, err := p.GetSP()
+	if err != nil {
+		return "", err
+	}
+	sp := m.ServiceProvider
+	location := sp.GetSLOBindingLocation(saml.HTTPRedirectBinding)
+	if location == "" {
+		return "", zerrors.ThrowPreconditionFailed(nil, "SAML-Zo3ie", "Errors.Intent.IDPInvalid")
+	}
+	// the request needs to be signed after the SessionIndex has been added
+	signatureMethod := sp.SignatureMethod
+	sp.SignatureMethod = ""
+	request, err := sp.MakeLogoutRequest(location, nameID)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "SAML-aeTh6", "Errors.Intent.IDPInvalid")
+	}
+	if sessionIndex != "" {
+		request.SessionIndex = &saml.SessionIndex{Value: sessionIndex}
+	}
+	if signatureMethod != "" {
+		sp.SignatureMethod = signatureMethod
+		if err = sp.SignLogoutRequest(request); err != nil {
+			return "", zerrors.ThrowInternal(err, "SAML-Iequ8", "Errors.Intent.IDPInvalid")
+		}
+	}
+	return request.Redirect(relayState).String(), nil
+}
+
 func (p *Provider) TransientMappingAttributeName() string {
 	return p.transientMappingAttributeName
 }
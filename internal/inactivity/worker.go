@@ -0,0 +1,181 @@
+package inactivity
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WorkerUserID is set as editor of the events pushed by the worker.
+const WorkerUserID = "INACTIVITY"
+
+const (
+	locksTable = "projections.locks"
+	lockName   = "inactivity_worker"
+)
+
+type Config struct {
+	// Enabled starts the worker, which warns and deactivates inactive users
+	// according to the inactivity policies.
+	Enabled bool
+	// RequeueEvery is the time interval between the runs of the worker.
+	RequeueEvery time.Duration
+}
+
+type Queries interface {
+	ActiveInstances() []string
+	UserActivityResourceOwners(ctx context.Context) ([]string, error)
+	InactivityPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (*query.InactivityPolicy, error)
+	InactiveUsers(ctx context.Context, orgID string, policy *query.InactivityPolicy, now time.Time) ([]*query.InactiveUser, error)
+}
+
+type Commands interface {
+	WarnInactiveUser(ctx context.Context, userID, resourceOwner string, lastActivity, deactivationDate time.Time) (*domain.ObjectDetails, error)
+	DeactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+// nowFunc makes [time.Now] mockable
+type nowFunc func() time.Time
+
+type Worker struct {
+	config   Config
+	commands Commands
+	queries  Queries
+	locker   crdb.Locker
+	now      nowFunc
+}
+
+func NewWorker(config Config, commands Commands, queries Queries, locker crdb.Locker) *Worker {
+	return &Worker{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+		locker:   locker,
+		now:      time.Now,
+	}
+}
+
+// NewLocker returns the lock, which ensures that each instance is only handled by a single worker
+// in case of multiple replicas.
+func NewLocker(client *database.DB) crdb.Locker {
+	return crdb.NewLocker(client.DB, locksTable, lockName)
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	if !w.config.Enabled {
+		return
+	}
+	go w.schedule(ctx)
+}
+
+func (w *Worker) schedule(ctx context.Context) {
+	t := time.NewTimer(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			logging.Info("inactivity worker stopped")
+			return
+		case <-t.C:
+			w.triggerInstances(call.WithTimestamp(ctx), w.queries.ActiveInstances())
+			t.Reset(w.config.RequeueEvery)
+		}
+	}
+}
+
+func (w *Worker) triggerInstances(ctx context.Context, instances []string) {
+	for _, instance := range instances {
+		instanceCtx := authz.WithInstanceID(ctx, instance)
+
+		err := w.lockAndTrigger(instanceCtx)
+		logging.WithFields("instance", instance).OnError(err).Info("inactivity worker trigger failed")
+	}
+}
+
+// lockAndTrigger only handles the instance if it's not locked by the worker of another replica.
+// The lock is held (and renewed while handling the instance) for the interval of the runs,
+// so the instance is handled once per interval.
+func (w *Worker) lockAndTrigger(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := w.locker.Lock(ctx, w.config.RequeueEvery, authz.GetInstance(ctx).InstanceID())
+	err, ok := <-errs
+	if err != nil || !ok {
+		if zerrors.IsErrorAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	go func() {
+		for err := range errs {
+			logging.OnError(err).Warn("unable to renew inactivity worker lock")
+		}
+	}()
+	return w.trigger(ctx)
+}
+
+func (w *Worker) trigger(ctx context.Context) error {
+	orgIDs, err := w.queries.UserActivityResourceOwners(ctx)
+	if err != nil {
+		return err
+	}
+	now := w.now()
+	for _, orgID := range orgIDs {
+		err = w.handleOrg(authz.SetCtxData(ctx, authz.CtxData{UserID: WorkerUserID, OrgID: orgID}), orgID, now)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "org", orgID).OnError(err).Info("unable to handle inactive users")
+	}
+	return nil
+}
+
+func (w *Worker) handleOrg(ctx context.Context, orgID string, now time.Time) error {
+	policy, err := w.queries.InactivityPolicyByOrg(ctx, false, orgID)
+	if err != nil {
+		return err
+	}
+	if !policy.Enabled() {
+		return nil
+	}
+	users, err := w.queries.InactiveUsers(ctx, orgID, policy, now)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		err = w.handleUser(ctx, policy, user)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "org", orgID, "user", user.UserID).OnError(err).Info("unable to handle inactive user")
+	}
+	return nil
+}
+
+func (w *Worker) handleUser(ctx context.Context, policy *query.InactivityPolicy, user *query.InactiveUser) (err error) {
+	switch user.Action {
+	case query.InactiveUserActionDeactivate:
+		if policy.DryRun {
+			logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "user", user.UserID, "lastActivity", user.LastActivity).Info("dry run: user would be deactivated")
+			return nil
+		}
+		_, err = w.commands.DeactivateUser(ctx, user.UserID, user.ResourceOwner)
+	case query.InactiveUserActionWarn:
+		// the user is only warned once, a new authentication resets the warning
+		if !user.WarnedAt.IsZero() {
+			return nil
+		}
+		if policy.DryRun {
+			logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "user", user.UserID, "deactivationDate", user.DeactivationDate).Info("dry run: user would be warned")
+			return nil
+		}
+		_, err = w.commands.WarnInactiveUser(ctx, user.UserID, user.ResourceOwner, user.LastActivity, user.DeactivationDate)
+	case query.InactiveUserActionUnspecified:
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package inactivity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type queriesMock struct {
+	policy *query.InactivityPolicy
+	users  []*query.InactiveUser
+}
+
+func (q *queriesMock) ActiveInstances() []string {
+	return []string{"instance"}
+}
+
+func (q *queriesMock) UserActivityResourceOwners(context.Context) ([]string, error) {
+	return []string{"org"}, nil
+}
+
+func (q *queriesMock) InactivityPolicyByOrg(context.Context, bool, string) (*query.InactivityPolicy, error) {
+	return q.policy, nil
+}
+
+func (q *queriesMock) InactiveUsers(context.Context, string, *query.InactivityPolicy, time.Time) ([]*query.InactiveUser, error) {
+	return q.users, nil
+}
+
+type commandsMock struct {
+	warned      []string
+	deactivated []string
+}
+
+func (c *commandsMock) WarnInactiveUser(_ context.Context, userID, _ string, _, _ time.Time) (*domain.ObjectDetails, error) {
+	c.warned = append(c.warned, userID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *commandsMock) DeactivateUser(_ context.Context, userID, _ string) (*domain.ObjectDetails, error) {
+	c.deactivated = append(c.deactivated, userID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func TestWorker_handleOrg(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	users := []*query.InactiveUser{
+		{
+			UserID:        "warn",
+			ResourceOwner: "org",
+			Action:        query.InactiveUserActionWarn,
+		},
+		{
+			UserID:        "warned",
+			ResourceOwner: "org",
+			WarnedAt:      now.Add(-time.Hour),
+			Action:        query.InactiveUserActionWarn,
+		},
+		{
+			UserID:        "deactivate",
+			ResourceOwner: "org",
+			Action:        query.InactiveUserActionDeactivate,
+		},
+	}
+	tests := []struct {
+		name            string
+		policy          *query.InactivityPolicy
+		wantWarned      []string
+		wantDeactivated []string
+	}{
+		{
+			name:   "policy disabled",
+			policy: &query.InactivityPolicy{},
+		},
+		{
+			name: "dry run",
+			policy: &query.InactivityPolicy{
+				InactivityPeriod: 90 * 24 * time.Hour,
+				WarningPeriod:    7 * 24 * time.Hour,
+				DryRun:           true,
+			},
+		},
+		{
+			name: "warn and deactivate",
+			policy: &query.InactivityPolicy{
+				InactivityPeriod: 90 * 24 * time.Hour,
+				WarningPeriod:    7 * 24 * time.Hour,
+			},
+			wantWarned:      []string{"warn"},
+			wantDeactivated: []string{"deactivate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(commandsMock)
+			w := NewWorker(Config{Enabled: true}, commands, &queriesMock{policy: tt.policy, users: users}, nil)
+			err := w.handleOrg(context.Background(), "org", now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWarned, commands.warned)
+			assert.Equal(t, tt.wantDeactivated, commands.deactivated)
+		})
+	}
+}
+
+type lockerMock struct {
+	err error
+}
+
+func (l *lockerMock) Lock(ctx context.Context, _ time.Duration, _ ...string) <-chan error {
+	errs := make(chan error, 1)
+	errs <- l.err
+	go func() {
+		<-ctx.Done()
+		close(errs)
+	}()
+	return errs
+}
+
+func (l *lockerMock) Unlock(...string) error {
+	return nil
+}
+
+func TestWorker_lockAndTrigger(t *testing.T) {
+	policy := &query.InactivityPolicy{
+		InactivityPeriod: 90 * 24 * time.Hour,
+		WarningPeriod:    7 * 24 * time.Hour,
+	}
+	users := []*query.InactiveUser{
+		{
+			UserID:        "warn",
+			ResourceOwner: "org",
+			Action:        query.InactiveUserActionWarn,
+		},
+	}
+	tests := []struct {
+		name       string
+		lockErr    error
+		wantErr    bool
+		wantWarned []string
+	}{
+		{
+			name:       "lock acquired, handled",
+			wantWarned: []string{"warn"},
+		},
+		{
+			name:    "locked by other worker, skipped",
+			lockErr: zerrors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked"),
+		},
+		{
+			name:    "lock failed, error",
+			lockErr: zerrors.ThrowInternal(nil, "CRDB-uaDoR", "unable to execute lock"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(commandsMock)
+			w := NewWorker(Config{Enabled: true, RequeueEvery: time.Hour}, commands, &queriesMock{policy: policy, users: users}, &lockerMock{err: tt.lockErr})
+			err := w.lockAndTrigger(authz.WithInstanceID(context.Background(), "instance"))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWarned, commands.warned)
+		})
+	}
+}
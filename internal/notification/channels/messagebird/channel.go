@@ -0,0 +1,138 @@
+package messagebird
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// MessageBird SMS API: https://developers.messagebird.com/api/sms-messaging/
+// MessageBird Verify API: https://developers.messagebird.com/api/verify/
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+func InitChannel(config Config) channels.NotificationChannel {
+	logging.Debug("successfully initialized messagebird sms channel")
+
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		birdMsg, ok := message.(*messages.SMS)
+		if !ok {
+			return zerrors.ThrowInternal(nil, "MSGBD-s0pLc", "message is not SMS")
+		}
+		if config.Verify {
+			return config.startVerification(birdMsg)
+		}
+		return config.sendMessage(birdMsg)
+	})
+}
+
+type response struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (c *Config) sendMessage(msg *messages.SMS) error {
+	content, err := msg.GetContent()
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(http.MethodPost, "/messages", url.Values{
+		"originator": {msg.SenderPhoneNumber},
+		"recipients": {strings.TrimPrefix(msg.RecipientPhoneNumber, "+")},
+		"body":       {content},
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MSGBD-osk3S", "could not send message")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return zerrors.ThrowInternal(fmt.Errorf("messagebird returned %s", resp.Status), "MSGBD-Hs8d2", "could not send message")
+	}
+	var result response
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return zerrors.ThrowInternal(err, "MSGBD-Ka93d", "could not send message")
+	}
+	logging.WithFields("message_id", result.ID).Debug("sms sent")
+	msg.MessageID = result.ID
+	return nil
+}
+
+func (c *Config) startVerification(msg *messages.SMS) error {
+	resp, err := c.do(http.MethodPost, "/verify", url.Values{
+		"originator": {msg.SenderPhoneNumber},
+		"recipient":  {strings.TrimPrefix(msg.RecipientPhoneNumber, "+")},
+		"type":       {"sms"},
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MSGBD-0s9f2", "could not send verification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return zerrors.ThrowInternal(fmt.Errorf("messagebird returned %s", resp.Status), "MSGBD-Ls9d3", "could not send verification")
+	}
+	var result response
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil || result.ID == "" {
+		return zerrors.ThrowInternal(err, "MSGBD-Pq2m1", "could not send verification")
+	}
+	logging.WithFields("verify_id", result.ID, "status", result.Status).Debug("verification sent")
+	msg.VerificationID = &result.ID
+	msg.MessageID = result.ID
+	return nil
+}
+
+func (c *Config) VerifyCode(verificationID, code string) error {
+	resp, err := c.do(http.MethodGet, "/verify/"+url.PathEscape(verificationID)+"?"+url.Values{"token": {code}}.Encode(), nil)
+	if err != nil {
+		return zerrors.ThrowInvalidArgument(err, "MSGBD-JK3ta", "Errors.User.Code.NotFound")
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return zerrors.ThrowInvalidArgument(nil, "MSGBD-Ok39a", "Errors.User.Code.NotFound")
+	default:
+		// a wrong token is answered with 422 Unprocessable Entity
+		return zerrors.ThrowInvalidArgument(nil, "MSGBD-Skwe4", "Errors.User.Code.Invalid")
+	}
+	var result response
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "MSGBD-Ue8s1", "Errors.User.Code.NotFound")
+	}
+	switch result.Status {
+	case "verified":
+		return nil
+	case "expired":
+		return zerrors.ThrowInvalidArgument(nil, "MSGBD-SF3ba", "Errors.User.Code.Expired")
+	case "failed":
+		// too many attempts
+		return zerrors.ThrowInvalidArgument(nil, "MSGBD-Fm2s9", "Errors.User.Code.NotFound")
+	default:
+		return zerrors.ThrowInvalidArgument(nil, "MSGBD-Wq7d2", "Errors.User.Code.Invalid")
+	}
+}
+
+func (c *Config) do(method, path string, form url.Values) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, c.url(path), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "AccessKey "+c.AccessKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return client.Do(req)
+}
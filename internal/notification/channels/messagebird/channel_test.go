@@ -0,0 +1,51 @@
+package messagebird
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestConfig_VerifyCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"verified", http.StatusOK, `{"id":"verify-id","status":"verified"}`, ""},
+		{"expired", http.StatusOK, `{"id":"verify-id","status":"expired"}`, "Errors.User.Code.Expired"},
+		{"too many attempts", http.StatusOK, `{"id":"verify-id","status":"failed"}`, "Errors.User.Code.NotFound"},
+		{"not found", http.StatusNotFound, `{}`, "Errors.User.Code.NotFound"},
+		{"wrong token", http.StatusUnprocessableEntity, `{}`, "Errors.User.Code.Invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/verify/verify-id", r.URL.Path)
+				assert.Equal(t, "123456", r.URL.Query().Get("token"))
+				assert.Equal(t, "AccessKey key", r.Header.Get("Authorization"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			config := &Config{AccessKey: "key", baseURL: server.URL}
+			err := config.VerifyCode("verify-id", "123456")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.True(t, zerrors.IsErrorInvalidArgument(err))
+			zitadelErr := new(zerrors.ZitadelError)
+			require.True(t, errors.As(err, &zitadelErr))
+			assert.Equal(t, tt.wantErr, zitadelErr.GetMessage())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package messagebird
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+const defaultBaseURL = "https://rest.messagebird.com"
+
+type Config struct {
+	AccessKey string
+	// Originator is the phone number or alphanumeric name (max. 11 characters) the messages are sent from
+	Originator string
+	// Verify enables MessageBird Verify to generate and check the codes
+	Verify bool
+
+	// baseURL is only overwritten in tests
+	baseURL string
+}
+
+func (c *Config) IsValid() bool {
+	return c.AccessKey != "" && c.Originator != ""
+}
+
+func (c *Config) Type() string {
+	return "messagebird"
+}
+
+func (c *Config) Sender() string {
+	return c.Originator
+}
+
+// GeneratesCode returns true if the code is generated and checked by MessageBird Verify
+func (c *Config) GeneratesCode() bool {
+	return c.Verify
+}
+
+func (c *Config) Channel() channels.NotificationChannel {
+	return InitChannel(*c)
+}
+
+func (c *Config) url(path string) string {
+	if c.baseURL != "" {
+		return c.baseURL + path
+	}
+	return defaultBaseURL + path
+}
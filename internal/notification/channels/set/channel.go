@@ -25,41 +25,85 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 	return channels.HandleMessageFunc(func(message channels.Message) error {
 		requestCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
-		msg, ok := message.(*messages.Form)
-		if !ok {
+		switch msg := message.(type) {
+		case *messages.Form:
+			return sendForm(requestCtx, cfg, msg)
+		case *messages.SecurityEventToken:
+			return pushSecurityEventToken(requestCtx, cfg, msg)
+		default:
 			return zerrors.ThrowInternal(nil, "SET-K686U", "message is not SET")
 		}
-		payload, err := msg.GetContent()
-		if err != nil {
-			return err
-		}
-		req, err := http.NewRequestWithContext(requestCtx, http.MethodPost, cfg.CallURL, strings.NewReader(payload))
-		if err != nil {
-			return err
-		}
-		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return err
-		}
-		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "calling_url", cfg.CallURL).Debug("security event token called")
-		if resp.StatusCode == http.StatusOK ||
-			resp.StatusCode == http.StatusAccepted ||
-			resp.StatusCode == http.StatusNoContent {
-			return nil
-		}
-		body, err := mapResponse(resp)
-		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "callURL", cfg.CallURL).
-			OnError(err).Debug("error mapping response")
-		if resp.StatusCode == http.StatusBadRequest {
-			logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "callURL", cfg.CallURL, "status", resp.Status, "body", body).
-				Error("security event token didn't return a success status")
-			return nil
-		}
-		return zerrors.ThrowInternalf(err, "SET-DF3dq", "security event token to %s didn't return a success status: %s (%v)", cfg.CallURL, resp.Status, body)
 	}), nil
 }
 
+func sendForm(ctx context.Context, cfg Config, msg *messages.Form) error {
+	payload, err := msg.GetContent()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.CallURL, strings.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "calling_url", cfg.CallURL).Debug("security event token called")
+	if resp.StatusCode == http.StatusOK ||
+		resp.StatusCode == http.StatusAccepted ||
+		resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	body, err := mapResponse(resp)
+	logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "callURL", cfg.CallURL).
+		OnError(err).Debug("error mapping response")
+	if resp.StatusCode == http.StatusBadRequest {
+		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "callURL", cfg.CallURL, "status", resp.Status, "body", body).
+			Error("security event token didn't return a success status")
+		return nil
+	}
+	return zerrors.ThrowInternalf(err, "SET-DF3dq", "security event token to %s didn't return a success status: %s (%v)", cfg.CallURL, resp.Status, body)
+}
+
+// pushSecurityEventToken delivers the token using push-based delivery (RFC 8935).
+// Other than for forms, a rejected token is returned as error,
+// so the caller is able to track the failed delivery.
+func pushSecurityEventToken(ctx context.Context, cfg Config, msg *messages.SecurityEventToken) error {
+	token, err := msg.GetContent()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.CallURL, strings.NewReader(token))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/secevent+jwt")
+	req.Header.Set("Accept", "application/json")
+	if cfg.AuthorizationHeader != "" {
+		req.Header.Set("Authorization", cfg.AuthorizationHeader)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "calling_url", cfg.CallURL).Debug("security event token pushed")
+	if resp.StatusCode == http.StatusAccepted ||
+		resp.StatusCode == http.StatusOK ||
+		resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		return nil
+	}
+	body, err := mapResponse(resp)
+	logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "callURL", cfg.CallURL).
+		OnError(err).Debug("error mapping response")
+	if resp.StatusCode == http.StatusBadRequest {
+		return zerrors.ThrowInvalidArgumentf(nil, "SET-Sf6r1", "security event token rejected by %s: %v (%v)", cfg.CallURL, body["err"], body["description"])
+	}
+	return zerrors.ThrowInternalf(err, "SET-Sf6s2", "security event token to %s didn't return a success status: %s (%v)", cfg.CallURL, resp.Status, body)
+}
+
 func mapResponse(resp *http.Response) (map[string]any, error) {
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
@@ -0,0 +1,71 @@
+package set
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestChannel_pushSecurityEventToken(t *testing.T) {
+	tests := []struct {
+		name                string
+		authorizationHeader string
+		status              int
+		body                string
+		wantErr             func(error) bool
+	}{
+		{
+			name:   "accepted",
+			status: http.StatusAccepted,
+		},
+		{
+			name:                "accepted with authorization",
+			authorizationHeader: "Bearer token",
+			status:              http.StatusAccepted,
+		},
+		{
+			name:    "rejected",
+			status:  http.StatusBadRequest,
+			body:    `{"err":"invalid_audience","description":"unknown audience"}`,
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "unavailable",
+			status:  http.StatusServiceUnavailable,
+			wantErr: zerrors.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/secevent+jwt", r.Header.Get("Content-Type"))
+				assert.Equal(t, "application/json", r.Header.Get("Accept"))
+				assert.Equal(t, tt.authorizationHeader, r.Header.Get("Authorization"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "header.payload.signature", string(body))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			channel, err := InitChannel(context.Background(), Config{CallURL: server.URL, AuthorizationHeader: tt.authorizationHeader})
+			require.NoError(t, err)
+			err = channel.HandleMessage(&messages.SecurityEventToken{Token: "header.payload.signature"})
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 type Config struct {
 	CallURL string
+	// AuthorizationHeader is sent in the Authorization header if set
+	AuthorizationHeader string
 }
 
 func (w *Config) Validate() error {
@@ -1,17 +1,57 @@
 package sms
 
 import (
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/channels/messagebird"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 )
 
 type Config struct {
-	ProviderConfig *Provider
-	TwilioConfig   *twilio.Config
-	WebhookConfig  *webhook.Config
+	ProviderConfig    *Provider
+	TwilioConfig      *twilio.Config
+	VonageConfig      *vonage.Config
+	MessageBirdConfig *messagebird.Config
+	WebhookConfig     *webhook.Config
 }
 
 type Provider struct {
 	ID          string `json:"id,omitempty"`
 	Description string `json:"description,omitempty"`
 }
+
+// NativeProvider is implemented by the configurations of the providers,
+// which send the messages through their own API.
+type NativeProvider interface {
+	// Type identifies the provider, e.g. in the delivery log
+	Type() string
+	// Sender is the phone number or name the messages are sent from
+	Sender() string
+	// GeneratesCode returns true if the provider generates the codes itself (e.g. Twilio Verify).
+	// The message then only triggers the verification and the id of the verification is set on the message.
+	GeneratesCode() bool
+	// VerifyCode checks the code of a verification started by the provider
+	VerifyCode(verificationID, code string) error
+	Channel() channels.NotificationChannel
+}
+
+var (
+	_ NativeProvider = (*twilio.Config)(nil)
+	_ NativeProvider = (*vonage.Config)(nil)
+	_ NativeProvider = (*messagebird.Config)(nil)
+)
+
+// Native returns the configuration of the native provider or nil if the messages are sent to a webhook
+func (c *Config) Native() NativeProvider {
+	switch {
+	case c.TwilioConfig != nil:
+		return c.TwilioConfig
+	case c.VonageConfig != nil:
+		return c.VonageConfig
+	case c.MessageBirdConfig != nil:
+		return c.MessageBirdConfig
+	default:
+		return nil
+	}
+}
@@ -1,10 +1,14 @@
 package smtp
 
 import (
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"net"
 	"net/smtp"
+	"strings"
+	"time"
 
 	"github.com/zitadel/logging"
 
@@ -20,6 +24,7 @@ type Email struct {
 	senderAddress  string
 	senderName     string
 	replyToAddress string
+	dkim           *DKIM
 }
 
 func InitChannel(cfg *Config) (*Email, error) {
@@ -34,6 +39,7 @@ func InitChannel(cfg *Config) (*Email, error) {
 		senderName:     cfg.FromName,
 		senderAddress:  cfg.From,
 		replyToAddress: cfg.ReplyToAddress,
+		dkim:           cfg.DKIM,
 	}, nil
 }
 
@@ -50,6 +56,13 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	emailMsg.SenderEmail = email.senderAddress
 	emailMsg.SenderName = email.senderName
 	emailMsg.ReplyToAddress = email.replyToAddress
+	if emailMsg.MessageID == "" {
+		messageID, err := newMessageID(emailMsg.SenderEmail)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "EMAIL-Mi3dG", "Errors.SMTP.CouldNotCreateMessageID")
+		}
+		emailMsg.MessageID = messageID
+	}
 	// To && From
 	if err := email.smtpClient.Mail(emailMsg.SenderEmail); err != nil {
 		return zerrors.ThrowInternal(err, "EMAIL-s3is3", "Errors.SMTP.CouldNotSetSender")
@@ -70,6 +83,12 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	if err != nil {
 		return err
 	}
+	if email.dkim != nil {
+		content, err = email.dkim.Sign(content, time.Now())
+		if err != nil {
+			return err
+		}
+	}
 
 	_, err = w.Write([]byte(content))
 	if err != nil {
@@ -84,6 +103,20 @@ func (email *Email) HandleMessage(message channels.Message) error {
 	return email.smtpClient.Quit()
 }
 
+// newMessageID creates a unique Message-ID header (RFC 5322) with the domain of the sender,
+// which is used by providers to report bounces.
+func newMessageID(senderAddress string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	domain := "localhost"
+	if at := strings.LastIndex(senderAddress, "@"); at >= 0 && at < len(senderAddress)-1 {
+		domain = senderAddress[at+1:]
+	}
+	return "<" + hex.EncodeToString(id) + "@" + domain + ">", nil
+}
+
 func (smtpConfig SMTP) connectToSMTP(tlsRequired bool) (client *smtp.Client, err error) {
 	host, _, err := net.SplitHostPort(smtpConfig.Host)
 	if err != nil {
@@ -151,8 +184,12 @@ func (smtpConfig SMTP) smtpAuth(client *smtp.Client, host string) error {
 	if !smtpConfig.HasAuth() {
 		return nil
 	}
+	auth := PlainOrLoginAuth(smtpConfig.User, smtpConfig.Password, host)
+	if smtpConfig.XOAuth2 != nil {
+		auth = XOAuth2Auth(smtpConfig.User, smtpConfig.XOAuth2.tokenSource())
+	}
 	// Auth
-	err := client.Auth(PlainOrLoginAuth(smtpConfig.User, smtpConfig.Password, host))
+	err := client.Auth(auth)
 	if err != nil {
 		return zerrors.ThrowInternal(err, "EMAIL-s9kfs", "Errors.SMTP.CouldNotAuth")
 	}
@@ -194,6 +231,12 @@ func TestConfiguration(cfg *Config, testEmail string) error {
 	if err != nil {
 		return err
 	}
+	if cfg.DKIM != nil {
+		content, err = cfg.DKIM.Sign(content, time.Now())
+		if err != nil {
+			return err
+		}
+	}
 	_, err = w.Write([]byte(content))
 	if err != nil {
 		return err
@@ -6,16 +6,40 @@ type Config struct {
 	From           string
 	FromName       string
 	ReplyToAddress string
+	// DKIM is optional, if set all messages are signed
+	DKIM *DKIM
 }
 
 type SMTP struct {
 	Host     string
 	User     string
 	Password string
+	// XOAuth2 is optional, if set it's used instead of the password to authenticate the User
+	XOAuth2 *XOAuth2
 }
 
 func (smtp *SMTP) HasAuth() bool {
-	return smtp.User != "" && smtp.Password != ""
+	return smtp.User != "" && (smtp.Password != "" || smtp.XOAuth2 != nil)
+}
+
+// XOAuth2 authenticates with an access token requested with the OAuth2 client credentials grant
+type XOAuth2 struct {
+	// ConfigID and Version identify the SMTP configuration the token source is cached for,
+	// the token source isn't cached if ConfigID is empty
+	ConfigID      string
+	Version       uint64
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+}
+
+// DKIM signs messages according to RFC 6376
+type DKIM struct {
+	Domain   string
+	Selector string
+	// PrivateKey is the PEM encoded RSA or Ed25519 key
+	PrivateKey []byte
 }
 
 type ConfigHTTP struct {
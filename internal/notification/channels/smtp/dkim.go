@@ -0,0 +1,167 @@
+package smtp
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	dkimCRLF = "\r\n"
+	// dkimMinRSABits is the minimum key size recommended by RFC 8301
+	dkimMinRSABits = 2048
+)
+
+// dkimSignedHeaders are signed if present in the message
+var dkimSignedHeaders = []string{"from", "reply-to", "subject", "date", "to", "cc", "message-id", "mime-version", "content-type"}
+
+// ParseDKIMPrivateKey parses a PEM encoded RSA (PKCS #1 or PKCS #8) or Ed25519 (PKCS #8) private key.
+func ParseDKIMPrivateKey(pemKey []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Dk1pE", "Errors.SMTPConfig.DKIM.InvalidKey")
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "SMTP-Dk2pK", "Errors.SMTPConfig.DKIM.InvalidKey")
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < dkimMinRSABits {
+			return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Dk3rS", "Errors.SMTPConfig.DKIM.KeyTooShort")
+		}
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "SMTP-Dk4kT", "Errors.SMTPConfig.DKIM.InvalidKey")
+	}
+}
+
+// Sign adds the DKIM-Signature header to the message
+// using the relaxed canonicalization for header and body.
+func (d *DKIM) Sign(message string, now time.Time) (string, error) {
+	signer, err := ParseDKIMPrivateKey(d.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	algorithm, hash := "rsa-sha256", crypto.SHA256
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		// RFC 8463: the SHA-256 hash is signed with PureEdDSA
+		algorithm, hash = "ed25519-sha256", crypto.Hash(0)
+	}
+
+	header, body, _ := strings.Cut(message, dkimCRLF+dkimCRLF)
+	header += dkimCRLF
+	bodyHash := sha256.Sum256([]byte(dkimRelaxedBody(body)))
+
+	fields := dkimHeaderFields(header)
+	signedNames := make([]string, 0, len(dkimSignedHeaders))
+	var signedHeaders strings.Builder
+	for _, name := range dkimSignedHeaders {
+		field, ok := fields[name]
+		if !ok {
+			continue
+		}
+		signedNames = append(signedNames, name)
+		signedHeaders.WriteString(dkimRelaxedHeader(field))
+	}
+
+	value := "v=1; a=" + algorithm + "; c=relaxed/relaxed; d=" + d.Domain + "; s=" + d.Selector +
+		"; t=" + strconv.FormatInt(now.Unix(), 10) +
+		"; h=" + strings.Join(signedNames, ":") +
+		"; bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]) +
+		"; b="
+	signedHeaders.WriteString(strings.TrimSuffix(dkimRelaxedHeader("DKIM-Signature: "+value), dkimCRLF))
+
+	digest := sha256.Sum256([]byte(signedHeaders.String()))
+	signature, err := signer.Sign(rand.Reader, digest[:], hash)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "SMTP-Dk5sI", "Errors.SMTP.CouldNotSignDKIM")
+	}
+	return "DKIM-Signature: " + value + base64.StdEncoding.EncodeToString(signature) + dkimCRLF + message, nil
+}
+
+// dkimHeaderFields returns the (unfolded) fields of the header by their lowercase name,
+// if a field occurs multiple times, the last one is returned as it's the one signed first
+func dkimHeaderFields(header string) map[string]string {
+	fields := make(map[string]string)
+	var current string
+	flush := func() {
+		if current == "" {
+			return
+		}
+		name, _, _ := strings.Cut(current, ":")
+		fields[strings.ToLower(strings.TrimSpace(name))] = current
+	}
+	for _, line := range strings.SplitAfter(header, dkimCRLF) {
+		if line == "" {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			current += line
+			continue
+		}
+		flush()
+		current = line
+	}
+	flush()
+	return fields
+}
+
+// dkimRelaxedHeader canonicalizes a header field according to RFC 6376 section 3.4.2
+func dkimRelaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.NewReplacer("\r\n", "", "\n", "").Replace(value)
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(dkimCompressWSP(value)) + dkimCRLF
+}
+
+// dkimRelaxedBody canonicalizes the body according to RFC 6376 section 3.4.4
+func dkimRelaxedBody(body string) string {
+	lines := strings.Split(body, dkimCRLF)
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(dkimCompressWSP(line), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, dkimCRLF) + dkimCRLF
+}
+
+func dkimCompressWSP(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	inWSP := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' {
+			if !inWSP {
+				b.WriteByte(' ')
+			}
+			inWSP = true
+			continue
+		}
+		inWSP = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package smtp
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dkimRelaxed(t *testing.T) {
+	// example of RFC 6376 section 3.4.5
+	assert.Equal(t, "a:X\r\n", dkimRelaxedHeader("A: X\r\n"))
+	assert.Equal(t, "b:Y Z\r\n", dkimRelaxedHeader("B : Y\t\r\n\tZ  \r\n"))
+	assert.Equal(t, " C\r\nD E\r\n", dkimRelaxedBody(" C \r\nD \t E\r\n\r\n\r\n"))
+	assert.Equal(t, "", dkimRelaxedBody("\r\n\r\n"))
+}
+
+func TestDKIM_Sign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	shortRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	message := "From: sender@zitadel.com\r\n" +
+		"To: user@zitadel.com\r\n" +
+		"Subject: Test\r\n" +
+		"\r\n" +
+		"Hello  World \r\n\r\n"
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		verify  func(t *testing.T, digest, signature []byte)
+		wantAlg string
+		wantErr bool
+	}{
+		{
+			name: "rsa pkcs1",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			verify: func(t *testing.T, digest, signature []byte) {
+				assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest, signature))
+			},
+			wantAlg: "rsa-sha256",
+		},
+		{
+			name: "ed25519 pkcs8",
+			key:  pkcs8PEM(t, edKey),
+			verify: func(t *testing.T, digest, signature []byte) {
+				assert.True(t, ed25519.Verify(edKey.Public().(ed25519.PublicKey), digest, signature))
+			},
+			wantAlg: "ed25519-sha256",
+		},
+		{
+			name:    "rsa key too short",
+			key:     pkcs8PEM(t, shortRSAKey),
+			wantErr: true,
+		},
+		{
+			name:    "no pem",
+			key:     []byte("key"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dkim := &DKIM{Domain: "zitadel.com", Selector: "mail", PrivateKey: tt.key}
+			signed, err := dkim.Sign(message, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, strings.HasSuffix(signed, "\r\n"+message))
+
+			field, _, _ := strings.Cut(signed, "\r\n")
+			value, signature, ok := strings.Cut(strings.TrimPrefix(field, "DKIM-Signature: "), "; b=")
+			require.True(t, ok)
+			bodyHash := sha256.Sum256([]byte("Hello World\r\n"))
+			assert.Equal(t, "v=1; a="+tt.wantAlg+"; c=relaxed/relaxed; d=zitadel.com; s=mail; t=1700000000; h=from:subject:to; bh="+base64.StdEncoding.EncodeToString(bodyHash[:]), value)
+
+			digest := sha256.Sum256([]byte("from:sender@zitadel.com\r\nsubject:Test\r\nto:user@zitadel.com\r\ndkim-signature:" + value + "; b="))
+			decoded, err := base64.StdEncoding.DecodeString(signature)
+			require.NoError(t, err)
+			tt.verify(t, digest[:], decoded)
+		})
+	}
+}
+
+func pkcs8PEM(t *testing.T, key any) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
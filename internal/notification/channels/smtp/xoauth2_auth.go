@@ -0,0 +1,96 @@
+package smtp
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SMTP AUTH XOAUTH2 Handler as used by Google and Microsoft
+// Reference: https://developers.google.com/gmail/imap/xoauth2-protocol
+
+func XOAuth2Auth(username string, tokens oauth2.TokenSource) smtp.Auth {
+	return &xoauth2Auth{username: username, tokens: tokens}
+}
+
+type xoauth2Auth struct {
+	username string
+	tokens   oauth2.TokenSource
+}
+
+func (a *xoauth2Auth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// same as smtp.PlainAuth, the token must not be sent over an unencrypted connection
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, zerrors.ThrowInternal(nil, "SMTP-Xo2tL", "unencrypted connection")
+	}
+	token, err := a.tokens.Token()
+	if err != nil {
+		return "", nil, zerrors.ThrowInternal(err, "SMTP-Xo2tK", "Errors.SMTP.CouldNotGetToken")
+	}
+	return "XOAUTH2", []byte("user=" + a.username + "\x01auth=Bearer " + token.AccessToken + "\x01\x01"), nil
+}
+
+func (a *xoauth2Auth) Next(_ []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	// the server sent an error as challenge, which is answered with an empty response to receive the final error
+	return []byte{}, nil
+}
+
+func isLocalhost(name string) bool {
+	if name == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(name)
+	return ip != nil && ip.IsLoopback()
+}
+
+// xoauth2TokenSources caches the token source per SMTP configuration,
+// so the tokens are reused over multiple messages and refreshed when they expire.
+// The token source of a configuration is replaced as soon as the configuration changes.
+var xoauth2TokenSources = struct {
+	sync.Mutex
+	sources map[string]*xoauth2TokenSource
+}{
+	sources: make(map[string]*xoauth2TokenSource),
+}
+
+type xoauth2TokenSource struct {
+	version uint64
+	tokens  oauth2.TokenSource
+}
+
+func (x *XOAuth2) tokenSource() oauth2.TokenSource {
+	// configurations without id (e.g. tested before they are stored) are not cached
+	if x.ConfigID == "" {
+		return x.newTokenSource()
+	}
+	xoauth2TokenSources.Lock()
+	defer xoauth2TokenSources.Unlock()
+	if source, ok := xoauth2TokenSources.sources[x.ConfigID]; ok && source.version == x.Version {
+		return source.tokens
+	}
+	source := &xoauth2TokenSource{
+		version: x.Version,
+		tokens:  x.newTokenSource(),
+	}
+	xoauth2TokenSources.sources[x.ConfigID] = source
+	return source.tokens
+}
+
+func (x *XOAuth2) newTokenSource() oauth2.TokenSource {
+	config := &clientcredentials.Config{
+		ClientID:     x.ClientID,
+		ClientSecret: x.ClientSecret,
+		TokenURL:     x.TokenEndpoint,
+		Scopes:       x.Scopes,
+	}
+	return config.TokenSource(context.Background())
+}
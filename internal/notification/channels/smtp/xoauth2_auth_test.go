@@ -0,0 +1,31 @@
+package smtp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXOAuth2_tokenSource(t *testing.T) {
+	config := func(configID string, version uint64) *XOAuth2 {
+		return &XOAuth2{
+			ConfigID:      configID,
+			Version:       version,
+			TokenEndpoint: "https://localhost/token",
+			ClientID:      "client",
+			ClientSecret:  "secret",
+		}
+	}
+	tokens := config("instance:config1", 1).tokenSource()
+
+	// the token source is reused for the same configuration
+	assert.Same(t, tokens, config("instance:config1", 1).tokenSource())
+	// a changed configuration replaces the token source
+	changed := config("instance:config1", 2).tokenSource()
+	assert.NotSame(t, tokens, changed)
+	assert.Same(t, changed, config("instance:config1", 2).tokenSource())
+	// other configurations get their own token source
+	assert.NotSame(t, changed, config("instance:config2", 2).tokenSource())
+	// configurations without id are not cached
+	assert.NotSame(t, config("", 0).tokenSource(), config("", 0).tokenSource())
+}
@@ -46,6 +46,9 @@ func InitChannel(config Config) channels.NotificationChannel {
 			logging.WithFields("sid", resp.Sid, "status", resp.Status).Debug("verification sent")
 
 			twilioMsg.VerificationID = resp.Sid
+			if resp.Sid != nil {
+				twilioMsg.MessageID = *resp.Sid
+			}
 			return nil
 		}
 
@@ -62,6 +65,9 @@ func InitChannel(config Config) channels.NotificationChannel {
 			return zerrors.ThrowInternal(err, "TWILI-osk3S", "could not send message")
 		}
 		logging.WithFields("message_sid", m.Sid, "status", m.Status).Debug("sms sent")
+		if m.Sid != nil {
+			twilioMsg.MessageID = *m.Sid
+		}
 		return nil
 	})
 }
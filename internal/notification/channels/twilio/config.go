@@ -4,6 +4,7 @@ import (
 	newTwilio "github.com/twilio/twilio-go"
 	verify "github.com/twilio/twilio-go/rest/verify/v2"
 
+	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -38,3 +39,20 @@ func (t *Config) VerifyCode(verificationID, code string) error {
 		return zerrors.ThrowInvalidArgument(nil, "TWILI-Skwe4", "Errors.User.Code.Invalid")
 	}
 }
+
+func (t *Config) Type() string {
+	return "twilio"
+}
+
+func (t *Config) Sender() string {
+	return t.SenderNumber
+}
+
+// GeneratesCode returns true if the code is generated and checked by Twilio Verify
+func (t *Config) GeneratesCode() bool {
+	return t.VerifyServiceSID != ""
+}
+
+func (t *Config) Channel() channels.NotificationChannel {
+	return InitChannel(*t)
+}
@@ -0,0 +1,158 @@
+package vonage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Vonage SMS API: https://developer.vonage.com/en/api/sms
+// Vonage Verify v2 API: https://developer.vonage.com/en/api/verify.v2
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+func InitChannel(config Config) channels.NotificationChannel {
+	logging.Debug("successfully initialized vonage sms channel")
+
+	return channels.HandleMessageFunc(func(message channels.Message) error {
+		vonageMsg, ok := message.(*messages.SMS)
+		if !ok {
+			return zerrors.ThrowInternal(nil, "VONAG-s0pLc", "message is not SMS")
+		}
+		if config.VerifyBrand != "" {
+			return config.startVerification(vonageMsg)
+		}
+		return config.sendMessage(vonageMsg)
+	})
+}
+
+type smsResponse struct {
+	Messages []struct {
+		MessageID string `json:"message-id"`
+		Status    string `json:"status"`
+		ErrorText string `json:"error-text"`
+	} `json:"messages"`
+}
+
+func (c *Config) sendMessage(msg *messages.SMS) error {
+	content, err := msg.GetContent()
+	if err != nil {
+		return err
+	}
+	form := url.Values{
+		"api_key":    {c.APIKey},
+		"api_secret": {c.APISecret},
+		"from":       {strings.TrimPrefix(msg.SenderPhoneNumber, "+")},
+		"to":         {strings.TrimPrefix(msg.RecipientPhoneNumber, "+")},
+		"text":       {content},
+		"type":       {"unicode"},
+	}
+	resp, err := client.PostForm(c.smsURL(), form)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "VONAG-osk3S", "could not send message")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return zerrors.ThrowInternal(fmt.Errorf("vonage returned %s", resp.Status), "VONAG-Hs8d2", "could not send message")
+	}
+	var result smsResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil || len(result.Messages) == 0 {
+		return zerrors.ThrowInternal(err, "VONAG-Ka93d", "could not send message")
+	}
+	sent := result.Messages[0]
+	if sent.Status != "0" {
+		return zerrors.ThrowInternal(fmt.Errorf("status %s: %s", sent.Status, sent.ErrorText), "VONAG-p2Lsm", "could not send message")
+	}
+	logging.WithFields("message_id", sent.MessageID).Debug("sms sent")
+	msg.MessageID = sent.MessageID
+	return nil
+}
+
+type verifyRequest struct {
+	Brand    string           `json:"brand"`
+	Workflow []verifyWorkflow `json:"workflow"`
+}
+
+type verifyWorkflow struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+}
+
+type verifyResponse struct {
+	RequestID string `json:"request_id"`
+}
+
+func (c *Config) startVerification(msg *messages.SMS) error {
+	resp, err := c.verifyRequest(c.verifyURL(), &verifyRequest{
+		Brand: c.VerifyBrand,
+		Workflow: []verifyWorkflow{{
+			Channel: "sms",
+			To:      strings.TrimPrefix(msg.RecipientPhoneNumber, "+"),
+		}},
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "VONAG-0s9f2", "could not send verification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusConflict {
+		// A verification to the number is already in progress,
+		// retries will fail until it's completed or expired.
+		// Instead, let the user initiate the verification again (e.g. using "resend code")
+		logging.WithFields("status", resp.Status).Warn("vonage create verification conflict")
+		return channels.NewCancelError(fmt.Errorf("vonage returned %s", resp.Status))
+	}
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return zerrors.ThrowInternal(fmt.Errorf("vonage returned %s", resp.Status), "VONAG-Ls9d3", "could not send verification")
+	}
+	var result verifyResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil || result.RequestID == "" {
+		return zerrors.ThrowInternal(err, "VONAG-Pq2m1", "could not send verification")
+	}
+	logging.WithFields("request_id", result.RequestID).Debug("verification sent")
+	msg.VerificationID = &result.RequestID
+	msg.MessageID = result.RequestID
+	return nil
+}
+
+func (c *Config) VerifyCode(verificationID, code string) error {
+	resp, err := c.verifyRequest(c.verifyURL()+"/"+url.PathEscape(verificationID), map[string]string{"code": code})
+	if err != nil {
+		return zerrors.ThrowInvalidArgument(err, "VONAG-JK3ta", "Errors.User.Code.NotFound")
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		// the verification does not exist anymore, e.g. because of too many wrong attempts
+		return zerrors.ThrowInvalidArgument(nil, "VONAG-Ok39a", "Errors.User.Code.NotFound")
+	case http.StatusGone:
+		return zerrors.ThrowInvalidArgument(nil, "VONAG-SF3ba", "Errors.User.Code.Expired")
+	default:
+		return zerrors.ThrowInvalidArgument(nil, "VONAG-Skwe4", "Errors.User.Code.Invalid")
+	}
+}
+
+func (c *Config) verifyRequest(endpoint string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.APIKey, c.APISecret)
+	req.Header.Set("Content-Type", "application/json")
+	return client.Do(req)
+}
@@ -0,0 +1,54 @@
+package vonage
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestConfig_VerifyCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr string
+	}{
+		{"verified", http.StatusOK, ""},
+		{"not found", http.StatusNotFound, "Errors.User.Code.NotFound"},
+		{"expired", http.StatusGone, "Errors.User.Code.Expired"},
+		{"invalid", http.StatusBadRequest, "Errors.User.Code.Invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v2/verify/request-id", r.URL.Path)
+				user, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "key", user)
+				assert.Equal(t, "secret", password)
+				var body map[string]string
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "123456", body["code"])
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			config := &Config{APIKey: "key", APISecret: "secret", apiURL: server.URL}
+			err := config.VerifyCode("request-id", "123456")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.True(t, zerrors.IsErrorInvalidArgument(err))
+			zitadelErr := new(zerrors.ZitadelError)
+			require.True(t, errors.As(err, &zitadelErr))
+			assert.Equal(t, tt.wantErr, zitadelErr.GetMessage())
+		})
+	}
+}
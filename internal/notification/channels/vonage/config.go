@@ -0,0 +1,57 @@
+package vonage
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+const (
+	defaultRestURL = "https://rest.nexmo.com"
+	defaultAPIURL  = "https://api.nexmo.com"
+)
+
+type Config struct {
+	APIKey       string
+	APISecret    string
+	SenderNumber string
+	// VerifyBrand enables Vonage Verify if set, it's included in the message sent to the user
+	VerifyBrand string
+
+	// restURL and apiURL are only overwritten in tests
+	restURL string
+	apiURL  string
+}
+
+func (c *Config) IsValid() bool {
+	return c.APIKey != "" && c.APISecret != "" && c.SenderNumber != ""
+}
+
+func (c *Config) Type() string {
+	return "vonage"
+}
+
+func (c *Config) Sender() string {
+	return c.SenderNumber
+}
+
+// GeneratesCode returns true if the code is generated and checked by Vonage Verify
+func (c *Config) GeneratesCode() bool {
+	return c.VerifyBrand != ""
+}
+
+func (c *Config) Channel() channels.NotificationChannel {
+	return InitChannel(*c)
+}
+
+func (c *Config) smsURL() string {
+	if c.restURL != "" {
+		return c.restURL + "/sms/json"
+	}
+	return defaultRestURL + "/sms/json"
+}
+
+func (c *Config) verifyURL() string {
+	if c.apiURL != "" {
+		return c.apiURL + "/v2/verify"
+	}
+	return defaultAPIURL + "/v2/verify"
+}
@@ -27,7 +27,7 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 		if !ok {
 			return zerrors.ThrowInternal(nil, "WEBH-K686U", "message is not JSON")
 		}
-		payload, err := msg.GetContent()
+		payload, contentType, err := requestBody(cfg, msg)
 		if err != nil {
 			return err
 		}
@@ -36,9 +36,9 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 			return err
 		}
 		if cfg.Headers != nil {
-			req.Header = cfg.Headers
+			req.Header = cfg.Headers.Clone()
 		}
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return err
@@ -53,3 +53,16 @@ func InitChannel(ctx context.Context, cfg Config) (channels.NotificationChannel,
 		return nil
 	}), nil
 }
+
+func requestBody(cfg Config, msg *messages.JSON) (payload, contentType string, err error) {
+	if cfg.Template == nil {
+		payload, err = msg.GetContent()
+		return payload, "application/json", err
+	}
+	contentType = cfg.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	payload, err = render(cfg.Template, msg.Serializable)
+	return payload, contentType, err
+}
@@ -3,12 +3,18 @@ package webhook
 import (
 	"net/http"
 	"net/url"
+	"text/template"
 )
 
 type Config struct {
 	CallURL string
 	Method  string
 	Headers http.Header
+	// Template renders the request body from the data of the message,
+	// if not set, the message is sent as JSON
+	Template *template.Template
+	// ContentType of the rendered Template, defaults to application/json
+	ContentType string
 }
 
 func (w *Config) Validate() error {
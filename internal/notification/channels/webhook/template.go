@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var templateFuncs = template.FuncMap{
+	// json encodes the value as JSON, e.g. to insert a quoted and escaped string into a JSON body
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ParseTemplate parses the body template of a request,
+// the data of the message is accessible as in the JSON body (e.g. {{.ContextInfo.recipientPhoneNumber}})
+func ParseTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("body").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "WEBH-Tm8pL", "Errors.Webhook.InvalidTemplate")
+	}
+	return tmpl, nil
+}
+
+func render(tmpl *template.Template, data any) (string, error) {
+	var body strings.Builder
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", zerrors.ThrowInternal(err, "WEBH-Tm9eX", "Errors.Webhook.InvalidTemplate")
+	}
+	return body.String(), nil
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	_ "github.com/zitadel/zitadel/internal/notification/statik"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	AccessRequestNotificationsProjectionTable = "projections.notifications_access_request"
+)
+
+type accessRequestNotifier struct {
+	queries  *NotificationQueries
+	channels types.ChannelChains
+}
+
+func NewAccessRequestNotifier(
+	ctx context.Context,
+	config handler.Config,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &accessRequestNotifier{
+		queries:  queries,
+		channels: channels,
+	})
+}
+
+func (*accessRequestNotifier) Name() string {
+	return AccessRequestNotificationsProjectionTable
+}
+
+func (n *accessRequestNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: accessrequest.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  accessrequest.AddedType,
+					Reduce: n.reduceAccessRequestAdded,
+				},
+			},
+		},
+	}
+}
+
+func (n *accessRequestNotifier) reduceAccessRequestAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*accessrequest.AddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ar1nA", "reduce.wrong.event.type %s", accessrequest.AddedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		members, err := n.approvers(ctx, e)
+		if err != nil {
+			return err
+		}
+		if len(members) == 0 {
+			return nil
+		}
+		project, err := n.queries.ProjectByID(ctx, false, e.ProjectID)
+		if err != nil {
+			return err
+		}
+		requester, err := n.queries.GetNotifyUserByID(ctx, true, e.UserID)
+		if err != nil {
+			return err
+		}
+		orgID := e.Aggregate().ResourceOwner
+		colors, err := n.queries.ActiveLabelPolicyByOrg(ctx, orgID, false)
+		if err != nil {
+			return err
+		}
+		template, err := n.queries.MailTemplateByOrg(ctx, orgID, false)
+		if err != nil {
+			return err
+		}
+		translator, err := n.queries.GetTranslatorWithOrgTexts(ctx, orgID, domain.AccessRequestMessageType)
+		if err != nil {
+			return err
+		}
+		customTemplates, err := n.queries.NotificationTemplatesByOrg(ctx, orgID, domain.AccessRequestMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = n.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			approver, err := n.queries.GetNotifyUserByID(ctx, true, member.UserID)
+			if err != nil {
+				return err
+			}
+			// the request is only sent to verified addresses
+			if approver.VerifiedEmail == "" {
+				continue
+			}
+			err = types.SendEmail(ctx, n.channels, string(template.Template), customTemplates, translator, approver, colors, e).
+				SendAccessRequest(ctx, approver, requester.DisplayName, project.Name, e.RoleKeys, e.Reason)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+// approvers returns the human members of the project or project grant which can decide on the request.
+func (n *accessRequestNotifier) approvers(ctx context.Context, e *accessrequest.AddedEvent) ([]*query.Member, error) {
+	var (
+		members *query.Members
+		err     error
+	)
+	if e.ProjectGrantID != "" {
+		members, err = n.queries.ProjectGrantMembers(ctx, &query.ProjectGrantMembersQuery{
+			ProjectID: e.ProjectID,
+			GrantID:   e.ProjectGrantID,
+			OrgID:     e.Aggregate().ResourceOwner,
+		})
+	} else {
+		members, err = n.queries.ProjectMembers(ctx, &query.ProjectMembersQuery{ProjectID: e.ProjectID})
+	}
+	if err != nil {
+		return nil, err
+	}
+	approverRoles := domain.AccessRequestApproverRoles(e.ProjectGrantID)
+	approvers := make([]*query.Member, 0, len(members.Members))
+	for _, member := range members.Members {
+		if member.UserType != domain.UserTypeHuman {
+			continue
+		}
+		if slices.ContainsFunc(member.Roles, func(role string) bool { return slices.Contains(approverRoles, role) }) {
+			approvers = append(approvers, member)
+		}
+	}
+	return approvers, nil
+}
@@ -14,7 +14,7 @@ type Commands interface {
 	RequestNotification(ctx context.Context, instanceID string, request *command.NotificationRequest) error
 	NotificationCanceled(ctx context.Context, tx *sql.Tx, id, resourceOwner string, err error) error
 	NotificationRetryRequested(ctx context.Context, tx *sql.Tx, id, resourceOwner string, request *command.NotificationRetryRequest, err error) error
-	NotificationSent(ctx context.Context, tx *sql.Tx, id, instanceID string) error
+	NotificationSent(ctx context.Context, tx *sql.Tx, id, instanceID string, deliveryInfo *senders.DeliveryInfo) error
 	HumanInitCodeSent(ctx context.Context, orgID, userID string) error
 	HumanEmailVerificationCodeSent(ctx context.Context, orgID, userID string) error
 	PasswordCodeSent(ctx context.Context, orgID, userID string, generatorInfo *senders.CodeGeneratorInfo) error
@@ -27,6 +27,11 @@ type Commands interface {
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string, generatorInfo *senders.CodeGeneratorInfo) error
 	InviteCodeSent(ctx context.Context, orgID, userID string) error
+	InactivityWarningSent(ctx context.Context, orgID, userID string) error
+	SecurityNotificationSent(ctx context.Context, orgID, userID, messageType, sessionID string) error
+	SignInDeviceAdded(ctx context.Context, orgID, userID, deviceID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, instanceID string, msType milestone.Type, endpoints []string) error
+	SSFSETDelivered(ctx context.Context, streamID, jti, eventType, subjectID string) error
+	SSFSETFailed(ctx context.Context, streamID, jti, eventType, subjectID, reason string) error
 }
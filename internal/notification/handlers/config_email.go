@@ -23,26 +23,55 @@ func (n *NotificationQueries) GetActiveEmailConfig(ctx context.Context) (*email.
 		Description: config.Description,
 	}
 	if config.SMTPConfig != nil {
-		if config.SMTPConfig.Password == nil {
+		if config.SMTPConfig.Password == nil && config.SMTPConfig.XOAuth2 == nil {
 			return nil, zerrors.ThrowNotFound(err, "QUERY-Wrs3gw", "Errors.SMTPConfig.NotFound")
 		}
-		password, err := crypto.DecryptString(config.SMTPConfig.Password, n.SMTPPasswordCrypto)
-		if err != nil {
-			return nil, err
+		var password string
+		if config.SMTPConfig.Password != nil {
+			password, err = crypto.DecryptString(config.SMTPConfig.Password, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		smtpConfig := &smtp.Config{
+			From:           config.SMTPConfig.SenderAddress,
+			FromName:       config.SMTPConfig.SenderName,
+			ReplyToAddress: config.SMTPConfig.ReplyToAddress,
+			Tls:            config.SMTPConfig.TLS,
+			SMTP: smtp.SMTP{
+				Host:     config.SMTPConfig.Host,
+				User:     config.SMTPConfig.User,
+				Password: password,
+			},
+		}
+		if xoauth2 := config.SMTPConfig.XOAuth2; xoauth2 != nil {
+			clientSecret, err := crypto.DecryptString(xoauth2.ClientSecret, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+			smtpConfig.SMTP.XOAuth2 = &smtp.XOAuth2{
+				ConfigID:      config.AggregateID + ":" + config.ID,
+				Version:       config.Sequence,
+				TokenEndpoint: xoauth2.TokenEndpoint,
+				ClientID:      xoauth2.ClientID,
+				ClientSecret:  clientSecret,
+				Scopes:        xoauth2.Scopes,
+			}
+		}
+		if dkim := config.SMTPConfig.DKIM; dkim != nil {
+			privateKey, err := crypto.Decrypt(dkim.PrivateKey, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+			smtpConfig.DKIM = &smtp.DKIM{
+				Domain:     dkim.Domain,
+				Selector:   dkim.Selector,
+				PrivateKey: privateKey,
+			}
 		}
 		return &email.Config{
 			ProviderConfig: provider,
-			SMTPConfig: &smtp.Config{
-				From:           config.SMTPConfig.SenderAddress,
-				FromName:       config.SMTPConfig.SenderName,
-				ReplyToAddress: config.SMTPConfig.ReplyToAddress,
-				Tls:            config.SMTPConfig.TLS,
-				SMTP: smtp.SMTP{
-					Host:     config.SMTPConfig.Host,
-					User:     config.SMTPConfig.User,
-					Password: password,
-				},
-			},
+			SMTPConfig:     smtpConfig,
 		}, nil
 	}
 	if config.HTTPConfig != nil {
@@ -6,8 +6,10 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/notification/channels/messagebird"
 	"github.com/zitadel/zitadel/internal/notification/channels/sms"
 	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/channels/vonage"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -41,16 +43,63 @@ func (n *NotificationQueries) GetActiveSMSConfig(ctx context.Context) (*sms.Conf
 			},
 		}, nil
 	}
-	if config.HTTPConfig != nil {
+	if config.VonageConfig != nil {
+		if config.VonageConfig.APISecret == nil {
+			return nil, zerrors.ThrowNotFound(nil, "QUERY-Vn4sLq", "Errors.SMSConfig.NotFound")
+		}
+		apiSecret, err := crypto.DecryptString(config.VonageConfig.APISecret, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
+		return &sms.Config{
+			ProviderConfig: provider,
+			VonageConfig: &vonage.Config{
+				APIKey:       config.VonageConfig.APIKey,
+				APISecret:    apiSecret,
+				SenderNumber: config.VonageConfig.SenderNumber,
+				VerifyBrand:  config.VonageConfig.VerifyBrand,
+			},
+		}, nil
+	}
+	if config.MessageBirdConfig != nil {
+		if config.MessageBirdConfig.AccessKey == nil {
+			return nil, zerrors.ThrowNotFound(nil, "QUERY-Mb7pWe", "Errors.SMSConfig.NotFound")
+		}
+		accessKey, err := crypto.DecryptString(config.MessageBirdConfig.AccessKey, n.SMSTokenCrypto)
+		if err != nil {
+			return nil, err
+		}
 		return &sms.Config{
 			ProviderConfig: provider,
-			WebhookConfig: &webhook.Config{
-				CallURL: config.HTTPConfig.Endpoint,
-				Method:  http.MethodPost,
-				Headers: nil,
+			MessageBirdConfig: &messagebird.Config{
+				AccessKey:  accessKey,
+				Originator: config.MessageBirdConfig.Originator,
+				Verify:     config.MessageBirdConfig.Verify,
 			},
 		}, nil
 	}
+	if config.HTTPConfig != nil {
+		webhookConfig := &webhook.Config{
+			CallURL:     config.HTTPConfig.Endpoint,
+			Method:      http.MethodPost,
+			ContentType: config.HTTPConfig.ContentType,
+		}
+		if config.HTTPConfig.Template != "" {
+			webhookConfig.Template, err = webhook.ParseTemplate(config.HTTPConfig.Template)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if config.HTTPConfig.Headers != nil {
+			if err = crypto.DecryptJSON(config.HTTPConfig.Headers, &webhookConfig.Headers, n.SMSTokenCrypto); err != nil {
+				return nil, err
+			}
+		}
+		return &sms.Config{
+			ProviderConfig: provider,
+			WebhookConfig:  webhookConfig,
+		}, nil
+	}
 
 	return nil, zerrors.ThrowNotFound(nil, "HANDLER-8nfow", "Errors.SMS.Twilio.NotFound")
 }
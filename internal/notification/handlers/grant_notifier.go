@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	_ "github.com/zitadel/zitadel/internal/notification/statik"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	GrantNotificationsProjectionTable = "projections.notifications_grant"
+)
+
+type grantNotifier struct {
+	queries  *NotificationQueries
+	channels types.ChannelChains
+}
+
+func NewGrantNotifier(
+	ctx context.Context,
+	config handler.Config,
+	queries *NotificationQueries,
+	channels types.ChannelChains,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &grantNotifier{
+		queries:  queries,
+		channels: channels,
+	})
+}
+
+func (*grantNotifier) Name() string {
+	return GrantNotificationsProjectionTable
+}
+
+func (g *grantNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: usergrant.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  usergrant.UserGrantExpiryWarnedType,
+					Reduce: g.reduceUserGrantExpiryWarned,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.GrantExpiryWarnedType,
+					Reduce: g.reduceProjectGrantExpiryWarned,
+				},
+			},
+		},
+	}
+}
+
+func (g *grantNotifier) reduceUserGrantExpiryWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*usergrant.UserGrantExpiryWarnedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gx1wU", "reduce.wrong.event.type %s", usergrant.UserGrantExpiryWarnedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		idQuery, err := query.NewUserGrantIDSearchQuery(e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		grant, err := g.queries.UserGrant(ctx, true, idQuery)
+		if err != nil {
+			return err
+		}
+		grantee := grant.DisplayName
+		if grantee == "" {
+			grantee = grant.PreferredLoginName
+		}
+		return g.notifyOwners(ctx, e, e.Aggregate().ResourceOwner, grantee, grant.ProjectName, e.ValidUntil)
+	}), nil
+}
+
+func (g *grantNotifier) reduceProjectGrantExpiryWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantExpiryWarnedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gx2wP", "reduce.wrong.event.type %s", project.GrantExpiryWarnedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		grant, err := g.queries.ProjectGrantByID(ctx, true, e.GrantID)
+		if err != nil {
+			return err
+		}
+		// the owners of the project and of the granted organization are affected by the expiry
+		for _, orgID := range []string{e.Aggregate().ResourceOwner, e.GrantedOrgID} {
+			if err = g.notifyOwners(ctx, e, orgID, grant.OrgName, grant.ProjectName, e.ValidUntil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+// notifyOwners sends the grant expiry warning to all human owners of the organization with a verified email.
+func (g *grantNotifier) notifyOwners(ctx context.Context, event eventstore.Event, orgID, grantee, projectName string, validUntil time.Time) error {
+	members, err := g.queries.OrgMembers(ctx, &query.OrgMembersQuery{OrgID: orgID})
+	if err != nil {
+		return err
+	}
+	colors, err := g.queries.ActiveLabelPolicyByOrg(ctx, orgID, false)
+	if err != nil {
+		return err
+	}
+	template, err := g.queries.MailTemplateByOrg(ctx, orgID, false)
+	if err != nil {
+		return err
+	}
+	translator, err := g.queries.GetTranslatorWithOrgTexts(ctx, orgID, domain.GrantExpiryWarningMessageType)
+	if err != nil {
+		return err
+	}
+	customTemplates, err := g.queries.NotificationTemplatesByOrg(ctx, orgID, domain.GrantExpiryWarningMessageType)
+	if err != nil {
+		return err
+	}
+	ctx, err = g.queries.Origin(ctx, event)
+	if err != nil {
+		return err
+	}
+	for _, member := range members.Members {
+		if member.UserType != domain.UserTypeHuman || !slices.Contains(member.Roles, domain.RoleOrgOwner) {
+			continue
+		}
+		owner, err := g.queries.GetNotifyUserByID(ctx, true, member.UserID)
+		if err != nil {
+			return err
+		}
+		// the warning is only sent to verified addresses
+		if owner.VerifiedEmail == "" {
+			continue
+		}
+		err = types.SendEmail(ctx, g.channels, string(template.Template), customTemplates, translator, owner, colors, event).
+			SendGrantExpiryWarning(ctx, owner, grantee, projectName, validUntil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
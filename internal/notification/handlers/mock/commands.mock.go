@@ -129,6 +129,20 @@ func (mr *MockCommandsMockRecorder) HumanPhoneVerificationCodeSent(ctx, orgID, u
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HumanPhoneVerificationCodeSent", reflect.TypeOf((*MockCommands)(nil).HumanPhoneVerificationCodeSent), ctx, orgID, userID, generatorInfo)
 }
 
+// InactivityWarningSent mocks base method.
+func (m *MockCommands) InactivityWarningSent(ctx context.Context, orgID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InactivityWarningSent", ctx, orgID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InactivityWarningSent indicates an expected call of InactivityWarningSent.
+func (mr *MockCommandsMockRecorder) InactivityWarningSent(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InactivityWarningSent", reflect.TypeOf((*MockCommands)(nil).InactivityWarningSent), ctx, orgID, userID)
+}
+
 // InviteCodeSent mocks base method.
 func (m *MockCommands) InviteCodeSent(ctx context.Context, orgID, userID string) error {
 	m.ctrl.T.Helper()
@@ -186,17 +200,17 @@ func (mr *MockCommandsMockRecorder) NotificationRetryRequested(ctx, tx, id, reso
 }
 
 // NotificationSent mocks base method.
-func (m *MockCommands) NotificationSent(ctx context.Context, tx *sql.Tx, id, instanceID string) error {
+func (m *MockCommands) NotificationSent(ctx context.Context, tx *sql.Tx, id, instanceID string, deliveryInfo *senders.DeliveryInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NotificationSent", ctx, tx, id, instanceID)
+	ret := m.ctrl.Call(m, "NotificationSent", ctx, tx, id, instanceID, deliveryInfo)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // NotificationSent indicates an expected call of NotificationSent.
-func (mr *MockCommandsMockRecorder) NotificationSent(ctx, tx, id, instanceID any) *gomock.Call {
+func (mr *MockCommandsMockRecorder) NotificationSent(ctx, tx, id, instanceID, deliveryInfo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationSent", reflect.TypeOf((*MockCommands)(nil).NotificationSent), ctx, tx, id, instanceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationSent", reflect.TypeOf((*MockCommands)(nil).NotificationSent), ctx, tx, id, instanceID, deliveryInfo)
 }
 
 // OTPEmailSent mocks base method.
@@ -269,6 +283,62 @@ func (mr *MockCommandsMockRecorder) RequestNotification(ctx, instanceID, request
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestNotification", reflect.TypeOf((*MockCommands)(nil).RequestNotification), ctx, instanceID, request)
 }
 
+// SSFSETDelivered mocks base method.
+func (m *MockCommands) SSFSETDelivered(ctx context.Context, streamID, jti, eventType, subjectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSFSETDelivered", ctx, streamID, jti, eventType, subjectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SSFSETDelivered indicates an expected call of SSFSETDelivered.
+func (mr *MockCommandsMockRecorder) SSFSETDelivered(ctx, streamID, jti, eventType, subjectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSFSETDelivered", reflect.TypeOf((*MockCommands)(nil).SSFSETDelivered), ctx, streamID, jti, eventType, subjectID)
+}
+
+// SSFSETFailed mocks base method.
+func (m *MockCommands) SSFSETFailed(ctx context.Context, streamID, jti, eventType, subjectID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSFSETFailed", ctx, streamID, jti, eventType, subjectID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SSFSETFailed indicates an expected call of SSFSETFailed.
+func (mr *MockCommandsMockRecorder) SSFSETFailed(ctx, streamID, jti, eventType, subjectID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSFSETFailed", reflect.TypeOf((*MockCommands)(nil).SSFSETFailed), ctx, streamID, jti, eventType, subjectID, reason)
+}
+
+// SecurityNotificationSent mocks base method.
+func (m *MockCommands) SecurityNotificationSent(ctx context.Context, orgID, userID, messageType, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityNotificationSent", ctx, orgID, userID, messageType, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecurityNotificationSent indicates an expected call of SecurityNotificationSent.
+func (mr *MockCommandsMockRecorder) SecurityNotificationSent(ctx, orgID, userID, messageType, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityNotificationSent", reflect.TypeOf((*MockCommands)(nil).SecurityNotificationSent), ctx, orgID, userID, messageType, sessionID)
+}
+
+// SignInDeviceAdded mocks base method.
+func (m *MockCommands) SignInDeviceAdded(ctx context.Context, orgID, userID, deviceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInDeviceAdded", ctx, orgID, userID, deviceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignInDeviceAdded indicates an expected call of SignInDeviceAdded.
+func (mr *MockCommandsMockRecorder) SignInDeviceAdded(ctx, orgID, userID, deviceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInDeviceAdded", reflect.TypeOf((*MockCommands)(nil).SignInDeviceAdded), ctx, orgID, userID, deviceID)
+}
+
 // UsageNotificationSent mocks base method.
 func (m *MockCommands) UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error {
 	m.ctrl.T.Helper()
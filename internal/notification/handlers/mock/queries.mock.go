@@ -90,6 +90,21 @@ func (mr *MockQueriesMockRecorder) ActivePrivateSigningKey(ctx, t any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivePrivateSigningKey", reflect.TypeOf((*MockQueries)(nil).ActivePrivateSigningKey), ctx, t)
 }
 
+// ActiveSSFStreams mocks base method.
+func (m *MockQueries) ActiveSSFStreams(ctx context.Context) (*query.SSFStreams, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveSSFStreams", ctx)
+	ret0, _ := ret[0].(*query.SSFStreams)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveSSFStreams indicates an expected call of ActiveSSFStreams.
+func (mr *MockQueriesMockRecorder) ActiveSSFStreams(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveSSFStreams", reflect.TypeOf((*MockQueries)(nil).ActiveSSFStreams), ctx)
+}
+
 // CustomTextListByTemplate mocks base method.
 func (m *MockQueries) CustomTextListByTemplate(ctx context.Context, aggregateID, template string, withOwnerRemoved bool) (*query.CustomTexts, error) {
 	m.ctrl.T.Helper()
@@ -224,6 +239,96 @@ func (mr *MockQueriesMockRecorder) NotificationProviderByIDAndType(ctx, aggID, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationProviderByIDAndType", reflect.TypeOf((*MockQueries)(nil).NotificationProviderByIDAndType), ctx, aggID, providerType)
 }
 
+// NotificationTemplatesByOrg mocks base method.
+func (m *MockQueries) NotificationTemplatesByOrg(ctx context.Context, orgID, messageType string) (*query.NotificationTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotificationTemplatesByOrg", ctx, orgID, messageType)
+	ret0, _ := ret[0].(*query.NotificationTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotificationTemplatesByOrg indicates an expected call of NotificationTemplatesByOrg.
+func (mr *MockQueriesMockRecorder) NotificationTemplatesByOrg(ctx, orgID, messageType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotificationTemplatesByOrg", reflect.TypeOf((*MockQueries)(nil).NotificationTemplatesByOrg), ctx, orgID, messageType)
+}
+
+// OrgMembers mocks base method.
+func (m *MockQueries) OrgMembers(ctx context.Context, queries *query.OrgMembersQuery) (*query.Members, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrgMembers", ctx, queries)
+	ret0, _ := ret[0].(*query.Members)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrgMembers indicates an expected call of OrgMembers.
+func (mr *MockQueriesMockRecorder) OrgMembers(ctx, queries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrgMembers", reflect.TypeOf((*MockQueries)(nil).OrgMembers), ctx, queries)
+}
+
+// ProjectByID mocks base method.
+func (m *MockQueries) ProjectByID(ctx context.Context, shouldTriggerBulk bool, id string) (*query.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectByID", ctx, shouldTriggerBulk, id)
+	ret0, _ := ret[0].(*query.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectByID indicates an expected call of ProjectByID.
+func (mr *MockQueriesMockRecorder) ProjectByID(ctx, shouldTriggerBulk, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectByID", reflect.TypeOf((*MockQueries)(nil).ProjectByID), ctx, shouldTriggerBulk, id)
+}
+
+// ProjectGrantByID mocks base method.
+func (m *MockQueries) ProjectGrantByID(ctx context.Context, shouldTriggerBulk bool, id string) (*query.ProjectGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectGrantByID", ctx, shouldTriggerBulk, id)
+	ret0, _ := ret[0].(*query.ProjectGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectGrantByID indicates an expected call of ProjectGrantByID.
+func (mr *MockQueriesMockRecorder) ProjectGrantByID(ctx, shouldTriggerBulk, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGrantByID", reflect.TypeOf((*MockQueries)(nil).ProjectGrantByID), ctx, shouldTriggerBulk, id)
+}
+
+// ProjectGrantMembers mocks base method.
+func (m *MockQueries) ProjectGrantMembers(ctx context.Context, queries *query.ProjectGrantMembersQuery) (*query.Members, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectGrantMembers", ctx, queries)
+	ret0, _ := ret[0].(*query.Members)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectGrantMembers indicates an expected call of ProjectGrantMembers.
+func (mr *MockQueriesMockRecorder) ProjectGrantMembers(ctx, queries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGrantMembers", reflect.TypeOf((*MockQueries)(nil).ProjectGrantMembers), ctx, queries)
+}
+
+// ProjectMembers mocks base method.
+func (m *MockQueries) ProjectMembers(ctx context.Context, queries *query.ProjectMembersQuery) (*query.Members, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectMembers", ctx, queries)
+	ret0, _ := ret[0].(*query.Members)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectMembers indicates an expected call of ProjectMembers.
+func (mr *MockQueriesMockRecorder) ProjectMembers(ctx, queries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembers", reflect.TypeOf((*MockQueries)(nil).ProjectMembers), ctx, queries)
+}
+
 // SMSProviderConfigActive mocks base method.
 func (m *MockQueries) SMSProviderConfigActive(ctx context.Context, resourceOwner string) (*query.SMSConfig, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +359,21 @@ func (mr *MockQueriesMockRecorder) SMTPConfigActive(ctx, resourceOwner any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMTPConfigActive", reflect.TypeOf((*MockQueries)(nil).SMTPConfigActive), ctx, resourceOwner)
 }
 
+// SSFStreamByID mocks base method.
+func (m *MockQueries) SSFStreamByID(ctx context.Context, shouldTriggerBulk bool, id string) (*query.SSFStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSFStreamByID", ctx, shouldTriggerBulk, id)
+	ret0, _ := ret[0].(*query.SSFStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SSFStreamByID indicates an expected call of SSFStreamByID.
+func (mr *MockQueriesMockRecorder) SSFStreamByID(ctx, shouldTriggerBulk, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSFStreamByID", reflect.TypeOf((*MockQueries)(nil).SSFStreamByID), ctx, shouldTriggerBulk, id)
+}
+
 // SearchInstanceDomains mocks base method.
 func (m *MockQueries) SearchInstanceDomains(ctx context.Context, queries *query.InstanceDomainSearchQueries) (*query.InstanceDomains, error) {
 	m.ctrl.T.Helper()
@@ -298,3 +418,23 @@ func (mr *MockQueriesMockRecorder) SessionByID(ctx, shouldTriggerBulk, id, sessi
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionByID", reflect.TypeOf((*MockQueries)(nil).SessionByID), ctx, shouldTriggerBulk, id, sessionToken)
 }
+
+// UserGrant mocks base method.
+func (m *MockQueries) UserGrant(ctx context.Context, shouldTriggerBulk bool, queries ...query.SearchQuery) (*query.UserGrant, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, shouldTriggerBulk}
+	for _, a := range queries {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserGrant", varargs...)
+	ret0, _ := ret[0].(*query.UserGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGrant indicates an expected call of UserGrant.
+func (mr *MockQueriesMockRecorder) UserGrant(ctx, shouldTriggerBulk any, queries ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, shouldTriggerBulk}, queries...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGrant", reflect.TypeOf((*MockQueries)(nil).UserGrant), varargs...)
+}
@@ -208,8 +208,21 @@ func (w *NotificationWorker) sendNotification(ctx, txCtx context.Context, tx *sq
 	if err != nil {
 		return err
 	}
+	customTemplates, err := w.queries.NotificationTemplatesByOrg(ctx, request.UserResourceOwner, request.MessageType)
+	if err != nil {
+		return err
+	}
+
+	// Notifications about a changed email are sent to the previous address, so the user can react if the change was not done by them.
+	if request.Args != nil && request.Args.PreviousEmail != "" {
+		previousEmailUser := *notifyUser
+		previousEmailUser.LastEmail = request.Args.PreviousEmail
+		previousEmailUser.VerifiedEmail = request.Args.PreviousEmail
+		notifyUser = &previousEmailUser
+	}
 
 	generatorInfo := new(senders.CodeGeneratorInfo)
+	ctx, deliveryInfo := senders.WithDeliveryInfo(ctx)
 	var notify types.Notify
 	switch request.NotificationType {
 	case domain.NotificationTypeEmail:
@@ -217,9 +230,9 @@ func (w *NotificationWorker) sendNotification(ctx, txCtx context.Context, tx *sq
 		if err != nil {
 			return err
 		}
-		notify = types.SendEmail(ctx, w.channels, string(template.Template), translator, notifyUser, colors, e)
+		notify = types.SendEmail(ctx, w.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e)
 	case domain.NotificationTypeSms:
-		notify = types.SendSMS(ctx, w.channels, translator, notifyUser, colors, e, generatorInfo)
+		notify = types.SendSMS(ctx, w.channels, customTemplates, translator, notifyUser, colors, e, generatorInfo)
 	}
 
 	args := request.Args.ToMap()
@@ -232,7 +245,7 @@ func (w *NotificationWorker) sendNotification(ctx, txCtx context.Context, tx *sq
 	if err := notify(request.URLTemplate, args, request.MessageType, request.UnverifiedNotificationChannel); err != nil {
 		return err
 	}
-	err = w.commands.NotificationSent(txCtx, tx, e.Aggregate().ID, e.Aggregate().ResourceOwner)
+	err = w.commands.NotificationSent(txCtx, tx, e.Aggregate().ID, e.Aggregate().ResourceOwner, deliveryInfo)
 	if err != nil {
 		// In case the notification event cannot be pushed, we most likely cannot create a retry or cancel event.
 		// Therefore, we'll only log the error and also do not need to try to push to the user / session.
@@ -105,7 +105,7 @@ func Test_userNotifier_reduceNotificationRequested(t *testing.T) {
 				}
 				codeAlg, code := cryptoValue(t, ctrl, "testcode")
 				expectTemplateWithNotifyUserQueries(queries, givenTemplate)
-				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID).Return(nil)
+				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID, &senders.DeliveryInfo{ProviderID: "emailProviderID", ProviderType: senders.ProviderTypeSMTP, Recipient: lastEmail}).Return(nil)
 				commands.EXPECT().InviteCodeSent(gomock.Any(), orgID, userID).Return(nil)
 				return fieldsWorker{
 						queries:  queries,
@@ -162,7 +162,7 @@ func Test_userNotifier_reduceNotificationRequested(t *testing.T) {
 				}
 				codeAlg, code := cryptoValue(t, ctrl, testCode)
 				expectTemplateWithNotifyUserQueriesSMS(queries)
-				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID).Return(nil)
+				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID, &senders.DeliveryInfo{ProviderID: "smsProviderID", ProviderType: senders.ProviderTypeTwilio, Recipient: verifiedPhone, MessageID: verificationID}).Return(nil)
 				commands.EXPECT().OTPSMSSent(gomock.Any(), sessionID, instanceID, &senders.CodeGeneratorInfo{
 					ID:             smsProviderID,
 					VerificationID: verificationID,
@@ -221,7 +221,7 @@ func Test_userNotifier_reduceNotificationRequested(t *testing.T) {
 					Content:    expectContent,
 				}
 				expectTemplateWithNotifyUserQueries(queries, givenTemplate)
-				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID).Return(nil)
+				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID, &senders.DeliveryInfo{ProviderID: "emailProviderID", ProviderType: senders.ProviderTypeSMTP, Recipient: verifiedEmail}).Return(nil)
 				commands.EXPECT().UserDomainClaimedSent(gomock.Any(), orgID, userID).Return(nil)
 				return fieldsWorker{
 						queries:  queries,
@@ -567,7 +567,7 @@ func Test_userNotifier_reduceNotificationRetry(t *testing.T) {
 				}
 				codeAlg, code := cryptoValue(t, ctrl, "testcode")
 				expectTemplateQueries(queries, givenTemplate)
-				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID).Return(nil)
+				commands.EXPECT().NotificationSent(gomock.Any(), gomock.Any(), notificationID, instanceID, &senders.DeliveryInfo{ProviderID: "emailProviderID", ProviderType: senders.ProviderTypeSMTP, Recipient: lastEmail}).Return(nil)
 				commands.EXPECT().InviteCodeSent(gomock.Any(), orgID, userID).Return(nil)
 				return fieldsWorker{
 						queries:  queries,
@@ -826,6 +826,7 @@ func newNotificationWorker(t *testing.T, ctrl *gomock.Controller, queries *mock.
 			w.messageSMS.TriggeringEvent = a.event
 			channel.EXPECT().HandleMessage(w.messageSMS).DoAndReturn(func(message *messages.SMS) error {
 				message.VerificationID = gu.Ptr(verificationID)
+				message.MessageID = verificationID
 				return w.sendError
 			})
 		}
@@ -28,6 +28,12 @@ func (n *NotificationQueries) Origin(ctx context.Context, e eventstore.Event) (c
 	if origin != "" {
 		return enrichCtx(ctx, origin)
 	}
+	return n.InstanceOrigin(ctx)
+}
+
+// InstanceOrigin sets the origin of the primary domain of the instance,
+// which is used for notifications about events without a trigger origin.
+func (n *NotificationQueries) InstanceOrigin(ctx context.Context) (context.Context, error) {
 	primary, err := query.NewInstanceDomainPrimarySearchQuery(true)
 	if err != nil {
 		return ctx, err
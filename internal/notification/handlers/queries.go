@@ -19,6 +19,7 @@ type Queries interface {
 	MailTemplateByOrg(ctx context.Context, orgID string, withOwnerRemoved bool) (*query.MailTemplate, error)
 	GetNotifyUserByID(ctx context.Context, shouldTriggered bool, userID string) (*query.NotifyUser, error)
 	CustomTextListByTemplate(ctx context.Context, aggregateID, template string, withOwnerRemoved bool) (*query.CustomTexts, error)
+	NotificationTemplatesByOrg(ctx context.Context, orgID, messageType string) (*query.NotificationTemplates, error)
 	SearchInstanceDomains(ctx context.Context, queries *query.InstanceDomainSearchQueries) (*query.InstanceDomains, error)
 	SessionByID(ctx context.Context, shouldTriggerBulk bool, id, sessionToken string) (*query.Session, error)
 	NotificationPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (*query.NotificationPolicy, error)
@@ -31,6 +32,14 @@ type Queries interface {
 	InstanceByID(ctx context.Context, id string) (instance authz.Instance, err error)
 	GetActiveSigningWebKey(ctx context.Context) (*jose.JSONWebKey, error)
 	ActivePrivateSigningKey(ctx context.Context, t time.Time) (keys *query.PrivateKeys, err error)
+	OrgMembers(ctx context.Context, queries *query.OrgMembersQuery) (members *query.Members, err error)
+	UserGrant(ctx context.Context, shouldTriggerBulk bool, queries ...query.SearchQuery) (grant *query.UserGrant, err error)
+	ProjectGrantByID(ctx context.Context, shouldTriggerBulk bool, id string) (grant *query.ProjectGrant, err error)
+	ProjectByID(ctx context.Context, shouldTriggerBulk bool, id string) (project *query.Project, err error)
+	ProjectMembers(ctx context.Context, queries *query.ProjectMembersQuery) (members *query.Members, err error)
+	ProjectGrantMembers(ctx context.Context, queries *query.ProjectGrantMembersQuery) (members *query.Members, err error)
+	ActiveSSFStreams(ctx context.Context) (*query.SSFStreams, error)
+	SSFStreamByID(ctx context.Context, shouldTriggerBulk bool, id string) (*query.SSFStream, error)
 
 	ActiveInstances() []string
 }
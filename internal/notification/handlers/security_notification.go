@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/senders"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// securityNotificationTypes maps the events triggering a notification about a security relevant change
+// of the user's account to the message type of the notification.
+var securityNotificationTypes = map[eventstore.EventType]string{
+	user.HumanPasswordCheckSucceededType:          domain.NewDeviceSignInMessageType,
+	user.HumanPasswordlessTokenCheckSucceededType: domain.NewDeviceSignInMessageType,
+	user.UserIDPLoginCheckSucceededType:           domain.NewDeviceSignInMessageType,
+	session.PasswordCheckedType:                   domain.NewDeviceSignInMessageType,
+	session.WebAuthNCheckedType:                   domain.NewDeviceSignInMessageType,
+	session.IntentCheckedType:                     domain.NewDeviceSignInMessageType,
+	user.HumanMFAOTPVerifiedType:                  domain.MFAAddedMessageType,
+	user.HumanU2FTokenVerifiedType:                domain.MFAAddedMessageType,
+	user.HumanPasswordlessTokenVerifiedType:       domain.MFAAddedMessageType,
+	user.HumanOTPSMSAddedType:                     domain.MFAAddedMessageType,
+	user.HumanOTPEmailAddedType:                   domain.MFAAddedMessageType,
+	user.HumanMFAOTPRemovedType:                   domain.MFARemovedMessageType,
+	user.HumanU2FTokenRemovedType:                 domain.MFARemovedMessageType,
+	user.HumanPasswordlessTokenRemovedType:        domain.MFARemovedMessageType,
+	user.HumanOTPSMSRemovedType:                   domain.MFARemovedMessageType,
+	user.HumanOTPEmailRemovedType:                 domain.MFARemovedMessageType,
+	user.HumanEmailChangedType:                    domain.EmailChangeMessageType,
+	user.UserLockedType:                           domain.AccountLockedMessageType,
+	user.PersonalAccessTokenAddedType:             domain.PersonalAccessTokenAddedMessageType,
+}
+
+// securityNotificationAuthMethods names the multi-factor authentication method of the events
+// triggering a [domain.MFAAddedMessageType] or [domain.MFARemovedMessageType] notification.
+var securityNotificationAuthMethods = map[eventstore.EventType]string{
+	user.HumanMFAOTPVerifiedType:            "TOTP",
+	user.HumanMFAOTPRemovedType:             "TOTP",
+	user.HumanU2FTokenVerifiedType:          "U2F",
+	user.HumanU2FTokenRemovedType:           "U2F",
+	user.HumanPasswordlessTokenVerifiedType: "Passkey",
+	user.HumanPasswordlessTokenRemovedType:  "Passkey",
+	user.HumanOTPSMSAddedType:               "OTP SMS",
+	user.HumanOTPSMSRemovedType:             "OTP SMS",
+	user.HumanOTPEmailAddedType:             "OTP Email",
+	user.HumanOTPEmailRemovedType:           "OTP Email",
+}
+
+func init() {
+	for eventType, messageType := range securityNotificationTypes {
+		RegisterSentHandler(eventType,
+			func(ctx context.Context, commands Commands, id, orgID string, _ *senders.CodeGeneratorInfo, args map[string]any) error {
+				sessionID, _ := args["SessionID"].(string)
+				return commands.SecurityNotificationSent(ctx, orgID, id, messageType, sessionID)
+			},
+		)
+	}
+}
+
+// securityNotificationEnabled checks if the notification policy enables notifications of the message type.
+func securityNotificationEnabled(policy *query.NotificationPolicy, messageType string) bool {
+	if policy == nil {
+		return false
+	}
+	switch messageType {
+	case domain.NewDeviceSignInMessageType:
+		return policy.NewDeviceSignIn
+	case domain.MFAAddedMessageType:
+		return policy.MFAAdded
+	case domain.MFARemovedMessageType:
+		return policy.MFARemoved
+	case domain.EmailChangeMessageType:
+		return policy.EmailChange
+	case domain.AccountLockedMessageType:
+		return policy.AccountLocked
+	case domain.PersonalAccessTokenAddedMessageType:
+		return policy.PersonalAccessTokenAdded
+	default:
+		return false
+	}
+}
+
+// SecurityNotification checks if the user must be notified about the event and returns the user and the arguments of the notification.
+// The returned user is nil if no notification must be sent,
+// because the notification policy disables it, the user has no email or nothing relevant happened.
+// The deviceID and sessionID are only used for sign-in notifications.
+func (n *NotificationQueries) SecurityNotification(
+	ctx context.Context,
+	event eventstore.Event,
+	messageType,
+	userID,
+	resourceOwner,
+	deviceID,
+	sessionID string,
+) (*query.NotifyUser, *domain.NotificationArguments, error) {
+	policy, err := n.NotificationPolicyByOrg(ctx, true, resourceOwner, false)
+	if err != nil && !zerrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if !securityNotificationEnabled(policy, messageType) {
+		return nil, nil, nil
+	}
+	notifyUser, err := n.GetNotifyUserByID(ctx, true, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// machine users can't be notified
+	if notifyUser.LastEmail == "" {
+		return nil, nil, nil
+	}
+	args := &domain.NotificationArguments{
+		SessionID:  sessionID,
+		AuthMethod: securityNotificationAuthMethods[event.Type()],
+	}
+	switch messageType {
+	case domain.NewDeviceSignInMessageType:
+		isNew, err := n.IsNewSignInDevice(ctx, event, userID, deviceID)
+		if err != nil || !isNew {
+			return nil, nil, err
+		}
+	case domain.EmailChangeMessageType:
+		previous, err := n.PreviousEmail(ctx, event)
+		if err != nil || previous == "" {
+			return nil, nil, err
+		}
+		args.PreviousEmail = string(previous)
+	}
+	return notifyUser, args, nil
+}
+
+// securityNotificationOrigin uses the trigger origin of the event if there is one, otherwise the origin of the instance.
+func (n *NotificationQueries) securityNotificationOrigin(ctx context.Context, event eventstore.Event) (context.Context, error) {
+	if _, ok := event.(OriginEvent); ok {
+		return n.Origin(ctx, event)
+	}
+	return n.InstanceOrigin(ctx)
+}
+
+// signInUserAgentID returns the id of the user agent used for a sign-in of the login UI v1.
+func signInUserAgentID(event eventstore.Event) string {
+	var info *user.AuthRequestInfo
+	switch e := event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.HumanPasswordlessCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	case *user.UserIDPCheckSucceededEvent:
+		info = e.AuthRequestInfo
+	}
+	if info == nil {
+		return ""
+	}
+	return info.UserAgentID
+}
+
+// eventsExist is a query reducer which only checks if any event matches the query.
+type eventsExist struct {
+	query *eventstore.SearchQueryBuilder
+
+	exist bool
+}
+
+func (e *eventsExist) Reduce() error {
+	return nil
+}
+
+func (e *eventsExist) AppendEvents(events ...eventstore.Event) {
+	if len(events) > 0 {
+		e.exist = true
+	}
+}
+
+func (e *eventsExist) Query() *eventstore.SearchQueryBuilder {
+	return e.query
+}
+
+func (n *NotificationQueries) eventsExist(ctx context.Context, query *eventstore.SearchQueryBuilder) (bool, error) {
+	exist := &eventsExist{query: query.Limit(1)}
+	if err := n.es.FilterToQueryReducer(ctx, exist); err != nil {
+		return false, err
+	}
+	return exist.exist, nil
+}
+
+// IsSecurityNotificationSent checks if the notification triggered by the session was already sent to the user.
+// Notifications triggered by events of the user itself are checked by [NotificationQueries.IsAlreadyHandled].
+func (n *NotificationQueries) IsSecurityNotificationSent(ctx context.Context, instanceID, userID, messageType, sessionID string) (bool, error) {
+	return n.eventsExist(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(instanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		EventTypes(user.HumanSecurityNotificationSentType).
+		EventData(map[string]interface{}{
+			"messageType": messageType,
+			"sessionID":   sessionID,
+		}).
+		Builder())
+}
+
+// signInSession is the state of a session at the time a factor was checked.
+type signInSession struct {
+	instanceID string
+	sessionID  string
+	before     uint64
+
+	UserID            string
+	UserResourceOwner string
+	FingerprintID     string
+	// FirstFactor is set if the checked factor is the first factor of the session,
+	// because only the first factor signs in the user.
+	FirstFactor bool
+}
+
+func (s *signInSession) Reduce() error {
+	return nil
+}
+
+func (s *signInSession) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Sequence() >= s.before {
+			continue
+		}
+		switch e := event.(type) {
+		case *session.AddedEvent:
+			if e.UserAgent != nil && e.UserAgent.FingerprintID != nil {
+				s.FingerprintID = *e.UserAgent.FingerprintID
+			}
+		case *session.UserCheckedEvent:
+			s.UserID = e.UserID
+			s.UserResourceOwner = e.UserResourceOwner
+		case *session.PasswordCheckedEvent,
+			*session.WebAuthNCheckedEvent,
+			*session.IntentCheckedEvent:
+			s.FirstFactor = false
+		}
+	}
+}
+
+func (s *signInSession) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(s.instanceID).
+		AddQuery().
+		AggregateTypes(session.AggregateType).
+		AggregateIDs(s.sessionID).
+		EventTypes(
+			session.AddedType,
+			session.UserCheckedType,
+			session.PasswordCheckedType,
+			session.WebAuthNCheckedType,
+			session.IntentCheckedType,
+		).
+		Builder()
+}
+
+// SignInSession returns the user and device of the session the factor of the event was checked on.
+func (n *NotificationQueries) SignInSession(ctx context.Context, event eventstore.Event) (*signInSession, error) {
+	s := &signInSession{
+		instanceID:  event.Aggregate().InstanceID,
+		sessionID:   event.Aggregate().ID,
+		before:      event.Sequence(),
+		FirstFactor: true,
+	}
+	if err := n.es.FilterToQueryReducer(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// IsNewSignInDevice checks if the user signs in from a device, which was never used for a sign-in of the user before the event.
+// The device is identified by the user agent id of the login UI or the fingerprint of the session,
+// which is recorded on the user by [Commands.SignInDeviceAdded].
+// The first sign-in of a user is not considered to be from a new device.
+func (n *NotificationQueries) IsNewSignInDevice(ctx context.Context, event eventstore.Event, userID, deviceID string) (bool, error) {
+	if deviceID == "" {
+		return false, nil
+	}
+	instanceID := event.Aggregate().InstanceID
+	signInTypes := []eventstore.EventType{
+		user.HumanPasswordCheckSucceededType,
+		user.HumanPasswordlessTokenCheckSucceededType,
+		user.UserIDPLoginCheckSucceededType,
+	}
+	knownDevice, err := n.eventsExist(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(instanceID).
+		CreationDateBefore(event.CreatedAt()).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		EventTypes(signInTypes...).
+		EventData(map[string]interface{}{
+			"userAgentID": deviceID,
+		}).
+		Or().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		EventTypes(user.HumanSignInDeviceAddedType).
+		EventData(map[string]interface{}{
+			"deviceID": deviceID,
+		}).
+		Builder())
+	if err != nil || knownDevice {
+		return false, err
+	}
+	// without any previous sign-in, this is the first sign-in of the user
+	return n.eventsExist(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(instanceID).
+		CreationDateBefore(event.CreatedAt()).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		EventTypes(append(signInTypes, user.HumanSignInDeviceAddedType)...).
+		Builder())
+}
+
+// previousEmail reduces the email address of the user before the event.
+type previousEmail struct {
+	event eventstore.Event
+
+	Email domain.EmailAddress
+}
+
+func (p *previousEmail) Reduce() error {
+	return nil
+}
+
+func (p *previousEmail) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Sequence() >= p.event.Sequence() {
+			continue
+		}
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			p.Email = e.EmailAddress
+		case *user.HumanRegisteredEvent:
+			p.Email = e.EmailAddress
+		case *user.HumanEmailChangedEvent:
+			p.Email = e.EmailAddress
+		}
+	}
+}
+
+func (p *previousEmail) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(p.event.Aggregate().InstanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(p.event.Aggregate().ID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.UserV1RegisteredType,
+			user.UserV1EmailChangedType,
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanEmailChangedType,
+		).
+		Builder()
+}
+
+// PreviousEmail returns the email address the user had before the event changed it.
+func (n *NotificationQueries) PreviousEmail(ctx context.Context, event eventstore.Event) (domain.EmailAddress, error) {
+	previous := &previousEmail{event: event}
+	if err := n.es.FilterToQueryReducer(ctx, previous); err != nil {
+		return "", err
+	}
+	return previous.Email, nil
+}
@@ -0,0 +1,676 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/crypto"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	zoidc "github.com/zitadel/zitadel/internal/api/oidc"
+	zcrypto "github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels/set"
+	"github.com/zitadel/zitadel/internal/notification/types"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	SSFTransmitterProjectionTable = "projections.notifications_ssf_transmitter"
+)
+
+// ssfCredentialTypes maps the events of a changed credential to the credential type of the CAEP credential change event.
+var ssfCredentialTypes = map[eventstore.EventType]string{
+	user.HumanPasswordChangedType:           "password",
+	user.HumanMFAOTPVerifiedType:            "app",
+	user.HumanMFAOTPRemovedType:             "app",
+	user.HumanU2FTokenVerifiedType:          "fido-u2f",
+	user.HumanU2FTokenRemovedType:           "fido-u2f",
+	user.HumanPasswordlessTokenVerifiedType: "fido2-platform",
+	user.HumanPasswordlessTokenRemovedType:  "fido2-platform",
+	user.HumanOTPSMSAddedType:               "phone-sms",
+	user.HumanOTPSMSRemovedType:             "phone-sms",
+}
+
+// ssfSecondFactorTypes are the events changing the multi-factor authentication methods of the user,
+// which might change the assurance level of the user.
+var ssfSecondFactorTypes = []eventstore.EventType{
+	user.HumanMFAOTPVerifiedType,
+	user.HumanMFAOTPRemovedType,
+	user.HumanU2FTokenVerifiedType,
+	user.HumanU2FTokenRemovedType,
+	user.HumanPasswordlessTokenVerifiedType,
+	user.HumanPasswordlessTokenRemovedType,
+	user.HumanOTPSMSAddedType,
+	user.HumanOTPSMSRemovedType,
+	user.HumanOTPEmailAddedType,
+	user.HumanOTPEmailRemovedType,
+	user.UserV1MFAOTPVerifiedType,
+	user.UserV1MFAOTPRemovedType,
+}
+
+type ssfTransmitter struct {
+	commands         Commands
+	queries          *NotificationQueries
+	keyEncryptionAlg zcrypto.EncryptionAlgorithm
+	channels         types.ChannelChains
+}
+
+func NewSSFTransmitter(
+	ctx context.Context,
+	config handler.Config,
+	commands Commands,
+	queries *NotificationQueries,
+	keyEncryptionAlg zcrypto.EncryptionAlgorithm,
+	channels types.ChannelChains,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &ssfTransmitter{
+		commands:         commands,
+		queries:          queries,
+		keyEncryptionAlg: keyEncryptionAlg,
+		channels:         channels,
+	})
+}
+
+func (*ssfTransmitter) Name() string {
+	return SSFTransmitterProjectionTable
+}
+
+func (t *ssfTransmitter) Reducers() []handler.AggregateReducer {
+	userReducers := []handler.EventReducer{
+		{
+			Event:  user.HumanSignedOutType,
+			Reduce: t.reduceSessionRevoked,
+		},
+		{
+			Event:  user.UserDeactivatedType,
+			Reduce: t.reduceAccountDisabled,
+		},
+		{
+			Event:  user.UserLockedType,
+			Reduce: t.reduceAccountDisabled,
+		},
+		{
+			Event:  user.HumanPasswordChangedType,
+			Reduce: t.reduceCredentialChange,
+		},
+		{
+			Event:  user.HumanPasswordCompromisedType,
+			Reduce: t.reduceCredentialCompromise,
+		},
+	}
+	for _, eventType := range ssfSecondFactorTypes {
+		userReducers = append(userReducers, handler.EventReducer{
+			Event:  eventType,
+			Reduce: t.reduceCredentialChange,
+		})
+	}
+	return []handler.AggregateReducer{
+		{
+			Aggregate: session.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  session.TerminateType,
+					Reduce: t.reduceSessionRevoked,
+				},
+			},
+		},
+		{
+			Aggregate:     user.AggregateType,
+			EventReducers: userReducers,
+		},
+		{
+			Aggregate: ssf.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  ssf.VerificationRequestedEventType,
+					Reduce: t.reduceVerificationRequested,
+				},
+				{
+					Event:  ssf.StatusChangedEventType,
+					Reduce: t.reduceStatusChanged,
+				},
+			},
+		},
+	}
+}
+
+// ssfSubject is the subject identifier of a security event token (RFC 9493).
+type ssfSubject struct {
+	Format  string      `json:"format"`
+	Issuer  string      `json:"iss,omitempty"`
+	Subject string      `json:"sub,omitempty"`
+	ID      string      `json:"id,omitempty"`
+	User    *ssfSubject `json:"user,omitempty"`
+	Session *ssfSubject `json:"session,omitempty"`
+}
+
+func ssfUserSubject(issuer, userID string, sessionID string) *ssfSubject {
+	userSubject := &ssfSubject{
+		Format:  "iss_sub",
+		Issuer:  issuer,
+		Subject: userID,
+	}
+	if sessionID == "" {
+		return userSubject
+	}
+	return &ssfSubject{
+		Format:  "complex",
+		User:    userSubject,
+		Session: &ssfSubject{Format: "opaque", ID: sessionID},
+	}
+}
+
+// securityEventToken are the claims of a security event token (RFC 8417) as used by the Shared Signals Framework.
+type securityEventToken struct {
+	Issuer    string         `json:"iss"`
+	Audience  []string       `json:"aud"`
+	IssuedAt  int64          `json:"iat"`
+	JWTID     string         `json:"jti"`
+	SubjectID *ssfSubject    `json:"sub_id"`
+	Events    map[string]any `json:"events"`
+}
+
+// ssfEvent is an event which is delivered to all enabled streams, which requested the event type.
+type ssfEvent struct {
+	eventType string
+	// userID is used to build the subject and to track the delivery
+	userID    string
+	sessionID string
+	payload   map[string]any
+}
+
+func (t *ssfTransmitter) reduceSessionRevoked(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *session.TerminateEvent, *user.HumanSignedOutEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7s1", "reduce.wrong.event.type %v", []eventstore.EventType{session.TerminateType, user.HumanSignedOutType})
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		var userID, sessionID string
+		switch e := event.(type) {
+		case *session.TerminateEvent:
+			s, err := t.queries.SignInSession(ctx, e)
+			if err != nil {
+				return err
+			}
+			// sessions without a checked user don't have a subject
+			if s.UserID == "" {
+				return nil
+			}
+			userID, sessionID = s.UserID, e.Aggregate().ID
+		case *user.HumanSignedOutEvent:
+			userID, sessionID = e.Aggregate().ID, e.SessionID
+		}
+		return t.deliver(ctx, event, &ssfEvent{
+			eventType: domain.CAEPEventTypeSessionRevoked,
+			userID:    userID,
+			sessionID: sessionID,
+			payload:   ssfEventPayload(event),
+		})
+	}), nil
+}
+
+func (t *ssfTransmitter) reduceAccountDisabled(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.UserDeactivatedEvent, *user.UserLockedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7a2", "reduce.wrong.event.type %v", []eventstore.EventType{user.UserDeactivatedType, user.UserLockedType})
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		return t.deliver(ctx, event, &ssfEvent{
+			eventType: domain.RISCEventTypeAccountDisabled,
+			userID:    event.Aggregate().ID,
+			payload:   map[string]any{},
+		})
+	}), nil
+}
+
+// reduceCredentialChange delivers a credential change event for every changed credential.
+// If the multi-factor authentication methods of the user changed, an assurance level change event might be delivered as well.
+func (t *ssfTransmitter) reduceCredentialChange(event eventstore.Event) (*handler.Statement, error) {
+	if event.Aggregate().Type != user.AggregateType {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7c3", "reduce.wrong.event.type %s", event.Type())
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		userID := event.Aggregate().ID
+		events := make([]*ssfEvent, 0, 2)
+		if credentialType, ok := ssfCredentialTypes[event.Type()]; ok {
+			payload := ssfEventPayload(event)
+			payload["credential_type"] = credentialType
+			payload["change_type"] = ssfCredentialChangeType(event)
+			events = append(events, &ssfEvent{
+				eventType: domain.CAEPEventTypeCredentialChange,
+				userID:    userID,
+				payload:   payload,
+			})
+		}
+		if slices.Contains(ssfSecondFactorTypes, event.Type()) {
+			payload, err := t.queries.assuranceLevelChange(ctx, event)
+			if err != nil {
+				return err
+			}
+			if payload != nil {
+				events = append(events, &ssfEvent{
+					eventType: domain.CAEPEventTypeAssuranceLevelChange,
+					userID:    userID,
+					payload:   payload,
+				})
+			}
+		}
+		return t.deliver(ctx, event, events...)
+	}), nil
+}
+
+// reduceCredentialCompromise delivers a credential compromise event for a password explicitly reported as compromised.
+func (t *ssfTransmitter) reduceCredentialCompromise(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordCompromisedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7p2", "reduce.wrong.event.type %s", user.HumanPasswordCompromisedType)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		payload := ssfEventPayload(event)
+		payload["credential_type"] = "password"
+		if e.Reason != "" {
+			payload["reason_admin"] = map[string]string{"en": e.Reason}
+		}
+		return t.deliver(ctx, event, &ssfEvent{
+			eventType: domain.RISCEventTypeCredentialCompromise,
+			userID:    event.Aggregate().ID,
+			payload:   payload,
+		})
+	}), nil
+}
+
+func (t *ssfTransmitter) reduceVerificationRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*ssf.VerificationRequestedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7v5", "reduce.wrong.event.type %s", ssf.VerificationRequestedEventType)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		stream, err := t.queries.SSFStreamByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			if zerrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if stream.State != domain.SSFStreamStateEnabled {
+			return nil
+		}
+		payload := map[string]any{}
+		if e.State != "" {
+			payload["state"] = e.State
+		}
+		return t.deliverToStreams(ctx, e, []*query.SSFStream{stream}, &ssfEvent{
+			eventType: domain.SSFEventTypeVerification,
+			payload:   payload,
+		})
+	}), nil
+}
+
+// reduceStatusChanged notifies the receiver about the changed status of its stream.
+// Other than any other event, it's also delivered to paused and disabled streams.
+func (t *ssfTransmitter) reduceStatusChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*ssf.StatusChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sf7u6", "reduce.wrong.event.type %s", ssf.StatusChangedEventType)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx, err := t.queries.HandlerContext(event.Aggregate())
+		if err != nil {
+			return err
+		}
+		stream, err := t.queries.SSFStreamByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			if zerrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		payload := map[string]any{"status": e.State.String()}
+		if e.Reason != "" {
+			payload["reason"] = e.Reason
+		}
+		return t.deliverToStreams(ctx, e, []*query.SSFStream{stream}, &ssfEvent{
+			eventType: domain.SSFEventTypeStreamUpdated,
+			payload:   payload,
+		})
+	}), nil
+}
+
+// deliver pushes the events to all enabled streams of the instance, which requested them.
+func (t *ssfTransmitter) deliver(ctx context.Context, event eventstore.Event, events ...*ssfEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	streams, err := t.queries.ActiveSSFStreams(ctx)
+	if err != nil {
+		return err
+	}
+	if len(streams.Streams) == 0 {
+		return nil
+	}
+	return t.deliverToStreams(ctx, event, streams.Streams, events...)
+}
+
+// deliverToStreams pushes the events to the streams, which requested them.
+// If a push fails, the other streams are still served and an error is returned afterward,
+// so the handler retries the event. Security event tokens already delivered on a previous try are skipped.
+func (t *ssfTransmitter) deliverToStreams(ctx context.Context, event eventstore.Event, streams []*query.SSFStream, events ...*ssfEvent) error {
+	ctx, err := t.queries.InstanceOrigin(ctx)
+	if err != nil {
+		return err
+	}
+	pending := make([]*ssfDelivery, 0, len(streams)*len(events))
+	for _, stream := range streams {
+		for i, ssfEvent := range events {
+			if !ssfEventRequested(stream, ssfEvent.eventType) {
+				continue
+			}
+			pending = append(pending, &ssfDelivery{
+				jwtID:  ssfJWTID(stream.ID, event, i),
+				stream: stream,
+				event:  ssfEvent,
+			})
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	delivered, err := t.queries.deliveredSETs(ctx, event, streams)
+	if err != nil {
+		return err
+	}
+	issuer := http_utils.DomainContext(ctx).Origin()
+	getSigner := zoidc.GetSignerOnce(t.queries.GetActiveSigningWebKey, t.signingKey)
+	var failed int
+	for _, delivery := range pending {
+		if delivered[delivery.jwtID] {
+			continue
+		}
+		ok, err := t.push(ctx, event, issuer, delivery, getSigner)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return zerrors.ThrowUnavailablef(nil, "HANDL-Sf7r8", "%d security event tokens not delivered", failed)
+	}
+	return nil
+}
+
+// ssfDelivery is a security event token which is pushed to a stream.
+type ssfDelivery struct {
+	jwtID  string
+	stream *query.SSFStream
+	event  *ssfEvent
+}
+
+// ssfJWTID identifies the security event token of the stream by the event it was created for,
+// so the receiver is able to detect a token, which is pushed again.
+func ssfJWTID(streamID string, event eventstore.Event, index int) string {
+	return streamID + ":" + event.Aggregate().ID + ":" + strconv.FormatUint(event.Sequence(), 10) + ":" + strconv.Itoa(index)
+}
+
+// ssfEventRequested checks if the stream requested the event type.
+// Verification and stream updated events are always delivered.
+func ssfEventRequested(stream *query.SSFStream, eventType string) bool {
+	if eventType == domain.SSFEventTypeVerification || eventType == domain.SSFEventTypeStreamUpdated {
+		return true
+	}
+	return slices.Contains(stream.EventsDelivered(), eventType)
+}
+
+// push signs and pushes the security event token to the receiver of the stream.
+// The outcome is tracked on the stream, delivered reports if the receiver accepted the token.
+func (t *ssfTransmitter) push(ctx context.Context, event eventstore.Event, issuer string, delivery *ssfDelivery, getSigner zoidc.SignerFunc) (delivered bool, err error) {
+	stream, ssfEvent := delivery.stream, delivery.event
+	subject := &ssfSubject{Format: "opaque", ID: stream.ID}
+	if ssfEvent.userID != "" {
+		subject = ssfUserSubject(issuer, ssfEvent.userID, ssfEvent.sessionID)
+	}
+	token, err := t.securityEventToken(ctx, &securityEventToken{
+		Issuer:    issuer,
+		Audience:  stream.Audience,
+		IssuedAt:  time.Now().Unix(),
+		JWTID:     delivery.jwtID,
+		SubjectID: subject,
+		Events:    map[string]any{ssfEvent.eventType: ssfEvent.payload},
+	}, getSigner)
+	if err != nil {
+		return false, err
+	}
+	err = types.PushSecurityEventToken(ctx, set.Config{CallURL: stream.EndpointURL, AuthorizationHeader: stream.AuthorizationHeader}, t.channels, token, event).WithoutTemplate()
+	if err != nil {
+		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID(), "streamID", stream.ID, "eventType", ssfEvent.eventType).
+			WithError(err).Warn("security event token not delivered")
+		return false, t.commands.SSFSETFailed(ctx, stream.ID, delivery.jwtID, ssfEvent.eventType, ssfEvent.userID, err.Error())
+	}
+	return true, t.commands.SSFSETDelivered(ctx, stream.ID, delivery.jwtID, ssfEvent.eventType, ssfEvent.userID)
+}
+
+func (t *ssfTransmitter) securityEventToken(ctx context.Context, claims *securityEventToken, getSigner zoidc.SignerFunc) (string, error) {
+	signer, _, err := getSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	return crypto.Sign(claims, signer)
+}
+
+func (t *ssfTransmitter) signingKey(ctx context.Context) (op.SigningKey, error) {
+	keys, err := t.queries.ActivePrivateSigningKey(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(keys.Keys) == 0 {
+		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID()).
+			Info("There's no active signing key and automatic rotation is not supported for security event tokens." +
+				"Please enable the webkey management feature on your instance")
+		return nil, zerrors.ThrowPreconditionFailed(nil, "HANDL-Sf7k4", "no active signing key")
+	}
+	return zoidc.PrivateKeyToSigningKey(zoidc.SelectSigningKey(keys.Keys), t.keyEncryptionAlg)
+}
+
+// ssfEventPayload returns the payload common to all CAEP events.
+func ssfEventPayload(event eventstore.Event) map[string]any {
+	return map[string]any{
+		"event_timestamp": event.CreatedAt().Unix(),
+	}
+}
+
+func ssfCredentialChangeType(event eventstore.Event) string {
+	switch event.Type() {
+	case user.HumanPasswordChangedType:
+		return "update"
+	case user.HumanMFAOTPRemovedType,
+		user.HumanU2FTokenRemovedType,
+		user.HumanPasswordlessTokenRemovedType,
+		user.HumanOTPSMSRemovedType:
+		return "delete"
+	default:
+		return "create"
+	}
+}
+
+// deliveredSETs reduces the security event tokens delivered to the streams since the event was created.
+type deliveredSETs struct {
+	event     eventstore.Event
+	streamIDs []string
+
+	jwtIDs map[string]bool
+}
+
+func (d *deliveredSETs) Reduce() error {
+	return nil
+}
+
+func (d *deliveredSETs) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if e, ok := event.(*ssf.SETDeliveredEvent); ok {
+			d.jwtIDs[e.JTI] = true
+		}
+	}
+}
+
+func (d *deliveredSETs) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(d.event.Aggregate().InstanceID).
+		CreationDateAfter(d.event.CreatedAt()).
+		AddQuery().
+		AggregateTypes(ssf.AggregateType).
+		AggregateIDs(d.streamIDs...).
+		EventTypes(ssf.SETDeliveredEventType).
+		Builder()
+}
+
+// deliveredSETs returns the IDs of the security event tokens, which were already delivered to the streams for the event.
+func (n *NotificationQueries) deliveredSETs(ctx context.Context, event eventstore.Event, streams []*query.SSFStream) (map[string]bool, error) {
+	delivered := &deliveredSETs{
+		event:     event,
+		streamIDs: make([]string, len(streams)),
+		jwtIDs:    make(map[string]bool),
+	}
+	for i, stream := range streams {
+		delivered.streamIDs[i] = stream.ID
+	}
+	if err := n.es.FilterToQueryReducer(ctx, delivered); err != nil {
+		return nil, err
+	}
+	return delivered.jwtIDs, nil
+}
+
+var secondFactorRemovedTypes = []eventstore.EventType{
+	user.HumanMFAOTPRemovedType,
+	user.UserV1MFAOTPRemovedType,
+	user.HumanU2FTokenRemovedType,
+	user.HumanPasswordlessTokenRemovedType,
+	user.HumanOTPSMSRemovedType,
+	user.HumanOTPEmailRemovedType,
+}
+
+// secondFactors reduces the multi-factor authentication methods of the user before and after the event.
+type secondFactors struct {
+	event eventstore.Event
+
+	before map[string]bool
+	after  map[string]bool
+}
+
+func (s *secondFactors) Reduce() error {
+	return nil
+}
+
+func (s *secondFactors) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if event.Sequence() > s.event.Sequence() {
+			continue
+		}
+		key := secondFactorKey(event)
+		active := !slices.Contains(secondFactorRemovedTypes, event.Type())
+		s.after[key] = active
+		if event.Sequence() < s.event.Sequence() {
+			s.before[key] = active
+		}
+	}
+}
+
+func (s *secondFactors) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(s.event.Aggregate().InstanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(s.event.Aggregate().ID).
+		EventTypes(ssfSecondFactorTypes...).
+		Builder()
+}
+
+// secondFactorKey identifies the multi-factor authentication method the event belongs to.
+func secondFactorKey(event eventstore.Event) string {
+	switch e := event.(type) {
+	case *user.HumanU2FVerifiedEvent:
+		return "u2f:" + e.WebAuthNTokenID
+	case *user.HumanU2FRemovedEvent:
+		return "u2f:" + e.WebAuthNTokenID
+	case *user.HumanPasswordlessVerifiedEvent:
+		return "passwordless:" + e.WebAuthNTokenID
+	case *user.HumanPasswordlessRemovedEvent:
+		return "passwordless:" + e.WebAuthNTokenID
+	}
+	switch event.Type() {
+	case user.HumanOTPSMSAddedType, user.HumanOTPSMSRemovedType:
+		return "otp_sms"
+	case user.HumanOTPEmailAddedType, user.HumanOTPEmailRemovedType:
+		return "otp_email"
+	default:
+		return "otp"
+	}
+}
+
+func hasSecondFactor(factors map[string]bool) bool {
+	for _, active := range factors {
+		if active {
+			return true
+		}
+	}
+	return false
+}
+
+// assuranceLevelChange returns the payload of an assurance level change event,
+// if the event changed the NIST authenticator assurance level of the user.
+// Users with any multi-factor authentication method are considered as AAL2, all others as AAL1.
+func (n *NotificationQueries) assuranceLevelChange(ctx context.Context, event eventstore.Event) (map[string]any, error) {
+	factors := &secondFactors{
+		event:  event,
+		before: make(map[string]bool),
+		after:  make(map[string]bool),
+	}
+	if err := n.es.FilterToQueryReducer(ctx, factors); err != nil {
+		return nil, err
+	}
+	before, after := hasSecondFactor(factors.before), hasSecondFactor(factors.after)
+	if before == after {
+		return nil, nil
+	}
+	payload := ssfEventPayload(event)
+	payload["namespace"] = "NIST-AAL"
+	payload["previous_level"], payload["current_level"], payload["change_direction"] = "nist-aal1", "nist-aal2", "increase"
+	if before {
+		payload["previous_level"], payload["current_level"], payload["change_direction"] = "nist-aal2", "nist-aal1", "decrease"
+	}
+	return payload, nil
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	es_repo_mock "github.com/zitadel/zitadel/internal/eventstore/repository/mock"
+	"github.com/zitadel/zitadel/internal/notification/handlers/mock"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_ssfEventRequested(t *testing.T) {
+	stream := &query.SSFStream{
+		EventsRequested: []string{domain.CAEPEventTypeSessionRevoked},
+	}
+	tests := []struct {
+		name      string
+		eventType string
+		want      bool
+	}{
+		{
+			name:      "requested",
+			eventType: domain.CAEPEventTypeSessionRevoked,
+			want:      true,
+		},
+		{
+			name:      "not requested",
+			eventType: domain.RISCEventTypeAccountDisabled,
+			want:      false,
+		},
+		{
+			name:      "verification",
+			eventType: domain.SSFEventTypeVerification,
+			want:      true,
+		},
+		{
+			name:      "stream updated",
+			eventType: domain.SSFEventTypeStreamUpdated,
+			want:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ssfEventRequested(stream, tt.eventType))
+		})
+	}
+}
+
+func Test_ssfUserSubject(t *testing.T) {
+	tests := []struct {
+		name      string
+		sessionID string
+		want      *ssfSubject
+	}{
+		{
+			name: "user",
+			want: &ssfSubject{Format: "iss_sub", Issuer: eventOrigin, Subject: userID},
+		},
+		{
+			name:      "user session",
+			sessionID: sessionID,
+			want: &ssfSubject{
+				Format:  "complex",
+				User:    &ssfSubject{Format: "iss_sub", Issuer: eventOrigin, Subject: userID},
+				Session: &ssfSubject{Format: "opaque", ID: sessionID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ssfUserSubject(eventOrigin, userID, tt.sessionID))
+		})
+	}
+}
+
+func Test_ssfTransmitter_reduceAccountDisabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(queries *mock.MockQueries)
+	}{
+		{
+			name: "no active stream",
+			expect: func(queries *mock.MockQueries) {
+				queries.EXPECT().ActiveSSFStreams(gomock.Any()).Return(&query.SSFStreams{}, nil)
+			},
+		},
+		{
+			name: "event not requested",
+			expect: func(queries *mock.MockQueries) {
+				queries.EXPECT().ActiveSSFStreams(gomock.Any()).Return(&query.SSFStreams{
+					Streams: []*query.SSFStream{{
+						ObjectDetails:   domain.ObjectDetails{ID: "stream1"},
+						EventsRequested: []string{domain.CAEPEventTypeSessionRevoked},
+					}},
+				}, nil)
+				queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+					Domains: []*query.InstanceDomain{{
+						Domain:    instancePrimaryDomain,
+						IsPrimary: true,
+					}},
+				}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			// no command must be called, as nothing is delivered
+			commands := mock.NewMockCommands(ctrl)
+			queries.EXPECT().InstanceByID(gomock.Any(), instanceID).Return(authz.GetInstance(authz.WithInstanceID(context.Background(), instanceID)), nil)
+			tt.expect(queries)
+			transmitter := &ssfTransmitter{
+				commands: commands,
+				queries:  NewNotificationQueries(queries, nil, externalDomain, externalPort, externalSecure, "", nil, nil, nil),
+			}
+			stmt, err := transmitter.reduceAccountDisabled(&user.UserDeactivatedEvent{
+				BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+					InstanceID:    instanceID,
+					AggregateID:   userID,
+					AggregateType: user.AggregateType,
+					ResourceOwner: sql.NullString{String: orgID},
+					CreationDate:  time.Now().UTC(),
+					Typ:           user.UserDeactivatedType,
+				}),
+			})
+			require.NoError(t, err)
+			assert.NoError(t, stmt.Execute(nil, ""))
+		})
+	}
+}
+
+func Test_ssfTransmitter_reduceCredentialCompromise(t *testing.T) {
+	transmitter := &ssfTransmitter{}
+	t.Run("wrong event type", func(t *testing.T) {
+		_, err := transmitter.reduceCredentialCompromise(&user.HumanPasswordChangedEvent{
+			BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+				AggregateID:   userID,
+				AggregateType: user.AggregateType,
+				Typ:           user.HumanPasswordChangedType,
+			}),
+			ChangeRequired: true,
+		})
+		assert.ErrorIs(t, err, zerrors.ThrowInvalidArgument(nil, "HANDL-Sf7p2", ""))
+	})
+	t.Run("event not requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		queries := mock.NewMockQueries(ctrl)
+		// no command must be called, as nothing is delivered
+		commands := mock.NewMockCommands(ctrl)
+		queries.EXPECT().InstanceByID(gomock.Any(), instanceID).Return(authz.GetInstance(authz.WithInstanceID(context.Background(), instanceID)), nil)
+		queries.EXPECT().ActiveSSFStreams(gomock.Any()).Return(&query.SSFStreams{
+			Streams: []*query.SSFStream{{
+				ObjectDetails:   domain.ObjectDetails{ID: "stream1"},
+				EventsRequested: []string{domain.CAEPEventTypeCredentialChange},
+			}},
+		}, nil)
+		queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+			Domains: []*query.InstanceDomain{{
+				Domain:    instancePrimaryDomain,
+				IsPrimary: true,
+			}},
+		}, nil)
+		transmitter := &ssfTransmitter{
+			commands: commands,
+			queries:  NewNotificationQueries(queries, nil, externalDomain, externalPort, externalSecure, "", nil, nil, nil),
+		}
+		stmt, err := transmitter.reduceCredentialCompromise(&user.HumanPasswordCompromisedEvent{
+			BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+				InstanceID:    instanceID,
+				AggregateID:   userID,
+				AggregateType: user.AggregateType,
+				ResourceOwner: sql.NullString{String: orgID},
+				CreationDate:  time.Now().UTC(),
+				Typ:           user.HumanPasswordCompromisedType,
+			}),
+			Reason: "leaked",
+		})
+		require.NoError(t, err)
+		assert.NoError(t, stmt.Execute(nil, ""))
+	})
+}
+
+func Test_NotificationQueries_assuranceLevelChange(t *testing.T) {
+	otpEvent := func(typ eventstore.EventType, sequence uint64) eventstore.Event {
+		return eventstore.BaseEventFromRepo(&repository.Event{
+			InstanceID:    instanceID,
+			AggregateID:   userID,
+			AggregateType: user.AggregateType,
+			ResourceOwner: sql.NullString{String: orgID},
+			CreationDate:  time.Now().UTC(),
+			Typ:           typ,
+			Seq:           sequence,
+		})
+	}
+	tests := []struct {
+		name     string
+		existing []eventstore.Event
+		event    eventstore.Event
+		want     map[string]any
+	}{
+		{
+			name: "first factor added",
+			existing: []eventstore.Event{
+				otpEvent(user.HumanMFAOTPVerifiedType, 2),
+			},
+			event: otpEvent(user.HumanMFAOTPVerifiedType, 2),
+			want: map[string]any{
+				"namespace":        "NIST-AAL",
+				"previous_level":   "nist-aal1",
+				"current_level":    "nist-aal2",
+				"change_direction": "increase",
+			},
+		},
+		{
+			name: "additional factor added",
+			existing: []eventstore.Event{
+				otpEvent(user.HumanMFAOTPVerifiedType, 2),
+				otpEvent(user.HumanOTPSMSAddedType, 3),
+			},
+			event: otpEvent(user.HumanOTPSMSAddedType, 3),
+		},
+		{
+			name: "last factor removed",
+			existing: []eventstore.Event{
+				otpEvent(user.HumanMFAOTPVerifiedType, 2),
+				otpEvent(user.HumanMFAOTPRemovedType, 3),
+			},
+			event: otpEvent(user.HumanMFAOTPRemovedType, 3),
+			want: map[string]any{
+				"namespace":        "NIST-AAL",
+				"previous_level":   "nist-aal2",
+				"current_level":    "nist-aal1",
+				"change_direction": "decrease",
+			},
+		},
+		{
+			name: "later events ignored",
+			existing: []eventstore.Event{
+				otpEvent(user.HumanMFAOTPVerifiedType, 2),
+				otpEvent(user.HumanMFAOTPRemovedType, 3),
+			},
+			event: otpEvent(user.HumanMFAOTPVerifiedType, 2),
+			want: map[string]any{
+				"namespace":        "NIST-AAL",
+				"previous_level":   "nist-aal1",
+				"current_level":    "nist-aal2",
+				"change_direction": "increase",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := NewNotificationQueries(nil, eventstore.NewEventstore(&eventstore.Config{
+				Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents(tt.existing...).MockQuerier,
+			}), externalDomain, externalPort, externalSecure, "", nil, nil, nil)
+			got, err := queries.assuranceLevelChange(context.Background(), tt.event)
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			tt.want["event_timestamp"] = tt.event.CreatedAt().Unix()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_NotificationQueries_deliveredSETs(t *testing.T) {
+	event := eventstore.BaseEventFromRepo(&repository.Event{
+		InstanceID:    instanceID,
+		AggregateID:   userID,
+		AggregateType: user.AggregateType,
+		ResourceOwner: sql.NullString{String: orgID},
+		CreationDate:  time.Now().UTC(),
+		Typ:           user.UserDeactivatedType,
+		Seq:           3,
+	})
+	delivered := ssfJWTID("stream1", event, 0)
+	queries := NewNotificationQueries(nil, eventstore.NewEventstore(&eventstore.Config{
+		Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents(
+			eventstore.BaseEventFromRepo(&repository.Event{
+				InstanceID:    instanceID,
+				AggregateID:   "stream1",
+				AggregateType: ssf.AggregateType,
+				CreationDate:  time.Now().UTC(),
+				Typ:           ssf.SETDeliveredEventType,
+				Data:          []byte(`{"jti":"` + delivered + `","eventType":"` + domain.RISCEventTypeAccountDisabled + `"}`),
+			}),
+		).MockQuerier,
+	}), externalDomain, externalPort, externalSecure, "", nil, nil, nil)
+
+	got, err := queries.deliveredSETs(context.Background(), event, []*query.SSFStream{
+		{ObjectDetails: domain.ObjectDetails{ID: "stream1"}},
+		{ObjectDetails: domain.ObjectDetails{ID: "stream2"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{delivered: true}, got)
+	// the token of the other stream is still pushed on a retry
+	assert.False(t, got[ssfJWTID("stream2", event, 0)])
+}
@@ -74,6 +74,11 @@ func init() {
 			return commands.HumanPhoneVerificationCodeSent(ctx, orgID, id, generatorInfo)
 		},
 	)
+	RegisterSentHandler(user.HumanInactivityWarnedType,
+		func(ctx context.Context, commands Commands, id, orgID string, _ *senders.CodeGeneratorInfo, args map[string]any) error {
+			return commands.InactivityWarningSent(ctx, orgID, id)
+		},
+	)
 	RegisterSentHandler(user.HumanInviteCodeAddedType,
 		func(ctx context.Context, commands Commands, id, orgID string, _ *senders.CodeGeneratorInfo, args map[string]any) error {
 			return commands.InviteCodeSent(ctx, orgID, id)
@@ -163,6 +168,10 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanInactivityWarnedType,
+					Reduce: u.reduceInactivityWarned,
+				},
 				{
 					Event:  user.HumanOTPSMSCodeAddedType,
 					Reduce: u.reduceOTPSMSCodeAdded,
@@ -175,6 +184,70 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanInviteCodeAddedType,
 					Reduce: u.reduceInviteCodeAdded,
 				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPSMSAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPEmailAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPSMSRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPEmailRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
 			},
 		},
 		{
@@ -188,6 +261,18 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  session.OTPEmailChallengedType,
 					Reduce: u.reduceSessionOTPEmailChallenged,
 				},
+				{
+					Event:  session.PasswordCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
+				{
+					Event:  session.WebAuthNCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
+				{
+					Event:  session.IntentCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
 			},
 		},
 	}
@@ -680,6 +765,150 @@ func (u *userNotifier) reducePasswordChanged(event eventstore.Event) (*handler.S
 	}), nil
 }
 
+func (u *userNotifier) reduceInactivityWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanInactivityWarnedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ina1w", "reduce.wrong.event.type %s", user.HumanInactivityWarnedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, user.HumanInactivityWarningSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		origin := http_util.DomainContext(ctx).Origin()
+
+		return u.commands.RequestNotification(ctx,
+			e.Aggregate().ResourceOwner,
+			command.NewNotificationRequest(
+				e.Aggregate().ID,
+				e.Aggregate().ResourceOwner,
+				origin,
+				e.EventType,
+				domain.NotificationTypeEmail,
+				domain.InactivityWarningMessageType,
+			).
+				WithURLTemplate(console.LoginHintLink(origin, "{{.PreferredLoginName}}")).
+				WithArgs(&domain.NotificationArguments{
+					DeactivationDate: e.DeactivationDate.Format(time.DateOnly),
+				}).
+				WithUnverifiedChannel(),
+		)
+	}), nil
+}
+
+func (u *userNotifier) reduceSecurityNotification(event eventstore.Event) (*handler.Statement, error) {
+	messageType, ok := securityNotificationTypes[event.Type()]
+	if !ok || event.Aggregate().Type != user.AggregateType {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sn3t1", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"messageType": messageType}, user.HumanSecurityNotificationSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		notifyUser, args, err := u.queries.SecurityNotification(ctx, event, messageType,
+			event.Aggregate().ID, event.Aggregate().ResourceOwner, signInUserAgentID(event), "")
+		if err != nil || notifyUser == nil {
+			return err
+		}
+
+		ctx, err = u.queries.securityNotificationOrigin(ctx, event)
+		if err != nil {
+			return err
+		}
+		origin := http_util.DomainContext(ctx).Origin()
+
+		return u.commands.RequestNotification(ctx,
+			event.Aggregate().ResourceOwner,
+			command.NewNotificationRequest(
+				event.Aggregate().ID,
+				event.Aggregate().ResourceOwner,
+				origin,
+				event.Type(),
+				domain.NotificationTypeEmail,
+				messageType,
+			).
+				WithURLTemplate(console.LoginHintLink(origin, "{{.PreferredLoginName}}")).
+				WithArgs(args).
+				WithUnverifiedChannel(),
+		)
+	}), nil
+}
+
+func (u *userNotifier) reduceSessionSignIn(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *session.PasswordCheckedEvent, *session.WebAuthNCheckedEvent, *session.IntentCheckedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sn4s2", "reduce.wrong.event.type %v",
+			[]eventstore.EventType{session.PasswordCheckedType, session.WebAuthNCheckedType, session.IntentCheckedType})
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		signIn, err := u.queries.SignInSession(ctx, event)
+		if err != nil {
+			return err
+		}
+		if !signIn.FirstFactor || signIn.UserID == "" {
+			return nil
+		}
+		// the device is recorded on the user, so later sign-ins from it are recognized without searching the sessions of the user
+		if err = u.commands.SignInDeviceAdded(ctx, signIn.UserResourceOwner, signIn.UserID, signIn.FingerprintID); err != nil {
+			return err
+		}
+		alreadyHandled, err := u.queries.IsSecurityNotificationSent(ctx, event.Aggregate().InstanceID, signIn.UserID, domain.NewDeviceSignInMessageType, event.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		notifyUser, args, err := u.queries.SecurityNotification(ctx, event, domain.NewDeviceSignInMessageType,
+			signIn.UserID, signIn.UserResourceOwner, signIn.FingerprintID, event.Aggregate().ID)
+		if err != nil || notifyUser == nil {
+			return err
+		}
+
+		ctx, err = u.queries.securityNotificationOrigin(ctx, event)
+		if err != nil {
+			return err
+		}
+		origin := http_util.DomainContext(ctx).Origin()
+
+		return u.commands.RequestNotification(ctx,
+			signIn.UserResourceOwner,
+			command.NewNotificationRequest(
+				signIn.UserID,
+				signIn.UserResourceOwner,
+				origin,
+				event.Type(),
+				domain.NotificationTypeEmail,
+				domain.NewDeviceSignInMessageType,
+			).
+				WithURLTemplate(console.LoginHintLink(origin, "{{.PreferredLoginName}}")).
+				WithArgs(args).
+				WithUnverifiedChannel(),
+		)
+	}), nil
+}
+
 func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
 	if !ok {
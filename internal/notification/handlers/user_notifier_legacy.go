@@ -95,6 +95,10 @@ func (u *userNotifierLegacy) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanPasswordChangedType,
 					Reduce: u.reducePasswordChanged,
 				},
+				{
+					Event:  user.HumanInactivityWarnedType,
+					Reduce: u.reduceInactivityWarned,
+				},
 				{
 					Event:  user.HumanOTPSMSCodeAddedType,
 					Reduce: u.reduceOTPSMSCodeAdded,
@@ -107,6 +111,70 @@ func (u *userNotifierLegacy) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanInviteCodeAddedType,
 					Reduce: u.reduceInviteCodeAdded,
 				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanMFAOTPVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanU2FTokenVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenVerifiedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPSMSAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPEmailAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanMFAOTPRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanU2FTokenRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPSMSRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanOTPEmailRemovedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.UserLockedType,
+					Reduce: u.reduceSecurityNotification,
+				},
+				{
+					Event:  user.PersonalAccessTokenAddedType,
+					Reduce: u.reduceSecurityNotification,
+				},
 			},
 		},
 		{
@@ -120,6 +188,18 @@ func (u *userNotifierLegacy) Reducers() []handler.AggregateReducer {
 					Event:  session.OTPEmailChallengedType,
 					Reduce: u.reduceSessionOTPEmailChallenged,
 				},
+				{
+					Event:  session.PasswordCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
+				{
+					Event:  session.WebAuthNCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
+				{
+					Event:  session.IntentCheckedType,
+					Reduce: u.reduceSessionSignIn,
+				},
 			},
 		},
 	}
@@ -164,12 +244,16 @@ func (u *userNotifierLegacy) reduceInitCodeAdded(event eventstore.Event) (*handl
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.InitCodeMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
 			SendUserInitCode(ctx, notifyUser, code, e.AuthRequestID)
 		if err != nil {
 			return err
@@ -221,12 +305,16 @@ func (u *userNotifierLegacy) reduceEmailCodeAdded(event eventstore.Event) (*hand
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.VerifyEmailMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
 			SendEmailVerificationCode(ctx, notifyUser, code, e.URLTemplate, e.AuthRequestID)
 		if err != nil {
 			return err
@@ -280,15 +368,19 @@ func (u *userNotifierLegacy) reducePasswordCodeAdded(event eventstore.Event) (*h
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.PasswordResetMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
 		generatorInfo := new(senders.CodeGeneratorInfo)
-		notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e)
+		notify := types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e)
 		if e.NotificationType == domain.NotificationTypeSms {
-			notify = types.SendSMS(ctx, u.channels, translator, notifyUser, colors, e, generatorInfo)
+			notify = types.SendSMS(ctx, u.channels, customTemplates, translator, notifyUser, colors, e, generatorInfo)
 		}
 		err = notify.SendPasswordCode(ctx, notifyUser, code, e.URLTemplate, e.AuthRequestID)
 		if err != nil {
@@ -377,12 +469,16 @@ func (u *userNotifierLegacy) reduceOTPSMS(
 	if err != nil {
 		return nil, err
 	}
+	customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
 	ctx, err = u.queries.Origin(ctx, event)
 	if err != nil {
 		return nil, err
 	}
 	generatorInfo := new(senders.CodeGeneratorInfo)
-	notify := types.SendSMS(ctx, u.channels, translator, notifyUser, colors, event, generatorInfo)
+	notify := types.SendSMS(ctx, u.channels, customTemplates, translator, notifyUser, colors, event, generatorInfo)
 	err = notify.SendOTPSMSCode(ctx, plainCode, expiry)
 	if err != nil {
 		return nil, err
@@ -496,6 +592,10 @@ func (u *userNotifierLegacy) reduceOTPEmail(
 	if err != nil {
 		return nil, err
 	}
+	customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, resourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return nil, err
+	}
 	ctx, err = u.queries.Origin(ctx, event)
 	if err != nil {
 		return nil, err
@@ -504,7 +604,7 @@ func (u *userNotifierLegacy) reduceOTPEmail(
 	if err != nil {
 		return nil, err
 	}
-	notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, event)
+	notify := types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, event)
 	err = notify.SendOTPEmailCode(ctx, url, plainCode, expiry)
 	if err != nil {
 		return nil, err
@@ -549,12 +649,16 @@ func (u *userNotifierLegacy) reduceDomainClaimed(event eventstore.Event) (*handl
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.DomainClaimedMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
 			SendDomainClaimed(ctx, notifyUser, e.UserName)
 		if err != nil {
 			return err
@@ -603,12 +707,16 @@ func (u *userNotifierLegacy) reducePasswordlessCodeRequested(event eventstore.Ev
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.PasswordlessRegistrationMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
 			SendPasswordlessRegistrationLink(ctx, notifyUser, code, e.ID, e.URLTemplate)
 		if err != nil {
 			return err
@@ -663,11 +771,15 @@ func (u *userNotifierLegacy) reducePasswordChanged(event eventstore.Event) (*han
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.PasswordChangeMessageType)
+		if err != nil {
+			return err
+		}
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
 			SendPasswordChange(ctx, notifyUser)
 		if err != nil {
 			return err
@@ -676,6 +788,158 @@ func (u *userNotifierLegacy) reducePasswordChanged(event eventstore.Event) (*han
 	}), nil
 }
 
+func (u *userNotifierLegacy) reduceInactivityWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanInactivityWarnedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ina2w", "reduce.wrong.event.type %s", user.HumanInactivityWarnedType)
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, user.HumanInactivityWarningSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		template, err := u.queries.MailTemplateByOrg(ctx, e.Aggregate().ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.InactivityWarningMessageType)
+		if err != nil {
+			return err
+		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.InactivityWarningMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e).
+			SendInactivityWarning(ctx, notifyUser, e.DeactivationDate)
+		if err != nil {
+			return err
+		}
+		return u.commands.InactivityWarningSent(ctx, e.Aggregate().ResourceOwner, e.Aggregate().ID)
+	}), nil
+}
+
+func (u *userNotifierLegacy) reduceSecurityNotification(event eventstore.Event) (*handler.Statement, error) {
+	messageType, ok := securityNotificationTypes[event.Type()]
+	if !ok || event.Aggregate().Type != user.AggregateType {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sn5t3", "reduce.wrong.event.type %s", event.Type())
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, map[string]interface{}{"messageType": messageType}, user.HumanSecurityNotificationSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		notifyUser, args, err := u.queries.SecurityNotification(ctx, event, messageType,
+			event.Aggregate().ID, event.Aggregate().ResourceOwner, signInUserAgentID(event), "")
+		if err != nil || notifyUser == nil {
+			return err
+		}
+		return u.sendSecurityNotification(ctx, event, notifyUser, messageType, args)
+	}), nil
+}
+
+func (u *userNotifierLegacy) reduceSessionSignIn(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *session.PasswordCheckedEvent, *session.WebAuthNCheckedEvent, *session.IntentCheckedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Sn6s4", "reduce.wrong.event.type %v",
+			[]eventstore.EventType{session.PasswordCheckedType, session.WebAuthNCheckedType, session.IntentCheckedType})
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		signIn, err := u.queries.SignInSession(ctx, event)
+		if err != nil {
+			return err
+		}
+		if !signIn.FirstFactor || signIn.UserID == "" {
+			return nil
+		}
+		// the device is recorded on the user, so later sign-ins from it are recognized without searching the sessions of the user
+		if err = u.commands.SignInDeviceAdded(ctx, signIn.UserResourceOwner, signIn.UserID, signIn.FingerprintID); err != nil {
+			return err
+		}
+		alreadyHandled, err := u.queries.IsSecurityNotificationSent(ctx, event.Aggregate().InstanceID, signIn.UserID, domain.NewDeviceSignInMessageType, event.Aggregate().ID)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+
+		notifyUser, args, err := u.queries.SecurityNotification(ctx, event, domain.NewDeviceSignInMessageType,
+			signIn.UserID, signIn.UserResourceOwner, signIn.FingerprintID, event.Aggregate().ID)
+		if err != nil || notifyUser == nil {
+			return err
+		}
+		return u.sendSecurityNotification(ctx, event, notifyUser, domain.NewDeviceSignInMessageType, args)
+	}), nil
+}
+
+func (u *userNotifierLegacy) sendSecurityNotification(ctx context.Context, event eventstore.Event, notifyUser *query.NotifyUser, messageType string, args *domain.NotificationArguments) error {
+	colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	template, err := u.queries.MailTemplateByOrg(ctx, notifyUser.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+
+	translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return err
+	}
+	customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, messageType)
+	if err != nil {
+		return err
+	}
+	ctx, err = u.queries.securityNotificationOrigin(ctx, event)
+	if err != nil {
+		return err
+	}
+	recipient := notifyUser
+	if args.PreviousEmail != "" {
+		previousEmailUser := *notifyUser
+		previousEmailUser.LastEmail = args.PreviousEmail
+		previousEmailUser.VerifiedEmail = args.PreviousEmail
+		recipient = &previousEmailUser
+	}
+	err = types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, recipient, colors, event).
+		SendSecurityNotification(ctx, recipient, messageType, args)
+	if err != nil {
+		return err
+	}
+	return u.commands.SecurityNotificationSent(ctx, notifyUser.ResourceOwner, notifyUser.ID, messageType, args.SessionID)
+}
+
 func (u *userNotifierLegacy) reducePhoneCodeAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanPhoneCodeAddedEvent)
 	if !ok {
@@ -716,13 +980,17 @@ func (u *userNotifierLegacy) reducePhoneCodeAdded(event eventstore.Event) (*hand
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.VerifyPhoneMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
 		generatorInfo := new(senders.CodeGeneratorInfo)
-		if err = types.SendSMS(ctx, u.channels, translator, notifyUser, colors, e, generatorInfo).
+		if err = types.SendSMS(ctx, u.channels, customTemplates, translator, notifyUser, colors, e, generatorInfo).
 			SendPhoneVerificationCode(ctx, code); err != nil {
 			return err
 		}
@@ -771,12 +1039,16 @@ func (u *userNotifierLegacy) reduceInviteCodeAdded(event eventstore.Event) (*han
 		if err != nil {
 			return err
 		}
+		customTemplates, err := u.queries.NotificationTemplatesByOrg(ctx, notifyUser.ResourceOwner, domain.InviteUserMessageType)
+		if err != nil {
+			return err
+		}
 
 		ctx, err = u.queries.Origin(ctx, e)
 		if err != nil {
 			return err
 		}
-		notify := types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e)
+		notify := types.SendEmail(ctx, u.channels, string(template.Template), customTemplates, translator, notifyUser, colors, e)
 		err = notify.SendInviteCode(ctx, notifyUser, code, e.ApplicationName, e.URLTemplate, e.AuthRequestID)
 		if err != nil {
 			return err
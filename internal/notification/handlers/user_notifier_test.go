@@ -971,6 +971,296 @@ func Test_userNotifier_reducePasswordChanged(t *testing.T) {
 	}
 }
 
+func Test_userNotifier_reduceInactivityWarned(t *testing.T) {
+	deactivationDate := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{
+		{
+			name: "with event trigger",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				commands.EXPECT().RequestNotification(gomock.Any(), orgID, &command.NotificationRequest{
+					UserID:                        userID,
+					UserResourceOwner:             orgID,
+					TriggerOrigin:                 eventOrigin,
+					URLTemplate:                   fmt.Sprintf("%s/ui/console?login_hint={{.PreferredLoginName}}", eventOrigin),
+					EventType:                     user.HumanInactivityWarnedType,
+					NotificationType:              domain.NotificationTypeEmail,
+					MessageType:                   domain.InactivityWarningMessageType,
+					UnverifiedNotificationChannel: true,
+					Args: &domain.NotificationArguments{
+						DeactivationDate: "2024-04-01",
+					},
+				}).Return(nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.HumanInactivityWarnedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.HumanInactivityWarnedType,
+							}),
+							DeactivationDate:  deactivationDate,
+							TriggeredAtOrigin: eventOrigin,
+						},
+					}, w
+			},
+		},
+		{
+			name: "without event trigger",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+					Domains: []*query.InstanceDomain{{
+						Domain:    instancePrimaryDomain,
+						IsPrimary: true,
+					}},
+				}, nil)
+				commands.EXPECT().RequestNotification(gomock.Any(), orgID, &command.NotificationRequest{
+					UserID:            userID,
+					UserResourceOwner: orgID,
+					TriggerOrigin:     fmt.Sprintf("%s://%s:%d", externalProtocol, instancePrimaryDomain, externalPort),
+					URLTemplate: fmt.Sprintf("%s://%s:%d/ui/console?login_hint={{.PreferredLoginName}}",
+						externalProtocol, instancePrimaryDomain, externalPort),
+					EventType:                     user.HumanInactivityWarnedType,
+					NotificationType:              domain.NotificationTypeEmail,
+					MessageType:                   domain.InactivityWarningMessageType,
+					UnverifiedNotificationChannel: true,
+					Args: &domain.NotificationArguments{
+						DeactivationDate: "2024-04-01",
+					},
+				}).Return(nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.HumanInactivityWarnedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.HumanInactivityWarnedType,
+							}),
+							DeactivationDate: deactivationDate,
+						},
+					}, w
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceInactivityWarned(a.event)
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			err = stmt.Execute(nil, "")
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_userNotifier_reduceSecurityNotification(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(*gomock.Controller, *mock.MockQueries, *mock.MockCommands) (fields, args, want)
+	}{
+		{
+			name: "account locked",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+					AccountLocked: true,
+				}, nil)
+				queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), userID).Return(&query.NotifyUser{
+					ID:        userID,
+					LastEmail: lastEmail,
+				}, nil)
+				queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+					Domains: []*query.InstanceDomain{{
+						Domain:    instancePrimaryDomain,
+						IsPrimary: true,
+					}},
+				}, nil)
+				commands.EXPECT().RequestNotification(gomock.Any(), orgID, &command.NotificationRequest{
+					UserID:            userID,
+					UserResourceOwner: orgID,
+					TriggerOrigin:     fmt.Sprintf("%s://%s:%d", externalProtocol, instancePrimaryDomain, externalPort),
+					URLTemplate: fmt.Sprintf("%s://%s:%d/ui/console?login_hint={{.PreferredLoginName}}",
+						externalProtocol, instancePrimaryDomain, externalPort),
+					EventType:                     user.UserLockedType,
+					NotificationType:              domain.NotificationTypeEmail,
+					MessageType:                   domain.AccountLockedMessageType,
+					UnverifiedNotificationChannel: true,
+					Args:                          &domain.NotificationArguments{},
+				}).Return(nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.UserLockedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								AggregateType: user.AggregateType,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.UserLockedType,
+							}),
+						},
+					}, w
+			},
+		},
+		{
+			name: "mfa added",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+					MFAAdded: true,
+				}, nil)
+				queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), userID).Return(&query.NotifyUser{
+					ID:        userID,
+					LastEmail: lastEmail,
+				}, nil)
+				queries.EXPECT().SearchInstanceDomains(gomock.Any(), gomock.Any()).Return(&query.InstanceDomains{
+					Domains: []*query.InstanceDomain{{
+						Domain:    instancePrimaryDomain,
+						IsPrimary: true,
+					}},
+				}, nil)
+				commands.EXPECT().RequestNotification(gomock.Any(), orgID, &command.NotificationRequest{
+					UserID:            userID,
+					UserResourceOwner: orgID,
+					TriggerOrigin:     fmt.Sprintf("%s://%s:%d", externalProtocol, instancePrimaryDomain, externalPort),
+					URLTemplate: fmt.Sprintf("%s://%s:%d/ui/console?login_hint={{.PreferredLoginName}}",
+						externalProtocol, instancePrimaryDomain, externalPort),
+					EventType:                     user.HumanMFAOTPVerifiedType,
+					NotificationType:              domain.NotificationTypeEmail,
+					MessageType:                   domain.MFAAddedMessageType,
+					UnverifiedNotificationChannel: true,
+					Args: &domain.NotificationArguments{
+						AuthMethod: "TOTP",
+					},
+				}).Return(nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.HumanOTPVerifiedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								AggregateType: user.AggregateType,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.HumanMFAOTPVerifiedType,
+							}),
+						},
+					}, w
+			},
+		},
+		{
+			name: "no notification",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+					AccountLocked: false,
+				}, nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.UserLockedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								AggregateType: user.AggregateType,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.UserLockedType,
+							}),
+						},
+					}, w
+			},
+		},
+		{
+			name: "machine user",
+			test: func(ctrl *gomock.Controller, queries *mock.MockQueries, commands *mock.MockCommands) (f fields, a args, w want) {
+				queries.EXPECT().NotificationPolicyByOrg(gomock.Any(), gomock.Any(), orgID, gomock.Any()).Return(&query.NotificationPolicy{
+					AccountLocked: true,
+				}, nil)
+				queries.EXPECT().GetNotifyUserByID(gomock.Any(), gomock.Any(), userID).Return(&query.NotifyUser{
+					ID: userID,
+				}, nil)
+				return fields{
+						queries:  queries,
+						commands: commands,
+						es: eventstore.NewEventstore(&eventstore.Config{
+							Querier: es_repo_mock.NewRepo(t).ExpectFilterEvents().MockQuerier,
+						}),
+					}, args{
+						event: &user.UserLockedEvent{
+							BaseEvent: *eventstore.BaseEventFromRepo(&repository.Event{
+								InstanceID:    instanceID,
+								AggregateID:   userID,
+								AggregateType: user.AggregateType,
+								ResourceOwner: sql.NullString{String: orgID},
+								CreationDate:  time.Now().UTC(),
+								Typ:           user.UserLockedType,
+							}),
+						},
+					}, w
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			queries := mock.NewMockQueries(ctrl)
+			commands := mock.NewMockCommands(ctrl)
+			f, a, w := tt.test(ctrl, queries, commands)
+			stmt, err := newUserNotifier(t, ctrl, queries, f, a, w).reduceSecurityNotification(a.event)
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			err = stmt.Execute(nil, "")
+			if w.err != nil {
+				w.err(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_userNotifier_reduceOTPEmailChallenged(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1802,6 +2092,7 @@ func expectTemplateQueries(queries *mock.MockQueries, template string) {
 	queries.EXPECT().MailTemplateByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.MailTemplate{Template: []byte(template)}, nil)
 	queries.EXPECT().GetDefaultLanguage(gomock.Any()).Return(language.English)
 	queries.EXPECT().CustomTextListByTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&query.CustomTexts{}, nil)
+	queries.EXPECT().NotificationTemplatesByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.NotificationTemplates{}, nil)
 }
 
 func expectTemplateWithNotifyUserQueries(queries *mock.MockQueries, template string) {
@@ -1838,6 +2129,7 @@ func expectTemplateWithNotifyUserQueriesSMS(queries *mock.MockQueries) {
 	}, nil)
 	queries.EXPECT().GetDefaultLanguage(gomock.Any()).Return(language.English)
 	queries.EXPECT().CustomTextListByTemplate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(&query.CustomTexts{}, nil)
+	queries.EXPECT().NotificationTemplatesByOrg(gomock.Any(), gomock.Any(), gomock.Any()).Return(&query.NotificationTemplates{}, nil)
 }
 
 func cryptoValue(t *testing.T, ctrl *gomock.Controller, value string) (*crypto.MockEncryptionAlgorithm, *crypto.CryptoValue) {
@@ -1,8 +1,11 @@
 package messages
 
 import (
+	"bytes"
 	"fmt"
 	"mime"
+	"mime/multipart"
+	"net/textproto"
 	"regexp"
 	"strings"
 	"time"
@@ -19,15 +22,20 @@ var (
 var _ channels.Message = (*Email)(nil)
 
 type Email struct {
-	Recipients      []string
-	BCC             []string
-	CC              []string
-	SenderEmail     string
-	SenderName      string
-	ReplyToAddress  string
-	Subject         string
-	Content         string
+	Recipients     []string
+	BCC            []string
+	CC             []string
+	SenderEmail    string
+	SenderName     string
+	ReplyToAddress string
+	Subject        string
+	Content        string
+	// TextContent is sent as text/plain part, as alternative to the HTML of Content if both are set.
+	TextContent     string
 	TriggeringEvent eventstore.Event
+
+	// MessageID is set by the sender and used as Message-ID header
+	MessageID string
 }
 
 func (msg *Email) GetContent() (string, error) {
@@ -44,6 +52,9 @@ func (msg *Email) GetContent() (string, error) {
 	headers["To"] = strings.Join(msg.Recipients, ", ")
 	headers["Cc"] = strings.Join(msg.CC, ", ")
 	headers["Date"] = time.Now().Format(time.RFC1123Z)
+	if msg.MessageID != "" {
+		headers["Message-ID"] = msg.MessageID
+	}
 
 	message := ""
 	for k, v := range headers {
@@ -51,16 +62,56 @@ func (msg *Email) GetContent() (string, error) {
 	}
 
 	//default mime-type is html
-	mime := "MIME-Version: 1.0" + lineBreak + "Content-Type: text/html; charset=\"UTF-8\"" + lineBreak + lineBreak
-	if !isHTML(msg.Content) {
-		mime = "MIME-Version: 1.0" + lineBreak + "Content-Type: text/plain; charset=\"UTF-8\"" + lineBreak + lineBreak
+	content := msg.Content
+	contentType := htmlContentType
+	switch {
+	case msg.Content != "" && msg.TextContent != "":
+		var err error
+		content, contentType, err = msg.alternativeContent()
+		if err != nil {
+			return "", err
+		}
+	case msg.Content == "":
+		content = msg.TextContent
+		contentType = textContentType
+	case !isHTML(msg.Content):
+		contentType = textContentType
 	}
+	mime := "MIME-Version: 1.0" + lineBreak + "Content-Type: " + contentType + lineBreak + lineBreak
 	subject := "Subject: " + bEncodeWord(msg.Subject) + lineBreak
-	message += subject + mime + lineBreak + msg.Content
+	message += subject + mime + lineBreak + content
 
 	return message, nil
 }
 
+const (
+	htmlContentType = "text/html; charset=\"UTF-8\""
+	textContentType = "text/plain; charset=\"UTF-8\""
+)
+
+// alternativeContent returns the text and the HTML as parts of a multipart/alternative body,
+// the HTML is the last part, so it is preferred by the mail clients.
+func (msg *Email) alternativeContent() (content, contentType string, err error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{contentType: textContentType, content: msg.TextContent},
+		{contentType: htmlContentType, content: msg.Content},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return "", "", err
+		}
+		if _, err = w.Write([]byte(part.content)); err != nil {
+			return "", "", err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return "", "", err
+	}
+	return body.String(), "multipart/alternative; boundary=" + writer.Boundary(), nil
+}
+
 func (msg *Email) GetTriggeringEvent() eventstore.Event {
 	return msg.TriggeringEvent
 }
@@ -0,0 +1,94 @@
+package messages
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail_GetContent(t *testing.T) {
+	tests := []struct {
+		name            string
+		email           *Email
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name: "html",
+			email: &Email{
+				Content: "<html><body>Hi <b>Gigi</b></body></html>",
+			},
+			wantContentType: "text/html",
+			wantBody:        "<html><body>Hi <b>Gigi</b></body></html>",
+		},
+		{
+			name: "plain content",
+			email: &Email{
+				Content: "Hi Gigi",
+			},
+			wantContentType: "text/plain",
+			wantBody:        "Hi Gigi",
+		},
+		{
+			name: "text only",
+			email: &Email{
+				TextContent: "Hi <b>Gigi</b>",
+			},
+			wantContentType: "text/plain",
+			wantBody:        "Hi <b>Gigi</b>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.email.GetContent()
+			require.NoError(t, err)
+			headers, body, _ := strings.Cut(got, lineBreak+lineBreak+lineBreak)
+			mediaType, _, err := mime.ParseMediaType(header(headers, "Content-Type"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContentType, mediaType)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestEmail_GetContent_alternative(t *testing.T) {
+	email := &Email{
+		Content:     "<html><body>Hi <b>Gigi</b></body></html>",
+		TextContent: "Hi Gigi",
+	}
+	got, err := email.GetContent()
+	require.NoError(t, err)
+	headers, body, _ := strings.Cut(got, lineBreak+lineBreak+lineBreak)
+	mediaType, params, err := mime.ParseMediaType(header(headers, "Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	for _, want := range []struct{ contentType, content string }{
+		{contentType: textContentType, content: "Hi Gigi"},
+		{contentType: htmlContentType, content: "<html><body>Hi <b>Gigi</b></body></html>"},
+	} {
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, want.contentType, part.Header.Get("Content-Type"))
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Equal(t, want.content, string(content))
+	}
+	_, err = reader.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func header(headers, name string) string {
+	for _, line := range strings.Split(headers, lineBreak) {
+		if value, ok := strings.CutPrefix(line, name+": "); ok {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,23 @@
+package messages
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+)
+
+var _ channels.Message = (*SecurityEventToken)(nil)
+
+// SecurityEventToken is a signed security event token (RFC 8417),
+// which is pushed as is to the receiver (RFC 8935).
+type SecurityEventToken struct {
+	Token           string
+	TriggeringEvent eventstore.Event
+}
+
+func (msg *SecurityEventToken) GetContent() (string, error) {
+	return msg.Token, nil
+}
+
+func (msg *SecurityEventToken) GetTriggeringEvent() eventstore.Event {
+	return msg.TriggeringEvent
+}
@@ -15,6 +15,8 @@ type SMS struct {
 
 	// VerificationID is set by the sender
 	VerificationID *string
+	// MessageID is set by the sender
+	MessageID string
 }
 
 func (msg *SMS) GetContent() (string, error) {
@@ -40,6 +40,8 @@ func Register(
 	c := newChannels(q)
 	projections = append(projections, handlers.NewUserNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), commands, q, c, otpEmailTmpl, notificationWorkerConfig.LegacyEnabled))
 	projections = append(projections, handlers.NewQuotaNotifier(ctx, projection.ApplyCustomConfig(quotaHandlerCustomConfig), commands, q, c))
+	projections = append(projections, handlers.NewGrantNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), q, c))
+	projections = append(projections, handlers.NewAccessRequestNotifier(ctx, projection.ApplyCustomConfig(userHandlerCustomConfig), q, c))
 	projections = append(projections, handlers.NewBackChannelLogoutNotifier(
 		ctx,
 		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
@@ -50,6 +52,14 @@ func Register(
 		c,
 		tokenLifetime,
 	))
+	projections = append(projections, handlers.NewSSFTransmitter(
+		ctx,
+		projection.ApplyCustomConfig(backChannelLogoutHandlerCustomConfig),
+		commands,
+		q,
+		keysEncryptionAlg,
+		c,
+	))
 	if telemetryCfg.Enabled {
 		projections = append(projections, handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c))
 	}
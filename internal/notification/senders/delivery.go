@@ -0,0 +1,36 @@
+package senders
+
+import "context"
+
+const (
+	ProviderTypeSMTP    = "smtp"
+	ProviderTypeTwilio  = "twilio"
+	ProviderTypeWebhook = "webhook"
+)
+
+// DeliveryInfo is set while sending a notification and describes how it was handed over to the provider.
+type DeliveryInfo struct {
+	ProviderID   string `json:"providerId,omitempty"`
+	ProviderType string `json:"providerType,omitempty"`
+	// Recipient is the unmasked email address or phone number
+	Recipient string `json:"recipient,omitempty"`
+	// MessageID is the id the provider uses to report the delivery status of the message
+	MessageID string `json:"messageId,omitempty"`
+}
+
+type deliveryInfoKey struct{}
+
+// WithDeliveryInfo returns a context which collects the delivery info of the notification sent with it.
+func WithDeliveryInfo(ctx context.Context) (context.Context, *DeliveryInfo) {
+	info := new(DeliveryInfo)
+	return context.WithValue(ctx, deliveryInfoKey{}, info), info
+}
+
+// DeliveryInfoFromContext returns the delivery info to be set by the sender,
+// if no info is collected, the returned info is discarded.
+func DeliveryInfoFromContext(ctx context.Context) *DeliveryInfo {
+	if info, ok := ctx.Value(deliveryInfoKey{}).(*DeliveryInfo); ok {
+		return info
+	}
+	return new(DeliveryInfo)
+}
@@ -11,12 +11,9 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/sms"
-	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 )
 
-const twilioSpanName = "twilio.NotificationChannel"
-
 func SMSChannels(
 	ctx context.Context,
 	smsConfig *sms.Config,
@@ -26,13 +23,13 @@ func SMSChannels(
 	failureMetricName string,
 ) (chain *Chain, err error) {
 	channels := make([]channels.NotificationChannel, 0, 3)
-	if smsConfig.TwilioConfig != nil {
+	if native := smsConfig.Native(); native != nil {
 		channels = append(
 			channels,
 			instrumenting.Wrap(
 				ctx,
-				twilio.InitChannel(*smsConfig.TwilioConfig),
-				twilioSpanName,
+				native.Channel(),
+				native.Type()+".NotificationChannel",
 				successMetricName,
 				failureMetricName,
 			),
@@ -0,0 +1,325 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	html_template "html/template"
+	"io"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	text_template "text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// maxRenderedLength limits the output of a custom template,
+	// so a template cannot produce messages of arbitrary size.
+	maxRenderedLength = 1024 * 1024
+	// maxRenderDuration limits the time a custom template is executed,
+	// so a template cannot stall the request or the notification worker rendering it.
+	maxRenderDuration = time.Second
+	// maxRangeDepth limits the nesting of range actions,
+	// so the iterations of a template stay in the order of the size of the data.
+	maxRangeDepth = 2
+)
+
+var (
+	errCallNotAllowed     = errors.New("call is not allowed in notification templates")
+	errOutputTooLarge     = errors.New("rendered notification template exceeds the maximum size")
+	errRenderTimeout      = errors.New("rendering the notification template took too long")
+	errTemplateNotAllowed = errors.New("define and template actions are not allowed in notification templates")
+	errRangeNotAllowed    = errors.New("range is only allowed over fields of the data in notification templates")
+	errRangeTooDeep       = errors.New("range actions are nested too deep in notification template")
+	customTemplateFunc    = map[string]any{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"replace": func(old, new, s string) string {
+			return strings.ReplaceAll(s, old, new)
+		},
+		"contains": func(substr, s string) bool {
+			return strings.Contains(s, substr)
+		},
+		"truncate": func(length int, s string) string {
+			if utf8.RuneCountInString(s) <= length {
+				return s
+			}
+			return string([]rune(s)[:length])
+		},
+		"default": func(fallback, value any) any {
+			if value == nil || value == "" {
+				return fallback
+			}
+			return value
+		},
+		// call would execute functions passed as data, which is not allowed in the sandbox
+		"call": func(any, ...any) (any, error) {
+			return nil, errCallNotAllowed
+		},
+	}
+)
+
+// CustomTemplateData is the data model of custom notification templates.
+// The fields of TemplateData are accessible directly, e.g. {{.Title}}, {{.URL}} or {{.PrimaryColor}}.
+// The arguments of the message, e.g. {{.Args.Code}}, {{.Args.FirstName}} or {{.Args.PreferredLoginName}},
+// depend on the message type, see SampleArgs for the common ones.
+type CustomTemplateData struct {
+	TemplateData
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// CustomTemplate is the rendered result of a custom notification template.
+type CustomTemplate struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// SampleArgs returns the arguments used to validate and preview custom templates.
+func SampleArgs() map[string]any {
+	return map[string]any{
+		"UserID":             "69629023906488334",
+		"OrgID":              "69629026806489455",
+		"UserName":           "gigi",
+		"FirstName":          "Gigi",
+		"LastName":           "Giraffe",
+		"NickName":           "gigi",
+		"DisplayName":        "Gigi Giraffe",
+		"LastEmail":          "gigi@zitadel.com",
+		"VerifiedEmail":      "gigi@zitadel.com",
+		"LastPhone":          "+41 79 123 45 67",
+		"VerifiedPhone":      "+41 79 123 45 67",
+		"PreferredLoginName": "gigi@zitadel.com",
+		"LoginName":          "gigi@zitadel.com",
+		"LoginNames":         []string{"gigi@zitadel.com"},
+		"ChangeDate":         time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"CreationDate":       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"Code":               "ABC123",
+		"OTP":                "123456",
+		"Expiry":             "5m0s",
+		"Domain":             "zitadel.com",
+		"TempUsername":       "gigi@temporary.zitadel.com",
+		"ApplicationName":    "ZITADEL",
+	}
+}
+
+// SampleTemplateData returns the data used to preview custom templates of the message type.
+// The texts are translated into the language if a translator is passed.
+func SampleTemplateData(translator *i18n.Translator, messageType, lang string) CustomTemplateData {
+	data := CustomTemplateData{
+		TemplateData: TemplateData{
+			URL:             "https://zitadel.com/ui/login",
+			PrimaryColor:    DefaultPrimaryColor,
+			BackgroundColor: DefaultBackgroundColor,
+			FontColor:       DefaultFontColor,
+			FontFamily:      DefaultFontFamily,
+		},
+		Args: SampleArgs(),
+	}
+	if translator != nil {
+		data.Translate(translator, messageType, data.Args, lang)
+	}
+	return data
+}
+
+// ValidateCustomTemplate parses the parts of a custom template and executes them with sample data,
+// so syntax errors and references to unknown fields are detected before the template is stored.
+func ValidateCustomTemplate(subject, html, text string) error {
+	_, err := RenderCustomTemplate(subject, html, text, SampleTemplateData(nil, "", ""))
+	return err
+}
+
+// RenderCustomTemplate executes the parts of a custom template which are set.
+// The HTML is rendered with contextual escaping, subject and text as plain text.
+// Actions which could run without bound, like range over integers, are rejected
+// and the execution is aborted after maxRenderDuration.
+func RenderCustomTemplate(subject, html, text string, data CustomTemplateData) (_ *CustomTemplate, err error) {
+	data.Args = integerArgsAsText(data.Args)
+	rendered := new(CustomTemplate)
+	if rendered.Subject, err = renderCustomText("subject", subject, data); err != nil {
+		return nil, err
+	}
+	if rendered.HTML, err = renderCustomHTML(html, data); err != nil {
+		return nil, err
+	}
+	if rendered.Text, err = renderCustomText("text", text, data); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func renderCustomHTML(tmpl string, data CustomTemplateData) (string, error) {
+	if tmpl == "" {
+		return "", nil
+	}
+	parsed, err := html_template.New("html").Funcs(customTemplateFunc).Parse(tmpl)
+	if err == nil && len(parsed.Templates()) > 1 {
+		err = errTemplateNotAllowed
+	}
+	if err == nil {
+		err = validateTree(parsed.Tree)
+	}
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "TEMPL-Ct1hP", "Errors.NotificationTemplate.Invalid")
+	}
+	rendered, err := execute(func(w io.Writer) error {
+		return parsed.Execute(w, data)
+	})
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "TEMPL-Ct2hE", "Errors.NotificationTemplate.Invalid")
+	}
+	return rendered, nil
+}
+
+func renderCustomText(name, tmpl string, data CustomTemplateData) (string, error) {
+	if tmpl == "" {
+		return "", nil
+	}
+	parsed, err := text_template.New(name).Funcs(customTemplateFunc).Parse(tmpl)
+	if err == nil && len(parsed.Templates()) > 1 {
+		err = errTemplateNotAllowed
+	}
+	if err == nil {
+		err = validateTree(parsed.Tree)
+	}
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "TEMPL-Ct3tP", "Errors.NotificationTemplate.Invalid")
+	}
+	rendered, err := execute(func(w io.Writer) error {
+		return parsed.Execute(w, data)
+	})
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "TEMPL-Ct4tE", "Errors.NotificationTemplate.Invalid")
+	}
+	return rendered, nil
+}
+
+// integerArgsAsText returns a copy of the arguments with the integers, e.g. the expiry of a code, formatted as text.
+// They are printed the same, but a template can't range over them.
+func integerArgsAsText(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	converted := make(map[string]any, len(args))
+	for key, arg := range args {
+		switch reflect.ValueOf(arg).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			converted[key] = fmt.Sprint(arg)
+		default:
+			converted[key] = arg
+		}
+	}
+	return converted
+}
+
+// execute runs the template with a limited output and aborts it after maxRenderDuration.
+// A template which is still running afterwards fails on its next write.
+func execute(run func(w io.Writer) error) (string, error) {
+	w := &limitedWriter{remaining: maxRenderedLength}
+	done := make(chan error, 1)
+	go func() {
+		done <- run(w)
+	}()
+	timer := time.NewTimer(maxRenderDuration)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return w.String(), nil
+	case <-timer.C:
+		w.canceled.Store(true)
+		return "", errRenderTimeout
+	}
+}
+
+// validateTree rejects the actions whose execution isn't bounded by the size of the data:
+// templates calling other templates, range over anything but the fields of the data, e.g. integer literals,
+// variables or the results of functions, and range actions nested deeper than maxRangeDepth.
+func validateTree(tree *parse.Tree) error {
+	if tree == nil {
+		return nil
+	}
+	return validateNode(tree.Root, true, 0)
+}
+
+// validateNode checks the node and its children, dotIsData is false if the dot was set by a with action to something else than data.
+func validateNode(node parse.Node, dotIsData bool, rangeDepth int) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateNode(child, dotIsData, rangeDepth); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return errTemplateNotAllowed
+	case *parse.IfNode:
+		return validateBranch(&n.BranchNode, dotIsData, dotIsData, rangeDepth, rangeDepth)
+	case *parse.WithNode:
+		return validateBranch(&n.BranchNode, isDataPipe(n.Pipe, dotIsData), dotIsData, rangeDepth, rangeDepth)
+	case *parse.RangeNode:
+		if rangeDepth >= maxRangeDepth {
+			return errRangeTooDeep
+		}
+		if !isDataPipe(n.Pipe, dotIsData) {
+			return errRangeNotAllowed
+		}
+		return validateBranch(&n.BranchNode, true, dotIsData, rangeDepth+1, rangeDepth)
+	}
+	return nil
+}
+
+func validateBranch(branch *parse.BranchNode, listDotIsData, elseDotIsData bool, listRangeDepth, elseRangeDepth int) error {
+	if err := validateNode(branch.List, listDotIsData, listRangeDepth); err != nil {
+		return err
+	}
+	return validateNode(branch.ElseList, elseDotIsData, elseRangeDepth)
+}
+
+// isDataPipe returns if the pipeline only selects a field of the data, e.g. {{.Args.LoginNames}}, {{$.Args}} or {{.}}.
+func isDataPipe(pipe *parse.PipeNode, dotIsData bool) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		return dotIsData
+	case *parse.DotNode:
+		return dotIsData
+	case *parse.VariableNode:
+		// only the root of the data, other variables can be assigned anything
+		return arg.Ident[0] == "$"
+	default:
+		return false
+	}
+}
+
+type limitedWriter struct {
+	bytes.Buffer
+	remaining int
+	canceled  atomic.Bool
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.canceled.Load() {
+		return 0, errRenderTimeout
+	}
+	if len(p) > w.remaining {
+		return 0, errOutputTooLarge
+	}
+	w.remaining -= len(p)
+	return w.Buffer.Write(p)
+}
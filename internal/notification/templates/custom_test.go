@@ -0,0 +1,196 @@
+package templates
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestRenderCustomTemplate(t *testing.T) {
+	data := CustomTemplateData{
+		TemplateData: TemplateData{
+			Title:        "Verify your email",
+			URL:          "https://example.com/verify?code=ABC",
+			PrimaryColor: "#ffffff",
+		},
+		Args: map[string]any{
+			"FirstName": "Gigi",
+			"Code":      "ABC123",
+			"Script":    "<script>alert(1)</script>",
+			"Names":     []string{"Gigi", "Giraffe"},
+			"Count":     1000000000,
+		},
+	}
+	type args struct {
+		subject string
+		html    string
+		text    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *CustomTemplate
+		wantErr func(error) bool
+	}{
+		{
+			name: "all parts",
+			args: args{
+				subject: "{{.Title}} for {{.Args.FirstName}}",
+				html:    `<a href="{{.URL}}" style="color: {{.PrimaryColor}}">{{upper .Args.Code}}</a>`,
+				text:    "Hi {{.Args.FirstName}}, your code is {{.Args.Code}}",
+			},
+			want: &CustomTemplate{
+				Subject: "Verify your email for Gigi",
+				HTML:    `<a href="https://example.com/verify?code=ABC" style="color: #ffffff">ABC123</a>`,
+				Text:    "Hi Gigi, your code is ABC123",
+			},
+		},
+		{
+			name: "only text",
+			args: args{
+				text: `{{truncate 3 .Args.Code}} {{default "there" .Args.NickName}}`,
+			},
+			want: &CustomTemplate{
+				Text: "ABC there",
+			},
+		},
+		{
+			name: "html escaped",
+			args: args{
+				html: "<p>{{.Args.Script}}</p>",
+			},
+			want: &CustomTemplate{
+				HTML: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>",
+			},
+		},
+		{
+			name: "syntax error",
+			args: args{
+				text: "{{.Title",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "unknown field",
+			args: args{
+				html: "{{.Unknown}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "unknown function",
+			args: args{
+				subject: "{{exec .Title}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over integer literal",
+			args: args{
+				text: "{{range 1000000000}}{{end}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over function result",
+			args: args{
+				html: `{{range default 1000000000 .Args.Missing}}{{end}}`,
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over variable",
+			args: args{
+				text: "{{$n := 1000000000}}{{range $n}}{{end}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over dot set by with",
+			args: args{
+				text: "{{with 1000000000}}{{range .}}{{end}}{{end}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over integer argument",
+			args: args{
+				text: "{{range .Args.Count}}{{end}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range nested too deep",
+			args: args{
+				text: "{{range .Args.Names}}{{range $.Args.Names}}{{range $.Args.Names}}{{end}}{{end}}{{end}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "range over data",
+			args: args{
+				text: "{{range $i, $name := .Args.Names}}{{if $i}}, {{end}}{{$name}}{{end}} ({{.Args.Count}})",
+			},
+			want: &CustomTemplate{
+				Text: "Gigi, Giraffe (1000000000)",
+			},
+		},
+		{
+			name: "recursive template",
+			args: args{
+				text: `{{define "a"}}{{template "a" .}}{{end}}{{template "a" .}}`,
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "call not allowed",
+			args: args{
+				text: "{{call .Title}}",
+			},
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderCustomTemplate(tt.args.subject, tt.args.html, tt.args.text, data)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateCustomTemplate(t *testing.T) {
+	assert.NoError(t, ValidateCustomTemplate("{{.Subject}}", "<p>{{.Greeting}} {{.Args.Code}}</p>", "{{.Text}}"))
+	assert.Error(t, ValidateCustomTemplate("", "{{range .Title}}", ""))
+}
+
+func Test_execute_timeout(t *testing.T) {
+	lateWrite := make(chan error, 1)
+	_, err := execute(func(w io.Writer) error {
+		time.Sleep(maxRenderDuration + 100*time.Millisecond)
+		_, err := w.Write([]byte("late"))
+		lateWrite <- err
+		return err
+	})
+	assert.ErrorIs(t, err, errRenderTimeout)
+	// the template still running is stopped on its next write
+	assert.ErrorIs(t, <-lateWrite, errRenderTimeout)
+}
+
+func Test_limitedWriter(t *testing.T) {
+	w := &limitedWriter{remaining: 4}
+	_, err := w.Write([]byte("abc"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("de"))
+	assert.ErrorIs(t, err, errOutputTooLarge)
+	assert.Equal(t, "abc", w.String())
+}
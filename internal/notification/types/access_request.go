@@ -0,0 +1,21 @@
+package types
+
+import (
+	"context"
+	"strings"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendAccessRequest(ctx context.Context, approver *query.NotifyUser, requester, projectName string, roleKeys []string, reason string) error {
+	url := console.LoginHintLink(http_utils.DomainContext(ctx).Origin(), approver.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["Requester"] = requester
+	args["ProjectName"] = projectName
+	args["Roles"] = strings.Join(roleKeys, ", ")
+	args["Reason"] = reason
+	return notify(url, args, domain.AccessRequestMessageType, false)
+}
@@ -0,0 +1,19 @@
+package types
+
+import (
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// renderCustomTemplate renders the custom template of the organization in the preferred language of the user.
+// It returns nil if the organization did not define a template for the language.
+func renderCustomTemplate(customTemplates *query.NotificationTemplates, user *query.NotifyUser, data templates.TemplateData, args map[string]interface{}) (*templates.CustomTemplate, error) {
+	custom := customTemplates.ForLanguage(user.PreferredLanguage)
+	if custom == nil {
+		return nil, nil
+	}
+	return templates.RenderCustomTemplate(custom.Subject, custom.HTML, custom.Text, templates.CustomTemplateData{
+		TemplateData: data,
+		Args:         args,
+	})
+}
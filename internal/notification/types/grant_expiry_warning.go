@@ -0,0 +1,20 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendGrantExpiryWarning(ctx context.Context, owner *query.NotifyUser, grantee, projectName string, expiryDate time.Time) error {
+	url := console.LoginHintLink(http_utils.DomainContext(ctx).Origin(), owner.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["Grantee"] = grantee
+	args["ProjectName"] = projectName
+	args["ExpiryDate"] = expiryDate.Format(time.DateOnly)
+	return notify(url, args, domain.GrantExpiryWarningMessageType, false)
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendInactivityWarning(ctx context.Context, user *query.NotifyUser, deactivationDate time.Time) error {
+	url := console.LoginHintLink(http_utils.DomainContext(ctx).Origin(), user.PreferredLoginName)
+	args := make(map[string]interface{})
+	args["DeactivationDate"] = deactivationDate.Format(time.DateOnly)
+	return notify(url, args, domain.InactivityWarningMessageType, true)
+}
@@ -36,6 +36,7 @@ func SendEmail(
 	ctx context.Context,
 	channels ChannelChains,
 	mailhtml string,
+	customTemplates *query.NotificationTemplates,
 	translator *i18n.Translator,
 	user *query.NotifyUser,
 	colors *query.LabelPolicy,
@@ -54,15 +55,30 @@ func SendEmail(
 			return err
 		}
 		data := GetTemplateData(ctx, translator, args, url, messageType, user.PreferredLanguage.String(), colors)
-		template, err := templates.GetParsedTemplate(mailhtml, data)
+		custom, err := renderCustomTemplate(customTemplates, user, data, args)
 		if err != nil {
 			return err
 		}
+		var template, text string
+		if custom != nil && (custom.HTML != "" || custom.Text != "") {
+			// the text is rendered without escaping, so it's never sent as HTML but as text/plain part
+			template = custom.HTML
+			text = custom.Text
+			if custom.Subject != "" {
+				data.Subject = custom.Subject
+			}
+		} else {
+			template, err = templates.GetParsedTemplate(mailhtml, data)
+			if err != nil {
+				return err
+			}
+		}
 		return generateEmail(
 			ctx,
 			channels,
 			user,
 			template,
+			text,
 			data,
 			args,
 			allowUnverifiedNotificationChannel,
@@ -99,6 +115,7 @@ func urlFromTemplate(urlTmpl string, args map[string]interface{}) (string, error
 func SendSMS(
 	ctx context.Context,
 	channels ChannelChains,
+	customTemplates *query.NotificationTemplates,
 	translator *i18n.Translator,
 	user *query.NotifyUser,
 	colors *query.LabelPolicy,
@@ -117,6 +134,13 @@ func SendSMS(
 			return err
 		}
 		data := GetTemplateData(ctx, translator, args, url, messageType, user.PreferredLanguage.String(), colors)
+		custom, err := renderCustomTemplate(customTemplates, user, data, args)
+		if err != nil {
+			return err
+		}
+		if custom != nil && custom.Text != "" {
+			data.Text = custom.Text
+		}
 		return generateSms(
 			ctx,
 			channels,
@@ -165,3 +189,22 @@ func SendSecurityTokenEvent(
 		)
 	}
 }
+
+// PushSecurityEventToken delivers a signed security event token to the receiver of a Shared Signals Framework stream.
+func PushSecurityEventToken(
+	ctx context.Context,
+	setConfig set.Config,
+	channels ChannelChains,
+	token string,
+	triggeringEvent eventstore.Event,
+) Notify {
+	return func(_ string, _ map[string]interface{}, _ string, _ bool) error {
+		return handleSecurityEventTokenPush(
+			ctx,
+			setConfig,
+			channels,
+			token,
+			triggeringEvent,
+		)
+	}
+}
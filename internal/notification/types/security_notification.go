@@ -0,0 +1,17 @@
+package types
+
+import (
+	"context"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/console"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// SendSecurityNotification notifies the user about a security relevant change of their account.
+// It is also sent to an unverified email, since the user must be informed in any case.
+func (notify Notify) SendSecurityNotification(ctx context.Context, user *query.NotifyUser, messageType string, args *domain.NotificationArguments) error {
+	url := console.LoginHintLink(http_utils.DomainContext(ctx).Origin(), user.PreferredLoginName)
+	return notify(url, args.ToMap(), messageType, true)
+}
@@ -25,3 +25,21 @@ func handleSecurityTokenEvent(
 	}
 	return setChannels.HandleMessage(message)
 }
+
+func handleSecurityEventTokenPush(
+	ctx context.Context,
+	setConfig set.Config,
+	channels ChannelChains,
+	token string,
+	triggeringEvent eventstore.Event,
+) error {
+	message := &messages.SecurityEventToken{
+		Token:           token,
+		TriggeringEvent: triggeringEvent,
+	}
+	setChannels, err := channels.SecurityTokenEvent(ctx, setConfig)
+	if err != nil {
+		return err
+	}
+	return setChannels.HandleMessage(message)
+}
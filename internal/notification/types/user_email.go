@@ -9,8 +9,10 @@ import (
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/templates"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -19,6 +21,7 @@ func generateEmail(
 	channels ChannelChains,
 	user *query.NotifyUser,
 	template string,
+	text string,
 	data templates.TemplateData,
 	args map[string]interface{},
 	lastEmail bool,
@@ -33,14 +36,25 @@ func generateEmail(
 	if lastEmail {
 		recipient = user.LastEmail
 	}
+	deliveryInfo := senders.DeliveryInfoFromContext(ctx)
+	deliveryInfo.Recipient = recipient
+	if config.ProviderConfig != nil {
+		deliveryInfo.ProviderID = config.ProviderConfig.ID
+	}
 	if config.SMTPConfig != nil {
 		message := &messages.Email{
 			Recipients:      []string{recipient},
 			Subject:         data.Subject,
 			Content:         html.UnescapeString(template),
+			TextContent:     html.UnescapeString(text),
 			TriggeringEvent: triggeringEvent,
 		}
-		return emailChannels.HandleMessage(message)
+		deliveryInfo.ProviderType = senders.ProviderTypeSMTP
+		if err = emailChannels.HandleMessage(message); err != nil {
+			return err
+		}
+		deliveryInfo.MessageID = message.MessageID
+		return nil
 	}
 	if config.WebhookConfig != nil {
 		caseArgs := make(map[string]interface{}, len(args))
@@ -52,6 +66,10 @@ func generateEmail(
 			"eventType":             triggeringEvent.Type(),
 			"provider":              config.ProviderConfig,
 		}
+		// notifications of the notification worker can be reported by their id, see [senders.DeliveryInfo]
+		if triggeringEvent.Aggregate().Type == notification.AggregateType {
+			contextInfo["notificationId"] = triggeringEvent.Aggregate().ID
+		}
 
 		message := &messages.JSON{
 			Serializable: &serializableData{
@@ -65,6 +83,7 @@ func generateEmail(
 		if err != nil {
 			return err
 		}
+		deliveryInfo.ProviderType = senders.ProviderTypeWebhook
 		return webhookChannels.HandleMessage(message)
 	}
 	return zerrors.ThrowPreconditionFailed(nil, "MAIL-83nof", "Errors.Notification.Channels.NotPresent")
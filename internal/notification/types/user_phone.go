@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/senders"
 	"github.com/zitadel/zitadel/internal/notification/templates"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/notification"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -39,10 +40,15 @@ func generateSms(
 	if lastPhone {
 		recipient = user.LastPhone
 	}
-	if config.TwilioConfig != nil {
+	deliveryInfo := senders.DeliveryInfoFromContext(ctx)
+	deliveryInfo.Recipient = recipient
+	if config.ProviderConfig != nil {
+		deliveryInfo.ProviderID = config.ProviderConfig.ID
+	}
+	if native := config.Native(); native != nil {
 		number := ""
 		if err == nil {
-			number = config.TwilioConfig.SenderNumber
+			number = native.Sender()
 		}
 		message := &messages.SMS{
 			SenderPhoneNumber:    number,
@@ -50,11 +56,13 @@ func generateSms(
 			Content:              data.Text,
 			TriggeringEvent:      triggeringEvent,
 		}
+		deliveryInfo.ProviderType = native.Type()
 		err = smsChannels.HandleMessage(message)
 		if err != nil {
 			return err
 		}
-		if config.TwilioConfig.VerifyServiceSID != "" {
+		deliveryInfo.MessageID = message.MessageID
+		if native.GeneratesCode() {
 			generatorInfo.ID = config.ProviderConfig.ID
 			generatorInfo.VerificationID = *message.VerificationID
 		}
@@ -70,6 +78,10 @@ func generateSms(
 			"eventType":            triggeringEvent.Type(),
 			"provider":             config.ProviderConfig,
 		}
+		// notifications of the notification worker can be reported by their id, see [senders.DeliveryInfo]
+		if triggeringEvent.Aggregate().Type == notification.AggregateType {
+			contextInfo["notificationId"] = triggeringEvent.Aggregate().ID
+		}
 
 		message := &messages.JSON{
 			Serializable: &serializableData{
@@ -83,6 +95,7 @@ func generateSms(
 		if err != nil {
 			return err
 		}
+		deliveryInfo.ProviderType = senders.ProviderTypeWebhook
 		return webhookChannels.HandleMessage(message)
 	}
 	return zerrors.ThrowPreconditionFailed(nil, "PHONE-w8nfow", "Errors.Notification.Channels.NotPresent")
@@ -0,0 +1,295 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	accessRequestTable = table{
+		name:          projection.AccessRequestTable,
+		instanceIDCol: projection.AccessRequestInstanceIDCol,
+	}
+	AccessRequestColumnID = Column{
+		name:  projection.AccessRequestIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnInstanceID = Column{
+		name:  projection.AccessRequestInstanceIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnResourceOwner = Column{
+		name:  projection.AccessRequestResourceOwnerCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnCreationDate = Column{
+		name:  projection.AccessRequestCreationDateCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnChangeDate = Column{
+		name:  projection.AccessRequestChangeDateCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnSequence = Column{
+		name:  projection.AccessRequestSequenceCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnState = Column{
+		name:  projection.AccessRequestStateCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnUserID = Column{
+		name:  projection.AccessRequestUserIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnProjectID = Column{
+		name:  projection.AccessRequestProjectIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnProjectGrantID = Column{
+		name:  projection.AccessRequestProjectGrantIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnRoleKeys = Column{
+		name:  projection.AccessRequestRoleKeysCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnReason = Column{
+		name:  projection.AccessRequestReasonCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnUserGrantID = Column{
+		name:  projection.AccessRequestUserGrantIDCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnComment = Column{
+		name:  projection.AccessRequestCommentCol,
+		table: accessRequestTable,
+	}
+	AccessRequestColumnDecidedBy = Column{
+		name:  projection.AccessRequestDecidedByCol,
+		table: accessRequestTable,
+	}
+)
+
+type AccessRequests struct {
+	SearchResponse
+	AccessRequests []*AccessRequest
+}
+
+func (r *AccessRequests) SetState(s *State) {
+	r.State = s
+}
+
+// AccessRequest is the request of a user for roles on a project.
+// DecidedBy is the user who approved, denied or withdrew the request.
+type AccessRequest struct {
+	ID             string
+	ResourceOwner  string
+	CreationDate   time.Time
+	ChangeDate     time.Time
+	Sequence       uint64
+	State          domain.AccessRequestState
+	UserID         string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       database.TextArray[string]
+	Reason         string
+	UserGrantID    string
+	Comment        string
+	DecidedBy      string
+}
+
+type AccessRequestSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *AccessRequestSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *AccessRequestSearchQueries) AppendMyResourceOwnerQuery(orgID string) error {
+	query, err := NewAccessRequestResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return err
+	}
+	q.Queries = append(q.Queries, query)
+	return nil
+}
+
+func (q *Queries) AccessRequestByID(ctx context.Context, id, resourceOwner string) (_ *AccessRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		AccessRequestColumnID.identifier():         id,
+		AccessRequestColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if resourceOwner != "" {
+		eq[AccessRequestColumnResourceOwner.identifier()] = resourceOwner
+	}
+	query, scan := prepareAccessRequestQuery(ctx, q.client)
+	return genericRowQuery[*AccessRequest](ctx, q.client, query.Where(eq), scan)
+}
+
+func (q *Queries) SearchAccessRequests(ctx context.Context, queries *AccessRequestSearchQueries) (_ *AccessRequests, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		AccessRequestColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareAccessRequestsQuery(ctx, q.client)
+	return genericRowsQueryWithState[*AccessRequests](ctx, q.client, accessRequestTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+// UserGrantTargetOfOrg returns the resource owner and project grant
+// a user grant of a user of the organization on the project belongs to.
+// The user grant belongs to the organization of the project if the user is part of it,
+// else to the project grant of the organization of the user.
+func (q *Queries) UserGrantTargetOfOrg(ctx context.Context, projectID, orgID string) (resourceOwner, projectGrantID string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	project, err := q.ProjectByID(ctx, false, projectID)
+	if err != nil {
+		return "", "", err
+	}
+	if project.ResourceOwner == orgID {
+		return orgID, "", nil
+	}
+	projectQuery, err := NewProjectGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return "", "", err
+	}
+	grantedOrgQuery, err := NewProjectGrantGrantedOrgIDSearchQuery(orgID)
+	if err != nil {
+		return "", "", err
+	}
+	grants, err := q.SearchProjectGrants(ctx, &ProjectGrantSearchQueries{Queries: []SearchQuery{projectQuery, grantedOrgQuery}})
+	if err != nil {
+		return "", "", err
+	}
+	if len(grants.ProjectGrants) != 1 {
+		return "", "", zerrors.ThrowPreconditionFailed(nil, "QUERY-Ar1ng", "Errors.AccessRequest.ProjectNotGranted")
+	}
+	return orgID, grants.ProjectGrants[0].GrantID, nil
+}
+
+func NewAccessRequestResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnResourceOwner, value, TextEquals)
+}
+
+func NewAccessRequestUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnUserID, value, TextEquals)
+}
+
+func NewAccessRequestProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnProjectID, value, TextEquals)
+}
+
+func NewAccessRequestProjectGrantIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(AccessRequestColumnProjectGrantID, value, TextEquals)
+}
+
+func NewAccessRequestStateSearchQuery(value domain.AccessRequestState) (SearchQuery, error) {
+	return NewNumberQuery(AccessRequestColumnState, value, NumberEquals)
+}
+
+func accessRequestColumns() []string {
+	return []string{
+		AccessRequestColumnID.identifier(),
+		AccessRequestColumnResourceOwner.identifier(),
+		AccessRequestColumnCreationDate.identifier(),
+		AccessRequestColumnChangeDate.identifier(),
+		AccessRequestColumnSequence.identifier(),
+		AccessRequestColumnState.identifier(),
+		AccessRequestColumnUserID.identifier(),
+		AccessRequestColumnProjectID.identifier(),
+		AccessRequestColumnProjectGrantID.identifier(),
+		AccessRequestColumnRoleKeys.identifier(),
+		AccessRequestColumnReason.identifier(),
+		AccessRequestColumnUserGrantID.identifier(),
+		AccessRequestColumnComment.identifier(),
+		AccessRequestColumnDecidedBy.identifier(),
+	}
+}
+
+func scanAccessRequest(scan func(dest ...any) error, dest ...any) (*AccessRequest, error) {
+	request := new(AccessRequest)
+	err := scan(append([]any{
+		&request.ID,
+		&request.ResourceOwner,
+		&request.CreationDate,
+		&request.ChangeDate,
+		&request.Sequence,
+		&request.State,
+		&request.UserID,
+		&request.ProjectID,
+		&request.ProjectGrantID,
+		&request.RoleKeys,
+		&request.Reason,
+		&request.UserGrantID,
+		&request.Comment,
+		&request.DecidedBy,
+	}, dest...)...)
+	return request, err
+}
+
+func prepareAccessRequestQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*AccessRequest, error)) {
+	return sq.Select(accessRequestColumns()...).
+			From(accessRequestTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*AccessRequest, error) {
+			request, err := scanAccessRequest(row.Scan)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ar2nf", "Errors.AccessRequest.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ar3sc", "Errors.Internal")
+			}
+			return request, nil
+		}
+}
+
+func prepareAccessRequestsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*AccessRequests, error)) {
+	return sq.Select(append(accessRequestColumns(), countColumn.identifier())...).
+			From(accessRequestTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*AccessRequests, error) {
+			requests := make([]*AccessRequest, 0)
+			var count uint64
+			for rows.Next() {
+				request, err := scanAccessRequest(rows.Scan, &count)
+				if err != nil {
+					return nil, err
+				}
+				requests = append(requests, request)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ar4cl", "Errors.Query.CloseRows")
+			}
+			return &AccessRequests{
+				AccessRequests: requests,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
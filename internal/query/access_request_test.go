@@ -0,0 +1,204 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	accessRequestSelect = `SELECT projections.access_requests.id,` +
+		` projections.access_requests.resource_owner,` +
+		` projections.access_requests.creation_date,` +
+		` projections.access_requests.change_date,` +
+		` projections.access_requests.sequence,` +
+		` projections.access_requests.state,` +
+		` projections.access_requests.user_id,` +
+		` projections.access_requests.project_id,` +
+		` projections.access_requests.grant_id,` +
+		` projections.access_requests.role_keys,` +
+		` projections.access_requests.reason,` +
+		` projections.access_requests.user_grant_id,` +
+		` projections.access_requests.comment,` +
+		` projections.access_requests.decided_by`
+	prepareAccessRequestStmt  = accessRequestSelect + ` FROM projections.access_requests`
+	prepareAccessRequestsStmt = accessRequestSelect + `, COUNT(*) OVER () FROM projections.access_requests`
+	prepareAccessRequestCols  = []string{
+		"id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"user_id",
+		"project_id",
+		"grant_id",
+		"role_keys",
+		"reason",
+		"user_grant_id",
+		"comment",
+		"decided_by",
+	}
+	prepareAccessRequestsCols = append(prepareAccessRequestCols, "count")
+)
+
+func Test_AccessRequestPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAccessRequestQuery no result",
+			prepare: prepareAccessRequestQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareAccessRequestStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AccessRequest)(nil),
+		},
+		{
+			name:    "prepareAccessRequestQuery found",
+			prepare: prepareAccessRequestQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareAccessRequestStmt),
+					prepareAccessRequestCols,
+					[]driver.Value{
+						"request-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211111),
+						domain.AccessRequestStateApproved,
+						"user-id",
+						"project-id",
+						"",
+						database.TextArray[string]{"role"},
+						"reason",
+						"user-grant-id",
+						"comment",
+						"approver-id",
+					},
+				),
+			},
+			object: &AccessRequest{
+				ID:            "request-id",
+				ResourceOwner: "ro",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211111,
+				State:         domain.AccessRequestStateApproved,
+				UserID:        "user-id",
+				ProjectID:     "project-id",
+				RoleKeys:      database.TextArray[string]{"role"},
+				Reason:        "reason",
+				UserGrantID:   "user-grant-id",
+				Comment:       "comment",
+				DecidedBy:     "approver-id",
+			},
+		},
+		{
+			name:    "prepareAccessRequestsQuery no result",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAccessRequestsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &AccessRequests{AccessRequests: []*AccessRequest{}},
+		},
+		{
+			name:    "prepareAccessRequestsQuery found",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAccessRequestsStmt),
+					prepareAccessRequestsCols,
+					[][]driver.Value{
+						{
+							"request-id",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211111),
+							domain.AccessRequestStatePending,
+							"user-id",
+							"project-id",
+							"grant-id",
+							database.TextArray[string]{"role"},
+							"",
+							"",
+							"",
+							"",
+						},
+					},
+				),
+			},
+			object: &AccessRequests{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				AccessRequests: []*AccessRequest{
+					{
+						ID:             "request-id",
+						ResourceOwner:  "ro",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						Sequence:       20211111,
+						State:          domain.AccessRequestStatePending,
+						UserID:         "user-id",
+						ProjectID:      "project-id",
+						ProjectGrantID: "grant-id",
+						RoleKeys:       database.TextArray[string]{"role"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAccessRequestsQuery sql err",
+			prepare: prepareAccessRequestsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareAccessRequestsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AccessRequests)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	customRoleTable = table{
+		name:          projection.CustomRoleTable,
+		instanceIDCol: projection.CustomRoleInstanceIDCol,
+	}
+	CustomRoleColumnKey = Column{
+		name:  projection.CustomRoleKeyCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnInstanceID = Column{
+		name:  projection.CustomRoleInstanceIDCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnResourceOwner = Column{
+		name:  projection.CustomRoleResourceOwnerCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnDisplayName = Column{
+		name:  projection.CustomRoleDisplayNameCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnPermissions = Column{
+		name:  projection.CustomRolePermissionsCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnCreationDate = Column{
+		name:  projection.CustomRoleCreationDateCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnChangeDate = Column{
+		name:  projection.CustomRoleChangeDateCol,
+		table: customRoleTable,
+	}
+	CustomRoleColumnSequence = Column{
+		name:  projection.CustomRoleSequenceCol,
+		table: customRoleTable,
+	}
+)
+
+type CustomRoles struct {
+	SearchResponse
+	CustomRoles []*CustomRole
+}
+
+func (r *CustomRoles) SetState(s *State) {
+	r.State = s
+}
+
+// CustomRole is a role of the instance (the resource owner is the instance)
+// or of an organization.
+type CustomRole struct {
+	Key           string
+	ResourceOwner string
+	DisplayName   string
+	Permissions   database.TextArray[string]
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+}
+
+type CustomRoleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *CustomRoleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchCustomRoles(ctx context.Context, queries *CustomRoleSearchQueries) (_ *CustomRoles, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		CustomRoleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareCustomRolesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*CustomRoles](ctx, q.client, customRoleTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+// CustomRoleMappingsOfOrg returns the custom roles of the instance, the organization and its ancestors
+// mapped to their permissions. The roles of the organizations are restricted to their memberships.
+func (q *Queries) CustomRoleMappingsOfOrg(ctx context.Context, orgID string) (_ []authz.RoleMapping, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	query, scan := prepareCustomRolesQuery(ctx, q.client)
+	stmt := withOrgAncestors(query, orgID, instanceID).
+		Where(sq.Eq{CustomRoleColumnInstanceID.identifier(): instanceID}).
+		Where(orgOrAncestorsCond(CustomRoleColumnResourceOwner, instanceID))
+	roles, err := genericRowsQuery[*CustomRoles](ctx, q.client, stmt, scan)
+	if err != nil {
+		return nil, err
+	}
+	return customRolesToRoleMappings(roles.CustomRoles, instanceID), nil
+}
+
+func customRolesToRoleMappings(roles []*CustomRole, instanceID string) []authz.RoleMapping {
+	mappings := make([]authz.RoleMapping, len(roles))
+	for i, role := range roles {
+		mappings[i] = authz.RoleMapping{
+			Role:        role.Key,
+			Permissions: role.Permissions,
+		}
+		if role.ResourceOwner != instanceID {
+			mappings[i].ResourceOwner = role.ResourceOwner
+		}
+	}
+	return mappings
+}
+
+func NewCustomRoleResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnResourceOwner, value, TextEquals)
+}
+
+func NewCustomRoleKeySearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(CustomRoleColumnKey, value, method)
+}
+
+func prepareCustomRolesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*CustomRoles, error)) {
+	return sq.Select(
+			CustomRoleColumnKey.identifier(),
+			CustomRoleColumnResourceOwner.identifier(),
+			CustomRoleColumnDisplayName.identifier(),
+			CustomRoleColumnPermissions.identifier(),
+			CustomRoleColumnCreationDate.identifier(),
+			CustomRoleColumnChangeDate.identifier(),
+			CustomRoleColumnSequence.identifier(),
+			countColumn.identifier(),
+		).From(customRoleTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*CustomRoles, error) {
+			roles := make([]*CustomRole, 0)
+			var count uint64
+			for rows.Next() {
+				role := new(CustomRole)
+				err := rows.Scan(
+					&role.Key,
+					&role.ResourceOwner,
+					&role.DisplayName,
+					&role.Permissions,
+					&role.CreationDate,
+					&role.ChangeDate,
+					&role.Sequence,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				roles = append(roles, role)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Cr1cl", "Errors.Query.CloseRows")
+			}
+			return &CustomRoles{
+				CustomRoles: roles,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
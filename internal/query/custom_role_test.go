@@ -0,0 +1,133 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	prepareCustomRolesStmt = `SELECT projections.custom_roles.role_key,` +
+		` projections.custom_roles.resource_owner,` +
+		` projections.custom_roles.display_name,` +
+		` projections.custom_roles.permissions,` +
+		` projections.custom_roles.creation_date,` +
+		` projections.custom_roles.change_date,` +
+		` projections.custom_roles.sequence,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.custom_roles`
+	prepareCustomRolesCols = []string{
+		"role_key",
+		"resource_owner",
+		"display_name",
+		"permissions",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"count",
+	}
+)
+
+func Test_CustomRolePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareCustomRolesQuery no result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareCustomRolesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &CustomRoles{CustomRoles: []*CustomRole{}},
+		},
+		{
+			name:    "prepareCustomRolesQuery one result",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareCustomRolesStmt),
+					prepareCustomRolesCols,
+					[][]driver.Value{
+						{
+							"ORG_HELPDESK",
+							"org",
+							"Help Desk",
+							database.TextArray[string]{"user.credential.write"},
+							testNow,
+							testNow,
+							uint64(20211108),
+						},
+					},
+				),
+			},
+			object: &CustomRoles{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				CustomRoles: []*CustomRole{
+					{
+						Key:           "ORG_HELPDESK",
+						ResourceOwner: "org",
+						DisplayName:   "Help Desk",
+						Permissions:   database.TextArray[string]{"user.credential.write"},
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211108,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareCustomRolesQuery sql err",
+			prepare: prepareCustomRolesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareCustomRolesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*CustomRoles)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_customRolesToRoleMappings(t *testing.T) {
+	roles := []*CustomRole{
+		{Key: "IAM_HELPDESK", ResourceOwner: "instance", Permissions: database.TextArray[string]{"user.credential.write"}},
+		{Key: "ORG_HELPDESK", ResourceOwner: "org", Permissions: database.TextArray[string]{"user.write"}},
+	}
+	assert.Equal(t, []authz.RoleMapping{
+		{Role: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+		{Role: "ORG_HELPDESK", Permissions: []string{"user.write"}, ResourceOwner: "org"},
+	}, customRolesToRoleMappings(roles, "instance"))
+}
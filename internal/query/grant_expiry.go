@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type ExpiringGrantAction int32
+
+const (
+	ExpiringGrantActionUnspecified ExpiringGrantAction = iota
+	// ExpiringGrantActionWarn means the grant expires soon and the owners will be notified.
+	ExpiringGrantActionWarn
+	// ExpiringGrantActionExpire means the validity of the grant ended.
+	ExpiringGrantActionExpire
+)
+
+type ExpiringUserGrant struct {
+	ID            string
+	ResourceOwner string
+	ValidUntil    time.Time
+	ExpiryWarned  bool
+	Action        ExpiringGrantAction
+}
+
+type ExpiringProjectGrant struct {
+	ProjectID     string
+	GrantID       string
+	ResourceOwner string
+	ValidUntil    time.Time
+	ExpiryWarned  bool
+	Action        ExpiringGrantAction
+}
+
+func expiringGrantAction(validUntil, now time.Time) ExpiringGrantAction {
+	if !now.Before(validUntil) {
+		return ExpiringGrantActionExpire
+	}
+	return ExpiringGrantActionWarn
+}
+
+// ExpiringUserGrants returns the active user grants of the instance whose validity ends before the warn date.
+// Grants whose owners were already warned are returned as well, their ExpiryWarned is set in that case.
+func (q *Queries) ExpiringUserGrants(ctx context.Context, warnBefore time.Duration, now time.Time) (_ []*ExpiringUserGrant, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareExpiringUserGrantsQuery(ctx, q.client)
+	eq := sq.And{
+		sq.Eq{
+			UserGrantInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			UserGrantState.identifier():      domain.UserGrantStateActive,
+		},
+		sq.LtOrEq{
+			UserGrantValidUntil.identifier(): now.Add(warnBefore),
+		},
+	}
+	grants, err := genericRowsQuery[[]*ExpiringUserGrant](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		grant.Action = expiringGrantAction(grant.ValidUntil, now)
+	}
+	return grants, nil
+}
+
+func prepareExpiringUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*ExpiringUserGrant, error)) {
+	return sq.Select(
+			UserGrantID.identifier(),
+			UserGrantResourceOwner.identifier(),
+			UserGrantValidUntil.identifier(),
+			UserGrantExpiryWarned.identifier(),
+		).
+			From(userGrantTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(UserGrantValidUntil.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ExpiringUserGrant, error) {
+			grants := make([]*ExpiringUserGrant, 0)
+			for rows.Next() {
+				grant := new(ExpiringUserGrant)
+				err := rows.Scan(
+					&grant.ID,
+					&grant.ResourceOwner,
+					&grant.ValidUntil,
+					&grant.ExpiryWarned,
+				)
+				if err != nil {
+					return nil, err
+				}
+				grants = append(grants, grant)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gx1cr", "Errors.Query.CloseRows")
+			}
+			return grants, nil
+		}
+}
+
+// ExpiringProjectGrants returns the active project grants of the instance whose validity ends before the warn date.
+// Grants whose owners were already warned are returned as well, their ExpiryWarned is set in that case.
+func (q *Queries) ExpiringProjectGrants(ctx context.Context, warnBefore time.Duration, now time.Time) (_ []*ExpiringProjectGrant, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareExpiringProjectGrantsQuery(ctx, q.client)
+	eq := sq.And{
+		sq.Eq{
+			ProjectGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			ProjectGrantColumnState.identifier():      domain.ProjectGrantStateActive,
+		},
+		sq.LtOrEq{
+			ProjectGrantColumnValidUntil.identifier(): now.Add(warnBefore),
+		},
+	}
+	grants, err := genericRowsQuery[[]*ExpiringProjectGrant](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		grant.Action = expiringGrantAction(grant.ValidUntil, now)
+	}
+	return grants, nil
+}
+
+func prepareExpiringProjectGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*ExpiringProjectGrant, error)) {
+	return sq.Select(
+			ProjectGrantColumnProjectID.identifier(),
+			ProjectGrantColumnGrantID.identifier(),
+			ProjectGrantColumnResourceOwner.identifier(),
+			ProjectGrantColumnValidUntil.identifier(),
+			ProjectGrantColumnExpiryWarned.identifier(),
+		).
+			From(projectGrantsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			OrderBy(ProjectGrantColumnValidUntil.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*ExpiringProjectGrant, error) {
+			grants := make([]*ExpiringProjectGrant, 0)
+			for rows.Next() {
+				grant := new(ExpiringProjectGrant)
+				err := rows.Scan(
+					&grant.ProjectID,
+					&grant.GrantID,
+					&grant.ResourceOwner,
+					&grant.ValidUntil,
+					&grant.ExpiryWarned,
+				)
+				if err != nil {
+					return nil, err
+				}
+				grants = append(grants, grant)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gx2cr", "Errors.Query.CloseRows")
+			}
+			return grants, nil
+		}
+}
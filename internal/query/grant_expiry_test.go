@@ -0,0 +1,180 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	prepareExpiringUserGrantsStmt = `SELECT projections.user_grants6.id,` +
+		` projections.user_grants6.resource_owner,` +
+		` projections.user_grants6.valid_until,` +
+		` projections.user_grants6.expiry_warned` +
+		` FROM projections.user_grants6` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.user_grants6.valid_until`
+	prepareExpiringUserGrantsCols = []string{
+		"id",
+		"resource_owner",
+		"valid_until",
+		"expiry_warned",
+	}
+	prepareExpiringProjectGrantsStmt = `SELECT projections.project_grants5.project_id,` +
+		` projections.project_grants5.grant_id,` +
+		` projections.project_grants5.resource_owner,` +
+		` projections.project_grants5.valid_until,` +
+		` projections.project_grants5.expiry_warned` +
+		` FROM projections.project_grants5` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.project_grants5.valid_until`
+	prepareExpiringProjectGrantsCols = []string{
+		"project_id",
+		"grant_id",
+		"resource_owner",
+		"valid_until",
+		"expiry_warned",
+	}
+)
+
+func Test_GrantExpiryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareExpiringUserGrantsQuery no result",
+			prepare: prepareExpiringUserGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExpiringUserGrantsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*ExpiringUserGrant{},
+		},
+		{
+			name:    "prepareExpiringUserGrantsQuery found",
+			prepare: prepareExpiringUserGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExpiringUserGrantsStmt),
+					prepareExpiringUserGrantsCols,
+					[][]driver.Value{
+						{
+							"usergrant-1",
+							"ro",
+							testNow,
+							false,
+						},
+						{
+							"usergrant-2",
+							"ro",
+							testNow,
+							true,
+						},
+					},
+				),
+			},
+			object: []*ExpiringUserGrant{
+				{
+					ID:            "usergrant-1",
+					ResourceOwner: "ro",
+					ValidUntil:    testNow,
+				},
+				{
+					ID:            "usergrant-2",
+					ResourceOwner: "ro",
+					ValidUntil:    testNow,
+					ExpiryWarned:  true,
+				},
+			},
+		},
+		{
+			name:    "prepareExpiringUserGrantsQuery sql err",
+			prepare: prepareExpiringUserGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareExpiringUserGrantsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*ExpiringUserGrant)(nil),
+		},
+		{
+			name:    "prepareExpiringProjectGrantsQuery found",
+			prepare: prepareExpiringProjectGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareExpiringProjectGrantsStmt),
+					prepareExpiringProjectGrantsCols,
+					[][]driver.Value{
+						{
+							"project-1",
+							"projectgrant-1",
+							"ro",
+							testNow,
+							false,
+						},
+					},
+				),
+			},
+			object: []*ExpiringProjectGrant{
+				{
+					ProjectID:     "project-1",
+					GrantID:       "projectgrant-1",
+					ResourceOwner: "ro",
+					ValidUntil:    testNow,
+				},
+			},
+		},
+		{
+			name:    "prepareExpiringProjectGrantsQuery sql err",
+			prepare: prepareExpiringProjectGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareExpiringProjectGrantsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*ExpiringProjectGrant)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_expiringGrantAction(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, ExpiringGrantActionWarn, expiringGrantAction(now.Add(time.Hour), now))
+	assert.Equal(t, ExpiringGrantActionExpire, expiringGrantAction(now, now))
+	assert.Equal(t, ExpiringGrantActionExpire, expiringGrantAction(now.Add(-time.Hour), now))
+}
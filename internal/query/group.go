@@ -0,0 +1,478 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	groupTable = table{
+		name:          projection.GroupProjectionTable,
+		instanceIDCol: projection.GroupInstanceIDCol,
+	}
+	GroupColumnID = Column{
+		name:  projection.GroupIDCol,
+		table: groupTable,
+	}
+	GroupColumnCreationDate = Column{
+		name:  projection.GroupCreationDateCol,
+		table: groupTable,
+	}
+	GroupColumnChangeDate = Column{
+		name:  projection.GroupChangeDateCol,
+		table: groupTable,
+	}
+	GroupColumnSequence = Column{
+		name:  projection.GroupSequenceCol,
+		table: groupTable,
+	}
+	GroupColumnResourceOwner = Column{
+		name:  projection.GroupResourceOwnerCol,
+		table: groupTable,
+	}
+	GroupColumnInstanceID = Column{
+		name:  projection.GroupInstanceIDCol,
+		table: groupTable,
+	}
+	GroupColumnName = Column{
+		name:  projection.GroupNameCol,
+		table: groupTable,
+	}
+	GroupColumnDescription = Column{
+		name:  projection.GroupDescriptionCol,
+		table: groupTable,
+	}
+
+	groupMemberTable = table{
+		name:          projection.GroupMemberTable,
+		instanceIDCol: projection.GroupMemberInstanceIDCol,
+	}
+	GroupMemberColumnGroupID = Column{
+		name:  projection.GroupMemberGroupIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnUserID = Column{
+		name:  projection.GroupMemberUserIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnInstanceID = Column{
+		name:  projection.GroupMemberInstanceIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnResourceOwner = Column{
+		name:  projection.GroupMemberResourceOwnerCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnUserResourceOwner = Column{
+		name:  projection.GroupMemberUserResourceOwnerCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnCreationDate = Column{
+		name:  projection.GroupMemberCreationDateCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnSequence = Column{
+		name:  projection.GroupMemberSequenceCol,
+		table: groupMemberTable,
+	}
+
+	groupGrantTable = table{
+		name:          projection.GroupGrantTable,
+		instanceIDCol: projection.GroupGrantInstanceIDCol,
+	}
+	GroupGrantColumnID = Column{
+		name:  projection.GroupGrantIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGroupID = Column{
+		name:  projection.GroupGrantGroupIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnInstanceID = Column{
+		name:  projection.GroupGrantInstanceIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnResourceOwner = Column{
+		name:  projection.GroupGrantResourceOwnerCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnCreationDate = Column{
+		name:  projection.GroupGrantCreationDateCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnChangeDate = Column{
+		name:  projection.GroupGrantChangeDateCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnSequence = Column{
+		name:  projection.GroupGrantSequenceCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectID = Column{
+		name:  projection.GroupGrantProjectIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGrantID = Column{
+		name:  projection.GroupGrantGrantIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnRoles = Column{
+		name:  projection.GroupGrantRolesCol,
+		table: groupGrantTable,
+	}
+)
+
+type Groups struct {
+	SearchResponse
+	Groups []*Group
+}
+
+func (g *Groups) SetState(s *State) {
+	g.State = s
+}
+
+type Group struct {
+	domain.ObjectDetails
+
+	Name        string
+	Description string
+}
+
+type GroupSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type GroupMembers struct {
+	SearchResponse
+	Members []*GroupMember
+}
+
+func (m *GroupMembers) SetState(s *State) {
+	m.State = s
+}
+
+type GroupMember struct {
+	GroupID           string
+	UserID            string
+	ResourceOwner     string
+	UserResourceOwner string
+	CreationDate      time.Time
+	Sequence          uint64
+}
+
+type GroupMemberSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupMemberSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type GroupGrants struct {
+	SearchResponse
+	Grants []*GroupGrant
+}
+
+func (g *GroupGrants) SetState(s *State) {
+	g.State = s
+}
+
+type GroupGrant struct {
+	ID             string
+	GroupID        string
+	ResourceOwner  string
+	CreationDate   time.Time
+	ChangeDate     time.Time
+	Sequence       uint64
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       database.TextArray[string]
+}
+
+type GroupGrantSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupGrantSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) GroupByID(ctx context.Context, id, resourceOwner string) (_ *Group, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupColumnID.identifier():         id,
+		GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if resourceOwner != "" {
+		eq[GroupColumnResourceOwner.identifier()] = resourceOwner
+	}
+	query, scan := prepareGroupQuery(ctx, q.client)
+	return genericRowQuery[*Group](ctx, q.client, query.Where(eq), scan)
+}
+
+func (q *Queries) SearchGroups(ctx context.Context, queries *GroupSearchQueries) (_ *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupsQuery(ctx, q.client)
+	return genericRowsQueryWithState[*Groups](ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func (q *Queries) SearchGroupMembers(ctx context.Context, queries *GroupMemberSearchQueries) (_ *GroupMembers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupMembersQuery(ctx, q.client)
+	return genericRowsQueryWithState[*GroupMembers](ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func (q *Queries) SearchGroupGrants(ctx context.Context, queries *GroupGrantSearchQueries) (_ *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupGrantsQuery(ctx, q.client)
+	return genericRowsQueryWithState[*GroupGrants](ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewGroupNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnName, value, method)
+}
+
+func NewGroupResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnResourceOwner, value, TextEquals)
+}
+
+func NewGroupInIDsSearchQuery(values []string) (SearchQuery, error) {
+	return NewInTextQuery(GroupColumnID, values)
+}
+
+func NewGroupMemberGroupIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnGroupID, value, TextEquals)
+}
+
+func NewGroupMemberUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnUserID, value, TextEquals)
+}
+
+func NewGroupMemberResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupMemberColumnResourceOwner, value, TextEquals)
+}
+
+func NewGroupGrantGroupIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnGroupID, value, TextEquals)
+}
+
+func NewGroupGrantProjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnProjectID, value, TextEquals)
+}
+
+func NewGroupGrantResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnResourceOwner, value, TextEquals)
+}
+
+func prepareGroupQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*Group, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+		).From(groupTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Group, error) {
+			group := new(Group)
+			err := row.Scan(
+				&group.ID,
+				&group.CreationDate,
+				&group.EventDate,
+				&group.Sequence,
+				&group.ResourceOwner,
+				&group.Name,
+				&group.Description,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Gq8nc", "Errors.Group.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gq9nd", "Errors.Internal")
+			}
+			return group, nil
+		}
+}
+
+func prepareGroupsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*Groups, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+			countColumn.identifier(),
+		).From(groupTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Groups, error) {
+			groups := make([]*Group, 0)
+			var count uint64
+			for rows.Next() {
+				group := new(Group)
+				err := rows.Scan(
+					&group.ID,
+					&group.CreationDate,
+					&group.EventDate,
+					&group.Sequence,
+					&group.ResourceOwner,
+					&group.Name,
+					&group.Description,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groups = append(groups, group)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gr0cl", "Errors.Query.CloseRows")
+			}
+			return &Groups{
+				Groups: groups,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupMembersQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberColumnGroupID.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			GroupMemberColumnResourceOwner.identifier(),
+			GroupMemberColumnUserResourceOwner.identifier(),
+			GroupMemberColumnCreationDate.identifier(),
+			GroupMemberColumnSequence.identifier(),
+			countColumn.identifier(),
+		).From(groupMemberTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			members := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				member := new(GroupMember)
+				err := rows.Scan(
+					&member.GroupID,
+					&member.UserID,
+					&member.ResourceOwner,
+					&member.UserResourceOwner,
+					&member.CreationDate,
+					&member.Sequence,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, member)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gm0cl", "Errors.Query.CloseRows")
+			}
+			return &GroupMembers{
+				Members: members,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareGroupGrantsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*GroupGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupGrantColumnResourceOwner.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnProjectID.identifier(),
+			GroupGrantColumnGrantID.identifier(),
+			GroupGrantColumnRoles.identifier(),
+			countColumn.identifier(),
+		).From(groupGrantTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupGrants, error) {
+			grants := make([]*GroupGrant, 0)
+			var count uint64
+			for rows.Next() {
+				grant := new(GroupGrant)
+				err := rows.Scan(
+					&grant.ID,
+					&grant.GroupID,
+					&grant.ResourceOwner,
+					&grant.CreationDate,
+					&grant.ChangeDate,
+					&grant.Sequence,
+					&grant.ProjectID,
+					&grant.ProjectGrantID,
+					&grant.RoleKeys,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				grants = append(grants, grant)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gg0cl", "Errors.Query.CloseRows")
+			}
+			return &GroupGrants{
+				Grants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
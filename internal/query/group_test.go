@@ -0,0 +1,314 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareGroupStmt = `SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description` +
+		` FROM projections.groups`
+	prepareGroupCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"resource_owner",
+		"name",
+		"description",
+	}
+	prepareGroupsStmt = `SELECT projections.groups.id,` +
+		` projections.groups.creation_date,` +
+		` projections.groups.change_date,` +
+		` projections.groups.sequence,` +
+		` projections.groups.resource_owner,` +
+		` projections.groups.name,` +
+		` projections.groups.description,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups`
+	prepareGroupsCols = append(prepareGroupCols, "count")
+
+	prepareGroupMembersStmt = `SELECT projections.groups_members.group_id,` +
+		` projections.groups_members.user_id,` +
+		` projections.groups_members.resource_owner,` +
+		` projections.groups_members.user_resource_owner,` +
+		` projections.groups_members.creation_date,` +
+		` projections.groups_members.sequence,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_members`
+	prepareGroupMembersCols = []string{
+		"group_id",
+		"user_id",
+		"resource_owner",
+		"user_resource_owner",
+		"creation_date",
+		"sequence",
+		"count",
+	}
+
+	prepareGroupGrantsStmt = `SELECT projections.groups_grants.id,` +
+		` projections.groups_grants.group_id,` +
+		` projections.groups_grants.resource_owner,` +
+		` projections.groups_grants.creation_date,` +
+		` projections.groups_grants.change_date,` +
+		` projections.groups_grants.sequence,` +
+		` projections.groups_grants.project_id,` +
+		` projections.groups_grants.grant_id,` +
+		` projections.groups_grants.roles,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.groups_grants`
+	prepareGroupGrantsCols = []string{
+		"id",
+		"group_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"project_id",
+		"grant_id",
+		"roles",
+		"count",
+	}
+)
+
+func Test_GroupPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareGroupQuery no result",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareGroupStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Group)(nil),
+		},
+		{
+			name:    "prepareGroupQuery found",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareGroupStmt),
+					prepareGroupCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						uint64(20211108),
+						"ro",
+						"name",
+						"description",
+					},
+				),
+			},
+			object: &Group{
+				ObjectDetails: domain.ObjectDetails{
+					ID:            "id",
+					CreationDate:  testNow,
+					EventDate:     testNow,
+					Sequence:      20211108,
+					ResourceOwner: "ro",
+				},
+				Name:        "name",
+				Description: "description",
+			},
+		},
+		{
+			name:    "prepareGroupQuery sql err",
+			prepare: prepareGroupQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareGroupStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Group)(nil),
+		},
+		{
+			name:    "prepareGroupsQuery no result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &Groups{Groups: []*Group{}},
+		},
+		{
+			name:    "prepareGroupsQuery one result",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					prepareGroupsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"ro",
+							"name",
+							"description",
+						},
+					},
+				),
+			},
+			object: &Groups{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Groups: []*Group{
+					{
+						ObjectDetails: domain.ObjectDetails{
+							ID:            "id",
+							CreationDate:  testNow,
+							EventDate:     testNow,
+							Sequence:      20211108,
+							ResourceOwner: "ro",
+						},
+						Name:        "name",
+						Description: "description",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupsQuery sql err",
+			prepare: prepareGroupsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareGroupsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*Groups)(nil),
+		},
+		{
+			name:    "prepareGroupMembersQuery one result",
+			prepare: prepareGroupMembersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupMembersStmt),
+					prepareGroupMembersCols,
+					[][]driver.Value{
+						{
+							"group-id",
+							"user-id",
+							"ro",
+							"user-ro",
+							testNow,
+							uint64(20211108),
+						},
+					},
+				),
+			},
+			object: &GroupMembers{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Members: []*GroupMember{
+					{
+						GroupID:           "group-id",
+						UserID:            "user-id",
+						ResourceOwner:     "ro",
+						UserResourceOwner: "user-ro",
+						CreationDate:      testNow,
+						Sequence:          20211108,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareGroupGrantsQuery one result",
+			prepare: prepareGroupGrantsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareGroupGrantsStmt),
+					prepareGroupGrantsCols,
+					[][]driver.Value{
+						{
+							"grant-id",
+							"group-id",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211108),
+							"project-id",
+							"project-grant-id",
+							database.TextArray[string]{"role"},
+						},
+					},
+				),
+			},
+			object: &GroupGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Grants: []*GroupGrant{
+					{
+						ID:             "grant-id",
+						GroupID:        "group-id",
+						ResourceOwner:  "ro",
+						CreationDate:   testNow,
+						ChangeDate:     testNow,
+						Sequence:       20211108,
+						ProjectID:      "project-id",
+						ProjectGrantID: "project-grant-id",
+						RoleKeys:       database.TextArray[string]{"role"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
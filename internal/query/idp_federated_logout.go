@@ -0,0 +1,108 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpFederatedLogoutTable = table{
+		name:          projection.IDPFederatedLogoutTable,
+		instanceIDCol: projection.IDPFederatedLogoutInstanceIDCol,
+	}
+	IDPFederatedLogoutColumnIDPID = Column{
+		name:  projection.IDPFederatedLogoutIDPIDCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnInstanceID = Column{
+		name:  projection.IDPFederatedLogoutInstanceIDCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnResourceOwner = Column{
+		name:  projection.IDPFederatedLogoutResourceOwnerCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnCreationDate = Column{
+		name:  projection.IDPFederatedLogoutCreationDateCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnChangeDate = Column{
+		name:  projection.IDPFederatedLogoutChangeDateCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnSequence = Column{
+		name:  projection.IDPFederatedLogoutSequenceCol,
+		table: idpFederatedLogoutTable,
+	}
+	IDPFederatedLogoutColumnEnabled = Column{
+		name:  projection.IDPFederatedLogoutEnabledCol,
+		table: idpFederatedLogoutTable,
+	}
+)
+
+type IDPFederatedLogout struct {
+	domain.ObjectDetails
+
+	IDPID   string
+	Enabled bool
+}
+
+// IDPFederatedLogoutByIDPID returns the federated logout setting of the IdP.
+// If it was never set, the federated logout is returned as disabled.
+func (q *Queries) IDPFederatedLogoutByIDPID(ctx context.Context, idpID string) (logout *IDPFederatedLogout, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		IDPFederatedLogoutColumnIDPID.identifier():      idpID,
+		IDPFederatedLogoutColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareIDPFederatedLogoutQuery(ctx, q.client)
+	logout, err = genericRowQuery[*IDPFederatedLogout](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if logout == nil {
+		return &IDPFederatedLogout{IDPID: idpID}, nil
+	}
+	return logout, nil
+}
+
+func prepareIDPFederatedLogoutQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPFederatedLogout, error)) {
+	return sq.Select(
+			IDPFederatedLogoutColumnIDPID.identifier(),
+			IDPFederatedLogoutColumnResourceOwner.identifier(),
+			IDPFederatedLogoutColumnCreationDate.identifier(),
+			IDPFederatedLogoutColumnChangeDate.identifier(),
+			IDPFederatedLogoutColumnSequence.identifier(),
+			IDPFederatedLogoutColumnEnabled.identifier(),
+		).From(idpFederatedLogoutTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*IDPFederatedLogout, error) {
+			logout := new(IDPFederatedLogout)
+			err := row.Scan(
+				&logout.IDPID,
+				&logout.ResourceOwner,
+				&logout.CreationDate,
+				&logout.EventDate,
+				&logout.Sequence,
+				&logout.Enabled,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, nil
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Fl1q2", "Errors.Internal")
+			}
+			return logout, nil
+		}
+}
@@ -0,0 +1,106 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	prepareIDPFederatedLogoutStmt = `SELECT projections.idp_federated_logouts.idp_id,` +
+		` projections.idp_federated_logouts.resource_owner,` +
+		` projections.idp_federated_logouts.creation_date,` +
+		` projections.idp_federated_logouts.change_date,` +
+		` projections.idp_federated_logouts.sequence,` +
+		` projections.idp_federated_logouts.enabled` +
+		` FROM projections.idp_federated_logouts`
+	prepareIDPFederatedLogoutCols = []string{
+		"idp_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"enabled",
+	}
+)
+
+func Test_IDPFederatedLogoutPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPFederatedLogoutQuery no result",
+			prepare: prepareIDPFederatedLogoutQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareIDPFederatedLogoutStmt),
+					nil,
+					nil,
+				),
+			},
+			object: (*IDPFederatedLogout)(nil),
+		},
+		{
+			name:    "prepareIDPFederatedLogoutQuery found",
+			prepare: prepareIDPFederatedLogoutQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareIDPFederatedLogoutStmt),
+					prepareIDPFederatedLogoutCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						true,
+					},
+				),
+			},
+			object: &IDPFederatedLogout{
+				ObjectDetails: domain.ObjectDetails{
+					ResourceOwner: "ro",
+					CreationDate:  testNow,
+					EventDate:     testNow,
+					Sequence:      20211109,
+				},
+				IDPID:   "idp-id",
+				Enabled: true,
+			},
+		},
+		{
+			name:    "prepareIDPFederatedLogoutQuery sql err",
+			prepare: prepareIDPFederatedLogoutQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareIDPFederatedLogoutStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPFederatedLogout)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
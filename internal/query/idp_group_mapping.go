@@ -0,0 +1,129 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpGroupMappingTable = table{
+		name:          projection.IDPGroupMappingTable,
+		instanceIDCol: projection.IDPGroupMappingInstanceIDCol,
+	}
+	IDPGroupMappingColumnIDPID = Column{
+		name:  projection.IDPGroupMappingIDPIDCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnInstanceID = Column{
+		name:  projection.IDPGroupMappingInstanceIDCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnResourceOwner = Column{
+		name:  projection.IDPGroupMappingResourceOwnerCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnCreationDate = Column{
+		name:  projection.IDPGroupMappingCreationDateCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnChangeDate = Column{
+		name:  projection.IDPGroupMappingChangeDateCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnSequence = Column{
+		name:  projection.IDPGroupMappingSequenceCol,
+		table: idpGroupMappingTable,
+	}
+	IDPGroupMappingColumnMappings = Column{
+		name:  projection.IDPGroupMappingMappingsCol,
+		table: idpGroupMappingTable,
+	}
+)
+
+type IDPGroupMappings struct {
+	domain.ObjectDetails
+
+	IDPID    string
+	Mappings []*domain.IDPGroupMapping
+}
+
+// IDPGroupMappingsByIDPID returns the group mappings of the IdP.
+// If no mappings were defined yet, an empty list is returned.
+func (q *Queries) IDPGroupMappingsByIDPID(ctx context.Context, idpID string) (mappings *IDPGroupMappings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		IDPGroupMappingColumnIDPID.identifier():      idpID,
+		IDPGroupMappingColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareIDPGroupMappingsQuery(ctx, q.client)
+	mappings, err = genericRowQuery[*IDPGroupMappings](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if mappings == nil {
+		return &IDPGroupMappings{IDPID: idpID}, nil
+	}
+	return mappings, nil
+}
+
+func prepareIDPGroupMappingsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPGroupMappings, error)) {
+	return sq.Select(
+			IDPGroupMappingColumnIDPID.identifier(),
+			IDPGroupMappingColumnResourceOwner.identifier(),
+			IDPGroupMappingColumnCreationDate.identifier(),
+			IDPGroupMappingColumnChangeDate.identifier(),
+			IDPGroupMappingColumnSequence.identifier(),
+			IDPGroupMappingColumnMappings.identifier(),
+		).From(idpGroupMappingTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*IDPGroupMappings, error) {
+			mappings := new(IDPGroupMappings)
+			var data []byte
+			err := row.Scan(
+				&mappings.IDPID,
+				&mappings.ResourceOwner,
+				&mappings.CreationDate,
+				&mappings.EventDate,
+				&mappings.Sequence,
+				&data,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, nil
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gm1q2", "Errors.Internal")
+			}
+			if len(data) == 0 {
+				return mappings, nil
+			}
+			var eventMappings []idp.GroupMapping
+			if err = json.Unmarshal(data, &eventMappings); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Gm2q2", "Errors.Internal")
+			}
+			mappings.Mappings = make([]*domain.IDPGroupMapping, len(eventMappings))
+			for i, mapping := range eventMappings {
+				mappings.Mappings[i] = &domain.IDPGroupMapping{
+					Claim:          mapping.Claim,
+					Group:          mapping.Group,
+					ProjectID:      mapping.ProjectID,
+					ProjectGrantID: mapping.ProjectGrantID,
+					RoleKeys:       mapping.RoleKeys,
+					OrgMemberRoles: mapping.OrgMemberRoles,
+				}
+			}
+			return mappings, nil
+		}
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	prepareIDPGroupMappingsStmt = `SELECT projections.idp_group_mappings.idp_id,` +
+		` projections.idp_group_mappings.resource_owner,` +
+		` projections.idp_group_mappings.creation_date,` +
+		` projections.idp_group_mappings.change_date,` +
+		` projections.idp_group_mappings.sequence,` +
+		` projections.idp_group_mappings.mappings` +
+		` FROM projections.idp_group_mappings`
+	prepareIDPGroupMappingsCols = []string{
+		"idp_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"mappings",
+	}
+)
+
+func Test_IDPGroupMappingsPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPGroupMappingsQuery no result",
+			prepare: prepareIDPGroupMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareIDPGroupMappingsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: (*IDPGroupMappings)(nil),
+		},
+		{
+			name:    "prepareIDPGroupMappingsQuery found",
+			prepare: prepareIDPGroupMappingsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareIDPGroupMappingsStmt),
+					prepareIDPGroupMappingsCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						[]byte(`[{"claim":"groups","group":"devs","projectId":"project-id","roleKeys":["dev"]},{"claim":"groups","group":"admins","orgMemberRoles":["ORG_OWNER"]}]`),
+					},
+				),
+			},
+			object: &IDPGroupMappings{
+				ObjectDetails: domain.ObjectDetails{
+					ResourceOwner: "ro",
+					CreationDate:  testNow,
+					EventDate:     testNow,
+					Sequence:      20211109,
+				},
+				IDPID: "idp-id",
+				Mappings: []*domain.IDPGroupMapping{
+					{
+						Claim:     "groups",
+						Group:     "devs",
+						ProjectID: "project-id",
+						RoleKeys:  []string{"dev"},
+					},
+					{
+						Claim:          "groups",
+						Group:          "admins",
+						OrgMemberRoles: []string{"ORG_OWNER"},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPGroupMappingsQuery sql err",
+			prepare: prepareIDPGroupMappingsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareIDPGroupMappingsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPGroupMappings)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	idpTokenExchangeClientsTable = table{
+		name:          projection.IDPTokenExchangeClientsTable,
+		instanceIDCol: projection.IDPTokenExchangeClientsInstanceIDCol,
+	}
+	IDPTokenExchangeClientsColumnIDPID = Column{
+		name:  projection.IDPTokenExchangeClientsIDPIDCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnInstanceID = Column{
+		name:  projection.IDPTokenExchangeClientsInstanceIDCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnResourceOwner = Column{
+		name:  projection.IDPTokenExchangeClientsResourceOwnerCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnCreationDate = Column{
+		name:  projection.IDPTokenExchangeClientsCreationDateCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnChangeDate = Column{
+		name:  projection.IDPTokenExchangeClientsChangeDateCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnSequence = Column{
+		name:  projection.IDPTokenExchangeClientsSequenceCol,
+		table: idpTokenExchangeClientsTable,
+	}
+	IDPTokenExchangeClientsColumnClientIDs = Column{
+		name:  projection.IDPTokenExchangeClientsClientIDsCol,
+		table: idpTokenExchangeClientsTable,
+	}
+)
+
+type IDPTokenExchangeClients struct {
+	domain.ObjectDetails
+
+	IDPID     string
+	ClientIDs database.TextArray[string]
+}
+
+// IDPTokenExchangeClientsByIDPID returns the clients, which are allowed to exchange a token of a user
+// for the (stored) access token of the IdP.
+// If it was never set, an empty list is returned.
+func (q *Queries) IDPTokenExchangeClientsByIDPID(ctx context.Context, idpID string) (clients *IDPTokenExchangeClients, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		IDPTokenExchangeClientsColumnIDPID.identifier():      idpID,
+		IDPTokenExchangeClientsColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareIDPTokenExchangeClientsQuery(ctx, q.client)
+	clients, err = genericRowQuery[*IDPTokenExchangeClients](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if clients == nil {
+		return &IDPTokenExchangeClients{IDPID: idpID}, nil
+	}
+	return clients, nil
+}
+
+func prepareIDPTokenExchangeClientsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*IDPTokenExchangeClients, error)) {
+	return sq.Select(
+			IDPTokenExchangeClientsColumnIDPID.identifier(),
+			IDPTokenExchangeClientsColumnResourceOwner.identifier(),
+			IDPTokenExchangeClientsColumnCreationDate.identifier(),
+			IDPTokenExchangeClientsColumnChangeDate.identifier(),
+			IDPTokenExchangeClientsColumnSequence.identifier(),
+			IDPTokenExchangeClientsColumnClientIDs.identifier(),
+		).From(idpTokenExchangeClientsTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*IDPTokenExchangeClients, error) {
+			clients := new(IDPTokenExchangeClients)
+			err := row.Scan(
+				&clients.IDPID,
+				&clients.ResourceOwner,
+				&clients.CreationDate,
+				&clients.EventDate,
+				&clients.Sequence,
+				&clients.ClientIDs,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, nil
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Tx1q2", "Errors.Internal")
+			}
+			return clients, nil
+		}
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	prepareIDPTokenExchangeClientsStmt = `SELECT projections.idp_token_exchange_clients.idp_id,` +
+		` projections.idp_token_exchange_clients.resource_owner,` +
+		` projections.idp_token_exchange_clients.creation_date,` +
+		` projections.idp_token_exchange_clients.change_date,` +
+		` projections.idp_token_exchange_clients.sequence,` +
+		` projections.idp_token_exchange_clients.client_ids` +
+		` FROM projections.idp_token_exchange_clients`
+	prepareIDPTokenExchangeClientsCols = []string{
+		"idp_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"client_ids",
+	}
+)
+
+func Test_IDPTokenExchangeClientsPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareIDPTokenExchangeClientsQuery no result",
+			prepare: prepareIDPTokenExchangeClientsQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareIDPTokenExchangeClientsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: (*IDPTokenExchangeClients)(nil),
+		},
+		{
+			name:    "prepareIDPTokenExchangeClientsQuery found",
+			prepare: prepareIDPTokenExchangeClientsQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareIDPTokenExchangeClientsStmt),
+					prepareIDPTokenExchangeClientsCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						database.TextArray[string]{"client1"},
+					},
+				),
+			},
+			object: &IDPTokenExchangeClients{
+				ObjectDetails: domain.ObjectDetails{
+					ResourceOwner: "ro",
+					CreationDate:  testNow,
+					EventDate:     testNow,
+					Sequence:      20211109,
+				},
+				IDPID:     "idp-id",
+				ClientIDs: database.TextArray[string]{"client1"},
+			},
+		},
+		{
+			name:    "prepareIDPTokenExchangeClientsQuery sql err",
+			prepare: prepareIDPTokenExchangeClientsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareIDPTokenExchangeClientsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*IDPTokenExchangeClients)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -158,6 +158,10 @@ func NewIDPUserLinksUserIDSearchQuery(value string) (SearchQuery, error) {
 	return NewTextQuery(IDPUserLinkUserIDCol, value, TextEquals)
 }
 
+func NewIDPUserLinksUserIDsSearchQuery(values []string) (SearchQuery, error) {
+	return NewInTextQuery(IDPUserLinkUserIDCol, values)
+}
+
 func NewIDPUserLinksResourceOwnerSearchQuery(value string) (SearchQuery, error) {
 	return NewTextQuery(IDPUserLinkResourceOwnerCol, value, TextEquals)
 }
@@ -0,0 +1,204 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type InactivityPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	InactivityPeriod time.Duration
+	WarningPeriod    time.Duration
+	DryRun           bool
+
+	IsDefault bool
+}
+
+// Enabled reports if users are deactivated after the inactivity period.
+func (p *InactivityPolicy) Enabled() bool {
+	return p.InactivityPeriod > 0
+}
+
+var (
+	inactivityPolicyTable = table{
+		name:          projection.InactivityPolicyTable,
+		instanceIDCol: projection.InactivityPolicyInstanceIDCol,
+	}
+	InactivityPolicyColID = Column{
+		name:  projection.InactivityPolicyIDCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColInstanceID = Column{
+		name:  projection.InactivityPolicyInstanceIDCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColSequence = Column{
+		name:  projection.InactivityPolicySequenceCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColCreationDate = Column{
+		name:  projection.InactivityPolicyCreationDateCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColChangeDate = Column{
+		name:  projection.InactivityPolicyChangeDateCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColResourceOwner = Column{
+		name:  projection.InactivityPolicyResourceOwnerCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColInactivityPeriod = Column{
+		name:  projection.InactivityPolicyInactivityPeriodCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColWarningPeriod = Column{
+		name:  projection.InactivityPolicyWarningPeriodCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColDryRun = Column{
+		name:  projection.InactivityPolicyDryRunCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColIsDefault = Column{
+		name:  projection.InactivityPolicyIsDefaultCol,
+		table: inactivityPolicyTable,
+	}
+	InactivityPolicyColState = Column{
+		name:  projection.InactivityPolicyStateCol,
+		table: inactivityPolicyTable,
+	}
+)
+
+// InactivityPolicyByOrg returns the inactivity policy of the organization, its nearest ancestor or the instance.
+// If none of them defines a policy, a disabled default policy is returned.
+func (q *Queries) InactivityPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string) (policy *InactivityPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerInactivityPolicyProjection")
+		ctx, err = projection.InactivityPolicyProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+	eq := sq.Eq{
+		InactivityPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+
+	stmt, scan := prepareInactivityPolicyQuery(ctx, q.client)
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
+		sq.And{
+			eq,
+			orgOrAncestorsCond(InactivityPolicyColID, authz.GetInstance(ctx).InstanceID()),
+		}).
+		OrderBy(InactivityPolicyColIsDefault.identifier(), orgAncestorsOrder(InactivityPolicyColID)).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ina1q", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	if zerrors.IsNotFound(err) {
+		return disabledInactivityPolicy(ctx), nil
+	}
+	return policy, err
+}
+
+// DefaultInactivityPolicy returns the inactivity policy of the instance.
+// If the instance does not define a policy, a disabled policy is returned.
+func (q *Queries) DefaultInactivityPolicy(ctx context.Context) (policy *InactivityPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareInactivityPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		InactivityPolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		InactivityPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(InactivityPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ina2q", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	if zerrors.IsNotFound(err) {
+		return disabledInactivityPolicy(ctx), nil
+	}
+	return policy, err
+}
+
+func disabledInactivityPolicy(ctx context.Context) *InactivityPolicy {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return &InactivityPolicy{
+		ID:            instanceID,
+		ResourceOwner: instanceID,
+		State:         domain.PolicyStateActive,
+		IsDefault:     true,
+	}
+}
+
+func prepareInactivityPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*InactivityPolicy, error)) {
+	return sq.Select(
+			InactivityPolicyColID.identifier(),
+			InactivityPolicyColSequence.identifier(),
+			InactivityPolicyColCreationDate.identifier(),
+			InactivityPolicyColChangeDate.identifier(),
+			InactivityPolicyColResourceOwner.identifier(),
+			InactivityPolicyColInactivityPeriod.identifier(),
+			InactivityPolicyColWarningPeriod.identifier(),
+			InactivityPolicyColDryRun.identifier(),
+			InactivityPolicyColIsDefault.identifier(),
+			InactivityPolicyColState.identifier(),
+		).
+			From(inactivityPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*InactivityPolicy, error) {
+			policy := new(InactivityPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.InactivityPeriod,
+				&policy.WarningPeriod,
+				&policy.DryRun,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ina3q", "Errors.Org.InactivityPolicy.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ina4q", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,130 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareInactivityPolicyStmt = `SELECT projections.inactivity_policies.id,` +
+		` projections.inactivity_policies.sequence,` +
+		` projections.inactivity_policies.creation_date,` +
+		` projections.inactivity_policies.change_date,` +
+		` projections.inactivity_policies.resource_owner,` +
+		` projections.inactivity_policies.inactivity_period,` +
+		` projections.inactivity_policies.warning_period,` +
+		` projections.inactivity_policies.dry_run,` +
+		` projections.inactivity_policies.is_default,` +
+		` projections.inactivity_policies.state` +
+		` FROM projections.inactivity_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`
+
+	prepareInactivityPolicyCols = []string{
+		"id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"inactivity_period",
+		"warning_period",
+		"dry_run",
+		"is_default",
+		"state",
+	}
+)
+
+func Test_InactivityPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareInactivityPolicyQuery no result",
+			prepare: prepareInactivityPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareInactivityPolicyStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*InactivityPolicy)(nil),
+		},
+		{
+			name:    "prepareInactivityPolicyQuery found",
+			prepare: prepareInactivityPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareInactivityPolicyStmt),
+					prepareInactivityPolicyCols,
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						int64(90 * 24 * time.Hour),
+						int64(7 * 24 * time.Hour),
+						true,
+						false,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &InactivityPolicy{
+				ID:               "pol-id",
+				CreationDate:     testNow,
+				ChangeDate:       testNow,
+				Sequence:         20211109,
+				ResourceOwner:    "ro",
+				State:            domain.PolicyStateActive,
+				InactivityPeriod: 90 * 24 * time.Hour,
+				WarningPeriod:    7 * 24 * time.Hour,
+				DryRun:           true,
+				IsDefault:        false,
+			},
+		},
+		{
+			name:    "prepareInactivityPolicyQuery sql err",
+			prepare: prepareInactivityPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareInactivityPolicyStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*InactivityPolicy)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
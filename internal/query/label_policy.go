@@ -55,15 +55,12 @@ func (q *Queries) ActiveLabelPolicyByOrg(ctx context.Context, orgID string, with
 	if !withOwnerRemoved {
 		eq[LabelPolicyOwnerRemoved.identifier()] = false
 	}
-	query, args, err := stmt.Where(
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
-			sq.Or{
-				sq.Eq{LabelPolicyColID.identifier(): orgID},
-				sq.Eq{LabelPolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
-			},
+			orgOrAncestorsCond(LabelPolicyColID, authz.GetInstance(ctx).InstanceID()),
 			eq,
 		}).
-		OrderBy(LabelPolicyColIsDefault.identifier()).
+		OrderBy(LabelPolicyColIsDefault.identifier(), orgAncestorsOrder(LabelPolicyColID)).
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-V22un", "unable to create sql stmt")
@@ -81,22 +78,15 @@ func (q *Queries) PreviewLabelPolicyByOrg(ctx context.Context, orgID string) (po
 	defer func() { span.EndWithError(err) }()
 
 	stmt, scan := prepareLabelPolicyQuery(ctx, q.client)
-	query, args, err := stmt.Where(
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
-			sq.Or{
-				sq.Eq{
-					LabelPolicyColID.identifier(): orgID,
-				},
-				sq.Eq{
-					LabelPolicyColID.identifier(): authz.GetInstance(ctx).InstanceID(),
-				},
-			},
+			orgOrAncestorsCond(LabelPolicyColID, authz.GetInstance(ctx).InstanceID()),
 			sq.Eq{
 				LabelPolicyColState.identifier():      domain.LabelPolicyStatePreview,
 				LabelPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
 			},
 		}).
-		OrderBy(LabelPolicyColIsDefault.identifier()).
+		OrderBy(LabelPolicyColIsDefault.identifier(), orgAncestorsOrder(LabelPolicyColID)).
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-AG5eq", "unable to create sql stmt")
@@ -99,15 +99,12 @@ func (q *Queries) LockoutPolicyByOrg(ctx context.Context, shouldTriggerBulk bool
 	}
 
 	stmt, scan := prepareLockoutPolicyQuery(ctx, q.client)
-	query, args, err := stmt.Where(
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
 			eq,
-			sq.Or{
-				sq.Eq{LockoutColID.identifier(): orgID},
-				sq.Eq{LockoutColID.identifier(): authz.GetInstance(ctx).InstanceID()},
-			},
+			orgOrAncestorsCond(LockoutColID, authz.GetInstance(ctx).InstanceID()),
 		}).
-		OrderBy(LockoutColIsDefault.identifier()).
+		OrderBy(LockoutColIsDefault.identifier(), orgAncestorsOrder(LockoutColID)).
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-SKR6X", "Errors.Query.SQLStatement")
@@ -184,14 +184,11 @@ func (q *Queries) LoginPolicyByID(ctx context.Context, shouldTriggerBulk bool, o
 	}
 
 	query, scan := prepareLoginPolicyQuery(ctx, q.client)
-	stmt, args, err := query.Where(
+	stmt, args, err := withOrgAncestors(query, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
 			eq,
-			sq.Or{
-				sq.Eq{LoginPolicyColumnOrgID.identifier(): orgID},
-				sq.Eq{LoginPolicyColumnOrgID.identifier(): authz.GetInstance(ctx).InstanceID()},
-			},
-		}).Limit(1).OrderBy(LoginPolicyColumnIsDefault.identifier()).ToSql()
+			orgOrAncestorsCond(LoginPolicyColumnOrgID, authz.GetInstance(ctx).InstanceID()),
+		}).Limit(1).OrderBy(LoginPolicyColumnIsDefault.identifier(), orgAncestorsOrder(LoginPolicyColumnOrgID)).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-scVHo", "Errors.Query.SQLStatement")
 	}
@@ -31,6 +31,39 @@ func (q *Queries) GetOrgMemberRoles(isGlobal bool) []string {
 	return roles
 }
 
+// GetCustomIAMMemberRoles returns the keys of the custom roles of the instance which can be assigned to instance members.
+func (q *Queries) GetCustomIAMMemberRoles(ctx context.Context) ([]string, error) {
+	mappings, err := q.CustomRoleMappingsOfOrg(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return customMemberRoles(mappings, domain.IAMRolePrefix, ""), nil
+}
+
+// GetCustomOrgMemberRoles returns the keys of the custom roles of the instance and the organization
+// which can be assigned to members of the organization.
+func (q *Queries) GetCustomOrgMemberRoles(ctx context.Context, orgID string) ([]string, error) {
+	mappings, err := q.CustomRoleMappingsOfOrg(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return customMemberRoles(mappings, domain.OrgRolePrefix, orgID), nil
+}
+
+func customMemberRoles(mappings []authz.RoleMapping, prefix, orgID string) []string {
+	roles := make([]string, 0, len(mappings))
+	for _, mapping := range mappings {
+		if !strings.HasPrefix(mapping.Role, prefix) {
+			continue
+		}
+		if mapping.ResourceOwner != "" && mapping.ResourceOwner != orgID {
+			continue
+		}
+		roles = append(roles, mapping.Role)
+	}
+	return roles
+}
+
 func (q *Queries) GetProjectMemberRoles(ctx context.Context) ([]string, error) {
 	instance, err := q.Instance(ctx, false)
 	if err != nil {
@@ -0,0 +1,275 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	notificationDeliveryTable = table{
+		name:          projection.NotificationDeliveryTable,
+		instanceIDCol: projection.NotificationDeliveryInstanceIDCol,
+	}
+	NotificationDeliveryColumnID = Column{
+		name:  projection.NotificationDeliveryIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnInstanceID = Column{
+		name:  projection.NotificationDeliveryInstanceIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnCreationDate = Column{
+		name:  projection.NotificationDeliveryCreationDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChangeDate = Column{
+		name:  projection.NotificationDeliveryChangeDateCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnSequence = Column{
+		name:  projection.NotificationDeliverySequenceCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnUserID = Column{
+		name:  projection.NotificationDeliveryUserIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnUserResourceOwner = Column{
+		name:  projection.NotificationDeliveryUserResourceOwnerCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnMessageType = Column{
+		name:  projection.NotificationDeliveryMessageTypeCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnChannel = Column{
+		name:  projection.NotificationDeliveryChannelCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnStatus = Column{
+		name:  projection.NotificationDeliveryStatusCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnAttempts = Column{
+		name:  projection.NotificationDeliveryAttemptsCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnProviderID = Column{
+		name:  projection.NotificationDeliveryProviderIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnProviderType = Column{
+		name:  projection.NotificationDeliveryProviderTypeCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnRecipient = Column{
+		name:  projection.NotificationDeliveryRecipientCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnProviderMessageID = Column{
+		name:  projection.NotificationDeliveryProviderMessageIDCol,
+		table: notificationDeliveryTable,
+	}
+	NotificationDeliveryColumnError = Column{
+		name:  projection.NotificationDeliveryErrorCol,
+		table: notificationDeliveryTable,
+	}
+)
+
+type NotificationDeliveries struct {
+	SearchResponse
+	NotificationDeliveries []*NotificationDelivery
+}
+
+func (d *NotificationDeliveries) SetState(s *State) {
+	d.State = s
+}
+
+// NotificationDelivery is the record of a notification sent by the notification worker.
+// The recipient is masked, Error contains the error of the last failed attempt or the reason reported by the provider.
+type NotificationDelivery struct {
+	ID                string
+	CreationDate      time.Time
+	ChangeDate        time.Time
+	Sequence          uint64
+	UserID            string
+	UserResourceOwner string
+	MessageType       string
+	Channel           domain.NotificationType
+	Status            domain.NotificationDeliveryStatus
+	Attempts          uint64
+	ProviderID        string
+	ProviderType      string
+	Recipient         string
+	ProviderMessageID string
+	Error             string
+}
+
+type NotificationDeliverySearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *NotificationDeliverySearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) NotificationDeliveryByID(ctx context.Context, id string) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareNotificationDeliveryQuery(ctx, q.client)
+	return genericRowQuery[*NotificationDelivery](ctx, q.client, query.Where(sq.Eq{
+		NotificationDeliveryColumnID.identifier():         id,
+		NotificationDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}), scan)
+}
+
+// NotificationDeliveryByProviderMessageID returns the delivery of the instance the provider sent with the message id.
+// Providers might report the status right after sending, so the projection should be triggered on their callbacks.
+func (q *Queries) NotificationDeliveryByProviderMessageID(ctx context.Context, shouldTriggerBulk bool, providerMessageID string) (_ *NotificationDelivery, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if providerMessageID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Nd1mI", "Errors.Notification.Delivery.NotFound")
+	}
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerNotificationDeliveryProjection")
+		ctx, err = projection.NotificationDeliveryProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+	query, scan := prepareNotificationDeliveryQuery(ctx, q.client)
+	return genericRowQuery[*NotificationDelivery](ctx, q.client, query.Where(sq.Eq{
+		NotificationDeliveryColumnProviderMessageID.identifier(): providerMessageID,
+		NotificationDeliveryColumnInstanceID.identifier():        authz.GetInstance(ctx).InstanceID(),
+	}), scan)
+}
+
+func (q *Queries) SearchNotificationDeliveries(ctx context.Context, queries *NotificationDeliverySearchQueries) (_ *NotificationDeliveries, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		NotificationDeliveryColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareNotificationDeliveriesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*NotificationDeliveries](ctx, q.client, notificationDeliveryTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewNotificationDeliveryUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnUserID, value, TextEquals)
+}
+
+func NewNotificationDeliveryMessageTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(NotificationDeliveryColumnMessageType, value, TextEquals)
+}
+
+func NewNotificationDeliveryChannelSearchQuery(value domain.NotificationType) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnChannel, value, NumberEquals)
+}
+
+func NewNotificationDeliveryStatusSearchQuery(value domain.NotificationDeliveryStatus) (SearchQuery, error) {
+	return NewNumberQuery(NotificationDeliveryColumnStatus, value, NumberEquals)
+}
+
+func notificationDeliveryColumns() []string {
+	return []string{
+		NotificationDeliveryColumnID.identifier(),
+		NotificationDeliveryColumnCreationDate.identifier(),
+		NotificationDeliveryColumnChangeDate.identifier(),
+		NotificationDeliveryColumnSequence.identifier(),
+		NotificationDeliveryColumnUserID.identifier(),
+		NotificationDeliveryColumnUserResourceOwner.identifier(),
+		NotificationDeliveryColumnMessageType.identifier(),
+		NotificationDeliveryColumnChannel.identifier(),
+		NotificationDeliveryColumnStatus.identifier(),
+		NotificationDeliveryColumnAttempts.identifier(),
+		NotificationDeliveryColumnProviderID.identifier(),
+		NotificationDeliveryColumnProviderType.identifier(),
+		NotificationDeliveryColumnRecipient.identifier(),
+		NotificationDeliveryColumnProviderMessageID.identifier(),
+		NotificationDeliveryColumnError.identifier(),
+	}
+}
+
+func scanNotificationDelivery(scan func(dest ...any) error, dest ...any) (*NotificationDelivery, error) {
+	delivery := new(NotificationDelivery)
+	err := scan(append([]any{
+		&delivery.ID,
+		&delivery.CreationDate,
+		&delivery.ChangeDate,
+		&delivery.Sequence,
+		&delivery.UserID,
+		&delivery.UserResourceOwner,
+		&delivery.MessageType,
+		&delivery.Channel,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.ProviderID,
+		&delivery.ProviderType,
+		&delivery.Recipient,
+		&delivery.ProviderMessageID,
+		&delivery.Error,
+	}, dest...)...)
+	return delivery, err
+}
+
+func prepareNotificationDeliveryQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*NotificationDelivery, error)) {
+	return sq.Select(notificationDeliveryColumns()...).
+			From(notificationDeliveryTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*NotificationDelivery, error) {
+			delivery, err := scanNotificationDelivery(row.Scan)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Nd2nF", "Errors.Notification.Delivery.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Nd3sc", "Errors.Internal")
+			}
+			return delivery, nil
+		}
+}
+
+func prepareNotificationDeliveriesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationDeliveries, error)) {
+	return sq.Select(append(notificationDeliveryColumns(), countColumn.identifier())...).
+			From(notificationDeliveryTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationDeliveries, error) {
+			deliveries := make([]*NotificationDelivery, 0)
+			var count uint64
+			for rows.Next() {
+				delivery, err := scanNotificationDelivery(rows.Scan, &count)
+				if err != nil {
+					return nil, err
+				}
+				deliveries = append(deliveries, delivery)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Nd4cl", "Errors.Query.CloseRows")
+			}
+			return &NotificationDeliveries{
+				NotificationDeliveries: deliveries,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,208 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	notificationDeliverySelect = `SELECT projections.notification_deliveries.id,` +
+		` projections.notification_deliveries.creation_date,` +
+		` projections.notification_deliveries.change_date,` +
+		` projections.notification_deliveries.sequence,` +
+		` projections.notification_deliveries.user_id,` +
+		` projections.notification_deliveries.user_resource_owner,` +
+		` projections.notification_deliveries.message_type,` +
+		` projections.notification_deliveries.channel,` +
+		` projections.notification_deliveries.status,` +
+		` projections.notification_deliveries.attempts,` +
+		` projections.notification_deliveries.provider_id,` +
+		` projections.notification_deliveries.provider_type,` +
+		` projections.notification_deliveries.recipient,` +
+		` projections.notification_deliveries.provider_message_id,` +
+		` projections.notification_deliveries.error`
+	prepareNotificationDeliveryStmt   = notificationDeliverySelect + ` FROM projections.notification_deliveries`
+	prepareNotificationDeliveriesStmt = notificationDeliverySelect + `, COUNT(*) OVER () FROM projections.notification_deliveries`
+	prepareNotificationDeliveryCols   = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"user_id",
+		"user_resource_owner",
+		"message_type",
+		"channel",
+		"status",
+		"attempts",
+		"provider_id",
+		"provider_type",
+		"recipient",
+		"provider_message_id",
+		"error",
+	}
+	prepareNotificationDeliveriesCols = append(prepareNotificationDeliveryCols, "count")
+)
+
+func Test_NotificationDeliveryPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationDeliveryQuery no result",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareNotificationDeliveryStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationDelivery)(nil),
+		},
+		{
+			name:    "prepareNotificationDeliveryQuery found",
+			prepare: prepareNotificationDeliveryQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareNotificationDeliveryStmt),
+					prepareNotificationDeliveryCols,
+					[]driver.Value{
+						"notification-id",
+						testNow,
+						testNow,
+						uint64(20211111),
+						"user-id",
+						"org-id",
+						domain.InitCodeMessageType,
+						domain.NotificationTypeEmail,
+						domain.NotificationDeliveryStatusBounced,
+						uint64(1),
+						"provider-id",
+						"smtp",
+						"g***@zitadel.com",
+						"<message-id@zitadel.com>",
+						"mailbox full",
+					},
+				),
+			},
+			object: &NotificationDelivery{
+				ID:                "notification-id",
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211111,
+				UserID:            "user-id",
+				UserResourceOwner: "org-id",
+				MessageType:       domain.InitCodeMessageType,
+				Channel:           domain.NotificationTypeEmail,
+				Status:            domain.NotificationDeliveryStatusBounced,
+				Attempts:          1,
+				ProviderID:        "provider-id",
+				ProviderType:      "smtp",
+				Recipient:         "g***@zitadel.com",
+				ProviderMessageID: "<message-id@zitadel.com>",
+				Error:             "mailbox full",
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery no result",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationDeliveries{NotificationDeliveries: []*NotificationDelivery{}},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery found",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					prepareNotificationDeliveriesCols,
+					[][]driver.Value{
+						{
+							"notification-id",
+							testNow,
+							testNow,
+							uint64(20211111),
+							"user-id",
+							"org-id",
+							domain.VerifyPhoneMessageType,
+							domain.NotificationTypeSms,
+							domain.NotificationDeliveryStatusPending,
+							uint64(0),
+							"",
+							"",
+							"",
+							"",
+							"",
+						},
+					},
+				),
+			},
+			object: &NotificationDeliveries{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				NotificationDeliveries: []*NotificationDelivery{
+					{
+						ID:                "notification-id",
+						CreationDate:      testNow,
+						ChangeDate:        testNow,
+						Sequence:          20211111,
+						UserID:            "user-id",
+						UserResourceOwner: "org-id",
+						MessageType:       domain.VerifyPhoneMessageType,
+						Channel:           domain.NotificationTypeSms,
+						Status:            domain.NotificationDeliveryStatusPending,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationDeliveriesQuery sql err",
+			prepare: prepareNotificationDeliveriesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationDeliveriesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationDeliveries)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
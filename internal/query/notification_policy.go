@@ -25,7 +25,13 @@ type NotificationPolicy struct {
 	ResourceOwner string
 	State         domain.PolicyState
 
-	PasswordChange bool
+	PasswordChange           bool
+	NewDeviceSignIn          bool
+	MFAAdded                 bool
+	MFARemoved               bool
+	EmailChange              bool
+	AccountLocked            bool
+	PersonalAccessTokenAdded bool
 
 	IsDefault bool
 }
@@ -63,6 +69,30 @@ var (
 		name:  projection.NotificationPolicyColumnPasswordChange,
 		table: notificationPolicyTable,
 	}
+	NotificationPolicyColNewDeviceSignIn = Column{
+		name:  projection.NotificationPolicyColumnNewDeviceSignIn,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFAAdded = Column{
+		name:  projection.NotificationPolicyColumnMFAAdded,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColMFARemoved = Column{
+		name:  projection.NotificationPolicyColumnMFARemoved,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColEmailChange = Column{
+		name:  projection.NotificationPolicyColumnEmailChange,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColAccountLocked = Column{
+		name:  projection.NotificationPolicyColumnAccountLocked,
+		table: notificationPolicyTable,
+	}
+	NotificationPolicyColPersonalAccessTokenAdded = Column{
+		name:  projection.NotificationPolicyColumnPersonalAccessTokenAdded,
+		table: notificationPolicyTable,
+	}
 	NotificationPolicyColIsDefault = Column{
 		name:  projection.NotificationPolicyColumnIsDefault,
 		table: notificationPolicyTable,
@@ -153,6 +183,12 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 			NotificationPolicyColChangeDate.identifier(),
 			NotificationPolicyColResourceOwner.identifier(),
 			NotificationPolicyColPasswordChange.identifier(),
+			NotificationPolicyColNewDeviceSignIn.identifier(),
+			NotificationPolicyColMFAAdded.identifier(),
+			NotificationPolicyColMFARemoved.identifier(),
+			NotificationPolicyColEmailChange.identifier(),
+			NotificationPolicyColAccountLocked.identifier(),
+			NotificationPolicyColPersonalAccessTokenAdded.identifier(),
 			NotificationPolicyColIsDefault.identifier(),
 			NotificationPolicyColState.identifier(),
 		).
@@ -167,6 +203,12 @@ func prepareNotificationPolicyQuery(ctx context.Context, db prepareDatabase) (sq
 				&policy.ChangeDate,
 				&policy.ResourceOwner,
 				&policy.PasswordChange,
+				&policy.NewDeviceSignIn,
+				&policy.MFAAdded,
+				&policy.MFARemoved,
+				&policy.EmailChange,
+				&policy.AccountLocked,
+				&policy.PersonalAccessTokenAdded,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -13,15 +13,21 @@ import (
 )
 
 var (
-	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies.id,` +
-		` projections.notification_policies.sequence,` +
-		` projections.notification_policies.creation_date,` +
-		` projections.notification_policies.change_date,` +
-		` projections.notification_policies.resource_owner,` +
-		` projections.notification_policies.password_change,` +
-		` projections.notification_policies.is_default,` +
-		` projections.notification_policies.state` +
-		` FROM projections.notification_policies` +
+	notificationPolicyStmt = regexp.QuoteMeta(`SELECT projections.notification_policies2.id,` +
+		` projections.notification_policies2.sequence,` +
+		` projections.notification_policies2.creation_date,` +
+		` projections.notification_policies2.change_date,` +
+		` projections.notification_policies2.resource_owner,` +
+		` projections.notification_policies2.password_change,` +
+		` projections.notification_policies2.new_device_sign_in,` +
+		` projections.notification_policies2.mfa_added,` +
+		` projections.notification_policies2.mfa_removed,` +
+		` projections.notification_policies2.email_change,` +
+		` projections.notification_policies2.account_locked,` +
+		` projections.notification_policies2.personal_access_token_added,` +
+		` projections.notification_policies2.is_default,` +
+		` projections.notification_policies2.state` +
+		` FROM projections.notification_policies2` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	notificationPolicyCols = []string{
 		"id",
@@ -30,6 +36,12 @@ var (
 		"change_date",
 		"resource_owner",
 		"password_change",
+		"new_device_sign_in",
+		"mfa_added",
+		"mfa_removed",
+		"email_change",
+		"account_locked",
+		"personal_access_token_added",
 		"is_default",
 		"state",
 	}
@@ -79,19 +91,28 @@ func Test_NotificationPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						true,
+						false,
+						true,
+						false,
+						true,
+						false,
+						true,
 						domain.PolicyStateActive,
 					},
 				),
 			},
 			object: &NotificationPolicy{
-				ID:             "pol-id",
-				CreationDate:   testNow,
-				ChangeDate:     testNow,
-				Sequence:       20211109,
-				ResourceOwner:  "ro",
-				State:          domain.PolicyStateActive,
-				PasswordChange: true,
-				IsDefault:      true,
+				ID:              "pol-id",
+				CreationDate:    testNow,
+				ChangeDate:      testNow,
+				Sequence:        20211109,
+				ResourceOwner:   "ro",
+				State:           domain.PolicyStateActive,
+				PasswordChange:  true,
+				NewDeviceSignIn: true,
+				MFARemoved:      true,
+				AccountLocked:   true,
+				IsDefault:       true,
 			},
 		},
 		{
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type NotificationTemplates struct {
+	SearchResponse
+	Templates []*NotificationTemplate
+}
+
+func (t *NotificationTemplates) SetState(s *State) {
+	t.State = s
+}
+
+// ForLanguage returns the template in the language or nil if the templates do not contain the language.
+func (t *NotificationTemplates) ForLanguage(lang language.Tag) *NotificationTemplate {
+	if t == nil {
+		return nil
+	}
+	for _, template := range t.Templates {
+		if template.Language == lang {
+			return template
+		}
+	}
+	return nil
+}
+
+type NotificationTemplate struct {
+	AggregateID  string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Sequence     uint64
+	MessageType  string
+	Language     language.Tag
+	Subject      string
+	HTML         string
+	Text         string
+}
+
+var (
+	notificationTemplateTable = table{
+		name:          projection.NotificationTemplateTable,
+		instanceIDCol: projection.NotificationTemplateInstanceIDCol,
+	}
+	NotificationTemplateColAggregateID = Column{
+		name:  projection.NotificationTemplateAggregateIDCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColInstanceID = Column{
+		name:  projection.NotificationTemplateInstanceIDCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColCreationDate = Column{
+		name:  projection.NotificationTemplateCreationDateCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColChangeDate = Column{
+		name:  projection.NotificationTemplateChangeDateCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColSequence = Column{
+		name:  projection.NotificationTemplateSequenceCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColMessageType = Column{
+		name:  projection.NotificationTemplateMessageTypeCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColLanguage = Column{
+		name:  projection.NotificationTemplateLanguageCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColSubject = Column{
+		name:  projection.NotificationTemplateSubjectCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColHTML = Column{
+		name:  projection.NotificationTemplateHTMLCol,
+		table: notificationTemplateTable,
+	}
+	NotificationTemplateColText = Column{
+		name:  projection.NotificationTemplateTextCol,
+		table: notificationTemplateTable,
+	}
+)
+
+// NotificationTemplatesByOrg returns the custom templates of the organization in all languages.
+// If the message type is empty, the templates of all message types are returned.
+func (q *Queries) NotificationTemplatesByOrg(ctx context.Context, orgID, messageType string) (_ *NotificationTemplates, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		NotificationTemplateColInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+		NotificationTemplateColAggregateID.identifier(): orgID,
+	}
+	if messageType != "" {
+		eq[NotificationTemplateColMessageType.identifier()] = messageType
+	}
+	query, scan := prepareNotificationTemplatesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*NotificationTemplates](ctx, q.client, notificationTemplateTable,
+		query.Where(eq).OrderBy(NotificationTemplateColMessageType.identifier(), NotificationTemplateColLanguage.identifier()),
+		scan,
+	)
+}
+
+func prepareNotificationTemplatesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*NotificationTemplates, error)) {
+	return sq.Select(
+			NotificationTemplateColAggregateID.identifier(),
+			NotificationTemplateColCreationDate.identifier(),
+			NotificationTemplateColChangeDate.identifier(),
+			NotificationTemplateColSequence.identifier(),
+			NotificationTemplateColMessageType.identifier(),
+			NotificationTemplateColLanguage.identifier(),
+			NotificationTemplateColSubject.identifier(),
+			NotificationTemplateColHTML.identifier(),
+			NotificationTemplateColText.identifier(),
+			countColumn.identifier(),
+		).
+			From(notificationTemplateTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*NotificationTemplates, error) {
+			templates := make([]*NotificationTemplate, 0)
+			var count uint64
+			for rows.Next() {
+				template := new(NotificationTemplate)
+				var lang string
+				err := rows.Scan(
+					&template.AggregateID,
+					&template.CreationDate,
+					&template.ChangeDate,
+					&template.Sequence,
+					&template.MessageType,
+					&lang,
+					&template.Subject,
+					&template.HTML,
+					&template.Text,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				template.Language = language.Make(lang)
+				templates = append(templates, template)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Nt1cl", "Errors.Query.CloseRows")
+			}
+			return &NotificationTemplates{
+				Templates: templates,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,140 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+var (
+	prepareNotificationTemplatesStmt = `SELECT projections.notification_templates.aggregate_id,` +
+		` projections.notification_templates.creation_date,` +
+		` projections.notification_templates.change_date,` +
+		` projections.notification_templates.sequence,` +
+		` projections.notification_templates.message_type,` +
+		` projections.notification_templates.language,` +
+		` projections.notification_templates.subject,` +
+		` projections.notification_templates.html,` +
+		` projections.notification_templates.text,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.notification_templates`
+	prepareNotificationTemplatesCols = []string{
+		"aggregate_id",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"message_type",
+		"language",
+		"subject",
+		"html",
+		"text",
+		"count",
+	}
+)
+
+func Test_NotificationTemplatePrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareNotificationTemplatesQuery no result",
+			prepare: prepareNotificationTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationTemplatesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &NotificationTemplates{Templates: []*NotificationTemplate{}},
+		},
+		{
+			name:    "prepareNotificationTemplatesQuery found",
+			prepare: prepareNotificationTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareNotificationTemplatesStmt),
+					prepareNotificationTemplatesCols,
+					[][]driver.Value{
+						{
+							"org-id",
+							testNow,
+							testNow,
+							uint64(20211111),
+							"InitCode",
+							"de",
+							"{{.Subject}}",
+							"<p>{{.Args.Code}}</p>",
+							"{{.Args.Code}}",
+						},
+					},
+				),
+			},
+			object: &NotificationTemplates{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Templates: []*NotificationTemplate{
+					{
+						AggregateID:  "org-id",
+						CreationDate: testNow,
+						ChangeDate:   testNow,
+						Sequence:     20211111,
+						MessageType:  "InitCode",
+						Language:     language.German,
+						Subject:      "{{.Subject}}",
+						HTML:         "<p>{{.Args.Code}}</p>",
+						Text:         "{{.Args.Code}}",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareNotificationTemplatesQuery sql err",
+			prepare: prepareNotificationTemplatesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareNotificationTemplatesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*NotificationTemplates)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestNotificationTemplates_ForLanguage(t *testing.T) {
+	templates := &NotificationTemplates{
+		Templates: []*NotificationTemplate{
+			{Language: language.German, Text: "de"},
+			{Language: language.English, Text: "en"},
+		},
+	}
+	assert.Equal(t, "en", templates.ForLanguage(language.English).Text)
+	assert.Nil(t, templates.ForLanguage(language.French))
+	assert.Nil(t, (*NotificationTemplates)(nil).ForLanguage(language.English))
+}
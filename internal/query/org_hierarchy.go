@@ -0,0 +1,122 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	orgHierarchyTable = table{
+		name:          projection.OrgHierarchyTable,
+		instanceIDCol: projection.OrgHierarchyInstanceIDCol,
+	}
+	OrgHierarchyColumnOrgID = Column{
+		name:  projection.OrgHierarchyOrgIDCol,
+		table: orgHierarchyTable,
+	}
+	OrgHierarchyColumnInstanceID = Column{
+		name:  projection.OrgHierarchyInstanceIDCol,
+		table: orgHierarchyTable,
+	}
+	OrgHierarchyColumnParentOrgID = Column{
+		name:  projection.OrgHierarchyParentOrgIDCol,
+		table: orgHierarchyTable,
+	}
+)
+
+// maxOrgHierarchyDepth matches the limit enforced when setting the parent of an organization
+// and prevents endless recursion in case the hierarchy is inconsistent.
+const maxOrgHierarchyDepth = 32
+
+// orgAncestorsCTE resolves the organization (depth 0) and all its ancestors (depth > 0).
+// The first argument is the id of the organization, the second the id of the instance.
+const orgAncestorsCTE = `WITH RECURSIVE org_ancestors(id, depth) AS (` +
+	`SELECT CAST(? AS TEXT), 0` +
+	` UNION ALL` +
+	` SELECT h.parent_org_id, a.depth + 1 FROM ` + projection.OrgHierarchyTable + ` h` +
+	` JOIN org_ancestors a ON h.org_id = a.id AND h.instance_id = ?` +
+	` WHERE a.depth < ?` +
+	`)`
+
+// withOrgAncestors makes the org_ancestors of the organization available to the query.
+// It must be used together with [orgOrAncestorsCond] and [orgAncestorsOrder]
+// so policies are inherited from the nearest ancestor which defines them.
+func withOrgAncestors(query sq.SelectBuilder, orgID, instanceID string) sq.SelectBuilder {
+	return query.Prefix(orgAncestorsCTE, orgID, instanceID, maxOrgHierarchyDepth)
+}
+
+// orgOrAncestorsCond matches rows of the organization, its ancestors and the instance defaults.
+func orgOrAncestorsCond(col Column, instanceID string) sq.Sqlizer {
+	return sq.Or{
+		sq.Expr(col.identifier() + " IN (SELECT id FROM org_ancestors)"),
+		sq.Eq{col.identifier(): instanceID},
+	}
+}
+
+// orgAncestorsOrder orders the rows by the distance of the owning organization.
+// Rows of the instance are not part of the hierarchy and therefore sorted last.
+func orgAncestorsOrder(col Column) string {
+	return "(SELECT depth FROM org_ancestors WHERE org_ancestors.id = " + col.identifier() + ")"
+}
+
+// OrgAncestorIDs returns the ids of all ancestors of the organization,
+// starting with the parent and ending with the root organization.
+func (q *Queries) OrgAncestorIDs(ctx context.Context, orgID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOrgAncestorIDsQuery(ctx, q.client)
+	return genericRowsQuery[[]string](ctx, q.client, withOrgAncestors(query, orgID, authz.GetInstance(ctx).InstanceID()), scan)
+}
+
+func prepareOrgAncestorIDsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]string, error)) {
+	return sq.Select("org_ancestors.id").
+			From("org_ancestors").
+			Where(sq.Gt{"org_ancestors.depth": 0}).
+			OrderBy("org_ancestors.depth").
+			PlaceholderFormat(sq.Dollar),
+		scanOrgIDs
+}
+
+// OrgChildIDs returns the ids of the direct children of the organization.
+func (q *Queries) OrgChildIDs(ctx context.Context, orgID string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareOrgChildIDsQuery(ctx, q.client)
+	eq := sq.Eq{
+		OrgHierarchyColumnParentOrgID.identifier(): orgID,
+		OrgHierarchyColumnInstanceID.identifier():  authz.GetInstance(ctx).InstanceID(),
+	}
+	return genericRowsQuery[[]string](ctx, q.client, query.Where(eq), scan)
+}
+
+func prepareOrgChildIDsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]string, error)) {
+	return sq.Select(OrgHierarchyColumnOrgID.identifier()).
+			From(orgHierarchyTable.identifier()).
+			OrderBy(OrgHierarchyColumnOrgID.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		scanOrgIDs
+}
+
+func scanOrgIDs(rows *sql.Rows) ([]string, error) {
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Oh1rq", "Errors.Query.CloseRows")
+	}
+	return ids, nil
+}
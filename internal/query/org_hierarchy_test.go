@@ -0,0 +1,121 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+var (
+	prepareOrgAncestorIDsStmt = `SELECT org_ancestors.id` +
+		` FROM org_ancestors` +
+		` WHERE org_ancestors.depth > $1` +
+		` ORDER BY org_ancestors.depth`
+	prepareOrgChildIDsStmt = `SELECT projections.org_hierarchy.org_id` +
+		` FROM projections.org_hierarchy` +
+		` ORDER BY projections.org_hierarchy.org_id`
+	prepareOrgIDsCols = []string{
+		"id",
+	}
+)
+
+func Test_OrgHierarchyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareOrgAncestorIDsQuery no result",
+			prepare: prepareOrgAncestorIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgAncestorIDsStmt),
+					nil,
+					nil,
+					0,
+				),
+			},
+			object: []string{},
+		},
+		{
+			name:    "prepareOrgAncestorIDsQuery found",
+			prepare: prepareOrgAncestorIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgAncestorIDsStmt),
+					prepareOrgIDsCols,
+					[][]driver.Value{
+						{"parent-id"},
+						{"root-id"},
+					},
+					0,
+				),
+			},
+			object: []string{"parent-id", "root-id"},
+		},
+		{
+			name:    "prepareOrgAncestorIDsQuery sql err",
+			prepare: prepareOrgAncestorIDsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareOrgAncestorIDsStmt),
+					sql.ErrConnDone,
+					0,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]string)(nil),
+		},
+		{
+			name:    "prepareOrgChildIDsQuery found",
+			prepare: prepareOrgChildIDsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareOrgChildIDsStmt),
+					prepareOrgIDsCols,
+					[][]driver.Value{
+						{"child-1"},
+						{"child-2"},
+					},
+				),
+			},
+			object: []string{"child-1", "child-2"},
+		},
+		{
+			name:    "prepareOrgChildIDsQuery sql err",
+			prepare: prepareOrgChildIDsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareOrgChildIDsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]string)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -98,15 +98,12 @@ func (q *Queries) PasswordAgePolicyByOrg(ctx context.Context, shouldTriggerBulk
 		eq[PasswordAgeColOwnerRemoved.identifier()] = false
 	}
 	stmt, scan := preparePasswordAgePolicyQuery(ctx, q.client)
-	query, args, err := stmt.Where(
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
 			eq,
-			sq.Or{
-				sq.Eq{PasswordAgeColID.identifier(): orgID},
-				sq.Eq{PasswordAgeColID.identifier(): authz.GetInstance(ctx).InstanceID()},
-			},
+			orgOrAncestorsCond(PasswordAgeColID, authz.GetInstance(ctx).InstanceID()),
 		}).
-		OrderBy(PasswordAgeColIsDefault.identifier()).
+		OrderBy(PasswordAgeColIsDefault.identifier(), orgAncestorsOrder(PasswordAgeColID)).
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-SKR6X", "Errors.Query.SQLStatement")
@@ -50,15 +50,12 @@ func (q *Queries) PasswordComplexityPolicyByOrg(ctx context.Context, shouldTrigg
 		eq[PasswordComplexityColOwnerRemoved.identifier()] = false
 	}
 	stmt, scan := preparePasswordComplexityPolicyQuery(ctx, q.client)
-	query, args, err := stmt.Where(
+	query, args, err := withOrgAncestors(stmt, orgID, authz.GetInstance(ctx).InstanceID()).Where(
 		sq.And{
 			eq,
-			sq.Or{
-				sq.Eq{PasswordComplexityColID.identifier(): orgID},
-				sq.Eq{PasswordComplexityColID.identifier(): authz.GetInstance(ctx).InstanceID()},
-			},
+			orgOrAncestorsCond(PasswordComplexityColID, authz.GetInstance(ctx).InstanceID()),
 		}).
-		OrderBy(PasswordComplexityColIsDefault.identifier()).
+		OrderBy(PasswordComplexityColIsDefault.identifier(), orgAncestorsOrder(PasswordComplexityColID)).
 		Limit(1).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-lDnrk", "Errors.Query.SQLStatement")
@@ -69,6 +69,18 @@ var (
 		name:  projection.ProjectGrantColumnRoleKeys,
 		table: projectGrantsTable,
 	}
+	ProjectGrantColumnValidFrom = Column{
+		name:  projection.ProjectGrantColumnValidFrom,
+		table: projectGrantsTable,
+	}
+	ProjectGrantColumnValidUntil = Column{
+		name:  projection.ProjectGrantColumnValidUntil,
+		table: projectGrantsTable,
+	}
+	ProjectGrantColumnExpiryWarned = Column{
+		name:  projection.ProjectGrantColumnExpiryWarned,
+		table: projectGrantsTable,
+	}
 	ProjectGrantColumnGrantedOrgName = Column{
 		name:  projection.OrgColumnName,
 		table: orgsTable.setAlias(ProjectGrantGrantedOrgTableAlias),
@@ -92,6 +104,8 @@ type ProjectGrant struct {
 	ResourceOwner string
 	State         domain.ProjectGrantState
 	Sequence      uint64
+	ValidFrom     time.Time
+	ValidUntil    time.Time
 
 	ProjectName       string
 	GrantedOrgID      string
@@ -277,6 +291,8 @@ func prepareProjectGrantQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			ProjectGrantColumnResourceOwner.identifier(),
 			ProjectGrantColumnState.identifier(),
 			ProjectGrantColumnSequence.identifier(),
+			ProjectGrantColumnValidFrom.identifier(),
+			ProjectGrantColumnValidUntil.identifier(),
 			ProjectColumnName.identifier(),
 			ProjectGrantColumnGrantedOrgID.identifier(),
 			ProjectGrantColumnGrantedOrgName.identifier(),
@@ -293,6 +309,8 @@ func prepareProjectGrantQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				projectName       sql.NullString
 				orgName           sql.NullString
 				resourceOwnerName sql.NullString
+				validFrom         sql.NullTime
+				validUntil        sql.NullTime
 			)
 			err := row.Scan(
 				&grant.ProjectID,
@@ -302,6 +320,8 @@ func prepareProjectGrantQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 				&grant.ResourceOwner,
 				&grant.State,
 				&grant.Sequence,
+				&validFrom,
+				&validUntil,
 				&projectName,
 				&grant.GrantedOrgID,
 				&orgName,
@@ -318,6 +338,8 @@ func prepareProjectGrantQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			grant.ProjectName = projectName.String
 			grant.ResourceOwnerName = resourceOwnerName.String
 			grant.OrgName = orgName.String
+			grant.ValidFrom = validFrom.Time
+			grant.ValidUntil = validUntil.Time
 
 			return grant, nil
 		}
@@ -336,6 +358,8 @@ func prepareProjectGrantsQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 			ProjectGrantColumnResourceOwner.identifier(),
 			ProjectGrantColumnState.identifier(),
 			ProjectGrantColumnSequence.identifier(),
+			ProjectGrantColumnValidFrom.identifier(),
+			ProjectGrantColumnValidUntil.identifier(),
 			ProjectColumnName.identifier(),
 			ProjectGrantColumnGrantedOrgID.identifier(),
 			ProjectGrantColumnGrantedOrgName.identifier(),
@@ -354,6 +378,8 @@ func prepareProjectGrantsQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 				projectName       sql.NullString
 				orgName           sql.NullString
 				resourceOwnerName sql.NullString
+				validFrom         sql.NullTime
+				validUntil        sql.NullTime
 			)
 			for rows.Next() {
 				grant := new(ProjectGrant)
@@ -365,6 +391,8 @@ func prepareProjectGrantsQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 					&grant.ResourceOwner,
 					&grant.State,
 					&grant.Sequence,
+					&validFrom,
+					&validUntil,
 					&projectName,
 					&grant.GrantedOrgID,
 					&orgName,
@@ -379,6 +407,8 @@ func prepareProjectGrantsQuery(ctx context.Context, db prepareDatabase) (sq.Sele
 				grant.ProjectName = projectName.String
 				grant.ResourceOwnerName = resourceOwnerName.String
 				grant.OrgName = orgName.String
+				grant.ValidFrom = validFrom.Time
+				grant.ValidUntil = validUntil.Time
 
 				projects = append(projects, grant)
 			}
@@ -42,9 +42,9 @@ var (
 		"LEFT JOIN projections.login_names3 " +
 		"ON members.user_id = projections.login_names3.user_id " +
 		"AND members.instance_id = projections.login_names3.instance_id " +
-		"LEFT JOIN projections.project_grants4 " +
-		"ON members.grant_id = projections.project_grants4.grant_id " +
-		"AND members.instance_id = projections.project_grants4.instance_id " +
+		"LEFT JOIN projections.project_grants5 " +
+		"ON members.grant_id = projections.project_grants5.grant_id " +
+		"AND members.instance_id = projections.project_grants5.instance_id " +
 		`AS OF SYSTEM TIME '-1 ms' ` +
 		"WHERE projections.login_names3.is_primary = $1")
 	projectGrantMembersColumns = []string{
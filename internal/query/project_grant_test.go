@@ -14,23 +14,25 @@ import (
 )
 
 var (
-	projectGrantsQuery = `SELECT projections.project_grants4.project_id,` +
-		` projections.project_grants4.grant_id,` +
-		` projections.project_grants4.creation_date,` +
-		` projections.project_grants4.change_date,` +
-		` projections.project_grants4.resource_owner,` +
-		` projections.project_grants4.state,` +
-		` projections.project_grants4.sequence,` +
+	projectGrantsQuery = `SELECT projections.project_grants5.project_id,` +
+		` projections.project_grants5.grant_id,` +
+		` projections.project_grants5.creation_date,` +
+		` projections.project_grants5.change_date,` +
+		` projections.project_grants5.resource_owner,` +
+		` projections.project_grants5.state,` +
+		` projections.project_grants5.sequence,` +
+		` projections.project_grants5.valid_from,` +
+		` projections.project_grants5.valid_until,` +
 		` projections.projects4.name,` +
-		` projections.project_grants4.granted_org_id,` +
+		` projections.project_grants5.granted_org_id,` +
 		` o.name,` +
-		` projections.project_grants4.granted_role_keys,` +
+		` projections.project_grants5.granted_role_keys,` +
 		` r.name,` +
 		` COUNT(*) OVER () ` +
-		` FROM projections.project_grants4 ` +
-		` LEFT JOIN projections.projects4 ON projections.project_grants4.project_id = projections.projects4.id AND projections.project_grants4.instance_id = projections.projects4.instance_id ` +
-		` LEFT JOIN projections.orgs1 AS r ON projections.project_grants4.resource_owner = r.id AND projections.project_grants4.instance_id = r.instance_id` +
-		` LEFT JOIN projections.orgs1 AS o ON projections.project_grants4.granted_org_id = o.id AND projections.project_grants4.instance_id = o.instance_id` +
+		` FROM projections.project_grants5 ` +
+		` LEFT JOIN projections.projects4 ON projections.project_grants5.project_id = projections.projects4.id AND projections.project_grants5.instance_id = projections.projects4.instance_id ` +
+		` LEFT JOIN projections.orgs1 AS r ON projections.project_grants5.resource_owner = r.id AND projections.project_grants5.instance_id = r.instance_id` +
+		` LEFT JOIN projections.orgs1 AS o ON projections.project_grants5.granted_org_id = o.id AND projections.project_grants5.instance_id = o.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	projectGrantsCols = []string{
 		"project_id",
@@ -40,6 +42,8 @@ var (
 		"resource_owner",
 		"state",
 		"sequence",
+		"valid_from",
+		"valid_until",
 		"name",
 		"granted_org_id",
 		"name",
@@ -47,22 +51,24 @@ var (
 		"name",
 		"count",
 	}
-	projectGrantQuery = `SELECT projections.project_grants4.project_id,` +
-		` projections.project_grants4.grant_id,` +
-		` projections.project_grants4.creation_date,` +
-		` projections.project_grants4.change_date,` +
-		` projections.project_grants4.resource_owner,` +
-		` projections.project_grants4.state,` +
-		` projections.project_grants4.sequence,` +
+	projectGrantQuery = `SELECT projections.project_grants5.project_id,` +
+		` projections.project_grants5.grant_id,` +
+		` projections.project_grants5.creation_date,` +
+		` projections.project_grants5.change_date,` +
+		` projections.project_grants5.resource_owner,` +
+		` projections.project_grants5.state,` +
+		` projections.project_grants5.sequence,` +
+		` projections.project_grants5.valid_from,` +
+		` projections.project_grants5.valid_until,` +
 		` projections.projects4.name,` +
-		` projections.project_grants4.granted_org_id,` +
+		` projections.project_grants5.granted_org_id,` +
 		` o.name,` +
-		` projections.project_grants4.granted_role_keys,` +
+		` projections.project_grants5.granted_role_keys,` +
 		` r.name` +
-		` FROM projections.project_grants4 ` +
-		` LEFT JOIN projections.projects4 ON projections.project_grants4.project_id = projections.projects4.id AND projections.project_grants4.instance_id = projections.projects4.instance_id ` +
-		` LEFT JOIN projections.orgs1 AS r ON projections.project_grants4.resource_owner = r.id AND projections.project_grants4.instance_id = r.instance_id` +
-		` LEFT JOIN projections.orgs1 AS o ON projections.project_grants4.granted_org_id = o.id AND projections.project_grants4.instance_id = o.instance_id` +
+		` FROM projections.project_grants5 ` +
+		` LEFT JOIN projections.projects4 ON projections.project_grants5.project_id = projections.projects4.id AND projections.project_grants5.instance_id = projections.projects4.instance_id ` +
+		` LEFT JOIN projections.orgs1 AS r ON projections.project_grants5.resource_owner = r.id AND projections.project_grants5.instance_id = r.instance_id` +
+		` LEFT JOIN projections.orgs1 AS o ON projections.project_grants5.granted_org_id = o.id AND projections.project_grants5.instance_id = o.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	projectGrantCols = []string{
 		"project_id",
@@ -72,6 +78,8 @@ var (
 		"resource_owner",
 		"state",
 		"sequence",
+		"valid_from",
+		"valid_until",
 		"name",
 		"granted_org_id",
 		"name",
@@ -119,6 +127,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							"ro",
 							domain.ProjectGrantStateActive,
 							20211111,
+							nil,
+							nil,
 							"project-name",
 							"org-id",
 							"org-name",
@@ -167,6 +177,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							domain.ProjectGrantStateActive,
 							20211111,
 							nil,
+							nil,
+							nil,
 							"org-id",
 							"org-name",
 							database.TextArray[string]{"role-key"},
@@ -213,6 +225,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							"ro",
 							domain.ProjectGrantStateActive,
 							20211111,
+							nil,
+							nil,
 							"project-name",
 							"org-id",
 							nil,
@@ -260,6 +274,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							"ro",
 							domain.ProjectGrantStateActive,
 							20211111,
+							nil,
+							nil,
 							"project-name",
 							"org-id",
 							"org-name",
@@ -307,6 +323,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							"ro",
 							domain.ProjectGrantStateActive,
 							20211111,
+							nil,
+							nil,
 							"project-name",
 							"org-id",
 							"org-name",
@@ -321,6 +339,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 							"ro",
 							domain.ProjectGrantStateActive,
 							20211111,
+							nil,
+							nil,
 							"project-name",
 							"org-id",
 							"org-name",
@@ -416,6 +436,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 						"ro",
 						domain.ProjectGrantStateActive,
 						20211111,
+						nil,
+						nil,
 						"project-name",
 						"org-id",
 						"org-name",
@@ -454,6 +476,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 						"ro",
 						domain.ProjectGrantStateActive,
 						20211111,
+						nil,
+						nil,
 						"project-name",
 						"org-id",
 						nil,
@@ -492,6 +516,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 						"ro",
 						domain.ProjectGrantStateActive,
 						20211111,
+						nil,
+						nil,
 						"project-name",
 						"org-id",
 						"org-name",
@@ -531,6 +557,8 @@ func Test_ProjectGrantPrepares(t *testing.T) {
 						domain.ProjectGrantStateActive,
 						20211111,
 						nil,
+						nil,
+						nil,
 						"org-id",
 						"org-name",
 						database.TextArray[string]{"role-key"},
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -57,6 +60,10 @@ var (
 		name:  projection.ProjectRoleColumnGroupName,
 		table: projectRolesTable,
 	}
+	ProjectRoleColumnIncludedRoles = Column{
+		name:  projection.ProjectRoleColumnIncludedRoles,
+		table: projectRolesTable,
+	}
 )
 
 type ProjectRoles struct {
@@ -74,6 +81,8 @@ type ProjectRole struct {
 	Key         string
 	DisplayName string
 	Group       string
+	// IncludedRoles are the keys of the roles directly included by the role
+	IncludedRoles database.TextArray[string]
 }
 
 type ProjectRoleSearchQueries struct {
@@ -144,6 +153,41 @@ func (q *Queries) SearchGrantedProjectRoles(ctx context.Context, grantID, grante
 	return roles, err
 }
 
+// ExpandUserGrantRoles adds the roles included by composite roles to the roles of the user grants.
+func (q *Queries) ExpandUserGrantRoles(ctx context.Context, grants *UserGrants) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if grants == nil || len(grants.UserGrants) == 0 {
+		return nil
+	}
+	projectIDs := make([]string, 0, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if !slices.Contains(projectIDs, grant.ProjectID) {
+			projectIDs = append(projectIDs, grant.ProjectID)
+		}
+	}
+	projectQuery, err := NewInTextQuery(ProjectRoleColumnProjectID, projectIDs)
+	if err != nil {
+		return err
+	}
+	roles, err := q.SearchProjectRoles(ctx, false, &ProjectRoleSearchQueries{Queries: []SearchQuery{projectQuery}})
+	if err != nil {
+		return err
+	}
+	hierarchies := make(map[string]domain.ProjectRoleHierarchy, len(projectIDs))
+	for _, role := range roles.ProjectRoles {
+		if hierarchies[role.ProjectID] == nil {
+			hierarchies[role.ProjectID] = make(domain.ProjectRoleHierarchy)
+		}
+		hierarchies[role.ProjectID][role.Key] = role.IncludedRoles
+	}
+	for _, grant := range grants.UserGrants {
+		grant.Roles = hierarchies[grant.ProjectID].Expand(grant.Roles...)
+	}
+	return nil
+}
+
 func NewProjectRoleProjectIDSearchQuery(value string) (SearchQuery, error) {
 	return NewTextQuery(ProjectRoleColumnProjectID, value, TextEquals)
 }
@@ -164,6 +208,11 @@ func NewProjectRoleKeysSearchQuery(values []string) (SearchQuery, error) {
 	return NewListQuery(ProjectRoleColumnKey, list, ListIn)
 }
 
+// NewProjectRoleIncludesRoleSearchQuery matches the roles which directly include the role
+func NewProjectRoleIncludesRoleSearchQuery(key string) (SearchQuery, error) {
+	return NewListContains(ProjectRoleColumnIncludedRoles, database.TextArray[string]{key})
+}
+
 func NewProjectRoleDisplayNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
 	return NewTextQuery(ProjectRoleColumnDisplayName, value, method)
 }
@@ -217,6 +266,7 @@ func prepareProjectRolesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 			ProjectRoleColumnKey.identifier(),
 			ProjectRoleColumnDisplayName.identifier(),
 			ProjectRoleColumnGroupName.identifier(),
+			ProjectRoleColumnIncludedRoles.identifier(),
 			countColumn.identifier()).
 			From(projectRolesTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -234,6 +284,7 @@ func prepareProjectRolesQuery(ctx context.Context, db prepareDatabase) (sq.Selec
 					&project.Key,
 					&project.DisplayName,
 					&project.Group,
+					&project.IncludedRoles,
 					&count,
 				)
 				if err != nil {
@@ -7,19 +7,22 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
 )
 
 var (
-	prepareProjectRolesStmt = `SELECT projections.project_roles4.project_id,` +
-		` projections.project_roles4.creation_date,` +
-		` projections.project_roles4.change_date,` +
-		` projections.project_roles4.resource_owner,` +
-		` projections.project_roles4.sequence,` +
-		` projections.project_roles4.role_key,` +
-		` projections.project_roles4.display_name,` +
-		` projections.project_roles4.group_name,` +
+	prepareProjectRolesStmt = `SELECT projections.project_roles5.project_id,` +
+		` projections.project_roles5.creation_date,` +
+		` projections.project_roles5.change_date,` +
+		` projections.project_roles5.resource_owner,` +
+		` projections.project_roles5.sequence,` +
+		` projections.project_roles5.role_key,` +
+		` projections.project_roles5.display_name,` +
+		` projections.project_roles5.group_name,` +
+		` projections.project_roles5.included_roles,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.project_roles4` +
+		` FROM projections.project_roles5` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareProjectRolesCols = []string{
 		"project_id",
@@ -30,6 +33,7 @@ var (
 		"role_key",
 		"display_name",
 		"group_name",
+		"included_roles",
 		"count",
 	}
 )
@@ -74,6 +78,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 							"role-key",
 							"role-display-name",
 							"role-group",
+							database.TextArray[string]{"included-key"},
 						},
 					},
 				),
@@ -92,6 +97,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 						Key:           "role-key",
 						DisplayName:   "role-display-name",
 						Group:         "role-group",
+						IncludedRoles: database.TextArray[string]{"included-key"},
 					},
 				},
 			},
@@ -113,6 +119,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 							"role-key-1",
 							"role-display-name-1",
 							"role-group",
+							nil,
 						},
 						{
 							"project-id",
@@ -123,6 +130,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 							"role-key-2",
 							"role-display-name-2",
 							"role-group",
+							nil,
 						},
 					},
 				),
@@ -141,6 +149,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 						Key:           "role-key-1",
 						DisplayName:   "role-display-name-1",
 						Group:         "role-group",
+						IncludedRoles: database.TextArray[string]{},
 					},
 					{
 						ProjectID:     "project-id",
@@ -151,6 +160,7 @@ func Test_ProjectRolePrepares(t *testing.T) {
 						Key:           "role-key-2",
 						DisplayName:   "role-display-name-2",
 						Group:         "role-group",
+						IncludedRoles: database.TextArray[string]{},
 					},
 				},
 			},
@@ -0,0 +1,227 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/accessrequest"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestAccessRequestProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						accessrequest.AddedType,
+						accessrequest.AggregateType,
+						[]byte(`{"userId": "user-id", "projectId": "project-id", "grantId": "grant-id", "roleKeys": ["role"], "reason": "reason"}`),
+					), eventstore.GenericEventMapper[accessrequest.AddedEvent]),
+			},
+			reduce: (&accessRequestProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: accessrequest.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.access_requests (id, instance_id, resource_owner, creation_date, change_date, sequence, state, user_id, project_id, grant_id, role_keys, reason) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.AccessRequestStatePending,
+								"user-id",
+								"project-id",
+								"grant-id",
+								database.TextArray[string]{"role"},
+								"reason",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApproved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						accessrequest.ApprovedType,
+						accessrequest.AggregateType,
+						[]byte(`{"userGrantId": "user-grant-id", "comment": "comment"}`),
+					), eventstore.GenericEventMapper[accessrequest.ApprovedEvent]),
+			},
+			reduce: (&accessRequestProjection{}).reduceApproved,
+			want: wantReduce{
+				aggregateType: accessrequest.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.access_requests SET (change_date, sequence, state, comment, decided_by, user_grant_id) = ($1, $2, $3, $4, $5, $6) WHERE (instance_id = $7) AND (id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.AccessRequestStateApproved,
+								"comment",
+								"editor-user",
+								"user-grant-id",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDenied",
+			args: args{
+				event: getEvent(
+					testEvent(
+						accessrequest.DeniedType,
+						accessrequest.AggregateType,
+						[]byte(`{"comment": "comment"}`),
+					), eventstore.GenericEventMapper[accessrequest.DeniedEvent]),
+			},
+			reduce: (&accessRequestProjection{}).reduceDenied,
+			want: wantReduce{
+				aggregateType: accessrequest.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.access_requests SET (change_date, sequence, state, comment, decided_by) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.AccessRequestStateDenied,
+								"comment",
+								"editor-user",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceWithdrawn",
+			args: args{
+				event: getEvent(
+					testEvent(
+						accessrequest.WithdrawnType,
+						accessrequest.AggregateType,
+						[]byte(`{}`),
+					), eventstore.GenericEventMapper[accessrequest.WithdrawnEvent]),
+			},
+			reduce: (&accessRequestProjection{}).reduceWithdrawn,
+			want: wantReduce{
+				aggregateType: accessrequest.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.access_requests SET (change_date, sequence, state, comment, decided_by) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.AccessRequestStateWithdrawn,
+								"",
+								"editor-user",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&accessRequestProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.access_requests WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(AccessRequestInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.access_requests WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, AccessRequestTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	AuthorizationModelProjectionTable = "projections.authorization_models"
+	RelationTupleTable                = AuthorizationModelProjectionTable + "_" + RelationTupleSuffix
+
+	AuthorizationModelProjectIDCol     = "project_id"
+	AuthorizationModelInstanceIDCol    = "instance_id"
+	AuthorizationModelResourceOwnerCol = "resource_owner"
+	AuthorizationModelCreationDateCol  = "creation_date"
+	AuthorizationModelChangeDateCol    = "change_date"
+	AuthorizationModelSequenceCol      = "sequence"
+	AuthorizationModelModelCol         = "model"
+
+	RelationTupleSuffix             = "tuples"
+	RelationTupleProjectIDCol       = "project_id"
+	RelationTupleInstanceIDCol      = "instance_id"
+	RelationTupleResourceOwnerCol   = "resource_owner"
+	RelationTupleCreationDateCol    = "creation_date"
+	RelationTupleSequenceCol        = "sequence"
+	RelationTupleObjectTypeCol      = "object_type"
+	RelationTupleObjectIDCol        = "object_id"
+	RelationTupleRelationCol        = "relation"
+	RelationTupleSubjectTypeCol     = "subject_type"
+	RelationTupleSubjectIDCol       = "subject_id"
+	RelationTupleSubjectRelationCol = "subject_relation"
+)
+
+type authorizationModelProjection struct{}
+
+func newAuthorizationModelProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(authorizationModelProjection))
+}
+
+func (*authorizationModelProjection) Name() string {
+	return AuthorizationModelProjectionTable
+}
+
+func (*authorizationModelProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(AuthorizationModelProjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AuthorizationModelInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(AuthorizationModelResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(AuthorizationModelCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AuthorizationModelChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AuthorizationModelSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(AuthorizationModelModelCol, handler.ColumnTypeJSONB),
+		},
+			handler.NewPrimaryKey(AuthorizationModelInstanceIDCol, AuthorizationModelProjectIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{AuthorizationModelResourceOwnerCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(RelationTupleProjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(RelationTupleSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(RelationTupleObjectTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleObjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleRelationCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleSubjectTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleSubjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RelationTupleSubjectRelationCol, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(
+				RelationTupleInstanceIDCol,
+				RelationTupleProjectIDCol,
+				RelationTupleObjectTypeCol,
+				RelationTupleObjectIDCol,
+				RelationTupleRelationCol,
+				RelationTupleSubjectTypeCol,
+				RelationTupleSubjectIDCol,
+				RelationTupleSubjectRelationCol,
+			),
+			RelationTupleSuffix,
+			// checks are resolved backwards from the object using the primary key,
+			// lists of related objects expand the relations of a subject and search the tuples by their subject
+			handler.WithIndex(handler.NewIndex("subject", []string{RelationTupleInstanceIDCol, RelationTupleProjectIDCol, RelationTupleSubjectTypeCol, RelationTupleSubjectIDCol})),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{RelationTupleResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *authorizationModelProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.AuthorizationModelSetType,
+					Reduce: p.reduceModelSet,
+				},
+				{
+					Event:  project.RelationTupleAddedType,
+					Reduce: p.reduceTupleAdded,
+				},
+				{
+					Event:  project.RelationTupleRemovedType,
+					Reduce: p.reduceTupleRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *authorizationModelProjection) reduceModelSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.AuthorizationModelSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AuthorizationModelInstanceIDCol, nil),
+			handler.NewCol(AuthorizationModelProjectIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(AuthorizationModelProjectIDCol, e.Aggregate().ID),
+			handler.NewCol(AuthorizationModelInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(AuthorizationModelResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(AuthorizationModelCreationDateCol, handler.OnlySetValueOnInsert(AuthorizationModelProjectionTable, e.CreatedAt())),
+			handler.NewCol(AuthorizationModelChangeDateCol, e.CreatedAt()),
+			handler.NewCol(AuthorizationModelSequenceCol, e.Sequence()),
+			handler.NewJSONCol(AuthorizationModelModelCol, e.Model),
+		},
+	), nil
+}
+
+func (p *authorizationModelProjection) reduceTupleAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.RelationTupleAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RelationTupleProjectIDCol, e.Aggregate().ID),
+			handler.NewCol(RelationTupleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(RelationTupleResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(RelationTupleCreationDateCol, e.CreatedAt()),
+			handler.NewCol(RelationTupleSequenceCol, e.Sequence()),
+			handler.NewCol(RelationTupleObjectTypeCol, e.ObjectType),
+			handler.NewCol(RelationTupleObjectIDCol, e.ObjectID),
+			handler.NewCol(RelationTupleRelationCol, e.Relation),
+			handler.NewCol(RelationTupleSubjectTypeCol, e.SubjectType),
+			handler.NewCol(RelationTupleSubjectIDCol, e.SubjectID),
+			handler.NewCol(RelationTupleSubjectRelationCol, e.SubjectRelation),
+		},
+		handler.WithTableSuffix(RelationTupleSuffix),
+	), nil
+}
+
+func (p *authorizationModelProjection) reduceTupleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.RelationTupleRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RelationTupleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(RelationTupleProjectIDCol, e.Aggregate().ID),
+			handler.NewCond(RelationTupleObjectTypeCol, e.ObjectType),
+			handler.NewCond(RelationTupleObjectIDCol, e.ObjectID),
+			handler.NewCond(RelationTupleRelationCol, e.Relation),
+			handler.NewCond(RelationTupleSubjectTypeCol, e.SubjectType),
+			handler.NewCond(RelationTupleSubjectIDCol, e.SubjectID),
+			handler.NewCond(RelationTupleSubjectRelationCol, e.SubjectRelation),
+		},
+		handler.WithTableSuffix(RelationTupleSuffix),
+	), nil
+}
+
+func (p *authorizationModelProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ProjectRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AuthorizationModelInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(AuthorizationModelProjectIDCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(RelationTupleInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(RelationTupleProjectIDCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(RelationTupleSuffix),
+		),
+	), nil
+}
+
+func (p *authorizationModelProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AuthorizationModelInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(AuthorizationModelResourceOwnerCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(RelationTupleInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(RelationTupleResourceOwnerCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(RelationTupleSuffix),
+		),
+	), nil
+}
+
+func (p *authorizationModelProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.InstanceRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AuthorizationModelInstanceIDCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(RelationTupleInstanceIDCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(RelationTupleSuffix),
+		),
+	), nil
+}
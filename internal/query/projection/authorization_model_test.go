@@ -0,0 +1,239 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestAuthorizationModelProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceModelSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.AuthorizationModelSetType,
+						project.AggregateType,
+						[]byte(`{"model": {"types": [{"name": "document", "relations": [{"name": "viewer", "directSubjects": [{"type": "user"}]}]}]}}`),
+					), eventstore.GenericEventMapper[project.AuthorizationModelSetEvent]),
+			},
+			reduce: (&authorizationModelProjection{}).reduceModelSet,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.authorization_models (project_id, instance_id, resource_owner, creation_date, change_date, sequence, model) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, project_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, model) = (EXCLUDED.resource_owner, projections.authorization_models.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.model)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTupleAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.RelationTupleAddedType,
+						project.AggregateType,
+						[]byte(`{"objectType": "document", "objectId": "doc-id", "relation": "viewer", "subjectType": "group", "subjectId": "group-id", "subjectRelation": "member"}`),
+					), eventstore.GenericEventMapper[project.RelationTupleAddedEvent]),
+			},
+			reduce: (&authorizationModelProjection{}).reduceTupleAdded,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.authorization_models_tuples (project_id, instance_id, resource_owner, creation_date, sequence, object_type, object_id, relation, subject_type, subject_id, subject_relation) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								"document",
+								"doc-id",
+								"viewer",
+								"group",
+								"group-id",
+								"member",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceTupleRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.RelationTupleRemovedType,
+						project.AggregateType,
+						[]byte(`{"objectType": "document", "objectId": "doc-id", "relation": "viewer", "subjectType": "user", "subjectId": "user-id", "subjectRelation": ""}`),
+					), eventstore.GenericEventMapper[project.RelationTupleRemovedEvent]),
+			},
+			reduce: (&authorizationModelProjection{}).reduceTupleRemoved,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models_tuples WHERE (instance_id = $1) AND (project_id = $2) AND (object_type = $3) AND (object_id = $4) AND (relation = $5) AND (subject_type = $6) AND (subject_id = $7) AND (subject_relation = $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"document",
+								"doc-id",
+								"viewer",
+								"user",
+								"user-id",
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceProjectRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectRemovedType,
+						project.AggregateType,
+						nil,
+					), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&authorizationModelProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models_tuples WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&authorizationModelProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models_tuples WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&authorizationModelProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.authorization_models_tuples WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, AuthorizationModelProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/customrole"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// CustomRoleTable contains the custom roles of the instances and organizations.
+	// The resource owner of the roles of an instance is the instance itself.
+	CustomRoleTable = "projections.custom_roles"
+
+	CustomRoleKeyCol           = "role_key"
+	CustomRoleInstanceIDCol    = "instance_id"
+	CustomRoleResourceOwnerCol = "resource_owner"
+	CustomRoleDisplayNameCol   = "display_name"
+	CustomRolePermissionsCol   = "permissions"
+	CustomRoleCreationDateCol  = "creation_date"
+	CustomRoleChangeDateCol    = "change_date"
+	CustomRoleSequenceCol      = "sequence"
+)
+
+type customRoleProjection struct{}
+
+func newCustomRoleProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(customRoleProjection))
+}
+
+func (*customRoleProjection) Name() string {
+	return CustomRoleTable
+}
+
+func (*customRoleProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(CustomRoleKeyCol, handler.ColumnTypeText),
+			handler.NewColumn(CustomRoleInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(CustomRoleResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(CustomRoleDisplayNameCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(CustomRolePermissionsCol, handler.ColumnTypeTextArray),
+			handler.NewColumn(CustomRoleCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(CustomRoleChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(CustomRoleSequenceCol, handler.ColumnTypeInt64),
+		},
+			handler.NewPrimaryKey(CustomRoleInstanceIDCol, CustomRoleResourceOwnerCol, CustomRoleKeyCol),
+		),
+	)
+}
+
+func (p *customRoleProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.CustomRoleAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.CustomRoleChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.CustomRoleRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOrgRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.CustomRoleAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.CustomRoleChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  instance.CustomRoleRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(CustomRoleInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *customRoleProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var e *customrole.AddedEvent
+	switch added := event.(type) {
+	case *org.CustomRoleAddedEvent:
+		e = &added.AddedEvent
+	case *instance.CustomRoleAddedEvent:
+		e = &added.AddedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Cr1ad", "reduce.wrong.event.type %v", []eventstore.EventType{org.CustomRoleAddedEventType, instance.CustomRoleAddedEventType})
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(CustomRoleKeyCol, e.Key),
+			handler.NewCol(CustomRoleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(CustomRoleResourceOwnerCol, e.Aggregate().ID),
+			handler.NewCol(CustomRoleDisplayNameCol, e.DisplayName),
+			handler.NewCol(CustomRolePermissionsCol, database.TextArray[string](e.Permissions)),
+			handler.NewCol(CustomRoleCreationDateCol, e.CreationDate()),
+			handler.NewCol(CustomRoleChangeDateCol, e.CreationDate()),
+			handler.NewCol(CustomRoleSequenceCol, e.Sequence()),
+		},
+	), nil
+}
+
+func (p *customRoleProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var e *customrole.ChangedEvent
+	switch changed := event.(type) {
+	case *org.CustomRoleChangedEvent:
+		e = &changed.ChangedEvent
+	case *instance.CustomRoleChangedEvent:
+		e = &changed.ChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Cr2ch", "reduce.wrong.event.type %v", []eventstore.EventType{org.CustomRoleChangedEventType, instance.CustomRoleChangedEventType})
+	}
+	columns := []handler.Column{
+		handler.NewCol(CustomRoleChangeDateCol, e.CreationDate()),
+		handler.NewCol(CustomRoleSequenceCol, e.Sequence()),
+	}
+	if e.DisplayName != nil {
+		columns = append(columns, handler.NewCol(CustomRoleDisplayNameCol, *e.DisplayName))
+	}
+	if len(e.Permissions) > 0 {
+		columns = append(columns, handler.NewCol(CustomRolePermissionsCol, database.TextArray[string](e.Permissions)))
+	}
+	return handler.NewUpdateStatement(
+		e,
+		columns,
+		[]handler.Condition{
+			handler.NewCond(CustomRoleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(CustomRoleResourceOwnerCol, e.Aggregate().ID),
+			handler.NewCond(CustomRoleKeyCol, e.Key),
+		},
+	), nil
+}
+
+func (p *customRoleProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var e *customrole.RemovedEvent
+	switch removed := event.(type) {
+	case *org.CustomRoleRemovedEvent:
+		e = &removed.RemovedEvent
+	case *instance.CustomRoleRemovedEvent:
+		e = &removed.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Cr3rm", "reduce.wrong.event.type %v", []eventstore.EventType{org.CustomRoleRemovedEventType, instance.CustomRoleRemovedEventType})
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomRoleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(CustomRoleResourceOwnerCol, e.Aggregate().ID),
+			handler.NewCond(CustomRoleKeyCol, e.Key),
+		},
+	), nil
+}
+
+func (p *customRoleProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(CustomRoleInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(CustomRoleResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,217 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCustomRoleProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.CustomRoleAddedEventType,
+						org.AggregateType,
+						[]byte(`{"key": "ORG_HELPDESK", "displayName": "Help Desk", "permissions": ["user.credential.write"]}`),
+					), eventstore.GenericEventMapper[org.CustomRoleAddedEvent]),
+			},
+			reduce: (&customRoleProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.custom_roles (role_key, instance_id, resource_owner, display_name, permissions, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"ORG_HELPDESK",
+								"instance-id",
+								"agg-id",
+								"Help Desk",
+								database.TextArray[string]{"user.credential.write"},
+								anyArg{},
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.CustomRoleAddedEventType,
+						instance.AggregateType,
+						[]byte(`{"key": "IAM_HELPDESK", "permissions": ["user.credential.write"]}`),
+					), eventstore.GenericEventMapper[instance.CustomRoleAddedEvent]),
+			},
+			reduce: (&customRoleProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.custom_roles (role_key, instance_id, resource_owner, display_name, permissions, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"IAM_HELPDESK",
+								"instance-id",
+								"agg-id",
+								"",
+								database.TextArray[string]{"user.credential.write"},
+								anyArg{},
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.CustomRoleChangedEventType,
+						instance.AggregateType,
+						[]byte(`{"key": "IAM_HELPDESK", "displayName": "Help Desk", "permissions": ["user.credential.write", "user.write"]}`),
+					), eventstore.GenericEventMapper[instance.CustomRoleChangedEvent]),
+			},
+			reduce: (&customRoleProjection{}).reduceChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.custom_roles SET (change_date, sequence, display_name, permissions) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (resource_owner = $6) AND (role_key = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"Help Desk",
+								database.TextArray[string]{"user.credential.write", "user.write"},
+								"instance-id",
+								"agg-id",
+								"IAM_HELPDESK",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.CustomRoleRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"key": "ORG_HELPDESK"}`),
+					), eventstore.GenericEventMapper[org.CustomRoleRemovedEvent]),
+			},
+			reduce: (&customRoleProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.custom_roles WHERE (instance_id = $1) AND (resource_owner = $2) AND (role_key = $3)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"ORG_HELPDESK",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOrgRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&customRoleProjection{}).reduceOrgRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.custom_roles WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(CustomRoleInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.custom_roles WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, CustomRoleTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	GroupProjectionTable = "projections.groups"
+	GroupMemberTable     = GroupProjectionTable + "_" + GroupMemberSuffix
+	GroupGrantTable      = GroupProjectionTable + "_" + GroupGrantSuffix
+
+	GroupIDCol            = "id"
+	GroupCreationDateCol  = "creation_date"
+	GroupChangeDateCol    = "change_date"
+	GroupSequenceCol      = "sequence"
+	GroupResourceOwnerCol = "resource_owner"
+	GroupInstanceIDCol    = "instance_id"
+	GroupNameCol          = "name"
+	GroupDescriptionCol   = "description"
+
+	GroupMemberSuffix               = "members"
+	GroupMemberGroupIDCol           = "group_id"
+	GroupMemberUserIDCol            = "user_id"
+	GroupMemberInstanceIDCol        = "instance_id"
+	GroupMemberResourceOwnerCol     = "resource_owner"
+	GroupMemberUserResourceOwnerCol = "user_resource_owner"
+	GroupMemberCreationDateCol      = "creation_date"
+	GroupMemberSequenceCol          = "sequence"
+
+	GroupGrantSuffix           = "grants"
+	GroupGrantIDCol            = "id"
+	GroupGrantGroupIDCol       = "group_id"
+	GroupGrantInstanceIDCol    = "instance_id"
+	GroupGrantResourceOwnerCol = "resource_owner"
+	GroupGrantCreationDateCol  = "creation_date"
+	GroupGrantChangeDateCol    = "change_date"
+	GroupGrantSequenceCol      = "sequence"
+	GroupGrantProjectIDCol     = "project_id"
+	GroupGrantGrantIDCol       = "grant_id"
+	GroupGrantRolesCol         = "roles"
+)
+
+type groupProjection struct {
+	es handler.EventStore
+}
+
+func newGroupProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &groupProjection{es: config.Eventstore})
+}
+
+func (*groupProjection) Name() string {
+	return GroupProjectionTable
+}
+
+func (*groupProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(GroupIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(GroupChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(GroupSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(GroupResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupNameCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupDescriptionCol, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(GroupInstanceIDCol, GroupIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{GroupResourceOwnerCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(GroupMemberGroupIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupMemberUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupMemberInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupMemberResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupMemberUserResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupMemberCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(GroupMemberSequenceCol, handler.ColumnTypeInt64),
+		},
+			handler.NewPrimaryKey(GroupMemberInstanceIDCol, GroupMemberGroupIDCol, GroupMemberUserIDCol),
+			GroupMemberSuffix,
+			handler.WithForeignKey(handler.NewForeignKey("group", []string{GroupMemberInstanceIDCol, GroupMemberGroupIDCol}, []string{GroupInstanceIDCol, GroupIDCol})),
+			handler.WithIndex(handler.NewIndex("user_id", []string{GroupMemberUserIDCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(GroupGrantIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupGrantGroupIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupGrantInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupGrantResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupGrantCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(GroupGrantChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(GroupGrantSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(GroupGrantProjectIDCol, handler.ColumnTypeText),
+			handler.NewColumn(GroupGrantGrantIDCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(GroupGrantRolesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(GroupGrantInstanceIDCol, GroupGrantIDCol),
+			GroupGrantSuffix,
+			handler.WithForeignKey(handler.NewForeignKey("group", []string{GroupGrantInstanceIDCol, GroupGrantGroupIDCol}, []string{GroupInstanceIDCol, GroupIDCol})),
+			handler.WithIndex(handler.NewIndex("group_id", []string{GroupGrantGroupIDCol})),
+		),
+	)
+}
+
+func (p *groupProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: group.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  group.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  group.ChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  group.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  group.MemberAddedEventType,
+					Reduce: p.reduceMemberAdded,
+				},
+				{
+					Event:  group.MemberRemovedEventType,
+					Reduce: p.reduceMemberRemoved,
+				},
+				{
+					Event:  group.GrantAddedEventType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  group.GrantChangedEventType,
+					Reduce: p.reduceGrantChanged,
+				},
+				{
+					Event:  group.GrantRemovedEventType,
+					Reduce: p.reduceGrantRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+				{
+					Event:  project.GrantRemovedType,
+					Reduce: p.reduceProjectGrantRemoved,
+				},
+				{
+					Event:  project.RoleRemovedType,
+					Reduce: p.reduceRoleRemoved,
+				},
+				{
+					Event:  project.GrantChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+				{
+					Event:  project.GrantCascadeChangedType,
+					Reduce: p.reduceProjectGrantChanged,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(GroupInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *groupProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(GroupIDCol, e.Aggregate().ID),
+			handler.NewCol(GroupCreationDateCol, e.CreatedAt()),
+			handler.NewCol(GroupChangeDateCol, e.CreatedAt()),
+			handler.NewCol(GroupSequenceCol, e.Sequence()),
+			handler.NewCol(GroupResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(GroupInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(GroupNameCol, e.Name),
+			handler.NewCol(GroupDescriptionCol, e.Description),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.ChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	values := []handler.Column{
+		handler.NewCol(GroupChangeDateCol, e.CreatedAt()),
+		handler.NewCol(GroupSequenceCol, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(GroupNameCol, *e.Name))
+	}
+	if e.Description != nil {
+		values = append(values, handler.NewCol(GroupDescriptionCol, *e.Description))
+	}
+	return handler.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(GroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	// members and grants are removed by the foreign keys
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *groupProjection) reduceMemberAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.MemberAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	userOwner, err := getUserResourceOwner(setUserGrantContext(e.Aggregate()), p.es, e.Aggregate().InstanceID, e.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(GroupMemberGroupIDCol, e.Aggregate().ID),
+				handler.NewCol(GroupMemberUserIDCol, e.UserID),
+				handler.NewCol(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(GroupMemberResourceOwnerCol, e.Aggregate().ResourceOwner),
+				handler.NewCol(GroupMemberUserResourceOwnerCol, userOwner),
+				handler.NewCol(GroupMemberCreationDateCol, e.CreatedAt()),
+				handler.NewCol(GroupMemberSequenceCol, e.Sequence()),
+			},
+			handler.WithTableSuffix(GroupMemberSuffix),
+		),
+		p.updateGroup(e),
+	), nil
+}
+
+func (p *groupProjection) reduceMemberRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.MemberRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(GroupMemberGroupIDCol, e.Aggregate().ID),
+				handler.NewCond(GroupMemberUserIDCol, e.UserID),
+				handler.NewCond(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(GroupMemberSuffix),
+		),
+		p.updateGroup(e),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.GrantAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(GroupGrantIDCol, e.GrantID),
+				handler.NewCol(GroupGrantGroupIDCol, e.Aggregate().ID),
+				handler.NewCol(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(GroupGrantResourceOwnerCol, e.Aggregate().ResourceOwner),
+				handler.NewCol(GroupGrantCreationDateCol, e.CreatedAt()),
+				handler.NewCol(GroupGrantChangeDateCol, e.CreatedAt()),
+				handler.NewCol(GroupGrantSequenceCol, e.Sequence()),
+				handler.NewCol(GroupGrantProjectIDCol, e.ProjectID),
+				handler.NewCol(GroupGrantGrantIDCol, e.ProjectGrantID),
+				handler.NewCol(GroupGrantRolesCol, database.TextArray[string](e.RoleKeys)),
+			},
+			handler.WithTableSuffix(GroupGrantSuffix),
+		),
+		p.updateGroup(e),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.GrantChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(GroupGrantChangeDateCol, e.CreatedAt()),
+				handler.NewCol(GroupGrantSequenceCol, e.Sequence()),
+				handler.NewCol(GroupGrantRolesCol, database.TextArray[string](e.RoleKeys)),
+			},
+			[]handler.Condition{
+				handler.NewCond(GroupGrantIDCol, e.GrantID),
+				handler.NewCond(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(GroupGrantSuffix),
+		),
+		p.updateGroup(e),
+	), nil
+}
+
+func (p *groupProjection) reduceGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*group.GrantRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(GroupGrantIDCol, e.GrantID),
+				handler.NewCond(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(GroupGrantSuffix),
+		),
+		p.updateGroup(e),
+	), nil
+}
+
+// updateGroup sets the change date and sequence of the group on changes of its members and grants
+func (p *groupProjection) updateGroup(e eventstore.Event) func(eventstore.Event) handler.Exec {
+	return handler.AddUpdateStatement(
+		[]handler.Column{
+			handler.NewCol(GroupChangeDateCol, e.CreatedAt()),
+			handler.NewCol(GroupSequenceCol, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	)
+}
+
+func (p *groupProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.UserRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupMemberUserIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(GroupMemberSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ProjectRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantProjectIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.GrantRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(GroupGrantGrantIDCol, e.GrantID),
+			handler.NewCond(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceRoleRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.RoleRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewArrayRemoveCol(GroupGrantRolesCol, e.Key),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantProjectIDCol, e.Aggregate().ID),
+			handler.NewCond(GroupGrantInstanceIDCol, e.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceProjectGrantChanged(event eventstore.Event) (*handler.Statement, error) {
+	var grantID string
+	var keys database.TextArray[string]
+	switch e := event.(type) {
+	case *project.GrantChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	case *project.GrantCascadeChangedEvent:
+		grantID = e.GrantID
+		keys = e.RoleKeys
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Gr2cw", "reduce.wrong.event.type %v", []eventstore.EventType{project.GrantChangedType, project.GrantCascadeChangedType})
+	}
+	return handler.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewArrayIntersectCol(GroupGrantRolesCol, keys),
+		},
+		[]handler.Condition{
+			handler.NewCond(GroupGrantGrantIDCol, grantID),
+			handler.NewCond(GroupGrantInstanceIDCol, event.Aggregate().InstanceID),
+		},
+		handler.WithTableSuffix(GroupGrantSuffix),
+	), nil
+}
+
+func (p *groupProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		// members and grants of the groups are removed by the foreign keys
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(GroupInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(GroupResourceOwnerCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(GroupMemberInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(GroupMemberUserResourceOwnerCol, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(GroupMemberSuffix),
+		),
+	), nil
+}
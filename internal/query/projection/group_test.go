@@ -0,0 +1,575 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestGroupProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.AddedEventType,
+						group.AggregateType,
+						[]byte(`{"name": "group", "description": "description"}`),
+					),
+					eventstore.GenericEventMapper[group.AddedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups (id, creation_date, change_date, sequence, resource_owner, instance_id, name, description) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								"group",
+								"description",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.ChangedEventType,
+						group.AggregateType,
+						[]byte(`{"name": "new"}`),
+					),
+					eventstore.GenericEventMapper[group.ChangedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceChanged,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence, name) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"new",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.RemovedEventType,
+						group.AggregateType,
+						[]byte(`{}`),
+					),
+					eventstore.GenericEventMapper[group.RemovedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.MemberAddedEventType,
+						group.AggregateType,
+						[]byte(`{"userId": "user-id"}`),
+					),
+					eventstore.GenericEventMapper[group.MemberAddedEvent],
+				),
+			},
+			reduce: (&groupProjection{
+				es: newMockEventStore().
+					appendFilterResponse([]eventstore.Event{
+						user.NewHumanAddedEvent(context.Background(),
+							&user.NewAggregate("user-id", "org1").Aggregate,
+							"username1",
+							"firstname1",
+							"lastname1",
+							"nickname1",
+							"displayname1",
+							language.German,
+							domain.GenderMale,
+							"email1",
+							true,
+						),
+					}),
+			}).reduceMemberAdded,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_members (group_id, user_id, instance_id, resource_owner, user_resource_owner, creation_date, sequence) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"user-id",
+								"instance-id",
+								"ro-id",
+								"org1",
+								anyArg{},
+								uint64(15),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceMemberRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.MemberRemovedEventType,
+						group.AggregateType,
+						[]byte(`{"userId": "user-id"}`),
+					),
+					eventstore.GenericEventMapper[group.MemberRemovedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceMemberRemoved,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (group_id = $1) AND (user_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"user-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.GrantAddedEventType,
+						group.AggregateType,
+						[]byte(`{"grantId": "grant-id", "projectId": "project-id", "projectGrantId": "project-grant-id", "roleKeys": ["role"]}`),
+					),
+					eventstore.GenericEventMapper[group.GrantAddedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceGrantAdded,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.groups_grants (id, group_id, instance_id, resource_owner, creation_date, change_date, sequence, project_id, grant_id, roles) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"project-id",
+								"project-grant-id",
+								database.TextArray[string]{"role"},
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.GrantChangedEventType,
+						group.AggregateType,
+						[]byte(`{"grantId": "grant-id", "roleKeys": ["role", "role2"]}`),
+					),
+					eventstore.GenericEventMapper[group.GrantChangedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceGrantChanged,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET (change_date, sequence, roles) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								database.TextArray[string]{"role", "role2"},
+								"grant-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceGrantRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						group.GrantRemovedEventType,
+						group.AggregateType,
+						[]byte(`{"grantId": "grant-id"}`),
+					),
+					eventstore.GenericEventMapper[group.GrantRemovedEvent],
+				),
+			},
+			reduce: (&groupProjection{}).reduceGrantRemoved,
+			want: wantReduce{
+				aggregateType: group.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"grant-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.groups SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserRemovedType,
+						user.AggregateType,
+						[]byte(`{}`),
+					),
+					user.UserRemovedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectRemovedType,
+						project.AggregateType,
+						[]byte(`{}`),
+					),
+					project.ProjectRemovedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectGrantRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.GrantRemovedType,
+						project.AggregateType,
+						[]byte(`{"grantId": "project-grant-id"}`),
+					),
+					project.GrantRemovedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceProjectGrantRemoved,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups_grants WHERE (grant_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"project-grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRoleRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.RoleRemovedType,
+						project.AggregateType,
+						[]byte(`{"key": "role"}`),
+					),
+					project.RoleRemovedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceRoleRemoved,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET roles = array_remove(roles, $1) WHERE (project_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"role",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectGrantChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.GrantChangedType,
+						project.AggregateType,
+						[]byte(`{"grantId": "project-grant-id", "roleKeys": ["role"]}`),
+					),
+					project.GrantChangedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceProjectGrantChanged,
+			want: wantReduce{
+				aggregateType: project.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.groups_grants SET (roles) = (SELECT ARRAY( SELECT UNNEST(roles) INTERSECT SELECT UNNEST ($1::TEXT[]))) WHERE (grant_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								database.TextArray[string]{"role"},
+								"project-grant-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						[]byte(`{}`),
+					),
+					org.OrgRemovedEventMapper,
+				),
+			},
+			reduce: (&groupProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.groups_members WHERE (instance_id = $1) AND (user_resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(GroupInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.groups WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, GroupProjectionTable, tt.want)
+		})
+	}
+}
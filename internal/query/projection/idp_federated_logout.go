@@ -0,0 +1,154 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPFederatedLogoutTable            = "projections.idp_federated_logouts"
+	IDPFederatedLogoutIDPIDCol         = "idp_id"
+	IDPFederatedLogoutInstanceIDCol    = "instance_id"
+	IDPFederatedLogoutResourceOwnerCol = "resource_owner"
+	IDPFederatedLogoutCreationDateCol  = "creation_date"
+	IDPFederatedLogoutChangeDateCol    = "change_date"
+	IDPFederatedLogoutSequenceCol      = "sequence"
+	IDPFederatedLogoutEnabledCol       = "enabled"
+)
+
+type idpFederatedLogoutProjection struct{}
+
+func newIDPFederatedLogoutProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpFederatedLogoutProjection))
+}
+
+func (*idpFederatedLogoutProjection) Name() string {
+	return IDPFederatedLogoutTable
+}
+
+func (*idpFederatedLogoutProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPFederatedLogoutIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPFederatedLogoutInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPFederatedLogoutResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPFederatedLogoutCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPFederatedLogoutChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPFederatedLogoutSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPFederatedLogoutEnabledCol, handler.ColumnTypeBool, handler.Default(false)),
+		},
+			handler.NewPrimaryKey(IDPFederatedLogoutInstanceIDCol, IDPFederatedLogoutIDPIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{IDPFederatedLogoutResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *idpFederatedLogoutProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPFederatedLogoutSetEventType,
+					Reduce: p.reduceFederatedLogoutSet,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPFederatedLogoutInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPFederatedLogoutSetEventType,
+					Reduce: p.reduceFederatedLogoutSet,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpFederatedLogoutProjection) reduceFederatedLogoutSet(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.FederatedLogoutSetEvent
+	switch e := event.(type) {
+	case *org.IDPFederatedLogoutSetEvent:
+		idpEvent = e.FederatedLogoutSetEvent
+	case *instance.IDPFederatedLogoutSetEvent:
+		idpEvent = e.FederatedLogoutSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Fl1p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPFederatedLogoutSetEventType, instance.IDPFederatedLogoutSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&idpEvent,
+		[]handler.Column{
+			handler.NewCol(IDPFederatedLogoutInstanceIDCol, nil),
+			handler.NewCol(IDPFederatedLogoutIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPFederatedLogoutIDPIDCol, idpEvent.ID),
+			handler.NewCol(IDPFederatedLogoutInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPFederatedLogoutResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPFederatedLogoutCreationDateCol, handler.OnlySetValueOnInsert(IDPFederatedLogoutTable, idpEvent.CreationDate())),
+			handler.NewCol(IDPFederatedLogoutChangeDateCol, idpEvent.CreationDate()),
+			handler.NewCol(IDPFederatedLogoutSequenceCol, idpEvent.Sequence()),
+			handler.NewCol(IDPFederatedLogoutEnabledCol, idpEvent.Enabled),
+		},
+	), nil
+}
+
+func (p *idpFederatedLogoutProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Fl2p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&idpEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPFederatedLogoutIDPIDCol, idpEvent.ID),
+			handler.NewCond(IDPFederatedLogoutInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpFederatedLogoutProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPFederatedLogoutInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPFederatedLogoutResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
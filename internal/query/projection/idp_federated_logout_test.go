@@ -0,0 +1,181 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestIDPFederatedLogoutProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceFederatedLogoutSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.IDPFederatedLogoutSetEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-id", "enabled": true}`),
+					), instance.IDPFederatedLogoutSetEventMapper),
+			},
+			reduce: (&idpFederatedLogoutProjection{}).reduceFederatedLogoutSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_federated_logouts (idp_id, instance_id, resource_owner, creation_date, change_date, sequence, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, enabled) = (EXCLUDED.resource_owner, projections.idp_federated_logouts.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.enabled)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceFederatedLogoutSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.IDPFederatedLogoutSetEventType,
+						org.AggregateType,
+						[]byte(`{"id": "idp-id", "enabled": false}`),
+					), org.IDPFederatedLogoutSetEventMapper),
+			},
+			reduce: (&idpFederatedLogoutProjection{}).reduceFederatedLogoutSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_federated_logouts (idp_id, instance_id, resource_owner, creation_date, change_date, sequence, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, enabled) = (EXCLUDED.resource_owner, projections.idp_federated_logouts.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.enabled)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								false,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceIDPRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.IDPRemovedEventType,
+						instance.AggregateType,
+						[]byte(`{"id": "idp-id"}`),
+					), instance.IDPRemovedEventMapper),
+			},
+			reduce: (&idpFederatedLogoutProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_federated_logouts WHERE (idp_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&idpFederatedLogoutProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_federated_logouts WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(IDPFederatedLogoutInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_federated_logouts WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPFederatedLogoutTable, tt.want)
+		})
+	}
+}
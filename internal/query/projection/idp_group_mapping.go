@@ -0,0 +1,154 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPGroupMappingTable            = "projections.idp_group_mappings"
+	IDPGroupMappingIDPIDCol         = "idp_id"
+	IDPGroupMappingInstanceIDCol    = "instance_id"
+	IDPGroupMappingResourceOwnerCol = "resource_owner"
+	IDPGroupMappingCreationDateCol  = "creation_date"
+	IDPGroupMappingChangeDateCol    = "change_date"
+	IDPGroupMappingSequenceCol      = "sequence"
+	IDPGroupMappingMappingsCol      = "mappings"
+)
+
+type idpGroupMappingProjection struct{}
+
+func newIDPGroupMappingProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpGroupMappingProjection))
+}
+
+func (*idpGroupMappingProjection) Name() string {
+	return IDPGroupMappingTable
+}
+
+func (*idpGroupMappingProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPGroupMappingIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPGroupMappingInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPGroupMappingResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPGroupMappingCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPGroupMappingChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPGroupMappingSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPGroupMappingMappingsCol, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(IDPGroupMappingInstanceIDCol, IDPGroupMappingIDPIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{IDPGroupMappingResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *idpGroupMappingProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPGroupMappingsSetEventType,
+					Reduce: p.reduceGroupMappingsSet,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPGroupMappingInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPGroupMappingsSetEventType,
+					Reduce: p.reduceGroupMappingsSet,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpGroupMappingProjection) reduceGroupMappingsSet(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.GroupMappingsSetEvent
+	switch e := event.(type) {
+	case *org.IDPGroupMappingsSetEvent:
+		idpEvent = e.GroupMappingsSetEvent
+	case *instance.IDPGroupMappingsSetEvent:
+		idpEvent = e.GroupMappingsSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gm1p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPGroupMappingsSetEventType, instance.IDPGroupMappingsSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&idpEvent,
+		[]handler.Column{
+			handler.NewCol(IDPGroupMappingInstanceIDCol, nil),
+			handler.NewCol(IDPGroupMappingIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPGroupMappingIDPIDCol, idpEvent.ID),
+			handler.NewCol(IDPGroupMappingInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPGroupMappingResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPGroupMappingCreationDateCol, handler.OnlySetValueOnInsert(IDPGroupMappingTable, idpEvent.CreationDate())),
+			handler.NewCol(IDPGroupMappingChangeDateCol, idpEvent.CreationDate()),
+			handler.NewCol(IDPGroupMappingSequenceCol, idpEvent.Sequence()),
+			handler.NewJSONCol(IDPGroupMappingMappingsCol, idpEvent.Mappings),
+		},
+	), nil
+}
+
+func (p *idpGroupMappingProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gm2p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&idpEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPGroupMappingIDPIDCol, idpEvent.ID),
+			handler.NewCond(IDPGroupMappingInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpGroupMappingProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPGroupMappingInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPGroupMappingResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,155 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	IDPTokenExchangeClientsTable            = "projections.idp_token_exchange_clients"
+	IDPTokenExchangeClientsIDPIDCol         = "idp_id"
+	IDPTokenExchangeClientsInstanceIDCol    = "instance_id"
+	IDPTokenExchangeClientsResourceOwnerCol = "resource_owner"
+	IDPTokenExchangeClientsCreationDateCol  = "creation_date"
+	IDPTokenExchangeClientsChangeDateCol    = "change_date"
+	IDPTokenExchangeClientsSequenceCol      = "sequence"
+	IDPTokenExchangeClientsClientIDsCol     = "client_ids"
+)
+
+type idpTokenExchangeClientsProjection struct{}
+
+func newIDPTokenExchangeClientsProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(idpTokenExchangeClientsProjection))
+}
+
+func (*idpTokenExchangeClientsProjection) Name() string {
+	return IDPTokenExchangeClientsTable
+}
+
+func (*idpTokenExchangeClientsProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(IDPTokenExchangeClientsIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPTokenExchangeClientsInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPTokenExchangeClientsResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(IDPTokenExchangeClientsCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPTokenExchangeClientsChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(IDPTokenExchangeClientsSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(IDPTokenExchangeClientsClientIDsCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(IDPTokenExchangeClientsInstanceIDCol, IDPTokenExchangeClientsIDPIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{IDPTokenExchangeClientsResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *idpTokenExchangeClientsProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPTokenExchangeClientsSetEventType,
+					Reduce: p.reduceTokenExchangeClientsSet,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(IDPTokenExchangeClientsInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPTokenExchangeClientsSetEventType,
+					Reduce: p.reduceTokenExchangeClientsSet,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *idpTokenExchangeClientsProjection) reduceTokenExchangeClientsSet(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.TokenExchangeClientsSetEvent
+	switch e := event.(type) {
+	case *org.IDPTokenExchangeClientsSetEvent:
+		idpEvent = e.TokenExchangeClientsSetEvent
+	case *instance.IDPTokenExchangeClientsSetEvent:
+		idpEvent = e.TokenExchangeClientsSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Tx1p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPTokenExchangeClientsSetEventType, instance.IDPTokenExchangeClientsSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&idpEvent,
+		[]handler.Column{
+			handler.NewCol(IDPTokenExchangeClientsInstanceIDCol, nil),
+			handler.NewCol(IDPTokenExchangeClientsIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(IDPTokenExchangeClientsIDPIDCol, idpEvent.ID),
+			handler.NewCol(IDPTokenExchangeClientsInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			handler.NewCol(IDPTokenExchangeClientsResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+			handler.NewCol(IDPTokenExchangeClientsCreationDateCol, handler.OnlySetValueOnInsert(IDPTokenExchangeClientsTable, idpEvent.CreationDate())),
+			handler.NewCol(IDPTokenExchangeClientsChangeDateCol, idpEvent.CreationDate()),
+			handler.NewCol(IDPTokenExchangeClientsSequenceCol, idpEvent.Sequence()),
+			handler.NewCol(IDPTokenExchangeClientsClientIDsCol, database.TextArray[string](idpEvent.ClientIDs)),
+		},
+	), nil
+}
+
+func (p *idpTokenExchangeClientsProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Tx2p2", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&idpEvent,
+		[]handler.Condition{
+			handler.NewCond(IDPTokenExchangeClientsIDPIDCol, idpEvent.ID),
+			handler.NewCond(IDPTokenExchangeClientsInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *idpTokenExchangeClientsProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(IDPTokenExchangeClientsInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(IDPTokenExchangeClientsResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
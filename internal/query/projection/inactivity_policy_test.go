@@ -0,0 +1,264 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestInactivityPolicyProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.InactivityPolicyAddedEventType,
+						org.AggregateType,
+						[]byte(`{
+						"inactivityPeriod": 7776000000000000,
+						"warningPeriod": 604800000000000,
+						"dryRun": true
+}`),
+					), org.InactivityPolicyAddedEventMapper),
+			},
+			reduce: (&inactivityPolicyProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.inactivity_policies (creation_date, change_date, sequence, id, state, inactivity_period, warning_period, dry_run, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								domain.PolicyStateActive,
+								90 * 24 * time.Hour,
+								7 * 24 * time.Hour,
+								true,
+								false,
+								"ro-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org reduceChanged",
+			reduce: (&inactivityPolicyProjection{}).reduceChanged,
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.InactivityPolicyChangedEventType,
+						org.AggregateType,
+						[]byte(`{
+						"inactivityPeriod": 7776000000000000,
+						"dryRun": false
+		}`),
+					), org.InactivityPolicyChangedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.inactivity_policies SET (change_date, sequence, inactivity_period, dry_run) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								90 * 24 * time.Hour,
+								false,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org reduceRemoved",
+			reduce: (&inactivityPolicyProjection{}).reduceRemoved,
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.InactivityPolicyRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.InactivityPolicyRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.inactivity_policies WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(InactivityPolicyInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.inactivity_policies WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance reduceAdded",
+			reduce: (&inactivityPolicyProjection{}).reduceAdded,
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InactivityPolicyAddedEventType,
+						instance.AggregateType,
+						[]byte(`{
+						"inactivityPeriod": 7776000000000000,
+						"warningPeriod": 604800000000000
+					}`),
+					), instance.InactivityPolicyAddedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.inactivity_policies (creation_date, change_date, sequence, id, state, inactivity_period, warning_period, dry_run, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								domain.PolicyStateActive,
+								90 * 24 * time.Hour,
+								7 * 24 * time.Hour,
+								false,
+								true,
+								"ro-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "instance reduceChanged",
+			reduce: (&inactivityPolicyProjection{}).reduceChanged,
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InactivityPolicyChangedEventType,
+						instance.AggregateType,
+						[]byte(`{
+						"warningPeriod": 604800000000000
+					}`),
+					), instance.InactivityPolicyChangedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.inactivity_policies SET (change_date, sequence, warning_period) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								7 * 24 * time.Hour,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "org.reduceOwnerRemoved",
+			reduce: (&inactivityPolicyProjection{}).reduceOwnerRemoved,
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.inactivity_policies WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, InactivityPolicyTable, tt.want)
+		})
+	}
+}
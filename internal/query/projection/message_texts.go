@@ -273,7 +273,16 @@ func isMessageTemplate(template string) bool {
 		template == domain.DomainClaimedMessageType ||
 		template == domain.PasswordlessRegistrationMessageType ||
 		template == domain.PasswordChangeMessageType ||
-		template == domain.InviteUserMessageType
+		template == domain.InviteUserMessageType ||
+		template == domain.InactivityWarningMessageType ||
+		template == domain.GrantExpiryWarningMessageType ||
+		template == domain.AccessRequestMessageType ||
+		template == domain.NewDeviceSignInMessageType ||
+		template == domain.MFAAddedMessageType ||
+		template == domain.MFARemovedMessageType ||
+		template == domain.EmailChangeMessageType ||
+		template == domain.AccountLockedMessageType ||
+		template == domain.PersonalAccessTokenAddedMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -0,0 +1,257 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// NotificationDeliveryTable keeps a record per notification of the notification worker.
+	NotificationDeliveryTable = "projections.notification_deliveries"
+
+	NotificationDeliveryIDCol                = "id"
+	NotificationDeliveryInstanceIDCol        = "instance_id"
+	NotificationDeliveryCreationDateCol      = "creation_date"
+	NotificationDeliveryChangeDateCol        = "change_date"
+	NotificationDeliverySequenceCol          = "sequence"
+	NotificationDeliveryUserIDCol            = "user_id"
+	NotificationDeliveryUserResourceOwnerCol = "user_resource_owner"
+	NotificationDeliveryMessageTypeCol       = "message_type"
+	NotificationDeliveryChannelCol           = "channel"
+	NotificationDeliveryStatusCol            = "status"
+	NotificationDeliveryAttemptsCol          = "attempts"
+	NotificationDeliveryProviderIDCol        = "provider_id"
+	NotificationDeliveryProviderTypeCol      = "provider_type"
+	NotificationDeliveryRecipientCol         = "recipient"
+	NotificationDeliveryProviderMessageIDCol = "provider_message_id"
+	NotificationDeliveryErrorCol             = "error"
+)
+
+// The events of the notification aggregate are defined in the repository/notification package,
+// which cannot be imported as it depends on the query package.
+// Therefore, the projection only decodes the fields of the payload it needs.
+const (
+	notificationAggregateType                                  = "notification"
+	notificationRequestedEventType        eventstore.EventType = "notification.requested"
+	notificationRetryRequestedEventType   eventstore.EventType = "notification.retry.requested"
+	notificationSentEventType             eventstore.EventType = "notification.sent"
+	notificationCanceledEventType         eventstore.EventType = "notification.canceled"
+	notificationDeliveryReportedEventType eventstore.EventType = "notification.delivery.reported"
+)
+
+type notificationRequestPayload struct {
+	Request struct {
+		UserID            string                  `json:"userID"`
+		UserResourceOwner string                  `json:"userResourceOwner"`
+		MessageType       string                  `json:"messageType"`
+		NotificationType  domain.NotificationType `json:"notificationType"`
+	} `json:"request"`
+	Error string `json:"error"`
+}
+
+type notificationSentPayload struct {
+	ProviderID        string `json:"providerID"`
+	ProviderType      string `json:"providerType"`
+	Recipient         string `json:"recipient"`
+	ProviderMessageID string `json:"providerMessageID"`
+}
+
+type notificationCanceledPayload struct {
+	Error string `json:"error"`
+}
+
+type notificationDeliveryReportedPayload struct {
+	Status domain.NotificationDeliveryStatus `json:"status"`
+	Reason string                            `json:"reason"`
+}
+
+func notificationEventPayload[T any](event eventstore.Event, eventType eventstore.EventType) (*T, error) {
+	if event.Type() != eventType {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Nd1eT", "reduce.wrong.event.type %s", eventType)
+	}
+	payload := new(T)
+	if err := event.Unmarshal(payload); err != nil {
+		return nil, zerrors.ThrowInternal(err, "HANDL-Nd2uM", "Errors.Internal")
+	}
+	return payload, nil
+}
+
+type notificationDeliveryProjection struct{}
+
+func newNotificationDeliveryProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationDeliveryProjection))
+}
+
+func (*notificationDeliveryProjection) Name() string {
+	return NotificationDeliveryTable
+}
+
+func (*notificationDeliveryProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationDeliveryIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationDeliveryChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationDeliverySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationDeliveryUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryUserResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryMessageTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationDeliveryChannelCol, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationDeliveryStatusCol, handler.ColumnTypeEnum),
+			handler.NewColumn(NotificationDeliveryAttemptsCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(NotificationDeliveryProviderIDCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationDeliveryProviderTypeCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationDeliveryRecipientCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationDeliveryProviderMessageIDCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(NotificationDeliveryErrorCol, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(NotificationDeliveryInstanceIDCol, NotificationDeliveryIDCol),
+			handler.WithIndex(handler.NewIndex("user_id", []string{NotificationDeliveryUserIDCol})),
+			handler.WithIndex(handler.NewIndex("provider_message_id", []string{NotificationDeliveryProviderMessageIDCol})),
+		),
+	)
+}
+
+func (p *notificationDeliveryProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: notificationAggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  notificationRequestedEventType,
+					Reduce: p.reduceRequested,
+				},
+				{
+					Event:  notificationRetryRequestedEventType,
+					Reduce: p.reduceRetryRequested,
+				},
+				{
+					Event:  notificationSentEventType,
+					Reduce: p.reduceSent,
+				},
+				{
+					Event:  notificationCanceledEventType,
+					Reduce: p.reduceCanceled,
+				},
+				{
+					Event:  notificationDeliveryReportedEventType,
+					Reduce: p.reduceDeliveryReported,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationDeliveryProjection) reduceRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := notificationEventPayload[notificationRequestPayload](event, notificationRequestedEventType)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(NotificationDeliveryIDCol, event.Aggregate().ID),
+			handler.NewCol(NotificationDeliveryInstanceIDCol, event.Aggregate().InstanceID),
+			handler.NewCol(NotificationDeliveryCreationDateCol, event.CreatedAt()),
+			handler.NewCol(NotificationDeliveryChangeDateCol, event.CreatedAt()),
+			handler.NewCol(NotificationDeliverySequenceCol, event.Sequence()),
+			handler.NewCol(NotificationDeliveryUserIDCol, e.Request.UserID),
+			handler.NewCol(NotificationDeliveryUserResourceOwnerCol, e.Request.UserResourceOwner),
+			handler.NewCol(NotificationDeliveryMessageTypeCol, e.Request.MessageType),
+			handler.NewCol(NotificationDeliveryChannelCol, e.Request.NotificationType),
+			handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusPending),
+		},
+	), nil
+}
+
+// The attempts are derived from the sequence of the notification aggregate,
+// as every attempt after the request results in exactly one event (retry requested, sent or canceled).
+func (p *notificationDeliveryProjection) reduceRetryRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, err := notificationEventPayload[notificationRequestPayload](event, notificationRetryRequestedEventType)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(event,
+		handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusPending),
+		handler.NewCol(NotificationDeliveryAttemptsCol, event.Sequence()-1),
+		handler.NewCol(NotificationDeliveryErrorCol, e.Error),
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceSent(event eventstore.Event) (*handler.Statement, error) {
+	e, err := notificationEventPayload[notificationSentPayload](event, notificationSentEventType)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(event,
+		handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusSent),
+		handler.NewCol(NotificationDeliveryAttemptsCol, event.Sequence()-1),
+		handler.NewCol(NotificationDeliveryProviderIDCol, e.ProviderID),
+		handler.NewCol(NotificationDeliveryProviderTypeCol, e.ProviderType),
+		handler.NewCol(NotificationDeliveryRecipientCol, e.Recipient),
+		handler.NewCol(NotificationDeliveryProviderMessageIDCol, e.ProviderMessageID),
+		handler.NewCol(NotificationDeliveryErrorCol, ""),
+	), nil
+}
+
+func (p *notificationDeliveryProjection) reduceCanceled(event eventstore.Event) (*handler.Statement, error) {
+	e, err := notificationEventPayload[notificationCanceledPayload](event, notificationCanceledEventType)
+	if err != nil {
+		return nil, err
+	}
+	// notifications exceeding their time to live are canceled without an error and without another attempt
+	attempts := event.Sequence() - 1
+	if e.Error == "" {
+		attempts--
+	}
+	columns := []handler.Column{
+		handler.NewCol(NotificationDeliveryStatusCol, domain.NotificationDeliveryStatusFailed),
+		handler.NewCol(NotificationDeliveryAttemptsCol, attempts),
+	}
+	// keep the error of the last retry
+	if e.Error != "" {
+		columns = append(columns, handler.NewCol(NotificationDeliveryErrorCol, e.Error))
+	}
+	return p.updateStatement(event, columns...), nil
+}
+
+func (p *notificationDeliveryProjection) reduceDeliveryReported(event eventstore.Event) (*handler.Statement, error) {
+	e, err := notificationEventPayload[notificationDeliveryReportedPayload](event, notificationDeliveryReportedEventType)
+	if err != nil {
+		return nil, err
+	}
+	return p.updateStatement(event,
+		handler.NewCol(NotificationDeliveryStatusCol, e.Status),
+		handler.NewCol(NotificationDeliveryErrorCol, e.Reason),
+	), nil
+}
+
+func (p *notificationDeliveryProjection) updateStatement(e eventstore.Event, columns ...handler.Column) *handler.Statement {
+	return handler.NewUpdateStatement(
+		e,
+		append([]handler.Column{
+			handler.NewCol(NotificationDeliveryChangeDateCol, e.CreatedAt()),
+			handler.NewCol(NotificationDeliverySequenceCol, e.Sequence()),
+		}, columns...),
+		[]handler.Condition{
+			handler.NewCond(NotificationDeliveryInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationDeliveryIDCol, e.Aggregate().ID),
+		},
+	)
+}
@@ -0,0 +1,264 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func notificationEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return eventstore.BaseEventFromRepo(event), nil
+}
+
+func TestNotificationDeliveryProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationRequestedEventType,
+						notificationAggregateType,
+						[]byte(`{"request": {"userID": "user-id", "userResourceOwner": "org-id", "messageType": "InitCode", "notificationType": 1}}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceRequested,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_deliveries (id, instance_id, creation_date, change_date, sequence, user_id, user_resource_owner, message_type, channel, status) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"user-id",
+								"org-id",
+								"InitCode",
+								domain.NotificationTypeSms,
+								domain.NotificationDeliveryStatusPending,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRetryRequested",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationRetryRequestedEventType,
+						notificationAggregateType,
+						[]byte(`{"request": {"userID": "user-id"}, "error": "provider unavailable"}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceRetryRequested,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, attempts, error) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusPending,
+								uint64(14),
+								"provider unavailable",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSent",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationSentEventType,
+						notificationAggregateType,
+						[]byte(`{"providerID": "provider-id", "providerType": "smtp", "recipient": "g***@zitadel.com", "providerMessageID": "message-id"}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceSent,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, attempts, provider_id, provider_type, recipient, provider_message_id, error) = ($1, $2, $3, $4, $5, $6, $7, $8, $9) WHERE (instance_id = $10) AND (id = $11)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusSent,
+								uint64(14),
+								"provider-id",
+								"smtp",
+								"g***@zitadel.com",
+								"message-id",
+								"",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCanceled",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationCanceledEventType,
+						notificationAggregateType,
+						[]byte(`{"error": "invalid recipient"}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceCanceled,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, attempts, error) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusFailed,
+								uint64(14),
+								"invalid recipient",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCanceled expired",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationCanceledEventType,
+						notificationAggregateType,
+						[]byte(`{}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceCanceled,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, attempts) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusFailed,
+								uint64(13),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceDeliveryReported",
+			args: args{
+				event: getEvent(
+					testEvent(
+						notificationDeliveryReportedEventType,
+						notificationAggregateType,
+						[]byte(`{"status": 5, "reason": "mailbox full"}`),
+					), notificationEventMapper),
+			},
+			reduce: (&notificationDeliveryProjection{}).reduceDeliveryReported,
+			want: wantReduce{
+				aggregateType: notificationAggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.notification_deliveries SET (change_date, sequence, status, error) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.NotificationDeliveryStatusBounced,
+								"mailbox full",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationDeliveryInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_deliveries WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationDeliveryTable, tt.want)
+		})
+	}
+}
@@ -14,18 +14,24 @@ import (
 )
 
 const (
-	NotificationPolicyProjectionTable = "projections.notification_policies"
+	NotificationPolicyProjectionTable = "projections.notification_policies2"
 
-	NotificationPolicyColumnID             = "id"
-	NotificationPolicyColumnCreationDate   = "creation_date"
-	NotificationPolicyColumnChangeDate     = "change_date"
-	NotificationPolicyColumnResourceOwner  = "resource_owner"
-	NotificationPolicyColumnInstanceID     = "instance_id"
-	NotificationPolicyColumnSequence       = "sequence"
-	NotificationPolicyColumnStateCol       = "state"
-	NotificationPolicyColumnIsDefault      = "is_default"
-	NotificationPolicyColumnPasswordChange = "password_change"
-	NotificationPolicyColumnOwnerRemoved   = "owner_removed"
+	NotificationPolicyColumnID                       = "id"
+	NotificationPolicyColumnCreationDate             = "creation_date"
+	NotificationPolicyColumnChangeDate               = "change_date"
+	NotificationPolicyColumnResourceOwner            = "resource_owner"
+	NotificationPolicyColumnInstanceID               = "instance_id"
+	NotificationPolicyColumnSequence                 = "sequence"
+	NotificationPolicyColumnStateCol                 = "state"
+	NotificationPolicyColumnIsDefault                = "is_default"
+	NotificationPolicyColumnPasswordChange           = "password_change"
+	NotificationPolicyColumnNewDeviceSignIn          = "new_device_sign_in"
+	NotificationPolicyColumnMFAAdded                 = "mfa_added"
+	NotificationPolicyColumnMFARemoved               = "mfa_removed"
+	NotificationPolicyColumnEmailChange              = "email_change"
+	NotificationPolicyColumnAccountLocked            = "account_locked"
+	NotificationPolicyColumnPersonalAccessTokenAdded = "personal_access_token_added"
+	NotificationPolicyColumnOwnerRemoved             = "owner_removed"
 )
 
 type notificationPolicyProjection struct{}
@@ -50,6 +56,12 @@ func (*notificationPolicyProjection) Init() *old_handler.Check {
 			handler.NewColumn(NotificationPolicyColumnStateCol, handler.ColumnTypeEnum),
 			handler.NewColumn(NotificationPolicyColumnIsDefault, handler.ColumnTypeBool),
 			handler.NewColumn(NotificationPolicyColumnPasswordChange, handler.ColumnTypeBool),
+			handler.NewColumn(NotificationPolicyColumnNewDeviceSignIn, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnMFAAdded, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnMFARemoved, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnEmailChange, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnAccountLocked, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(NotificationPolicyColumnPersonalAccessTokenAdded, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(NotificationPolicyColumnOwnerRemoved, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(NotificationPolicyColumnInstanceID, NotificationPolicyColumnID),
@@ -122,6 +134,12 @@ func (p *notificationPolicyProjection) reduceAdded(event eventstore.Event) (*han
 			handler.NewCol(NotificationPolicyColumnID, policyEvent.Aggregate().ID),
 			handler.NewCol(NotificationPolicyColumnStateCol, domain.PolicyStateActive),
 			handler.NewCol(NotificationPolicyColumnPasswordChange, policyEvent.PasswordChange),
+			handler.NewCol(NotificationPolicyColumnNewDeviceSignIn, policyEvent.NewDeviceSignIn),
+			handler.NewCol(NotificationPolicyColumnMFAAdded, policyEvent.MFAAdded),
+			handler.NewCol(NotificationPolicyColumnMFARemoved, policyEvent.MFARemoved),
+			handler.NewCol(NotificationPolicyColumnEmailChange, policyEvent.EmailChange),
+			handler.NewCol(NotificationPolicyColumnAccountLocked, policyEvent.AccountLocked),
+			handler.NewCol(NotificationPolicyColumnPersonalAccessTokenAdded, policyEvent.PersonalAccessTokenAdded),
 			handler.NewCol(NotificationPolicyColumnIsDefault, isDefault),
 			handler.NewCol(NotificationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
 			handler.NewCol(NotificationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
@@ -145,6 +163,24 @@ func (p *notificationPolicyProjection) reduceChanged(event eventstore.Event) (*h
 	if policyEvent.PasswordChange != nil {
 		cols = append(cols, handler.NewCol(NotificationPolicyColumnPasswordChange, *policyEvent.PasswordChange))
 	}
+	if policyEvent.NewDeviceSignIn != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnNewDeviceSignIn, *policyEvent.NewDeviceSignIn))
+	}
+	if policyEvent.MFAAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFAAdded, *policyEvent.MFAAdded))
+	}
+	if policyEvent.MFARemoved != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnMFARemoved, *policyEvent.MFARemoved))
+	}
+	if policyEvent.EmailChange != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnEmailChange, *policyEvent.EmailChange))
+	}
+	if policyEvent.AccountLocked != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnAccountLocked, *policyEvent.AccountLocked))
+	}
+	if policyEvent.PersonalAccessTokenAdded != nil {
+		cols = append(cols, handler.NewCol(NotificationPolicyColumnPersonalAccessTokenAdded, *policyEvent.PersonalAccessTokenAdded))
+	}
 	return handler.NewUpdateStatement(
 		&policyEvent,
 		cols,
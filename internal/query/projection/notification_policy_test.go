@@ -29,7 +29,9 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 						org.NotificationPolicyAddedEventType,
 						org.AggregateType,
 						[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"newDeviceSignIn": true,
+						"accountLocked": true
 }`),
 					), org.NotificationPolicyAddedEventMapper),
 			},
@@ -40,7 +42,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies2 (creation_date, change_date, sequence, id, state, password_change, new_device_sign_in, mfa_added, mfa_removed, email_change, account_locked, personal_access_token_added, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -48,6 +50,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								true,
+								true,
+								false,
+								false,
+								false,
+								true,
+								false,
 								false,
 								"ro-id",
 								"instance-id",
@@ -66,7 +74,8 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 						org.NotificationPolicyChangedEventType,
 						org.AggregateType,
 						[]byte(`{
-						"passwordChange": true
+						"passwordChange": true,
+						"mfaAdded": false
 		}`),
 					), org.NotificationPolicyChangedEventMapper),
 			},
@@ -76,11 +85,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies2 SET (change_date, sequence, password_change, mfa_added) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								true,
+								false,
 								"agg-id",
 								"instance-id",
 							},
@@ -106,7 +116,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.notification_policies2 WHERE (id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -132,7 +142,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.notification_policies2 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -160,7 +170,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.notification_policies (creation_date, change_date, sequence, id, state, password_change, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.notification_policies2 (creation_date, change_date, sequence, id, state, password_change, new_device_sign_in, mfa_added, mfa_removed, email_change, account_locked, personal_access_token_added, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -168,6 +178,12 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								true,
+								false,
+								false,
+								false,
+								false,
+								false,
+								false,
 								true,
 								"ro-id",
 								"instance-id",
@@ -196,7 +212,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.notification_policies SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedStmt: "UPDATE projections.notification_policies2 SET (change_date, sequence, password_change) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -226,7 +242,7 @@ func TestNotificationPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.notification_policies WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.notification_policies2 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -0,0 +1,144 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	NotificationTemplateTable = "projections.notification_templates"
+
+	NotificationTemplateAggregateIDCol  = "aggregate_id"
+	NotificationTemplateInstanceIDCol   = "instance_id"
+	NotificationTemplateCreationDateCol = "creation_date"
+	NotificationTemplateChangeDateCol   = "change_date"
+	NotificationTemplateSequenceCol     = "sequence"
+	NotificationTemplateMessageTypeCol  = "message_type"
+	NotificationTemplateLanguageCol     = "language"
+	NotificationTemplateSubjectCol      = "subject"
+	NotificationTemplateHTMLCol         = "html"
+	NotificationTemplateTextCol         = "text"
+)
+
+type notificationTemplateProjection struct{}
+
+func newNotificationTemplateProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(notificationTemplateProjection))
+}
+
+func (*notificationTemplateProjection) Name() string {
+	return NotificationTemplateTable
+}
+
+func (*notificationTemplateProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(NotificationTemplateAggregateIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationTemplateChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(NotificationTemplateSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(NotificationTemplateMessageTypeCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateLanguageCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateSubjectCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateHTMLCol, handler.ColumnTypeText),
+			handler.NewColumn(NotificationTemplateTextCol, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(NotificationTemplateInstanceIDCol, NotificationTemplateAggregateIDCol, NotificationTemplateMessageTypeCol, NotificationTemplateLanguageCol),
+		),
+	)
+}
+
+func (p *notificationTemplateProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.NotificationTemplateSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.NotificationTemplateRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(NotificationTemplateInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *notificationTemplateProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.NotificationTemplateSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(NotificationTemplateInstanceIDCol, nil),
+			handler.NewCol(NotificationTemplateAggregateIDCol, nil),
+			handler.NewCol(NotificationTemplateMessageTypeCol, nil),
+			handler.NewCol(NotificationTemplateLanguageCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(NotificationTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(NotificationTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCol(NotificationTemplateMessageTypeCol, e.MessageType),
+			handler.NewCol(NotificationTemplateLanguageCol, e.Language.String()),
+			handler.NewCol(NotificationTemplateCreationDateCol, handler.OnlySetValueOnInsert(NotificationTemplateTable, e.CreatedAt())),
+			handler.NewCol(NotificationTemplateChangeDateCol, e.CreatedAt()),
+			handler.NewCol(NotificationTemplateSequenceCol, e.Sequence()),
+			handler.NewCol(NotificationTemplateSubjectCol, e.Subject),
+			handler.NewCol(NotificationTemplateHTMLCol, e.HTML),
+			handler.NewCol(NotificationTemplateTextCol, e.Text),
+		},
+	), nil
+}
+
+func (p *notificationTemplateProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.NotificationTemplateRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationTemplateAggregateIDCol, e.Aggregate().ID),
+			handler.NewCond(NotificationTemplateMessageTypeCol, e.MessageType),
+			handler.NewCond(NotificationTemplateLanguageCol, e.Language.String()),
+		},
+	), nil
+}
+
+func (p *notificationTemplateProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(NotificationTemplateInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(NotificationTemplateAggregateIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,154 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestNotificationTemplateProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.NotificationTemplateSetEventType,
+						org.AggregateType,
+						[]byte(`{"messageType": "InitCode", "language": "en", "subject": "{{.Subject}}", "html": "<p>{{.Args.Code}}</p>", "text": "{{.Args.Code}}"}`),
+					), eventstore.GenericEventMapper[org.NotificationTemplateSetEvent]),
+			},
+			reduce: (&notificationTemplateProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.notification_templates (instance_id, aggregate_id, message_type, language, creation_date, change_date, sequence, subject, html, text) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, aggregate_id, message_type, language) DO UPDATE SET (creation_date, change_date, sequence, subject, html, text) = (projections.notification_templates.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.subject, EXCLUDED.html, EXCLUDED.text)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"InitCode",
+								"en",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"{{.Subject}}",
+								"<p>{{.Args.Code}}</p>",
+								"{{.Args.Code}}",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.NotificationTemplateRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"messageType": "InitCode", "language": "en"}`),
+					), eventstore.GenericEventMapper[org.NotificationTemplateRemovedEvent]),
+			},
+			reduce: (&notificationTemplateProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_templates WHERE (instance_id = $1) AND (aggregate_id = $2) AND (message_type = $3) AND (language = $4)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"InitCode",
+								"en",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&notificationTemplateProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_templates WHERE (instance_id = $1) AND (aggregate_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(NotificationTemplateInstanceIDCol),
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.notification_templates WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, NotificationTemplateTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	// OrgHierarchyTable only contains the organizations with a parent.
+	OrgHierarchyTable = "projections.org_hierarchy"
+
+	OrgHierarchyOrgIDCol        = "org_id"
+	OrgHierarchyInstanceIDCol   = "instance_id"
+	OrgHierarchyParentOrgIDCol  = "parent_org_id"
+	OrgHierarchyCreationDateCol = "creation_date"
+	OrgHierarchyChangeDateCol   = "change_date"
+	OrgHierarchySequenceCol     = "sequence"
+)
+
+type orgHierarchyProjection struct{}
+
+func newOrgHierarchyProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(orgHierarchyProjection))
+}
+
+func (*orgHierarchyProjection) Name() string {
+	return OrgHierarchyTable
+}
+
+func (*orgHierarchyProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(OrgHierarchyOrgIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgHierarchyInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgHierarchyParentOrgIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OrgHierarchyCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgHierarchyChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(OrgHierarchySequenceCol, handler.ColumnTypeInt64),
+		},
+			handler.NewPrimaryKey(OrgHierarchyInstanceIDCol, OrgHierarchyOrgIDCol),
+			handler.WithIndex(handler.NewIndex("parent", []string{OrgHierarchyInstanceIDCol, OrgHierarchyParentOrgIDCol})),
+		),
+	)
+}
+
+func (p *orgHierarchyProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgParentSetEventType,
+					Reduce: p.reduceParentSet,
+				},
+				{
+					Event:  org.OrgParentRemovedEventType,
+					Reduce: p.reduceParentRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOrgRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(OrgHierarchyInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *orgHierarchyProjection) reduceParentSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgParentSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(OrgHierarchyInstanceIDCol, nil),
+			handler.NewCol(OrgHierarchyOrgIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(OrgHierarchyOrgIDCol, e.Aggregate().ID),
+			handler.NewCol(OrgHierarchyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(OrgHierarchyParentOrgIDCol, e.ParentOrgID),
+			handler.NewCol(OrgHierarchyCreationDateCol, handler.OnlySetValueOnInsert(OrgHierarchyTable, e.CreationDate())),
+			handler.NewCol(OrgHierarchyChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgHierarchySequenceCol, e.Sequence()),
+		},
+	), nil
+}
+
+func (p *orgHierarchyProjection) reduceParentRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgParentRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(OrgHierarchyInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(OrgHierarchyOrgIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+// reduceOrgRemoved removes the organization from the hierarchy.
+// Its children become root organizations.
+func (p *orgHierarchyProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(OrgHierarchyInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(OrgHierarchyOrgIDCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(OrgHierarchyInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(OrgHierarchyParentOrgIDCol, e.Aggregate().ID),
+			},
+		),
+	), nil
+}
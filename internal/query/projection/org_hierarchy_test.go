@@ -0,0 +1,155 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestOrgHierarchyProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceParentSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgParentSetEventType,
+						org.AggregateType,
+						[]byte(`{"parentOrgId": "parent-id"}`),
+					), eventstore.GenericEventMapper[org.OrgParentSetEvent]),
+			},
+			reduce: (&orgHierarchyProjection{}).reduceParentSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_hierarchy (org_id, instance_id, parent_org_id, creation_date, change_date, sequence) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (instance_id, org_id) DO UPDATE SET (parent_org_id, creation_date, change_date, sequence) = (EXCLUDED.parent_org_id, projections.org_hierarchy.creation_date, EXCLUDED.change_date, EXCLUDED.sequence)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"parent-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceParentRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgParentRemovedEventType,
+						org.AggregateType,
+						[]byte(`{"parentOrgId": "parent-id"}`),
+					), eventstore.GenericEventMapper[org.OrgParentRemovedEvent]),
+			},
+			reduce: (&orgHierarchyProjection{}).reduceParentRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_hierarchy WHERE (instance_id = $1) AND (org_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOrgRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&orgHierarchyProjection{}).reduceOrgRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_hierarchy WHERE (instance_id = $1) AND (org_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.org_hierarchy WHERE (instance_id = $1) AND (parent_org_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(OrgHierarchyInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.org_hierarchy WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, OrgHierarchyTable, tt.want)
+		})
+	}
+}
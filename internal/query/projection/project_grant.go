@@ -2,6 +2,7 @@ package projection
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -15,7 +16,7 @@ import (
 )
 
 const (
-	ProjectGrantProjectionTable = "projections.project_grants4"
+	ProjectGrantProjectionTable = "projections.project_grants5"
 
 	ProjectGrantColumnGrantID       = "grant_id"
 	ProjectGrantColumnCreationDate  = "creation_date"
@@ -27,6 +28,9 @@ const (
 	ProjectGrantColumnProjectID     = "project_id"
 	ProjectGrantColumnGrantedOrgID  = "granted_org_id"
 	ProjectGrantColumnRoleKeys      = "granted_role_keys"
+	ProjectGrantColumnValidFrom     = "valid_from"
+	ProjectGrantColumnValidUntil    = "valid_until"
+	ProjectGrantColumnExpiryWarned  = "expiry_warned"
 )
 
 type projectGrantProjection struct{}
@@ -52,6 +56,9 @@ func (*projectGrantProjection) Init() *old_handler.Check {
 			handler.NewColumn(ProjectGrantColumnProjectID, handler.ColumnTypeText),
 			handler.NewColumn(ProjectGrantColumnGrantedOrgID, handler.ColumnTypeText),
 			handler.NewColumn(ProjectGrantColumnRoleKeys, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(ProjectGrantColumnValidFrom, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(ProjectGrantColumnValidUntil, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(ProjectGrantColumnExpiryWarned, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(ProjectGrantColumnInstanceID, ProjectGrantColumnGrantID),
 			handler.WithIndex(handler.NewIndex("resource_owner", []string{ProjectGrantColumnResourceOwner})),
@@ -85,6 +92,18 @@ func (p *projectGrantProjection) Reducers() []handler.AggregateReducer {
 					Event:  project.GrantReactivatedType,
 					Reduce: p.reduceProjectGrantReactivated,
 				},
+				{
+					Event:  project.GrantValidityChangedType,
+					Reduce: p.reduceProjectGrantValidityChanged,
+				},
+				{
+					Event:  project.GrantExpiryWarnedType,
+					Reduce: p.reduceProjectGrantExpiryWarned,
+				},
+				{
+					Event:  project.GrantExpiredType,
+					Reduce: p.reduceProjectGrantExpired,
+				},
 				{
 					Event:  project.GrantRemovedType,
 					Reduce: p.reduceProjectGrantRemoved,
@@ -218,6 +237,68 @@ func (p *projectGrantProjection) reduceProjectGrantReactivated(event eventstore.
 	), nil
 }
 
+func (p *projectGrantProjection) reduceProjectGrantValidityChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantValidityChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gv1Rd", "reduce.wrong.event.type %s", project.GrantValidityChangedType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ProjectGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ProjectGrantColumnSequence, e.Sequence()),
+			handler.NewCol(ProjectGrantColumnValidFrom, &sql.NullTime{Time: e.ValidFrom, Valid: !e.ValidFrom.IsZero()}),
+			handler.NewCol(ProjectGrantColumnValidUntil, &sql.NullTime{Time: e.ValidUntil, Valid: !e.ValidUntil.IsZero()}),
+			handler.NewCol(ProjectGrantColumnExpiryWarned, false),
+		},
+		[]handler.Condition{
+			handler.NewCond(ProjectGrantColumnGrantID, e.GrantID),
+			handler.NewCond(ProjectGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *projectGrantProjection) reduceProjectGrantExpiryWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantExpiryWarnedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gv2Rd", "reduce.wrong.event.type %s", project.GrantExpiryWarnedType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ProjectGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ProjectGrantColumnSequence, e.Sequence()),
+			handler.NewCol(ProjectGrantColumnExpiryWarned, true),
+		},
+		[]handler.Condition{
+			handler.NewCond(ProjectGrantColumnGrantID, e.GrantID),
+			handler.NewCond(ProjectGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *projectGrantProjection) reduceProjectGrantExpired(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.GrantExpiredEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Gv3Rd", "reduce.wrong.event.type %s", project.GrantExpiredType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ProjectGrantColumnChangeDate, e.CreationDate()),
+			handler.NewCol(ProjectGrantColumnSequence, e.Sequence()),
+			handler.NewCol(ProjectGrantColumnState, domain.ProjectGrantStateInactive),
+		},
+		[]handler.Condition{
+			handler.NewCond(ProjectGrantColumnGrantID, e.GrantID),
+			handler.NewCond(ProjectGrantColumnProjectID, e.Aggregate().ID),
+			handler.NewCond(ProjectGrantColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *projectGrantProjection) reduceProjectGrantRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*project.GrantRemovedEvent)
 	if !ok {
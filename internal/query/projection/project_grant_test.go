@@ -1,7 +1,9 @@
 package projection
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -40,7 +42,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_grants4 WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.project_grants5 WHERE (project_id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -67,7 +69,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_grants4 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.project_grants5 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -93,7 +95,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_grants4 WHERE (grant_id = $1) AND (project_id = $2) AND (instance_id = $3)",
+							expectedStmt: "DELETE FROM projections.project_grants5 WHERE (grant_id = $1) AND (project_id = $2) AND (instance_id = $3)",
 							expectedArgs: []interface{}{
 								"grant-id",
 								"agg-id",
@@ -121,7 +123,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_grants4 SET (change_date, sequence, state) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, state) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -135,6 +137,101 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceProjectGrantValidityChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.GrantValidityChangedType,
+						project.AggregateType,
+						[]byte(`{"grantId": "grant-id", "validFrom": "2023-01-01T00:00:00Z", "validUntil": "2024-01-01T00:00:00Z"}`),
+					), eventstore.GenericEventMapper[project.GrantValidityChangedEvent]),
+			},
+			reduce: (&projectGrantProjection{}).reduceProjectGrantValidityChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, valid_from, valid_until, expiry_warned) = ($1, $2, $3, $4, $5) WHERE (grant_id = $6) AND (project_id = $7) AND (instance_id = $8)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								&sql.NullTime{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+								&sql.NullTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+								false,
+								"grant-id",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectGrantExpiryWarned",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.GrantExpiryWarnedType,
+						project.AggregateType,
+						[]byte(`{"grantId": "grant-id", "grantedOrgId": "granted-org-id", "validUntil": "2024-01-01T00:00:00Z"}`),
+					), eventstore.GenericEventMapper[project.GrantExpiryWarnedEvent]),
+			},
+			reduce: (&projectGrantProjection{}).reduceProjectGrantExpiryWarned,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, expiry_warned) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"grant-id",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectGrantExpired",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.GrantExpiredType,
+						project.AggregateType,
+						[]byte(`{"grantId": "grant-id", "validUntil": "2024-01-01T00:00:00Z"}`),
+					), eventstore.GenericEventMapper[project.GrantExpiredEvent]),
+			},
+			reduce: (&projectGrantProjection{}).reduceProjectGrantExpired,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, state) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.ProjectGrantStateInactive,
+								"grant-id",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceProjectGrantDeactivated",
 			args: args{
@@ -152,7 +249,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_grants4 SET (change_date, sequence, state) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, state) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -183,7 +280,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_grants4 SET (change_date, sequence, granted_role_keys) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, granted_role_keys) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -214,7 +311,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_grants4 SET (change_date, sequence, granted_role_keys) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedStmt: "UPDATE projections.project_grants5 SET (change_date, sequence, granted_role_keys) = ($1, $2, $3) WHERE (grant_id = $4) AND (project_id = $5) AND (instance_id = $6)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -245,7 +342,7 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.project_grants4 (grant_id, project_id, creation_date, change_date, resource_owner, instance_id, state, sequence, granted_org_id, granted_role_keys) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.project_grants5 (grant_id, project_id, creation_date, change_date, resource_owner, instance_id, state, sequence, granted_org_id, granted_role_keys) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"grant-id",
 								"agg-id",
@@ -280,14 +377,14 @@ func TestProjectGrantProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_grants4 WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.project_grants5 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
 							},
 						},
 						{
-							expectedStmt: "DELETE FROM projections.project_grants4 WHERE (instance_id = $1) AND (granted_org_id = $2)",
+							expectedStmt: "DELETE FROM projections.project_grants5 WHERE (instance_id = $1) AND (granted_org_id = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -13,7 +14,7 @@ import (
 )
 
 const (
-	ProjectRoleProjectionTable = "projections.project_roles4"
+	ProjectRoleProjectionTable = "projections.project_roles5"
 
 	ProjectRoleColumnProjectID     = "project_id"
 	ProjectRoleColumnKey           = "role_key"
@@ -24,6 +25,7 @@ const (
 	ProjectRoleColumnInstanceID    = "instance_id"
 	ProjectRoleColumnDisplayName   = "display_name"
 	ProjectRoleColumnGroupName     = "group_name"
+	ProjectRoleColumnIncludedRoles = "included_roles"
 )
 
 type projectRoleProjection struct{}
@@ -44,6 +46,7 @@ func (*projectRoleProjection) Init() *old_handler.Check {
 			handler.NewColumn(ProjectRoleColumnInstanceID, handler.ColumnTypeText),
 			handler.NewColumn(ProjectRoleColumnDisplayName, handler.ColumnTypeText),
 			handler.NewColumn(ProjectRoleColumnGroupName, handler.ColumnTypeText),
+			handler.NewColumn(ProjectRoleColumnIncludedRoles, handler.ColumnTypeTextArray, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(ProjectRoleColumnInstanceID, ProjectRoleColumnProjectID, ProjectRoleColumnKey),
 		),
@@ -115,6 +118,7 @@ func (p *projectRoleProjection) reduceProjectRoleAdded(event eventstore.Event) (
 			handler.NewCol(ProjectRoleColumnSequence, e.Sequence()),
 			handler.NewCol(ProjectRoleColumnDisplayName, e.DisplayName),
 			handler.NewCol(ProjectRoleColumnGroupName, e.Group),
+			handler.NewCol(ProjectRoleColumnIncludedRoles, database.TextArray[string](e.IncludedRoles)),
 		},
 	), nil
 }
@@ -124,7 +128,7 @@ func (p *projectRoleProjection) reduceProjectRoleChanged(event eventstore.Event)
 	if !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-sM0f", "reduce.wrong.event.type %s", project.GrantChangedType)
 	}
-	if e.DisplayName == nil && e.Group == nil {
+	if e.DisplayName == nil && e.Group == nil && e.IncludedRoles == nil {
 		return handler.NewNoOpStatement(e), nil
 	}
 	columns := make([]handler.Column, 0, 7)
@@ -136,6 +140,9 @@ func (p *projectRoleProjection) reduceProjectRoleChanged(event eventstore.Event)
 	if e.Group != nil {
 		columns = append(columns, handler.NewCol(ProjectRoleColumnGroupName, *e.Group))
 	}
+	if e.IncludedRoles != nil {
+		columns = append(columns, handler.NewCol(ProjectRoleColumnIncludedRoles, database.TextArray[string](*e.IncludedRoles)))
+	}
 	return handler.NewUpdateStatement(
 		e,
 		columns,
@@ -152,13 +159,26 @@ func (p *projectRoleProjection) reduceProjectRoleRemoved(event eventstore.Event)
 	if !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-L0fJf", "reduce.wrong.event.type %s", project.GrantRemovedType)
 	}
-	return handler.NewDeleteStatement(
+	return handler.NewMultiStatement(
 		e,
-		[]handler.Condition{
-			handler.NewCond(ProjectRoleColumnKey, e.Key),
-			handler.NewCond(ProjectRoleColumnProjectID, e.Aggregate().ID),
-			handler.NewCond(ProjectRoleColumnInstanceID, e.Aggregate().InstanceID),
-		},
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(ProjectRoleColumnKey, e.Key),
+				handler.NewCond(ProjectRoleColumnProjectID, e.Aggregate().ID),
+				handler.NewCond(ProjectRoleColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+		// the removed role is no longer included in other roles
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewArrayRemoveCol(ProjectRoleColumnIncludedRoles, e.Key),
+			},
+			[]handler.Condition{
+				handler.NewCond(ProjectRoleColumnProjectID, e.Aggregate().ID),
+				handler.NewCond(ProjectRoleColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewTextArrayContainsCond(ProjectRoleColumnIncludedRoles, e.Key),
+			},
+		),
 	), nil
 }
 
@@ -3,6 +3,7 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/repository/instance"
@@ -38,7 +39,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_roles4 WHERE (project_id = $1) AND (instance_id = $2)",
+							expectedStmt: "DELETE FROM projections.project_roles5 WHERE (project_id = $1) AND (instance_id = $2)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -65,7 +66,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_roles4 WHERE (instance_id = $1)",
+							expectedStmt: "DELETE FROM projections.project_roles5 WHERE (instance_id = $1)",
 							expectedArgs: []interface{}{
 								"agg-id",
 							},
@@ -91,13 +92,22 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_roles4 WHERE (role_key = $1) AND (project_id = $2) AND (instance_id = $3)",
+							expectedStmt: "DELETE FROM projections.project_roles5 WHERE (role_key = $1) AND (project_id = $2) AND (instance_id = $3)",
 							expectedArgs: []interface{}{
 								"key",
 								"agg-id",
 								"instance-id",
 							},
 						},
+						{
+							expectedStmt: "UPDATE projections.project_roles5 SET included_roles = array_remove(included_roles, $1) WHERE (project_id = $2) AND (instance_id = $3) AND (included_roles @> $4)",
+							expectedArgs: []interface{}{
+								"key",
+								"agg-id",
+								"instance-id",
+								database.TextArray[string]{"key"},
+							},
+						},
 					},
 				},
 			},
@@ -119,7 +129,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.project_roles4 SET (change_date, sequence, display_name, group_name) = ($1, $2, $3, $4) WHERE (role_key = $5) AND (project_id = $6) AND (instance_id = $7)",
+							expectedStmt: "UPDATE projections.project_roles5 SET (change_date, sequence, display_name, group_name) = ($1, $2, $3, $4) WHERE (role_key = $5) AND (project_id = $6) AND (instance_id = $7)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
@@ -134,6 +144,37 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceProjectRoleChanged included roles",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.RoleChangedType,
+						project.AggregateType,
+						[]byte(`{"key": "key", "includedRoles": []}`),
+					), project.RoleChangedEventMapper),
+			},
+			reduce: (&projectRoleProjection{}).reduceProjectRoleChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.project_roles5 SET (change_date, sequence, included_roles) = ($1, $2, $3) WHERE (role_key = $4) AND (project_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								database.TextArray[string]{},
+								"key",
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceProjectRoleChanged no changes",
 			args: args{
@@ -158,7 +199,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 					testEvent(
 						project.RoleAddedType,
 						project.AggregateType,
-						[]byte(`{"key": "key", "displayName": "Key", "group": "Group"}`),
+						[]byte(`{"key": "key", "displayName": "Key", "group": "Group", "includedRoles": ["included"]}`),
 					), project.RoleAddedEventMapper),
 			},
 			reduce: (&projectRoleProjection{}).reduceProjectRoleAdded,
@@ -168,7 +209,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.project_roles4 (role_key, project_id, creation_date, change_date, resource_owner, instance_id, sequence, display_name, group_name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedStmt: "INSERT INTO projections.project_roles5 (role_key, project_id, creation_date, change_date, resource_owner, instance_id, sequence, display_name, group_name, included_roles) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
 							expectedArgs: []interface{}{
 								"key",
 								"agg-id",
@@ -179,6 +220,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 								uint64(15),
 								"Key",
 								"Group",
+								database.TextArray[string]{"included"},
 							},
 						},
 					},
@@ -202,7 +244,7 @@ func TestProjectRoleProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.project_roles4 WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.project_roles5 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"agg-id",
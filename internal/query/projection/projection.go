@@ -8,12 +8,14 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/migration"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
 	CurrentStateTable = "projections.current_states"
 	LocksTable        = "projections.locks"
 	FailedEventsTable = "projections.failed_events2"
+	RebuildsTable     = "projections.rebuilds"
 )
 
 var (
@@ -80,6 +82,19 @@ var (
 	UserSchemaProjection                *handler.Handler
 	WebKeyProjection                    *handler.Handler
 	DebugEventsProjection               *handler.Handler
+	IDPGroupMappingProjection           *handler.Handler
+	OrgHierarchyProjection              *handler.Handler
+	IDPFederatedLogoutProjection        *handler.Handler
+	IDPTokenExchangeClientsProjection   *handler.Handler
+	GroupProjection                     *handler.Handler
+	InactivityPolicyProjection          *handler.Handler
+	UserActivityProjection              *handler.Handler
+	CustomRoleProjection                *handler.Handler
+	AccessRequestProjection             *handler.Handler
+	AuthorizationModelProjection        *handler.Handler
+	NotificationTemplateProjection      *handler.Handler
+	NotificationDeliveryProjection      *handler.Handler
+	SSFStreamProjection                 *handler.Handler
 
 	ProjectGrantFields      *handler.FieldHandler
 	OrgDomainVerifiedFields *handler.FieldHandler
@@ -90,6 +105,8 @@ type projection interface {
 	Start(ctx context.Context)
 	Init(ctx context.Context) error
 	Trigger(ctx context.Context, opts ...handler.TriggerOpt) (_ context.Context, err error)
+	Rebuild(ctx context.Context) error
+	RebuildInBackground(ctx context.Context) error
 	migration.Migration
 }
 
@@ -168,6 +185,19 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	UserSchemaProjection = newUserSchemaProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_schemas"]))
 	WebKeyProjection = newWebKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["web_keys"]))
 	DebugEventsProjection = newDebugEventsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_events"]))
+	IDPGroupMappingProjection = newIDPGroupMappingProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_group_mappings"]))
+	OrgHierarchyProjection = newOrgHierarchyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_hierarchy"]))
+	IDPFederatedLogoutProjection = newIDPFederatedLogoutProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_federated_logouts"]))
+	IDPTokenExchangeClientsProjection = newIDPTokenExchangeClientsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_token_exchange_clients"]))
+	GroupProjection = newGroupProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["groups"]))
+	InactivityPolicyProjection = newInactivityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["inactivity_policies"]))
+	UserActivityProjection = newUserActivityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_activities"]))
+	CustomRoleProjection = newCustomRoleProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_roles"]))
+	AccessRequestProjection = newAccessRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["access_requests"]))
+	AuthorizationModelProjection = newAuthorizationModelProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["authorization_models"]))
+	NotificationTemplateProjection = newNotificationTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_templates"]))
+	NotificationDeliveryProjection = newNotificationDeliveryProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_deliveries"]))
+	SSFStreamProjection = newSSFStreamProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["ssf_streams"]))
 
 	ProjectGrantFields = newFillProjectGrantFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsProjectGrant]))
 	OrgDomainVerifiedFields = newFillOrgDomainVerifiedFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsOrgDomainVerified]))
@@ -206,6 +236,36 @@ func ProjectInstance(ctx context.Context) error {
 	return nil
 }
 
+// Rebuild rebuilds the projection with the given name in the shadow schema
+// and replaces its tables as soon as the rebuilt projection caught up.
+// The name is the name of the projection with or without schema.
+func Rebuild(ctx context.Context, name string) error {
+	p, err := projectionByName(name)
+	if err != nil {
+		return err
+	}
+	return p.Rebuild(ctx)
+}
+
+// RebuildInBackground starts the rebuild of the projection with the given name without waiting for it to finish.
+// The progress can be queried from projections.rebuilds.
+func RebuildInBackground(ctx context.Context, name string) error {
+	p, err := projectionByName(name)
+	if err != nil {
+		return err
+	}
+	return p.RebuildInBackground(ctx)
+}
+
+func projectionByName(name string) (projection, error) {
+	for _, p := range projections {
+		if p.String() == name || p.String() == "projections."+name {
+			return p, nil
+		}
+	}
+	return nil, zerrors.ThrowNotFoundf(nil, "PROJE-q2Wjs", "projection %s not found", name)
+}
+
 func ApplyCustomConfig(customConfig CustomConfig) handler.Config {
 	return applyCustomConfig(projectionConfig, customConfig)
 }
@@ -297,5 +357,18 @@ func newProjectionsList() {
 		UserSchemaProjection,
 		WebKeyProjection,
 		DebugEventsProjection,
+		IDPGroupMappingProjection,
+		OrgHierarchyProjection,
+		IDPFederatedLogoutProjection,
+		IDPTokenExchangeClientsProjection,
+		GroupProjection,
+		InactivityPolicyProjection,
+		UserActivityProjection,
+		CustomRoleProjection,
+		AccessRequestProjection,
+		AuthorizationModelProjection,
+		NotificationTemplateProjection,
+		NotificationDeliveryProjection,
+		SSFStreamProjection,
 	}
 }
@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	SMSConfigProjectionTable = "projections.sms_configs3"
+	SMSConfigProjectionTable = "projections.sms_configs4"
 	SMSTwilioTable           = SMSConfigProjectionTable + "_" + smsTwilioTableSuffix
+	SMSVonageTable           = SMSConfigProjectionTable + "_" + smsVonageTableSuffix
+	SMSMessageBirdTable      = SMSConfigProjectionTable + "_" + smsMessageBirdTableSuffix
 	SMSHTTPTable             = SMSConfigProjectionTable + "_" + smsHTTPTableSuffix
 
 	SMSColumnID            = "id"
@@ -33,10 +35,28 @@ const (
 	SMSTwilioColumnToken            = "token"
 	SMSTwilioColumnVerifyServiceSID = "verify_service_sid"
 
-	smsHTTPTableSuffix      = "http"
-	SMSHTTPColumnSMSID      = "sms_id"
-	SMSHTTPColumnInstanceID = "instance_id"
-	SMSHTTPColumnEndpoint   = "endpoint"
+	smsVonageTableSuffix        = "vonage"
+	SMSVonageColumnSMSID        = "sms_id"
+	SMSVonageColumnInstanceID   = "instance_id"
+	SMSVonageColumnAPIKey       = "api_key"
+	SMSVonageColumnAPISecret    = "api_secret"
+	SMSVonageColumnSenderNumber = "sender_number"
+	SMSVonageColumnVerifyBrand  = "verify_brand"
+
+	smsMessageBirdTableSuffix      = "messagebird"
+	SMSMessageBirdColumnSMSID      = "sms_id"
+	SMSMessageBirdColumnInstanceID = "instance_id"
+	SMSMessageBirdColumnAccessKey  = "access_key"
+	SMSMessageBirdColumnOriginator = "originator"
+	SMSMessageBirdColumnVerify     = "verify"
+
+	smsHTTPTableSuffix       = "http"
+	SMSHTTPColumnSMSID       = "sms_id"
+	SMSHTTPColumnInstanceID  = "instance_id"
+	SMSHTTPColumnEndpoint    = "endpoint"
+	SMSHTTPColumnTemplate    = "template"
+	SMSHTTPColumnContentType = "content_type"
+	SMSHTTPColumnHeaders     = "headers"
 )
 
 type smsConfigProjection struct{}
@@ -76,10 +96,36 @@ func (*smsConfigProjection) Init() *old_handler.Check {
 			smsTwilioTableSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMSVonageColumnSMSID, handler.ColumnTypeText),
+			handler.NewColumn(SMSVonageColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMSVonageColumnAPIKey, handler.ColumnTypeText),
+			handler.NewColumn(SMSVonageColumnAPISecret, handler.ColumnTypeJSONB),
+			handler.NewColumn(SMSVonageColumnSenderNumber, handler.ColumnTypeText),
+			handler.NewColumn(SMSVonageColumnVerifyBrand, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(SMSVonageColumnInstanceID, SMSVonageColumnSMSID),
+			smsVonageTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMSMessageBirdColumnSMSID, handler.ColumnTypeText),
+			handler.NewColumn(SMSMessageBirdColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMSMessageBirdColumnAccessKey, handler.ColumnTypeJSONB),
+			handler.NewColumn(SMSMessageBirdColumnOriginator, handler.ColumnTypeText),
+			handler.NewColumn(SMSMessageBirdColumnVerify, handler.ColumnTypeBool),
+		},
+			handler.NewPrimaryKey(SMSMessageBirdColumnInstanceID, SMSMessageBirdColumnSMSID),
+			smsMessageBirdTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 		handler.NewSuffixedTable([]*handler.InitColumn{
 			handler.NewColumn(SMSHTTPColumnSMSID, handler.ColumnTypeText),
 			handler.NewColumn(SMSHTTPColumnInstanceID, handler.ColumnTypeText),
 			handler.NewColumn(SMSHTTPColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(SMSHTTPColumnTemplate, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMSHTTPColumnContentType, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMSHTTPColumnHeaders, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SMSHTTPColumnInstanceID, SMSHTTPColumnSMSID),
 			smsHTTPTableSuffix,
@@ -113,6 +159,30 @@ func (p *smsConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMSConfigHTTPChangedEventType,
 					Reduce: p.reduceSMSConfigHTTPChanged,
 				},
+				{
+					Event:  instance.SMSConfigVonageAddedEventType,
+					Reduce: p.reduceSMSConfigVonageAdded,
+				},
+				{
+					Event:  instance.SMSConfigVonageChangedEventType,
+					Reduce: p.reduceSMSConfigVonageChanged,
+				},
+				{
+					Event:  instance.SMSConfigVonageAPISecretChangedEventType,
+					Reduce: p.reduceSMSConfigVonageAPISecretChanged,
+				},
+				{
+					Event:  instance.SMSConfigMessageBirdAddedEventType,
+					Reduce: p.reduceSMSConfigMessageBirdAdded,
+				},
+				{
+					Event:  instance.SMSConfigMessageBirdChangedEventType,
+					Reduce: p.reduceSMSConfigMessageBirdChanged,
+				},
+				{
+					Event:  instance.SMSConfigMessageBirdAccessKeyChangedEventType,
+					Reduce: p.reduceSMSConfigMessageBirdAccessKeyChanged,
+				},
 				{
 					Event:  instance.SMSConfigTwilioActivatedEventType,
 					Reduce: p.reduceSMSConfigTwilioActivated,
@@ -286,6 +356,9 @@ func (p *smsConfigProjection) reduceSMSConfigHTTPAdded(event eventstore.Event) (
 				handler.NewCol(SMSHTTPColumnSMSID, e.ID),
 				handler.NewCol(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
 				handler.NewCol(SMSHTTPColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMSHTTPColumnTemplate, e.Template),
+				handler.NewCol(SMSHTTPColumnContentType, e.ContentType),
+				handler.NewCol(SMSHTTPColumnHeaders, e.Headers),
 			},
 			handler.WithTableSuffix(smsHTTPTableSuffix),
 		),
@@ -316,11 +389,22 @@ func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event)
 		))
 	}
 
+	httpColumns := make([]handler.Column, 0, 4)
 	if e.Endpoint != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMSHTTPColumnEndpoint, *e.Endpoint))
+	}
+	if e.Template != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMSHTTPColumnTemplate, *e.Template))
+	}
+	if e.ContentType != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMSHTTPColumnContentType, *e.ContentType))
+	}
+	if e.Headers != nil {
+		httpColumns = append(httpColumns, handler.NewCol(SMSHTTPColumnHeaders, e.Headers))
+	}
+	if len(httpColumns) > 0 {
 		stmts = append(stmts, handler.AddUpdateStatement(
-			[]handler.Column{
-				handler.NewCol(SMSHTTPColumnEndpoint, *e.Endpoint),
-			},
+			httpColumns,
 			[]handler.Condition{
 				handler.NewCond(SMSHTTPColumnSMSID, e.ID),
 				handler.NewCond(SMSHTTPColumnInstanceID, e.Aggregate().InstanceID),
@@ -332,6 +416,228 @@ func (p *smsConfigProjection) reduceSMSConfigHTTPChanged(event eventstore.Event)
 	return handler.NewMultiStatement(e, stmts...), nil
 }
 
+func (p *smsConfigProjection) reduceSMSConfigVonageAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigVonageAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+				handler.NewCol(SMSColumnDescription, e.Description),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSVonageColumnSMSID, e.ID),
+				handler.NewCol(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSVonageColumnAPIKey, e.APIKey),
+				handler.NewCol(SMSVonageColumnAPISecret, e.APISecret),
+				handler.NewCol(SMSVonageColumnSenderNumber, e.SenderNumber),
+				handler.NewCol(SMSVonageColumnVerifyBrand, e.VerifyBrand),
+			},
+			handler.WithTableSuffix(smsVonageTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigVonageChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigVonageChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []handler.Column{
+		handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMSColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMSColumnDescription, *e.Description))
+	}
+	stmts := []func(eventstore.Event) handler.Exec{
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	}
+
+	vonageColumns := make([]handler.Column, 0, 3)
+	if e.APIKey != nil {
+		vonageColumns = append(vonageColumns, handler.NewCol(SMSVonageColumnAPIKey, *e.APIKey))
+	}
+	if e.SenderNumber != nil {
+		vonageColumns = append(vonageColumns, handler.NewCol(SMSVonageColumnSenderNumber, *e.SenderNumber))
+	}
+	if e.VerifyBrand != nil {
+		vonageColumns = append(vonageColumns, handler.NewCol(SMSVonageColumnVerifyBrand, *e.VerifyBrand))
+	}
+	if len(vonageColumns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			vonageColumns,
+			[]handler.Condition{
+				handler.NewCond(SMSVonageColumnSMSID, e.ID),
+				handler.NewCond(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsVonageTableSuffix),
+		))
+	}
+
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigVonageAPISecretChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigVonageAPISecretChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSVonageColumnAPISecret, e.APISecret),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSVonageColumnSMSID, e.ID),
+				handler.NewCond(SMSVonageColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsVonageTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigMessageBirdAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigMessageBirdAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnID, e.ID),
+				handler.NewCol(SMSColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMSColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMSColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSColumnState, domain.SMSConfigStateInactive),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+				handler.NewCol(SMSColumnDescription, e.Description),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSMessageBirdColumnSMSID, e.ID),
+				handler.NewCol(SMSMessageBirdColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMSMessageBirdColumnAccessKey, e.AccessKey),
+				handler.NewCol(SMSMessageBirdColumnOriginator, e.Originator),
+				handler.NewCol(SMSMessageBirdColumnVerify, e.Verify),
+			},
+			handler.WithTableSuffix(smsMessageBirdTableSuffix),
+		),
+	), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigMessageBirdChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigMessageBirdChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []handler.Column{
+		handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMSColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMSColumnDescription, *e.Description))
+	}
+	stmts := []func(eventstore.Event) handler.Exec{
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	}
+
+	messageBirdColumns := make([]handler.Column, 0, 2)
+	if e.Originator != nil {
+		messageBirdColumns = append(messageBirdColumns, handler.NewCol(SMSMessageBirdColumnOriginator, *e.Originator))
+	}
+	if e.Verify != nil {
+		messageBirdColumns = append(messageBirdColumns, handler.NewCol(SMSMessageBirdColumnVerify, *e.Verify))
+	}
+	if len(messageBirdColumns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			messageBirdColumns,
+			[]handler.Condition{
+				handler.NewCond(SMSMessageBirdColumnSMSID, e.ID),
+				handler.NewCond(SMSMessageBirdColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsMessageBirdTableSuffix),
+		))
+	}
+
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *smsConfigProjection) reduceSMSConfigMessageBirdAccessKeyChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMSConfigMessageBirdAccessKeyChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSMessageBirdColumnAccessKey, e.AccessKey),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSMessageBirdColumnSMSID, e.ID),
+				handler.NewCond(SMSMessageBirdColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smsMessageBirdTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMSColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMSColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMSColumnID, e.ID),
+				handler.NewCond(SMSColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
 func (p *smsConfigProjection) reduceSMSConfigTwilioActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*instance.SMSConfigTwilioActivatedEvent](event)
 	if err != nil {
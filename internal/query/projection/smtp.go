@@ -3,6 +3,7 @@ package projection
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -11,7 +12,7 @@ import (
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs5"
+	SMTPConfigProjectionTable = "projections.smtp_configs6"
 	SMTPConfigTable           = SMTPConfigProjectionTable + "_" + smtpConfigSMTPTableSuffix
 	SMTPConfigHTTPTable       = SMTPConfigProjectionTable + "_" + smtpConfigHTTPTableSuffix
 
@@ -24,6 +25,8 @@ const (
 	SMTPConfigColumnSequence      = "sequence"
 	SMTPConfigColumnState         = "state"
 	SMTPConfigColumnDescription   = "description"
+	// SMTPConfigColumnDeliverySecret authenticates the delivery status callbacks of the provider
+	SMTPConfigColumnDeliverySecret = "delivery_secret"
 
 	smtpConfigSMTPTableSuffix          = "smtp"
 	SMTPConfigSMTPColumnInstanceID     = "instance_id"
@@ -36,6 +39,14 @@ const (
 	SMTPConfigSMTPColumnUser           = "username"
 	SMTPConfigSMTPColumnPassword       = "password"
 
+	SMTPConfigSMTPColumnXOAuth2TokenEndpoint = "xoauth2_token_endpoint"
+	SMTPConfigSMTPColumnXOAuth2ClientID      = "xoauth2_client_id"
+	SMTPConfigSMTPColumnXOAuth2ClientSecret  = "xoauth2_client_secret"
+	SMTPConfigSMTPColumnXOAuth2Scopes        = "xoauth2_scopes"
+	SMTPConfigSMTPColumnDKIMDomain           = "dkim_domain"
+	SMTPConfigSMTPColumnDKIMSelector         = "dkim_selector"
+	SMTPConfigSMTPColumnDKIMPrivateKey       = "dkim_private_key"
+
 	smtpConfigHTTPTableSuffix      = "http"
 	SMTPConfigHTTPColumnInstanceID = "instance_id"
 	SMTPConfigHTTPColumnID         = "id"
@@ -64,6 +75,7 @@ func (*smtpConfigProjection) Init() *old_handler.Check {
 			handler.NewColumn(SMTPConfigColumnInstanceID, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnDescription, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigColumnState, handler.ColumnTypeEnum),
+			handler.NewColumn(SMTPConfigColumnDeliverySecret, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SMTPConfigColumnInstanceID, SMTPConfigColumnID),
 		),
@@ -77,6 +89,13 @@ func (*smtpConfigProjection) Init() *old_handler.Check {
 			handler.NewColumn(SMTPConfigSMTPColumnHost, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigSMTPColumnUser, handler.ColumnTypeText),
 			handler.NewColumn(SMTPConfigSMTPColumnPassword, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnXOAuth2TokenEndpoint, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnXOAuth2ClientID, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnXOAuth2ClientSecret, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnXOAuth2Scopes, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnDKIMDomain, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnDKIMSelector, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(SMTPConfigSMTPColumnDKIMPrivateKey, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SMTPConfigSMTPColumnInstanceID, SMTPConfigSMTPColumnID),
 			smtpConfigSMTPTableSuffix,
@@ -111,6 +130,26 @@ func (p *smtpConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigPasswordChangedEventType,
 					Reduce: p.reduceSMTPConfigPasswordChanged,
 				},
+				{
+					Event:  instance.SMTPConfigXOAuth2SetEventType,
+					Reduce: p.reduceSMTPConfigXOAuth2Set,
+				},
+				{
+					Event:  instance.SMTPConfigXOAuth2RemovedEventType,
+					Reduce: p.reduceSMTPConfigXOAuth2Removed,
+				},
+				{
+					Event:  instance.SMTPConfigDKIMSetEventType,
+					Reduce: p.reduceSMTPConfigDKIMSet,
+				},
+				{
+					Event:  instance.SMTPConfigDKIMRemovedEventType,
+					Reduce: p.reduceSMTPConfigDKIMRemoved,
+				},
+				{
+					Event:  instance.SMTPConfigDeliverySecretSetEventType,
+					Reduce: p.reduceSMTPConfigDeliverySecretSet,
+				},
 				{
 					Event:  instance.SMTPConfigHTTPAddedEventType,
 					Reduce: p.reduceSMTPConfigHTTPAdded,
@@ -350,6 +389,105 @@ func (p *smtpConfigProjection) reduceSMTPConfigPasswordChanged(event eventstore.
 	), nil
 }
 
+func (p *smtpConfigProjection) reduceSMTPConfigXOAuth2Set(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigXOAuth2SetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.updateSMTPColumns(e, e.ID,
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2TokenEndpoint, e.TokenEndpoint),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2ClientID, e.ClientID),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2ClientSecret, e.ClientSecret),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2Scopes, database.TextArray[string](e.Scopes)),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigXOAuth2Removed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigXOAuth2RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.updateSMTPColumns(e, e.ID,
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2TokenEndpoint, nil),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2ClientID, nil),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2ClientSecret, nil),
+		handler.NewCol(SMTPConfigSMTPColumnXOAuth2Scopes, nil),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigDKIMSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDKIMSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.updateSMTPColumns(e, e.ID,
+		handler.NewCol(SMTPConfigSMTPColumnDKIMDomain, e.Domain),
+		handler.NewCol(SMTPConfigSMTPColumnDKIMSelector, e.Selector),
+		handler.NewCol(SMTPConfigSMTPColumnDKIMPrivateKey, e.PrivateKey),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigDKIMRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDKIMRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.updateSMTPColumns(e, e.ID,
+		handler.NewCol(SMTPConfigSMTPColumnDKIMDomain, nil),
+		handler.NewCol(SMTPConfigSMTPColumnDKIMSelector, nil),
+		handler.NewCol(SMTPConfigSMTPColumnDKIMPrivateKey, nil),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigDeliverySecretSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigDeliverySecretSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+			handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			handler.NewCol(SMTPConfigColumnDeliverySecret, e.Secret),
+		},
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, getSMTPConfigID(e.ID, e.Aggregate())),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+// updateSMTPColumns updates the columns of the smtp table and the change date and sequence of the config
+func (p *smtpConfigProjection) updateSMTPColumns(e eventstore.Event, id string, columns ...handler.Column) *handler.Statement {
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			columns,
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigSMTPColumnID, getSMTPConfigID(id, e.Aggregate())),
+				handler.NewCond(SMTPConfigSMTPColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smtpConfigSMTPTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigColumnChangeDate, e.CreatedAt()),
+				handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigColumnID, getSMTPConfigID(id, e.Aggregate())),
+				handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	)
+}
+
 func (p *smtpConfigProjection) reduceSMTPConfigActivated(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*instance.SMTPConfigActivatedEvent](event)
 	if err != nil {
@@ -0,0 +1,252 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+)
+
+const (
+	SSFStreamTable = "projections.ssf_streams"
+
+	SSFStreamIDCol                  = "id"
+	SSFStreamCreationDateCol        = "creation_date"
+	SSFStreamChangeDateCol          = "change_date"
+	SSFStreamResourceOwnerCol       = "resource_owner"
+	SSFStreamInstanceIDCol          = "instance_id"
+	SSFStreamSequenceCol            = "sequence"
+	SSFStreamDescriptionCol         = "description"
+	SSFStreamAudienceCol            = "audience"
+	SSFStreamEventsRequestedCol     = "events_requested"
+	SSFStreamDeliveryMethodCol      = "delivery_method"
+	SSFStreamEndpointURLCol         = "endpoint_url"
+	SSFStreamAuthorizationHeaderCol = "authorization_header"
+	SSFStreamStateCol               = "state"
+	SSFStreamStatusReasonCol        = "status_reason"
+	SSFStreamDeliveredCountCol      = "delivered_count"
+	SSFStreamFailedCountCol         = "failed_count"
+	SSFStreamLastDeliveryDateCol    = "last_delivery_date"
+	SSFStreamLastErrorCol           = "last_error"
+)
+
+type ssfStreamProjection struct{}
+
+func newSSFStreamProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(ssfStreamProjection))
+}
+
+func (*ssfStreamProjection) Name() string {
+	return SSFStreamTable
+}
+
+func (*ssfStreamProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(SSFStreamIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SSFStreamCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(SSFStreamChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(SSFStreamResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(SSFStreamInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(SSFStreamSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(SSFStreamDescriptionCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SSFStreamAudienceCol, handler.ColumnTypeTextArray),
+			handler.NewColumn(SSFStreamEventsRequestedCol, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(SSFStreamDeliveryMethodCol, handler.ColumnTypeText),
+			handler.NewColumn(SSFStreamEndpointURLCol, handler.ColumnTypeText),
+			handler.NewColumn(SSFStreamAuthorizationHeaderCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(SSFStreamStateCol, handler.ColumnTypeEnum),
+			handler.NewColumn(SSFStreamStatusReasonCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(SSFStreamDeliveredCountCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(SSFStreamFailedCountCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(SSFStreamLastDeliveryDateCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(SSFStreamLastErrorCol, handler.ColumnTypeText, handler.Default("")),
+		},
+			handler.NewPrimaryKey(SSFStreamInstanceIDCol, SSFStreamIDCol),
+		),
+	)
+}
+
+func (p *ssfStreamProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: ssf.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  ssf.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  ssf.ChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  ssf.StatusChangedEventType,
+					Reduce: p.reduceStatusChanged,
+				},
+				{
+					Event:  ssf.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  ssf.SETDeliveredEventType,
+					Reduce: p.reduceSETDelivered,
+				},
+				{
+					Event:  ssf.SETFailedEventType,
+					Reduce: p.reduceSETFailed,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(SSFStreamInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *ssfStreamProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(SSFStreamResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(SSFStreamIDCol, e.Aggregate().ID),
+			handler.NewCol(SSFStreamCreationDateCol, handler.OnlySetValueOnInsert(SSFStreamTable, e.CreationDate())),
+			handler.NewCol(SSFStreamChangeDateCol, e.CreationDate()),
+			handler.NewCol(SSFStreamSequenceCol, e.Sequence()),
+			handler.NewCol(SSFStreamDescriptionCol, e.Description),
+			handler.NewCol(SSFStreamAudienceCol, database.TextArray[string](e.Audience)),
+			handler.NewCol(SSFStreamEventsRequestedCol, database.TextArray[string](e.EventsRequested)),
+			handler.NewCol(SSFStreamDeliveryMethodCol, e.DeliveryMethod),
+			handler.NewCol(SSFStreamEndpointURLCol, e.EndpointURL),
+			handler.NewCol(SSFStreamAuthorizationHeaderCol, e.AuthorizationHeader),
+			handler.NewCol(SSFStreamStateCol, domain.SSFStreamStateEnabled),
+		},
+	), nil
+}
+
+func (p *ssfStreamProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.ChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	values := []handler.Column{
+		handler.NewCol(SSFStreamChangeDateCol, e.CreationDate()),
+		handler.NewCol(SSFStreamSequenceCol, e.Sequence()),
+	}
+	if e.Description != nil {
+		values = append(values, handler.NewCol(SSFStreamDescriptionCol, *e.Description))
+	}
+	if e.Audience != nil {
+		values = append(values, handler.NewCol(SSFStreamAudienceCol, database.TextArray[string](*e.Audience)))
+	}
+	if e.EventsRequested != nil {
+		values = append(values, handler.NewCol(SSFStreamEventsRequestedCol, database.TextArray[string](*e.EventsRequested)))
+	}
+	if e.EndpointURL != nil {
+		values = append(values, handler.NewCol(SSFStreamEndpointURLCol, *e.EndpointURL))
+	}
+	if e.AuthorizationHeader != nil {
+		if len(e.AuthorizationHeader.Crypted) == 0 {
+			values = append(values, handler.NewCol(SSFStreamAuthorizationHeaderCol, nil))
+		} else {
+			values = append(values, handler.NewCol(SSFStreamAuthorizationHeaderCol, e.AuthorizationHeader))
+		}
+	}
+	return handler.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SSFStreamIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *ssfStreamProjection) reduceStatusChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.StatusChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SSFStreamChangeDateCol, e.CreationDate()),
+			handler.NewCol(SSFStreamSequenceCol, e.Sequence()),
+			handler.NewCol(SSFStreamStateCol, e.State),
+			handler.NewCol(SSFStreamStatusReasonCol, e.Reason),
+		},
+		[]handler.Condition{
+			handler.NewCond(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SSFStreamIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *ssfStreamProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SSFStreamIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+// reduceSETDelivered only tracks the delivery, the change date is kept as the configuration of the stream did not change.
+func (p *ssfStreamProjection) reduceSETDelivered(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.SETDeliveredEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SSFStreamSequenceCol, e.Sequence()),
+			handler.NewIncrementCol(SSFStreamDeliveredCountCol, 1),
+			handler.NewCol(SSFStreamLastDeliveryDateCol, e.CreationDate()),
+		},
+		[]handler.Condition{
+			handler.NewCond(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SSFStreamIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *ssfStreamProjection) reduceSETFailed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ssf.SETFailedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(SSFStreamSequenceCol, e.Sequence()),
+			handler.NewIncrementCol(SSFStreamFailedCountCol, 1),
+			handler.NewCol(SSFStreamLastErrorCol, e.Reason),
+		},
+		[]handler.Condition{
+			handler.NewCond(SSFStreamInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(SSFStreamIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
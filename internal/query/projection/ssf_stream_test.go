@@ -0,0 +1,266 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/ssf"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestSSFStreamProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.AddedEventType,
+						ssf.AggregateType,
+						[]byte(`{"description": "receiver", "audience": ["aud"], "eventsRequested": ["https://schemas.openid.net/secevent/caep/event-type/session-revoked"], "deliveryMethod": "urn:ietf:rfc:8935", "endpointURL": "https://receiver.example.com/events"}`),
+					),
+					eventstore.GenericEventMapper[ssf.AddedEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.ssf_streams (instance_id, resource_owner, id, creation_date, change_date, sequence, description, audience, events_requested, delivery_method, endpoint_url, authorization_header, state) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"ro-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"receiver",
+								database.TextArray[string]{"aud"},
+								database.TextArray[string]{domain.CAEPEventTypeSessionRevoked},
+								domain.SSFDeliveryMethodPush,
+								"https://receiver.example.com/events",
+								anyArg{},
+								domain.SSFStreamStateEnabled,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.ChangedEventType,
+						ssf.AggregateType,
+						[]byte(`{"endpointURL": "https://other.example.com/events", "authorizationHeader": {}}`),
+					),
+					eventstore.GenericEventMapper[ssf.ChangedEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceChanged,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.ssf_streams SET (change_date, sequence, endpoint_url, authorization_header) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"https://other.example.com/events",
+								nil,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceStatusChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.StatusChangedEventType,
+						ssf.AggregateType,
+						[]byte(`{"state": 2, "reason": "maintenance"}`),
+					),
+					eventstore.GenericEventMapper[ssf.StatusChangedEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceStatusChanged,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.ssf_streams SET (change_date, sequence, state, status_reason) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.SSFStreamStatePaused,
+								"maintenance",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSETDelivered",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.SETDeliveredEventType,
+						ssf.AggregateType,
+						[]byte(`{"jti": "jti", "eventType": "https://schemas.openid.net/secevent/caep/event-type/session-revoked", "subjectID": "user1"}`),
+					),
+					eventstore.GenericEventMapper[ssf.SETDeliveredEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceSETDelivered,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.ssf_streams SET (sequence, delivered_count, last_delivery_date) = ($1, delivered_count + $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								uint64(15),
+								1,
+								anyArg{},
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSETFailed",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.SETFailedEventType,
+						ssf.AggregateType,
+						[]byte(`{"jti": "jti", "eventType": "https://schemas.openid.net/secevent/caep/event-type/session-revoked", "subjectID": "user1", "reason": "status 503"}`),
+					),
+					eventstore.GenericEventMapper[ssf.SETFailedEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceSETFailed,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.ssf_streams SET (sequence, failed_count, last_error) = ($1, failed_count + $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								uint64(15),
+								1,
+								"status 503",
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						ssf.RemovedEventType,
+						ssf.AggregateType,
+						nil,
+					),
+					eventstore.GenericEventMapper[ssf.RemovedEvent],
+				),
+			},
+			reduce: (&ssfStreamProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: ssf.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.ssf_streams WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(SSFStreamInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.ssf_streams WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, SSFStreamTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	UserActivityTable = "projections.user_activities"
+
+	UserActivityUserIDCol        = "user_id"
+	UserActivityInstanceIDCol    = "instance_id"
+	UserActivityResourceOwnerCol = "resource_owner"
+	UserActivityChangeDateCol    = "change_date"
+	UserActivitySequenceCol      = "sequence"
+	UserActivityLastActivityCol  = "last_activity"
+	UserActivityWarnedAtCol      = "warned_at"
+)
+
+// userActivityProjection keeps track of the last successful authentication of human users.
+// It's used to determine inactive users based on the inactivity policy.
+type userActivityProjection struct{}
+
+func newUserActivityProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(userActivityProjection))
+}
+
+func (*userActivityProjection) Name() string {
+	return UserActivityTable
+}
+
+func (*userActivityProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(UserActivityUserIDCol, handler.ColumnTypeText),
+			handler.NewColumn(UserActivityInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(UserActivityResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(UserActivityChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserActivitySequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(UserActivityLastActivityCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserActivityWarnedAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(UserActivityInstanceIDCol, UserActivityUserIDCol),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{UserActivityResourceOwnerCol})),
+		),
+	)
+}
+
+func (p *userActivityProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserV1AddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.HumanAddedType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.UserV1RegisteredType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.HumanRegisteredType,
+					Reduce: p.reduceHumanAdded,
+				},
+				{
+					Event:  user.UserV1PasswordCheckSucceededType,
+					Reduce: p.reduceUserActivity,
+				},
+				{
+					Event:  user.HumanPasswordCheckSucceededType,
+					Reduce: p.reduceUserActivity,
+				},
+				{
+					Event:  user.HumanPasswordlessTokenCheckSucceededType,
+					Reduce: p.reduceUserActivity,
+				},
+				{
+					Event:  user.UserIDPLoginCheckSucceededType,
+					Reduce: p.reduceUserActivity,
+				},
+				{
+					Event:  user.UserReactivatedType,
+					Reduce: p.reduceUserActivity,
+				},
+				{
+					Event:  user.HumanInactivityWarnedType,
+					Reduce: p.reduceInactivityWarned,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: p.reduceUserRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: authrequest.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  authrequest.SessionLinkedType,
+					Reduce: p.reduceAuthRequestSessionLinked,
+				},
+			},
+		},
+		{
+			Aggregate: oidcsession.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  oidcsession.AddedType,
+					Reduce: p.reduceOIDCSessionAdded,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(UserActivityInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+// reduceHumanAdded handles the creation of a human user as its first activity,
+// so users which never authenticated are deactivated as well.
+func (p *userActivityProjection) reduceHumanAdded(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Ua1ad", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanAddedType, user.HumanRegisteredType})
+	}
+	return handler.NewCreateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserActivityUserIDCol, event.Aggregate().ID),
+			handler.NewCol(UserActivityInstanceIDCol, event.Aggregate().InstanceID),
+			handler.NewCol(UserActivityResourceOwnerCol, event.Aggregate().ResourceOwner),
+			handler.NewCol(UserActivityChangeDateCol, event.CreatedAt()),
+			handler.NewCol(UserActivitySequenceCol, event.Sequence()),
+			handler.NewCol(UserActivityLastActivityCol, event.CreatedAt()),
+		},
+	), nil
+}
+
+// reduceUserActivity handles successful authentications (and the reactivation) of the user.
+// Rows only exist for human users, therefore the update does not affect machine users.
+func (p *userActivityProjection) reduceUserActivity(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *user.HumanPasswordCheckSucceededEvent,
+		*user.HumanPasswordlessCheckSucceededEvent,
+		*user.UserIDPCheckSucceededEvent,
+		*user.UserReactivatedEvent:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Ua2ac", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordCheckSucceededType, user.HumanPasswordlessTokenCheckSucceededType, user.UserIDPLoginCheckSucceededType, user.UserReactivatedType})
+	}
+	return p.activityStatement(event, event.Aggregate().ID), nil
+}
+
+func (p *userActivityProjection) reduceAuthRequestSessionLinked(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*authrequest.SessionLinkedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.activityStatement(e, e.UserID), nil
+}
+
+func (p *userActivityProjection) reduceOIDCSessionAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*oidcsession.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.activityStatement(e, e.UserID), nil
+}
+
+func (p *userActivityProjection) activityStatement(event eventstore.Event, userID string) *handler.Statement {
+	return handler.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserActivityChangeDateCol, event.CreatedAt()),
+			handler.NewCol(UserActivitySequenceCol, event.Sequence()),
+			handler.NewCol(UserActivityLastActivityCol, event.CreatedAt()),
+			handler.NewCol(UserActivityWarnedAtCol, nil),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserActivityUserIDCol, userID),
+			handler.NewCond(UserActivityInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	)
+}
+
+func (p *userActivityProjection) reduceInactivityWarned(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanInactivityWarnedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserActivityChangeDateCol, e.CreatedAt()),
+			handler.NewCol(UserActivitySequenceCol, e.Sequence()),
+			handler.NewCol(UserActivityWarnedAtCol, e.CreatedAt()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserActivityUserIDCol, e.Aggregate().ID),
+			handler.NewCond(UserActivityInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userActivityProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.UserRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserActivityUserIDCol, e.Aggregate().ID),
+			handler.NewCond(UserActivityInstanceIDCol, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userActivityProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(UserActivityInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(UserActivityResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,305 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestUserActivityProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceHumanAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanAddedType,
+						user.AggregateType,
+						[]byte(`{"userName": "username"}`),
+					), user.HumanAddedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceHumanAdded,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_activities (user_id, instance_id, resource_owner, change_date, sequence, last_activity) VALUES ($1, $2, $3, $4, $5, $6)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								uint64(15),
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserActivity password check succeeded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanPasswordCheckSucceededType,
+						user.AggregateType,
+						nil,
+					), user.HumanPasswordCheckSucceededEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserActivity,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity, warned_at) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								nil,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserActivity reactivated",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserReactivatedType,
+						user.AggregateType,
+						nil,
+					), user.UserReactivatedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserActivity,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity, warned_at) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								nil,
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAuthRequestSessionLinked",
+			args: args{
+				event: getEvent(
+					testEvent(
+						authrequest.SessionLinkedType,
+						authrequest.AggregateType,
+						[]byte(`{"session_id": "session-id", "user_id": "user-id"}`),
+					), authrequest.SessionLinkedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceAuthRequestSessionLinked,
+			want: wantReduce{
+				aggregateType: authrequest.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity, warned_at) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								nil,
+								"user-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceOIDCSessionAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						oidcsession.AddedType,
+						oidcsession.AggregateType,
+						[]byte(`{"userID": "user-id", "sessionID": "session-id"}`),
+					), eventstore.GenericEventMapper[oidcsession.AddedEvent]),
+			},
+			reduce: (&userActivityProjection{}).reduceOIDCSessionAdded,
+			want: wantReduce{
+				aggregateType: oidcsession.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, last_activity, warned_at) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								nil,
+								"user-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInactivityWarned",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.HumanInactivityWarnedType,
+						user.AggregateType,
+						[]byte(`{"lastActivity": "2024-01-01T00:00:00Z", "deactivationDate": "2024-04-01T00:00:00Z"}`),
+					), eventstore.GenericEventMapper[user.HumanInactivityWarnedEvent]),
+			},
+			reduce: (&userActivityProjection{}).reduceInactivityWarned,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_activities SET (change_date, sequence, warned_at) = ($1, $2, $3) WHERE (user_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceUserRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						user.UserRemovedType,
+						user.AggregateType,
+						nil,
+					), user.UserRemovedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceUserRemoved,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities WHERE (user_id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&userActivityProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: org.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: reduceInstanceRemovedHelper(UserActivityInstanceIDCol),
+			want: wantReduce{
+				aggregateType: instance.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_activities WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserActivityTable, tt.want)
+		})
+	}
+}
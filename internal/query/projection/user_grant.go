@@ -2,6 +2,7 @@ package projection
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
@@ -18,7 +19,7 @@ import (
 )
 
 const (
-	UserGrantProjectionTable = "projections.user_grants5"
+	UserGrantProjectionTable = "projections.user_grants6"
 
 	UserGrantID                   = "id"
 	UserGrantCreationDate         = "creation_date"
@@ -34,6 +35,9 @@ const (
 	UserGrantGrantID              = "grant_id"
 	UserGrantGrantedOrg           = "granted_org"
 	UserGrantRoles                = "roles"
+	UserGrantValidFrom            = "valid_from"
+	UserGrantValidUntil           = "valid_until"
+	UserGrantExpiryWarned         = "expiry_warned"
 )
 
 type userGrantProjection struct {
@@ -65,6 +69,9 @@ func (*userGrantProjection) Init() *old_handler.Check {
 			handler.NewColumn(UserGrantGrantID, handler.ColumnTypeText),
 			handler.NewColumn(UserGrantGrantedOrg, handler.ColumnTypeText),
 			handler.NewColumn(UserGrantRoles, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(UserGrantValidFrom, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(UserGrantValidUntil, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(UserGrantExpiryWarned, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(UserGrantInstanceID, UserGrantID),
 			handler.WithIndex(handler.NewIndex("user_id", []string{UserGrantUserID})),
@@ -106,6 +113,18 @@ func (p *userGrantProjection) Reducers() []handler.AggregateReducer {
 					Event:  usergrant.UserGrantReactivatedType,
 					Reduce: p.reduceReactivated,
 				},
+				{
+					Event:  usergrant.UserGrantValidityChangedType,
+					Reduce: p.reduceValidityChanged,
+				},
+				{
+					Event:  usergrant.UserGrantExpiryWarnedType,
+					Reduce: p.reduceExpiryWarned,
+				},
+				{
+					Event:  usergrant.UserGrantExpiredType,
+					Reduce: p.reduceExpired,
+				},
 			},
 		},
 		{
@@ -277,6 +296,66 @@ func (p *userGrantProjection) reduceReactivated(event eventstore.Event) (*handle
 	), nil
 }
 
+func (p *userGrantProjection) reduceValidityChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*usergrant.UserGrantValidityChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Gv1Rd", "reduce.wrong.event.type %s", usergrant.UserGrantValidityChangedType)
+	}
+
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserGrantChangeDate, e.CreatedAt()),
+			handler.NewCol(UserGrantValidFrom, &sql.NullTime{Time: e.ValidFrom, Valid: !e.ValidFrom.IsZero()}),
+			handler.NewCol(UserGrantValidUntil, &sql.NullTime{Time: e.ValidUntil, Valid: !e.ValidUntil.IsZero()}),
+			handler.NewCol(UserGrantExpiryWarned, false),
+			handler.NewCol(UserGrantSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserGrantID, e.Aggregate().ID),
+			handler.NewCond(UserGrantInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userGrantProjection) reduceExpiryWarned(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*usergrant.UserGrantExpiryWarnedEvent); !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Gv2Rd", "reduce.wrong.event.type %s", usergrant.UserGrantExpiryWarnedType)
+	}
+
+	return handler.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserGrantChangeDate, event.CreatedAt()),
+			handler.NewCol(UserGrantExpiryWarned, true),
+			handler.NewCol(UserGrantSequence, event.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserGrantID, event.Aggregate().ID),
+			handler.NewCond(UserGrantInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *userGrantProjection) reduceExpired(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*usergrant.UserGrantExpiredEvent); !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Gv3Rd", "reduce.wrong.event.type %s", usergrant.UserGrantExpiredType)
+	}
+
+	return handler.NewUpdateStatement(
+		event,
+		[]handler.Column{
+			handler.NewCol(UserGrantChangeDate, event.CreatedAt()),
+			handler.NewCol(UserGrantState, domain.UserGrantStateInactive),
+			handler.NewCol(UserGrantSequence, event.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserGrantID, event.Aggregate().ID),
+			handler.NewCond(UserGrantInstanceID, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *userGrantProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
 	if _, ok := event.(*user.UserRemovedEvent); !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-Bner2a", "reduce.wrong.event.type %s", user.UserRemovedType)
@@ -0,0 +1,143 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type ProjectionRebuilds struct {
+	SearchResponse
+	Rebuilds []*ProjectionRebuild
+}
+
+type ProjectionRebuild struct {
+	ProjectionName string
+	State          handler.RebuildState
+	InstancesTotal uint32
+	InstancesDone  uint32
+	Error          string
+	StartedAt      time.Time
+	ChangedAt      time.Time
+}
+
+// RebuildProjection starts to rebuild the projection in the background.
+// The live projection is replaced as soon as the rebuilt projection caught up.
+func (q *Queries) RebuildProjection(ctx context.Context, projectionName string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return projection.RebuildInBackground(ctx, projectionName)
+}
+
+func (q *Queries) SearchProjectionRebuilds(ctx context.Context) (rebuilds *ProjectionRebuilds, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareProjectionRebuildsQuery(ctx, q.client)
+	stmt, args, err := query.ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Hw2kd", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		rebuilds, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-a8Tnd", "Errors.Internal")
+	}
+
+	return rebuilds, nil
+}
+
+func prepareProjectionRebuildsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*ProjectionRebuilds, error)) {
+	return sq.Select(
+			ProjectionRebuildColProjectionName.identifier(),
+			ProjectionRebuildColState.identifier(),
+			ProjectionRebuildColInstancesTotal.identifier(),
+			ProjectionRebuildColInstancesDone.identifier(),
+			ProjectionRebuildColError.identifier(),
+			ProjectionRebuildColStartedAt.identifier(),
+			ProjectionRebuildColChangedAt.identifier(),
+			countColumn.identifier()).
+			From(projectionRebuildsTable.identifier()).
+			OrderBy(ProjectionRebuildColProjectionName.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ProjectionRebuilds, error) {
+			rebuilds := make([]*ProjectionRebuild, 0)
+			var count uint64
+			for rows.Next() {
+				rebuild := new(ProjectionRebuild)
+				var rebuildErr sql.NullString
+
+				err := rows.Scan(
+					&rebuild.ProjectionName,
+					&rebuild.State,
+					&rebuild.InstancesTotal,
+					&rebuild.InstancesDone,
+					&rebuildErr,
+					&rebuild.StartedAt,
+					&rebuild.ChangedAt,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				rebuild.Error = rebuildErr.String
+				rebuilds = append(rebuilds, rebuild)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Yx3cM", "Errors.Query.CloseRows")
+			}
+
+			return &ProjectionRebuilds{
+				Rebuilds: rebuilds,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+var (
+	projectionRebuildsTable = table{
+		name: projection.RebuildsTable,
+	}
+	ProjectionRebuildColProjectionName = Column{
+		name:  "projection_name",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColState = Column{
+		name:  "state",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColInstancesTotal = Column{
+		name:  "instances_total",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColInstancesDone = Column{
+		name:  "instances_done",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColError = Column{
+		name:  "error",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColStartedAt = Column{
+		name:  "started_at",
+		table: projectionRebuildsTable,
+	}
+	ProjectionRebuildColChangedAt = Column{
+		name:  "changed_at",
+		table: projectionRebuildsTable,
+	}
+)
@@ -0,0 +1,139 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+var (
+	projectionRebuildsStmt = `SELECT` +
+		` projections.rebuilds.projection_name,` +
+		` projections.rebuilds.state,` +
+		` projections.rebuilds.instances_total,` +
+		` projections.rebuilds.instances_done,` +
+		` projections.rebuilds.error,` +
+		` projections.rebuilds.started_at,` +
+		` projections.rebuilds.changed_at,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.rebuilds` +
+		` ORDER BY projections.rebuilds.projection_name`
+
+	projectionRebuildsCols = []string{
+		"projection_name",
+		"state",
+		"instances_total",
+		"instances_done",
+		"error",
+		"started_at",
+		"changed_at",
+		"count",
+	}
+)
+
+func Test_ProjectionRebuildsPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareProjectionRebuildsQuery no result",
+			prepare: prepareProjectionRebuildsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(projectionRebuildsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &ProjectionRebuilds{Rebuilds: []*ProjectionRebuild{}},
+		},
+		{
+			name:    "prepareProjectionRebuildsQuery multiple result",
+			prepare: prepareProjectionRebuildsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(projectionRebuildsStmt),
+					projectionRebuildsCols,
+					[][]driver.Value{
+						{
+							"projections.orgs1",
+							int16(handler.RebuildStateBuilding),
+							uint32(3),
+							uint32(1),
+							nil,
+							testNow,
+							testNow,
+						},
+						{
+							"projections.users14",
+							int16(handler.RebuildStateFailed),
+							uint32(3),
+							uint32(2),
+							"statement failed",
+							testNow,
+							testNow,
+						},
+					},
+				),
+			},
+			object: &ProjectionRebuilds{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Rebuilds: []*ProjectionRebuild{
+					{
+						ProjectionName: "projections.orgs1",
+						State:          handler.RebuildStateBuilding,
+						InstancesTotal: 3,
+						InstancesDone:  1,
+						StartedAt:      testNow,
+						ChangedAt:      testNow,
+					},
+					{
+						ProjectionName: "projections.users14",
+						State:          handler.RebuildStateFailed,
+						InstancesTotal: 3,
+						InstancesDone:  2,
+						Error:          "statement failed",
+						StartedAt:      testNow,
+						ChangedAt:      testNow,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareProjectionRebuildsQuery sql err",
+			prepare: prepareProjectionRebuildsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(projectionRebuildsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ProjectionRebuilds)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
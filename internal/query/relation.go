@@ -0,0 +1,719 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// maxRelationDepth limits the hops between a subject and an object,
+// checks and lists of relations nested deeper fail.
+const maxRelationDepth = 16
+
+var (
+	authorizationModelTable = table{
+		name:          projection.AuthorizationModelProjectionTable,
+		instanceIDCol: projection.AuthorizationModelInstanceIDCol,
+	}
+	AuthorizationModelColumnProjectID = Column{
+		name:  projection.AuthorizationModelProjectIDCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnInstanceID = Column{
+		name:  projection.AuthorizationModelInstanceIDCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnResourceOwner = Column{
+		name:  projection.AuthorizationModelResourceOwnerCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnCreationDate = Column{
+		name:  projection.AuthorizationModelCreationDateCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnChangeDate = Column{
+		name:  projection.AuthorizationModelChangeDateCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnSequence = Column{
+		name:  projection.AuthorizationModelSequenceCol,
+		table: authorizationModelTable,
+	}
+	AuthorizationModelColumnModel = Column{
+		name:  projection.AuthorizationModelModelCol,
+		table: authorizationModelTable,
+	}
+)
+
+var (
+	relationTupleTable = table{
+		name:          projection.RelationTupleTable,
+		instanceIDCol: projection.RelationTupleInstanceIDCol,
+	}
+	RelationTupleColumnProjectID = Column{
+		name:  projection.RelationTupleProjectIDCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnInstanceID = Column{
+		name:  projection.RelationTupleInstanceIDCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnResourceOwner = Column{
+		name:  projection.RelationTupleResourceOwnerCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnCreationDate = Column{
+		name:  projection.RelationTupleCreationDateCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnSequence = Column{
+		name:  projection.RelationTupleSequenceCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnObjectType = Column{
+		name:  projection.RelationTupleObjectTypeCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnObjectID = Column{
+		name:  projection.RelationTupleObjectIDCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnRelation = Column{
+		name:  projection.RelationTupleRelationCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnSubjectType = Column{
+		name:  projection.RelationTupleSubjectTypeCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnSubjectID = Column{
+		name:  projection.RelationTupleSubjectIDCol,
+		table: relationTupleTable,
+	}
+	RelationTupleColumnSubjectRelation = Column{
+		name:  projection.RelationTupleSubjectRelationCol,
+		table: relationTupleTable,
+	}
+)
+
+type AuthorizationModel struct {
+	ProjectID     string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	Model         *domain.AuthorizationModel
+}
+
+type RelationTuples struct {
+	SearchResponse
+	Tuples []*RelationTuple
+}
+
+func (t *RelationTuples) SetState(s *State) {
+	t.State = s
+}
+
+type RelationTuple struct {
+	ProjectID     string
+	ResourceOwner string
+	CreationDate  time.Time
+	Sequence      uint64
+	domain.RelationTuple
+}
+
+type RelationTupleSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *RelationTupleSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) AuthorizationModelByProjectID(ctx context.Context, projectID, resourceOwner string) (_ *AuthorizationModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		AuthorizationModelColumnProjectID.identifier():  projectID,
+		AuthorizationModelColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	if resourceOwner != "" {
+		eq[AuthorizationModelColumnResourceOwner.identifier()] = resourceOwner
+	}
+	query, scan := prepareAuthorizationModelQuery(ctx, q.client)
+	return genericRowQuery[*AuthorizationModel](ctx, q.client, query.Where(eq), scan)
+}
+
+func (q *Queries) SearchRelationTuples(ctx context.Context, projectID string, queries *RelationTupleSearchQueries) (_ *RelationTuples, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		RelationTupleColumnProjectID.identifier():  projectID,
+		RelationTupleColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareRelationTuplesQuery(ctx, q.client)
+	return genericRowsQueryWithState[*RelationTuples](ctx, q.client, relationTupleTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+// CheckRelation returns true if the user holds the relation on the object,
+// either by a relation tuple or derived through the authorization model of the project.
+func (q *Queries) CheckRelation(ctx context.Context, projectID, resourceOwner, userID, objectType, objectID, relation string) (_ bool, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if objectID == "" {
+		return false, zerrors.ThrowInvalidArgument(nil, "QUERY-Rc1oI", "Errors.Project.RelationTuple.Invalid")
+	}
+	model, err := q.relationModel(ctx, projectID, resourceOwner, objectType, relation)
+	if err != nil {
+		return false, err
+	}
+	subjects, err := q.builtInRelationSubjects(ctx, projectID, userID)
+	if err != nil || len(subjects) == 0 {
+		return false, err
+	}
+	target := relationSubject{Type: objectType, ID: objectID, Relation: relation}
+	query, scan := prepareRelationNodesQuery(ctx, q.client)
+	nodes, err := genericRowsQuery[[]*relationNode](ctx, q.client, withRelationNodes(query, model, target, projectID, authz.GetInstance(ctx).InstanceID()), scan)
+	if err != nil {
+		return false, err
+	}
+	return relationNodesMatch(model, nodes, subjects)
+}
+
+// ListRelatedObjects returns the ids of all objects of the type the user holds the relation on.
+func (q *Queries) ListRelatedObjects(ctx context.Context, projectID, resourceOwner, userID, objectType, relation string) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	relations, err := q.userRelations(ctx, projectID, resourceOwner, userID, objectType, relation)
+	if err != nil {
+		return nil, err
+	}
+	objectIDs := make([]string, 0)
+	for subject := range relations {
+		if subject.Type == objectType && subject.Relation == relation {
+			objectIDs = append(objectIDs, subject.ID)
+		}
+	}
+	slices.Sort(objectIDs)
+	return objectIDs, nil
+}
+
+func (q *Queries) userRelations(ctx context.Context, projectID, resourceOwner, userID, objectType, relation string) (map[relationSubject]struct{}, error) {
+	model, err := q.relationModel(ctx, projectID, resourceOwner, objectType, relation)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := q.builtInRelationSubjects(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return expandRelations(ctx, model, subjects, q.relationTupleLoader(projectID))
+}
+
+// relationModel returns the authorization model of the project, if it defines the relation.
+func (q *Queries) relationModel(ctx context.Context, projectID, resourceOwner, objectType, relation string) (*domain.AuthorizationModel, error) {
+	model, err := q.AuthorizationModelByProjectID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if model.Model.Relation(objectType, relation) == nil {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Rc2nM", "Errors.Project.RelationTuple.NotInModel")
+	}
+	return model.Model, nil
+}
+
+// relationNode is an object and relation which includes the checked relation,
+// found after Depth hops backwards from the checked object.
+type relationNode struct {
+	relationSubject
+	Depth int
+}
+
+// relationNodesCTE resolves the checked object and relation (depth 0)
+// and all objects and relations including it (depth > 0) backwards over the relation tuples.
+// The rules are the relations of the model, the tuple relations which include them
+// and, if the parent relation is set, the relation which must be held on the parent referenced by the tuple.
+// The recursion stops one hop after maxRelationDepth, so exceeding the depth can be detected.
+const relationNodesCTE = `, relation_nodes(object_type, object_id, relation, depth) AS (` +
+	`SELECT CAST(? AS TEXT), CAST(? AS TEXT), CAST(? AS TEXT), 0` +
+	` UNION` +
+	` SELECT t.subject_type, t.subject_id, CASE WHEN r.parent_relation = '' THEN t.subject_relation ELSE r.parent_relation END, n.depth + 1` +
+	` FROM relation_nodes n` +
+	` JOIN relation_rules r ON r.object_type = n.object_type AND r.relation = n.relation` +
+	` JOIN ` + projection.RelationTupleTable + ` t ON t.instance_id = ? AND t.project_id = ?` +
+	` AND t.object_type = n.object_type AND t.object_id = n.object_id AND t.relation = r.tuple_relation` +
+	` AND (r.parent_relation = '' OR t.subject_relation = '')` +
+	` WHERE n.depth <= ?` +
+	`)`
+
+// withRelationNodes makes the relation_nodes of the target available to the query.
+func withRelationNodes(query sq.SelectBuilder, model *domain.AuthorizationModel, target relationSubject, projectID, instanceID string) sq.SelectBuilder {
+	rules := relationRules(model)
+	values := make([]string, len(rules))
+	args := make([]any, 0, len(rules)*4+6)
+	for i, rule := range rules {
+		values[i] = "(CAST(? AS TEXT), CAST(? AS TEXT), CAST(? AS TEXT), CAST(? AS TEXT))"
+		args = append(args, rule.objectType, rule.relation, rule.tupleRelation, rule.parentRelation)
+	}
+	args = append(args, target.Type, target.ID, target.Relation, instanceID, projectID, maxRelationDepth)
+	return query.Prefix(
+		"WITH RECURSIVE relation_rules(object_type, relation, tuple_relation, parent_relation) AS (VALUES "+strings.Join(values, ", ")+")"+relationNodesCTE,
+		args...,
+	)
+}
+
+type relationRule struct {
+	objectType     string
+	relation       string
+	tupleRelation  string
+	parentRelation string
+}
+
+// relationRules flattens the model into the tuple relations which include a relation.
+func relationRules(model *domain.AuthorizationModel) []relationRule {
+	rules := make([]relationRule, 0)
+	for _, t := range model.Types {
+		for _, r := range t.Relations {
+			for _, implying := range impliedRelations(model, t.Name, r.Name) {
+				rules = append(rules, relationRule{objectType: t.Name, relation: r.Name, tupleRelation: implying})
+				for _, parent := range model.Relation(t.Name, implying).FromParents {
+					rules = append(rules, relationRule{objectType: t.Name, relation: r.Name, tupleRelation: parent.Tupleset, parentRelation: parent.Relation})
+				}
+			}
+		}
+	}
+	return rules
+}
+
+// impliedRelations returns the relation and all relations of the type which include it.
+func impliedRelations(model *domain.AuthorizationModel, objectType, relation string) []string {
+	relations := []string{relation}
+	for i := 0; i < len(relations); i++ {
+		r := model.Relation(objectType, relations[i])
+		if r == nil {
+			continue
+		}
+		for _, implying := range r.ImpliedBy {
+			if !slices.Contains(relations, implying) {
+				relations = append(relations, implying)
+			}
+		}
+	}
+	return relations
+}
+
+// relationNodesMatch returns true if one of the subjects of the user holds one of the relation nodes.
+// If none matches and the nodes were cut off at maxRelationDepth, the result is unknown and an error is returned.
+func relationNodesMatch(model *domain.AuthorizationModel, nodes []*relationNode, subjects []relationSubject) (bool, error) {
+	var exceeded bool
+	for _, node := range nodes {
+		exceeded = exceeded || node.Depth > maxRelationDepth
+		for _, relation := range impliedRelations(model, node.Type, node.Relation) {
+			if slices.Contains(subjects, relationSubject{Type: node.Type, ID: node.ID, Relation: relation}) {
+				return true, nil
+			}
+		}
+	}
+	if exceeded {
+		return false, zerrors.ThrowPreconditionFailed(nil, "QUERY-Rc4dX", "Errors.Project.RelationTuple.MaxDepthExceeded")
+	}
+	return false, nil
+}
+
+func prepareRelationNodesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*relationNode, error)) {
+	return sq.Select(
+			"relation_nodes.object_type",
+			"relation_nodes.object_id",
+			"relation_nodes.relation",
+			"MIN(relation_nodes.depth)",
+		).
+			From("relation_nodes").
+			GroupBy(
+				"relation_nodes.object_type",
+				"relation_nodes.object_id",
+				"relation_nodes.relation",
+			).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*relationNode, error) {
+			nodes := make([]*relationNode, 0)
+			for rows.Next() {
+				node := new(relationNode)
+				if err := rows.Scan(&node.Type, &node.ID, &node.Relation, &node.Depth); err != nil {
+					return nil, err
+				}
+				nodes = append(nodes, node)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Rn1cl", "Errors.Query.CloseRows")
+			}
+			return nodes, nil
+		}
+}
+
+// relationSubject is an object or, if Relation is set, the subjects holding the relation on it.
+type relationSubject struct {
+	Type     string
+	ID       string
+	Relation string
+}
+
+// relationTupleLoader returns all tuples of which the subject is one of the objects of the subjects.
+type relationTupleLoader func(ctx context.Context, subjects []relationSubject) ([]*domain.RelationTuple, error)
+
+func (q *Queries) relationTupleLoader(projectID string) relationTupleLoader {
+	return func(ctx context.Context, subjects []relationSubject) ([]*domain.RelationTuple, error) {
+		objects := make(sq.Or, 0, len(subjects))
+		for _, subject := range subjects {
+			objects = append(objects, sq.Eq{
+				RelationTupleColumnSubjectType.identifier(): subject.Type,
+				RelationTupleColumnSubjectID.identifier():   subject.ID,
+			})
+		}
+		query := &RelationTupleSearchQueries{Queries: []SearchQuery{&relationSubjectsQuery{objects}}}
+		tuples, err := q.SearchRelationTuples(ctx, projectID, query)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]*domain.RelationTuple, len(tuples.Tuples))
+		for i, tuple := range tuples.Tuples {
+			result[i] = &tuple.RelationTuple
+		}
+		return result, nil
+	}
+}
+
+type relationSubjectsQuery struct {
+	objects sq.Or
+}
+
+func (q *relationSubjectsQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *relationSubjectsQuery) comp() sq.Sqlizer {
+	return q.objects
+}
+
+func (q *relationSubjectsQuery) Col() Column {
+	return RelationTupleColumnSubjectID
+}
+
+// builtInRelationSubjects returns the subjects the user is part of by its organization,
+// group memberships, active grants on the project and the grants of the project to its organization.
+func (q *Queries) builtInRelationSubjects(ctx context.Context, projectID, userID string) ([]relationSubject, error) {
+	user, err := q.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.State.IsEnabled() {
+		return nil, nil
+	}
+	now := time.Now()
+	subjects := []relationSubject{
+		{Type: domain.RelationTypeUser, ID: user.ID},
+		{Type: domain.RelationTypeOrg, ID: user.ResourceOwner, Relation: domain.RelationMember},
+	}
+	addRoles := func(resourceOwner string, roles []string) {
+		for _, role := range roles {
+			subjects = append(subjects,
+				relationSubject{Type: domain.RelationTypeProject, ID: projectID, Relation: role},
+				relationSubject{Type: domain.RelationTypeOrg, ID: resourceOwner, Relation: role},
+			)
+		}
+	}
+
+	memberQuery, err := NewGroupMemberUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := q.SearchGroupMembers(ctx, &GroupMemberSearchQueries{Queries: []SearchQuery{memberQuery}})
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make([]string, len(members.Members))
+	for i, member := range members.Members {
+		groupIDs[i] = member.GroupID
+		subjects = append(subjects, relationSubject{Type: domain.RelationTypeGroup, ID: member.GroupID, Relation: domain.RelationMember})
+	}
+	if len(groupIDs) > 0 {
+		groupQuery, err := NewInTextQuery(GroupGrantColumnGroupID, groupIDs)
+		if err != nil {
+			return nil, err
+		}
+		projectQuery, err := NewGroupGrantProjectIDSearchQuery(projectID)
+		if err != nil {
+			return nil, err
+		}
+		groupGrants, err := q.SearchGroupGrants(ctx, &GroupGrantSearchQueries{Queries: []SearchQuery{groupQuery, projectQuery}})
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range groupGrants.Grants {
+			addRoles(grant.ResourceOwner, grant.RoleKeys)
+		}
+	}
+
+	userQuery, err := NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	stateQuery, err := NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	validityQuery, err := NewUserGrantValidityQuery()
+	if err != nil {
+		return nil, err
+	}
+	userGrants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{userQuery, projectQuery, stateQuery, validityQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range userGrants.UserGrants {
+		addRoles(grant.ResourceOwner, grant.Roles)
+	}
+
+	grantProjectQuery, err := NewProjectGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	grantedOrgQuery, err := NewProjectGrantGrantedOrgIDSearchQuery(user.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	projectGrants, err := q.SearchProjectGrants(ctx, &ProjectGrantSearchQueries{Queries: []SearchQuery{grantProjectQuery, grantedOrgQuery}})
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range projectGrants.ProjectGrants {
+		validity := domain.GrantValidity{ValidFrom: grant.ValidFrom, ValidUntil: grant.ValidUntil}
+		if grant.State == domain.ProjectGrantStateActive && validity.IsActiveAt(now) {
+			subjects = append(subjects, relationSubject{Type: domain.RelationTypeProject, ID: projectID, Relation: domain.RelationGranted})
+			break
+		}
+	}
+	return subjects, nil
+}
+
+// expandRelations returns all objects and relations the subjects are related to.
+// Each round loads the tuples of the objects found in the previous one,
+// relates the subjects of matching tuples to their objects
+// and applies the implied and parent relations of the model.
+// If tuples are still found after maxRelationDepth rounds, an error is returned instead of an incomplete result.
+func expandRelations(ctx context.Context, model *domain.AuthorizationModel, subjects []relationSubject, load relationTupleLoader) (map[relationSubject]struct{}, error) {
+	relations := make(map[relationSubject]struct{}, len(subjects))
+	var frontier []relationSubject
+	add := func(subject relationSubject) {
+		pending := []relationSubject{subject}
+		for len(pending) > 0 {
+			subject, pending = pending[0], pending[1:]
+			if _, ok := relations[subject]; ok {
+				continue
+			}
+			relations[subject] = struct{}{}
+			frontier = append(frontier, subject)
+			for _, r := range modelRelations(model, subject.Type) {
+				if slices.Contains(r.ImpliedBy, subject.Relation) {
+					pending = append(pending, relationSubject{Type: subject.Type, ID: subject.ID, Relation: r.Name})
+				}
+			}
+		}
+	}
+	for _, subject := range subjects {
+		add(subject)
+	}
+
+	for depth := 0; depth < maxRelationDepth && len(frontier) > 0; depth++ {
+		objects := relationObjects(frontier)
+		frontier = nil
+		tuples, err := load(ctx, objects)
+		if err != nil {
+			return nil, err
+		}
+		for _, tuple := range tuples {
+			subject := relationSubject{Type: tuple.SubjectType, ID: tuple.SubjectID, Relation: tuple.SubjectRelation}
+			if _, ok := relations[subject]; ok {
+				add(relationSubject{Type: tuple.ObjectType, ID: tuple.ObjectID, Relation: tuple.Relation})
+			}
+			if tuple.SubjectRelation != "" {
+				continue
+			}
+			for _, r := range modelRelations(model, tuple.ObjectType) {
+				for _, parent := range r.FromParents {
+					if parent.Tupleset != tuple.Relation {
+						continue
+					}
+					if _, ok := relations[relationSubject{Type: tuple.SubjectType, ID: tuple.SubjectID, Relation: parent.Relation}]; ok {
+						add(relationSubject{Type: tuple.ObjectType, ID: tuple.ObjectID, Relation: r.Name})
+					}
+				}
+			}
+		}
+	}
+	if len(frontier) > 0 {
+		tuples, err := load(ctx, relationObjects(frontier))
+		if err != nil {
+			return nil, err
+		}
+		if len(tuples) > 0 {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "QUERY-Rc5dX", "Errors.Project.RelationTuple.MaxDepthExceeded")
+		}
+	}
+	return relations, nil
+}
+
+func modelRelations(model *domain.AuthorizationModel, objectType string) []*domain.AuthorizationModelRelation {
+	t := model.Type(objectType)
+	if t == nil {
+		return nil
+	}
+	return t.Relations
+}
+
+// relationObjects returns the distinct objects of the subjects.
+func relationObjects(subjects []relationSubject) []relationSubject {
+	objects := make([]relationSubject, 0, len(subjects))
+	for _, subject := range subjects {
+		object := relationSubject{Type: subject.Type, ID: subject.ID}
+		if !slices.Contains(objects, object) {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+func NewRelationTupleResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnResourceOwner, value, TextEquals)
+}
+
+func NewRelationTupleObjectTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnObjectType, value, TextEquals)
+}
+
+func NewRelationTupleObjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnObjectID, value, TextEquals)
+}
+
+func NewRelationTupleRelationSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnRelation, value, TextEquals)
+}
+
+func NewRelationTupleSubjectTypeSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnSubjectType, value, TextEquals)
+}
+
+func NewRelationTupleSubjectIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(RelationTupleColumnSubjectID, value, TextEquals)
+}
+
+func prepareAuthorizationModelQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*AuthorizationModel, error)) {
+	return sq.Select(
+			AuthorizationModelColumnProjectID.identifier(),
+			AuthorizationModelColumnResourceOwner.identifier(),
+			AuthorizationModelColumnCreationDate.identifier(),
+			AuthorizationModelColumnChangeDate.identifier(),
+			AuthorizationModelColumnSequence.identifier(),
+			AuthorizationModelColumnModel.identifier(),
+		).
+			From(authorizationModelTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*AuthorizationModel, error) {
+			model := new(AuthorizationModel)
+			var data []byte
+			err := row.Scan(
+				&model.ProjectID,
+				&model.ResourceOwner,
+				&model.CreationDate,
+				&model.ChangeDate,
+				&model.Sequence,
+				&data,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Rm1nf", "Errors.Project.AuthorizationModel.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Rm2sc", "Errors.Internal")
+			}
+			model.Model = new(domain.AuthorizationModel)
+			if err := json.Unmarshal(data, model.Model); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Rm3um", "Errors.Internal")
+			}
+			return model, nil
+		}
+}
+
+func prepareRelationTuplesQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*RelationTuples, error)) {
+	return sq.Select(
+			RelationTupleColumnProjectID.identifier(),
+			RelationTupleColumnResourceOwner.identifier(),
+			RelationTupleColumnCreationDate.identifier(),
+			RelationTupleColumnSequence.identifier(),
+			RelationTupleColumnObjectType.identifier(),
+			RelationTupleColumnObjectID.identifier(),
+			RelationTupleColumnRelation.identifier(),
+			RelationTupleColumnSubjectType.identifier(),
+			RelationTupleColumnSubjectID.identifier(),
+			RelationTupleColumnSubjectRelation.identifier(),
+			countColumn.identifier(),
+		).
+			From(relationTupleTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*RelationTuples, error) {
+			tuples := make([]*RelationTuple, 0)
+			var count uint64
+			for rows.Next() {
+				tuple := new(RelationTuple)
+				err := rows.Scan(
+					&tuple.ProjectID,
+					&tuple.ResourceOwner,
+					&tuple.CreationDate,
+					&tuple.Sequence,
+					&tuple.ObjectType,
+					&tuple.ObjectID,
+					&tuple.Relation,
+					&tuple.SubjectType,
+					&tuple.SubjectID,
+					&tuple.SubjectRelation,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				tuples = append(tuples, tuple)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Rt1cl", "Errors.Query.CloseRows")
+			}
+			return &RelationTuples{
+				Tuples: tuples,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
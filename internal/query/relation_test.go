@@ -0,0 +1,539 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareAuthorizationModelStmt = `SELECT projections.authorization_models.project_id,` +
+		` projections.authorization_models.resource_owner,` +
+		` projections.authorization_models.creation_date,` +
+		` projections.authorization_models.change_date,` +
+		` projections.authorization_models.sequence,` +
+		` projections.authorization_models.model` +
+		` FROM projections.authorization_models`
+	prepareAuthorizationModelCols = []string{
+		"project_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"model",
+	}
+	prepareRelationTuplesStmt = `SELECT projections.authorization_models_tuples.project_id,` +
+		` projections.authorization_models_tuples.resource_owner,` +
+		` projections.authorization_models_tuples.creation_date,` +
+		` projections.authorization_models_tuples.sequence,` +
+		` projections.authorization_models_tuples.object_type,` +
+		` projections.authorization_models_tuples.object_id,` +
+		` projections.authorization_models_tuples.relation,` +
+		` projections.authorization_models_tuples.subject_type,` +
+		` projections.authorization_models_tuples.subject_id,` +
+		` projections.authorization_models_tuples.subject_relation,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.authorization_models_tuples`
+	prepareRelationTuplesCols = []string{
+		"project_id",
+		"resource_owner",
+		"creation_date",
+		"sequence",
+		"object_type",
+		"object_id",
+		"relation",
+		"subject_type",
+		"subject_id",
+		"subject_relation",
+		"count",
+	}
+	prepareRelationNodesStmt = `SELECT relation_nodes.object_type,` +
+		` relation_nodes.object_id,` +
+		` relation_nodes.relation,` +
+		` MIN(relation_nodes.depth)` +
+		` FROM relation_nodes` +
+		` GROUP BY relation_nodes.object_type, relation_nodes.object_id, relation_nodes.relation`
+	prepareRelationNodesCols = []string{
+		"object_type",
+		"object_id",
+		"relation",
+		"depth",
+	}
+)
+
+func Test_RelationPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAuthorizationModelQuery no result",
+			prepare: prepareAuthorizationModelQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareAuthorizationModelStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AuthorizationModel)(nil),
+		},
+		{
+			name:    "prepareAuthorizationModelQuery found",
+			prepare: prepareAuthorizationModelQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareAuthorizationModelStmt),
+					prepareAuthorizationModelCols,
+					[]driver.Value{
+						"project-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211111),
+						[]byte(`{"types":[{"name":"document","relations":[{"name":"viewer","directSubjects":[{"type":"user"}]}]}]}`),
+					},
+				),
+			},
+			object: &AuthorizationModel{
+				ProjectID:     "project-id",
+				ResourceOwner: "ro",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211111,
+				Model: &domain.AuthorizationModel{
+					Types: []*domain.AuthorizationModelType{
+						{
+							Name: "document",
+							Relations: []*domain.AuthorizationModelRelation{
+								{
+									Name:           "viewer",
+									DirectSubjects: []*domain.RelationSubjectType{{Type: domain.RelationTypeUser}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAuthorizationModelQuery sql err",
+			prepare: prepareAuthorizationModelQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareAuthorizationModelStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AuthorizationModel)(nil),
+		},
+		{
+			name:    "prepareRelationTuplesQuery no result",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareRelationTuplesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &RelationTuples{Tuples: []*RelationTuple{}},
+		},
+		{
+			name:    "prepareRelationTuplesQuery found",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareRelationTuplesStmt),
+					prepareRelationTuplesCols,
+					[][]driver.Value{
+						{
+							"project-id",
+							"ro",
+							testNow,
+							uint64(20211111),
+							"document",
+							"doc-id",
+							"viewer",
+							"group",
+							"group-id",
+							"member",
+						},
+					},
+				),
+			},
+			object: &RelationTuples{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Tuples: []*RelationTuple{
+					{
+						ProjectID:     "project-id",
+						ResourceOwner: "ro",
+						CreationDate:  testNow,
+						Sequence:      20211111,
+						RelationTuple: domain.RelationTuple{
+							ObjectType:      "document",
+							ObjectID:        "doc-id",
+							Relation:        "viewer",
+							SubjectType:     "group",
+							SubjectID:       "group-id",
+							SubjectRelation: "member",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareRelationTuplesQuery sql err",
+			prepare: prepareRelationTuplesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareRelationTuplesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*RelationTuples)(nil),
+		},
+		{
+			name:    "prepareRelationNodesQuery no result",
+			prepare: prepareRelationNodesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareRelationNodesStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*relationNode{},
+		},
+		{
+			name:    "prepareRelationNodesQuery found",
+			prepare: prepareRelationNodesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareRelationNodesStmt),
+					prepareRelationNodesCols,
+					[][]driver.Value{
+						{"document", "doc1", "viewer", 0},
+						{"user", "user1", "", 1},
+					},
+				),
+			},
+			object: []*relationNode{
+				{relationSubject: relationSubject{Type: "document", ID: "doc1", Relation: "viewer"}, Depth: 0},
+				{relationSubject: relationSubject{Type: "user", ID: "user1"}, Depth: 1},
+			},
+		},
+		{
+			name:    "prepareRelationNodesQuery sql err",
+			prepare: prepareRelationNodesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareRelationNodesStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*relationNode)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func testRelationModel() *domain.AuthorizationModel {
+	return &domain.AuthorizationModel{
+		Types: []*domain.AuthorizationModelType{
+			{
+				Name: "folder",
+				Relations: []*domain.AuthorizationModelRelation{
+					{
+						Name: "viewer",
+						DirectSubjects: []*domain.RelationSubjectType{
+							{Type: domain.RelationTypeUser},
+							{Type: domain.RelationTypeGroup, Relation: domain.RelationMember},
+						},
+						FromParents: []*domain.RelationFromParent{{Tupleset: "parent", Relation: "viewer"}},
+					},
+					{
+						Name:           "parent",
+						DirectSubjects: []*domain.RelationSubjectType{{Type: "folder"}},
+					},
+				},
+			},
+			{
+				Name: "document",
+				Relations: []*domain.AuthorizationModelRelation{
+					{
+						Name:           "parent",
+						DirectSubjects: []*domain.RelationSubjectType{{Type: "folder"}},
+					},
+					{
+						Name: "editor",
+						DirectSubjects: []*domain.RelationSubjectType{
+							{Type: domain.RelationTypeUser},
+							{Type: domain.RelationTypeProject, Relation: "writer"},
+						},
+					},
+					{
+						Name:        "viewer",
+						ImpliedBy:   []string{"editor"},
+						FromParents: []*domain.RelationFromParent{{Tupleset: "parent", Relation: "viewer"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testTupleLoader returns the tuples of which the subject is one of the requested objects
+func testTupleLoader(tuples ...*domain.RelationTuple) relationTupleLoader {
+	return func(_ context.Context, subjects []relationSubject) ([]*domain.RelationTuple, error) {
+		result := make([]*domain.RelationTuple, 0)
+		for _, tuple := range tuples {
+			for _, subject := range subjects {
+				if tuple.SubjectType == subject.Type && tuple.SubjectID == subject.ID {
+					result = append(result, tuple)
+					break
+				}
+			}
+		}
+		return result, nil
+	}
+}
+
+func Test_expandRelations(t *testing.T) {
+	user := relationSubject{Type: domain.RelationTypeUser, ID: "user1"}
+	tuples := []*domain.RelationTuple{
+		{ObjectType: "document", ObjectID: "doc1", Relation: "editor", SubjectType: domain.RelationTypeUser, SubjectID: "user1"},
+		{ObjectType: "document", ObjectID: "doc2", Relation: "editor", SubjectType: domain.RelationTypeProject, SubjectID: "project1", SubjectRelation: "writer"},
+		{ObjectType: "folder", ObjectID: "root", Relation: "viewer", SubjectType: domain.RelationTypeGroup, SubjectID: "group1", SubjectRelation: domain.RelationMember},
+		{ObjectType: "folder", ObjectID: "sub", Relation: "parent", SubjectType: "folder", SubjectID: "root"},
+		{ObjectType: "document", ObjectID: "doc3", Relation: "parent", SubjectType: "folder", SubjectID: "sub"},
+		{ObjectType: "document", ObjectID: "doc4", Relation: "editor", SubjectType: domain.RelationTypeUser, SubjectID: "user2"},
+	}
+	tests := []struct {
+		name     string
+		subjects []relationSubject
+		tuples   []*domain.RelationTuple
+		want     []relationSubject
+		notWant  []relationSubject
+	}{
+		{
+			name:     "direct and implied",
+			subjects: []relationSubject{user},
+			tuples:   tuples,
+			want: []relationSubject{
+				{Type: "document", ID: "doc1", Relation: "editor"},
+				{Type: "document", ID: "doc1", Relation: "viewer"},
+			},
+			notWant: []relationSubject{
+				{Type: "document", ID: "doc2", Relation: "editor"},
+				{Type: "document", ID: "doc3", Relation: "viewer"},
+				{Type: "document", ID: "doc4", Relation: "editor"},
+			},
+		},
+		{
+			name: "project role",
+			subjects: []relationSubject{
+				user,
+				{Type: domain.RelationTypeProject, ID: "project1", Relation: "writer"},
+			},
+			tuples: tuples,
+			want: []relationSubject{
+				{Type: "document", ID: "doc2", Relation: "editor"},
+				{Type: "document", ID: "doc2", Relation: "viewer"},
+			},
+		},
+		{
+			name: "group and nested parents",
+			subjects: []relationSubject{
+				user,
+				{Type: domain.RelationTypeGroup, ID: "group1", Relation: domain.RelationMember},
+			},
+			tuples: tuples,
+			want: []relationSubject{
+				{Type: "folder", ID: "root", Relation: "viewer"},
+				{Type: "folder", ID: "sub", Relation: "viewer"},
+				{Type: "document", ID: "doc3", Relation: "viewer"},
+			},
+			notWant: []relationSubject{
+				{Type: "document", ID: "doc3", Relation: "editor"},
+			},
+		},
+		{
+			name: "cyclic parents",
+			subjects: []relationSubject{
+				{Type: domain.RelationTypeGroup, ID: "group1", Relation: domain.RelationMember},
+			},
+			tuples: append(tuples,
+				&domain.RelationTuple{ObjectType: "folder", ObjectID: "root", Relation: "parent", SubjectType: "folder", SubjectID: "sub"},
+			),
+			want: []relationSubject{
+				{Type: "folder", ID: "root", Relation: "viewer"},
+				{Type: "folder", ID: "sub", Relation: "viewer"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandRelations(context.Background(), testRelationModel(), tt.subjects, testTupleLoader(tt.tuples...))
+			require.NoError(t, err)
+			for _, subject := range tt.want {
+				assert.Contains(t, got, subject)
+			}
+			for _, subject := range tt.notWant {
+				assert.NotContains(t, got, subject)
+			}
+		})
+	}
+}
+
+func Test_expandRelations_loaderError(t *testing.T) {
+	load := func(context.Context, []relationSubject) ([]*domain.RelationTuple, error) {
+		return nil, zerrors.ThrowInternal(nil, "QUERY-test", "Errors.Internal")
+	}
+	_, err := expandRelations(context.Background(), testRelationModel(), []relationSubject{{Type: domain.RelationTypeUser, ID: "user1"}}, load)
+	assert.True(t, zerrors.IsInternal(err))
+}
+
+func Test_expandRelations_maxDepthExceeded(t *testing.T) {
+	tuples := []*domain.RelationTuple{
+		{ObjectType: "folder", ObjectID: "folder0", Relation: "viewer", SubjectType: domain.RelationTypeUser, SubjectID: "user1"},
+	}
+	for i := 1; i <= maxRelationDepth+1; i++ {
+		tuples = append(tuples, &domain.RelationTuple{
+			ObjectType: "folder", ObjectID: fmt.Sprintf("folder%d", i), Relation: "parent", SubjectType: "folder", SubjectID: fmt.Sprintf("folder%d", i-1),
+		})
+	}
+	_, err := expandRelations(context.Background(), testRelationModel(), []relationSubject{{Type: domain.RelationTypeUser, ID: "user1"}}, testTupleLoader(tuples...))
+	assert.ErrorIs(t, err, zerrors.ThrowPreconditionFailed(nil, "QUERY-Rc5dX", "Errors.Project.RelationTuple.MaxDepthExceeded"))
+}
+
+func Test_relationRules(t *testing.T) {
+	rules := relationRules(testRelationModel())
+	assert.Contains(t, rules, relationRule{objectType: "document", relation: "viewer", tupleRelation: "viewer"})
+	assert.Contains(t, rules, relationRule{objectType: "document", relation: "viewer", tupleRelation: "editor"})
+	assert.Contains(t, rules, relationRule{objectType: "document", relation: "viewer", tupleRelation: "parent", parentRelation: "viewer"})
+	assert.Contains(t, rules, relationRule{objectType: "folder", relation: "viewer", tupleRelation: "parent", parentRelation: "viewer"})
+	assert.NotContains(t, rules, relationRule{objectType: "document", relation: "editor", tupleRelation: "viewer"})
+}
+
+func Test_withRelationNodes(t *testing.T) {
+	query, _ := prepareRelationNodesQuery(context.Background(), nil)
+	stmt, args, err := withRelationNodes(query, testRelationModel(), relationSubject{Type: "document", ID: "doc1", Relation: "viewer"}, "project1", "instance1").ToSql()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stmt, "WITH RECURSIVE relation_rules(object_type, relation, tuple_relation, parent_relation) AS (VALUES (CAST($1 AS TEXT), "), stmt)
+	assert.True(t, strings.HasSuffix(stmt, prepareRelationNodesStmt), stmt)
+	rules := relationRules(testRelationModel())
+	require.Len(t, args, len(rules)*4+6)
+	assert.Equal(t, []any{"document", "doc1", "viewer", "instance1", "project1", maxRelationDepth}, args[len(rules)*4:])
+}
+
+func Test_relationNodesMatch(t *testing.T) {
+	user := relationSubject{Type: domain.RelationTypeUser, ID: "user1"}
+	tests := []struct {
+		name     string
+		nodes    []*relationNode
+		subjects []relationSubject
+		want     bool
+		wantErr  error
+	}{
+		{
+			name: "user",
+			nodes: []*relationNode{
+				{relationSubject: relationSubject{Type: "document", ID: "doc1", Relation: "viewer"}},
+				{relationSubject: user, Depth: 1},
+			},
+			subjects: []relationSubject{user},
+			want:     true,
+		},
+		{
+			name: "group member",
+			nodes: []*relationNode{
+				{relationSubject: relationSubject{Type: "folder", ID: "root", Relation: "viewer"}},
+				{relationSubject: relationSubject{Type: domain.RelationTypeGroup, ID: "group1", Relation: domain.RelationMember}, Depth: 1},
+			},
+			subjects: []relationSubject{user, {Type: domain.RelationTypeGroup, ID: "group1", Relation: domain.RelationMember}},
+			want:     true,
+		},
+		{
+			name: "no match",
+			nodes: []*relationNode{
+				{relationSubject: relationSubject{Type: "document", ID: "doc1", Relation: "viewer"}},
+				{relationSubject: relationSubject{Type: domain.RelationTypeUser, ID: "user2"}, Depth: 1},
+			},
+			subjects: []relationSubject{user},
+			want:     false,
+		},
+		{
+			name: "match beyond max depth",
+			nodes: []*relationNode{
+				{relationSubject: relationSubject{Type: "folder", ID: "deep", Relation: "viewer"}, Depth: maxRelationDepth + 1},
+				{relationSubject: user, Depth: 2},
+			},
+			subjects: []relationSubject{user},
+			want:     true,
+		},
+		{
+			name: "max depth exceeded",
+			nodes: []*relationNode{
+				{relationSubject: relationSubject{Type: "document", ID: "doc1", Relation: "viewer"}},
+				{relationSubject: relationSubject{Type: "folder", ID: "deep", Relation: "viewer"}, Depth: maxRelationDepth + 1},
+			},
+			subjects: []relationSubject{user},
+			wantErr:  zerrors.ThrowPreconditionFailed(nil, "QUERY-Rc4dX", "Errors.Project.RelationTuple.MaxDepthExceeded"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := relationNodesMatch(testRelationModel(), tt.nodes, tt.subjects)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SchemaUser is a user based on a user schema.
+type SchemaUser struct {
+	domain.ObjectDetails
+	SchemaID        string
+	SchemaType      string
+	SchemaRevision  uint64
+	Data            json.RawMessage
+	Email           string
+	IsEmailVerified bool
+	Phone           string
+	IsPhoneVerified bool
+	State           domain.UserState
+}
+
+type SchemaUsers struct {
+	SearchResponse
+	Users []*SchemaUser
+}
+
+// SchemaUserSearchQueries searches the users by the indexed fields of their data.
+// Only users based on a schema declaring indexed fields can be found.
+type SchemaUserSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+var (
+	// schemaUserDataTable contains the indexed fields of the users,
+	// each user which has indexed fields has exactly one row with the schema ID.
+	schemaUserDataTable = table{
+		name:          "eventstore.fields",
+		alias:         "schema_users",
+		instanceIDCol: "instance_id",
+	}
+	SchemaUserIDCol = Column{
+		name:  "aggregate_id",
+		table: schemaUserDataTable,
+	}
+	SchemaUserResourceOwnerCol = Column{
+		name:  "resource_owner",
+		table: schemaUserDataTable,
+	}
+	SchemaUserInstanceIDCol = Column{
+		name:  "instance_id",
+		table: schemaUserDataTable,
+	}
+	SchemaUserSchemaIDCol = Column{
+		name:  "text_value",
+		table: schemaUserDataTable,
+	}
+	schemaUserAggregateTypeCol = Column{
+		name:  "aggregate_type",
+		table: schemaUserDataTable,
+	}
+	schemaUserObjectTypeCol = Column{
+		name:  "object_type",
+		table: schemaUserDataTable,
+	}
+	schemaUserFieldNameCol = Column{
+		name:  "field_name",
+		table: schemaUserDataTable,
+	}
+)
+
+// SearchSchemaUsers returns the users matching the queries ordered by the sorting column.
+// The users are reduced from their events, so their state is always up to date.
+func (q *Queries) SearchSchemaUsers(ctx context.Context, queries *SchemaUserSearchQueries) (_ *SchemaUsers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		SchemaUserInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+		schemaUserAggregateTypeCol.identifier(): schemauser.AggregateType,
+		schemaUserObjectTypeCol.identifier():    schemauser.DataSearchType,
+		schemaUserFieldNameCol.identifier():     schemauser.DataSearchSchemaIDField,
+	}
+	query, scan := prepareSchemaUserIDsQuery()
+	ids, err := genericRowsQuery[*schemaUserIDs](ctx, q.client, combineToWhereStmt(query, queries.toQuery, eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	users := &SchemaUsers{
+		SearchResponse: SearchResponse{Count: ids.count},
+		Users:          make([]*SchemaUser, 0, len(ids.ids)),
+	}
+	if len(ids.ids) == 0 {
+		return users, nil
+	}
+
+	readModel := newSchemaUsersReadModel(ids.ids)
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	schemaTypes := make(map[string]string)
+	for _, id := range ids.ids {
+		user, ok := readModel.users[id]
+		if !ok || user.State == domain.UserStateDeleted {
+			continue
+		}
+		schemaType, ok := schemaTypes[user.SchemaID]
+		if !ok {
+			userSchema, err := q.GetUserSchemaByID(ctx, user.SchemaID)
+			if err != nil && !zerrors.IsNotFound(err) {
+				return nil, err
+			}
+			if userSchema != nil {
+				schemaType = userSchema.Type
+			}
+			schemaTypes[user.SchemaID] = schemaType
+		}
+		user.SchemaType = schemaType
+		users.Users = append(users.Users, user)
+	}
+	return users, nil
+}
+
+func (q *SchemaUserSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewSchemaUserIDSearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
+	return NewTextQuery(SchemaUserIDCol, value, comparison)
+}
+
+func NewSchemaUserResourceOwnerSearchQuery(value string, comparison TextComparison) (SearchQuery, error) {
+	return NewTextQuery(SchemaUserResourceOwnerCol, value, comparison)
+}
+
+func NewSchemaUserSchemaIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(SchemaUserSchemaIDCol, value, TextEquals)
+}
+
+// schemaUserDataQuery filters the users by the value of an indexed field of their data.
+type schemaUserDataQuery struct {
+	path    string
+	value   any
+	compare TextComparison
+}
+
+// NewSchemaUserDataSearchQuery filters the users by the value of the field at the path (JSON pointer) of their data.
+// The value must be a string, number or boolean. Only strings can be compared with [TextStartsWith].
+func NewSchemaUserDataSearchQuery(path string, value any, comparison TextComparison) (SearchQuery, error) {
+	if path == "" {
+		return nil, ErrMissingColumn
+	}
+	switch v := value.(type) {
+	case string:
+		if comparison != TextEquals && comparison != TextStartsWith {
+			return nil, ErrInvalidCompare
+		}
+		if comparison == TextStartsWith {
+			value = database.EscapeLikeWildcards(v)
+		}
+	case float64, bool:
+		if comparison != TextEquals {
+			return nil, ErrInvalidCompare
+		}
+	default:
+		return nil, ErrInvalidCompare
+	}
+	return &schemaUserDataQuery{
+		path:    path,
+		value:   value,
+		compare: comparison,
+	}, nil
+}
+
+func (q *schemaUserDataQuery) Col() Column {
+	return schemaUserFieldNameCol
+}
+
+func (q *schemaUserDataQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *schemaUserDataQuery) comp() sq.Sqlizer {
+	return q
+}
+
+func (q *schemaUserDataQuery) ToSql() (string, []interface{}, error) {
+	var value sq.Sqlizer
+	switch v := q.value.(type) {
+	case string:
+		if q.compare == TextStartsWith {
+			value = sq.Like{"data.text_value": v + "%"}
+		} else {
+			value = sq.Eq{"data.text_value": v}
+		}
+	case float64:
+		value = sq.Eq{"data.number_value": v}
+	case bool:
+		value = sq.Eq{"data.bool_value": v}
+	}
+	stmt, args, err := sq.Select("1").
+		From(schemaUserDataTable.name + " AS data").
+		Where("data.instance_id = " + SchemaUserInstanceIDCol.identifier()).
+		Where("data.aggregate_id = " + SchemaUserIDCol.identifier()).
+		Where(sq.Eq{
+			"data.aggregate_type": schemauser.AggregateType,
+			"data.object_type":    schemauser.DataSearchType,
+			"data.field_name":     q.path,
+		}).
+		Where(value).
+		ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return "EXISTS (" + stmt + ")", args, nil
+}
+
+type schemaUserIDs struct {
+	ids   []string
+	count uint64
+}
+
+func prepareSchemaUserIDsQuery() (sq.SelectBuilder, func(*sql.Rows) (*schemaUserIDs, error)) {
+	return sq.Select(
+			SchemaUserIDCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(schemaUserDataTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*schemaUserIDs, error) {
+			ids := new(schemaUserIDs)
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id, &ids.count); err != nil {
+					return nil, err
+				}
+				ids.ids = append(ids.ids, id)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Sch1u", "Errors.Query.CloseRows")
+			}
+			return ids, nil
+		}
+}
+
+type schemaUsersReadModel struct {
+	eventstore.ReadModel
+
+	ids   []string
+	users map[string]*SchemaUser
+}
+
+func newSchemaUsersReadModel(ids []string) *schemaUsersReadModel {
+	return &schemaUsersReadModel{
+		ids:   ids,
+		users: make(map[string]*SchemaUser, len(ids)),
+	}
+}
+
+func (rm *schemaUsersReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if e, ok := event.(*schemauser.CreatedEvent); ok {
+			rm.users[e.Aggregate().ID] = &SchemaUser{
+				ObjectDetails: domain.ObjectDetails{
+					ID:            e.Aggregate().ID,
+					ResourceOwner: e.Aggregate().ResourceOwner,
+					CreationDate:  e.CreatedAt(),
+				},
+				SchemaID:       e.SchemaID,
+				SchemaRevision: e.SchemaRevision,
+				Data:           e.Data,
+				State:          domain.UserStateActive,
+			}
+		}
+		user, ok := rm.users[event.Aggregate().ID]
+		if !ok {
+			continue
+		}
+		user.EventDate = event.CreatedAt()
+		user.Sequence = event.Sequence()
+		switch e := event.(type) {
+		case *schemauser.UpdatedEvent:
+			if e.SchemaID != nil {
+				user.SchemaID = *e.SchemaID
+			}
+			if e.SchemaRevision != nil {
+				user.SchemaRevision = *e.SchemaRevision
+			}
+			if len(e.Data) > 0 {
+				user.Data = e.Data
+			}
+		case *schemauser.EmailUpdatedEvent:
+			user.Email = string(e.EmailAddress)
+			user.IsEmailVerified = false
+		case *schemauser.EmailVerifiedEvent:
+			user.IsEmailVerified = true
+		case *schemauser.PhoneUpdatedEvent:
+			user.Phone = string(e.PhoneNumber)
+			user.IsPhoneVerified = false
+		case *schemauser.PhoneVerifiedEvent:
+			user.IsPhoneVerified = true
+		case *schemauser.LockedEvent:
+			user.State = domain.UserStateLocked
+		case *schemauser.UnlockedEvent, *schemauser.ActivatedEvent:
+			user.State = domain.UserStateActive
+		case *schemauser.DeactivatedEvent:
+			user.State = domain.UserStateInactive
+		case *schemauser.DeletedEvent:
+			user.State = domain.UserStateDeleted
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *schemaUsersReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(schemauser.AggregateType).
+		AggregateIDs(rm.ids...).
+		EventTypes(
+			schemauser.CreatedType,
+			schemauser.UpdatedType,
+			schemauser.DeletedType,
+			schemauser.LockedType,
+			schemauser.UnlockedType,
+			schemauser.DeactivatedType,
+			schemauser.ActivatedType,
+			schemauser.EmailUpdatedType,
+			schemauser.EmailVerifiedType,
+			schemauser.PhoneUpdatedType,
+			schemauser.PhoneVerifiedType,
+		).
+		Builder()
+}
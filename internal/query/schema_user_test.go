@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+)
+
+func TestNewSchemaUserDataSearchQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      any
+		comparison TextComparison
+		wantStmt   string
+		wantArgs   []interface{}
+		wantErr    error
+	}{
+		{
+			name:       "text equals",
+			value:      "E-1",
+			comparison: TextEquals,
+			wantStmt:   "EXISTS (SELECT 1 FROM eventstore.fields AS data WHERE data.instance_id = schema_users.instance_id AND data.aggregate_id = schema_users.aggregate_id AND data.aggregate_type = ? AND data.field_name = ? AND data.object_type = ? AND data.text_value = ?)",
+			wantArgs:   []interface{}{"schemauser", "/employeeNumber", "schemauser_data", "E-1"},
+		},
+		{
+			name:       "text starts with, escaped",
+			value:      "E_1",
+			comparison: TextStartsWith,
+			wantStmt:   "EXISTS (SELECT 1 FROM eventstore.fields AS data WHERE data.instance_id = schema_users.instance_id AND data.aggregate_id = schema_users.aggregate_id AND data.aggregate_type = ? AND data.field_name = ? AND data.object_type = ? AND data.text_value LIKE ?)",
+			wantArgs:   []interface{}{"schemauser", "/employeeNumber", "schemauser_data", `E\_1%`},
+		},
+		{
+			name:       "number equals",
+			value:      float64(1),
+			comparison: TextEquals,
+			wantStmt:   "EXISTS (SELECT 1 FROM eventstore.fields AS data WHERE data.instance_id = schema_users.instance_id AND data.aggregate_id = schema_users.aggregate_id AND data.aggregate_type = ? AND data.field_name = ? AND data.object_type = ? AND data.number_value = ?)",
+			wantArgs:   []interface{}{"schemauser", "/employeeNumber", "schemauser_data", float64(1)},
+		},
+		{
+			name:       "number starts with, error",
+			value:      float64(1),
+			comparison: TextStartsWith,
+			wantErr:    ErrInvalidCompare,
+		},
+		{
+			name:       "object, error",
+			value:      map[string]any{},
+			comparison: TextEquals,
+			wantErr:    ErrInvalidCompare,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSchemaUserDataSearchQuery("/employeeNumber", tt.value, tt.comparison)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			stmt, args, err := got.comp().ToSql()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStmt, stmt)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSchemaUserSearchQueries_toQuery(t *testing.T) {
+	dataQuery, err := NewSchemaUserDataSearchQuery("/employeeNumber", "E-1", TextEquals)
+	require.NoError(t, err)
+	schemaQuery, err := NewSchemaUserSchemaIDSearchQuery("schema1")
+	require.NoError(t, err)
+	queries := &SchemaUserSearchQueries{
+		SearchRequest: SearchRequest{
+			Limit:         10,
+			SortingColumn: SchemaUserIDCol,
+			Asc:           true,
+		},
+		Queries: []SearchQuery{schemaQuery, dataQuery},
+	}
+	query, _ := prepareSchemaUserIDsQuery()
+	stmt, args, err := combineToWhereStmt(query, queries.toQuery, sq.Eq{SchemaUserInstanceIDCol.identifier(): "instance"}).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT schema_users.aggregate_id, COUNT(*) OVER () FROM eventstore.fields AS schema_users WHERE schema_users.text_value = $1 AND EXISTS (SELECT 1 FROM eventstore.fields AS data WHERE data.instance_id = schema_users.instance_id AND data.aggregate_id = schema_users.aggregate_id AND data.aggregate_type = $2 AND data.field_name = $3 AND data.object_type = $4 AND data.text_value = $5) AND schema_users.instance_id = $6 ORDER BY schema_users.aggregate_id LIMIT 10",
+		stmt,
+	)
+	assert.Equal(t, []interface{}{"schema1", "schemauser", "/employeeNumber", "schemauser_data", "E-1", "instance"}, args)
+}
+
+func Test_schemaUsersReadModel_Reduce(t *testing.T) {
+	agg1 := &schemauser.NewAggregate("user1", "org1").Aggregate
+	agg2 := &schemauser.NewAggregate("user2", "org1").Aggregate
+	readModel := newSchemaUsersReadModel([]string{"user1", "user2"})
+	readModel.AppendEvents(
+		schemauser.NewCreatedEvent(context.Background(), agg1, "schema1", 1, json.RawMessage(`{"name":"user1"}`)),
+		schemauser.NewCreatedEvent(context.Background(), agg2, "schema1", 1, json.RawMessage(`{"name":"user2"}`)),
+		schemauser.NewUpdatedEvent(context.Background(), agg1, []schemauser.Changes{
+			schemauser.ChangeSchemaRevision(2),
+			schemauser.ChangeData(json.RawMessage(`{"name":"user1","employeeNumber":"E-1"}`)),
+		}),
+		schemauser.NewEmailUpdatedEvent(context.Background(), agg1, "user1@example.com"),
+		schemauser.NewEmailVerifiedEvent(context.Background(), agg1),
+		schemauser.NewLockedEvent(context.Background(), agg2),
+	)
+	require.NoError(t, readModel.Reduce())
+
+	assert.Equal(t, "schema1", readModel.users["user1"].SchemaID)
+	assert.Equal(t, uint64(2), readModel.users["user1"].SchemaRevision)
+	assert.JSONEq(t, `{"name":"user1","employeeNumber":"E-1"}`, string(readModel.users["user1"].Data))
+	assert.Equal(t, "user1@example.com", readModel.users["user1"].Email)
+	assert.True(t, readModel.users["user1"].IsEmailVerified)
+	assert.Equal(t, domain.UserStateActive, readModel.users["user1"].State)
+	assert.Equal(t, domain.UserStateLocked, readModel.users["user2"].State)
+	assert.Equal(t, "org1", readModel.users["user2"].ResourceOwner)
+}
@@ -32,8 +32,10 @@ type SMSConfig struct {
 	Sequence      uint64
 	Description   string
 
-	TwilioConfig *Twilio
-	HTTPConfig   *HTTP
+	TwilioConfig      *Twilio
+	VonageConfig      *Vonage
+	MessageBirdConfig *MessageBird
+	HTTPConfig        *HTTP
 }
 
 type Twilio struct {
@@ -43,8 +45,25 @@ type Twilio struct {
 	VerifyServiceSID string
 }
 
+type Vonage struct {
+	APIKey       string
+	APISecret    *crypto.CryptoValue
+	SenderNumber string
+	VerifyBrand  string
+}
+
+type MessageBird struct {
+	AccessKey  *crypto.CryptoValue
+	Originator string
+	Verify     bool
+}
+
 type HTTP struct {
 	Endpoint string
+	// Template, ContentType and Headers are only set for SMS providers
+	Template    string
+	ContentType string
+	Headers     *crypto.CryptoValue
 }
 
 type SMSConfigsSearchQueries struct {
@@ -130,6 +149,56 @@ var (
 	}
 )
 
+var (
+	smsVonageTable = table{
+		name:          projection.SMSVonageTable,
+		instanceIDCol: projection.SMSVonageColumnInstanceID,
+	}
+	SMSVonageColumnSMSID = Column{
+		name:  projection.SMSVonageColumnSMSID,
+		table: smsVonageTable,
+	}
+	SMSVonageColumnAPIKey = Column{
+		name:  projection.SMSVonageColumnAPIKey,
+		table: smsVonageTable,
+	}
+	SMSVonageColumnAPISecret = Column{
+		name:  projection.SMSVonageColumnAPISecret,
+		table: smsVonageTable,
+	}
+	SMSVonageColumnSenderNumber = Column{
+		name:  projection.SMSVonageColumnSenderNumber,
+		table: smsVonageTable,
+	}
+	SMSVonageColumnVerifyBrand = Column{
+		name:  projection.SMSVonageColumnVerifyBrand,
+		table: smsVonageTable,
+	}
+)
+
+var (
+	smsMessageBirdTable = table{
+		name:          projection.SMSMessageBirdTable,
+		instanceIDCol: projection.SMSMessageBirdColumnInstanceID,
+	}
+	SMSMessageBirdColumnSMSID = Column{
+		name:  projection.SMSMessageBirdColumnSMSID,
+		table: smsMessageBirdTable,
+	}
+	SMSMessageBirdColumnAccessKey = Column{
+		name:  projection.SMSMessageBirdColumnAccessKey,
+		table: smsMessageBirdTable,
+	}
+	SMSMessageBirdColumnOriginator = Column{
+		name:  projection.SMSMessageBirdColumnOriginator,
+		table: smsMessageBirdTable,
+	}
+	SMSMessageBirdColumnVerify = Column{
+		name:  projection.SMSMessageBirdColumnVerify,
+		table: smsMessageBirdTable,
+	}
+)
+
 var (
 	smsHTTPTable = table{
 		name:          projection.SMSHTTPTable,
@@ -143,6 +212,18 @@ var (
 		name:  projection.SMSHTTPColumnEndpoint,
 		table: smsHTTPTable,
 	}
+	SMSHTTPColumnTemplate = Column{
+		name:  projection.SMSHTTPColumnTemplate,
+		table: smsHTTPTable,
+	}
+	SMSHTTPColumnContentType = Column{
+		name:  projection.SMSHTTPColumnContentType,
+		table: smsHTTPTable,
+	}
+	SMSHTTPColumnHeaders = Column{
+		name:  projection.SMSHTTPColumnHeaders,
+		table: smsHTTPTable,
+	}
 )
 
 func (q *Queries) SMSProviderConfigByID(ctx context.Context, id string) (config *SMSConfig, err error) {
@@ -234,17 +315,35 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			SMSTwilioColumnSenderNumber.identifier(),
 			SMSTwilioColumnVerifyServiceSID.identifier(),
 
+			SMSVonageColumnSMSID.identifier(),
+			SMSVonageColumnAPIKey.identifier(),
+			SMSVonageColumnAPISecret.identifier(),
+			SMSVonageColumnSenderNumber.identifier(),
+			SMSVonageColumnVerifyBrand.identifier(),
+
+			SMSMessageBirdColumnSMSID.identifier(),
+			SMSMessageBirdColumnAccessKey.identifier(),
+			SMSMessageBirdColumnOriginator.identifier(),
+			SMSMessageBirdColumnVerify.identifier(),
+
 			SMSHTTPColumnSMSID.identifier(),
 			SMSHTTPColumnEndpoint.identifier(),
+			SMSHTTPColumnTemplate.identifier(),
+			SMSHTTPColumnContentType.identifier(),
+			SMSHTTPColumnHeaders.identifier(),
 		).From(smsConfigsTable.identifier()).
 			LeftJoin(join(SMSTwilioColumnSMSID, SMSColumnID)).
+			LeftJoin(join(SMSVonageColumnSMSID, SMSColumnID)).
+			LeftJoin(join(SMSMessageBirdColumnSMSID, SMSColumnID)).
 			LeftJoin(join(SMSHTTPColumnSMSID, SMSColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*SMSConfig, error) {
 			config := new(SMSConfig)
 
 			var (
-				twilioConfig = sqlTwilioConfig{}
-				httpConfig   = sqlHTTPConfig{}
+				twilioConfig      = sqlTwilioConfig{}
+				vonageConfig      = sqlVonageConfig{}
+				messageBirdConfig = sqlMessageBirdConfig{}
+				httpConfig        = sqlHTTPConfig{}
 			)
 
 			err := row.Scan(
@@ -263,8 +362,22 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&twilioConfig.senderNumber,
 				&twilioConfig.verifyServiceSid,
 
+				&vonageConfig.smsID,
+				&vonageConfig.apiKey,
+				&vonageConfig.apiSecret,
+				&vonageConfig.senderNumber,
+				&vonageConfig.verifyBrand,
+
+				&messageBirdConfig.smsID,
+				&messageBirdConfig.accessKey,
+				&messageBirdConfig.originator,
+				&messageBirdConfig.verify,
+
 				&httpConfig.id,
 				&httpConfig.endpoint,
+				&httpConfig.template,
+				&httpConfig.contentType,
+				&httpConfig.headers,
 			)
 
 			if err != nil {
@@ -275,6 +388,8 @@ func prepareSMSConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			}
 
 			twilioConfig.set(config)
+			vonageConfig.set(config)
+			messageBirdConfig.set(config)
 			httpConfig.setSMS(config)
 
 			return config, nil
@@ -298,12 +413,28 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMSTwilioColumnSenderNumber.identifier(),
 			SMSTwilioColumnVerifyServiceSID.identifier(),
 
+			SMSVonageColumnSMSID.identifier(),
+			SMSVonageColumnAPIKey.identifier(),
+			SMSVonageColumnAPISecret.identifier(),
+			SMSVonageColumnSenderNumber.identifier(),
+			SMSVonageColumnVerifyBrand.identifier(),
+
+			SMSMessageBirdColumnSMSID.identifier(),
+			SMSMessageBirdColumnAccessKey.identifier(),
+			SMSMessageBirdColumnOriginator.identifier(),
+			SMSMessageBirdColumnVerify.identifier(),
+
 			SMSHTTPColumnSMSID.identifier(),
 			SMSHTTPColumnEndpoint.identifier(),
+			SMSHTTPColumnTemplate.identifier(),
+			SMSHTTPColumnContentType.identifier(),
+			SMSHTTPColumnHeaders.identifier(),
 
 			countColumn.identifier(),
 		).From(smsConfigsTable.identifier()).
 			LeftJoin(join(SMSTwilioColumnSMSID, SMSColumnID)).
+			LeftJoin(join(SMSVonageColumnSMSID, SMSColumnID)).
+			LeftJoin(join(SMSMessageBirdColumnSMSID, SMSColumnID)).
 			LeftJoin(join(SMSHTTPColumnSMSID, SMSColumnID) + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*SMSConfigs, error) {
 			configs := &SMSConfigs{Configs: []*SMSConfig{}}
@@ -311,8 +442,10 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			for row.Next() {
 				config := new(SMSConfig)
 				var (
-					twilioConfig = sqlTwilioConfig{}
-					httpConfig   = sqlHTTPConfig{}
+					twilioConfig      = sqlTwilioConfig{}
+					vonageConfig      = sqlVonageConfig{}
+					messageBirdConfig = sqlMessageBirdConfig{}
+					httpConfig        = sqlHTTPConfig{}
 				)
 
 				err := row.Scan(
@@ -331,8 +464,22 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&twilioConfig.senderNumber,
 					&twilioConfig.verifyServiceSid,
 
+					&vonageConfig.smsID,
+					&vonageConfig.apiKey,
+					&vonageConfig.apiSecret,
+					&vonageConfig.senderNumber,
+					&vonageConfig.verifyBrand,
+
+					&messageBirdConfig.smsID,
+					&messageBirdConfig.accessKey,
+					&messageBirdConfig.originator,
+					&messageBirdConfig.verify,
+
 					&httpConfig.id,
 					&httpConfig.endpoint,
+					&httpConfig.template,
+					&httpConfig.contentType,
+					&httpConfig.headers,
 
 					&configs.Count,
 				)
@@ -342,6 +489,8 @@ func prepareSMSConfigsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				}
 
 				twilioConfig.set(config)
+				vonageConfig.set(config)
+				messageBirdConfig.set(config)
 				httpConfig.setSMS(config)
 
 				configs.Configs = append(configs.Configs, config)
@@ -371,9 +520,50 @@ func (c sqlTwilioConfig) set(smsConfig *SMSConfig) {
 	}
 }
 
+type sqlVonageConfig struct {
+	smsID        sql.NullString
+	apiKey       sql.NullString
+	apiSecret    *crypto.CryptoValue
+	senderNumber sql.NullString
+	verifyBrand  sql.NullString
+}
+
+func (c sqlVonageConfig) set(smsConfig *SMSConfig) {
+	if !c.smsID.Valid {
+		return
+	}
+	smsConfig.VonageConfig = &Vonage{
+		APIKey:       c.apiKey.String,
+		APISecret:    c.apiSecret,
+		SenderNumber: c.senderNumber.String,
+		VerifyBrand:  c.verifyBrand.String,
+	}
+}
+
+type sqlMessageBirdConfig struct {
+	smsID      sql.NullString
+	accessKey  *crypto.CryptoValue
+	originator sql.NullString
+	verify     sql.NullBool
+}
+
+func (c sqlMessageBirdConfig) set(smsConfig *SMSConfig) {
+	if !c.smsID.Valid {
+		return
+	}
+	smsConfig.MessageBirdConfig = &MessageBird{
+		AccessKey:  c.accessKey,
+		Originator: c.originator.String,
+		Verify:     c.verify.Bool,
+	}
+}
+
 type sqlHTTPConfig struct {
-	id       sql.NullString
-	endpoint sql.NullString
+	id          sql.NullString
+	endpoint    sql.NullString
+	template    sql.NullString
+	contentType sql.NullString
+	headers     *crypto.CryptoValue
 }
 
 func (c sqlHTTPConfig) setSMS(smsConfig *SMSConfig) {
@@ -381,6 +571,9 @@ func (c sqlHTTPConfig) setSMS(smsConfig *SMSConfig) {
 		return
 	}
 	smsConfig.HTTPConfig = &HTTP{
-		Endpoint: c.endpoint.String,
+		Endpoint:    c.endpoint.String,
+		Template:    c.template.String,
+		ContentType: c.contentType.String,
+		Headers:     c.headers,
 	}
 }
@@ -14,52 +14,88 @@ import (
 )
 
 var (
-	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
-		` projections.sms_configs3.aggregate_id,` +
-		` projections.sms_configs3.creation_date,` +
-		` projections.sms_configs3.change_date,` +
-		` projections.sms_configs3.resource_owner,` +
-		` projections.sms_configs3.state,` +
-		` projections.sms_configs3.sequence,` +
-		` projections.sms_configs3.description,` +
+	expectedSMSConfigQuery = regexp.QuoteMeta(`SELECT projections.sms_configs4.id,` +
+		` projections.sms_configs4.aggregate_id,` +
+		` projections.sms_configs4.creation_date,` +
+		` projections.sms_configs4.change_date,` +
+		` projections.sms_configs4.resource_owner,` +
+		` projections.sms_configs4.state,` +
+		` projections.sms_configs4.sequence,` +
+		` projections.sms_configs4.description,` +
 
 		// twilio config
-		` projections.sms_configs3_twilio.sms_id,` +
-		` projections.sms_configs3_twilio.sid,` +
-		` projections.sms_configs3_twilio.token,` +
-		` projections.sms_configs3_twilio.sender_number,` +
-		` projections.sms_configs3_twilio.verify_service_sid,` +
+		` projections.sms_configs4_twilio.sms_id,` +
+		` projections.sms_configs4_twilio.sid,` +
+		` projections.sms_configs4_twilio.token,` +
+		` projections.sms_configs4_twilio.sender_number,` +
+		` projections.sms_configs4_twilio.verify_service_sid,` +
+
+		// vonage config
+		` projections.sms_configs4_vonage.sms_id,` +
+		` projections.sms_configs4_vonage.api_key,` +
+		` projections.sms_configs4_vonage.api_secret,` +
+		` projections.sms_configs4_vonage.sender_number,` +
+		` projections.sms_configs4_vonage.verify_brand,` +
+
+		// messagebird config
+		` projections.sms_configs4_messagebird.sms_id,` +
+		` projections.sms_configs4_messagebird.access_key,` +
+		` projections.sms_configs4_messagebird.originator,` +
+		` projections.sms_configs4_messagebird.verify,` +
 
 		// http config
-		` projections.sms_configs3_http.sms_id,` +
-		` projections.sms_configs3_http.endpoint` +
-		` FROM projections.sms_configs3` +
-		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
-		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
+		` projections.sms_configs4_http.sms_id,` +
+		` projections.sms_configs4_http.endpoint,` +
+		` projections.sms_configs4_http.template,` +
+		` projections.sms_configs4_http.content_type,` +
+		` projections.sms_configs4_http.headers` +
+		` FROM projections.sms_configs4` +
+		` LEFT JOIN projections.sms_configs4_twilio ON projections.sms_configs4.id = projections.sms_configs4_twilio.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs4_vonage ON projections.sms_configs4.id = projections.sms_configs4_vonage.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_vonage.instance_id` +
+		` LEFT JOIN projections.sms_configs4_messagebird ON projections.sms_configs4.id = projections.sms_configs4_messagebird.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_messagebird.instance_id` +
+		` LEFT JOIN projections.sms_configs4_http ON projections.sms_configs4.id = projections.sms_configs4_http.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs3.id,` +
-		` projections.sms_configs3.aggregate_id,` +
-		` projections.sms_configs3.creation_date,` +
-		` projections.sms_configs3.change_date,` +
-		` projections.sms_configs3.resource_owner,` +
-		` projections.sms_configs3.state,` +
-		` projections.sms_configs3.sequence,` +
-		` projections.sms_configs3.description,` +
+	expectedSMSConfigsQuery = regexp.QuoteMeta(`SELECT projections.sms_configs4.id,` +
+		` projections.sms_configs4.aggregate_id,` +
+		` projections.sms_configs4.creation_date,` +
+		` projections.sms_configs4.change_date,` +
+		` projections.sms_configs4.resource_owner,` +
+		` projections.sms_configs4.state,` +
+		` projections.sms_configs4.sequence,` +
+		` projections.sms_configs4.description,` +
 
 		// twilio config
-		` projections.sms_configs3_twilio.sms_id,` +
-		` projections.sms_configs3_twilio.sid,` +
-		` projections.sms_configs3_twilio.token,` +
-		` projections.sms_configs3_twilio.sender_number,` +
-		` projections.sms_configs3_twilio.verify_service_sid,` +
+		` projections.sms_configs4_twilio.sms_id,` +
+		` projections.sms_configs4_twilio.sid,` +
+		` projections.sms_configs4_twilio.token,` +
+		` projections.sms_configs4_twilio.sender_number,` +
+		` projections.sms_configs4_twilio.verify_service_sid,` +
+
+		// vonage config
+		` projections.sms_configs4_vonage.sms_id,` +
+		` projections.sms_configs4_vonage.api_key,` +
+		` projections.sms_configs4_vonage.api_secret,` +
+		` projections.sms_configs4_vonage.sender_number,` +
+		` projections.sms_configs4_vonage.verify_brand,` +
+
+		// messagebird config
+		` projections.sms_configs4_messagebird.sms_id,` +
+		` projections.sms_configs4_messagebird.access_key,` +
+		` projections.sms_configs4_messagebird.originator,` +
+		` projections.sms_configs4_messagebird.verify,` +
 
 		// http config
-		` projections.sms_configs3_http.sms_id,` +
-		` projections.sms_configs3_http.endpoint,` +
+		` projections.sms_configs4_http.sms_id,` +
+		` projections.sms_configs4_http.endpoint,` +
+		` projections.sms_configs4_http.template,` +
+		` projections.sms_configs4_http.content_type,` +
+		` projections.sms_configs4_http.headers,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.sms_configs3` +
-		` LEFT JOIN projections.sms_configs3_twilio ON projections.sms_configs3.id = projections.sms_configs3_twilio.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_twilio.instance_id` +
-		` LEFT JOIN projections.sms_configs3_http ON projections.sms_configs3.id = projections.sms_configs3_http.sms_id AND projections.sms_configs3.instance_id = projections.sms_configs3_http.instance_id` +
+		` FROM projections.sms_configs4` +
+		` LEFT JOIN projections.sms_configs4_twilio ON projections.sms_configs4.id = projections.sms_configs4_twilio.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_twilio.instance_id` +
+		` LEFT JOIN projections.sms_configs4_vonage ON projections.sms_configs4.id = projections.sms_configs4_vonage.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_vonage.instance_id` +
+		` LEFT JOIN projections.sms_configs4_messagebird ON projections.sms_configs4.id = projections.sms_configs4_messagebird.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_messagebird.instance_id` +
+		` LEFT JOIN projections.sms_configs4_http ON projections.sms_configs4.id = projections.sms_configs4_http.sms_id AND projections.sms_configs4.instance_id = projections.sms_configs4_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	smsConfigCols = []string{
@@ -77,9 +113,23 @@ var (
 		"token",
 		"sender-number",
 		"verify_sid",
+		// vonage config
+		"sms_id",
+		"api_key",
+		"api_secret",
+		"sender_number",
+		"verify_brand",
+		// messagebird config
+		"sms_id",
+		"access_key",
+		"originator",
+		"verify",
 		// http config
 		"sms_id",
 		"endpoint",
+		"template",
+		"content_type",
+		"headers",
 	}
 	smsConfigsCols = append(smsConfigCols, "count")
 )
@@ -130,9 +180,23 @@ func Test_SMSConfigsPrepare(t *testing.T) {
 							&crypto.CryptoValue{},
 							"sender-number",
 							"",
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// messagebird config
+							nil,
+							nil,
+							nil,
+							nil,
 							// http config
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -184,9 +248,23 @@ func Test_SMSConfigsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// messagebird config
+							nil,
+							nil,
+							nil,
+							nil,
 							// http config
 							"sms-id",
 							"endpoint",
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -235,9 +313,23 @@ func Test_SMSConfigsPrepare(t *testing.T) {
 							&crypto.CryptoValue{},
 							"sender-number",
 							"verify-service-sid",
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// messagebird config
+							nil,
+							nil,
+							nil,
+							nil,
 							// http config
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"sms-id2",
@@ -254,9 +346,23 @@ func Test_SMSConfigsPrepare(t *testing.T) {
 							&crypto.CryptoValue{},
 							"sender-number2",
 							"verify-service-sid2",
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// messagebird config
+							nil,
+							nil,
+							nil,
+							nil,
 							// http config
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"sms-id3",
@@ -273,9 +379,23 @@ func Test_SMSConfigsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// vonage config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// messagebird config
+							nil,
+							nil,
+							nil,
+							nil,
 							// http config
 							"sms-id3",
 							"endpoint3",
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -409,9 +529,23 @@ func Test_SMSConfigPrepare(t *testing.T) {
 						&crypto.CryptoValue{},
 						"sender-number",
 						"verify-service-sid",
+						// vonage config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// messagebird config
+						nil,
+						nil,
+						nil,
+						nil,
 						// http config
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -454,9 +588,23 @@ func Test_SMSConfigPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// vonage config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// messagebird config
+						nil,
+						nil,
+						nil,
+						nil,
 						// http config
 						"sms-id",
 						"endpoint",
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -474,6 +622,182 @@ func Test_SMSConfigPrepare(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareSMSConfigQuery, vonage, found",
+			prepare: prepareSMSConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSMSConfigQuery,
+					smsConfigCols,
+					[]driver.Value{
+						"sms-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.SMSConfigStateInactive,
+						uint64(20211109),
+						"description",
+						// twilio config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// vonage config
+						"sms-id",
+						"api-key",
+						&crypto.CryptoValue{},
+						"sender-number",
+						"verify-brand",
+						// messagebird config
+						nil,
+						nil,
+						nil,
+						nil,
+						// http config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &SMSConfig{
+				ID:            "sms-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.SMSConfigStateInactive,
+				Sequence:      20211109,
+				Description:   "description",
+				VonageConfig: &Vonage{
+					APIKey:       "api-key",
+					APISecret:    &crypto.CryptoValue{},
+					SenderNumber: "sender-number",
+					VerifyBrand:  "verify-brand",
+				},
+			},
+		},
+		{
+			name:    "prepareSMSConfigQuery, messagebird, found",
+			prepare: prepareSMSConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSMSConfigQuery,
+					smsConfigCols,
+					[]driver.Value{
+						"sms-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.SMSConfigStateInactive,
+						uint64(20211109),
+						"description",
+						// twilio config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// vonage config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// messagebird config
+						"sms-id",
+						&crypto.CryptoValue{},
+						"originator",
+						true,
+						// http config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &SMSConfig{
+				ID:            "sms-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.SMSConfigStateInactive,
+				Sequence:      20211109,
+				Description:   "description",
+				MessageBirdConfig: &MessageBird{
+					AccessKey:  &crypto.CryptoValue{},
+					Originator: "originator",
+					Verify:     true,
+				},
+			},
+		},
+		{
+			name:    "prepareSMSConfigQuery, http with template, found",
+			prepare: prepareSMSConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					expectedSMSConfigQuery,
+					smsConfigCols,
+					[]driver.Value{
+						"sms-id",
+						"agg-id",
+						testNow,
+						testNow,
+						"ro",
+						domain.SMSConfigStateInactive,
+						uint64(20211109),
+						"description",
+						// twilio config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// vonage config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// messagebird config
+						nil,
+						nil,
+						nil,
+						nil,
+						// http config
+						"sms-id",
+						"endpoint",
+						"template",
+						"text/plain",
+						&crypto.CryptoValue{},
+					},
+				),
+			},
+			object: &SMSConfig{
+				ID:            "sms-id",
+				AggregateID:   "agg-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.SMSConfigStateInactive,
+				Sequence:      20211109,
+				Description:   "description",
+				HTTPConfig: &HTTP{
+					Endpoint:    "endpoint",
+					Template:    "template",
+					ContentType: "text/plain",
+					Headers:     &crypto.CryptoValue{},
+				},
+			},
+		},
 		{
 			name:    "prepareSMSConfigQuery sql err",
 			prepare: prepareSMSConfigQuery,
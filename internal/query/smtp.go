@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -56,6 +57,10 @@ var (
 		name:  projection.SMTPConfigColumnID,
 		table: smtpConfigsTable,
 	}
+	SMTPConfigColumnDeliverySecret = Column{
+		name:  projection.SMTPConfigColumnDeliverySecret,
+		table: smtpConfigsTable,
+	}
 	SMTPConfigColumnState = Column{
 		name:  projection.SMTPConfigColumnState,
 		table: smtpConfigsTable,
@@ -105,6 +110,34 @@ var (
 		name:  projection.SMTPConfigSMTPColumnPassword,
 		table: smtpConfigsSMTPTable,
 	}
+	SMTPConfigSMTPColumnXOAuth2TokenEndpoint = Column{
+		name:  projection.SMTPConfigSMTPColumnXOAuth2TokenEndpoint,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnXOAuth2ClientID = Column{
+		name:  projection.SMTPConfigSMTPColumnXOAuth2ClientID,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnXOAuth2ClientSecret = Column{
+		name:  projection.SMTPConfigSMTPColumnXOAuth2ClientSecret,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnXOAuth2Scopes = Column{
+		name:  projection.SMTPConfigSMTPColumnXOAuth2Scopes,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnDKIMDomain = Column{
+		name:  projection.SMTPConfigSMTPColumnDKIMDomain,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnDKIMSelector = Column{
+		name:  projection.SMTPConfigSMTPColumnDKIMSelector,
+		table: smtpConfigsSMTPTable,
+	}
+	SMTPConfigSMTPColumnDKIMPrivateKey = Column{
+		name:  projection.SMTPConfigSMTPColumnDKIMPrivateKey,
+		table: smtpConfigsSMTPTable,
+	}
 
 	smtpConfigsHTTPTable = table{
 		name:          projection.SMTPConfigHTTPTable,
@@ -147,6 +180,21 @@ type SMTP struct {
 	Host           string
 	User           string
 	Password       *crypto.CryptoValue
+	XOAuth2        *SMTPXOAuth2
+	DKIM           *SMTPDKIM
+}
+
+type SMTPXOAuth2 struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  *crypto.CryptoValue
+	Scopes        []string
+}
+
+type SMTPDKIM struct {
+	Domain     string
+	Selector   string
+	PrivateKey *crypto.CryptoValue
 }
 
 func (q *Queries) SMTPConfigActive(ctx context.Context, resourceOwner string) (config *SMTPConfig, err error) {
@@ -190,6 +238,45 @@ func (q *Queries) SMTPConfigByID(ctx context.Context, instanceID, id string) (co
 	return config, err
 }
 
+// SMTPConfigDeliverySecret returns the secret the provider of the config sends with its delivery status callbacks.
+// It returns nil if no secret was generated for the config.
+func (q *Queries) SMTPConfigDeliverySecret(ctx context.Context, id string) (secret *crypto.CryptoValue, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareSMTPConfigDeliverySecretQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		SMTPConfigColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		SMTPConfigColumnID.identifier():         id,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ds1qL", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		secret, err = scan(row)
+		return err
+	}, query, args...)
+	return secret, err
+}
+
+func prepareSMTPConfigDeliverySecretQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*crypto.CryptoValue, error)) {
+	return sq.Select(
+			SMTPConfigColumnDeliverySecret.identifier()).
+			From(smtpConfigsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*crypto.CryptoValue, error) {
+			var secret *crypto.CryptoValue
+			if err := row.Scan(&secret); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ds1nF", "Errors.SMTPConfig.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ds1sC", "Errors.Internal")
+			}
+			return secret, nil
+		}
+}
+
 func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	password := new(crypto.CryptoValue)
 
@@ -210,6 +297,13 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			SMTPConfigSMTPColumnHost.identifier(),
 			SMTPConfigSMTPColumnUser.identifier(),
 			SMTPConfigSMTPColumnPassword.identifier(),
+			SMTPConfigSMTPColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigSMTPColumnXOAuth2ClientID.identifier(),
+			SMTPConfigSMTPColumnXOAuth2ClientSecret.identifier(),
+			SMTPConfigSMTPColumnXOAuth2Scopes.identifier(),
+			SMTPConfigSMTPColumnDKIMDomain.identifier(),
+			SMTPConfigSMTPColumnDKIMSelector.identifier(),
+			SMTPConfigSMTPColumnDKIMPrivateKey.identifier(),
 
 			SMTPConfigHTTPColumnID.identifier(),
 			SMTPConfigHTTPColumnEndpoint.identifier()).
@@ -239,6 +333,13 @@ func prepareSMTPConfigQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				&smtpConfig.host,
 				&smtpConfig.user,
 				&password,
+				&smtpConfig.xoauth2TokenEndpoint,
+				&smtpConfig.xoauth2ClientID,
+				&smtpConfig.xoauth2ClientSecret,
+				&smtpConfig.xoauth2Scopes,
+				&smtpConfig.dkimDomain,
+				&smtpConfig.dkimSelector,
+				&smtpConfig.dkimPrivateKey,
 				&httpConfig.id,
 				&httpConfig.endpoint,
 			)
@@ -273,6 +374,13 @@ func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.Select
 			SMTPConfigSMTPColumnHost.identifier(),
 			SMTPConfigSMTPColumnUser.identifier(),
 			SMTPConfigSMTPColumnPassword.identifier(),
+			SMTPConfigSMTPColumnXOAuth2TokenEndpoint.identifier(),
+			SMTPConfigSMTPColumnXOAuth2ClientID.identifier(),
+			SMTPConfigSMTPColumnXOAuth2ClientSecret.identifier(),
+			SMTPConfigSMTPColumnXOAuth2Scopes.identifier(),
+			SMTPConfigSMTPColumnDKIMDomain.identifier(),
+			SMTPConfigSMTPColumnDKIMSelector.identifier(),
+			SMTPConfigSMTPColumnDKIMPrivateKey.identifier(),
 
 			SMTPConfigHTTPColumnID.identifier(),
 			SMTPConfigHTTPColumnEndpoint.identifier(),
@@ -306,6 +414,13 @@ func prepareSMTPConfigsQuery(ctx context.Context, db prepareDatabase) (sq.Select
 					&smtpConfig.host,
 					&smtpConfig.user,
 					&password,
+					&smtpConfig.xoauth2TokenEndpoint,
+					&smtpConfig.xoauth2ClientID,
+					&smtpConfig.xoauth2ClientSecret,
+					&smtpConfig.xoauth2Scopes,
+					&smtpConfig.dkimDomain,
+					&smtpConfig.dkimSelector,
+					&smtpConfig.dkimPrivateKey,
 					&httpConfig.id,
 					&httpConfig.endpoint,
 					&configs.Count,
@@ -358,6 +473,14 @@ type sqlSmtpConfig struct {
 	host           sql.NullString
 	user           sql.NullString
 	password       *crypto.CryptoValue
+
+	xoauth2TokenEndpoint sql.NullString
+	xoauth2ClientID      sql.NullString
+	xoauth2ClientSecret  *crypto.CryptoValue
+	xoauth2Scopes        database.TextArray[string]
+	dkimDomain           sql.NullString
+	dkimSelector         sql.NullString
+	dkimPrivateKey       *crypto.CryptoValue
 }
 
 func (c sqlSmtpConfig) set(smtpConfig *SMTPConfig) {
@@ -373,6 +496,21 @@ func (c sqlSmtpConfig) set(smtpConfig *SMTPConfig) {
 		User:           c.user.String,
 		Password:       c.password,
 	}
+	if c.xoauth2TokenEndpoint.Valid {
+		smtpConfig.SMTPConfig.XOAuth2 = &SMTPXOAuth2{
+			TokenEndpoint: c.xoauth2TokenEndpoint.String,
+			ClientID:      c.xoauth2ClientID.String,
+			ClientSecret:  c.xoauth2ClientSecret,
+			Scopes:        c.xoauth2Scopes,
+		}
+	}
+	if c.dkimSelector.Valid {
+		smtpConfig.SMTPConfig.DKIM = &SMTPDKIM{
+			Domain:     c.dkimDomain.String,
+			Selector:   c.dkimSelector.String,
+			PrivateKey: c.dkimPrivateKey,
+		}
+	}
 }
 
 func (c sqlHTTPConfig) setSMTP(smtpConfig *SMTPConfig) {
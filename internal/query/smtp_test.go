@@ -9,31 +9,39 @@ import (
 	"testing"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs5.creation_date,` +
-		` projections.smtp_configs5.change_date,` +
-		` projections.smtp_configs5.resource_owner,` +
-		` projections.smtp_configs5.sequence,` +
-		` projections.smtp_configs5.id,` +
-		` projections.smtp_configs5.state,` +
-		` projections.smtp_configs5.description,` +
-		` projections.smtp_configs5_smtp.id,` +
-		` projections.smtp_configs5_smtp.tls,` +
-		` projections.smtp_configs5_smtp.sender_address,` +
-		` projections.smtp_configs5_smtp.sender_name,` +
-		` projections.smtp_configs5_smtp.reply_to_address,` +
-		` projections.smtp_configs5_smtp.host,` +
-		` projections.smtp_configs5_smtp.username,` +
-		` projections.smtp_configs5_smtp.password,` +
-		` projections.smtp_configs5_http.id,` +
-		` projections.smtp_configs5_http.endpoint` +
-		` FROM projections.smtp_configs5` +
-		` LEFT JOIN projections.smtp_configs5_smtp ON projections.smtp_configs5.id = projections.smtp_configs5_smtp.id AND projections.smtp_configs5.instance_id = projections.smtp_configs5_smtp.instance_id` +
-		` LEFT JOIN projections.smtp_configs5_http ON projections.smtp_configs5.id = projections.smtp_configs5_http.id AND projections.smtp_configs5.instance_id = projections.smtp_configs5_http.instance_id` +
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs6.creation_date,` +
+		` projections.smtp_configs6.change_date,` +
+		` projections.smtp_configs6.resource_owner,` +
+		` projections.smtp_configs6.sequence,` +
+		` projections.smtp_configs6.id,` +
+		` projections.smtp_configs6.state,` +
+		` projections.smtp_configs6.description,` +
+		` projections.smtp_configs6_smtp.id,` +
+		` projections.smtp_configs6_smtp.tls,` +
+		` projections.smtp_configs6_smtp.sender_address,` +
+		` projections.smtp_configs6_smtp.sender_name,` +
+		` projections.smtp_configs6_smtp.reply_to_address,` +
+		` projections.smtp_configs6_smtp.host,` +
+		` projections.smtp_configs6_smtp.username,` +
+		` projections.smtp_configs6_smtp.password,` +
+		` projections.smtp_configs6_smtp.xoauth2_token_endpoint,` +
+		` projections.smtp_configs6_smtp.xoauth2_client_id,` +
+		` projections.smtp_configs6_smtp.xoauth2_client_secret,` +
+		` projections.smtp_configs6_smtp.xoauth2_scopes,` +
+		` projections.smtp_configs6_smtp.dkim_domain,` +
+		` projections.smtp_configs6_smtp.dkim_selector,` +
+		` projections.smtp_configs6_smtp.dkim_private_key,` +
+		` projections.smtp_configs6_http.id,` +
+		` projections.smtp_configs6_http.endpoint` +
+		` FROM projections.smtp_configs6` +
+		` LEFT JOIN projections.smtp_configs6_smtp ON projections.smtp_configs6.id = projections.smtp_configs6_smtp.id AND projections.smtp_configs6.instance_id = projections.smtp_configs6_smtp.instance_id` +
+		` LEFT JOIN projections.smtp_configs6_http ON projections.smtp_configs6.id = projections.smtp_configs6_http.id AND projections.smtp_configs6.instance_id = projections.smtp_configs6_http.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareSMTPConfigCols = []string{
 		"creation_date",
@@ -51,11 +59,21 @@ var (
 		"smtp_host",
 		"smtp_user",
 		"smtp_password",
+		"xoauth2_token_endpoint",
+		"xoauth2_client_id",
+		"xoauth2_client_secret",
+		"xoauth2_scopes",
+		"dkim_domain",
+		"dkim_selector",
+		"dkim_private_key",
 		"id",
 		"endpoint",
 	}
 )
 
+const prepareSMTPConfigDeliverySecretStmt = `SELECT projections.smtp_configs6.delivery_secret` +
+	` FROM projections.smtp_configs6 AS OF SYSTEM TIME '-1 ms'`
+
 func Test_SMTPConfigsPrepares(t *testing.T) {
 	type want struct {
 		sqlExpectations sqlExpectation
@@ -108,6 +126,13 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						"host",
 						"user",
 						&crypto.CryptoValue{},
+						"https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+						"client",
+						&crypto.CryptoValue{},
+						database.TextArray[string]{"https://outlook.office365.com/.default"},
+						"zitadel.com",
+						"mail",
+						&crypto.CryptoValue{},
 						nil,
 						nil,
 					},
@@ -126,6 +151,17 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 					Host:           "host",
 					User:           "user",
 					Password:       &crypto.CryptoValue{},
+					XOAuth2: &SMTPXOAuth2{
+						TokenEndpoint: "https://login.microsoftonline.com/tenant/oauth2/v2.0/token",
+						ClientID:      "client",
+						ClientSecret:  &crypto.CryptoValue{},
+						Scopes:        []string{"https://outlook.office365.com/.default"},
+					},
+					DKIM: &SMTPDKIM{
+						Domain:     "zitadel.com",
+						Selector:   "mail",
+						PrivateKey: &crypto.CryptoValue{},
+					},
 				},
 				ID:          "2232323",
 				State:       domain.SMTPConfigStateActive,
@@ -155,6 +191,13 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						"2232323",
 						"endpoint",
 					},
@@ -198,6 +241,13 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						&crypto.CryptoValue{},
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -245,6 +295,13 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						&crypto.CryptoValue{},
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -267,6 +324,48 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Description: "test3",
 			},
 		},
+		{
+			name:    "prepareSMTPConfigDeliverySecretQuery no result",
+			prepare: prepareSMTPConfigDeliverySecretQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareSMTPConfigDeliverySecretStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*crypto.CryptoValue)(nil),
+		},
+		{
+			name:    "prepareSMTPConfigDeliverySecretQuery not generated",
+			prepare: prepareSMTPConfigDeliverySecretQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSMTPConfigDeliverySecretStmt),
+					[]string{"delivery_secret"},
+					[]driver.Value{nil},
+				),
+			},
+			object: (*crypto.CryptoValue)(nil),
+		},
+		{
+			name:    "prepareSMTPConfigDeliverySecretQuery found",
+			prepare: prepareSMTPConfigDeliverySecretQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSMTPConfigDeliverySecretStmt),
+					[]string{"delivery_secret"},
+					[]driver.Value{[]byte(`{"keyId":"key-id"}`)},
+				),
+			},
+			object: &crypto.CryptoValue{KeyID: "key-id"},
+		},
 		{
 			name:    "prepareSMTPConfigQuery sql err",
 			prepare: prepareSMTPConfigQuery,
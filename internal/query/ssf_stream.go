@@ -0,0 +1,308 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	ssfStreamTable = table{
+		name:          projection.SSFStreamTable,
+		instanceIDCol: projection.SSFStreamInstanceIDCol,
+	}
+	SSFStreamColumnID = Column{
+		name:  projection.SSFStreamIDCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnCreationDate = Column{
+		name:  projection.SSFStreamCreationDateCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnChangeDate = Column{
+		name:  projection.SSFStreamChangeDateCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnResourceOwner = Column{
+		name:  projection.SSFStreamResourceOwnerCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnInstanceID = Column{
+		name:  projection.SSFStreamInstanceIDCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnSequence = Column{
+		name:  projection.SSFStreamSequenceCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnDescription = Column{
+		name:  projection.SSFStreamDescriptionCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnAudience = Column{
+		name:  projection.SSFStreamAudienceCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnEventsRequested = Column{
+		name:  projection.SSFStreamEventsRequestedCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnDeliveryMethod = Column{
+		name:  projection.SSFStreamDeliveryMethodCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnEndpointURL = Column{
+		name:  projection.SSFStreamEndpointURLCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnAuthorizationHeader = Column{
+		name:  projection.SSFStreamAuthorizationHeaderCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnState = Column{
+		name:  projection.SSFStreamStateCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnStatusReason = Column{
+		name:  projection.SSFStreamStatusReasonCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnDeliveredCount = Column{
+		name:  projection.SSFStreamDeliveredCountCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnFailedCount = Column{
+		name:  projection.SSFStreamFailedCountCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnLastDeliveryDate = Column{
+		name:  projection.SSFStreamLastDeliveryDateCol,
+		table: ssfStreamTable,
+	}
+	SSFStreamColumnLastError = Column{
+		name:  projection.SSFStreamLastErrorCol,
+		table: ssfStreamTable,
+	}
+)
+
+type SSFStreams struct {
+	SearchResponse
+	Streams []*SSFStream
+}
+
+func (s *SSFStreams) SetState(state *State) {
+	s.State = state
+}
+
+type SSFStream struct {
+	domain.ObjectDetails
+
+	Description         string
+	Audience            database.TextArray[string]
+	EventsRequested     database.TextArray[string]
+	DeliveryMethod      string
+	EndpointURL         string
+	authorizationHeader *crypto.CryptoValue
+	AuthorizationHeader string
+	State               domain.SSFStreamState
+	StatusReason        string
+
+	DeliveredCount   uint64
+	FailedCount      uint64
+	LastDeliveryDate time.Time
+	LastError        string
+}
+
+// EventsDelivered returns the requested event types which will be delivered on the stream.
+func (s *SSFStream) EventsDelivered() []string {
+	return domain.SSFEventsDelivered(s.EventsRequested)
+}
+
+func (s *SSFStream) decryptAuthorizationHeader(alg crypto.EncryptionAlgorithm) error {
+	if s.authorizationHeader == nil {
+		return nil
+	}
+	header, err := crypto.DecryptString(s.authorizationHeader, alg)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "QUERY-Sf5h1", "Errors.Internal")
+	}
+	s.AuthorizationHeader = header
+	return nil
+}
+
+type SSFStreamSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *SSFStreamSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func NewSSFStreamStateSearchQuery(state domain.SSFStreamState) (SearchQuery, error) {
+	return NewNumberQuery(SSFStreamColumnState, state, NumberEquals)
+}
+
+func (q *Queries) SearchSSFStreams(ctx context.Context, queries *SSFStreamSearchQueries) (*SSFStreams, error) {
+	eq := sq.Eq{
+		SSFStreamColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareSSFStreamsQuery(ctx, q.client)
+	streams, err := genericRowsQueryWithState[*SSFStreams](ctx, q.client, ssfStreamTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	for i := range streams.Streams {
+		if err := streams.Streams[i].decryptAuthorizationHeader(q.keyEncryptionAlgorithm); err != nil {
+			return nil, err
+		}
+	}
+	return streams, nil
+}
+
+// ActiveSSFStreams returns all enabled streams of the instance.
+func (q *Queries) ActiveSSFStreams(ctx context.Context) (*SSFStreams, error) {
+	stateQuery, err := NewSSFStreamStateSearchQuery(domain.SSFStreamStateEnabled)
+	if err != nil {
+		return nil, err
+	}
+	return q.SearchSSFStreams(ctx, &SSFStreamSearchQueries{Queries: []SearchQuery{stateQuery}})
+}
+
+func (q *Queries) SSFStreamByID(ctx context.Context, shouldTriggerBulk bool, id string) (_ *SSFStream, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerSSFStreamProjection")
+		ctx, err = projection.SSFStreamProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	eq := sq.Eq{
+		SSFStreamColumnID.identifier():         id,
+		SSFStreamColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareSSFStreamQuery(ctx, q.client)
+	stream, err := genericRowQuery[*SSFStream](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.decryptAuthorizationHeader(q.keyEncryptionAlgorithm); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func ssfStreamColumns() []string {
+	return []string{
+		SSFStreamColumnID.identifier(),
+		SSFStreamColumnCreationDate.identifier(),
+		SSFStreamColumnChangeDate.identifier(),
+		SSFStreamColumnResourceOwner.identifier(),
+		SSFStreamColumnSequence.identifier(),
+		SSFStreamColumnDescription.identifier(),
+		SSFStreamColumnAudience.identifier(),
+		SSFStreamColumnEventsRequested.identifier(),
+		SSFStreamColumnDeliveryMethod.identifier(),
+		SSFStreamColumnEndpointURL.identifier(),
+		SSFStreamColumnAuthorizationHeader.identifier(),
+		SSFStreamColumnState.identifier(),
+		SSFStreamColumnStatusReason.identifier(),
+		SSFStreamColumnDeliveredCount.identifier(),
+		SSFStreamColumnFailedCount.identifier(),
+		SSFStreamColumnLastDeliveryDate.identifier(),
+		SSFStreamColumnLastError.identifier(),
+	}
+}
+
+type ssfStreamScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSSFStream(row ssfStreamScanner, stream *SSFStream, additional ...any) error {
+	var lastDeliveryDate sql.NullTime
+	err := row.Scan(append([]any{
+		&stream.ID,
+		&stream.CreationDate,
+		&stream.EventDate,
+		&stream.ResourceOwner,
+		&stream.Sequence,
+		&stream.Description,
+		&stream.Audience,
+		&stream.EventsRequested,
+		&stream.DeliveryMethod,
+		&stream.EndpointURL,
+		&stream.authorizationHeader,
+		&stream.State,
+		&stream.StatusReason,
+		&stream.DeliveredCount,
+		&stream.FailedCount,
+		&lastDeliveryDate,
+		&stream.LastError,
+	}, additional...)...)
+	stream.LastDeliveryDate = lastDeliveryDate.Time
+	return err
+}
+
+func prepareSSFStreamsQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(rows *sql.Rows) (*SSFStreams, error)) {
+	return sq.Select(append(ssfStreamColumns(), countColumn.identifier())...).
+			From(ssfStreamTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*SSFStreams, error) {
+			streams := make([]*SSFStream, 0)
+			var count uint64
+			for rows.Next() {
+				stream := new(SSFStream)
+				if err := scanSSFStream(rows, stream, &count); err != nil {
+					return nil, err
+				}
+				streams = append(streams, stream)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Sf5c2", "Errors.Query.CloseRows")
+			}
+
+			return &SSFStreams{
+				Streams: streams,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareSSFStreamQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(row *sql.Row) (*SSFStream, error)) {
+	return sq.Select(ssfStreamColumns()...).
+			From(ssfStreamTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*SSFStream, error) {
+			stream := new(SSFStream)
+			if err := scanSSFStream(row, stream); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Sf5n3", "Errors.SSF.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Sf5i4", "Errors.Internal")
+			}
+			return stream, nil
+		}
+}
@@ -0,0 +1,273 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	prepareSSFStreamStmt = `SELECT projections.ssf_streams.id,` +
+		` projections.ssf_streams.creation_date,` +
+		` projections.ssf_streams.change_date,` +
+		` projections.ssf_streams.resource_owner,` +
+		` projections.ssf_streams.sequence,` +
+		` projections.ssf_streams.description,` +
+		` projections.ssf_streams.audience,` +
+		` projections.ssf_streams.events_requested,` +
+		` projections.ssf_streams.delivery_method,` +
+		` projections.ssf_streams.endpoint_url,` +
+		` projections.ssf_streams.authorization_header,` +
+		` projections.ssf_streams.state,` +
+		` projections.ssf_streams.status_reason,` +
+		` projections.ssf_streams.delivered_count,` +
+		` projections.ssf_streams.failed_count,` +
+		` projections.ssf_streams.last_delivery_date,` +
+		` projections.ssf_streams.last_error` +
+		` FROM projections.ssf_streams`
+	prepareSSFStreamCols = []string{
+		"id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"description",
+		"audience",
+		"events_requested",
+		"delivery_method",
+		"endpoint_url",
+		"authorization_header",
+		"state",
+		"status_reason",
+		"delivered_count",
+		"failed_count",
+		"last_delivery_date",
+		"last_error",
+	}
+
+	prepareSSFStreamsStmt = `SELECT projections.ssf_streams.id,` +
+		` projections.ssf_streams.creation_date,` +
+		` projections.ssf_streams.change_date,` +
+		` projections.ssf_streams.resource_owner,` +
+		` projections.ssf_streams.sequence,` +
+		` projections.ssf_streams.description,` +
+		` projections.ssf_streams.audience,` +
+		` projections.ssf_streams.events_requested,` +
+		` projections.ssf_streams.delivery_method,` +
+		` projections.ssf_streams.endpoint_url,` +
+		` projections.ssf_streams.authorization_header,` +
+		` projections.ssf_streams.state,` +
+		` projections.ssf_streams.status_reason,` +
+		` projections.ssf_streams.delivered_count,` +
+		` projections.ssf_streams.failed_count,` +
+		` projections.ssf_streams.last_delivery_date,` +
+		` projections.ssf_streams.last_error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.ssf_streams`
+	prepareSSFStreamsCols = append(prepareSSFStreamCols, "count")
+)
+
+func Test_SSFStreamPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareSSFStreamsQuery no result",
+			prepare: prepareSSFStreamsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSSFStreamsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &SSFStreams{Streams: []*SSFStream{}},
+		},
+		{
+			name:    "prepareSSFStreamsQuery one result",
+			prepare: prepareSSFStreamsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareSSFStreamsStmt),
+					prepareSSFStreamsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							"instance",
+							uint64(20211109),
+							"receiver",
+							database.TextArray[string]{"aud"},
+							database.TextArray[string]{domain.CAEPEventTypeSessionRevoked},
+							domain.SSFDeliveryMethodPush,
+							"https://receiver.example.com/events",
+							nil,
+							domain.SSFStreamStateEnabled,
+							"",
+							uint64(3),
+							uint64(1),
+							testNow,
+							"status 503",
+						},
+					},
+				),
+			},
+			object: &SSFStreams{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				Streams: []*SSFStream{
+					{
+						ObjectDetails: domain.ObjectDetails{
+							ID:            "id",
+							EventDate:     testNow,
+							CreationDate:  testNow,
+							ResourceOwner: "instance",
+							Sequence:      20211109,
+						},
+						Description:      "receiver",
+						Audience:         database.TextArray[string]{"aud"},
+						EventsRequested:  database.TextArray[string]{domain.CAEPEventTypeSessionRevoked},
+						DeliveryMethod:   domain.SSFDeliveryMethodPush,
+						EndpointURL:      "https://receiver.example.com/events",
+						State:            domain.SSFStreamStateEnabled,
+						DeliveredCount:   3,
+						FailedCount:      1,
+						LastDeliveryDate: testNow,
+						LastError:        "status 503",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareSSFStreamsQuery sql err",
+			prepare: prepareSSFStreamsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSSFStreamsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SSFStreams)(nil),
+		},
+		{
+			name:    "prepareSSFStreamQuery no result",
+			prepare: prepareSSFStreamQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareSSFStreamStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !zerrors.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SSFStream)(nil),
+		},
+		{
+			name:    "prepareSSFStreamQuery found",
+			prepare: prepareSSFStreamQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSSFStreamStmt),
+					prepareSSFStreamCols,
+					[]driver.Value{
+						"id",
+						testNow,
+						testNow,
+						"instance",
+						uint64(20211109),
+						"",
+						database.TextArray[string]{"aud"},
+						nil,
+						domain.SSFDeliveryMethodPush,
+						"https://receiver.example.com/events",
+						&crypto.CryptoValue{
+							CryptoType: crypto.TypeEncryption,
+							Algorithm:  "alg",
+							KeyID:      "encKey",
+							Crypted:    []byte("crypted"),
+						},
+						domain.SSFStreamStatePaused,
+						"maintenance",
+						uint64(0),
+						uint64(0),
+						nil,
+						"",
+					},
+				),
+			},
+			object: &SSFStream{
+				ObjectDetails: domain.ObjectDetails{
+					ID:            "id",
+					EventDate:     testNow,
+					CreationDate:  testNow,
+					ResourceOwner: "instance",
+					Sequence:      20211109,
+				},
+				Audience:        database.TextArray[string]{"aud"},
+				EventsRequested: database.TextArray[string]{},
+				DeliveryMethod:  domain.SSFDeliveryMethodPush,
+				EndpointURL:     "https://receiver.example.com/events",
+				authorizationHeader: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "alg",
+					KeyID:      "encKey",
+					Crypted:    []byte("crypted"),
+				},
+				State:            domain.SSFStreamStatePaused,
+				StatusReason:     "maintenance",
+				LastDeliveryDate: time.Time{},
+			},
+		},
+		{
+			name:    "prepareSSFStreamQuery sql err",
+			prepare: prepareSSFStreamQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareSSFStreamStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*SSFStream)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type InactiveUserAction int32
+
+const (
+	InactiveUserActionUnspecified InactiveUserAction = iota
+	// InactiveUserActionWarn means the user is within the warning period and will be notified.
+	InactiveUserActionWarn
+	// InactiveUserActionDeactivate means the inactivity period of the user passed.
+	InactiveUserActionDeactivate
+)
+
+type InactiveUser struct {
+	UserID           string
+	ResourceOwner    string
+	Username         string
+	LastActivity     time.Time
+	WarnedAt         time.Time
+	DeactivationDate time.Time
+	Action           InactiveUserAction
+}
+
+var (
+	userActivityTable = table{
+		name:          projection.UserActivityTable,
+		instanceIDCol: projection.UserActivityInstanceIDCol,
+	}
+	UserActivityColUserID = Column{
+		name:  projection.UserActivityUserIDCol,
+		table: userActivityTable,
+	}
+	UserActivityColInstanceID = Column{
+		name:  projection.UserActivityInstanceIDCol,
+		table: userActivityTable,
+	}
+	UserActivityColResourceOwner = Column{
+		name:  projection.UserActivityResourceOwnerCol,
+		table: userActivityTable,
+	}
+	UserActivityColLastActivity = Column{
+		name:  projection.UserActivityLastActivityCol,
+		table: userActivityTable,
+	}
+	UserActivityColWarnedAt = Column{
+		name:  projection.UserActivityWarnedAtCol,
+		table: userActivityTable,
+	}
+)
+
+// InactiveUsers returns the active human users of the organization which must either be warned or deactivated
+// at the given time based on the inactivity policy.
+// Users which were already warned are returned as well, their WarnedAt is set in that case.
+func (q *Queries) InactiveUsers(ctx context.Context, orgID string, policy *InactivityPolicy, now time.Time) (_ []*InactiveUser, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if !policy.Enabled() {
+		return []*InactiveUser{}, nil
+	}
+	query, scan := prepareInactiveUsersQuery(ctx, q.client)
+	eq := sq.And{
+		sq.Eq{
+			UserActivityColInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+			UserActivityColResourceOwner.identifier(): orgID,
+			UserStateCol.identifier():                 domain.UserStateActive,
+			UserTypeCol.identifier():                  domain.UserTypeHuman,
+		},
+		sq.LtOrEq{
+			UserActivityColLastActivity.identifier(): now.Add(-(policy.InactivityPeriod - policy.WarningPeriod)),
+		},
+	}
+	users, err := genericRowsQuery[[]*InactiveUser](ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	classifyInactiveUsers(users, policy, now)
+	return users, nil
+}
+
+func classifyInactiveUsers(users []*InactiveUser, policy *InactivityPolicy, now time.Time) {
+	for _, user := range users {
+		user.DeactivationDate = user.LastActivity.Add(policy.InactivityPeriod)
+		user.Action = InactiveUserActionWarn
+		if !now.Before(user.DeactivationDate) {
+			user.Action = InactiveUserActionDeactivate
+		}
+	}
+}
+
+func prepareInactiveUsersQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*InactiveUser, error)) {
+	return sq.Select(
+			UserActivityColUserID.identifier(),
+			UserActivityColResourceOwner.identifier(),
+			UserUsernameCol.identifier(),
+			UserActivityColLastActivity.identifier(),
+			UserActivityColWarnedAt.identifier(),
+		).
+			From(userActivityTable.identifier()).
+			Join(join(UserIDCol, UserActivityColUserID) + db.Timetravel(call.Took(ctx))).
+			OrderBy(UserActivityColLastActivity.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*InactiveUser, error) {
+			users := make([]*InactiveUser, 0)
+			for rows.Next() {
+				user := new(InactiveUser)
+				var warnedAt sql.NullTime
+				err := rows.Scan(
+					&user.UserID,
+					&user.ResourceOwner,
+					&user.Username,
+					&user.LastActivity,
+					&warnedAt,
+				)
+				if err != nil {
+					return nil, err
+				}
+				user.WarnedAt = warnedAt.Time
+				users = append(users, user)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ina5q", "Errors.Query.CloseRows")
+			}
+			return users, nil
+		}
+}
+
+// UserActivityResourceOwners returns the ids of all organizations of the instance which have human users.
+func (q *Queries) UserActivityResourceOwners(ctx context.Context) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserActivityResourceOwnersQuery(ctx, q.client)
+	eq := sq.Eq{
+		UserActivityColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	return genericRowsQuery[[]string](ctx, q.client, query.Where(eq), scan)
+}
+
+func prepareUserActivityResourceOwnersQuery(context.Context, prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]string, error)) {
+	return sq.Select(UserActivityColResourceOwner.identifier()).
+			Distinct().
+			From(userActivityTable.identifier()).
+			OrderBy(UserActivityColResourceOwner.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		scanOrgIDs
+}
@@ -0,0 +1,166 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	prepareInactiveUsersStmt = `SELECT projections.user_activities.user_id,` +
+		` projections.user_activities.resource_owner,` +
+		` projections.users13.username,` +
+		` projections.user_activities.last_activity,` +
+		` projections.user_activities.warned_at` +
+		` FROM projections.user_activities` +
+		` JOIN projections.users13 ON projections.user_activities.user_id = projections.users13.id AND projections.user_activities.instance_id = projections.users13.instance_id` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.user_activities.last_activity`
+	prepareInactiveUsersCols = []string{
+		"user_id",
+		"resource_owner",
+		"username",
+		"last_activity",
+		"warned_at",
+	}
+	prepareUserActivityResourceOwnersStmt = `SELECT DISTINCT projections.user_activities.resource_owner` +
+		` FROM projections.user_activities` +
+		` ORDER BY projections.user_activities.resource_owner`
+)
+
+func Test_UserActivityPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareInactiveUsersQuery no result",
+			prepare: prepareInactiveUsersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareInactiveUsersStmt),
+					nil,
+					nil,
+				),
+			},
+			object: []*InactiveUser{},
+		},
+		{
+			name:    "prepareInactiveUsersQuery found",
+			prepare: prepareInactiveUsersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareInactiveUsersStmt),
+					prepareInactiveUsersCols,
+					[][]driver.Value{
+						{
+							"user-1",
+							"ro",
+							"username-1",
+							testNow,
+							nil,
+						},
+						{
+							"user-2",
+							"ro",
+							"username-2",
+							testNow,
+							testNow,
+						},
+					},
+				),
+			},
+			object: []*InactiveUser{
+				{
+					UserID:        "user-1",
+					ResourceOwner: "ro",
+					Username:      "username-1",
+					LastActivity:  testNow,
+				},
+				{
+					UserID:        "user-2",
+					ResourceOwner: "ro",
+					Username:      "username-2",
+					LastActivity:  testNow,
+					WarnedAt:      testNow,
+				},
+			},
+		},
+		{
+			name:    "prepareInactiveUsersQuery sql err",
+			prepare: prepareInactiveUsersQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareInactiveUsersStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*InactiveUser)(nil),
+		},
+		{
+			name:    "prepareUserActivityResourceOwnersQuery found",
+			prepare: prepareUserActivityResourceOwnersQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareUserActivityResourceOwnersStmt),
+					[]string{"resource_owner"},
+					[][]driver.Value{
+						{"org-1"},
+						{"org-2"},
+					},
+				),
+			},
+			object: []string{"org-1", "org-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func Test_classifyInactiveUsers(t *testing.T) {
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	policy := &InactivityPolicy{
+		InactivityPeriod: 90 * 24 * time.Hour,
+		WarningPeriod:    7 * 24 * time.Hour,
+	}
+	users := []*InactiveUser{
+		{UserID: "warn", LastActivity: now.Add(-85 * 24 * time.Hour)},
+		{UserID: "deactivate", LastActivity: now.Add(-90 * 24 * time.Hour)},
+	}
+	classifyInactiveUsers(users, policy, now)
+	assert.Equal(t, []*InactiveUser{
+		{
+			UserID:           "warn",
+			LastActivity:     now.Add(-85 * 24 * time.Hour),
+			DeactivationDate: now.Add(5 * 24 * time.Hour),
+			Action:           InactiveUserActionWarn,
+		},
+		{
+			UserID:           "deactivate",
+			LastActivity:     now.Add(-90 * 24 * time.Hour),
+			DeactivationDate: now,
+			Action:           InactiveUserActionDeactivate,
+		},
+	}, users)
+}
@@ -0,0 +1,541 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// UserDataExportVersion is increased on breaking changes of the [UserDataExport] format.
+const UserDataExportVersion = 1
+
+// redactedValue replaces secrets in the exported event payloads.
+const redactedValue = "[REDACTED]"
+
+// UserDataExport contains all data stored about a single user.
+// It is meant to answer data subject access requests and therefore marshals to a stable JSON format.
+type UserDataExport struct {
+	Version              int                                  `json:"version"`
+	ExportedAt           time.Time                            `json:"exportedAt"`
+	InstanceID           string                               `json:"instanceId"`
+	User                 *UserDataExportUser                  `json:"user"`
+	Metadata             []*UserDataExportMetadata            `json:"metadata"`
+	IDPLinks             []*UserDataExportIDPLink             `json:"idpLinks"`
+	Grants               []*UserDataExportGrant               `json:"grants"`
+	Memberships          []*UserDataExportMembership          `json:"memberships"`
+	Sessions             []*UserDataExportSession             `json:"sessions"`
+	PersonalAccessTokens []*UserDataExportPersonalAccessToken `json:"personalAccessTokens"`
+	AuthMethods          []*UserDataExportAuthMethod          `json:"authMethods"`
+	Events               []*UserDataExportEvent               `json:"events"`
+}
+
+type UserDataExportUser struct {
+	ID                 string                     `json:"id"`
+	CreationDate       time.Time                  `json:"creationDate"`
+	ChangeDate         time.Time                  `json:"changeDate"`
+	ResourceOwner      string                     `json:"resourceOwner"`
+	State              domain.UserState           `json:"state"`
+	Type               domain.UserType            `json:"type"`
+	Username           string                     `json:"username"`
+	LoginNames         []string                   `json:"loginNames"`
+	PreferredLoginName string                     `json:"preferredLoginName"`
+	Profile            *UserDataExportProfile     `json:"profile,omitempty"`
+	Emails             []*UserDataExportContact   `json:"emails,omitempty"`
+	Phones             []*UserDataExportContact   `json:"phones,omitempty"`
+	Machine            *UserDataExportMachineInfo `json:"machine,omitempty"`
+}
+
+type UserDataExportProfile struct {
+	FirstName         string        `json:"firstName"`
+	LastName          string        `json:"lastName"`
+	NickName          string        `json:"nickName,omitempty"`
+	DisplayName       string        `json:"displayName"`
+	PreferredLanguage string        `json:"preferredLanguage,omitempty"`
+	Gender            domain.Gender `json:"gender"`
+	AvatarKey         string        `json:"avatarKey,omitempty"`
+	PasswordChanged   time.Time     `json:"passwordChanged,omitempty"`
+}
+
+type UserDataExportContact struct {
+	Value    string `json:"value"`
+	Verified bool   `json:"verified"`
+}
+
+type UserDataExportMachineInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type UserDataExportMetadata struct {
+	Key          string    `json:"key"`
+	Value        []byte    `json:"value"`
+	CreationDate time.Time `json:"creationDate"`
+	ChangeDate   time.Time `json:"changeDate"`
+}
+
+type UserDataExportIDPLink struct {
+	IDPID            string         `json:"idpId"`
+	IDPName          string         `json:"idpName"`
+	IDPType          domain.IDPType `json:"idpType"`
+	ProvidedUserID   string         `json:"providedUserId"`
+	ProvidedUsername string         `json:"providedUsername"`
+}
+
+type UserDataExportGrant struct {
+	ID            string                `json:"id"`
+	CreationDate  time.Time             `json:"creationDate"`
+	ChangeDate    time.Time             `json:"changeDate"`
+	State         domain.UserGrantState `json:"state"`
+	ResourceOwner string                `json:"resourceOwner"`
+	OrgName       string                `json:"orgName"`
+	ProjectID     string                `json:"projectId"`
+	ProjectName   string                `json:"projectName"`
+	GrantID       string                `json:"grantId,omitempty"`
+	Roles         []string              `json:"roles"`
+}
+
+type UserDataExportMembership struct {
+	// Type is one of instance, org, project or projectGrant
+	Type          string    `json:"type"`
+	ObjectID      string    `json:"objectId"`
+	Name          string    `json:"name"`
+	ResourceOwner string    `json:"resourceOwner"`
+	Roles         []string  `json:"roles"`
+	CreationDate  time.Time `json:"creationDate"`
+	ChangeDate    time.Time `json:"changeDate"`
+}
+
+type UserDataExportSession struct {
+	ID                string              `json:"id"`
+	CreationDate      time.Time           `json:"creationDate"`
+	ChangeDate        time.Time           `json:"changeDate"`
+	Expiration        time.Time           `json:"expiration,omitempty"`
+	UserAgent         *domain.UserAgent   `json:"userAgent,omitempty"`
+	Metadata          map[string][]byte   `json:"metadata,omitempty"`
+	UserCheckedAt     time.Time           `json:"userCheckedAt,omitempty"`
+	PasswordCheckedAt time.Time           `json:"passwordCheckedAt,omitempty"`
+	IntentCheckedAt   time.Time           `json:"intentCheckedAt,omitempty"`
+	WebAuthNCheckedAt time.Time           `json:"webAuthNCheckedAt,omitempty"`
+	TOTPCheckedAt     time.Time           `json:"totpCheckedAt,omitempty"`
+	OTPSMSCheckedAt   time.Time           `json:"otpSmsCheckedAt,omitempty"`
+	OTPEmailCheckedAt time.Time           `json:"otpEmailCheckedAt,omitempty"`
+	State             domain.SessionState `json:"state"`
+}
+
+type UserDataExportPersonalAccessToken struct {
+	ID           string    `json:"id"`
+	CreationDate time.Time `json:"creationDate"`
+	Expiration   time.Time `json:"expiration"`
+	Scopes       []string  `json:"scopes"`
+}
+
+type UserDataExportAuthMethod struct {
+	ID           string                    `json:"id,omitempty"`
+	Type         domain.UserAuthMethodType `json:"type"`
+	Name         string                    `json:"name,omitempty"`
+	State        domain.MFAState           `json:"state"`
+	CreationDate time.Time                 `json:"creationDate"`
+}
+
+type UserDataExportEvent struct {
+	AggregateType eventstore.AggregateType `json:"aggregateType"`
+	AggregateID   string                   `json:"aggregateId"`
+	Sequence      uint64                   `json:"sequence"`
+	Type          eventstore.EventType     `json:"type"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	Creator       string                   `json:"creator"`
+	Payload       json.RawMessage          `json:"payload,omitempty"`
+}
+
+// UserDataExport collects all data stored about the user.
+// Secrets like password hashes, encrypted codes and tokens are redacted.
+// The caller is responsible to check the permission to read the user.
+func (q *Queries) UserDataExport(ctx context.Context, userID string) (export *UserDataExport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Gdpr1", "Errors.User.UserIDMissing")
+	}
+	u, err := q.GetUserByID(ctx, true, userID)
+	if err != nil {
+		return nil, err
+	}
+	export = &UserDataExport{
+		Version:    UserDataExportVersion,
+		ExportedAt: time.Now().UTC(),
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		User:       userDataExportUser(u),
+	}
+	if export.Metadata, err = q.userDataExportMetadata(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.IDPLinks, err = q.userDataExportIDPLinks(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Grants, err = q.userDataExportGrants(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Memberships, err = q.userDataExportMemberships(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Sessions, err = q.userDataExportSessions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.PersonalAccessTokens, err = q.userDataExportPersonalAccessTokens(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.AuthMethods, err = q.userDataExportAuthMethods(ctx, userID); err != nil {
+		return nil, err
+	}
+	if export.Events, err = q.userDataExportEvents(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func userDataExportUser(u *User) *UserDataExportUser {
+	exported := &UserDataExportUser{
+		ID:                 u.ID,
+		CreationDate:       u.CreationDate,
+		ChangeDate:         u.ChangeDate,
+		ResourceOwner:      u.ResourceOwner,
+		State:              u.State,
+		Type:               u.Type,
+		Username:           u.Username,
+		LoginNames:         u.LoginNames,
+		PreferredLoginName: u.PreferredLoginName,
+	}
+	if u.Human != nil {
+		exported.Profile = &UserDataExportProfile{
+			FirstName:         u.Human.FirstName,
+			LastName:          u.Human.LastName,
+			NickName:          u.Human.NickName,
+			DisplayName:       u.Human.DisplayName,
+			PreferredLanguage: u.Human.PreferredLanguage.String(),
+			Gender:            u.Human.Gender,
+			AvatarKey:         u.Human.AvatarKey,
+			PasswordChanged:   u.Human.PasswordChanged,
+		}
+		if u.Human.Email != "" {
+			exported.Emails = []*UserDataExportContact{{Value: string(u.Human.Email), Verified: u.Human.IsEmailVerified}}
+		}
+		if u.Human.Phone != "" {
+			exported.Phones = []*UserDataExportContact{{Value: string(u.Human.Phone), Verified: u.Human.IsPhoneVerified}}
+		}
+	}
+	if u.Machine != nil {
+		// the hashed secret of the machine is intentionally not exported
+		exported.Machine = &UserDataExportMachineInfo{
+			Name:        u.Machine.Name,
+			Description: u.Machine.Description,
+		}
+	}
+	return exported
+}
+
+func (q *Queries) userDataExportMetadata(ctx context.Context, userID string) ([]*UserDataExportMetadata, error) {
+	metadata, err := q.SearchUserMetadata(ctx, true, userID, &UserMetadataSearchQueries{}, false)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportMetadata, len(metadata.Metadata))
+	for i, md := range metadata.Metadata {
+		exported[i] = &UserDataExportMetadata{
+			Key:          md.Key,
+			Value:        md.Value,
+			CreationDate: md.CreationDate,
+			ChangeDate:   md.ChangeDate,
+		}
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportIDPLinks(ctx context.Context, userID string) ([]*UserDataExportIDPLink, error) {
+	userIDQuery, err := NewIDPUserLinksUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := q.idpUserLinks(ctx, &IDPUserLinksSearchQuery{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportIDPLink, len(links.Links))
+	for i, link := range links.Links {
+		exported[i] = &UserDataExportIDPLink{
+			IDPID:            link.IDPID,
+			IDPName:          link.IDPName,
+			IDPType:          link.IDPType,
+			ProvidedUserID:   link.ProvidedUserID,
+			ProvidedUsername: link.ProvidedUsername,
+		}
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportGrants(ctx context.Context, userID string) ([]*UserDataExportGrant, error) {
+	userIDQuery, err := NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := q.UserGrants(ctx, &UserGrantsQueries{Queries: []SearchQuery{userIDQuery}}, true)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportGrant, len(grants.UserGrants))
+	for i, grant := range grants.UserGrants {
+		exported[i] = &UserDataExportGrant{
+			ID:            grant.ID,
+			CreationDate:  grant.CreationDate,
+			ChangeDate:    grant.ChangeDate,
+			State:         grant.State,
+			ResourceOwner: grant.ResourceOwner,
+			OrgName:       grant.OrgName,
+			ProjectID:     grant.ProjectID,
+			ProjectName:   grant.ProjectName,
+			GrantID:       grant.GrantID,
+			Roles:         grant.Roles,
+		}
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportMemberships(ctx context.Context, userID string) ([]*UserDataExportMembership, error) {
+	userIDQuery, err := NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{Queries: []SearchQuery{userIDQuery}}, true)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportMembership, 0, len(memberships.Memberships))
+	for _, membership := range memberships.Memberships {
+		m := &UserDataExportMembership{
+			ResourceOwner: membership.ResourceOwner,
+			Roles:         membership.Roles,
+			CreationDate:  membership.CreationDate,
+			ChangeDate:    membership.ChangeDate,
+		}
+		switch {
+		case membership.IAM != nil:
+			m.Type, m.ObjectID, m.Name = "instance", membership.IAM.IAMID, membership.IAM.Name
+		case membership.Org != nil:
+			m.Type, m.ObjectID, m.Name = "org", membership.Org.OrgID, membership.Org.Name
+		case membership.Project != nil:
+			m.Type, m.ObjectID, m.Name = "project", membership.Project.ProjectID, membership.Project.Name
+		case membership.ProjectGrant != nil:
+			m.Type, m.ObjectID, m.Name = "projectGrant", membership.ProjectGrant.GrantID, membership.ProjectGrant.ProjectName
+		default:
+			continue
+		}
+		exported = append(exported, m)
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportSessions(ctx context.Context, userID string) ([]*UserDataExportSession, error) {
+	userIDQuery, err := NewUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := q.SearchSessions(ctx, &SessionsSearchQueries{Queries: []SearchQuery{userIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportSession, len(sessions.Sessions))
+	for i, s := range sessions.Sessions {
+		exported[i] = &UserDataExportSession{
+			ID:                s.ID,
+			CreationDate:      s.CreationDate,
+			ChangeDate:        s.ChangeDate,
+			Expiration:        s.Expiration,
+			UserAgent:         &s.UserAgent,
+			Metadata:          s.Metadata,
+			UserCheckedAt:     s.UserFactor.UserCheckedAt,
+			PasswordCheckedAt: s.PasswordFactor.PasswordCheckedAt,
+			IntentCheckedAt:   s.IntentFactor.IntentCheckedAt,
+			WebAuthNCheckedAt: s.WebAuthNFactor.WebAuthNCheckedAt,
+			TOTPCheckedAt:     s.TOTPFactor.TOTPCheckedAt,
+			OTPSMSCheckedAt:   s.OTPSMSFactor.OTPCheckedAt,
+			OTPEmailCheckedAt: s.OTPEmailFactor.OTPCheckedAt,
+			State:             s.State,
+		}
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportPersonalAccessTokens(ctx context.Context, userID string) ([]*UserDataExportPersonalAccessToken, error) {
+	userIDQuery, err := NewPersonalAccessTokenUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := q.SearchPersonalAccessTokens(ctx, &PersonalAccessTokenSearchQueries{Queries: []SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportPersonalAccessToken, len(tokens.PersonalAccessTokens))
+	for i, token := range tokens.PersonalAccessTokens {
+		exported[i] = &UserDataExportPersonalAccessToken{
+			ID:           token.ID,
+			CreationDate: token.CreationDate,
+			Expiration:   token.Expiration,
+			Scopes:       token.Scopes,
+		}
+	}
+	return exported, nil
+}
+
+func (q *Queries) userDataExportAuthMethods(ctx context.Context, userID string) ([]*UserDataExportAuthMethod, error) {
+	userIDQuery, err := NewUserAuthMethodUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := q.searchUserAuthMethods(ctx, &UserAuthMethodSearchQueries{Queries: []SearchQuery{userIDQuery}})
+	if err != nil {
+		return nil, err
+	}
+	exported := make([]*UserDataExportAuthMethod, len(methods.AuthMethods))
+	for i, method := range methods.AuthMethods {
+		exported[i] = &UserDataExportAuthMethod{
+			ID:           method.TokenID,
+			Type:         method.Type,
+			Name:         method.Name,
+			State:        method.State,
+			CreationDate: method.CreationDate,
+		}
+	}
+	return exported, nil
+}
+
+// userDataExportEvents returns the events of the user and of the exported grants and sessions of the user.
+func (q *Queries) userDataExportEvents(ctx context.Context, export *UserDataExport) ([]*UserDataExportEvent, error) {
+	readModel := newUserDataExportReadModel(export)
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.ExportedEvents, nil
+}
+
+type userDataExportReadModel struct {
+	eventstore.ReadModel
+
+	userID         string
+	grantIDs       []string
+	sessionIDs     []string
+	ExportedEvents []*UserDataExportEvent
+}
+
+func newUserDataExportReadModel(export *UserDataExport) *userDataExportReadModel {
+	rm := &userDataExportReadModel{
+		userID:     export.User.ID,
+		grantIDs:   make([]string, len(export.Grants)),
+		sessionIDs: make([]string, len(export.Sessions)),
+	}
+	for i, grant := range export.Grants {
+		rm.grantIDs[i] = grant.ID
+	}
+	for i, s := range export.Sessions {
+		rm.sessionIDs[i] = s.ID
+	}
+	return rm
+}
+
+func (rm *userDataExportReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		exported := &UserDataExportEvent{
+			AggregateType: event.Aggregate().Type,
+			AggregateID:   event.Aggregate().ID,
+			Sequence:      event.Sequence(),
+			Type:          event.Type(),
+			CreatedAt:     event.CreatedAt(),
+			Creator:       event.Creator(),
+		}
+		var payload any
+		if err := event.Unmarshal(&payload); err != nil {
+			return zerrors.ThrowInternal(err, "QUERY-Gdpr2", "Errors.Internal")
+		}
+		if payload != nil {
+			data, err := json.Marshal(redactUserDataExportPayload(payload))
+			if err != nil {
+				return zerrors.ThrowInternal(err, "QUERY-Gdpr3", "Errors.Internal")
+			}
+			exported.Payload = data
+		}
+		rm.ExportedEvents = append(rm.ExportedEvents, exported)
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userDataExportReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.userID)
+	if len(rm.grantIDs) > 0 {
+		query = query.Or().
+			AggregateTypes(usergrant.AggregateType).
+			AggregateIDs(rm.grantIDs...)
+	}
+	if len(rm.sessionIDs) > 0 {
+		query = query.Or().
+			AggregateTypes(session.AggregateType).
+			AggregateIDs(rm.sessionIDs...)
+	}
+	return query.Builder()
+}
+
+// userDataExportSecretFields are payload fields which contain secrets, hashes or tokens.
+var userDataExportSecretFields = map[string]struct{}{
+	"secret":         {},
+	"otpSecret":      {},
+	"encodedHash":    {},
+	"hashedSecret":   {},
+	"code":           {},
+	"clientSecret":   {},
+	"accessToken":    {},
+	"refreshToken":   {},
+	"idToken":        {},
+	"token":          {},
+	"challenge":      {},
+	"password":       {},
+	"passwordHash":   {},
+	"privateKey":     {},
+	"encryptedToken": {},
+}
+
+// redactUserDataExportPayload replaces all secrets in the unmarshalled event payload.
+// Besides the known secret fields, every encrypted value ([crypto.CryptoValue]) is redacted.
+func redactUserDataExportPayload(payload any) any {
+	switch p := payload.(type) {
+	case map[string]any:
+		if _, ok := p["crypted"]; ok {
+			return redactedValue
+		}
+		for key, value := range p {
+			if _, ok := userDataExportSecretFields[key]; ok && value != nil {
+				p[key] = redactedValue
+				continue
+			}
+			p[key] = redactUserDataExportPayload(value)
+		}
+		return p
+	case []any:
+		for i, value := range p {
+			p[i] = redactUserDataExportPayload(value)
+		}
+		return p
+	default:
+		return payload
+	}
+}
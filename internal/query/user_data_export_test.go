@@ -0,0 +1,57 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_redactUserDataExportPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{
+			name:    "no secrets",
+			payload: `{"firstName":"Gigi","lastName":"Giraffe","email":"gigi@example.com"}`,
+			want:    `{"email":"gigi@example.com","firstName":"Gigi","lastName":"Giraffe"}`,
+		},
+		{
+			name:    "password hash",
+			payload: `{"encodedHash":"$2a$14$hash","changeRequired":false}`,
+			want:    `{"changeRequired":false,"encodedHash":"[REDACTED]"}`,
+		},
+		{
+			name:    "encrypted code",
+			payload: `{"code":{"cryptoType":0,"algorithm":"aes","keyId":"key","crypted":"Y29kZQ=="},"expiry":3600}`,
+			want:    `{"code":"[REDACTED]","expiry":3600}`,
+		},
+		{
+			name:    "encrypted value in unknown field",
+			payload: `{"idpTokens":{"cryptoType":0,"algorithm":"aes","keyId":"key","crypted":"dG9rZW4="}}`,
+			want:    `{"idpTokens":"[REDACTED]"}`,
+		},
+		{
+			name:    "nested and in lists",
+			payload: `{"links":[{"userId":"id","refreshToken":"token"}]}`,
+			want:    `{"links":[{"refreshToken":"[REDACTED]","userId":"id"}]}`,
+		},
+		{
+			name:    "empty secret is kept",
+			payload: `{"secret":null}`,
+			want:    `{"secret":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload any
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &payload))
+			got, err := json.Marshal(redactUserDataExportPayload(payload))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// NewUserIDAfterSearchQuery only returns users with an ID after the provided one.
+// Combined with an ascending sorting by the ID, it allows to page through all users
+// by using the last returned ID as checkpoint, which is not affected by added or removed users.
+func NewUserIDAfterSearchQuery(id string) (SearchQuery, error) {
+	return &columnAfterQuery{
+		Column: UserIDCol,
+		Value:  id,
+	}, nil
+}
+
+type columnAfterQuery struct {
+	Column Column
+	Value  string
+}
+
+func (q *columnAfterQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *columnAfterQuery) comp() sq.Sqlizer {
+	return sq.Gt{q.Column.identifier(): q.Value}
+}
+
+func (q *columnAfterQuery) Col() Column {
+	return q.Column
+}
+
+// UserImportExternalIDs returns the IDs the users had in the system they were imported from.
+// Users which were not imported are not part of the result.
+func (q *Queries) UserImportExternalIDs(ctx context.Context, userIDs []string) (_ map[string]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	readModel := newUserImportsReadModel(userIDs)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.ExternalIDs, nil
+}
+
+type userImportsReadModel struct {
+	*eventstore.ReadModel
+
+	UserIDs     []string
+	ExternalIDs map[string]string
+}
+
+func newUserImportsReadModel(userIDs []string) *userImportsReadModel {
+	return &userImportsReadModel{
+		ReadModel:   &eventstore.ReadModel{},
+		UserIDs:     userIDs,
+		ExternalIDs: make(map[string]string, len(userIDs)),
+	}
+}
+
+func (rm *userImportsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if e, ok := event.(*user.UserImportedEvent); ok {
+			rm.ExternalIDs[e.Aggregate().ID] = e.ExternalID
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userImportsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.UserIDs...).
+		EventTypes(user.UserImportedType).
+		Builder()
+}
+
+// UsersMetadata returns the metadata of all provided users in a single query, mapped by the ID of the user.
+// Users without metadata are not part of the result.
+func (q *Queries) UsersMetadata(ctx context.Context, userIDs []string) (metadata map[string][]*UserMetadata, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	query, scan := prepareUsersMetadataQuery(ctx, q.client)
+	eq := sq.Eq{
+		UserMetadataUserIDCol.identifier():     userIDs,
+		UserMetadataInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ex1m2", "Errors.Query.SQLStatment")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		metadata, err = scan(rows)
+		return err
+	}, stmt, args...)
+	return metadata, err
+}
+
+func prepareUsersMetadataQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (map[string][]*UserMetadata, error)) {
+	return sq.Select(
+			UserMetadataUserIDCol.identifier(),
+			UserMetadataCreationDateCol.identifier(),
+			UserMetadataChangeDateCol.identifier(),
+			UserMetadataResourceOwnerCol.identifier(),
+			UserMetadataSequenceCol.identifier(),
+			UserMetadataKeyCol.identifier(),
+			UserMetadataValueCol.identifier()).
+			From(userMetadataTable.identifier()+db.Timetravel(call.Took(ctx))).
+			OrderBy(UserMetadataUserIDCol.identifier(), UserMetadataKeyCol.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (map[string][]*UserMetadata, error) {
+			metadata := make(map[string][]*UserMetadata)
+			for rows.Next() {
+				var userID string
+				m := new(UserMetadata)
+				err := rows.Scan(
+					&userID,
+					&m.CreationDate,
+					&m.ChangeDate,
+					&m.ResourceOwner,
+					&m.Sequence,
+					&m.Key,
+					&m.Value,
+				)
+				if err != nil {
+					return nil, err
+				}
+				metadata[userID] = append(metadata[userID], m)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Ex2m2", "Errors.Query.CloseRows")
+			}
+			return metadata, nil
+		}
+}
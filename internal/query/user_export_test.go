@@ -0,0 +1,113 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserIDAfterSearchQuery(t *testing.T) {
+	query, err := NewUserIDAfterSearchQuery("user1")
+	require.NoError(t, err)
+	assert.Equal(t, UserIDCol, query.Col())
+	assert.Equal(t, sq.Gt{"projections.users13.id": "user1"}, query.comp())
+
+	stmt, args, err := query.toQuery(sq.Select("id").From(userTable.identifier())).ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT id FROM projections.users13 WHERE projections.users13.id > ?", stmt)
+	assert.Equal(t, []interface{}{"user1"}, args)
+}
+
+func Test_prepareUsersMetadataQuery(t *testing.T) {
+	usersMetadataQuery := `SELECT projections.user_metadata5.user_id,` +
+		` projections.user_metadata5.creation_date,` +
+		` projections.user_metadata5.change_date,` +
+		` projections.user_metadata5.resource_owner,` +
+		` projections.user_metadata5.sequence,` +
+		` projections.user_metadata5.key,` +
+		` projections.user_metadata5.value` +
+		` FROM projections.user_metadata5` +
+		` AS OF SYSTEM TIME '-1 ms'` +
+		` ORDER BY projections.user_metadata5.user_id, projections.user_metadata5.key`
+	usersMetadataCols := []string{
+		"user_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"key",
+		"value",
+	}
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name   string
+		want   want
+		object interface{}
+	}{
+		{
+			name: "no result",
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(usersMetadataQuery),
+					nil,
+					nil,
+				),
+			},
+			object: map[string][]*UserMetadata{},
+		},
+		{
+			name: "multiple users",
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(usersMetadataQuery),
+					usersMetadataCols,
+					[][]driver.Value{
+						{"user1", testNow, testNow, "ro", uint64(20211108), "key", []byte("value")},
+						{"user1", testNow, testNow, "ro", uint64(20211108), "key2", []byte("value2")},
+						{"user2", testNow, testNow, "ro", uint64(20211108), "key", []byte("value3")},
+					},
+				),
+			},
+			object: map[string][]*UserMetadata{
+				"user1": {
+					{CreationDate: testNow, ChangeDate: testNow, ResourceOwner: "ro", Sequence: 20211108, Key: "key", Value: []byte("value")},
+					{CreationDate: testNow, ChangeDate: testNow, ResourceOwner: "ro", Sequence: 20211108, Key: "key2", Value: []byte("value2")},
+				},
+				"user2": {
+					{CreationDate: testNow, ChangeDate: testNow, ResourceOwner: "ro", Sequence: 20211108, Key: "key", Value: []byte("value3")},
+				},
+			},
+		},
+		{
+			name: "sql err",
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(usersMetadataQuery),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (map[string][]*UserMetadata)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, prepareUsersMetadataQuery, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
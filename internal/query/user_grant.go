@@ -29,6 +29,9 @@ type UserGrant struct {
 	// GrantID represents the project grant id
 	GrantID string                `json:"grant_id,omitempty"`
 	State   domain.UserGrantState `json:"state,omitempty"`
+	// ValidFrom and ValidUntil restrict the grant to a time window, zero values leave it open
+	ValidFrom  time.Time `json:"valid_from,omitempty"`
+	ValidUntil time.Time `json:"valid_until,omitempty"`
 
 	UserID             string          `json:"user_id,omitempty"`
 	Username           string          `json:"username,omitempty"`
@@ -75,6 +78,14 @@ func NewUserGrantUserIDSearchQuery(id string) (SearchQuery, error) {
 	return NewTextQuery(UserGrantUserID, id, TextEquals)
 }
 
+func NewUserGrantUserIDsSearchQuery(ids []string) (SearchQuery, error) {
+	list := make([]interface{}, len(ids))
+	for i, value := range ids {
+		list[i] = value
+	}
+	return NewListQuery(UserGrantUserID, list, ListIn)
+}
+
 func NewUserGrantProjectIDSearchQuery(id string) (SearchQuery, error) {
 	return NewTextQuery(UserGrantProjectID, id, TextEquals)
 }
@@ -147,6 +158,39 @@ func NewUserGrantStateQuery(value domain.UserGrantState) (SearchQuery, error) {
 	return NewNumberQuery(UserGrantState, value, NumberEquals)
 }
 
+// NewUserGrantValidityQuery only matches user grants which are inside of their validity at the time of the query.
+// User grants on a project grant outside of its validity are excluded as well.
+func NewUserGrantValidityQuery() (SearchQuery, error) {
+	return new(userGrantValidityQuery), nil
+}
+
+type userGrantValidityQuery struct{}
+
+func (q *userGrantValidityQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (q *userGrantValidityQuery) comp() sq.Sqlizer {
+	return sq.And{
+		sq.Or{
+			sq.Eq{UserGrantValidFrom.identifier(): nil},
+			sq.Expr(UserGrantValidFrom.identifier() + " <= now()"),
+		},
+		sq.Or{
+			sq.Eq{UserGrantValidUntil.identifier(): nil},
+			sq.Expr(UserGrantValidUntil.identifier() + " > now()"),
+		},
+		sq.Expr("NOT EXISTS (SELECT 1 FROM " + projectGrantsTable.identifier() +
+			" WHERE " + ProjectGrantColumnGrantID.identifier() + " = " + UserGrantGrantID.identifier() +
+			" AND " + ProjectGrantColumnInstanceID.identifier() + " = " + UserGrantInstanceID.identifier() +
+			" AND (" + ProjectGrantColumnValidFrom.identifier() + " > now() OR " + ProjectGrantColumnValidUntil.identifier() + " <= now()))"),
+	}
+}
+
+func (q *userGrantValidityQuery) Col() Column {
+	return UserGrantValidUntil
+}
+
 func NewUserGrantWithGrantedQuery(owner string) (SearchQuery, error) {
 	orgQuery, err := NewUserGrantResourceOwnerSearchQuery(owner)
 	if err != nil {
@@ -216,6 +260,18 @@ var (
 		name:  projection.UserGrantState,
 		table: userGrantTable,
 	}
+	UserGrantValidFrom = Column{
+		name:  projection.UserGrantValidFrom,
+		table: userGrantTable,
+	}
+	UserGrantValidUntil = Column{
+		name:  projection.UserGrantValidUntil,
+		table: userGrantTable,
+	}
+	UserGrantExpiryWarned = Column{
+		name:  projection.UserGrantExpiryWarned,
+		table: userGrantTable,
+	}
 	GrantedOrgsTable = table{
 		name:          projection.OrgProjectionTable,
 		alias:         "granted_orgs",
@@ -307,6 +363,8 @@ func prepareUserGrantQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			UserGrantGrantID.identifier(),
 			UserGrantRoles.identifier(),
 			UserGrantState.identifier(),
+			UserGrantValidFrom.identifier(),
+			UserGrantValidUntil.identifier(),
 
 			UserGrantUserID.identifier(),
 			UserUsernameCol.identifier(),
@@ -362,6 +420,9 @@ func prepareUserGrantQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				grantedOrgID     sql.NullString
 				grantedOrgName   sql.NullString
 				grantedOrgDomain sql.NullString
+
+				validFrom  sql.NullTime
+				validUntil sql.NullTime
 			)
 
 			err := row.Scan(
@@ -372,6 +433,8 @@ func prepareUserGrantQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 				&g.GrantID,
 				&g.Roles,
 				&g.State,
+				&validFrom,
+				&validUntil,
 
 				&g.UserID,
 				&username,
@@ -417,6 +480,8 @@ func prepareUserGrantQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 			g.GrantedOrgID = grantedOrgID.String
 			g.GrantedOrgName = grantedOrgName.String
 			g.GrantedOrgDomain = grantedOrgDomain.String
+			g.ValidFrom = validFrom.Time
+			g.ValidUntil = validUntil.Time
 			return g, nil
 		}
 }
@@ -430,6 +495,8 @@ func prepareUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			UserGrantGrantID.identifier(),
 			UserGrantRoles.identifier(),
 			UserGrantState.identifier(),
+			UserGrantValidFrom.identifier(),
+			UserGrantValidUntil.identifier(),
 
 			UserGrantUserID.identifier(),
 			UserUsernameCol.identifier(),
@@ -490,6 +557,9 @@ func prepareUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					grantedOrgDomain sql.NullString
 
 					projectName sql.NullString
+
+					validFrom  sql.NullTime
+					validUntil sql.NullTime
 				)
 
 				err := rows.Scan(
@@ -500,6 +570,8 @@ func prepareUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 					&g.GrantID,
 					&g.Roles,
 					&g.State,
+					&validFrom,
+					&validUntil,
 
 					&g.UserID,
 					&username,
@@ -544,6 +616,8 @@ func prepareUserGrantsQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				g.GrantedOrgID = grantedOrgID.String
 				g.GrantedOrgName = grantedOrgName.String
 				g.GrantedOrgDomain = grantedOrgDomain.String
+				g.ValidFrom = validFrom.Time
+				g.ValidUntil = validUntil.Time
 
 				userGrants = append(userGrants, g)
 			}
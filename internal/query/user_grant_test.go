@@ -15,14 +15,16 @@ import (
 
 var (
 	userGrantStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants5.id" +
-			", projections.user_grants5.creation_date" +
-			", projections.user_grants5.change_date" +
-			", projections.user_grants5.sequence" +
-			", projections.user_grants5.grant_id" +
-			", projections.user_grants5.roles" +
-			", projections.user_grants5.state" +
-			", projections.user_grants5.user_id" +
+		"SELECT projections.user_grants6.id" +
+			", projections.user_grants6.creation_date" +
+			", projections.user_grants6.change_date" +
+			", projections.user_grants6.sequence" +
+			", projections.user_grants6.grant_id" +
+			", projections.user_grants6.roles" +
+			", projections.user_grants6.state" +
+			", projections.user_grants6.valid_from" +
+			", projections.user_grants6.valid_until" +
+			", projections.user_grants6.user_id" +
 			", projections.users13.username" +
 			", projections.users13.type" +
 			", projections.users13.resource_owner" +
@@ -32,21 +34,21 @@ var (
 			", projections.users13_humans.display_name" +
 			", projections.users13_humans.avatar_key" +
 			", projections.login_names3.login_name" +
-			", projections.user_grants5.resource_owner" +
+			", projections.user_grants6.resource_owner" +
 			", projections.orgs1.name" +
 			", projections.orgs1.primary_domain" +
-			", projections.user_grants5.project_id" +
+			", projections.user_grants6.project_id" +
 			", projections.projects4.name" +
 			", granted_orgs.id" +
 			", granted_orgs.name" +
 			", granted_orgs.primary_domain" +
-			" FROM projections.user_grants5" +
-			" LEFT JOIN projections.users13 ON projections.user_grants5.user_id = projections.users13.id AND projections.user_grants5.instance_id = projections.users13.instance_id" +
-			" LEFT JOIN projections.users13_humans ON projections.user_grants5.user_id = projections.users13_humans.user_id AND projections.user_grants5.instance_id = projections.users13_humans.instance_id" +
-			" LEFT JOIN projections.orgs1 ON projections.user_grants5.resource_owner = projections.orgs1.id AND projections.user_grants5.instance_id = projections.orgs1.instance_id" +
-			" LEFT JOIN projections.projects4 ON projections.user_grants5.project_id = projections.projects4.id AND projections.user_grants5.instance_id = projections.projects4.instance_id" +
+			" FROM projections.user_grants6" +
+			" LEFT JOIN projections.users13 ON projections.user_grants6.user_id = projections.users13.id AND projections.user_grants6.instance_id = projections.users13.instance_id" +
+			" LEFT JOIN projections.users13_humans ON projections.user_grants6.user_id = projections.users13_humans.user_id AND projections.user_grants6.instance_id = projections.users13_humans.instance_id" +
+			" LEFT JOIN projections.orgs1 ON projections.user_grants6.resource_owner = projections.orgs1.id AND projections.user_grants6.instance_id = projections.orgs1.instance_id" +
+			" LEFT JOIN projections.projects4 ON projections.user_grants6.project_id = projections.projects4.id AND projections.user_grants6.instance_id = projections.projects4.instance_id" +
 			" LEFT JOIN projections.orgs1 AS granted_orgs ON projections.users13.resource_owner = granted_orgs.id AND projections.users13.instance_id = granted_orgs.instance_id" +
-			" LEFT JOIN projections.login_names3 ON projections.user_grants5.user_id = projections.login_names3.user_id AND projections.user_grants5.instance_id = projections.login_names3.instance_id" +
+			" LEFT JOIN projections.login_names3 ON projections.user_grants6.user_id = projections.login_names3.user_id AND projections.user_grants6.instance_id = projections.login_names3.instance_id" +
 			` AS OF SYSTEM TIME '-1 ms' ` +
 			" WHERE projections.login_names3.is_primary = $1")
 	userGrantCols = []string{
@@ -57,6 +59,8 @@ var (
 		"grant_id",
 		"roles",
 		"state",
+		"valid_from",
+		"valid_until",
 		"user_id",
 		"username",
 		"type",
@@ -77,14 +81,16 @@ var (
 		"primary_domain", // granted org domain
 	}
 	userGrantsStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants5.id" +
-			", projections.user_grants5.creation_date" +
-			", projections.user_grants5.change_date" +
-			", projections.user_grants5.sequence" +
-			", projections.user_grants5.grant_id" +
-			", projections.user_grants5.roles" +
-			", projections.user_grants5.state" +
-			", projections.user_grants5.user_id" +
+		"SELECT projections.user_grants6.id" +
+			", projections.user_grants6.creation_date" +
+			", projections.user_grants6.change_date" +
+			", projections.user_grants6.sequence" +
+			", projections.user_grants6.grant_id" +
+			", projections.user_grants6.roles" +
+			", projections.user_grants6.state" +
+			", projections.user_grants6.valid_from" +
+			", projections.user_grants6.valid_until" +
+			", projections.user_grants6.user_id" +
 			", projections.users13.username" +
 			", projections.users13.type" +
 			", projections.users13.resource_owner" +
@@ -94,22 +100,22 @@ var (
 			", projections.users13_humans.display_name" +
 			", projections.users13_humans.avatar_key" +
 			", projections.login_names3.login_name" +
-			", projections.user_grants5.resource_owner" +
+			", projections.user_grants6.resource_owner" +
 			", projections.orgs1.name" +
 			", projections.orgs1.primary_domain" +
-			", projections.user_grants5.project_id" +
+			", projections.user_grants6.project_id" +
 			", projections.projects4.name" +
 			", granted_orgs.id" +
 			", granted_orgs.name" +
 			", granted_orgs.primary_domain" +
 			", COUNT(*) OVER ()" +
-			" FROM projections.user_grants5" +
-			" LEFT JOIN projections.users13 ON projections.user_grants5.user_id = projections.users13.id AND projections.user_grants5.instance_id = projections.users13.instance_id" +
-			" LEFT JOIN projections.users13_humans ON projections.user_grants5.user_id = projections.users13_humans.user_id AND projections.user_grants5.instance_id = projections.users13_humans.instance_id" +
-			" LEFT JOIN projections.orgs1 ON projections.user_grants5.resource_owner = projections.orgs1.id AND projections.user_grants5.instance_id = projections.orgs1.instance_id" +
-			" LEFT JOIN projections.projects4 ON projections.user_grants5.project_id = projections.projects4.id AND projections.user_grants5.instance_id = projections.projects4.instance_id" +
+			" FROM projections.user_grants6" +
+			" LEFT JOIN projections.users13 ON projections.user_grants6.user_id = projections.users13.id AND projections.user_grants6.instance_id = projections.users13.instance_id" +
+			" LEFT JOIN projections.users13_humans ON projections.user_grants6.user_id = projections.users13_humans.user_id AND projections.user_grants6.instance_id = projections.users13_humans.instance_id" +
+			" LEFT JOIN projections.orgs1 ON projections.user_grants6.resource_owner = projections.orgs1.id AND projections.user_grants6.instance_id = projections.orgs1.instance_id" +
+			" LEFT JOIN projections.projects4 ON projections.user_grants6.project_id = projections.projects4.id AND projections.user_grants6.instance_id = projections.projects4.instance_id" +
 			" LEFT JOIN projections.orgs1 AS granted_orgs ON projections.users13.resource_owner = granted_orgs.id AND projections.users13.instance_id = granted_orgs.instance_id" +
-			" LEFT JOIN projections.login_names3 ON projections.user_grants5.user_id = projections.login_names3.user_id AND projections.user_grants5.instance_id = projections.login_names3.instance_id" +
+			" LEFT JOIN projections.login_names3 ON projections.user_grants6.user_id = projections.login_names3.user_id AND projections.user_grants6.instance_id = projections.login_names3.instance_id" +
 			` AS OF SYSTEM TIME '-1 ms' ` +
 			" WHERE projections.login_names3.is_primary = $1")
 	userGrantsCols = append(
@@ -162,6 +168,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.TextArray[string]{"role-key"},
 						domain.UserGrantStateActive,
+						testNow,
+						testNow,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -191,6 +199,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 				Roles:              database.TextArray[string]{"role-key"},
 				GrantID:            "grant-id",
 				State:              domain.UserGrantStateActive,
+				ValidFrom:          testNow,
+				ValidUntil:         testNow,
 				UserID:             "user-id",
 				Username:           "username",
 				UserType:           domain.UserTypeHuman,
@@ -226,6 +236,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.TextArray[string]{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeMachine,
@@ -290,6 +302,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.TextArray[string]{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -354,6 +368,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.TextArray[string]{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -418,6 +434,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 						"grant-id",
 						database.TextArray[string]{"role-key"},
 						domain.UserGrantStateActive,
+						nil,
+						nil,
 						"user-id",
 						"username",
 						domain.UserTypeHuman,
@@ -512,6 +530,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -585,6 +605,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeMachine,
@@ -658,6 +680,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeMachine,
@@ -731,6 +755,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -804,6 +830,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -877,6 +905,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -904,6 +934,8 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"grant-id",
 							database.TextArray[string]{"role-key"},
 							domain.UserGrantStateActive,
+							nil,
+							nil,
 							"user-id",
 							"username",
 							domain.UserTypeHuman,
@@ -1012,3 +1044,26 @@ func Test_UserGrantPrepares(t *testing.T) {
 		})
 	}
 }
+
+func Test_userGrantValidityQuery_comp(t *testing.T) {
+	query, err := NewUserGrantValidityQuery()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stmt, args, err := query.comp().ToSql()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "((projections.user_grants6.valid_from IS NULL OR projections.user_grants6.valid_from <= now())" +
+		" AND (projections.user_grants6.valid_until IS NULL OR projections.user_grants6.valid_until > now())" +
+		" AND NOT EXISTS (SELECT 1 FROM projections.project_grants5" +
+		" WHERE projections.project_grants5.grant_id = projections.user_grants6.grant_id" +
+		" AND projections.project_grants5.instance_id = projections.user_grants6.instance_id" +
+		" AND (projections.project_grants5.valid_from > now() OR projections.project_grants5.valid_until <= now())))"
+	if stmt != want {
+		t.Errorf("unexpected statement:\n got: %s\nwant: %s", stmt, want)
+	}
+	if len(args) != 0 {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
@@ -0,0 +1,221 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user/schema"
+	"github.com/zitadel/zitadel/internal/repository/user/schemauser"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type UserSchemaMigrationState int32
+
+const (
+	UserSchemaMigrationStateUnspecified UserSchemaMigrationState = iota
+	UserSchemaMigrationStateRunning
+	UserSchemaMigrationStateDone
+)
+
+// UserSchemaMigration is the progress of the latest migration of the users of a schema.
+type UserSchemaMigration struct {
+	SchemaID       string
+	ResourceOwner  string
+	State          UserSchemaMigrationState
+	SchemaRevision uint64
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	// Migrated is the amount of users whose data was migrated to the revision.
+	Migrated uint64
+	// Valid is the amount of users which already conformed to the revision.
+	Valid uint64
+	// Failures are the users which could not be migrated, they stay on their revision.
+	Failures []*UserSchemaMigrationFailure
+	// LastUserID is the last processed user, the migration resumes after it.
+	LastUserID string
+}
+
+type UserSchemaMigrationFailure struct {
+	UserID string
+	Reason string
+}
+
+// UserSchemaMigration returns the progress of the latest migration of the users of the schema.
+func (q *Queries) UserSchemaMigration(ctx context.Context, schemaID string) (_ *UserSchemaMigration, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newUserSchemaMigrationsReadModel(schemaID)
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	migration, ok := readModel.Migrations[schemaID]
+	if !ok {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Mig1n", "Errors.UserSchema.Migration.NotFound")
+	}
+	return migration, nil
+}
+
+// RunningUserSchemaMigrations returns all migrations of user schemas of the instance which are not done yet.
+func (q *Queries) RunningUserSchemaMigrations(ctx context.Context) (_ []*UserSchemaMigration, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newUserSchemaMigrationsReadModel("")
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	running := make([]*UserSchemaMigration, 0, len(readModel.Migrations))
+	for _, migration := range readModel.Migrations {
+		if migration.State == UserSchemaMigrationStateRunning {
+			running = append(running, migration)
+		}
+	}
+	slices.SortFunc(running, func(a, b *UserSchemaMigration) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return running, nil
+}
+
+// SchemaUserIDs returns the ids of the users which were created with or moved to the schema, ordered by id.
+// Only the ids after the passed id are returned, which allows to iterate over all users in batches.
+// The users might have been deleted or moved to another schema since.
+func (q *Queries) SchemaUserIDs(ctx context.Context, schemaID, afterUserID string, limit int) (_ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	readModel := newSchemaUserIDsReadModel(schemaID)
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.idsAfter(afterUserID, limit), nil
+}
+
+type userSchemaMigrationsReadModel struct {
+	eventstore.ReadModel
+
+	schemaID   string
+	Migrations map[string]*UserSchemaMigration
+}
+
+// newUserSchemaMigrationsReadModel reduces the migrations of the schema or of all schemas if schemaID is empty.
+func newUserSchemaMigrationsReadModel(schemaID string) *userSchemaMigrationsReadModel {
+	return &userSchemaMigrationsReadModel{
+		schemaID:   schemaID,
+		Migrations: make(map[string]*UserSchemaMigration),
+	}
+}
+
+func (rm *userSchemaMigrationsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *schema.MigrationStartedEvent:
+			// a new migration resets the progress of the previous one
+			rm.Migrations[e.Aggregate().ID] = &UserSchemaMigration{
+				SchemaID:       e.Aggregate().ID,
+				ResourceOwner:  e.Aggregate().ResourceOwner,
+				State:          UserSchemaMigrationStateRunning,
+				SchemaRevision: e.SchemaRevision,
+				StartedAt:      e.CreatedAt(),
+			}
+		case *schema.MigrationProgressedEvent:
+			migration, ok := rm.Migrations[e.Aggregate().ID]
+			if !ok {
+				continue
+			}
+			migration.Migrated += e.Migrated
+			migration.Valid += e.Valid
+			for _, failure := range e.Failures {
+				migration.Failures = append(migration.Failures, &UserSchemaMigrationFailure{
+					UserID: failure.UserID,
+					Reason: failure.Reason,
+				})
+			}
+			if e.LastUserID != "" {
+				migration.LastUserID = e.LastUserID
+			}
+		case *schema.MigrationDoneEvent:
+			migration, ok := rm.Migrations[e.Aggregate().ID]
+			if !ok {
+				continue
+			}
+			migration.State = UserSchemaMigrationStateDone
+			migration.FinishedAt = e.CreatedAt()
+		case *schema.DeletedEvent:
+			delete(rm.Migrations, e.Aggregate().ID)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userSchemaMigrationsReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		AddQuery().
+		AggregateTypes(schema.AggregateType).
+		EventTypes(
+			schema.MigrationStartedType,
+			schema.MigrationProgressedType,
+			schema.MigrationDoneType,
+			schema.DeletedType,
+		)
+	if rm.schemaID != "" {
+		query = query.AggregateIDs(rm.schemaID)
+	}
+	return query.Builder()
+}
+
+type schemaUserIDsReadModel struct {
+	eventstore.ReadModel
+
+	schemaID string
+	userIDs  map[string]struct{}
+}
+
+func newSchemaUserIDsReadModel(schemaID string) *schemaUserIDsReadModel {
+	return &schemaUserIDsReadModel{
+		schemaID: schemaID,
+		userIDs:  make(map[string]struct{}),
+	}
+}
+
+func (rm *schemaUserIDsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch event.(type) {
+		case *schemauser.CreatedEvent, *schemauser.UpdatedEvent:
+			rm.userIDs[event.Aggregate().ID] = struct{}{}
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *schemaUserIDsReadModel) idsAfter(afterUserID string, limit int) []string {
+	ids := make([]string, 0, len(rm.userIDs))
+	for id := range rm.userIDs {
+		if id > afterUserID {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+func (rm *schemaUserIDsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(schemauser.AggregateType).
+		EventTypes(
+			schemauser.CreatedType,
+			schemauser.UpdatedType,
+		).
+		EventData(map[string]interface{}{
+			"schemaID": rm.schemaID,
+		}).
+		Builder()
+}
@@ -0,0 +1,132 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user/schema"
+)
+
+func Test_userSchemaMigrationsReadModel_Reduce(t *testing.T) {
+	agg1 := &schema.NewAggregate("schema1", "instance").Aggregate
+	agg2 := &schema.NewAggregate("schema2", "instance").Aggregate
+	tests := []struct {
+		name   string
+		events []eventstore.Event
+		want   map[string]*UserSchemaMigration
+	}{
+		{
+			name: "no migration",
+			want: map[string]*UserSchemaMigration{},
+		},
+		{
+			name: "running migration with progress",
+			events: []eventstore.Event{
+				schema.NewMigrationStartedEvent(context.Background(), agg1, 2),
+				schema.NewMigrationProgressedEvent(context.Background(), agg1, 2, 1,
+					[]*schema.MigrationFailure{{UserID: "user3", Reason: "Errors.UserSchema.Data.Invalid"}},
+					"user4",
+				),
+				schema.NewMigrationProgressedEvent(context.Background(), agg1, 1, 0, nil, "user5"),
+			},
+			want: map[string]*UserSchemaMigration{
+				"schema1": {
+					SchemaID:       "schema1",
+					ResourceOwner:  "instance",
+					State:          UserSchemaMigrationStateRunning,
+					SchemaRevision: 2,
+					Migrated:       3,
+					Valid:          1,
+					Failures:       []*UserSchemaMigrationFailure{{UserID: "user3", Reason: "Errors.UserSchema.Data.Invalid"}},
+					LastUserID:     "user5",
+				},
+			},
+		},
+		{
+			name: "restarted migration resets progress",
+			events: []eventstore.Event{
+				schema.NewMigrationStartedEvent(context.Background(), agg1, 2),
+				schema.NewMigrationProgressedEvent(context.Background(), agg1, 2, 1, nil, "user4"),
+				schema.NewMigrationDoneEvent(context.Background(), agg1),
+				schema.NewMigrationStartedEvent(context.Background(), agg1, 3),
+			},
+			want: map[string]*UserSchemaMigration{
+				"schema1": {
+					SchemaID:       "schema1",
+					ResourceOwner:  "instance",
+					State:          UserSchemaMigrationStateRunning,
+					SchemaRevision: 3,
+				},
+			},
+		},
+		{
+			name: "done and deleted migrations",
+			events: []eventstore.Event{
+				schema.NewMigrationStartedEvent(context.Background(), agg1, 2),
+				schema.NewMigrationDoneEvent(context.Background(), agg1),
+				schema.NewMigrationStartedEvent(context.Background(), agg2, 2),
+				schema.NewDeletedEvent(context.Background(), agg2, "type"),
+			},
+			want: map[string]*UserSchemaMigration{
+				"schema1": {
+					SchemaID:       "schema1",
+					ResourceOwner:  "instance",
+					State:          UserSchemaMigrationStateDone,
+					SchemaRevision: 2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := newUserSchemaMigrationsReadModel("")
+			rm.AppendEvents(tt.events...)
+			require.NoError(t, rm.Reduce())
+			assert.Equal(t, tt.want, rm.Migrations)
+		})
+	}
+}
+
+func Test_schemaUserIDsReadModel_idsAfter(t *testing.T) {
+	rm := newSchemaUserIDsReadModel("schema")
+	for _, id := range []string{"user3", "user1", "user4", "user2"} {
+		rm.userIDs[id] = struct{}{}
+	}
+	tests := []struct {
+		name        string
+		afterUserID string
+		limit       int
+		want        []string
+	}{
+		{
+			name: "all",
+			want: []string{"user1", "user2", "user3", "user4"},
+		},
+		{
+			name:  "first batch",
+			limit: 2,
+			want:  []string{"user1", "user2"},
+		},
+		{
+			name:        "next batch",
+			afterUserID: "user2",
+			limit:       2,
+			want:        []string{"user3", "user4"},
+		},
+		{
+			name:        "no more users",
+			afterUserID: "user4",
+			limit:       2,
+			want:        []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rm.idsAfter(tt.afterUserID, tt.limit))
+		})
+	}
+}
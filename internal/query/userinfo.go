@@ -28,6 +28,8 @@ var oidcUserInfoTriggerHandlers = sync.OnceValue(func() []*handler.Handler {
 		projection.UserGrantProjection,
 		projection.OrgProjection,
 		projection.ProjectProjection,
+		projection.ProjectRoleProjection,
+		projection.GroupProjection,
 	}
 })
 
@@ -92,6 +94,16 @@ type OIDCUserInfo struct {
 	Metadata   []UserMetadata `json:"metadata,omitempty"`
 	Org        *UserInfoOrg   `json:"org,omitempty"`
 	UserGrants []UserGrant    `json:"user_grants,omitempty"`
+	// Groups the user is member of.
+	// Roles granted to the groups are part of the UserGrants.
+	// The roles of the UserGrants include the roles included by composite roles.
+	Groups []UserInfoGroup `json:"groups,omitempty"`
+}
+
+type UserInfoGroup struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	ResourceOwner string `json:"resource_owner,omitempty"`
 }
 
 type UserInfoOrg struct {
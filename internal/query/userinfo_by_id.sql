@@ -1,4 +1,4 @@
-with usr as (
+with recursive usr as (
 	select u.id, u.creation_date, u.change_date, u.sequence, u.state, u.resource_owner, u.username, n.login_name as preferred_login_name
 	from projections.users13 u
 	left join projections.login_names3 n on u.id = n.user_id and u.instance_id = n.instance_id
@@ -31,17 +31,76 @@ metadata as (
 		and instance_id = $2
 	) r
 ),
--- get all user grants, needed for the orgs query
-user_grants as (
+-- find the groups the user is member of
+user_groups as (
+	select g.id, g.name, g.resource_owner
+	from projections.groups_members m
+	join projections.groups g on g.id = m.group_id and g.instance_id = m.instance_id
+	where m.user_id = $1
+	and m.instance_id = $2
+),
+groups as (
+	select json_agg(row_to_json(r)) as groups from (
+		select id, name, resource_owner from user_groups
+	) r
+),
+-- project grants outside of their validity, the user grants on them are not effective
+expired_project_grants as (
+	select grant_id
+	from projections.project_grants5
+	where instance_id = $2
+	and project_id = any($3)
+	and (valid_from > now() or valid_until <= now())
+),
+-- get all user grants with the roles granted directly
+direct_user_grants as (
 	select id, grant_id, state, creation_date, change_date, sequence, user_id, roles, resource_owner, project_id
-	from projections.user_grants5
+	from projections.user_grants6
 	where user_id = $1
 	and instance_id = $2
 	and project_id = any($3)
     and state = 1
+	-- only grants inside of their validity are effective
+	and (valid_from is null or valid_from <= now())
+	and (valid_until is null or valid_until > now())
+	and not exists (select 1 from expired_project_grants pg where pg.grant_id = projections.user_grants6.grant_id)
 	{{ if . -}}
 	and resource_owner = any($4)
 	{{- end }}
+	union all
+	-- the grants of the user's groups are effective as if they were granted to the user
+	select gg.id, gg.grant_id, 1 as state, gg.creation_date, gg.change_date, gg.sequence, $1 as user_id, gg.roles, gg.resource_owner, gg.project_id
+	from projections.groups_grants gg
+	where gg.group_id in (select id from user_groups)
+	and gg.instance_id = $2
+	and gg.project_id = any($3)
+	and not exists (select 1 from expired_project_grants pg where pg.grant_id = gg.grant_id)
+	{{ if . -}}
+	and gg.resource_owner = any($4)
+	{{- end }}
+),
+-- composite roles include the roles of their included roles, each role includes itself
+included_roles as (
+	select project_id, role_key, role_key as included_key
+	from projections.project_roles5
+	where instance_id = $2
+	and project_id = any($3)
+	union
+	select i.project_id, i.role_key, r.included_key
+	from included_roles i
+	join projections.project_roles5 p on p.instance_id = $2 and p.project_id = i.project_id and p.role_key = i.included_key
+	cross join unnest(p.included_roles) as r(included_key)
+),
+-- get all user grants with the expanded roles, needed for the orgs query
+user_grants as (
+	select g.id, g.grant_id, g.state, g.creation_date, g.change_date, g.sequence, g.user_id,
+		array(
+			select unnest(g.roles)
+			union
+			select i.included_key from included_roles i where i.project_id = g.project_id and i.role_key = any(g.roles)
+		) as roles,
+		g.resource_owner, g.project_id
+	from direct_user_grants g
 ),
 -- filter all orgs we are interested in.
 orgs as (
@@ -87,5 +146,6 @@ select json_build_object(
 	),
 	'org', (select organization from user_org),
 	'metadata', (select metadata from metadata),
-	'user_grants', (select grants from grants)
+	'user_grants', (select grants from grants),
+	'groups', (select groups from groups)
 );
@@ -28,6 +28,8 @@ var (
 	testdataUserInfoHuman string
 	//go:embed testdata/userinfo_human_grants.json
 	testdataUserInfoHumanGrants string
+	//go:embed testdata/userinfo_human_groups.json
+	testdataUserInfoHumanGroups string
 	//go:embed testdata/userinfo_machine.json
 	testdataUserInfoMachine string
 
@@ -376,6 +378,51 @@ func TestQueries_GetOIDCUserInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "human with groups",
+			args: args{
+				userID: "231965491734773762",
+			},
+			mock: mockQuery(regexp.QuoteMeta(oidcUserInfoQuery), []string{"json_build_object"}, []driver.Value{testdataUserInfoHumanGroups}, "231965491734773762", "instanceID", database.TextArray[string](nil)),
+			want: &OIDCUserInfo{
+				User: &User{
+					ID:                 "231965491734773762",
+					CreationDate:       time.Date(2023, time.September, 15, 6, 10, 7, 434142000, timeLocation),
+					ChangeDate:         time.Date(2023, time.November, 14, 13, 27, 2, 72318000, timeLocation),
+					Sequence:           1148,
+					State:              1,
+					ResourceOwner:      "231848297847848962",
+					Username:           "tim+tesmail@zitadel.com",
+					PreferredLoginName: "tim+tesmail@zitadel.com@demo.localhost",
+					Human: &Human{
+						FirstName:         "Tim",
+						LastName:          "Mohlmann",
+						NickName:          "muhlemmer",
+						DisplayName:       "Tim Mohlmann",
+						AvatarKey:         "",
+						PreferredLanguage: language.English,
+						Gender:            domain.GenderMale,
+						Email:             "tim+tesmail@zitadel.com",
+						IsEmailVerified:   true,
+						Phone:             "+40123456789",
+						IsPhoneVerified:   false,
+					},
+					Machine: nil,
+				},
+				Org: &UserInfoOrg{
+					ID:            "231848297847848962",
+					Name:          "demo",
+					PrimaryDomain: "demo.localhost",
+				},
+				Groups: []UserInfoGroup{
+					{
+						ID:            "250000000000000001",
+						Name:          "developers",
+						ResourceOwner: "231848297847848962",
+					},
+				},
+			},
+		},
 		{
 			name: "machine with metadata",
 			args: args{
@@ -2,6 +2,7 @@ package query
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -12,11 +13,60 @@ func (q *Queries) MyZitadelPermissions(ctx context.Context, orgID, userID string
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	memberships, err := q.UserMembershipsOfOrg(ctx, orgID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	roleMappings := q.zitadelRoles
+	if hasUnknownRole(memberships, roleMappings) {
+		customRoles, err := q.CustomRoleMappingsOfOrg(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		roleMappings = slices.Concat(roleMappings, customRoles)
+	}
+	permissions := &domain.Permissions{Permissions: []string{}}
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			permissions = mapRoleToPermission(roleMappings, permissions, membership, role)
+		}
+	}
+	return permissions, nil
+}
+
+// hasUnknownRole checks if any membership has a role which is not configured statically,
+// in which case it might be a custom role.
+func hasUnknownRole(memberships []*Membership, roleMappings []authz.RoleMapping) bool {
+	for _, membership := range memberships {
+		for _, role := range membership.Roles {
+			if !slices.ContainsFunc(roleMappings, func(mapping authz.RoleMapping) bool {
+				return mapping.Role == role
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// UserMembershipsOfOrg returns the memberships of the user which are relevant for the organization.
+// Besides the memberships of the organization itself, the instance and the project grants to the organization,
+// the organization memberships of all ancestors are returned, so administrators of a parent organization
+// are able to manage its children.
+func (q *Queries) UserMembershipsOfOrg(ctx context.Context, orgID, userID string, shouldTriggerBulk bool) (_ []*Membership, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ancestorIDs, err := q.OrgAncestorIDs(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
 	userIDQuery, err := NewMembershipUserIDQuery(userID)
 	if err != nil {
 		return nil, err
 	}
-	orgIDsQuery, err := NewMembershipResourceOwnersSearchQuery(orgID, authz.GetInstance(ctx).InstanceID())
+	orgIDsQuery, err := NewMembershipResourceOwnersSearchQuery(append([]string{orgID, instanceID}, ancestorIDs...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -26,30 +76,48 @@ func (q *Queries) MyZitadelPermissions(ctx context.Context, orgID, userID string
 	}
 	memberships, err := q.Memberships(ctx, &MembershipSearchQuery{
 		Queries: []SearchQuery{userIDQuery, Or(orgIDsQuery, grantedOrgIDQuery)},
-	}, false)
+	}, shouldTriggerBulk)
 	if err != nil {
 		return nil, err
 	}
-	permissions := &domain.Permissions{Permissions: []string{}}
-	for _, membership := range memberships.Memberships {
-		for _, role := range membership.Roles {
-			permissions = q.mapRoleToPermission(permissions, membership, role)
+	if len(ancestorIDs) == 0 {
+		return memberships.Memberships, nil
+	}
+	return filterInheritedMemberships(memberships.Memberships, orgID, instanceID), nil
+}
+
+// filterInheritedMemberships removes the project and project grant memberships of the ancestors,
+// only their organization memberships are inherited.
+func filterInheritedMemberships(memberships []*Membership, orgID, instanceID string) []*Membership {
+	filtered := make([]*Membership, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership.Org != nil ||
+			membership.ResourceOwner == orgID ||
+			membership.ResourceOwner == instanceID ||
+			(membership.ProjectGrant != nil && membership.ProjectGrant.GrantedOrgID == orgID) {
+			filtered = append(filtered, membership)
 		}
 	}
-	return permissions, nil
+	return filtered
 }
 
-func (q *Queries) mapRoleToPermission(permissions *domain.Permissions, membership *Membership, role string) *domain.Permissions {
-	for _, mapping := range q.zitadelRoles {
-		if mapping.Role == role {
-			ctxID := ""
-			if membership.Project != nil {
-				ctxID = membership.Project.ProjectID
-			} else if membership.ProjectGrant != nil {
-				ctxID = membership.ProjectGrant.GrantID
-			}
-			permissions.AppendPermissions(ctxID, mapping.Permissions...)
+// mapRoleToPermission appends the permissions of the role.
+// Custom roles of an organization only apply to the memberships of the organization.
+func mapRoleToPermission(roleMappings []authz.RoleMapping, permissions *domain.Permissions, membership *Membership, role string) *domain.Permissions {
+	for _, mapping := range roleMappings {
+		if mapping.Role != role {
+			continue
+		}
+		if mapping.ResourceOwner != "" && (membership.Org == nil || membership.Org.OrgID != mapping.ResourceOwner) {
+			continue
+		}
+		ctxID := ""
+		if membership.Project != nil {
+			ctxID = membership.Project.ProjectID
+		} else if membership.ProjectGrant != nil {
+			ctxID = membership.ProjectGrant.GrantID
 		}
+		permissions.AppendPermissions(ctxID, mapping.Permissions...)
 	}
 	return permissions
 }
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_filterInheritedMemberships(t *testing.T) {
+	orgMembership := &Membership{ResourceOwner: "org", Org: &OrgMembership{OrgID: "org"}}
+	parentOrgMembership := &Membership{ResourceOwner: "parent", Org: &OrgMembership{OrgID: "parent"}}
+	iamMembership := &Membership{ResourceOwner: "instance", IAM: &IAMMembership{IAMID: "instance"}}
+	projectMembership := &Membership{ResourceOwner: "org", Project: &ProjectMembership{ProjectID: "project"}}
+	parentProjectMembership := &Membership{ResourceOwner: "parent", Project: &ProjectMembership{ProjectID: "parent-project"}}
+	grantedProjectGrantMembership := &Membership{ResourceOwner: "parent", ProjectGrant: &ProjectGrantMembership{GrantID: "grant", GrantedOrgID: "org"}}
+	parentProjectGrantMembership := &Membership{ResourceOwner: "parent", ProjectGrant: &ProjectGrantMembership{GrantID: "other-grant", GrantedOrgID: "other"}}
+
+	tests := []struct {
+		name        string
+		memberships []*Membership
+		want        []*Membership
+	}{
+		{
+			name:        "empty",
+			memberships: []*Membership{},
+			want:        []*Membership{},
+		},
+		{
+			name: "own memberships kept",
+			memberships: []*Membership{
+				orgMembership,
+				iamMembership,
+				projectMembership,
+				grantedProjectGrantMembership,
+			},
+			want: []*Membership{
+				orgMembership,
+				iamMembership,
+				projectMembership,
+				grantedProjectGrantMembership,
+			},
+		},
+		{
+			name: "only org memberships of ancestors inherited",
+			memberships: []*Membership{
+				parentOrgMembership,
+				parentProjectMembership,
+				parentProjectGrantMembership,
+			},
+			want: []*Membership{
+				parentOrgMembership,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filterInheritedMemberships(tt.memberships, "org", "instance"))
+		})
+	}
+}
+
+func Test_mapRoleToPermission(t *testing.T) {
+	roleMappings := []authz.RoleMapping{
+		{Role: "ORG_OWNER", Permissions: []string{"org.read", "user.write"}},
+		{Role: "ORG_HELPDESK", Permissions: []string{"user.credential.write"}, ResourceOwner: "org"},
+		{Role: "IAM_HELPDESK", Permissions: []string{"user.credential.write"}},
+	}
+	tests := []struct {
+		name       string
+		membership *Membership
+		role       string
+		want       []string
+	}{
+		{
+			name:       "static role",
+			membership: &Membership{Org: &OrgMembership{OrgID: "org"}},
+			role:       "ORG_OWNER",
+			want:       []string{"org.read", "user.write"},
+		},
+		{
+			name:       "custom role of the organization",
+			membership: &Membership{Org: &OrgMembership{OrgID: "org"}},
+			role:       "ORG_HELPDESK",
+			want:       []string{"user.credential.write"},
+		},
+		{
+			name:       "custom role of other organization",
+			membership: &Membership{Org: &OrgMembership{OrgID: "other"}},
+			role:       "ORG_HELPDESK",
+			want:       []string{},
+		},
+		{
+			name:       "custom role of the instance",
+			membership: &Membership{IAM: &IAMMembership{IAMID: "instance"}},
+			role:       "IAM_HELPDESK",
+			want:       []string{"user.credential.write"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapRoleToPermission(roleMappings, &domain.Permissions{Permissions: []string{}}, tt.membership, tt.role)
+			assert.Equal(t, tt.want, got.Permissions)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniquePendingAccessRequest = "pending_access_request"
+	eventTypePrefix            = AggregateType + "."
+	AddedType                  = eventTypePrefix + "added"
+	ApprovedType               = eventTypePrefix + "approved"
+	DeniedType                 = eventTypePrefix + "denied"
+	WithdrawnType              = eventTypePrefix + "withdrawn"
+)
+
+// A user can only have one pending request per project (grant).
+func NewAddPendingUniqueConstraint(userID, projectID, projectGrantID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniquePendingAccessRequest,
+		fmt.Sprintf("%s:%s:%s", userID, projectID, projectGrantID),
+		"Errors.AccessRequest.AlreadyPending")
+}
+
+func NewRemovePendingUniqueConstraint(userID, projectID, projectGrantID string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniquePendingAccessRequest,
+		fmt.Sprintf("%s:%s:%s", userID, projectID, projectGrantID),
+	)
+}
+
+// AddedEvent is pushed when a user requests roles on a project or granted project.
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID         string   `json:"userId"`
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"grantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys"`
+	Reason         string   `json:"reason,omitempty"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddPendingUniqueConstraint(e.UserID, e.ProjectID, e.ProjectGrantID)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	projectGrantID string,
+	roleKeys []string,
+	reason string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+		UserID:         userID,
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+		RoleKeys:       roleKeys,
+		Reason:         reason,
+	}
+}
+
+// ApprovedEvent is pushed together with the user grant created for the request.
+type ApprovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserGrantID string `json:"userGrantId"`
+	Comment     string `json:"comment,omitempty"`
+
+	userID         string
+	projectID      string
+	projectGrantID string
+}
+
+func (e *ApprovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *ApprovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApprovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemovePendingUniqueConstraint(e.userID, e.projectID, e.projectGrantID)}
+}
+
+func NewApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	projectGrantID,
+	userGrantID,
+	comment string,
+) *ApprovedEvent {
+	return &ApprovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApprovedType,
+		),
+		UserGrantID:    userGrantID,
+		Comment:        comment,
+		userID:         userID,
+		projectID:      projectID,
+		projectGrantID: projectGrantID,
+	}
+}
+
+type DeniedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Comment string `json:"comment,omitempty"`
+
+	userID         string
+	projectID      string
+	projectGrantID string
+}
+
+func (e *DeniedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *DeniedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *DeniedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemovePendingUniqueConstraint(e.userID, e.projectID, e.projectGrantID)}
+}
+
+func NewDeniedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	projectGrantID,
+	comment string,
+) *DeniedEvent {
+	return &DeniedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeniedType,
+		),
+		Comment:        comment,
+		userID:         userID,
+		projectID:      projectID,
+		projectGrantID: projectGrantID,
+	}
+}
+
+// WithdrawnEvent is pushed when the user cancels the pending request.
+type WithdrawnEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	userID         string
+	projectID      string
+	projectGrantID string
+}
+
+func (e *WithdrawnEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *WithdrawnEvent) Payload() interface{} {
+	return e
+}
+
+func (e *WithdrawnEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemovePendingUniqueConstraint(e.userID, e.projectID, e.projectGrantID)}
+}
+
+func NewWithdrawnEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID,
+	projectGrantID string,
+) *WithdrawnEvent {
+	return &WithdrawnEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			WithdrawnType,
+		),
+		userID:         userID,
+		projectID:      projectID,
+		projectGrantID: projectGrantID,
+	}
+}
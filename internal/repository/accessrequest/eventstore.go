@@ -0,0 +1,12 @@
+package accessrequest
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, AddedType, eventstore.GenericEventMapper[AddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, ApprovedType, eventstore.GenericEventMapper[ApprovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, DeniedType, eventstore.GenericEventMapper[DeniedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, WithdrawnType, eventstore.GenericEventMapper[WithdrawnEvent])
+}
@@ -0,0 +1,142 @@
+package customrole
+
+import (
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueCustomRole = "custom_role"
+	AddedEventType   = "custom_role.added"
+	ChangedEventType = "custom_role.changed"
+	RemovedEventType = "custom_role.removed"
+)
+
+func NewAddCustomRoleUniqueConstraint(aggregateID, key string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueCustomRole,
+		fmt.Sprintf("%s:%s", aggregateID, key),
+		"Errors.CustomRole.AlreadyExists")
+}
+
+func NewRemoveCustomRoleUniqueConstraint(aggregateID, key string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueCustomRole,
+		fmt.Sprintf("%s:%s", aggregateID, key),
+	)
+}
+
+// AddedEvent defines a role for the members of the aggregate (instance or organization),
+// which grants the listed permissions.
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key         string   `json:"key"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+func (e *AddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *AddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddCustomRoleUniqueConstraint(e.Aggregate().ID, e.Key)}
+}
+
+func NewAddedEvent(
+	base *eventstore.BaseEvent,
+	key,
+	displayName string,
+	permissions []string,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent:   *base,
+		Key:         key,
+		DisplayName: displayName,
+		Permissions: permissions,
+	}
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key         string   `json:"key"`
+	DisplayName *string  `json:"displayName,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+func (e *ChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *ChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewChangedEvent(
+	base *eventstore.BaseEvent,
+	key string,
+	changes []Changes,
+) *ChangedEvent {
+	changedEvent := &ChangedEvent{
+		BaseEvent: *base,
+		Key:       key,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent
+}
+
+type Changes func(event *ChangedEvent)
+
+func ChangeDisplayName(displayName string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.DisplayName = &displayName
+	}
+}
+
+func ChangePermissions(permissions []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Permissions = permissions
+	}
+}
+
+// RemovedEvent removes the role. Members keep the role assigned, but it no longer grants any permission.
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Key string `json:"key"`
+}
+
+func (e *RemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *RemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveCustomRoleUniqueConstraint(e.Aggregate().ID, e.Key)}
+}
+
+func NewRemovedEvent(
+	base *eventstore.BaseEvent,
+	key string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *base,
+		Key:       key,
+	}
+}
@@ -0,0 +1,26 @@
+package group
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueGroupName = "group_name"
+	DuplicateGroup  = "Errors.Group.AlreadyExists"
+)
+
+// group names are unique per organization
+func NewAddNameUniqueConstraint(name, resourceOwner string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueGroupName,
+		name+resourceOwner,
+		DuplicateGroup,
+	)
+}
+
+func NewRemoveNameUniqueConstraint(name, resourceOwner string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueGroupName,
+		name+resourceOwner,
+	)
+}
@@ -0,0 +1,14 @@
+package group
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, ChangedEventType, eventstore.GenericEventMapper[ChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MemberAddedEventType, eventstore.GenericEventMapper[MemberAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, eventstore.GenericEventMapper[MemberRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantAddedEventType, eventstore.GenericEventMapper[GrantAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantChangedEventType, eventstore.GenericEventMapper[GrantChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantRemovedEventType, eventstore.GenericEventMapper[GrantRemovedEvent])
+}
@@ -0,0 +1,123 @@
+package group
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	grantEventTypePrefix                       = eventTypePrefix + "grant."
+	GrantAddedEventType   eventstore.EventType = grantEventTypePrefix + "added"
+	GrantChangedEventType eventstore.EventType = grantEventTypePrefix + "changed"
+	GrantRemovedEventType eventstore.EventType = grantEventTypePrefix + "removed"
+)
+
+// GrantAddedEvent grants roles of a project (or a project grant) to the group.
+// The roles are effective for all members of the group.
+type GrantAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID        string   `json:"grantId"`
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys"`
+}
+
+func (e *GrantAddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantAddedEvent) Payload() any {
+	return e
+}
+
+func (e *GrantAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewGrantAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	grantID,
+	projectID,
+	projectGrantID string,
+	roleKeys []string,
+) *GrantAddedEvent {
+	return &GrantAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantAddedEventType,
+		),
+		GrantID:        grantID,
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+		RoleKeys:       roleKeys,
+	}
+}
+
+type GrantChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID  string   `json:"grantId"`
+	RoleKeys []string `json:"roleKeys"`
+}
+
+func (e *GrantChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantChangedEvent) Payload() any {
+	return e
+}
+
+func (e *GrantChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewGrantChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	grantID string,
+	roleKeys []string,
+) *GrantChangedEvent {
+	return &GrantChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantChangedEventType,
+		),
+		GrantID:  grantID,
+		RoleKeys: roleKeys,
+	}
+}
+
+type GrantRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID string `json:"grantId"`
+}
+
+func (e *GrantRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantRemovedEvent) Payload() any {
+	return e
+}
+
+func (e *GrantRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewGrantRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, grantID string) *GrantRemovedEvent {
+	return &GrantRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantRemovedEventType,
+		),
+		GrantID: grantID,
+	}
+}
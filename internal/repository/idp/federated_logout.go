@@ -0,0 +1,46 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type FederatedLogoutSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+func NewFederatedLogoutSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	enabled bool,
+) *FederatedLogoutSetEvent {
+	return &FederatedLogoutSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Enabled:   enabled,
+	}
+}
+
+func (e *FederatedLogoutSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *FederatedLogoutSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func FederatedLogoutSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &FederatedLogoutSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Fl0s2", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,55 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type GroupMapping struct {
+	Claim          string   `json:"claim"`
+	Group          string   `json:"group"`
+	ProjectID      string   `json:"projectId,omitempty"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys,omitempty"`
+	OrgMemberRoles []string `json:"orgMemberRoles,omitempty"`
+}
+
+type GroupMappingsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID       string         `json:"id"`
+	Mappings []GroupMapping `json:"mappings"`
+}
+
+func NewGroupMappingsSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	mappings []GroupMapping,
+) *GroupMappingsSetEvent {
+	return &GroupMappingsSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Mappings:  mappings,
+	}
+}
+
+func (e *GroupMappingsSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *GroupMappingsSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func GroupMappingsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &GroupMappingsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Gm0s2", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
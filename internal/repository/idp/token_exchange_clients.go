@@ -0,0 +1,46 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type TokenExchangeClientsSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID        string   `json:"id"`
+	ClientIDs []string `json:"clientIds,omitempty"`
+}
+
+func NewTokenExchangeClientsSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	clientIDs []string,
+) *TokenExchangeClientsSetEvent {
+	return &TokenExchangeClientsSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		ClientIDs: clientIDs,
+	}
+}
+
+func (e *TokenExchangeClientsSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *TokenExchangeClientsSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func TokenExchangeClientsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &TokenExchangeClientsSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Tx0s2", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
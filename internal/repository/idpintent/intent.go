@@ -3,6 +3,7 @@ package idpintent
 import (
 	"context"
 	"net/url"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -76,6 +77,17 @@ type SucceededEvent struct {
 
 	IDPAccessToken *crypto.CryptoValue `json:"idpAccessToken,omitempty"`
 	IDPIDToken     string              `json:"idpIdToken,omitempty"`
+
+	Tokens *IDPTokens `json:"tokens,omitempty"`
+}
+
+// IDPTokens are the (encrypted) tokens of the IdP,
+// which are stored on the link of the user, as soon as the user is known or linked.
+type IDPTokens struct {
+	AccessToken  *crypto.CryptoValue `json:"accessToken"`
+	TokenType    string              `json:"tokenType,omitempty"`
+	RefreshToken *crypto.CryptoValue `json:"refreshToken,omitempty"`
+	Expiry       time.Time           `json:"expiry,omitempty"`
 }
 
 func NewSucceededEvent(
@@ -87,6 +99,7 @@ func NewSucceededEvent(
 	userID string,
 	idpAccessToken *crypto.CryptoValue,
 	idpIDToken string,
+	tokens *IDPTokens,
 ) *SucceededEvent {
 	return &SucceededEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -100,6 +113,7 @@ func NewSucceededEvent(
 		UserID:         userID,
 		IDPAccessToken: idpAccessToken,
 		IDPIDToken:     idpIDToken,
+		Tokens:         tokens,
 	}
 }
 
@@ -20,11 +20,22 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPAddedEventType, eventstore.GenericEventMapper[SMTPConfigHTTPAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPChangedEventType, eventstore.GenericEventMapper[SMTPConfigHTTPChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, eventstore.GenericEventMapper[SMTPConfigRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigXOAuth2SetEventType, eventstore.GenericEventMapper[SMTPConfigXOAuth2SetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigXOAuth2RemovedEventType, eventstore.GenericEventMapper[SMTPConfigXOAuth2RemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDKIMSetEventType, eventstore.GenericEventMapper[SMTPConfigDKIMSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDKIMRemovedEventType, eventstore.GenericEventMapper[SMTPConfigDKIMRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigDeliverySecretSetEventType, eventstore.GenericEventMapper[SMTPConfigDeliverySecretSetEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioAddedEventType, eventstore.GenericEventMapper[SMSConfigTwilioAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioChangedEventType, eventstore.GenericEventMapper[SMSConfigTwilioChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioTokenChangedEventType, eventstore.GenericEventMapper[SMSConfigTwilioTokenChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigHTTPAddedEventType, eventstore.GenericEventMapper[SMSConfigHTTPAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigHTTPChangedEventType, eventstore.GenericEventMapper[SMSConfigHTTPChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigVonageAddedEventType, eventstore.GenericEventMapper[SMSConfigVonageAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigVonageChangedEventType, eventstore.GenericEventMapper[SMSConfigVonageChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigVonageAPISecretChangedEventType, eventstore.GenericEventMapper[SMSConfigVonageAPISecretChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigMessageBirdAddedEventType, eventstore.GenericEventMapper[SMSConfigMessageBirdAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigMessageBirdChangedEventType, eventstore.GenericEventMapper[SMSConfigMessageBirdChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigMessageBirdAccessKeyChangedEventType, eventstore.GenericEventMapper[SMSConfigMessageBirdAccessKeyChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioActivatedEventType, eventstore.GenericEventMapper[SMSConfigTwilioActivatedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioDeactivatedEventType, eventstore.GenericEventMapper[SMSConfigTwilioDeactivatedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioRemovedEventType, eventstore.GenericEventMapper[SMSConfigTwilioRemovedEvent])
@@ -64,12 +75,17 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, PasswordComplexityPolicyChangedEventType, PasswordComplexityPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LockoutPolicyAddedEventType, LockoutPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LockoutPolicyChangedEventType, LockoutPolicyChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InactivityPolicyAddedEventType, InactivityPolicyAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InactivityPolicyChangedEventType, InactivityPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, PrivacyPolicyAddedEventType, PrivacyPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, PrivacyPolicyChangedEventType, PrivacyPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberAddedEventType, MemberAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberChangedEventType, MemberChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, MemberRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberCascadeRemovedEventType, MemberCascadeRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleAddedEventType, eventstore.GenericEventMapper[CustomRoleAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleChangedEventType, eventstore.GenericEventMapper[CustomRoleChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleRemovedEventType, eventstore.GenericEventMapper[CustomRoleRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPConfigAddedEventType, IDPConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPConfigChangedEventType, IDPConfigChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPConfigRemovedEventType, IDPConfigRemovedEventMapper)
@@ -106,6 +122,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPGroupMappingsSetEventType, IDPGroupMappingsSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPFederatedLogoutSetEventType, IDPFederatedLogoutSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPTokenExchangeClientsSetEventType, IDPTokenExchangeClientsSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderAddedEventType, IdentityProviderAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderRemovedEventType, IdentityProviderRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderCascadeRemovedEventType, IdentityProviderCascadeRemovedEventMapper)
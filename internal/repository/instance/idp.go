@@ -38,6 +38,9 @@ const (
 	SAMLIDPAddedEventType               eventstore.EventType = "instance.idp.saml.added"
 	SAMLIDPChangedEventType             eventstore.EventType = "instance.idp.saml.changed"
 	IDPRemovedEventType                 eventstore.EventType = "instance.idp.removed"
+	IDPGroupMappingsSetEventType        eventstore.EventType = "instance.idp.group_mappings.set"
+	IDPFederatedLogoutSetEventType      eventstore.EventType = "instance.idp.federated_logout.set"
+	IDPTokenExchangeClientsSetEventType eventstore.EventType = "instance.idp.token_exchange_clients.set"
 )
 
 type OAuthIDPAddedEvent struct {
@@ -1119,3 +1122,111 @@ func IDPRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 
 	return &IDPRemovedEvent{RemovedEvent: *e.(*idp.RemovedEvent)}, nil
 }
+
+type IDPGroupMappingsSetEvent struct {
+	idp.GroupMappingsSetEvent
+}
+
+func NewIDPGroupMappingsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	mappings []idp.GroupMapping,
+) *IDPGroupMappingsSetEvent {
+	return &IDPGroupMappingsSetEvent{
+		GroupMappingsSetEvent: *idp.NewGroupMappingsSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPGroupMappingsSetEventType,
+			),
+			id,
+			mappings,
+		),
+	}
+}
+
+func (e *IDPGroupMappingsSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPGroupMappingsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.GroupMappingsSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPGroupMappingsSetEvent{GroupMappingsSetEvent: *e.(*idp.GroupMappingsSetEvent)}, nil
+}
+
+type IDPFederatedLogoutSetEvent struct {
+	idp.FederatedLogoutSetEvent
+}
+
+func NewIDPFederatedLogoutSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	enabled bool,
+) *IDPFederatedLogoutSetEvent {
+	return &IDPFederatedLogoutSetEvent{
+		FederatedLogoutSetEvent: *idp.NewFederatedLogoutSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPFederatedLogoutSetEventType,
+			),
+			id,
+			enabled,
+		),
+	}
+}
+
+func (e *IDPFederatedLogoutSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPFederatedLogoutSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.FederatedLogoutSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPFederatedLogoutSetEvent{FederatedLogoutSetEvent: *e.(*idp.FederatedLogoutSetEvent)}, nil
+}
+
+type IDPTokenExchangeClientsSetEvent struct {
+	idp.TokenExchangeClientsSetEvent
+}
+
+func NewIDPTokenExchangeClientsSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	clientIDs []string,
+) *IDPTokenExchangeClientsSetEvent {
+	return &IDPTokenExchangeClientsSetEvent{
+		TokenExchangeClientsSetEvent: *idp.NewTokenExchangeClientsSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPTokenExchangeClientsSetEventType,
+			),
+			id,
+			clientIDs,
+		),
+	}
+}
+
+func (e *IDPTokenExchangeClientsSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPTokenExchangeClientsSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.TokenExchangeClientsSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPTokenExchangeClientsSetEvent{TokenExchangeClientsSetEvent: *e.(*idp.TokenExchangeClientsSetEvent)}, nil
+}
@@ -0,0 +1,77 @@
+package instance
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	InactivityPolicyAddedEventType   = instanceEventTypePrefix + policy.InactivityPolicyAddedEventType
+	InactivityPolicyChangedEventType = instanceEventTypePrefix + policy.InactivityPolicyChangedEventType
+)
+
+type InactivityPolicyAddedEvent struct {
+	policy.InactivityPolicyAddedEvent
+}
+
+func NewInactivityPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	inactivityPeriod,
+	warningPeriod time.Duration,
+	dryRun bool,
+) *InactivityPolicyAddedEvent {
+	return &InactivityPolicyAddedEvent{
+		InactivityPolicyAddedEvent: *policy.NewInactivityPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				InactivityPolicyAddedEventType),
+			inactivityPeriod,
+			warningPeriod,
+			dryRun),
+	}
+}
+
+func InactivityPolicyAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.InactivityPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InactivityPolicyAddedEvent{InactivityPolicyAddedEvent: *e.(*policy.InactivityPolicyAddedEvent)}, nil
+}
+
+type InactivityPolicyChangedEvent struct {
+	policy.InactivityPolicyChangedEvent
+}
+
+func NewInactivityPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.InactivityPolicyChanges,
+) (*InactivityPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewInactivityPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InactivityPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &InactivityPolicyChangedEvent{InactivityPolicyChangedEvent: *changedEvent}, nil
+}
+
+func InactivityPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.InactivityPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InactivityPolicyChangedEvent{InactivityPolicyChangedEvent: *e.(*policy.InactivityPolicyChangedEvent)}, nil
+}
@@ -12,6 +12,8 @@ const (
 	smsConfigPrefix                      = "sms.config"
 	smsConfigTwilioPrefix                = "twilio."
 	smsConfigHTTPPrefix                  = "http."
+	smsConfigVonagePrefix                = "vonage."
+	smsConfigMessageBirdPrefix           = "messagebird."
 	SMSConfigTwilioAddedEventType        = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "added"
 	SMSConfigTwilioChangedEventType      = instanceEventTypePrefix + smsConfigPrefix + smsConfigTwilioPrefix + "changed"
 	SMSConfigHTTPAddedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigHTTPPrefix + "added"
@@ -23,6 +25,13 @@ const (
 	SMSConfigActivatedEventType          = instanceEventTypePrefix + smsConfigPrefix + "activated"
 	SMSConfigDeactivatedEventType        = instanceEventTypePrefix + smsConfigPrefix + "deactivated"
 	SMSConfigRemovedEventType            = instanceEventTypePrefix + smsConfigPrefix + "removed"
+
+	SMSConfigVonageAddedEventType                 = instanceEventTypePrefix + smsConfigPrefix + smsConfigVonagePrefix + "added"
+	SMSConfigVonageChangedEventType               = instanceEventTypePrefix + smsConfigPrefix + smsConfigVonagePrefix + "changed"
+	SMSConfigVonageAPISecretChangedEventType      = instanceEventTypePrefix + smsConfigPrefix + smsConfigVonagePrefix + "apisecret.changed"
+	SMSConfigMessageBirdAddedEventType            = instanceEventTypePrefix + smsConfigPrefix + smsConfigMessageBirdPrefix + "added"
+	SMSConfigMessageBirdChangedEventType          = instanceEventTypePrefix + smsConfigPrefix + smsConfigMessageBirdPrefix + "changed"
+	SMSConfigMessageBirdAccessKeyChangedEventType = instanceEventTypePrefix + smsConfigPrefix + smsConfigMessageBirdPrefix + "accesskey.changed"
 )
 
 type SMSConfigTwilioAddedEvent struct {
@@ -186,6 +195,11 @@ type SMSConfigHTTPAddedEvent struct {
 	ID          string `json:"id,omitempty"`
 	Description string `json:"description,omitempty"`
 	Endpoint    string `json:"endpoint,omitempty"`
+	// Template renders the request body, the JSON payload is sent if empty
+	Template    string `json:"template,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	// Headers are the encrypted JSON encoded headers of the request, as they might contain credentials
+	Headers *crypto.CryptoValue `json:"headers,omitempty"`
 }
 
 func NewSMSConfigHTTPAddedEvent(
@@ -193,7 +207,10 @@ func NewSMSConfigHTTPAddedEvent(
 	aggregate *eventstore.Aggregate,
 	id,
 	description,
-	endpoint string,
+	endpoint,
+	template,
+	contentType string,
+	headers *crypto.CryptoValue,
 ) *SMSConfigHTTPAddedEvent {
 	return &SMSConfigHTTPAddedEvent{
 		BaseEvent: eventstore.NewBaseEventForPush(
@@ -204,6 +221,9 @@ func NewSMSConfigHTTPAddedEvent(
 		ID:          id,
 		Description: description,
 		Endpoint:    endpoint,
+		Template:    template,
+		ContentType: contentType,
+		Headers:     headers,
 	}
 }
 
@@ -222,9 +242,12 @@ func (e *SMSConfigHTTPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstr
 type SMSConfigHTTPChangedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 
-	ID          string  `json:"id,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Endpoint    *string `json:"endpoint,omitempty"`
+	ID          string              `json:"id,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Endpoint    *string             `json:"endpoint,omitempty"`
+	Template    *string             `json:"template,omitempty"`
+	ContentType *string             `json:"contentType,omitempty"`
+	Headers     *crypto.CryptoValue `json:"headers,omitempty"`
 }
 
 func NewSMSConfigHTTPChangedEvent(
@@ -263,6 +286,24 @@ func ChangeSMSConfigHTTPEndpoint(endpoint string) func(event *SMSConfigHTTPChang
 	}
 }
 
+func ChangeSMSConfigHTTPTemplate(template string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Template = &template
+	}
+}
+
+func ChangeSMSConfigHTTPContentType(contentType string) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.ContentType = &contentType
+	}
+}
+
+func ChangeSMSConfigHTTPHeaders(headers *crypto.CryptoValue) func(event *SMSConfigHTTPChangedEvent) {
+	return func(e *SMSConfigHTTPChangedEvent) {
+		e.Headers = headers
+	}
+}
+
 func (e *SMSConfigHTTPChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
 	e.BaseEvent = event
 }
@@ -275,6 +316,306 @@ func (e *SMSConfigHTTPChangedEvent) UniqueConstraints() []*eventstore.UniqueCons
 	return nil
 }
 
+type SMSConfigVonageAddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id,omitempty"`
+	Description  string              `json:"description,omitempty"`
+	APIKey       string              `json:"apiKey,omitempty"`
+	APISecret    *crypto.CryptoValue `json:"apiSecret,omitempty"`
+	SenderNumber string              `json:"senderNumber,omitempty"`
+	VerifyBrand  string              `json:"verifyBrand,omitempty"`
+}
+
+func NewSMSConfigVonageAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	description,
+	apiKey string,
+	apiSecret *crypto.CryptoValue,
+	senderNumber,
+	verifyBrand string,
+) *SMSConfigVonageAddedEvent {
+	return &SMSConfigVonageAddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigVonageAddedEventType,
+		),
+		ID:           id,
+		Description:  description,
+		APIKey:       apiKey,
+		APISecret:    apiSecret,
+		SenderNumber: senderNumber,
+		VerifyBrand:  verifyBrand,
+	}
+}
+
+func (e *SMSConfigVonageAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigVonageAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigVonageAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMSConfigVonageChangedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID           string  `json:"id,omitempty"`
+	Description  *string `json:"description,omitempty"`
+	APIKey       *string `json:"apiKey,omitempty"`
+	SenderNumber *string `json:"senderNumber,omitempty"`
+	VerifyBrand  *string `json:"verifyBrand,omitempty"`
+}
+
+func NewSMSConfigVonageChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMSConfigVonageChanges,
+) (*SMSConfigVonageChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Vn8e2", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMSConfigVonageChangedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigVonageChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMSConfigVonageChanges func(event *SMSConfigVonageChangedEvent)
+
+func ChangeSMSConfigVonageDescription(description string) func(event *SMSConfigVonageChangedEvent) {
+	return func(e *SMSConfigVonageChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMSConfigVonageAPIKey(apiKey string) func(event *SMSConfigVonageChangedEvent) {
+	return func(e *SMSConfigVonageChangedEvent) {
+		e.APIKey = &apiKey
+	}
+}
+
+func ChangeSMSConfigVonageSenderNumber(senderNumber string) func(event *SMSConfigVonageChangedEvent) {
+	return func(e *SMSConfigVonageChangedEvent) {
+		e.SenderNumber = &senderNumber
+	}
+}
+
+func ChangeSMSConfigVonageVerifyBrand(verifyBrand string) func(event *SMSConfigVonageChangedEvent) {
+	return func(e *SMSConfigVonageChangedEvent) {
+		e.VerifyBrand = &verifyBrand
+	}
+}
+
+func (e *SMSConfigVonageChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigVonageChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigVonageChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMSConfigVonageAPISecretChangedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID        string              `json:"id,omitempty"`
+	APISecret *crypto.CryptoValue `json:"apiSecret,omitempty"`
+}
+
+func NewSMSConfigVonageAPISecretChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	apiSecret *crypto.CryptoValue,
+) *SMSConfigVonageAPISecretChangedEvent {
+	return &SMSConfigVonageAPISecretChangedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigVonageAPISecretChangedEventType,
+		),
+		ID:        id,
+		APISecret: apiSecret,
+	}
+}
+
+func (e *SMSConfigVonageAPISecretChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigVonageAPISecretChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigVonageAPISecretChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMSConfigMessageBirdAddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID          string              `json:"id,omitempty"`
+	Description string              `json:"description,omitempty"`
+	AccessKey   *crypto.CryptoValue `json:"accessKey,omitempty"`
+	Originator  string              `json:"originator,omitempty"`
+	Verify      bool                `json:"verify,omitempty"`
+}
+
+func NewSMSConfigMessageBirdAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	description string,
+	accessKey *crypto.CryptoValue,
+	originator string,
+	verify bool,
+) *SMSConfigMessageBirdAddedEvent {
+	return &SMSConfigMessageBirdAddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigMessageBirdAddedEventType,
+		),
+		ID:          id,
+		Description: description,
+		AccessKey:   accessKey,
+		Originator:  originator,
+		Verify:      verify,
+	}
+}
+
+func (e *SMSConfigMessageBirdAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigMessageBirdAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigMessageBirdAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMSConfigMessageBirdChangedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID          string  `json:"id,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Originator  *string `json:"originator,omitempty"`
+	Verify      *bool   `json:"verify,omitempty"`
+}
+
+func NewSMSConfigMessageBirdChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMSConfigMessageBirdChanges,
+) (*SMSConfigMessageBirdChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Mb8e2", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMSConfigMessageBirdChangedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigMessageBirdChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMSConfigMessageBirdChanges func(event *SMSConfigMessageBirdChangedEvent)
+
+func ChangeSMSConfigMessageBirdDescription(description string) func(event *SMSConfigMessageBirdChangedEvent) {
+	return func(e *SMSConfigMessageBirdChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMSConfigMessageBirdOriginator(originator string) func(event *SMSConfigMessageBirdChangedEvent) {
+	return func(e *SMSConfigMessageBirdChangedEvent) {
+		e.Originator = &originator
+	}
+}
+
+func ChangeSMSConfigMessageBirdVerify(verify bool) func(event *SMSConfigMessageBirdChangedEvent) {
+	return func(e *SMSConfigMessageBirdChangedEvent) {
+		e.Verify = &verify
+	}
+}
+
+func (e *SMSConfigMessageBirdChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigMessageBirdChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigMessageBirdChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMSConfigMessageBirdAccessKeyChangedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID        string              `json:"id,omitempty"`
+	AccessKey *crypto.CryptoValue `json:"accessKey,omitempty"`
+}
+
+func NewSMSConfigMessageBirdAccessKeyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	accessKey *crypto.CryptoValue,
+) *SMSConfigMessageBirdAccessKeyChangedEvent {
+	return &SMSConfigMessageBirdAccessKeyChangedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMSConfigMessageBirdAccessKeyChangedEventType,
+		),
+		ID:        id,
+		AccessKey: accessKey,
+	}
+}
+
+func (e *SMSConfigMessageBirdAccessKeyChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMSConfigMessageBirdAccessKeyChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMSConfigMessageBirdAccessKeyChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
 type SMSConfigTwilioActivatedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 	ID                    string `json:"id,omitempty"`
@@ -9,16 +9,21 @@ import (
 )
 
 const (
-	smtpConfigPrefix                   = "smtp.config."
-	httpConfigPrefix                   = "http."
-	SMTPConfigAddedEventType           = instanceEventTypePrefix + smtpConfigPrefix + "added"
-	SMTPConfigChangedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "changed"
-	SMTPConfigPasswordChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
-	SMTPConfigHTTPAddedEventType       = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "added"
-	SMTPConfigHTTPChangedEventType     = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "changed"
-	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
-	SMTPConfigActivatedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "activated"
-	SMTPConfigDeactivatedEventType     = instanceEventTypePrefix + smtpConfigPrefix + "deactivated"
+	smtpConfigPrefix                     = "smtp.config."
+	httpConfigPrefix                     = "http."
+	SMTPConfigAddedEventType             = instanceEventTypePrefix + smtpConfigPrefix + "added"
+	SMTPConfigChangedEventType           = instanceEventTypePrefix + smtpConfigPrefix + "changed"
+	SMTPConfigPasswordChangedEventType   = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
+	SMTPConfigHTTPAddedEventType         = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "added"
+	SMTPConfigHTTPChangedEventType       = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "changed"
+	SMTPConfigRemovedEventType           = instanceEventTypePrefix + smtpConfigPrefix + "removed"
+	SMTPConfigXOAuth2SetEventType        = instanceEventTypePrefix + smtpConfigPrefix + "xoauth2.set"
+	SMTPConfigXOAuth2RemovedEventType    = instanceEventTypePrefix + smtpConfigPrefix + "xoauth2.removed"
+	SMTPConfigDKIMSetEventType           = instanceEventTypePrefix + smtpConfigPrefix + "dkim.set"
+	SMTPConfigDKIMRemovedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "dkim.removed"
+	SMTPConfigDeliverySecretSetEventType = instanceEventTypePrefix + smtpConfigPrefix + "delivery.secret.set"
+	SMTPConfigActivatedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "activated"
+	SMTPConfigDeactivatedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "deactivated"
 )
 
 type SMTPConfigAddedEvent struct {
@@ -214,6 +219,191 @@ func (e *SMTPConfigPasswordChangedEvent) UniqueConstraints() []*eventstore.Uniqu
 	return nil
 }
 
+type SMTPConfigXOAuth2SetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string              `json:"id,omitempty"`
+	TokenEndpoint         string              `json:"tokenEndpoint,omitempty"`
+	ClientID              string              `json:"clientId,omitempty"`
+	ClientSecret          *crypto.CryptoValue `json:"clientSecret,omitempty"`
+	Scopes                []string            `json:"scopes,omitempty"`
+}
+
+func NewSMTPConfigXOAuth2SetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	tokenEndpoint,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+) *SMTPConfigXOAuth2SetEvent {
+	return &SMTPConfigXOAuth2SetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigXOAuth2SetEventType,
+		),
+		ID:            id,
+		TokenEndpoint: tokenEndpoint,
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		Scopes:        scopes,
+	}
+}
+
+func (e *SMTPConfigXOAuth2SetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigXOAuth2SetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigXOAuth2SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMTPConfigXOAuth2RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string `json:"id,omitempty"`
+}
+
+func NewSMTPConfigXOAuth2RemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *SMTPConfigXOAuth2RemovedEvent {
+	return &SMTPConfigXOAuth2RemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigXOAuth2RemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *SMTPConfigXOAuth2RemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigXOAuth2RemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigXOAuth2RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMTPConfigDKIMSetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string              `json:"id,omitempty"`
+	Domain                string              `json:"domain,omitempty"`
+	Selector              string              `json:"selector,omitempty"`
+	PrivateKey            *crypto.CryptoValue `json:"privateKey,omitempty"`
+}
+
+func NewSMTPConfigDKIMSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	domain,
+	selector string,
+	privateKey *crypto.CryptoValue,
+) *SMTPConfigDKIMSetEvent {
+	return &SMTPConfigDKIMSetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDKIMSetEventType,
+		),
+		ID:         id,
+		Domain:     domain,
+		Selector:   selector,
+		PrivateKey: privateKey,
+	}
+}
+
+func (e *SMTPConfigDKIMSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigDKIMSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDKIMSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMTPConfigDKIMRemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string `json:"id,omitempty"`
+}
+
+func NewSMTPConfigDKIMRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *SMTPConfigDKIMRemovedEvent {
+	return &SMTPConfigDKIMRemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDKIMRemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *SMTPConfigDKIMRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigDKIMRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDKIMRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// SMTPConfigDeliverySecretSetEvent sets the secret providers use to authenticate delivery status callbacks.
+type SMTPConfigDeliverySecretSetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string              `json:"id,omitempty"`
+	Secret                *crypto.CryptoValue `json:"secret,omitempty"`
+}
+
+func NewSMTPConfigDeliverySecretSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	secret *crypto.CryptoValue,
+) *SMTPConfigDeliverySecretSetEvent {
+	return &SMTPConfigDeliverySecretSetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigDeliverySecretSetEventType,
+		),
+		ID:     id,
+		Secret: secret,
+	}
+}
+
+func (e *SMTPConfigDeliverySecretSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigDeliverySecretSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigDeliverySecretSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
 type SMTPConfigHTTPAddedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 
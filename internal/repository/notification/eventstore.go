@@ -9,4 +9,5 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SentType, eventstore.GenericEventMapper[SentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, RetryRequestedType, eventstore.GenericEventMapper[RetryRequestedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, CanceledType, eventstore.GenericEventMapper[CanceledEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, DeliveryReportedType, eventstore.GenericEventMapper[DeliveryReportedEvent])
 }
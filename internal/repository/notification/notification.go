@@ -16,6 +16,7 @@ const (
 	RetryRequestedType      = notificationEventPrefix + "retry.requested"
 	SentType                = notificationEventPrefix + "sent"
 	CanceledType            = notificationEventPrefix + "canceled"
+	DeliveryReportedType    = notificationEventPrefix + "delivery.reported"
 )
 
 type Request struct {
@@ -116,8 +117,19 @@ func NewRequestedEvent(ctx context.Context,
 	}
 }
 
+// Delivery describes how a notification was handed over to the provider.
+type Delivery struct {
+	ProviderID   string `json:"providerID,omitempty"`
+	ProviderType string `json:"providerType,omitempty"`
+	// Recipient is masked, see [domain.MaskRecipient]
+	Recipient         string `json:"recipient,omitempty"`
+	ProviderMessageID string `json:"providerMessageID,omitempty"`
+}
+
 type SentEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	Delivery
 }
 
 func (e *SentEvent) Payload() interface{} {
@@ -134,6 +146,7 @@ func (e *SentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
 
 func NewSentEvent(ctx context.Context,
 	aggregate *eventstore.Aggregate,
+	delivery Delivery,
 ) *SentEvent {
 	return &SentEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -141,6 +154,7 @@ func NewSentEvent(ctx context.Context,
 			aggregate,
 			SentType,
 		),
+		Delivery: delivery,
 	}
 }
 
@@ -242,3 +256,40 @@ func NewRetryRequestedEvent(
 		Error:      errorMessage,
 	}
 }
+
+// DeliveryReportedEvent is pushed if the provider reports the status of a sent notification.
+type DeliveryReportedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Status domain.NotificationDeliveryStatus `json:"status"`
+	Reason string                            `json:"reason,omitempty"`
+}
+
+func (e *DeliveryReportedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *DeliveryReportedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *DeliveryReportedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewDeliveryReportedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	status domain.NotificationDeliveryStatus,
+	reason string,
+) *DeliveryReportedEvent {
+	return &DeliveryReportedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DeliveryReportedType,
+		),
+		Status: status,
+		Reason: reason,
+	}
+}
@@ -0,0 +1,83 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/customrole"
+)
+
+var (
+	CustomRoleAddedEventType   = orgEventTypePrefix + customrole.AddedEventType
+	CustomRoleChangedEventType = orgEventTypePrefix + customrole.ChangedEventType
+	CustomRoleRemovedEventType = orgEventTypePrefix + customrole.RemovedEventType
+)
+
+type CustomRoleAddedEvent struct {
+	customrole.AddedEvent
+}
+
+func NewCustomRoleAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key,
+	displayName string,
+	permissions []string,
+) *CustomRoleAddedEvent {
+	return &CustomRoleAddedEvent{
+		AddedEvent: *customrole.NewAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				CustomRoleAddedEventType,
+			),
+			key,
+			displayName,
+			permissions,
+		),
+	}
+}
+
+type CustomRoleChangedEvent struct {
+	customrole.ChangedEvent
+}
+
+func NewCustomRoleChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+	changes []customrole.Changes,
+) *CustomRoleChangedEvent {
+	return &CustomRoleChangedEvent{
+		ChangedEvent: *customrole.NewChangedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				CustomRoleChangedEventType,
+			),
+			key,
+			changes,
+		),
+	}
+}
+
+type CustomRoleRemovedEvent struct {
+	customrole.RemovedEvent
+}
+
+func NewCustomRoleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	key string,
+) *CustomRoleRemovedEvent {
+	return &CustomRoleRemovedEvent{
+		RemovedEvent: *customrole.NewRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				CustomRoleRemovedEventType,
+			),
+			key,
+		),
+	}
+}
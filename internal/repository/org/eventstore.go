@@ -10,6 +10,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgDeactivatedEventType, OrgDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgReactivatedEventType, OrgReactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgRemovedEventType, OrgRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, OrgParentSetEventType, eventstore.GenericEventMapper[OrgParentSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, OrgParentRemovedEventType, eventstore.GenericEventMapper[OrgParentRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgDomainAddedEventType, DomainAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgDomainVerificationAddedEventType, DomainVerificationAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, OrgDomainVerificationFailedEventType, DomainVerificationFailedEventMapper)
@@ -20,6 +22,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberChangedEventType, MemberChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberRemovedEventType, MemberRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MemberCascadeRemovedEventType, MemberCascadeRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleAddedEventType, eventstore.GenericEventMapper[CustomRoleAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleChangedEventType, eventstore.GenericEventMapper[CustomRoleChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, CustomRoleRemovedEventType, eventstore.GenericEventMapper[CustomRoleRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, LabelPolicyAddedEventType, LabelPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LabelPolicyChangedEventType, LabelPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LabelPolicyActivatedEventType, LabelPolicyActivatedEventMapper)
@@ -57,6 +62,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, LockoutPolicyAddedEventType, LockoutPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LockoutPolicyChangedEventType, LockoutPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LockoutPolicyRemovedEventType, LockoutPolicyRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InactivityPolicyAddedEventType, InactivityPolicyAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InactivityPolicyChangedEventType, InactivityPolicyChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, InactivityPolicyRemovedEventType, InactivityPolicyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, PrivacyPolicyAddedEventType, PrivacyPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, PrivacyPolicyChangedEventType, PrivacyPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, PrivacyPolicyRemovedEventType, PrivacyPolicyRemovedEventMapper)
@@ -105,6 +113,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPGroupMappingsSetEventType, IDPGroupMappingsSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPFederatedLogoutSetEventType, IDPFederatedLogoutSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPTokenExchangeClientsSetEventType, IDPTokenExchangeClientsSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, TriggerActionsSetEventType, TriggerActionsSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, TriggerActionsCascadeRemovedEventType, TriggerActionsCascadeRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, FlowClearedEventType, FlowClearedEventMapper)
@@ -114,4 +125,6 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, NotificationTemplateSetEventType, eventstore.GenericEventMapper[NotificationTemplateSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, NotificationTemplateRemovedEventType, eventstore.GenericEventMapper[NotificationTemplateRemovedEvent])
 }
@@ -0,0 +1,98 @@
+package org
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	notificationTemplateEventTypePrefix  = orgEventTypePrefix + "notification.template."
+	NotificationTemplateSetEventType     = notificationTemplateEventTypePrefix + "set"
+	NotificationTemplateRemovedEventType = notificationTemplateEventTypePrefix + "removed"
+)
+
+// NotificationTemplateSetEvent sets all parts of the custom template of a message type in a language,
+// parts which are not set are rendered by the default template again.
+type NotificationTemplateSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string       `json:"messageType,omitempty"`
+	Language    language.Tag `json:"language,omitempty"`
+	Subject     string       `json:"subject,omitempty"`
+	HTML        string       `json:"html,omitempty"`
+	Text        string       `json:"text,omitempty"`
+}
+
+func (e *NotificationTemplateSetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *NotificationTemplateSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *NotificationTemplateSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewNotificationTemplateSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	lang language.Tag,
+	subject,
+	html,
+	text string,
+) *NotificationTemplateSetEvent {
+	return &NotificationTemplateSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationTemplateSetEventType,
+		),
+		MessageType: messageType,
+		Language:    lang,
+		Subject:     subject,
+		HTML:        html,
+		Text:        text,
+	}
+}
+
+type NotificationTemplateRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string       `json:"messageType,omitempty"`
+	Language    language.Tag `json:"language,omitempty"`
+}
+
+func (e *NotificationTemplateRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *NotificationTemplateRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *NotificationTemplateRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewNotificationTemplateRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType string,
+	lang language.Tag,
+) *NotificationTemplateRemovedEvent {
+	return &NotificationTemplateRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			NotificationTemplateRemovedEventType,
+		),
+		MessageType: messageType,
+		Language:    lang,
+	}
+}
@@ -0,0 +1,74 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	OrgParentSetEventType     = orgEventTypePrefix + "parent.set"
+	OrgParentRemovedEventType = orgEventTypePrefix + "parent.removed"
+)
+
+// OrgParentSetEvent places the organization below a parent organization.
+// The organization inherits the policies of its parent and the administrators of the parent
+// are allowed to manage it.
+type OrgParentSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ParentOrgID string `json:"parentOrgId"`
+}
+
+func (e *OrgParentSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *OrgParentSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *OrgParentSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewOrgParentSetEvent(ctx context.Context, aggregate *eventstore.Aggregate, parentOrgID string) *OrgParentSetEvent {
+	return &OrgParentSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgParentSetEventType,
+		),
+		ParentOrgID: parentOrgID,
+	}
+}
+
+// OrgParentRemovedEvent makes the organization a root organization again.
+type OrgParentRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ParentOrgID string `json:"parentOrgId"`
+}
+
+func (e *OrgParentRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *OrgParentRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *OrgParentRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewOrgParentRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, parentOrgID string) *OrgParentRemovedEvent {
+	return &OrgParentRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OrgParentRemovedEventType,
+		),
+		ParentOrgID: parentOrgID,
+	}
+}
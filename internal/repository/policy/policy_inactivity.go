@@ -0,0 +1,146 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	InactivityPolicyAddedEventType   = "policy.inactivity.added"
+	InactivityPolicyChangedEventType = "policy.inactivity.changed"
+	InactivityPolicyRemovedEventType = "policy.inactivity.removed"
+)
+
+type InactivityPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	InactivityPeriod time.Duration `json:"inactivityPeriod,omitempty"`
+	WarningPeriod    time.Duration `json:"warningPeriod,omitempty"`
+	DryRun           bool          `json:"dryRun,omitempty"`
+}
+
+func (e *InactivityPolicyAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *InactivityPolicyAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewInactivityPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	inactivityPeriod,
+	warningPeriod time.Duration,
+	dryRun bool,
+) *InactivityPolicyAddedEvent {
+	return &InactivityPolicyAddedEvent{
+		BaseEvent:        *base,
+		InactivityPeriod: inactivityPeriod,
+		WarningPeriod:    warningPeriod,
+		DryRun:           dryRun,
+	}
+}
+
+func InactivityPolicyAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &InactivityPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "POLIC-Ina1d", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type InactivityPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	InactivityPeriod *time.Duration `json:"inactivityPeriod,omitempty"`
+	WarningPeriod    *time.Duration `json:"warningPeriod,omitempty"`
+	DryRun           *bool          `json:"dryRun,omitempty"`
+}
+
+func (e *InactivityPolicyChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *InactivityPolicyChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewInactivityPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []InactivityPolicyChanges,
+) (*InactivityPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "POLICY-Ina2c", "Errors.NoChangesFound")
+	}
+	changeEvent := &InactivityPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type InactivityPolicyChanges func(*InactivityPolicyChangedEvent)
+
+func ChangeInactivityPeriod(inactivityPeriod time.Duration) func(*InactivityPolicyChangedEvent) {
+	return func(e *InactivityPolicyChangedEvent) {
+		e.InactivityPeriod = &inactivityPeriod
+	}
+}
+
+func ChangeInactivityWarningPeriod(warningPeriod time.Duration) func(*InactivityPolicyChangedEvent) {
+	return func(e *InactivityPolicyChangedEvent) {
+		e.WarningPeriod = &warningPeriod
+	}
+}
+
+func ChangeInactivityDryRun(dryRun bool) func(*InactivityPolicyChangedEvent) {
+	return func(e *InactivityPolicyChangedEvent) {
+		e.DryRun = &dryRun
+	}
+}
+
+func InactivityPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &InactivityPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "POLIC-Ina3c", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type InactivityPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *InactivityPolicyRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *InactivityPolicyRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewInactivityPolicyRemovedEvent(base *eventstore.BaseEvent) *InactivityPolicyRemovedEvent {
+	return &InactivityPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func InactivityPolicyRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &InactivityPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -14,7 +15,13 @@ const (
 type NotificationPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange bool `json:"passwordChange,omitempty"`
+	PasswordChange           bool `json:"passwordChange,omitempty"`
+	NewDeviceSignIn          bool `json:"newDeviceSignIn,omitempty"`
+	MFAAdded                 bool `json:"mfaAdded,omitempty"`
+	MFARemoved               bool `json:"mfaRemoved,omitempty"`
+	EmailChange              bool `json:"emailChange,omitempty"`
+	AccountLocked            bool `json:"accountLocked,omitempty"`
+	PersonalAccessTokenAdded bool `json:"personalAccessTokenAdded,omitempty"`
 }
 
 func (e *NotificationPolicyAddedEvent) Payload() interface{} {
@@ -27,11 +34,17 @@ func (e *NotificationPolicyAddedEvent) UniqueConstraints() []*eventstore.UniqueC
 
 func NewNotificationPolicyAddedEvent(
 	base *eventstore.BaseEvent,
-	passwordChange bool,
+	notificationPolicy *domain.NotificationPolicy,
 ) *NotificationPolicyAddedEvent {
 	return &NotificationPolicyAddedEvent{
-		BaseEvent:      *base,
-		PasswordChange: passwordChange,
+		BaseEvent:                *base,
+		PasswordChange:           notificationPolicy.PasswordChange,
+		NewDeviceSignIn:          notificationPolicy.NewDeviceSignIn,
+		MFAAdded:                 notificationPolicy.MFAAdded,
+		MFARemoved:               notificationPolicy.MFARemoved,
+		EmailChange:              notificationPolicy.EmailChange,
+		AccountLocked:            notificationPolicy.AccountLocked,
+		PersonalAccessTokenAdded: notificationPolicy.PersonalAccessTokenAdded,
 	}
 }
 
@@ -51,7 +64,13 @@ func NotificationPolicyAddedEventMapper(event eventstore.Event) (eventstore.Even
 type NotificationPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	PasswordChange *bool `json:"passwordChange,omitempty"`
+	PasswordChange           *bool `json:"passwordChange,omitempty"`
+	NewDeviceSignIn          *bool `json:"newDeviceSignIn,omitempty"`
+	MFAAdded                 *bool `json:"mfaAdded,omitempty"`
+	MFARemoved               *bool `json:"mfaRemoved,omitempty"`
+	EmailChange              *bool `json:"emailChange,omitempty"`
+	AccountLocked            *bool `json:"accountLocked,omitempty"`
+	PersonalAccessTokenAdded *bool `json:"personalAccessTokenAdded,omitempty"`
 }
 
 func (e *NotificationPolicyChangedEvent) Payload() interface{} {
@@ -86,6 +105,42 @@ func ChangePasswordChange(passwordChange bool) func(*NotificationPolicyChangedEv
 	}
 }
 
+func ChangeNewDeviceSignIn(newDeviceSignIn bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.NewDeviceSignIn = &newDeviceSignIn
+	}
+}
+
+func ChangeMFAAdded(mfaAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFAAdded = &mfaAdded
+	}
+}
+
+func ChangeMFARemoved(mfaRemoved bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.MFARemoved = &mfaRemoved
+	}
+}
+
+func ChangeEmailChange(emailChange bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.EmailChange = &emailChange
+	}
+}
+
+func ChangeAccountLocked(accountLocked bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.AccountLocked = &accountLocked
+	}
+}
+
+func ChangePersonalAccessTokenAdded(personalAccessTokenAdded bool) func(*NotificationPolicyChangedEvent) {
+	return func(e *NotificationPolicyChangedEvent) {
+		e.PersonalAccessTokenAdded = &personalAccessTokenAdded
+	}
+}
+
 func NotificationPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &NotificationPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -23,6 +23,9 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantDeactivatedType, GrantDeactivateEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantReactivatedType, GrantReactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantRemovedType, GrantRemovedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantValidityChangedType, eventstore.GenericEventMapper[GrantValidityChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantExpiryWarnedType, eventstore.GenericEventMapper[GrantExpiryWarnedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, GrantExpiredType, eventstore.GenericEventMapper[GrantExpiredEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantMemberAddedType, GrantMemberAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantMemberChangedType, GrantMemberChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, GrantMemberRemovedType, GrantMemberRemovedEventMapper)
@@ -44,4 +47,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, AuthorizationModelSetType, eventstore.GenericEventMapper[AuthorizationModelSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, RelationTupleAddedType, eventstore.GenericEventMapper[RelationTupleAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, RelationTupleRemovedType, eventstore.GenericEventMapper[RelationTupleRemovedEvent])
 }
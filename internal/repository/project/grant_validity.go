@@ -0,0 +1,156 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	GrantValidityChangedType = grantEventTypePrefix + "validity.changed"
+	GrantExpiryWarnedType    = grantEventTypePrefix + "expiry.warned"
+	GrantExpiredType         = grantEventTypePrefix + "expired"
+)
+
+// GrantValidityChangedEvent sets the time window of the project grant.
+// Zero timestamps leave the window open on that side.
+type GrantValidityChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID    string    `json:"grantId,omitempty"`
+	ValidFrom  time.Time `json:"validFrom,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *GrantValidityChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantValidityChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *GrantValidityChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewGrantValidityChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	grantID string,
+	validFrom,
+	validUntil time.Time,
+) *GrantValidityChangedEvent {
+	return &GrantValidityChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantValidityChangedType,
+		),
+		GrantID:    grantID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}
+}
+
+// GrantExpiryWarnedEvent notifies the owners of the project's organization that the project grant will expire.
+type GrantExpiryWarnedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID      string    `json:"grantId,omitempty"`
+	GrantedOrgID string    `json:"grantedOrgId,omitempty"`
+	ValidUntil   time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *GrantExpiryWarnedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantExpiryWarnedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *GrantExpiryWarnedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewGrantExpiryWarnedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	grantID,
+	grantedOrgID string,
+	validUntil time.Time,
+) *GrantExpiryWarnedEvent {
+	return &GrantExpiryWarnedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantExpiryWarnedType,
+		),
+		GrantID:      grantID,
+		GrantedOrgID: grantedOrgID,
+		ValidUntil:   validUntil,
+	}
+}
+
+// GrantExpiredEvent deactivates the project grant because its validity ended.
+type GrantExpiredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	GrantID    string    `json:"grantId,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *GrantExpiredEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *GrantExpiredEvent) Payload() interface{} {
+	return e
+}
+
+func (e *GrantExpiredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *GrantExpiredEvent) Fields() []*eventstore.FieldOperation {
+	return []*eventstore.FieldOperation{
+		eventstore.SetField(
+			e.Aggregate(),
+			grantSearchObject(e.GrantID),
+
+			ProjectGrantStateSearchField,
+			&eventstore.Value{
+				Value:       domain.ProjectGrantStateInactive,
+				ShouldIndex: true,
+			},
+
+			eventstore.FieldTypeInstanceID,
+			eventstore.FieldTypeResourceOwner,
+			eventstore.FieldTypeAggregateType,
+			eventstore.FieldTypeAggregateID,
+			eventstore.FieldTypeObjectType,
+			eventstore.FieldTypeObjectID,
+			eventstore.FieldTypeFieldName,
+		),
+	}
+}
+
+func NewGrantExpiredEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	grantID string,
+	validUntil time.Time,
+) *GrantExpiredEvent {
+	return &GrantExpiredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			GrantExpiredType,
+		),
+		GrantID:    grantID,
+		ValidUntil: validUntil,
+	}
+}
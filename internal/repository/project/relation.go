@@ -0,0 +1,176 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueRelationTupleType = "project_relation_tuple"
+
+	AuthorizationModelSetType = projectEventTypePrefix + "authorization.model.set"
+
+	relationTupleEventTypePrefix = projectEventTypePrefix + "relation.tuple."
+	RelationTupleAddedType       = relationTupleEventTypePrefix + "added"
+	RelationTupleRemovedType     = relationTupleEventTypePrefix + "removed"
+)
+
+func NewAddRelationTupleUniqueConstraint(projectID string, tuple *domain.RelationTuple) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRelationTupleType,
+		fmt.Sprintf("%s:%s", projectID, tuple),
+		"Errors.Project.RelationTuple.AlreadyExists")
+}
+
+func NewRemoveRelationTupleUniqueConstraint(projectID string, tuple *domain.RelationTuple) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueRelationTupleType,
+		fmt.Sprintf("%s:%s", projectID, tuple))
+}
+
+// AuthorizationModelSetEvent replaces the authorization model of the project.
+// Existing relation tuples are kept, the model is only enforced when tuples are added.
+type AuthorizationModelSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Model *domain.AuthorizationModel `json:"model,omitempty"`
+}
+
+func (e *AuthorizationModelSetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AuthorizationModelSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AuthorizationModelSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewAuthorizationModelSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	model *domain.AuthorizationModel,
+) *AuthorizationModelSetEvent {
+	return &AuthorizationModelSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AuthorizationModelSetType,
+		),
+		Model: model,
+	}
+}
+
+// RelationTuple is the payload of the tuple events.
+// The fields are never omitted, so the events can be filtered by the whole tuple.
+type RelationTuple struct {
+	ObjectType      string `json:"objectType"`
+	ObjectID        string `json:"objectId"`
+	Relation        string `json:"relation"`
+	SubjectType     string `json:"subjectType"`
+	SubjectID       string `json:"subjectId"`
+	SubjectRelation string `json:"subjectRelation"`
+}
+
+func RelationTupleFromDomain(tuple *domain.RelationTuple) RelationTuple {
+	return RelationTuple{
+		ObjectType:      tuple.ObjectType,
+		ObjectID:        tuple.ObjectID,
+		Relation:        tuple.Relation,
+		SubjectType:     tuple.SubjectType,
+		SubjectID:       tuple.SubjectID,
+		SubjectRelation: tuple.SubjectRelation,
+	}
+}
+
+func (t RelationTuple) Domain() *domain.RelationTuple {
+	return &domain.RelationTuple{
+		ObjectType:      t.ObjectType,
+		ObjectID:        t.ObjectID,
+		Relation:        t.Relation,
+		SubjectType:     t.SubjectType,
+		SubjectID:       t.SubjectID,
+		SubjectRelation: t.SubjectRelation,
+	}
+}
+
+// EventData is used to filter the events of a single tuple.
+func (t RelationTuple) EventData() map[string]interface{} {
+	return map[string]interface{}{
+		"objectType":      t.ObjectType,
+		"objectId":        t.ObjectID,
+		"relation":        t.Relation,
+		"subjectType":     t.SubjectType,
+		"subjectId":       t.SubjectID,
+		"subjectRelation": t.SubjectRelation,
+	}
+}
+
+type RelationTupleAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	RelationTuple
+}
+
+func (e *RelationTupleAddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RelationTupleAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RelationTupleAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddRelationTupleUniqueConstraint(e.Aggregate().ID, e.Domain())}
+}
+
+func NewRelationTupleAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tuple *domain.RelationTuple,
+) *RelationTupleAddedEvent {
+	return &RelationTupleAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RelationTupleAddedType,
+		),
+		RelationTuple: RelationTupleFromDomain(tuple),
+	}
+}
+
+type RelationTupleRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	RelationTuple
+}
+
+func (e *RelationTupleRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RelationTupleRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RelationTupleRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveRelationTupleUniqueConstraint(e.Aggregate().ID, e.Domain())}
+}
+
+func NewRelationTupleRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tuple *domain.RelationTuple,
+) *RelationTupleRemovedEvent {
+	return &RelationTupleRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RelationTupleRemovedType,
+		),
+		RelationTuple: RelationTupleFromDomain(tuple),
+	}
+}
@@ -38,9 +38,10 @@ func NewRemoveProjectRoleUniqueConstraint(roleKey, projectID string) *eventstore
 type RoleAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Key         string `json:"key,omitempty"`
-	DisplayName string `json:"displayName,omitempty"`
-	Group       string `json:"group,omitempty"`
+	Key           string   `json:"key,omitempty"`
+	DisplayName   string   `json:"displayName,omitempty"`
+	Group         string   `json:"group,omitempty"`
+	IncludedRoles []string `json:"includedRoles,omitempty"`
 }
 
 func (e *RoleAddedEvent) Payload() interface{} {
@@ -110,6 +111,7 @@ func NewRoleAddedEvent(
 	key,
 	displayName,
 	group string,
+	includedRoles ...string,
 ) *RoleAddedEvent {
 	return &RoleAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -117,9 +119,10 @@ func NewRoleAddedEvent(
 			aggregate,
 			RoleAddedType,
 		),
-		Key:         key,
-		DisplayName: displayName,
-		Group:       group,
+		Key:           key,
+		DisplayName:   displayName,
+		Group:         group,
+		IncludedRoles: includedRoles,
 	}
 }
 
@@ -139,9 +142,10 @@ func RoleAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 type RoleChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Key         string  `json:"key,omitempty"`
-	DisplayName *string `json:"displayName,omitempty"`
-	Group       *string `json:"group,omitempty"`
+	Key           string    `json:"key,omitempty"`
+	DisplayName   *string   `json:"displayName,omitempty"`
+	Group         *string   `json:"group,omitempty"`
+	IncludedRoles *[]string `json:"includedRoles,omitempty"`
 }
 
 func (e *RoleChangedEvent) Payload() interface{} {
@@ -238,6 +242,16 @@ func ChangeGroup(group string) func(event *RoleChangedEvent) {
 		e.Group = &group
 	}
 }
+
+func ChangeIncludedRoles(includedRoles []string) func(event *RoleChangedEvent) {
+	return func(e *RoleChangedEvent) {
+		// an empty list must not be marshalled as null, which would not be distinguishable from no change
+		if includedRoles == nil {
+			includedRoles = []string{}
+		}
+		e.IncludedRoles = &includedRoles
+	}
+}
 func RoleChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &RoleChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
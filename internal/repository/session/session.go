@@ -20,6 +20,7 @@ const (
 	UserCheckedType        = sessionEventPrefix + "user.checked"
 	PasswordCheckedType    = sessionEventPrefix + "password.checked"
 	IntentCheckedType      = sessionEventPrefix + "intent.checked"
+	IDPSessionSetType      = sessionEventPrefix + "idp_session.set"
 	WebAuthNChallengedType = sessionEventPrefix + "webAuthN.challenged"
 	WebAuthNCheckedType    = sessionEventPrefix + "webAuthN.checked"
 	TOTPCheckedType        = sessionEventPrefix + "totp.checked"
@@ -207,6 +208,50 @@ func IntentCheckedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return added, nil
 }
 
+// IDPSessionSetEvent stores the identifiers of the session at the external identity provider
+// the user authenticated with, so it can be terminated at the identity provider as well (federated logout).
+type IDPSessionSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPID        string              `json:"idpId"`
+	IDTokenHint  *crypto.CryptoValue `json:"idTokenHint,omitempty"`
+	NameID       string              `json:"nameId,omitempty"`
+	SessionIndex string              `json:"sessionIndex,omitempty"`
+}
+
+func (e *IDPSessionSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *IDPSessionSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *IDPSessionSetEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewIDPSessionSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpID string,
+	idTokenHint *crypto.CryptoValue,
+	nameID,
+	sessionIndex string,
+) *IDPSessionSetEvent {
+	return &IDPSessionSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			IDPSessionSetType,
+		),
+		IDPID:        idpID,
+		IDTokenHint:  idTokenHint,
+		NameID:       nameID,
+		SessionIndex: sessionIndex,
+	}
+}
+
 type WebAuthNChallengedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
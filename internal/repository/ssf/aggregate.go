@@ -0,0 +1,18 @@
+package ssf
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+const (
+	AggregateType    = "ssf_stream"
+	AggregateVersion = "v1"
+)
+
+func NewAggregate(streamID, instanceID string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            streamID,
+		Type:          AggregateType,
+		ResourceOwner: instanceID,
+		InstanceID:    instanceID,
+		Version:       AggregateVersion,
+	}
+}
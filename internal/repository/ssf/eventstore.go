@@ -0,0 +1,13 @@
+package ssf
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func init() {
+	eventstore.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, ChangedEventType, eventstore.GenericEventMapper[ChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, StatusChangedEventType, eventstore.GenericEventMapper[StatusChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, VerificationRequestedEventType, eventstore.GenericEventMapper[VerificationRequestedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SETDeliveredEventType, eventstore.GenericEventMapper[SETDeliveredEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SETFailedEventType, eventstore.GenericEventMapper[SETFailedEvent])
+}
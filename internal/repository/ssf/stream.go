@@ -0,0 +1,283 @@
+package ssf
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix                eventstore.EventType = "ssf_stream."
+	AddedEventType                                      = eventTypePrefix + "added"
+	ChangedEventType                                    = eventTypePrefix + "changed"
+	StatusChangedEventType                              = eventTypePrefix + "status.changed"
+	RemovedEventType                                    = eventTypePrefix + "removed"
+	VerificationRequestedEventType                      = eventTypePrefix + "verification.requested"
+	SETDeliveredEventType                               = eventTypePrefix + "set.delivered"
+	SETFailedEventType                                  = eventTypePrefix + "set.failed"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Description         string              `json:"description,omitempty"`
+	Audience            []string            `json:"audience"`
+	EventsRequested     []string            `json:"eventsRequested"`
+	DeliveryMethod      string              `json:"deliveryMethod"`
+	EndpointURL         string              `json:"endpointURL"`
+	AuthorizationHeader *crypto.CryptoValue `json:"authorizationHeader,omitempty"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AddedEvent) Payload() any {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	description string,
+	audience,
+	eventsRequested []string,
+	deliveryMethod,
+	endpointURL string,
+	authorizationHeader *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, AddedEventType,
+		),
+		Description:         description,
+		Audience:            audience,
+		EventsRequested:     eventsRequested,
+		DeliveryMethod:      deliveryMethod,
+		EndpointURL:         endpointURL,
+		AuthorizationHeader: authorizationHeader,
+	}
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Description         *string             `json:"description,omitempty"`
+	Audience            *[]string           `json:"audience,omitempty"`
+	EventsRequested     *[]string           `json:"eventsRequested,omitempty"`
+	EndpointURL         *string             `json:"endpointURL,omitempty"`
+	AuthorizationHeader *crypto.CryptoValue `json:"authorizationHeader,omitempty"`
+}
+
+func (e *ChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *ChangedEvent) Payload() any {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []Changes,
+) *ChangedEvent {
+	changeEvent := &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent
+}
+
+type Changes func(event *ChangedEvent)
+
+func ChangeDescription(description string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeAudience(audience []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Audience = &audience
+	}
+}
+
+func ChangeEventsRequested(eventsRequested []string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.EventsRequested = &eventsRequested
+	}
+}
+
+func ChangeEndpointURL(endpointURL string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.EndpointURL = &endpointURL
+	}
+}
+
+func ChangeAuthorizationHeader(authorizationHeader *crypto.CryptoValue) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.AuthorizationHeader = authorizationHeader
+	}
+}
+
+type StatusChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	State  domain.SSFStreamState `json:"state"`
+	Reason string                `json:"reason,omitempty"`
+}
+
+func (e *StatusChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *StatusChangedEvent) Payload() any {
+	return e
+}
+
+func (e *StatusChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewStatusChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	state domain.SSFStreamState,
+	reason string,
+) *StatusChangedEvent {
+	return &StatusChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx, aggregate, StatusChangedEventType,
+		),
+		State:  state,
+		Reason: reason,
+	}
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RemovedEvent) Payload() any {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, RemovedEventType),
+	}
+}
+
+// VerificationRequestedEvent is pushed when a receiver requests a verification event.
+// The transmitter will then send a verification SET containing the state to the receiver.
+type VerificationRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	State string `json:"state,omitempty"`
+}
+
+func (e *VerificationRequestedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *VerificationRequestedEvent) Payload() any {
+	return e
+}
+
+func (e *VerificationRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewVerificationRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate, state string) *VerificationRequestedEvent {
+	return &VerificationRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, VerificationRequestedEventType),
+		State:     state,
+	}
+}
+
+// SETDeliveredEvent tracks a security event token which was accepted by the receiver.
+type SETDeliveredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	JTI       string `json:"jti"`
+	EventType string `json:"eventType"`
+	SubjectID string `json:"subjectID,omitempty"`
+}
+
+func (e *SETDeliveredEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *SETDeliveredEvent) Payload() any {
+	return e
+}
+
+func (e *SETDeliveredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSETDeliveredEvent(ctx context.Context, aggregate *eventstore.Aggregate, jti, eventType, subjectID string) *SETDeliveredEvent {
+	return &SETDeliveredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, SETDeliveredEventType),
+		JTI:       jti,
+		EventType: eventType,
+		SubjectID: subjectID,
+	}
+}
+
+// SETFailedEvent tracks a security event token which could not be delivered to the receiver.
+type SETFailedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	JTI       string `json:"jti"`
+	EventType string `json:"eventType"`
+	SubjectID string `json:"subjectID,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func (e *SETFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *SETFailedEvent) Payload() any {
+	return e
+}
+
+func (e *SETFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSETFailedEvent(ctx context.Context, aggregate *eventstore.Aggregate, jti, eventType, subjectID, reason string) *SETFailedEvent {
+	return &SETFailedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, SETFailedEventType),
+		JTI:       jti,
+		EventType: eventType,
+		SubjectID: subjectID,
+		Reason:    reason,
+	}
+}
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"slices"
+
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
@@ -65,12 +67,22 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanPasswordCheckSucceededType, HumanPasswordCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanPasswordCheckFailedType, HumanPasswordCheckFailedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanPasswordHashUpdatedType, eventstore.GenericEventMapper[HumanPasswordHashUpdatedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanPasswordCompromisedType, eventstore.GenericEventMapper[HumanPasswordCompromisedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkAddedType, UserIDPLinkAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkRemovedType, UserIDPLinkRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkCascadeRemovedType, UserIDPLinkCascadeRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLoginCheckSucceededType, UserIDPCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalIDMigratedType, eventstore.GenericEventMapper[UserIDPExternalIDMigratedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalUsernameChangedType, eventstore.GenericEventMapper[UserIDPExternalUsernameEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPGroupsSyncedType, eventstore.GenericEventMapper[UserIDPGroupsSyncedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkTokensSetType, eventstore.GenericEventMapper[UserIDPLinkTokensSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkTokensRemovedType, eventstore.GenericEventMapper[UserIDPLinkTokensRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLinkTokenRetrievedType, eventstore.GenericEventMapper[UserIDPLinkTokenRetrievedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserImportedType, eventstore.GenericEventMapper[UserImportedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanInactivityWarnedType, eventstore.GenericEventMapper[HumanInactivityWarnedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanInactivityWarningSentType, eventstore.GenericEventMapper[HumanInactivityWarningSentEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanSecurityNotificationSentType, eventstore.GenericEventMapper[HumanSecurityNotificationSentEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanSignInDeviceAddedType, eventstore.GenericEventMapper[HumanSignInDeviceAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailChangedType, HumanEmailChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerifiedType, HumanEmailVerifiedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerificationFailedType, HumanEmailVerificationFailedEventMapper)
@@ -141,4 +153,34 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCodeSentType, eventstore.GenericEventMapper[HumanInviteCodeSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckSucceededType, eventstore.GenericEventMapper[HumanInviteCheckSucceededEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckFailedType, eventstore.GenericEventMapper[HumanInviteCheckFailedEvent])
+
+	eventstore.RegisterPersonalDataFields(UserV1AddedType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserV1RegisteredType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserV1EmailChangedType, emailPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserV1PhoneChangedType, phonePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserV1ProfileChangedType, profilePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserV1AddressChangedType, addressPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanAddedType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanRegisteredType, humanPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanEmailChangedType, emailPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanPhoneChangedType, phonePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanProfileChangedType, profilePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(HumanAddressChangedType, addressPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserUserNameChangedType, userNamePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserDomainClaimedType, userNamePersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserIDPLinkAddedType, idpLinkPersonalDataFields...)
+	eventstore.RegisterPersonalDataFields(UserIDPExternalUsernameChangedType, idpUsernamePersonalDataFields...)
+	eventstore.RegisterPersonalDataShredder(UserRemovedType)
 }
+
+// the username is registered as personal data, because it often is the email of the user
+var (
+	userNamePersonalDataFields    = []string{"userName"}
+	profilePersonalDataFields     = []string{"firstName", "lastName", "nickName", "displayName"}
+	emailPersonalDataFields       = []string{"email"}
+	phonePersonalDataFields       = []string{"phone"}
+	addressPersonalDataFields     = []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanPersonalDataFields       = slices.Concat(userNamePersonalDataFields, profilePersonalDataFields, emailPersonalDataFields, phonePersonalDataFields, addressPersonalDataFields)
+	idpLinkPersonalDataFields     = []string{"displayName"}
+	idpUsernamePersonalDataFields = []string{"username"}
+)
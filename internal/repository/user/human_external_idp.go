@@ -17,6 +17,7 @@ const (
 	UserIDPLinkCascadeRemovedType      = UserIDPLinkEventPrefix + "cascade.removed"
 	UserIDPExternalIDMigratedType      = UserIDPLinkEventPrefix + "id.migrated"
 	UserIDPExternalUsernameChangedType = UserIDPLinkEventPrefix + "username.changed"
+	UserIDPGroupsSyncedType            = UserIDPLinkEventPrefix + "groups.synced"
 
 	UserIDPLoginCheckSucceededType = idpLoginEventPrefix + "check.succeeded"
 )
@@ -287,3 +288,51 @@ func NewUserIDPExternalUsernameEvent(
 		ExternalUsername: externalUsername,
 	}
 }
+
+// IDPGroupGrant is the part of a user grant, which was granted by the group mappings of an IdP
+type IDPGroupGrant struct {
+	UserGrantID    string   `json:"userGrantId"`
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys"`
+}
+
+// UserIDPGroupsSyncedEvent stores the grants and org member roles, which were granted by the group mappings of an IdP,
+// so they can be revoked again on a later login, without touching any manually granted roles.
+type UserIDPGroupsSyncedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	IDPConfigID          string           `json:"idpConfigId"`
+	Grants               []*IDPGroupGrant `json:"grants,omitempty"`
+	OrgMemberRoles       []string         `json:"orgMemberRoles,omitempty"`
+}
+
+func (e *UserIDPGroupsSyncedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPGroupsSyncedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPGroupsSyncedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPGroupsSyncedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID string,
+	grants []*IDPGroupGrant,
+	orgMemberRoles []string,
+) *UserIDPGroupsSyncedEvent {
+	return &UserIDPGroupsSyncedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPGroupsSyncedType,
+		),
+		IDPConfigID:    idpConfigID,
+		Grants:         grants,
+		OrgMemberRoles: orgMemberRoles,
+	}
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UserIDPLinkTokensSetType      = UserIDPLinkEventPrefix + "tokens.set"
+	UserIDPLinkTokenRetrievedType = UserIDPLinkEventPrefix + "token.retrieved"
+	UserIDPLinkTokensRemovedType  = UserIDPLinkEventPrefix + "tokens.removed"
+)
+
+// UserIDPLinkTokensSetEvent stores the (encrypted) tokens of the external IdP,
+// so they can be exchanged by authorized clients to call the API of the IdP on behalf of the user.
+type UserIDPLinkTokensSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string              `json:"idpConfigId"`
+	ExternalUserID string              `json:"userId"`
+	AccessToken    *crypto.CryptoValue `json:"accessToken"`
+	TokenType      string              `json:"tokenType,omitempty"`
+	RefreshToken   *crypto.CryptoValue `json:"refreshToken,omitempty"`
+	Expiry         time.Time           `json:"expiry,omitempty"`
+}
+
+func (e *UserIDPLinkTokensSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+	accessToken *crypto.CryptoValue,
+	tokenType string,
+	refreshToken *crypto.CryptoValue,
+	expiry time.Time,
+) *UserIDPLinkTokensSetEvent {
+	return &UserIDPLinkTokensSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensSetType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+		AccessToken:    accessToken,
+		TokenType:      tokenType,
+		RefreshToken:   refreshToken,
+		Expiry:         expiry,
+	}
+}
+
+// UserIDPLinkTokensRemovedEvent removes the stored tokens of the external IdP,
+// e.g. because the IdP revoked the refresh token.
+type UserIDPLinkTokensRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string `json:"idpConfigId"`
+	ExternalUserID string `json:"userId"`
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+) *UserIDPLinkTokensRemovedEvent {
+	return &UserIDPLinkTokensRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensRemovedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+// UserIDPLinkTokenRetrievedEvent audits the retrieval of the access token of the external IdP by a client.
+type UserIDPLinkTokenRetrievedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string `json:"idpConfigId"`
+	ExternalUserID string `json:"userId"`
+	ClientID       string `json:"clientId"`
+	Refreshed      bool   `json:"refreshed,omitempty"`
+}
+
+func (e *UserIDPLinkTokenRetrievedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokenRetrievedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokenRetrievedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokenRetrievedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID,
+	clientID string,
+	refreshed bool,
+) *UserIDPLinkTokenRetrievedEvent {
+	return &UserIDPLinkTokenRetrievedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokenRetrievedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+		ClientID:       clientID,
+		Refreshed:      refreshed,
+	}
+}
@@ -0,0 +1,85 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	inactivityEventPrefix          = humanEventPrefix + "inactivity."
+	HumanInactivityWarnedType      = inactivityEventPrefix + "warned"
+	HumanInactivityWarningSentType = inactivityEventPrefix + "warning.sent"
+)
+
+// HumanInactivityWarnedEvent is pushed when the user did not authenticate within the warning period
+// of the inactivity policy. The user will be notified about the upcoming deactivation.
+type HumanInactivityWarnedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	LastActivity      time.Time `json:"lastActivity"`
+	DeactivationDate  time.Time `json:"deactivationDate"`
+	TriggeredAtOrigin string    `json:"triggerOrigin,omitempty"`
+}
+
+func (e *HumanInactivityWarnedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanInactivityWarnedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanInactivityWarnedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func (e *HumanInactivityWarnedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanInactivityWarnedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	lastActivity,
+	deactivationDate time.Time,
+) *HumanInactivityWarnedEvent {
+	return &HumanInactivityWarnedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanInactivityWarnedType,
+		),
+		LastActivity:      lastActivity,
+		DeactivationDate:  deactivationDate,
+		TriggeredAtOrigin: http.DomainContext(ctx).Origin(),
+	}
+}
+
+type HumanInactivityWarningSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *HumanInactivityWarningSentEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *HumanInactivityWarningSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanInactivityWarningSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanInactivityWarningSentEvent(ctx context.Context, aggregate *eventstore.Aggregate) *HumanInactivityWarningSentEvent {
+	return &HumanInactivityWarningSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanInactivityWarningSentType,
+		),
+	}
+}
@@ -21,6 +21,7 @@ const (
 	HumanPasswordCheckSucceededType = passwordEventPrefix + "check.succeeded"
 	HumanPasswordCheckFailedType    = passwordEventPrefix + "check.failed"
 	HumanPasswordHashUpdatedType    = passwordEventPrefix + "hash.updated"
+	HumanPasswordCompromisedType    = passwordEventPrefix + "compromised"
 )
 
 type HumanPasswordChangedEvent struct {
@@ -335,3 +336,37 @@ func NewHumanPasswordHashUpdatedEvent(
 		EncodedHash: encoded,
 	}
 }
+
+// HumanPasswordCompromisedEvent reports that the current password of the user is known to be compromised,
+// for example because it was found in a leak.
+type HumanPasswordCompromisedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+	Reason               string `json:"reason,omitempty"`
+}
+
+func (e *HumanPasswordCompromisedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanPasswordCompromisedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanPasswordCompromisedEvent) SetBaseEvent(base *eventstore.BaseEvent) {
+	e.BaseEvent = *base
+}
+
+func NewHumanPasswordCompromisedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	reason string,
+) *HumanPasswordCompromisedEvent {
+	return &HumanPasswordCompromisedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanPasswordCompromisedType,
+		),
+		Reason: reason,
+	}
+}
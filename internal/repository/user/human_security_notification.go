@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	HumanSecurityNotificationSentType = humanEventPrefix + "security.notification.sent"
+	HumanSignInDeviceAddedType        = humanEventPrefix + "signin.device.added"
+)
+
+// HumanSecurityNotificationSentEvent is pushed after the user was notified about a security relevant change of their account.
+// The MessageType tells which notification was sent, the SessionID is set if the notification was triggered by a session.
+type HumanSecurityNotificationSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	MessageType string `json:"messageType"`
+	SessionID   string `json:"sessionID,omitempty"`
+}
+
+func (e *HumanSecurityNotificationSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanSecurityNotificationSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanSecurityNotificationSentEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanSecurityNotificationSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	messageType,
+	sessionID string,
+) *HumanSecurityNotificationSentEvent {
+	return &HumanSecurityNotificationSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSecurityNotificationSentType,
+		),
+		MessageType: messageType,
+		SessionID:   sessionID,
+	}
+}
+
+// HumanSignInDeviceAddedEvent records a device the user signed in with through a session,
+// so later sign-ins from the device are recognized without searching the sessions of the user.
+// The DeviceID is the fingerprint of the user agent of the session.
+type HumanSignInDeviceAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	DeviceID string `json:"deviceID"`
+}
+
+func (e *HumanSignInDeviceAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanSignInDeviceAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanSignInDeviceAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewHumanSignInDeviceAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	deviceID string,
+) *HumanSignInDeviceAddedEvent {
+	return &HumanSignInDeviceAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanSignInDeviceAddedType,
+		),
+		DeviceID: deviceID,
+	}
+}
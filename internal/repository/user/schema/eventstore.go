@@ -8,4 +8,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, DeactivatedType, eventstore.GenericEventMapper[DeactivatedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, ReactivatedType, eventstore.GenericEventMapper[ReactivatedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, DeletedType, eventstore.GenericEventMapper[DeletedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MigrationStartedType, eventstore.GenericEventMapper[MigrationStartedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MigrationProgressedType, eventstore.GenericEventMapper[MigrationProgressedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MigrationDoneType, eventstore.GenericEventMapper[MigrationDoneEvent])
 }
@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	migrationEventPrefix    = eventPrefix + "migration."
+	MigrationStartedType    = migrationEventPrefix + "started"
+	MigrationProgressedType = migrationEventPrefix + "progressed"
+	MigrationDoneType       = migrationEventPrefix + "done"
+)
+
+// MigrationStartedEvent starts the revalidation and migration of all users of the schema to the revision.
+type MigrationStartedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	SchemaRevision uint64 `json:"schemaRevision"`
+}
+
+func (e *MigrationStartedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *MigrationStartedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MigrationStartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewMigrationStartedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	revision uint64,
+) *MigrationStartedEvent {
+	return &MigrationStartedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MigrationStartedType,
+		),
+		SchemaRevision: revision,
+	}
+}
+
+type MigrationFailure struct {
+	UserID string `json:"userId"`
+	Reason string `json:"reason"`
+}
+
+// MigrationProgressedEvent reports the users processed since the last progress.
+type MigrationProgressedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Migrated uint64              `json:"migrated"`
+	Valid    uint64              `json:"valid"`
+	Failures []*MigrationFailure `json:"failures,omitempty"`
+	// LastUserID is the checkpoint to resume the migration
+	LastUserID string `json:"lastUserId"`
+}
+
+func (e *MigrationProgressedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *MigrationProgressedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MigrationProgressedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewMigrationProgressedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	migrated, valid uint64,
+	failures []*MigrationFailure,
+	lastUserID string,
+) *MigrationProgressedEvent {
+	return &MigrationProgressedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MigrationProgressedType,
+		),
+		Migrated:   migrated,
+		Valid:      valid,
+		Failures:   failures,
+		LastUserID: lastUserID,
+	}
+}
+
+type MigrationDoneEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *MigrationDoneEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *MigrationDoneEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MigrationDoneEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewMigrationDoneEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MigrationDoneEvent {
+	return &MigrationDoneEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MigrationDoneType,
+		),
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/muhlemmer/gu"
 
 	"github.com/zitadel/zitadel/internal/domain"
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
@@ -82,8 +83,10 @@ type UpdatedEvent struct {
 	Schema                 json.RawMessage            `json:"schema,omitempty"`
 	PossibleAuthenticators []domain.AuthenticatorType `json:"possibleAuthenticators,omitempty"`
 	SchemaRevision         *uint64                    `json:"schemaRevision,omitempty"`
-	oldSchemaType          string
-	oldRevision            uint64
+	// Migration transforms the data of the users from the previous revision to the new revision
+	Migration     *domain_schema.Migration `json:"migration,omitempty"`
+	oldSchemaType string
+	oldRevision   uint64
 }
 
 func (e *UpdatedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
@@ -150,6 +153,12 @@ func IncreaseRevision(oldRevision uint64) func(event *UpdatedEvent) {
 	}
 }
 
+func SetMigration(migration *domain_schema.Migration) func(event *UpdatedEvent) {
+	return func(e *UpdatedEvent) {
+		e.Migration = migration
+	}
+}
+
 type DeactivatedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 }
@@ -0,0 +1,136 @@
+package schemauser
+
+import (
+	"encoding/json"
+	"strings"
+
+	domain_schema "github.com/zitadel/zitadel/internal/domain/schema"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueDataFieldType = "schemauser_data_field"
+	// DataSearchType is the object type of the indexed fields of the user data.
+	// The field name is the JSON pointer of the field.
+	DataSearchType = "schemauser_data"
+	// DataSearchSchemaIDField is the field containing the schema of the indexed user data.
+	// It can't collide with the fields of the data, as JSON pointers always start with a slash.
+	DataSearchSchemaIDField = "schemaID"
+)
+
+// IndexedValues are the values of the indexed fields of the data of a user based on a schema.
+type IndexedValues struct {
+	SchemaID string
+	Values   []*domain_schema.IndexedValue
+}
+
+func dataSearchObject(id string) eventstore.Object {
+	return eventstore.Object{
+		Type:     DataSearchType,
+		Revision: 1,
+		ID:       id,
+	}
+}
+
+// uniqueDataFieldKey returns the key of the value within the schema and the scope of the field.
+func uniqueDataFieldKey(schemaID, resourceOwner string, value *domain_schema.IndexedValue) string {
+	scope := ""
+	if value.Field.Unique == domain_schema.UniqueScopeOrganization {
+		scope = resourceOwner
+	}
+	// the json representation distinguishes strings from other primitives
+	v, _ := json.Marshal(value.Value)
+	return strings.Join([]string{schemaID, scope, value.Field.Path, string(v)}, ":")
+}
+
+func NewAddUniqueDataFieldConstraint(schemaID, resourceOwner string, value *domain_schema.IndexedValue) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueDataFieldType,
+		uniqueDataFieldKey(schemaID, resourceOwner, value),
+		"Errors.UserSchema.Data.NotUnique",
+	)
+}
+
+func NewRemoveUniqueDataFieldConstraint(schemaID, resourceOwner string, value *domain_schema.IndexedValue) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueDataFieldType,
+		uniqueDataFieldKey(schemaID, resourceOwner, value),
+	)
+}
+
+// indexUniqueConstraints returns the constraints to remove the previous and add the new unique values.
+// Values which did not change are ignored.
+func indexUniqueConstraints(resourceOwner string, previous, values *IndexedValues) []*eventstore.UniqueConstraint {
+	previousKeys := uniqueDataFieldKeys(resourceOwner, previous)
+	keys := uniqueDataFieldKeys(resourceOwner, values)
+	constraints := make([]*eventstore.UniqueConstraint, 0, len(previousKeys)+len(keys))
+	if previous != nil {
+		for _, value := range previous.Values {
+			if value.Field.Unique == domain_schema.UniqueScopeNone {
+				continue
+			}
+			if _, ok := keys[uniqueDataFieldKey(previous.SchemaID, resourceOwner, value)]; ok {
+				continue
+			}
+			constraints = append(constraints, NewRemoveUniqueDataFieldConstraint(previous.SchemaID, resourceOwner, value))
+		}
+	}
+	if values != nil {
+		for _, value := range values.Values {
+			if value.Field.Unique == domain_schema.UniqueScopeNone {
+				continue
+			}
+			if _, ok := previousKeys[uniqueDataFieldKey(values.SchemaID, resourceOwner, value)]; ok {
+				continue
+			}
+			constraints = append(constraints, NewAddUniqueDataFieldConstraint(values.SchemaID, resourceOwner, value))
+		}
+	}
+	return constraints
+}
+
+func uniqueDataFieldKeys(resourceOwner string, values *IndexedValues) map[string]struct{} {
+	keys := make(map[string]struct{})
+	if values == nil {
+		return keys
+	}
+	for _, value := range values.Values {
+		if value.Field.Unique == domain_schema.UniqueScopeNone {
+			continue
+		}
+		keys[uniqueDataFieldKey(values.SchemaID, resourceOwner, value)] = struct{}{}
+	}
+	return keys
+}
+
+// indexFields replaces the search fields of the user data with the values.
+func indexFields(aggregate *eventstore.Aggregate, values *IndexedValues) []*eventstore.FieldOperation {
+	object := dataSearchObject(aggregate.ID)
+	operations := []*eventstore.FieldOperation{
+		eventstore.RemoveSearchFieldsByAggregateAndObject(aggregate, object),
+	}
+	if values == nil || len(values.Values) == 0 {
+		return operations
+	}
+	operations = append(operations, eventstore.SetField(
+		aggregate,
+		object,
+		DataSearchSchemaIDField,
+		&eventstore.Value{
+			Value:       values.SchemaID,
+			ShouldIndex: true,
+		},
+	))
+	for _, value := range values.Values {
+		operations = append(operations, eventstore.SetField(
+			aggregate,
+			object,
+			value.Field.Path,
+			&eventstore.Value{
+				Value:       value.Value,
+				ShouldIndex: true,
+			},
+		))
+	}
+	return operations
+}
@@ -24,6 +24,8 @@ type CreatedEvent struct {
 	SchemaID              string          `json:"schemaID"`
 	SchemaRevision        uint64          `json:"schemaRevision"`
 	Data                  json.RawMessage `json:"user,omitempty"`
+
+	indexedValues *IndexedValues
 }
 
 func (e *CreatedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
@@ -35,7 +37,24 @@ func (e *CreatedEvent) Payload() interface{} {
 }
 
 func (e *CreatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
-	return nil
+	if e.indexedValues == nil {
+		return nil
+	}
+	return indexUniqueConstraints(e.Aggregate().ResourceOwner, nil, e.indexedValues)
+}
+
+func (e *CreatedEvent) Fields() []*eventstore.FieldOperation {
+	if e.indexedValues == nil {
+		return nil
+	}
+	return indexFields(e.Aggregate(), e.indexedValues)
+}
+
+// WithIndexedValues sets the values of the indexed fields of the data,
+// which are stored as unique constraints and search fields.
+func (e *CreatedEvent) WithIndexedValues(values *IndexedValues) *CreatedEvent {
+	e.indexedValues = values
+	return e
 }
 
 func NewCreatedEvent(
@@ -64,6 +83,10 @@ type UpdatedEvent struct {
 	SchemaID       *string         `json:"schemaID,omitempty"`
 	SchemaRevision *uint64         `json:"schemaRevision,omitempty"`
 	Data           json.RawMessage `json:"schema,omitempty"`
+
+	indexChanged          bool
+	previousIndexedValues *IndexedValues
+	indexedValues         *IndexedValues
 }
 
 func (e *UpdatedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
@@ -75,8 +98,19 @@ func (e *UpdatedEvent) Payload() interface{} {
 }
 
 func (e *UpdatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
-	return nil
+	if !e.indexChanged {
+		return nil
+	}
+	return indexUniqueConstraints(e.Aggregate().ResourceOwner, e.previousIndexedValues, e.indexedValues)
+}
+
+func (e *UpdatedEvent) Fields() []*eventstore.FieldOperation {
+	if !e.indexChanged {
+		return nil
+	}
+	return indexFields(e.Aggregate(), e.indexedValues)
 }
+
 func NewUpdatedEvent(
 	ctx context.Context,
 	aggregate *eventstore.Aggregate,
@@ -114,8 +148,19 @@ func ChangeData(data json.RawMessage) func(event *UpdatedEvent) {
 	}
 }
 
+// ChangeIndexedValues replaces the unique constraints and search fields of the previous indexed values of the data.
+func ChangeIndexedValues(previous, values *IndexedValues) func(event *UpdatedEvent) {
+	return func(e *UpdatedEvent) {
+		e.indexChanged = true
+		e.previousIndexedValues = previous
+		e.indexedValues = values
+	}
+}
+
 type DeletedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
+
+	indexedValues *IndexedValues
 }
 
 func (e *DeletedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
@@ -127,7 +172,21 @@ func (e *DeletedEvent) Payload() interface{} {
 }
 
 func (e *DeletedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
-	return nil
+	if e.indexedValues == nil {
+		return nil
+	}
+	return indexUniqueConstraints(e.Aggregate().ResourceOwner, e.indexedValues, nil)
+}
+
+func (e *DeletedEvent) Fields() []*eventstore.FieldOperation {
+	return indexFields(e.Aggregate(), nil)
+}
+
+// WithIndexedValues sets the values of the indexed fields of the data,
+// whose unique constraints are removed.
+func (e *DeletedEvent) WithIndexedValues(values *IndexedValues) *DeletedEvent {
+	e.indexedValues = values
+	return e
 }
 
 func NewDeletedEvent(
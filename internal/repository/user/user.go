@@ -165,6 +165,7 @@ type UserRemovedEvent struct {
 
 	userName          string
 	externalIDPs      []*domain.UserIDPLink
+	importExternalID  string
 	loginMustBeDomain bool
 }
 
@@ -180,6 +181,9 @@ func (e *UserRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
 	for _, idp := range e.externalIDPs {
 		events = append(events, NewRemoveUserIDPLinkUniqueConstraint(idp.IDPConfigID, idp.ExternalUserID))
 	}
+	if e.importExternalID != "" {
+		events = append(events, NewRemoveUserImportUniqueConstraint(e.Aggregate().ResourceOwner, e.importExternalID))
+	}
 	return events
 }
 
@@ -188,6 +192,7 @@ func NewUserRemovedEvent(
 	aggregate *eventstore.Aggregate,
 	userName string,
 	externalIDPs []*domain.UserIDPLink,
+	importExternalID string,
 	userLoginMustBeDomain bool,
 ) *UserRemovedEvent {
 	return &UserRemovedEvent{
@@ -198,6 +203,7 @@ func NewUserRemovedEvent(
 		),
 		userName:          userName,
 		externalIDPs:      externalIDPs,
+		importExternalID:  importExternalID,
 		loginMustBeDomain: userLoginMustBeDomain,
 	}
 }
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueUserImportType = "user_import_external_ids"
+	UserImportedType     = userEventTypePrefix + "imported"
+)
+
+func NewAddUserImportUniqueConstraint(resourceOwner, externalID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueUserImportType,
+		resourceOwner+externalID,
+		"Errors.User.Import.AlreadyImported")
+}
+
+func NewRemoveUserImportUniqueConstraint(resourceOwner, externalID string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueUserImportType,
+		resourceOwner+externalID)
+}
+
+// UserImportedEvent records the ID of the user in the system it was imported from,
+// so repeated (or resumed) imports are able to skip already imported users.
+type UserImportedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ExternalID string `json:"externalId"`
+}
+
+func (e *UserImportedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserImportedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddUserImportUniqueConstraint(e.Aggregate().ResourceOwner, e.ExternalID)}
+}
+
+func (e *UserImportedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserImportedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	externalID string,
+) *UserImportedEvent {
+	return &UserImportedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImportedType,
+		),
+		ExternalID: externalID,
+	}
+}
@@ -12,4 +12,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantCascadeRemovedType, UserGrantCascadeRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantDeactivatedType, UserGrantDeactivatedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantReactivatedType, UserGrantReactivatedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantValidityChangedType, eventstore.GenericEventMapper[UserGrantValidityChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantExpiryWarnedType, eventstore.GenericEventMapper[UserGrantExpiryWarnedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserGrantExpiredType, eventstore.GenericEventMapper[UserGrantExpiredEvent])
 }
@@ -0,0 +1,122 @@
+package usergrant
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UserGrantValidityChangedType = userGrantEventTypePrefix + "validity.changed"
+	UserGrantExpiryWarnedType    = userGrantEventTypePrefix + "expiry.warned"
+	UserGrantExpiredType         = userGrantEventTypePrefix + "expired"
+)
+
+// UserGrantValidityChangedEvent sets the time window of the user grant.
+// Zero timestamps leave the window open on that side.
+type UserGrantValidityChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ValidFrom  time.Time `json:"validFrom,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *UserGrantValidityChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *UserGrantValidityChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserGrantValidityChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserGrantValidityChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	validFrom,
+	validUntil time.Time,
+) *UserGrantValidityChangedEvent {
+	return &UserGrantValidityChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserGrantValidityChangedType,
+		),
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
+	}
+}
+
+// UserGrantExpiryWarnedEvent notifies the owners of the organization that the user grant will expire.
+type UserGrantExpiryWarnedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID     string    `json:"userId,omitempty"`
+	ProjectID  string    `json:"projectId,omitempty"`
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *UserGrantExpiryWarnedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *UserGrantExpiryWarnedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserGrantExpiryWarnedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserGrantExpiryWarnedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	projectID string,
+	validUntil time.Time,
+) *UserGrantExpiryWarnedEvent {
+	return &UserGrantExpiryWarnedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserGrantExpiryWarnedType,
+		),
+		UserID:     userID,
+		ProjectID:  projectID,
+		ValidUntil: validUntil,
+	}
+}
+
+// UserGrantExpiredEvent deactivates the user grant because its validity ended.
+type UserGrantExpiredEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ValidUntil time.Time `json:"validUntil,omitempty"`
+}
+
+func (e *UserGrantExpiredEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *UserGrantExpiredEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserGrantExpiredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserGrantExpiredEvent(ctx context.Context, aggregate *eventstore.Aggregate, validUntil time.Time) *UserGrantExpiredEvent {
+	return &UserGrantExpiredEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserGrantExpiredType,
+		),
+		ValidUntil: validUntil,
+	}
+}
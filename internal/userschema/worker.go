@@ -0,0 +1,156 @@
+package userschema
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WorkerUserID is set as editor of the events pushed by the worker.
+const WorkerUserID = "USER_SCHEMA_MIGRATION"
+
+const (
+	locksTable = "projections.locks"
+	lockName   = "user_schema_migration_worker"
+)
+
+type Config struct {
+	// Enabled starts the worker, which revalidates and migrates the users
+	// of user schemas with a running migration.
+	Enabled bool
+	// RequeueEvery is the time interval between the runs of the worker.
+	RequeueEvery time.Duration
+	// BatchSize is the amount of users migrated before the progress is reported.
+	BatchSize int
+}
+
+type Queries interface {
+	ActiveInstances() []string
+	RunningUserSchemaMigrations(ctx context.Context) ([]*query.UserSchemaMigration, error)
+	SchemaUserIDs(ctx context.Context, schemaID, afterUserID string, limit int) ([]string, error)
+}
+
+type Commands interface {
+	MigrateSchemaUsers(ctx context.Context, schemaID, resourceOwner string, userIDs []string) (*domain.ObjectDetails, error)
+	FinishUserSchemaMigration(ctx context.Context, schemaID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+type Worker struct {
+	config   Config
+	commands Commands
+	queries  Queries
+	locker   crdb.Locker
+}
+
+func NewWorker(config Config, commands Commands, queries Queries, locker crdb.Locker) *Worker {
+	return &Worker{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+		locker:   locker,
+	}
+}
+
+// NewLocker returns the lock, which ensures that the migrations of an instance are only run by a single worker
+// in case of multiple replicas.
+func NewLocker(client *database.DB) crdb.Locker {
+	return crdb.NewLocker(client.DB, locksTable, lockName)
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	if !w.config.Enabled {
+		return
+	}
+	go w.schedule(ctx)
+}
+
+func (w *Worker) schedule(ctx context.Context) {
+	t := time.NewTimer(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			logging.Info("user schema migration worker stopped")
+			return
+		case <-t.C:
+			w.triggerInstances(call.WithTimestamp(ctx), w.queries.ActiveInstances())
+			t.Reset(w.config.RequeueEvery)
+		}
+	}
+}
+
+func (w *Worker) triggerInstances(ctx context.Context, instances []string) {
+	for _, instance := range instances {
+		instanceCtx := authz.WithInstanceID(ctx, instance)
+
+		err := w.lockAndTrigger(instanceCtx)
+		logging.WithFields("instance", instance).OnError(err).Info("user schema migration worker trigger failed")
+	}
+}
+
+// lockAndTrigger only runs the migrations of the instance if it's not locked by the worker of another replica.
+// The lock is held (and renewed while migrating) for the interval of the runs.
+func (w *Worker) lockAndTrigger(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := w.locker.Lock(ctx, w.config.RequeueEvery, authz.GetInstance(ctx).InstanceID())
+	err, ok := <-errs
+	if err != nil || !ok {
+		if zerrors.IsErrorAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	go func() {
+		for err := range errs {
+			logging.OnError(err).Warn("unable to renew user schema migration worker lock")
+		}
+	}()
+	return w.trigger(ctx)
+}
+
+func (w *Worker) trigger(ctx context.Context) error {
+	migrations, err := w.queries.RunningUserSchemaMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, migration := range migrations {
+		err = w.migrate(authz.SetCtxData(ctx, authz.CtxData{UserID: WorkerUserID, OrgID: migration.ResourceOwner}), migration)
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "schema", migration.SchemaID).OnError(err).Info("unable to migrate users of schema")
+	}
+	return nil
+}
+
+// migrate processes the users of the schema in batches, starting after the last processed user.
+// Every batch reports its progress, so an interrupted migration resumes at the last batch.
+func (w *Worker) migrate(ctx context.Context, migration *query.UserSchemaMigration) error {
+	lastUserID := migration.LastUserID
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		userIDs, err := w.queries.SchemaUserIDs(ctx, migration.SchemaID, lastUserID, w.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			_, err = w.commands.FinishUserSchemaMigration(ctx, migration.SchemaID, migration.ResourceOwner)
+			return err
+		}
+		if _, err = w.commands.MigrateSchemaUsers(ctx, migration.SchemaID, migration.ResourceOwner, userIDs); err != nil {
+			return err
+		}
+		lastUserID = userIDs[len(userIDs)-1]
+	}
+}
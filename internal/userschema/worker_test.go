@@ -0,0 +1,148 @@
+package userschema
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type queriesMock struct {
+	migrations []*query.UserSchemaMigration
+	userIDs    []string
+}
+
+func (q *queriesMock) ActiveInstances() []string {
+	return []string{"instance"}
+}
+
+func (q *queriesMock) RunningUserSchemaMigrations(context.Context) ([]*query.UserSchemaMigration, error) {
+	return q.migrations, nil
+}
+
+func (q *queriesMock) SchemaUserIDs(_ context.Context, _, afterUserID string, limit int) ([]string, error) {
+	ids := make([]string, 0, limit)
+	for _, id := range q.userIDs {
+		if id > afterUserID && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type commandsMock struct {
+	batches  [][]string
+	finished bool
+}
+
+func (c *commandsMock) MigrateSchemaUsers(_ context.Context, _, _ string, userIDs []string) (*domain.ObjectDetails, error) {
+	c.batches = append(c.batches, slices.Clone(userIDs))
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *commandsMock) FinishUserSchemaMigration(context.Context, string, string) (*domain.ObjectDetails, error) {
+	c.finished = true
+	return &domain.ObjectDetails{}, nil
+}
+
+func TestWorker_migrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		userIDs     []string
+		lastUserID  string
+		wantBatches [][]string
+	}{
+		{
+			name: "no users",
+		},
+		{
+			name:        "batches",
+			userIDs:     []string{"user1", "user2", "user3"},
+			wantBatches: [][]string{{"user1", "user2"}, {"user3"}},
+		},
+		{
+			name:        "resume after last user",
+			userIDs:     []string{"user1", "user2", "user3"},
+			lastUserID:  "user2",
+			wantBatches: [][]string{{"user3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(commandsMock)
+			w := NewWorker(Config{BatchSize: 2}, commands, &queriesMock{userIDs: tt.userIDs}, nil)
+			err := w.migrate(context.Background(), &query.UserSchemaMigration{
+				SchemaID:   "schema",
+				LastUserID: tt.lastUserID,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBatches, commands.batches)
+			assert.True(t, commands.finished)
+		})
+	}
+}
+
+type lockerMock struct {
+	err error
+}
+
+func (l *lockerMock) Lock(ctx context.Context, _ time.Duration, _ ...string) <-chan error {
+	errs := make(chan error, 1)
+	errs <- l.err
+	go func() {
+		<-ctx.Done()
+		close(errs)
+	}()
+	return errs
+}
+
+func (l *lockerMock) Unlock(...string) error {
+	return nil
+}
+
+func TestWorker_lockAndTrigger(t *testing.T) {
+	tests := []struct {
+		name         string
+		lockErr      error
+		wantErr      bool
+		wantFinished bool
+	}{
+		{
+			name:         "lock acquired, migrated",
+			wantFinished: true,
+		},
+		{
+			name:    "locked by other worker, skipped",
+			lockErr: zerrors.ThrowAlreadyExists(nil, "CRDB-mmi4J", "projection already locked"),
+		},
+		{
+			name:    "lock failed, error",
+			lockErr: zerrors.ThrowInternal(nil, "CRDB-uaDoR", "unable to execute lock"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(commandsMock)
+			queries := &queriesMock{
+				migrations: []*query.UserSchemaMigration{{SchemaID: "schema", ResourceOwner: "instance"}},
+			}
+			w := NewWorker(Config{BatchSize: 2, RequeueEvery: time.Minute}, commands, queries, &lockerMock{err: tt.lockErr})
+			err := w.lockAndTrigger(authz.WithInstanceID(context.Background(), "instance"))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFinished, commands.finished)
+		})
+	}
+}